@@ -1,6 +1,7 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -11,6 +12,8 @@ import (
 	"reflect"
 	"runtime"
 	"runtime/pprof"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -27,17 +30,28 @@ import (
 	"github.com/dharmab/skyeye/internal/application"
 	"github.com/dharmab/skyeye/internal/cli"
 	"github.com/dharmab/skyeye/internal/conf"
+	"github.com/dharmab/skyeye/pkg/areas"
 	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/composer"
+	"github.com/dharmab/skyeye/pkg/encyclopedia"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+	srs "github.com/dharmab/skyeye/pkg/simpleradio/types"
 	"github.com/dharmab/skyeye/pkg/synthesizer/voices"
+	"github.com/dharmab/skyeye/pkg/tankers"
+	"github.com/dharmab/skyeye/pkg/terrain"
+	"github.com/dharmab/skyeye/pkg/weather"
 	"github.com/ggerganov/whisper.cpp/bindings/go/pkg/whisper"
 )
 
 // Used for CLI configuration values.
 var (
 	configFile                   string
+	doctrineFile                 string
+	offline                      bool
 	logLevel                     string
 	logFormat                    string
 	enableTranscriptionLogging   bool
+	frequencyLog                 string
 	acmiFile                     string
 	telemetryAddress             string
 	telemetryConnectionTimeout   time.Duration
@@ -45,26 +59,107 @@ var (
 	srsAddress                   string
 	srsConnectionTimeout         time.Duration
 	srsExternalAWACSModePassword string
+	srsGUID                      string
+	srsGUIDFile                  string
 	srsFrequencies               []string
+	srsFrequencyPersonas         []string
+	srsRelays                    []string
+	srsRelayToneHz               float64
+	srsFrequencyChanges          []string
+	srsFrequencyChangeWarning    time.Duration
+	srsTransmitHoldTime          time.Duration
+	srsMaxTransmissionDuration   time.Duration
+	srsLongFrameLength           time.Duration
+	srsLazyDecode                bool
 	gciCallsign                  string
 	gciCallsigns                 []string
 	coalitionName                string
 	telemetryUpdateInterval      time.Duration
+	fadeTimeout                  time.Duration
+	fadedWindow                  time.Duration
+	fadedRadiusNM                float64
+	trackfileRetention           time.Duration
+	simulatedSweepInterval       time.Duration
 	whisperModelPath             string
+	keywordSpottingModelPath     string
+	fallbackWhisperModelPath     string
+	ensembleWhisperModelPath     string
+	ensembleThreshold            float64
+	ensembleRequests             []string
+	recognizerMaxConcurrency     int
+	recognizerMaxQueue           int
+	recognizerFallbackQueueDepth int
+	confidenceThreshold          float64
+	confidenceThresholds         []string
 	voiceName                    string
 	mute                         bool
 	playbackSpeed                string
 	playbackPause                time.Duration
+	altitudeFormat               string
+	platformPronunciations       []string
+	dialect                      string
+	composerTemplates            string
+	maxResponseDuration          time.Duration
+	timestampBroadcasts          bool
+	scopeBroadcasts              bool
+	encyclopediaDataset          string
+	aircraftOverrides            []string
+	terrainElevation             string
+	winds                        []string
 	enableAutomaticPicture       bool
 	automaticPictureInterval     time.Duration
+	quietPictureWhenClean        bool
+	pictureMaxGroups             int
+	enableFullPicture            bool
 	enableThreatMonitoring       bool
 	threatMonitoringInterval     time.Duration
 	threatMonitoringRequiresSRS  bool
 	mandatoryThreatRadiusNM      float64
+	groupingRadii                []string
+	excludeNonCombatants         bool
+	packageThreats               bool
+	coldThreats                  string
+	mezWarningLookahead          time.Duration
+	commitRangeNM                float64
+	commitUpdateInterval         time.Duration
+	mergeCooldown                time.Duration
+	hvaaCallsigns                []string
+	tankerInfo                   []string
+	hvaaProtectionRangeNM        float64
+	enableTraining               bool
+	groundClutterFilter          int
+	namedAreas                   string
+	requestRateLimit             time.Duration
+	requireCheckIn               []string
+	blockedCallsignWords         []string
+	adminCallsigns               []string
+	adminSRSGUIDs                []string
+	apiAddress                   string
+	apiToken                     string
+	apiAuditLog                  string
+	discordWebhookURL            string
+	bridgeURL                    string
+	bridgeTopicPrefix            string
+	datalinkAddress              string
+	datalinkToken                string
+	datalinkInterval             time.Duration
+	datalinkKeyframeInterval     time.Duration
+	debriefDirectory             string
+	debriefInterval              time.Duration
+	historyDirectory             string
+	historyMaxFileSizeMB         int
+	historyMaxTotalSizeMB        int
+
+	// doctrine is the doctrine profile applied from the doctrine file, and doctrineSettings are the settings it
+	// changed. These are logged once logging is set up.
+	doctrine         *cli.Doctrine
+	doctrineSettings []string
 )
 
 func init() {
 	skyeye.Flags().StringVar(&configFile, "config-file", "/etc/skyeye/config.yaml", "Path to config file")
+	skyeye.Flags().StringVar(&doctrineFile, "doctrine-file", "", "Path to a YAML or JSON file of doctrine profiles for each coalition, setting the threat radius, commit range, PICTURE cadence and phraseology")
+	skyeye.Flags().BoolVar(&offline, "offline", false, "Guarantee no network connections other than to the SRS and telemetry servers")
 
 	// Logging
 	logLevelFlag := cli.NewEnum(&logLevel, "Level", "info", "error", "warn", "info", "debug", "trace")
@@ -72,6 +167,7 @@ func init() {
 	logFormats := cli.NewEnum(&logFormat, "Format", "pretty", "json")
 	skyeye.Flags().Var(logFormats, "log-format", "Log format (pretty, json)")
 	skyeye.Flags().BoolVar(&enableTranscriptionLogging, "enable-transcription-logging", true, "Include transcriptions of SRS transmissions in logs")
+	skyeye.Flags().StringVar(&frequencyLog, "frequency-log", "", "Path to a file to append a timestamped transcript of every transmission on the GCI's frequencies, including traffic not addressed to the GCI. Disabled if empty")
 
 	// Telemetry
 	skyeye.Flags().StringVar(&acmiFile, "acmi-file", "", "path to ACMI file")
@@ -80,12 +176,28 @@ func init() {
 	skyeye.Flags().DurationVar(&telemetryConnectionTimeout, "telemetry-connection-timeout", 10*time.Second, "Connection timeout for real-time telemetry client")
 	skyeye.Flags().StringVar(&telemetryPassword, "telemetry-password", "", "Password for the real-time telemetry service")
 	skyeye.Flags().DurationVar(&telemetryUpdateInterval, "telemetry-update-interval", 2*time.Second, "Interval at which trackfiles are updated from telemetry data")
+	skyeye.Flags().DurationVar(&fadeTimeout, "fade-timeout", 1*time.Minute, "How long a trackfile may go without telemetry updates before it is considered faded")
+	skyeye.Flags().DurationVar(&fadedWindow, "faded-window", 15*time.Second, "How long to wait after a contact fades for further contacts to fade, so that contacts which fade together are reported in a single FADED call")
+	skyeye.Flags().Float64Var(&fadedRadiusNM, "faded-radius", 5, "Distance within which contacts which fade together are reported as a single group, in nautical miles. Each faded contact is reported separately if zero")
+	skyeye.Flags().DurationVar(&trackfileRetention, "trackfile-retention", 5*time.Minute, "How long a trackfile may go without telemetry updates before it is removed")
+	skyeye.Flags().DurationVar(&simulatedSweepInterval, "simulated-sweep-interval", 0, "Rotation period of a simulated search radar (e.g. 10s). Contacts are only updated once per sweep, and new contacts appear after a full sweep. Disabled if zero")
 
 	// SRS
 	skyeye.Flags().StringVar(&srsAddress, "srs-server-address", "localhost:5002", "Address of the SRS server")
 	skyeye.Flags().DurationVar(&srsConnectionTimeout, "srs-connection-timeout", 10*time.Second, "Connection timeout for SRS client")
 	skyeye.Flags().StringVar(&srsExternalAWACSModePassword, "srs-eam-password", "", "SRS external AWACS mode password")
+	skyeye.Flags().StringVar(&srsGUID, "srs-guid", "", "22 character GUID to identify the bot to the SRS server. If empty, srs-guid-file is used")
+	skyeye.Flags().StringVar(&srsGUIDFile, "srs-guid-file", "", "Path to a file in which to persist the bot's SRS GUID across restarts. The file is created if it does not exist. If both this and srs-guid are empty, a new GUID is generated on each start")
 	skyeye.Flags().StringSliceVar(&srsFrequencies, "srs-frequencies", []string{"251.0AM", "133.0AM", "30.0FM"}, "List of SRS frequencies to use")
+	skyeye.Flags().StringSliceVar(&srsFrequencyPersonas, "srs-frequency-personas", []string{}, "List of FREQUENCY:LANGUAGE[:VOICE[:CALLSIGN]] overrides (e.g. 133.0AM:ru:masculine or 251.0AM:en::Overlord) for the language spoken, voice used and GCI callsign on some SRS frequencies")
+	skyeye.Flags().StringSliceVar(&srsRelays, "srs-relays", []string{}, "List of FREQUENCY:FREQUENCY pairs (e.g. 251.0AM:133.0AM) between which received audio is retransmitted in both directions. Both frequencies must be in srs-frequencies")
+	skyeye.Flags().DurationVar(&srsTransmitHoldTime, "srs-transmit-hold-time", 10*time.Second, "Maximum time to delay a transmission while another station is transmitting on the same frequency. Set to 0 to transmit immediately")
+	skyeye.Flags().DurationVar(&srsMaxTransmissionDuration, "srs-max-transmission-duration", 60*time.Second, "Ignore audio from SRS clients which transmit continuously for longer than this, such as a stuck push-to-talk key. Set to 0 to disable")
+	skyeye.Flags().DurationVar(&srsLongFrameLength, "srs-long-frame-length", 40*time.Millisecond, "Opus frame length for transmissions longer than 5 seconds. One of 40ms, 60ms, 80ms, 100ms or 120ms. Longer frames send fewer packets")
+	skyeye.Flags().BoolVar(&srsLazyDecode, "srs-lazy-decode", false, "Defer decoding received audio until speech recognition needs it, instead of decoding every transmission as it ends. Reduces CPU usage on busy servers")
+	skyeye.Flags().Float64Var(&srsRelayToneHz, "srs-relay-tone", 1000, "Frequency in Hz of the tone played before relayed audio. Set to 0 to disable the tone")
+	skyeye.Flags().StringSliceVar(&srsFrequencyChanges, "srs-frequency-changes", []string{}, "List of HHMM:FREQUENCY:FREQUENCY scheduled frequency changes (e.g. 1430:251.0AM:264.0AM). At the given mission time, the GCI moves from the first frequency to the second")
+	skyeye.Flags().DurationVar(&srsFrequencyChangeWarning, "srs-frequency-change-warning", 2*time.Minute, "How long before a scheduled frequency change the GCI announces it on the old frequency")
 
 	// Identity
 	skyeye.Flags().StringVar(&gciCallsign, "callsign", "", "GCI callsign used in radio transmissions. Automatically chosen if not provided")
@@ -97,20 +209,91 @@ func init() {
 	// AI models
 	skyeye.Flags().StringVar(&whisperModelPath, "whisper-model", "", "Path to whisper.cpp model")
 	_ = skyeye.MarkFlagRequired("whisper-model")
+	skyeye.Flags().StringVar(&keywordSpottingModelPath, "keyword-spotting-model", "", "Path to a small whisper.cpp model used to discard transmissions not addressed to the GCI before full speech recognition. Disabled if not provided")
+	skyeye.Flags().StringVar(&fallbackWhisperModelPath, "whisper-fallback-model", "", "Path to a smaller whisper.cpp model used when speech recognition is running behind. Disabled if not provided")
+	skyeye.Flags().StringVar(&ensembleWhisperModelPath, "whisper-ensemble-model", "", "Path to a second whisper.cpp model used to recognize low-confidence critical requests a second time. Disabled if not provided")
+	skyeye.Flags().Float64Var(&ensembleThreshold, "recognizer-ensemble-threshold", 0.8, "Speech recognition confidence (0-1) below which critical requests are recognized a second time with the ensemble model")
+	skyeye.Flags().StringSliceVar(&ensembleRequests, "recognizer-ensemble-requests", []string{"declare", "snaplock"}, "List of request types which are recognized a second time with the ensemble model when confidence is low")
+	skyeye.Flags().IntVar(&recognizerMaxConcurrency, "recognizer-max-concurrency", 1, "Maximum number of transmissions recognized at the same time")
+	skyeye.Flags().IntVar(&recognizerMaxQueue, "recognizer-max-queue", 8, "Maximum number of transmissions waiting for speech recognition. Further transmissions are discarded, and callers are told to standby")
+	skyeye.Flags().IntVar(&recognizerFallbackQueueDepth, "recognizer-fallback-queue-depth", 2, "Number of transmissions waiting for speech recognition at which the fallback model is used")
+	skyeye.Flags().Float64Var(&confidenceThreshold, "recognizer-confidence-threshold", 0, "Minimum speech recognition confidence (0-1) at which requests are handled without asking the caller to confirm. Disabled if zero")
+	skyeye.Flags().StringSliceVar(&confidenceThresholds, "recognizer-confidence-thresholds", []string{}, "List of REQUEST:THRESHOLD overrides (e.g. declare:0.8) for the confidence threshold of some request types")
 	voiceFlag := cli.NewEnum(&voiceName, "Voice", "", "feminine", "masculine")
 	skyeye.Flags().Var(voiceFlag, "voice", "Voice to use for SRS transmissions (feminine, masculine). Automatically chosen if not provided")
 	playbackSpeedFlag := cli.NewEnum(&playbackSpeed, "string", "standard", "veryslow", "slow", "fast", "veryfast")
 	skyeye.Flags().Var(playbackSpeedFlag, "voice-playback-speed", "How fast the GCI speaks")
 	skyeye.Flags().DurationVar(&playbackPause, "voice-playback-pause", 200*time.Millisecond, "How long the GCI pauses between sentences")
+	altitudeFormatFlag := cli.NewEnum(&altitudeFormat, "Format", string(composer.StandardAltitudeFormat), string(composer.StandardAltitudeFormat), string(composer.AngelsAltitudeFormat), string(composer.FeetAltitudeFormat))
+	skyeye.Flags().Var(altitudeFormatFlag, "altitude-format", "How the GCI describes altitudes (standard, angels, feet). Standard uses angels for friendly aircraft and feet for all other aircraft")
+	skyeye.Flags().StringSliceVar(&platformPronunciations, "platform-pronunciations", []string{}, "List of PLATFORM:PRONUNCIATION overrides (e.g. JF-17:thunder) for how aircraft type names are spoken")
+	dialectFlag := cli.NewEnum(&dialect, "Dialect", string(composer.StandardDialect), string(composer.StandardDialect), string(composer.RedforDialect))
+	skyeye.Flags().Var(dialectFlag, "dialect", "Phraseology the GCI uses (standard, redfor). Redfor uses metric units and Soviet-style terms, and only applies to the red coalition")
+	skyeye.Flags().StringVar(&composerTemplates, "composer-templates", "", "Path to a YAML file of phrasing variants for some response types")
+	skyeye.Flags().DurationVar(&maxResponseDuration, "max-response-duration", 0, "Longest a single response should take to speak. Longer responses omit optional spoken details or are split into several transmissions; subtitles keep every detail. Disabled if zero")
+	skyeye.Flags().BoolVar(&timestampBroadcasts, "timestamp-broadcasts", false, "Prefix broadcast calls such as PICTURE and THREAT with the mission time, for reviewing recorded comms against the mission timeline")
+	skyeye.Flags().BoolVar(&scopeBroadcasts, "scope-broadcasts", false, "Transmit broadcast calls addressed to particular flights, such as THREAT and MERGED, only on the frequencies those flights are listening on. Calls are not transmitted if none of the flights are on frequency")
+	skyeye.Flags().StringVar(&encyclopediaDataset, "encyclopedia-dataset", "", "Path to a YAML or JSON aircraft dataset which adds new aircraft and renamed ACMI names to the built-in aircraft data")
+	skyeye.Flags().StringSliceVar(&aircraftOverrides, "aircraft-overrides", []string{}, "List of ACMI_NAME:TAGS[:THREAT_RADIUS] overrides (e.g. L-39C:fixed-wing+unarmed) for how aircraft are classified. TAGS are separated by +, and the threat radius is in nautical miles")
 	skyeye.Flags().BoolVar(&mute, "mute", false, "Mute all SRS transmissions. Useful for testing without disrupting play")
 
 	// Controller behavior
 	skyeye.Flags().BoolVar(&enableAutomaticPicture, "auto-picture", true, "Enable automatic PICTURE broadcasts")
 	skyeye.Flags().DurationVar(&automaticPictureInterval, "auto-picture-interval", 2*time.Minute, "How often to broadcast PICTURE")
+	skyeye.Flags().BoolVar(&quietPictureWhenClean, "auto-picture-quiet-when-clean", false, "Stop automatic PICTURE broadcasts while the picture is clean")
+	skyeye.Flags().IntVar(&pictureMaxGroups, "picture-max-groups", 3, "Maximum number of groups described in detail in a PICTURE. Further groups are summarized")
+	skyeye.Flags().BoolVar(&enableFullPicture, "full-picture", true, "Allow players to request a PICTURE FULL which describes every group in detail, split into several transmissions if needed")
 	skyeye.Flags().BoolVar(&enableThreatMonitoring, "threat-monitoring", true, "Enable THREAT monitoring")
 	skyeye.Flags().DurationVar(&threatMonitoringInterval, "threat-monitoring-interval", 3*time.Minute, "How often to broadcast THREAT")
 	skyeye.Flags().Float64Var(&mandatoryThreatRadiusNM, "mandatory-threat-radius", 25, "Briefed radius for mandatory THREAT calls, in nautical miles")
+	skyeye.Flags().StringSliceVar(&groupingRadii, "grouping-radii", []string{"20:3", "60:8"}, "List of RANGE:RADIUS breakpoints, in nautical miles, for how far apart aircraft may be to be grouped together at a given range from the requester. The radius is interpolated between breakpoints")
+	skyeye.Flags().StringSliceVar(&winds, "winds", []string{}, "List of ALTITUDE:DIRECTION/SPEED wind layers (e.g. 0:270/10,26000:250/60) from the mission weather, with the altitude in feet, the true direction the wind blows from in degrees and the speed in knots. If provided, the speed of hostile groups is judged by their true airspeed")
+	skyeye.Flags().StringVar(&terrainElevation, "terrain-elevation", "", "Path to an ESRI ASCII grid of terrain elevation for the mission's map. If provided, low flying hostile groups are described by their height above ground level")
+	skyeye.Flags().BoolVar(&excludeNonCombatants, "exclude-non-combatants", true, "Leave non-combatant aircraft such as transports, tankers and trainers out of PICTURE and THREAT calls. They can still be identified with DECLARE")
+	skyeye.Flags().BoolVar(&packageThreats, "package-threats", false, "Address THREAT calls to every player in the threatened aircraft's package, not just the threatened aircraft")
+	coldThreatsFlag := cli.NewEnum(&coldThreats, "Mode", string(conf.ReportColdThreats), string(conf.ReportColdThreats), string(conf.DemoteColdThreats), string(conf.ExcludeColdThreats))
+	skyeye.Flags().Var(coldThreatsFlag, "cold-threats", "How THREAT calls handle hostile groups which are cold to the threatened aircraft (report, demote, exclude). Demote calls them last and half as often; exclude leaves them out. Cold groups are still described in PICTURE calls")
+	skyeye.Flags().DurationVar(&mezWarningLookahead, "mez-warning-lookahead", 2*time.Minute, "How far ahead to project friendly aircraft's tracks to warn pilots who are about to fly into the MEZ of a hostile SAM site. Disabled if zero")
+	skyeye.Flags().Float64Var(&commitRangeNM, "commit-range", 0, "Range from a fighter to the target group last described to it within which merges are evaluated more often, in nautical miles. Disabled if zero")
+	skyeye.Flags().DurationVar(&commitUpdateInterval, "commit-update-interval", 5*time.Second, "How often merges are evaluated while a fighter is within the commit range of its target")
+	skyeye.Flags().DurationVar(&mergeCooldown, "merge-cooldown", 30*time.Second, "How long a friendly must be clear of every hostile before its merge is over. MERGED is called once per merge, and CLEAN is called when it is over")
+	skyeye.Flags().StringSliceVar(&tankerInfo, "tankers", []string{}, "List of CALLSIGN:TACAN:FREQUENCY tankers (e.g. Texaco:51X:251.0,Arco::252.5), with the frequency in MHz. The TACAN channel and frequency are reported with vectors to the nearest tanker. Tanker aircraft are found automatically; list a callsign to also treat aircraft with that callsign as tankers")
+	skyeye.Flags().StringSliceVar(&hvaaCallsigns, "hvaa-callsigns", []string{}, "List of callsigns (e.g. Magic, Texaco) of friendly High Value Airborne Assets to protect, in addition to aircraft tagged HVAA through the API")
+	skyeye.Flags().Float64Var(&hvaaProtectionRangeNM, "hvaa-protection-range", 40, "Range from an HVAA within which hostile groups trigger protection alerts to the nearest friendly fighters, in nautical miles. Disabled if zero")
+	skyeye.Flags().BoolVar(&enableTraining, "training-mode", false, "Follow up BOGEY DOPE, SNAPLOCK and PICTURE calls with plain language commentary for new pilots. Players can turn commentary on or off for themselves, and the API can change the default at runtime")
+	skyeye.Flags().IntVar(&groundClutterFilter, "ground-clutter-filter", 2, "Minimum number of units in a ground group for it to be reported in response to TROOPS IN CONTACT. Filters out clutter such as lone trucks and soldiers. Ships and air defenses are always reported")
+	skyeye.Flags().StringVar(&namedAreas, "named-areas", "", "Path to a YAML or JSON file of named areas, such as map keypads and landmarks, which players may reference in DECLARE requests")
 	skyeye.Flags().BoolVar(&threatMonitoringRequiresSRS, "threat-monitoring-requires-srs", true, "Require aircraft to be on SRS to receive THREAT calls. Only useful to disable when debugging")
+
+	// Request policies
+	skyeye.Flags().DurationVar(&requestRateLimit, "request-rate-limit", 0, "Minimum interval between requests from the same callsign. Disabled if zero")
+	skyeye.Flags().StringSliceVar(&requireCheckIn, "require-check-in", []string{}, "List of request types (e.g. picture, bogeydope, declare) which are ignored until the caller checks in with a RADIO CHECK or ALPHA CHECK")
+	skyeye.Flags().StringSliceVar(&blockedCallsignWords, "blocked-callsign-words", []string{}, "List of words. Requests from callsigns containing any of these words are ignored")
+	skyeye.Flags().StringSliceVar(&adminCallsigns, "admin-callsigns", []string{}, "List of callsigns permitted to control the GCI by voice with ADMIN commands")
+	skyeye.Flags().StringSliceVar(&adminSRSGUIDs, "admin-srs-guids", []string{}, "List of SRS client GUIDs permitted to control the GCI by voice with ADMIN commands")
+
+	// API
+	skyeye.Flags().StringVar(&apiAddress, "api-address", "", "Address on which to serve the HTTP API (e.g. localhost:8080). Disabled if empty")
+	skyeye.Flags().StringVar(&apiToken, "api-token", "", "Bearer token which clients must present to use the HTTP API")
+	skyeye.Flags().StringVar(&apiAuditLog, "api-audit-log", "", "Path to an append-only file recording admin actions taken through the HTTP API. If empty, recent actions are only kept in memory")
+
+	// Mission statistics
+	skyeye.Flags().StringVar(&discordWebhookURL, "discord-webhook-url", "", "Discord webhook URL to which a statistics summary is posted at the end of each mission. Disabled if empty")
+	skyeye.Flags().StringVar(&bridgeURL, "bridge-url", "", "mqtt://, mqtts://, nats:// or tls:// (NATS over TLS) URL of a message bus to which events are published. Disabled if empty")
+	skyeye.Flags().StringVar(&bridgeTopicPrefix, "bridge-topic-prefix", "skyeye", "Prefix of the topics or subjects to which events are published on the message bus")
+
+	// Datalink
+	skyeye.Flags().StringVar(&datalinkAddress, "datalink-address", "", "Address on which to stream the radar picture as JSON over TCP (e.g. localhost:8081). Disabled if empty")
+	skyeye.Flags().StringVar(&datalinkToken, "datalink-token", "", "Token which datalink clients must send before they receive the radar picture. Required if the datalink is enabled")
+	skyeye.Flags().DurationVar(&datalinkInterval, "datalink-interval", 2*time.Second, "How often the radar picture is sent to datalink clients")
+	skyeye.Flags().DurationVar(&datalinkKeyframeInterval, "datalink-keyframe-interval", 30*time.Second, "How often datalink clients which subscribe to deltas are sent the full radar picture")
+
+	// Debrief
+	skyeye.Flags().StringVar(&debriefDirectory, "debrief-directory", "", "Directory in which to record the GCI's view of each mission as a Tacview ACMI file. Disabled if empty")
+	skyeye.Flags().DurationVar(&debriefInterval, "debrief-interval", 2*time.Second, "How often a frame is recorded to the debrief file")
+	skyeye.Flags().StringVar(&historyDirectory, "history-directory", "", "Directory in which to record every trackfile update as compressed JSON Lines files. Disabled if empty")
+	skyeye.Flags().IntVar(&historyMaxFileSizeMB, "history-max-file-size", 64, "Size in megabytes at which a trackfile history file is rotated. Disabled if zero")
+	skyeye.Flags().IntVar(&historyMaxTotalSizeMB, "history-max-total-size", 1024, "Total size in megabytes of trackfile history files to keep. The oldest files are deleted when this is exceeded. Disabled if zero")
 }
 
 // Top-level CLI command.
@@ -159,10 +342,43 @@ func initializeConfig(cmd *cobra.Command) error {
 	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	v.AutomaticEnv()
 
+	// The doctrine profile is applied before the config file and environment, so that it takes precedence over them.
+	// Flags given on the command line take precedence over the profile.
+	if err := applyDoctrine(cmd, v); err != nil {
+		return err
+	}
 	bindFlags(cmd, v)
 	return nil
 }
 
+// applyDoctrine applies the profile for the GCI's coalition from the doctrine file, if one is set.
+func applyDoctrine(cmd *cobra.Command, v *viper.Viper) error {
+	path := doctrineFile
+	if !cmd.Flags().Changed("doctrine-file") && v.IsSet("doctrine-file") {
+		path = v.GetString("doctrine-file")
+	}
+	if path == "" {
+		return nil
+	}
+	coalition := coalitionName
+	if !cmd.Flags().Changed("coalition") && v.IsSet("coalition") {
+		coalition = v.GetString("coalition")
+	}
+	profile, ok, err := cli.LoadDoctrine(path, coalition)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("doctrine file %s has no profile for the %s coalition", path, coalition)
+	}
+	doctrineSettings, err = cli.ApplyDoctrine(cmd.Flags(), profile)
+	if err != nil {
+		return err
+	}
+	doctrine = &profile
+	return nil
+}
+
 func bindFlags(cmd *cobra.Command, v *viper.Viper) {
 	cmd.Flags().VisitAll(func(f *pflag.Flag) {
 		// Apply the viper config value to the flag when the flag is not set and viper has a value
@@ -189,6 +405,201 @@ func loadCoalition() (coalition coalitions.Coalition) {
 	return
 }
 
+func loadConfidenceThreshold() float64 {
+	if confidenceThreshold < 0 || confidenceThreshold > 1 {
+		log.Fatal().Float64("threshold", confidenceThreshold).Msg("recognizer confidence threshold must be between 0 and 1")
+	}
+	return confidenceThreshold
+}
+
+func loadConfidenceThresholds() map[string]float64 {
+	thresholds := make(map[string]float64, len(confidenceThresholds))
+	for _, s := range confidenceThresholds {
+		logger := log.With().Str("threshold", s).Logger()
+		requestType, value, ok := strings.Cut(s, ":")
+		if !ok {
+			logger.Fatal().Msg("recognizer confidence threshold override must be in the format REQUEST:THRESHOLD")
+		}
+		threshold, err := strconv.ParseFloat(value, 64)
+		if err != nil || threshold < 0 || threshold > 1 {
+			logger.Fatal().Msg("recognizer confidence threshold must be between 0 and 1")
+		}
+		thresholds[strings.ToLower(requestType)] = threshold
+	}
+	return thresholds
+}
+
+func loadPlatformPronunciations() map[string]string {
+	pronunciations := make(map[string]string, len(platformPronunciations))
+	for _, s := range platformPronunciations {
+		platform, pronunciation, ok := strings.Cut(s, ":")
+		if !ok || strings.TrimSpace(platform) == "" || strings.TrimSpace(pronunciation) == "" {
+			log.Fatal().Str("pronunciation", s).Msg("platform pronunciation override must be in the format PLATFORM:PRONUNCIATION")
+		}
+		pronunciations[platform] = pronunciation
+	}
+	return pronunciations
+}
+
+func loadDialect(coalition coalitions.Coalition) composer.Dialect {
+	if composer.Dialect(dialect) == composer.RedforDialect && coalition != coalitions.Red {
+		log.Warn().Str("dialect", dialect).Msg("redfor dialect only applies to the red coalition; using standard dialect")
+		return composer.StandardDialect
+	}
+	return composer.Dialect(dialect)
+}
+
+func loadComposerTemplates() *composer.Templates {
+	if composerTemplates == "" {
+		return nil
+	}
+	templates, err := composer.LoadTemplates(composerTemplates)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", composerTemplates).Msg("failed to load composer templates")
+	}
+	log.Info().Str("path", composerTemplates).Int("responseTypes", len(templates.Variants)).Msg("loaded composer templates")
+	return templates
+}
+
+func loadGroupingRadii() []conf.GroupingRadius {
+	radii := make([]conf.GroupingRadius, 0, len(groupingRadii))
+	for _, s := range groupingRadii {
+		logger := log.With().Str("breakpoint", s).Logger()
+		rangeNM, radiusNM, ok := strings.Cut(s, ":")
+		if !ok {
+			logger.Fatal().Msg("grouping radius breakpoint must be in the format RANGE:RADIUS")
+		}
+		_range, err := strconv.ParseFloat(rangeNM, 64)
+		if err != nil || _range < 0 {
+			logger.Fatal().Msg("grouping radius breakpoint range must be a non-negative number of nautical miles")
+		}
+		radius, err := strconv.ParseFloat(radiusNM, 64)
+		if err != nil || radius <= 0 {
+			logger.Fatal().Msg("grouping radius must be a positive number of nautical miles")
+		}
+		radii = append(radii, conf.GroupingRadius{
+			Range:  unit.Length(_range) * unit.NauticalMile,
+			Radius: unit.Length(radius) * unit.NauticalMile,
+		})
+	}
+	slices.SortFunc(radii, func(a, b conf.GroupingRadius) int {
+		return cmp.Compare(a.Range, b.Range)
+	})
+	return radii
+}
+
+func loadTerrain() terrain.Model {
+	if terrainElevation == "" {
+		return nil
+	}
+	grid, err := terrain.LoadGrid(terrainElevation)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", terrainElevation).Msg("failed to load terrain elevation")
+	}
+	log.Info().Str("path", terrainElevation).Msg("loaded terrain elevation")
+	return grid
+}
+
+func loadWind() *weather.Wind {
+	layers := make([]weather.Layer, 0, len(winds))
+	for _, s := range winds {
+		layer, err := weather.ParseLayer(s)
+		if err != nil {
+			log.Fatal().Err(err).Str("layer", s).Msg("failed to parse wind layer")
+		}
+		layers = append(layers, layer)
+	}
+	if len(layers) > 0 {
+		log.Info().Int("layers", len(layers)).Msg("loaded wind model")
+	}
+	return weather.NewWind(layers...)
+}
+
+func loadTankers() []tankers.Tanker {
+	result := make([]tankers.Tanker, 0, len(tankerInfo))
+	for _, s := range tankerInfo {
+		tanker, err := tankers.Parse(s)
+		if err != nil {
+			log.Fatal().Err(err).Str("tanker", s).Msg("failed to parse tanker")
+		}
+		result = append(result, tanker)
+	}
+	return result
+}
+
+func loadNamedAreas() *areas.Areas {
+	if namedAreas == "" {
+		return nil
+	}
+	a, err := areas.Load(namedAreas)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", namedAreas).Msg("failed to load named areas")
+	}
+	log.Info().Str("path", namedAreas).Int("areas", a.Len()).Msg("loaded named areas")
+	return a
+}
+
+func loadEncyclopediaDataset() *encyclopedia.Dataset {
+	if encyclopediaDataset == "" {
+		return nil
+	}
+	dataset, err := encyclopedia.LoadDataset(encyclopediaDataset)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", encyclopediaDataset).Msg("failed to load encyclopedia dataset")
+	}
+	log.Info().
+		Str("path", encyclopediaDataset).
+		Str("dcsVersion", dataset.DCSVersion).
+		Int("aircraft", len(dataset.Aircraft)).
+		Int("renames", len(dataset.Renames)).
+		Msg("loaded encyclopedia dataset")
+	return dataset
+}
+
+func loadAircraftOverrides() []encyclopedia.Override {
+	overrides := make([]encyclopedia.Override, 0, len(aircraftOverrides))
+	for _, s := range aircraftOverrides {
+		override, err := encyclopedia.ParseOverride(s)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to parse aircraft override")
+		}
+		overrides = append(overrides, override)
+	}
+	return overrides
+}
+
+func loadSRSGUID() string {
+	if srsGUID != "" {
+		guid, err := srs.ParseGUID(srsGUID)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to parse SRS GUID")
+		}
+		return string(guid)
+	}
+	if srsGUIDFile == "" {
+		return ""
+	}
+	guid, err := srs.LoadOrCreateGUID(srsGUIDFile)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", srsGUIDFile).Msg("failed to load SRS GUID")
+	}
+	return string(guid)
+}
+
+func loadAPIToken() string {
+	if apiAddress != "" && apiToken == "" {
+		log.Fatal().Msg("api-token must be set when the API is enabled")
+	}
+	return apiToken
+}
+
+func loadDatalinkToken() string {
+	if datalinkAddress != "" && datalinkToken == "" {
+		log.Fatal().Msg("datalink-token must be set when the datalink is enabled")
+	}
+	return datalinkToken
+}
+
 func loadPlaybackSpeed() float32 {
 	speedMap := map[string]float32{
 		"veryslow": 1.3,
@@ -206,15 +617,15 @@ func loadPlaybackSpeed() float32 {
 	}
 }
 
-func loadWhisperModel() *whisper.Model {
+func loadWhisperModel(path string) *whisper.Model {
 	if runtime.GOARCH == "amd64" && !cpu.X86.HasAVX2 {
 		log.Fatal().Msg("The CPU on this machine does not support AVX2 instructions.")
 	}
 
-	log.Info().Str("path", whisperModelPath).Msg("loading whisper model")
-	whisperModel, err := whisper.New(whisperModelPath)
+	log.Info().Str("path", path).Msg("loading whisper model")
+	whisperModel, err := whisper.New(path)
 	if err != nil {
-		log.Fatal().Err(err).Str("path", whisperModelPath).Err(err).Msg("failed to load whisper model")
+		log.Fatal().Err(err).Str("path", path).Err(err).Msg("failed to load whisper model")
 	}
 	log.Info().
 		Bool("multilingual", whisperModel.IsMultilingual()).
@@ -237,11 +648,13 @@ func randomizer() (rando *rand.Rand) {
 	return
 }
 
+var voiceOptions = map[string]voices.Voice{
+	"feminine":  voices.FeminineVoice,
+	"masculine": voices.MasculineVoice,
+}
+
 func loadVoice(rando *rand.Rand) (voice voices.Voice) {
-	options := map[string]voices.Voice{
-		"feminine":  voices.FeminineVoice,
-		"masculine": voices.MasculineVoice,
-	}
+	options := voiceOptions
 	if voiceName == "" {
 		keys := reflect.ValueOf(options).MapKeys()
 		voice = options[keys[rando.IntN(len(keys))].String()]
@@ -253,6 +666,126 @@ func loadVoice(rando *rand.Rand) (voice voices.Voice) {
 	return
 }
 
+func loadPersonas(frequencies []simpleradio.RadioFrequency, defaultVoice voices.Voice, defaultCallsign string) map[simpleradio.RadioFrequency]conf.Persona {
+	personas := make(map[simpleradio.RadioFrequency]conf.Persona, len(srsFrequencyPersonas))
+	for _, s := range srsFrequencyPersonas {
+		logger := log.With().Str("persona", s).Logger()
+		fields := strings.Split(s, ":")
+		if len(fields) < 2 || len(fields) > 4 {
+			logger.Fatal().Msg("SRS frequency persona must be in the format FREQUENCY:LANGUAGE[:VOICE[:CALLSIGN]]")
+		}
+		frequency, err := configuredFrequency(frequencies, fields[0])
+		if err != nil {
+			logger.Fatal().Err(err).Msg("failed to parse SRS frequency persona")
+		}
+		persona := conf.Persona{Language: strings.ToLower(fields[1]), Voice: defaultVoice, Callsign: defaultCallsign}
+		if persona.Language == "" {
+			persona.Language = "en"
+		}
+		if len(fields) >= 3 && fields[2] != "" {
+			voice, ok := voiceOptions[fields[2]]
+			if !ok {
+				logger.Fatal().Msg("SRS frequency persona voice must be either feminine or masculine")
+			}
+			persona.Voice = voice
+		}
+		if len(fields) == 4 && strings.TrimSpace(fields[3]) != "" {
+			persona.Callsign = strings.TrimSpace(fields[3])
+		}
+		personas[*frequency] = persona
+		logger.Info().
+			Stringer("frequency", frequency).
+			Str("language", persona.Language).
+			Int("voice", int(persona.Voice)).
+			Str("callsign", persona.Callsign).
+			Msg("assigned persona to SRS frequency")
+	}
+	return personas
+}
+
+func loadRelays(frequencies []simpleradio.RadioFrequency) []conf.Relay {
+	relays := make([]conf.Relay, 0, len(srsRelays))
+	for _, s := range srsRelays {
+		logger := log.With().Str("relay", s).Logger()
+		fields := strings.Split(s, ":")
+		if len(fields) != 2 {
+			logger.Fatal().Msg("SRS relay must be in the format FREQUENCY:FREQUENCY")
+		}
+		a, err := configuredFrequency(frequencies, fields[0])
+		if err != nil {
+			logger.Fatal().Err(err).Msg("failed to parse SRS relay")
+		}
+		b, err := configuredFrequency(frequencies, fields[1])
+		if err != nil {
+			logger.Fatal().Err(err).Msg("failed to parse SRS relay")
+		}
+		if a.IsSameFrequency(*b) {
+			logger.Fatal().Msg("SRS relay must be between two different frequencies")
+		}
+		relays = append(relays, conf.Relay{A: *a, B: *b})
+		logger.Info().Stringer("a", a).Stringer("b", b).Msg("relaying between SRS frequencies")
+	}
+	return relays
+}
+
+func loadFrequencyChanges(frequencies []simpleradio.RadioFrequency) []conf.FrequencyChange {
+	changes := make([]conf.FrequencyChange, 0, len(srsFrequencyChanges))
+	for _, s := range srsFrequencyChanges {
+		logger := log.With().Str("change", s).Logger()
+		fields := strings.Split(s, ":")
+		if len(fields) != 3 {
+			logger.Fatal().Msg("SRS frequency change must be in the format HHMM:FREQUENCY:FREQUENCY")
+		}
+		clock, err := time.Parse("1504", fields[0])
+		if err != nil {
+			logger.Fatal().Err(err).Msg("SRS frequency change time must be a 24-hour time in the format HHMM")
+		}
+		from, err := simpleradio.ParseRadioFrequency(fields[1])
+		if err != nil {
+			logger.Fatal().Err(err).Msg("failed to parse SRS frequency change")
+		}
+		to, err := simpleradio.ParseRadioFrequency(fields[2])
+		if err != nil {
+			logger.Fatal().Err(err).Msg("failed to parse SRS frequency change")
+		}
+		at := time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute
+		changes = append(changes, conf.FrequencyChange{At: at, From: *from, To: *to})
+	}
+	slices.SortStableFunc(changes, func(a, b conf.FrequencyChange) int {
+		return cmp.Compare(a.At, b.At)
+	})
+
+	// Check the schedule makes sense by replaying it against the configured frequencies.
+	tuned := slices.Clone(frequencies)
+	for _, change := range changes {
+		logger := log.With().Stringer("from", change.From).Stringer("to", change.To).Stringer("at", change.At).Logger()
+		i := slices.IndexFunc(tuned, change.From.IsSameFrequency)
+		if i == -1 {
+			logger.Fatal().Msg("SRS frequency change is from a frequency the GCI will not be on at that time")
+		}
+		if slices.ContainsFunc(tuned, change.To.IsSameFrequency) {
+			logger.Fatal().Msg("SRS frequency change is to a frequency the GCI will already be on at that time")
+		}
+		tuned[i] = change.To
+		logger.Info().Msg("scheduled SRS frequency change")
+	}
+	return changes
+}
+
+// configuredFrequency parses the given frequency and returns the matching configured SRS frequency.
+func configuredFrequency(frequencies []simpleradio.RadioFrequency, s string) (*simpleradio.RadioFrequency, error) {
+	parsed, err := simpleradio.ParseRadioFrequency(s)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range frequencies {
+		if f.IsSameFrequency(*parsed) {
+			return &f, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not one of the configured SRS frequencies", s)
+}
+
 func loadCallsign(rando *rand.Rand) (callsign string) {
 	var options []string
 	if gciCallsign != "" {
@@ -302,6 +835,9 @@ func run(cmd *cobra.Command, args []string) {
 	cli.SetupZerolog(logLevel, logFormat)
 
 	log.Info().Str("version", Version).Msg("SkyEye GCI Bot")
+	if doctrine != nil {
+		log.Info().Str("doctrine", doctrine.Name).Strs("settings", doctrineSettings).Msg("applied doctrine profile")
+	}
 
 	log.Info().Msg("setting up interrupt and TERM signal handler")
 	interuptChan := make(chan os.Signal, 1)
@@ -314,41 +850,141 @@ func run(cmd *cobra.Command, args []string) {
 		os.Exit(0)
 	}()
 
+	validateConfig()
+
 	log.Info().Msg("loading configuration")
+	if conf.ForceOffline && !offline {
+		log.Info().Msg("offline mode is enforced by this build")
+		offline = true
+	}
+	if offline {
+		log.Info().Msg("offline mode enabled; no network connections will be made other than to the SRS and telemetry servers")
+	}
 	coalition := loadCoalition()
-	whisperModel := loadWhisperModel()
+	whisperModel := loadWhisperModel(whisperModelPath)
+	var keywordSpottingModel *whisper.Model
+	if keywordSpottingModelPath != "" {
+		keywordSpottingModel = loadWhisperModel(keywordSpottingModelPath)
+	}
+	var fallbackWhisperModel *whisper.Model
+	if fallbackWhisperModelPath != "" {
+		fallbackWhisperModel = loadWhisperModel(fallbackWhisperModelPath)
+	}
+	var ensembleWhisperModel *whisper.Model
+	if ensembleWhisperModelPath != "" {
+		ensembleWhisperModel = loadWhisperModel(ensembleWhisperModelPath)
+	}
 	rando := randomizer()
 	voice := loadVoice(rando)
 	callsign := loadCallsign(rando)
 	parsedSRSFrequencies := cli.LoadFrequencies(srsFrequencies)
+	personas := loadPersonas(parsedSRSFrequencies, voice, callsign)
+	relays := loadRelays(parsedSRSFrequencies)
 	playbackSpeed := loadPlaybackSpeed()
 
 	config := conf.Configuration{
-		ACMIFile:                     acmiFile,
-		TelemetryAddress:             telemetryAddress,
-		TelemetryConnectionTimeout:   telemetryConnectionTimeout,
-		TelemetryClientName:          callsign,
-		TelemetryPassword:            telemetryPassword,
-		SRSAddress:                   srsAddress,
-		SRSConnectionTimeout:         srsConnectionTimeout,
-		SRSClientName:                fmt.Sprintf("GCI %s [BOT]", callsign),
-		SRSExternalAWACSModePassword: srsExternalAWACSModePassword,
-		SRSFrequencies:               parsedSRSFrequencies,
-		EnableTranscriptionLogging:   enableTranscriptionLogging,
-		Callsign:                     callsign,
-		Coalition:                    coalition,
-		RadarSweepInterval:           telemetryUpdateInterval,
-		WhisperModel:                 whisperModel,
-		Voice:                        voice,
-		Mute:                         mute,
-		PlaybackSpeed:                playbackSpeed,
-		PlaybackPause:                playbackPause,
-		EnableAutomaticPicture:       enableAutomaticPicture,
-		PictureBroadcastInterval:     automaticPictureInterval,
-		EnableThreatMonitoring:       enableThreatMonitoring,
-		ThreatMonitoringInterval:     threatMonitoringInterval,
-		ThreatMonitoringRequiresSRS:  threatMonitoringRequiresSRS,
-		MandatoryThreatRadius:        unit.Length(mandatoryThreatRadiusNM) * unit.NauticalMile,
+		Offline:                        offline,
+		ACMIFile:                       acmiFile,
+		TelemetryAddress:               telemetryAddress,
+		TelemetryConnectionTimeout:     telemetryConnectionTimeout,
+		TelemetryClientName:            callsign,
+		TelemetryPassword:              telemetryPassword,
+		SRSAddress:                     srsAddress,
+		SRSConnectionTimeout:           srsConnectionTimeout,
+		SRSClientName:                  fmt.Sprintf("GCI %s [BOT]", callsign),
+		SRSGUID:                        loadSRSGUID(),
+		SRSExternalAWACSModePassword:   srsExternalAWACSModePassword,
+		SRSFrequencies:                 parsedSRSFrequencies,
+		SRSFrequencyPersonas:           personas,
+		SRSRelays:                      relays,
+		SRSRelayTone:                   unit.Frequency(srsRelayToneHz) * unit.Hertz,
+		SRSFrequencyChanges:            loadFrequencyChanges(parsedSRSFrequencies),
+		SRSFrequencyChangeWarning:      srsFrequencyChangeWarning,
+		SRSTransmitHoldTime:            srsTransmitHoldTime,
+		SRSMaxTransmissionDuration:     srsMaxTransmissionDuration,
+		SRSLongFrameLength:             srsLongFrameLength,
+		SRSLazyDecode:                  srsLazyDecode,
+		EnableTranscriptionLogging:     enableTranscriptionLogging,
+		FrequencyLog:                   frequencyLog,
+		Callsign:                       callsign,
+		Coalition:                      coalition,
+		RadarSweepInterval:             telemetryUpdateInterval,
+		FadeTimeout:                    fadeTimeout,
+		FadedWindow:                    fadedWindow,
+		FadedRadius:                    unit.Length(fadedRadiusNM) * unit.NauticalMile,
+		SimulatedSweepInterval:         simulatedSweepInterval,
+		TrackfileRetention:             trackfileRetention,
+		WhisperModel:                   whisperModel,
+		KeywordSpottingModel:           keywordSpottingModel,
+		FallbackWhisperModel:           fallbackWhisperModel,
+		RecognizerMaxConcurrency:       recognizerMaxConcurrency,
+		RecognizerMaxQueue:             recognizerMaxQueue,
+		RecognizerFallbackQueueDepth:   recognizerFallbackQueueDepth,
+		EnsembleWhisperModel:           ensembleWhisperModel,
+		RecognizerEnsembleThreshold:    ensembleThreshold,
+		RecognizerEnsembleRequests:     ensembleRequests,
+		RecognizerConfidenceThreshold:  loadConfidenceThreshold(),
+		RecognizerConfidenceThresholds: loadConfidenceThresholds(),
+		Voice:                          voice,
+		Mute:                           mute,
+		PlaybackSpeed:                  playbackSpeed,
+		PlaybackPause:                  playbackPause,
+		AltitudeFormat:                 composer.AltitudeFormat(altitudeFormat),
+		PlatformPronunciations:         loadPlatformPronunciations(),
+		Dialect:                        loadDialect(coalition),
+		ComposerTemplates:              loadComposerTemplates(),
+		ComposerTemplatesFile:          composerTemplates,
+		MaxResponseDuration:            maxResponseDuration,
+		TimestampBroadcasts:            timestampBroadcasts,
+		ScopeBroadcasts:                scopeBroadcasts,
+		EncyclopediaDataset:            loadEncyclopediaDataset(),
+		EncyclopediaDatasetFile:        encyclopediaDataset,
+		AircraftOverrides:              loadAircraftOverrides(),
+		EnableAutomaticPicture:         enableAutomaticPicture,
+		PictureBroadcastInterval:       automaticPictureInterval,
+		QuietPictureWhenClean:          quietPictureWhenClean,
+		PictureMaxGroups:               pictureMaxGroups,
+		EnableFullPicture:              enableFullPicture,
+		EnableThreatMonitoring:         enableThreatMonitoring,
+		ThreatMonitoringInterval:       threatMonitoringInterval,
+		ThreatMonitoringRequiresSRS:    threatMonitoringRequiresSRS,
+		MandatoryThreatRadius:          unit.Length(mandatoryThreatRadiusNM) * unit.NauticalMile,
+		GroupingRadii:                  loadGroupingRadii(),
+		ExcludeNonCombatants:           excludeNonCombatants,
+		Terrain:                        loadTerrain(),
+		Wind:                           loadWind(),
+		PackageThreats:                 packageThreats,
+		ColdThreats:                    conf.ColdThreats(coldThreats),
+		MEZWarningLookahead:            mezWarningLookahead,
+		CommitRange:                    unit.Length(commitRangeNM) * unit.NauticalMile,
+		CommitUpdateInterval:           commitUpdateInterval,
+		MergeCooldown:                  mergeCooldown,
+		HVAACallsigns:                  hvaaCallsigns,
+		HVAAProtectionRange:            unit.Length(hvaaProtectionRangeNM) * unit.NauticalMile,
+		EnableTraining:                 enableTraining,
+		GroundClutterFilter:            groundClutterFilter,
+		NamedAreas:                     loadNamedAreas(),
+		Tankers:                        loadTankers(),
+		RequestRateLimit:               requestRateLimit,
+		RequireCheckIn:                 requireCheckIn,
+		BlockedCallsignWords:           blockedCallsignWords,
+		AdminCallsigns:                 adminCallsigns,
+		AdminSRSGUIDs:                  adminSRSGUIDs,
+		APIAddress:                     apiAddress,
+		APIToken:                       loadAPIToken(),
+		APIAuditLog:                    apiAuditLog,
+		DiscordWebhookURL:              discordWebhookURL,
+		BridgeURL:                      bridgeURL,
+		BridgeTopicPrefix:              bridgeTopicPrefix,
+		DatalinkAddress:                datalinkAddress,
+		DatalinkToken:                  loadDatalinkToken(),
+		DatalinkInterval:               datalinkInterval,
+		DatalinkKeyframeInterval:       datalinkKeyframeInterval,
+		DebriefDirectory:               debriefDirectory,
+		DebriefInterval:                debriefInterval,
+		HistoryDirectory:               historyDirectory,
+		HistoryMaxFileSize:             int64(historyMaxFileSizeMB) * 1024 * 1024,
+		HistoryMaxTotalSize:            int64(historyMaxTotalSizeMB) * 1024 * 1024,
 	}
 
 	log.Info().Msg("starting application")
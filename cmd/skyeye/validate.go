@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/internal/cli"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+	"github.com/rs/zerolog/log"
+)
+
+// maxReasonableRadiusNM is the largest radius, in nautical miles, which makes sense for the GCI's threat and
+// protection radii. Larger values are almost certainly a unit mistake.
+const maxReasonableRadiusNM = 300
+
+// validateConfig checks the configuration before anything is loaded, and exits listing every problem found.
+func validateConfig() {
+	log.Info().Msg("validating configuration")
+	var v cli.Validator
+
+	v.Check(coalitionName == "blue" || coalitionName == "red", "coalition", fmt.Sprintf("%q is not a coalition", coalitionName), "set coalition to blue or red")
+
+	v.Check(len(srsFrequencies) > 0, "srs-frequencies", "no SRS frequencies are configured", "list at least one frequency, e.g. 251.0AM")
+	for _, s := range srsFrequencies {
+		if _, err := simpleradio.ParseRadioFrequency(s); err != nil {
+			v.Add("srs-frequencies", fmt.Sprintf("%q is not a valid frequency: %v", s, err), "use a frequency in MHz followed by AM or FM, e.g. 251.0AM or 30.0FM")
+		}
+	}
+	if v.CheckAddress("srs-server-address", srsAddress) {
+		v.CheckReachable("srs-server-address", srsAddress, srsConnectionTimeout, "check that the SRS server is running and that the address and port are correct")
+	}
+	if acmiFile == "" {
+		v.CheckAddress("telemetry-address", telemetryAddress)
+	}
+	v.CheckFile("acmi-file", acmiFile, "check the path to the ACMI file")
+
+	const modelHint = "download a whisper.cpp model and check the path to it"
+	v.Check(whisperModelPath != "", "whisper-model", "no speech recognition model is configured", modelHint)
+	v.CheckFile("whisper-model", whisperModelPath, modelHint)
+	v.CheckFile("keyword-spotting-model", keywordSpottingModelPath, modelHint)
+	v.CheckFile("whisper-fallback-model", fallbackWhisperModelPath, modelHint)
+	v.CheckFile("whisper-ensemble-model", ensembleWhisperModelPath, modelHint)
+	v.Check(confidenceThreshold >= 0 && confidenceThreshold <= 1, "recognizer-confidence-threshold", fmt.Sprintf("%v is not between 0 and 1", confidenceThreshold), "set a confidence between 0 and 1, or 0 to disable")
+	v.Check(ensembleThreshold >= 0 && ensembleThreshold <= 1, "recognizer-ensemble-threshold", fmt.Sprintf("%v is not between 0 and 1", ensembleThreshold), "set a confidence between 0 and 1")
+
+	v.CheckFile("composer-templates", composerTemplates, "check the path to the templates file")
+	v.CheckFile("encyclopedia-dataset", encyclopediaDataset, "check the path to the dataset file")
+	v.CheckFile("terrain-elevation", terrainElevation, "check the path to the ESRI ASCII grid")
+	v.CheckFile("named-areas", namedAreas, "check the path to the named areas file")
+	v.CheckDirectory("debrief-directory", debriefDirectory, "create the directory, or leave the setting empty to disable debrief recording")
+	v.CheckDirectory("history-directory", historyDirectory, "create the directory, or leave the setting empty to disable history recording")
+
+	radii := []struct {
+		setting string
+		value   float64
+	}{
+		{"mandatory-threat-radius", mandatoryThreatRadiusNM},
+		{"commit-range", commitRangeNM},
+		{"hvaa-protection-range", hvaaProtectionRangeNM},
+		{"faded-radius", fadedRadiusNM},
+	}
+	for _, radius := range radii {
+		v.Check(radius.value >= 0, radius.setting, fmt.Sprintf("%v is negative", radius.value), "set a distance in nautical miles, or 0 to disable")
+		v.Check(radius.value <= maxReasonableRadiusNM, radius.setting, fmt.Sprintf("%v nautical miles is unreasonably large", radius.value), "distances are in nautical miles, not meters or feet")
+	}
+
+	v.Check(telemetryUpdateInterval > 0, "telemetry-update-interval", "must be positive", "set an interval such as 2s")
+	v.Check(!enableAutomaticPicture || automaticPictureInterval > 0, "auto-picture-interval", "must be positive when automatic PICTURE is enabled", "set an interval such as 2m, or disable auto-picture")
+	v.Check(!enableThreatMonitoring || threatMonitoringInterval > 0, "threat-monitoring-interval", "must be positive when threat monitoring is enabled", "set an interval such as 3m, or disable threat-monitoring")
+	v.Check(fadeTimeout < trackfileRetention, "trackfile-retention", fmt.Sprintf("%s is not longer than the fade timeout of %s", trackfileRetention, fadeTimeout), "set trackfile-retention longer than fade-timeout, so that contacts fade before they are removed")
+
+	v.Check(apiAddress == "" || apiToken != "", "api-token", "must be set when the API is enabled", "set a secret token, or leave api-address empty to disable the API")
+	if apiAddress != "" {
+		v.CheckAddress("api-address", apiAddress)
+	}
+	if datalinkAddress != "" {
+		v.CheckAddress("datalink-address", datalinkAddress)
+	}
+
+	if err := v.Report(); err != nil {
+		log.Fatal().Err(err).Msg("invalid configuration, see the errors above")
+	}
+}
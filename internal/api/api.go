@@ -0,0 +1,108 @@
+// package api serves SkyEye's HTTP API, which allows missions and server hosts to interact with the running GCI.
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// shutdownTimeout is how long the server waits for in-flight requests to finish when shutting down.
+const shutdownTimeout = 5 * time.Second
+
+// Server is an HTTP server for SkyEye's API. All endpoints require a bearer token.
+type Server struct {
+	// address to listen on, including port.
+	address string
+	// token is the bearer token clients must present.
+	token string
+	// mux routes requests to endpoints.
+	mux *http.ServeMux
+	// audit records admin actions taken through the API.
+	audit *AuditLog
+}
+
+// NewServer constructs a new API server which listens on the given address. Clients must authenticate with the given
+// bearer token. Events published to the transcript are streamed to connected clients. Admin actions are recorded to
+// the given audit log; if it is nil, they are only kept in memory.
+func NewServer(address, token string, broadcaster Broadcaster, annotator Annotator, trainer Trainer, threatMap ThreatMap, switchboard Switchboard, transcript *Transcript, stats *Statistics, audit *AuditLog) *Server {
+	if audit == nil {
+		audit = NewAuditLog()
+	}
+	s := &Server{
+		address: address,
+		token:   token,
+		mux:     http.NewServeMux(),
+		audit:   audit,
+	}
+	s.mux.Handle("POST /api/v1/broadcast", s.authenticate(broadcastHandler(broadcaster, audit)))
+	s.mux.Handle("GET /api/v1/trackfiles", s.authenticate(trackfilesHandler(annotator)))
+	s.mux.Handle("PUT /api/v1/trackfiles/{id}/tags", s.authenticate(tagsHandler(annotator, audit)))
+	s.mux.Handle("GET /api/v1/threats", s.authenticate(threatsHandler(threatMap)))
+	s.mux.Handle("GET /api/v1/stats", s.authenticate(statsHandler(stats)))
+	s.mux.Handle("PUT /api/v1/training", s.authenticate(trainingHandler(trainer, audit)))
+	s.mux.Handle("GET /api/v1/subsystems", s.authenticate(subsystemsHandler(switchboard)))
+	s.mux.Handle("PUT /api/v1/subsystems/{name}", s.authenticate(subsystemHandler(switchboard, audit)))
+	s.mux.Handle("GET /api/v1/grammar", s.authenticate(grammarHandler()))
+	s.mux.Handle("GET /api/v1/audit", s.authenticate(auditHandler(audit)))
+	s.mux.Handle("GET /api/v1/transcript", withQueryToken(s.authenticate(transcriptHandler(transcript))))
+	s.registerDebugHandlers()
+	return s
+}
+
+// Handler returns the server's HTTP handler.
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// Run serves the API until the context is cancelled.
+func (s *Server) Run(ctx context.Context, wg *sync.WaitGroup) error {
+	server := &http.Server{
+		Addr:              s.address,
+		Handler:           s.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		log.Info().Msg("stopping API server due to context cancellation")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Error().Err(err).Msg("error shutting down API server")
+		}
+		if err := s.audit.Close(); err != nil {
+			log.Error().Err(err).Msg("error closing audit log")
+		}
+	}()
+
+	log.Info().Str("address", s.address).Msg("serving API")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("error serving API: %w", err)
+	}
+	return nil
+}
+
+// authenticate rejects requests which do not present the server's bearer token.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			log.Warn().Str("remote", r.RemoteAddr).Str("path", r.URL.Path).Msg("rejecting unauthenticated API request")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
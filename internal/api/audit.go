@@ -0,0 +1,169 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxRecentAuditEntries is the number of audit entries kept in memory to be served by the API. Older entries remain
+// in the audit file.
+const maxRecentAuditEntries = 200
+
+// ActorHeader is an optional header which identifies the admin making a request, such as "Chazz". Because every
+// admin shares the same bearer token, this is taken on trust; it exists so that communities with several admins can
+// tell who did what.
+const ActorHeader = "X-SkyEye-Actor"
+
+// AuditEntry records a single admin action.
+type AuditEntry struct {
+	// Time the action was taken.
+	Time time.Time `json:"time"`
+	// Actor is the admin who took the action, from the X-SkyEye-Actor header. Empty if the header was not sent.
+	Actor string `json:"actor,omitempty"`
+	// Remote is the network address the request came from.
+	Remote string `json:"remote"`
+	// Action is what was done, such as "broadcast", "tags" or "training".
+	Action string `json:"action"`
+	// Target is what the action was done to, such as a trackfile ID. Empty if the action has no particular target.
+	Target string `json:"target,omitempty"`
+	// Old is the value before the action. Empty if the action does not replace a value.
+	Old any `json:"old,omitempty"`
+	// New is the value after the action.
+	New any `json:"new,omitempty"`
+}
+
+// AuditLog records admin actions to an append-only file and keeps the most recent entries in memory.
+type AuditLog struct {
+	lock sync.Mutex
+	// file is the audit file. This is nil if entries are only kept in memory.
+	file *os.File
+	// recent are the most recent entries, oldest first.
+	recent []AuditEntry
+}
+
+// NewAuditLog constructs an audit log which only keeps entries in memory.
+func NewAuditLog() *AuditLog {
+	return &AuditLog{}
+}
+
+// OpenAuditLog opens the audit file at the given path, creating it if it does not exist. The most recent entries
+// already in the file are loaded so that they survive restarts.
+func OpenAuditLog(path string) (*AuditLog, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	l := &AuditLog{file: file}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			log.Warn().Err(err).Str("path", path).Msg("skipping malformed audit log entry")
+			continue
+		}
+		l.remember(entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to read audit log: %w", err), file.Close())
+	}
+	return l, nil
+}
+
+// Record appends the entry to the audit log. Failure to write the audit file is logged but does not fail the action
+// being audited.
+func (l *AuditLog) Record(entry AuditEntry) {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	log.Info().
+		Str("actor", entry.Actor).
+		Str("remote", entry.Remote).
+		Str("action", entry.Action).
+		Str("target", entry.Target).
+		Any("old", entry.Old).
+		Any("new", entry.New).
+		Msg("audit")
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.remember(entry)
+	if l.file == nil {
+		return
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to encode audit log entry")
+		return
+	}
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		log.Error().Err(err).Msg("failed to write audit log entry")
+	}
+}
+
+// remember keeps the entry in memory, discarding the oldest entry if there are too many.
+func (l *AuditLog) remember(entry AuditEntry) {
+	l.recent = append(l.recent, entry)
+	if len(l.recent) > maxRecentAuditEntries {
+		l.recent = slices.Clone(l.recent[len(l.recent)-maxRecentAuditEntries:])
+	}
+}
+
+// Recent returns up to the given number of the most recent entries, newest first.
+func (l *AuditLog) Recent(limit int) []AuditEntry {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	entries := slices.Clone(l.recent[max(0, len(l.recent)-limit):])
+	slices.Reverse(entries)
+	return entries
+}
+
+// Close closes the audit file.
+func (l *AuditLog) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// newAuditEntry begins an audit entry for an action taken through the given request.
+func newAuditEntry(r *http.Request, action string) AuditEntry {
+	return AuditEntry{
+		Actor:  r.Header.Get(ActorHeader),
+		Remote: r.RemoteAddr,
+		Action: action,
+	}
+}
+
+// auditHandler serves the most recent audit entries as JSON, newest first. The optional limit query parameter sets the
+// maximum number of entries.
+func auditHandler(audit *AuditLog) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := 50
+		if value := r.URL.Query().Get("limit"); value != "" {
+			var err error
+			limit, err = strconv.Atoi(value)
+			if err != nil || limit < 1 {
+				http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(audit.Recent(limit)); err != nil {
+			log.Error().Err(err).Msg("failed to encode audit log")
+		}
+	})
+}
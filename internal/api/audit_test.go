@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogPersists(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	audit, err := OpenAuditLog(path)
+	require.NoError(t, err)
+	audit.Record(AuditEntry{Actor: "Chazz", Action: "training", Old: false, New: true})
+	audit.Record(AuditEntry{Actor: "Kei", Action: "training", Old: true, New: false})
+	require.NoError(t, audit.Close())
+
+	audit, err = OpenAuditLog(path)
+	require.NoError(t, err)
+	defer audit.Close()
+	entries := audit.Recent(10)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "Kei", entries[0].Actor)
+	assert.Equal(t, "Chazz", entries[1].Actor)
+	assert.Equal(t, true, entries[1].New)
+	assert.Len(t, audit.Recent(1), 1)
+}
+
+func TestAuditLogRecent(t *testing.T) {
+	t.Parallel()
+	audit := NewAuditLog()
+	for range maxRecentAuditEntries + 10 {
+		audit.Record(AuditEntry{Action: "broadcast"})
+	}
+	assert.Len(t, audit.Recent(2*maxRecentAuditEntries), maxRecentAuditEntries)
+}
+
+func TestAudit(t *testing.T) {
+	t.Parallel()
+	annotator := newMockAnnotator()
+	annotator.trackfiles[0].Tags = []string{"HVAA"}
+	trainer := &mockTrainer{}
+	server := NewServer("localhost:0", "hunter2", &mockBroadcaster{}, annotator, trainer, &mockThreatMap{}, &mockSwitchboard{}, NewTranscript(), NewStatistics(), nil)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		request.Header.Set("Authorization", "Bearer hunter2")
+		request.Header.Set(ActorHeader, "Chazz")
+		recorder := httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	require.Equal(t, http.StatusNoContent, send(http.MethodPut, "/api/v1/trackfiles/1/tags", `{"tags": ["ESCORT"]}`).Code)
+	require.Equal(t, http.StatusNoContent, send(http.MethodPut, "/api/v1/training", `{"enabled": true}`).Code)
+	require.Equal(t, http.StatusAccepted, send(http.MethodPost, "/api/v1/broadcast", `{"text": "Push now.", "package": "north"}`).Code)
+	// Rejected actions are not audited.
+	require.Equal(t, http.StatusNotFound, send(http.MethodPut, "/api/v1/trackfiles/3/tags", `{"tags": ["HVAA"]}`).Code)
+
+	recorder := send(http.MethodGet, "/api/v1/audit?limit=2", "")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var entries []AuditEntry
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&entries))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "broadcast", entries[0].Action)
+	assert.Equal(t, "package north", entries[0].Target)
+	assert.Equal(t, "Push now.", entries[0].New)
+	assert.Equal(t, "training", entries[1].Action)
+	assert.Equal(t, false, entries[1].Old)
+	assert.Equal(t, true, entries[1].New)
+
+	recorder = send(http.MethodGet, "/api/v1/audit", "")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&entries))
+	require.Len(t, entries, 3)
+	assert.Equal(t, "Chazz", entries[2].Actor)
+	assert.Equal(t, "1", entries[2].Target)
+	assert.Equal(t, []any{"HVAA"}, entries[2].Old)
+	assert.Equal(t, []any{"ESCORT"}, entries[2].New)
+
+	assert.Equal(t, http.StatusBadRequest, send(http.MethodGet, "/api/v1/audit?limit=0", "").Code)
+}
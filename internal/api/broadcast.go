@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrInvalidBroadcast is returned by a Broadcaster if the broadcast cannot be transmitted as requested, such as if
+	// the frequency is not one of the GCI's frequencies or the package does not exist.
+	ErrInvalidBroadcast = errors.New("invalid broadcast")
+	// ErrBroadcastQueueFull is returned by a Broadcaster if too many broadcasts are already waiting to be transmitted.
+	ErrBroadcastQueueFull = errors.New("broadcast queue is full")
+)
+
+// maxBroadcastLength is the maximum length of broadcast text, in bytes.
+const maxBroadcastLength = 1000
+
+// Broadcaster transmits text submitted by the mission.
+type Broadcaster interface {
+	// Broadcast queues the request's text to be spoken.
+	Broadcast(BroadcastRequest) error
+}
+
+// BroadcastRequest is the body of a broadcast request.
+type BroadcastRequest struct {
+	// Text to speak.
+	Text string `json:"text"`
+	// Frequency to speak on, such as "251.0AM". If empty, the text is spoken on all of the GCI's frequencies.
+	Frequency string `json:"frequency,omitempty"`
+	// Package addresses the broadcast to the players in the named package, such as "north". If empty, the broadcast
+	// is not addressed to anyone in particular.
+	Package string `json:"package,omitempty"`
+	// Near addresses the broadcast to the players within a radius of a point. It cannot be combined with Package.
+	Near *Proximity `json:"near,omitempty"`
+}
+
+// Proximity selects the players within a radius of a point, which is given as a bullseye position.
+type Proximity struct {
+	// Bearing from bullseye to the point, in magnetic degrees.
+	Bearing float64 `json:"bearing"`
+	// Range from bullseye to the point, in nautical miles. Zero is bullseye itself.
+	Range float64 `json:"range"`
+	// Radius around the point, in nautical miles.
+	Radius float64 `json:"radius"`
+}
+
+// String describes the area, e.g. "within 40 NM of bullseye 135/20".
+func (p Proximity) String() string {
+	if p.Range == 0 {
+		return fmt.Sprintf("within %.0f NM of bullseye", p.Radius)
+	}
+	return fmt.Sprintf("within %.0f NM of bullseye %03.0f/%.0f", p.Radius, p.Bearing, p.Range)
+}
+
+// validate returns an error if the area is not a valid bullseye position and radius.
+func (p Proximity) validate() error {
+	if p.Bearing < 0 || p.Bearing > 360 {
+		return errors.New("bearing must be between 0 and 360 degrees")
+	}
+	if p.Range < 0 {
+		return errors.New("range must not be negative")
+	}
+	if p.Radius <= 0 {
+		return errors.New("radius must be positive")
+	}
+	return nil
+}
+
+// broadcastHandler accepts text to be spoken by the GCI.
+func broadcastHandler(broadcaster Broadcaster, audit *AuditLog) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request BroadcastRequest
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4*maxBroadcastLength))
+		if err := decoder.Decode(&request); err != nil {
+			http.Error(w, "request body must be a JSON object", http.StatusBadRequest)
+			return
+		}
+		request.Text = strings.TrimSpace(request.Text)
+		if request.Text == "" {
+			http.Error(w, "text is required", http.StatusBadRequest)
+			return
+		}
+		if len(request.Text) > maxBroadcastLength {
+			http.Error(w, "text is too long", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		if request.Near != nil {
+			if request.Package != "" {
+				http.Error(w, "near and package cannot both be set", http.StatusBadRequest)
+				return
+			}
+			if err := request.Near.validate(); err != nil {
+				http.Error(w, "near: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		logger := log.With().Str("text", request.Text).Str("frequency", request.Frequency).Str("package", request.Package).Logger()
+		err := broadcaster.Broadcast(request)
+		switch {
+		case errors.Is(err, ErrInvalidBroadcast):
+			logger.Warn().Err(err).Msg("rejecting invalid broadcast")
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, ErrBroadcastQueueFull):
+			logger.Warn().Err(err).Msg("rejecting broadcast because the queue is full")
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		case err != nil:
+			logger.Error().Err(err).Msg("failed to queue broadcast")
+			http.Error(w, "failed to queue broadcast", http.StatusInternalServerError)
+		default:
+			logger.Info().Msg("queued broadcast")
+			entry := newAuditEntry(r, "broadcast")
+			entry.Target = request.Frequency
+			if request.Package != "" {
+				entry.Target = "package " + request.Package
+			}
+			if request.Near != nil {
+				entry.Target = request.Near.String()
+			}
+			entry.New = request.Text
+			audit.Record(entry)
+			w.WriteHeader(http.StatusAccepted)
+		}
+	})
+}
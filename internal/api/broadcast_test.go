@@ -0,0 +1,142 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBroadcaster struct {
+	broadcasts []BroadcastRequest
+	err        error
+}
+
+func (b *mockBroadcaster) Broadcast(request BroadcastRequest) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.broadcasts = append(b.broadcasts, request)
+	return nil
+}
+
+func TestBroadcast(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		token    string
+		body     string
+		err      error
+		expected int
+		queued   []BroadcastRequest
+	}{
+		{
+			name:     "all frequencies",
+			token:    "hunter2",
+			body:     `{"text": "Attention all players, the airfield is under attack."}`,
+			expected: http.StatusAccepted,
+			queued:   []BroadcastRequest{{Text: "Attention all players, the airfield is under attack."}},
+		},
+		{
+			name:     "one frequency",
+			token:    "hunter2",
+			body:     `{"text": "Tanker is on station.", "frequency": "251.0AM"}`,
+			expected: http.StatusAccepted,
+			queued:   []BroadcastRequest{{Text: "Tanker is on station.", Frequency: "251.0AM"}},
+		},
+		{
+			name:     "one package",
+			token:    "hunter2",
+			body:     `{"text": "Push now.", "package": "north"}`,
+			expected: http.StatusAccepted,
+			queued:   []BroadcastRequest{{Text: "Push now.", Package: "north"}},
+		},
+		{
+			name:     "nearby players",
+			token:    "hunter2",
+			body:     `{"text": "SAM site active.", "near": {"bearing": 135, "range": 20, "radius": 40}}`,
+			expected: http.StatusAccepted,
+			queued:   []BroadcastRequest{{Text: "SAM site active.", Near: &Proximity{Bearing: 135, Range: 20, Radius: 40}}},
+		},
+		{
+			name:     "no radius",
+			token:    "hunter2",
+			body:     `{"text": "SAM site active.", "near": {"bearing": 135, "range": 20}}`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "near and package",
+			token:    "hunter2",
+			body:     `{"text": "Push now.", "package": "north", "near": {"radius": 40}}`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown package",
+			token:    "hunter2",
+			body:     `{"text": "Push now.", "package": "up"}`,
+			err:      fmt.Errorf("%w: no package named up", ErrInvalidBroadcast),
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "wrong token",
+			token:    "hunter3",
+			body:     `{"text": "Tanker is on station."}`,
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "missing token",
+			body:     `{"text": "Tanker is on station."}`,
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "malformed body",
+			token:    "hunter2",
+			body:     `Tanker is on station.`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "empty text",
+			token:    "hunter2",
+			body:     `{"text": "  "}`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "text too long",
+			token:    "hunter2",
+			body:     fmt.Sprintf(`{"text": %q}`, strings.Repeat("a", maxBroadcastLength+1)),
+			expected: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:     "unknown frequency",
+			token:    "hunter2",
+			body:     `{"text": "Tanker is on station.", "frequency": "123.4AM"}`,
+			err:      fmt.Errorf("%w: not tuned to 123.4AM", ErrInvalidBroadcast),
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "queue full",
+			token:    "hunter2",
+			body:     `{"text": "Tanker is on station."}`,
+			err:      ErrBroadcastQueueFull,
+			expected: http.StatusServiceUnavailable,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			broadcaster := &mockBroadcaster{err: test.err}
+			server := NewServer("localhost:0", "hunter2", broadcaster, &mockAnnotator{}, &mockTrainer{}, &mockThreatMap{}, &mockSwitchboard{}, NewTranscript(), NewStatistics(), nil)
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/broadcast", strings.NewReader(test.body))
+			if test.token != "" {
+				request.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, request)
+			assert.Equal(t, test.expected, recorder.Code)
+			assert.Equal(t, test.queued, broadcaster.broadcasts)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package api
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+)
+
+// registerDebugHandlers serves profiling and runtime metrics, so that server hosts can diagnose a misbehaving instance
+// without restarting it.
+func (s *Server) registerDebugHandlers() {
+	s.mux.Handle("GET /debug/pprof/", s.authenticate(http.HandlerFunc(pprof.Index)))
+	s.mux.Handle("GET /debug/pprof/cmdline", s.authenticate(http.HandlerFunc(pprof.Cmdline)))
+	s.mux.Handle("GET /debug/pprof/profile", s.authenticate(http.HandlerFunc(pprof.Profile)))
+	s.mux.Handle("GET /debug/pprof/symbol", s.authenticate(http.HandlerFunc(pprof.Symbol)))
+	s.mux.Handle("POST /debug/pprof/symbol", s.authenticate(http.HandlerFunc(pprof.Symbol)))
+	s.mux.Handle("GET /debug/pprof/trace", s.authenticate(http.HandlerFunc(pprof.Trace)))
+	s.mux.Handle("GET /debug/vars", s.authenticate(expvar.Handler()))
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebug(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		path     string
+		token    string
+		expected int
+	}{
+		{
+			name:     "pprof index",
+			path:     "/debug/pprof/",
+			token:    "hunter2",
+			expected: http.StatusOK,
+		},
+		{
+			name:     "heap profile",
+			path:     "/debug/pprof/heap",
+			token:    "hunter2",
+			expected: http.StatusOK,
+		},
+		{
+			name:     "runtime metrics",
+			path:     "/debug/vars",
+			token:    "hunter2",
+			expected: http.StatusOK,
+		},
+		{
+			name:     "pprof without token",
+			path:     "/debug/pprof/heap",
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "runtime metrics with wrong token",
+			path:     "/debug/vars",
+			token:    "hunter3",
+			expected: http.StatusUnauthorized,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			server := NewServer("localhost:0", "hunter2", &mockBroadcaster{}, &mockAnnotator{}, &mockTrainer{}, &mockThreatMap{}, &mockSwitchboard{}, NewTranscript(), NewStatistics(), nil)
+			request := httptest.NewRequest(http.MethodGet, test.path, nil)
+			if test.token != "" {
+				request.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, request)
+			assert.Equal(t, test.expected, recorder.Code)
+		})
+	}
+}
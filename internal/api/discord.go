@@ -0,0 +1,44 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// discordMessageLimit is the maximum length of a Discord message.
+const discordMessageLimit = 2000
+
+// discordMessage is the body of a Discord webhook request.
+// Reference: https://discord.com/developers/docs/resources/webhook#execute-webhook
+type discordMessage struct {
+	Content string `json:"content"`
+}
+
+// PostSummary posts the mission summary to a Discord channel through the given webhook URL.
+func PostSummary(ctx context.Context, client *http.Client, webhookURL string, summary Summary) error {
+	content := "```\n" + summary.String() + "```"
+	if len(content) > discordMessageLimit {
+		content = content[:discordMessageLimit-len("...```")] + "...```"
+	}
+	body, err := json.Marshal(discordMessage{Content: content})
+	if err != nil {
+		return fmt.Errorf("failed to encode Discord message: %w", err)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Discord webhook request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to call Discord webhook: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d from Discord webhook", response.StatusCode)
+	}
+	return nil
+}
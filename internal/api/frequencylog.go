@@ -0,0 +1,84 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// WriteFrequencyLog writes a timestamped line to w for every transmission heard and every response spoken, until the
+// context is cancelled. gciName is the name used for the GCI's own responses. This is intended for after-action review
+// and comms discipline debriefs, so every recognized transmission is logged, not only requests to the GCI.
+//
+// Lines are buffered and flushed whenever no more events are waiting, so a slow writer does not cause events to be
+// dropped. Events published before the context is cancelled are written before returning.
+func (t *Transcript) WriteFrequencyLog(ctx context.Context, w io.Writer, gciName string) error {
+	events := t.Subscribe()
+	defer t.Unsubscribe(events)
+	writer := bufio.NewWriter(w)
+	for {
+		select {
+		case <-ctx.Done():
+			t.Unsubscribe(events)
+			for {
+				select {
+				case event := <-events:
+					if err := writeFrequencyLogLine(writer, event, gciName); err != nil {
+						return err
+					}
+				default:
+					if err := writer.Flush(); err != nil {
+						return fmt.Errorf("failed to flush frequency log: %w", err)
+					}
+					return nil
+				}
+			}
+		case event := <-events:
+			if err := writeFrequencyLogLine(writer, event, gciName); err != nil {
+				return err
+			}
+			if len(events) == 0 {
+				if err := writer.Flush(); err != nil {
+					return fmt.Errorf("failed to flush frequency log: %w", err)
+				}
+			}
+		}
+	}
+}
+
+// writeFrequencyLogLine writes a transcript event to the frequency log, if it belongs in the frequency log.
+func writeFrequencyLogLine(w *bufio.Writer, event Event, gciName string) error {
+	line, ok := formatFrequencyLogLine(event, gciName)
+	if !ok {
+		return nil
+	}
+	if _, err := w.WriteString(line); err != nil {
+		return fmt.Errorf("failed to write frequency log: %w", err)
+	}
+	return nil
+}
+
+// formatFrequencyLogLine formats a transcript event as a line of the frequency log. The second return value is false
+// if the event does not belong in the frequency log.
+func formatFrequencyLogLine(event Event, gciName string) (string, bool) {
+	var speaker string
+	switch event.Type {
+	case TransmissionEvent:
+		speaker = event.Transmitter
+		if speaker == "" {
+			speaker = "unknown"
+		}
+	case ResponseEvent:
+		speaker = gciName
+	default:
+		return "", false
+	}
+	frequencies := "all"
+	if len(event.Frequencies) > 0 {
+		frequencies = strings.Join(event.Frequencies, ",")
+	}
+	return fmt.Sprintf("%s %s %s: %s\n", event.Time.UTC().Format(time.RFC3339), frequencies, speaker, event.Text), true
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a bytes.Buffer which is safe for concurrent use.
+type syncBuffer struct {
+	lock   sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.String()
+}
+
+func TestFrequencyLog(t *testing.T) {
+	t.Parallel()
+	transcript := NewTranscript()
+	ctx, cancel := context.WithCancel(context.Background())
+	buffer := &syncBuffer{}
+	done := make(chan error)
+	go func() {
+		done <- transcript.WriteFrequencyLog(ctx, buffer, "Focus")
+	}()
+	require.Eventually(t, func() bool {
+		transcript.lock.Lock()
+		defer transcript.lock.Unlock()
+		return len(transcript.subscribers) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	at := time.Date(2026, 10, 15, 14, 2, 11, 0, time.UTC)
+	transcript.Publish(Event{Type: TransmissionEvent, Time: at, Frequencies: []string{"251.000AM"}, Text: "Two, fence in.", Transmitter: "Mobius 1"})
+	transcript.Publish(Event{Type: TransmissionEvent, Time: at, Frequencies: []string{"251.000AM"}, Text: "Focus Mobius 1 bogey dope"})
+	transcript.Publish(Event{Type: RequestEvent, Time: at, Request: "bogeydope", Callsign: "mobius 1"})
+	transcript.Publish(Event{Type: ResponseEvent, Time: at.Add(2 * time.Second), Frequencies: []string{"251.000AM"}, Text: "Mobius 1, Focus, clean.", Callsign: "mobius 1"})
+	transcript.Publish(Event{Type: ResponseEvent, Time: at.Add(time.Minute), Text: "Focus, picture clean."})
+
+	expected := "2026-10-15T14:02:11Z 251.000AM Mobius 1: Two, fence in.\n" +
+		"2026-10-15T14:02:11Z 251.000AM unknown: Focus Mobius 1 bogey dope\n" +
+		"2026-10-15T14:02:13Z 251.000AM Focus: Mobius 1, Focus, clean.\n" +
+		"2026-10-15T14:03:11Z all Focus: Focus, picture clean.\n"
+	assert.Eventually(t, func() bool { return buffer.String() == expected }, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// failingWriter is an io.Writer which always fails.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestFrequencyLogWriteError(t *testing.T) {
+	t.Parallel()
+	transcript := NewTranscript()
+	done := make(chan error)
+	go func() {
+		done <- transcript.WriteFrequencyLog(context.Background(), failingWriter{}, "Focus")
+	}()
+	require.Eventually(t, func() bool {
+		transcript.lock.Lock()
+		defer transcript.lock.Unlock()
+		return len(transcript.subscribers) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	transcript.Publish(Event{Type: ResponseEvent, Text: "Focus, picture clean."})
+	select {
+	case err := <-done:
+		require.Error(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "frequency log did not return write error")
+	}
+}
+
+func TestFrequencyLogWritesPendingEventsOnCancel(t *testing.T) {
+	t.Parallel()
+	transcript := NewTranscript()
+	ctx, cancel := context.WithCancel(context.Background())
+	buffer := &syncBuffer{}
+	// Hold the buffer's lock so that nothing is written until the context is cancelled.
+	buffer.lock.Lock()
+	done := make(chan error)
+	go func() {
+		done <- transcript.WriteFrequencyLog(ctx, buffer, "Focus")
+	}()
+	require.Eventually(t, func() bool {
+		transcript.lock.Lock()
+		defer transcript.lock.Unlock()
+		return len(transcript.subscribers) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	at := time.Date(2026, 10, 15, 14, 2, 11, 0, time.UTC)
+	for range 3 {
+		transcript.Publish(Event{Type: ResponseEvent, Time: at, Text: "Focus, picture clean."})
+	}
+	cancel()
+	buffer.lock.Unlock()
+	require.NoError(t, <-done)
+	assert.Equal(t, 3, strings.Count(buffer.String(), "Focus, picture clean.\n"))
+}
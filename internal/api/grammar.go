@@ -0,0 +1,19 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dharmab/skyeye/pkg/parser"
+	"github.com/rs/zerolog/log"
+)
+
+// grammarHandler reports every request the parser understands and the phrasings it accepts.
+func grammarHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(parser.Describe()); err != nil {
+			log.Error().Err(err).Msg("failed to encode grammar")
+		}
+	})
+}
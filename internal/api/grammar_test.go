@@ -0,0 +1,33 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrammar(t *testing.T) {
+	t.Parallel()
+	server := NewServer("localhost:0", "hunter2", &mockBroadcaster{}, &mockAnnotator{}, &mockTrainer{}, &mockThreatMap{}, &mockSwitchboard{}, NewTranscript(), NewStatistics(), nil)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/grammar", nil)
+	request.Header.Set("Authorization", "Bearer hunter2")
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	var grammar parser.Grammar
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&grammar))
+	assert.Equal(t, parser.Describe(), grammar)
+
+	request = httptest.NewRequest(http.MethodGet, "/api/v1/grammar", nil)
+	recorder = httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
@@ -0,0 +1,268 @@
+package api
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// busiestCallsignsLimit is how many callsigns are listed in the busiest callsigns of a summary.
+const busiestCallsignsLimit = 5
+
+// maxResponseLatency is the longest time between a request and a response to the same callsign which is counted as
+// the response to that request. Requests which were never answered, such as blocked or rate limited requests, would
+// otherwise be matched with an unrelated response much later.
+const maxResponseLatency = time.Minute
+
+// Kill is an object destroyed during the mission.
+type Kill struct {
+	// Coalition is the name of the coalition the object belonged to, such as "Red".
+	Coalition string
+	// IsAircraft is true if the object was an airplane or helicopter.
+	IsAircraft bool
+}
+
+// Summary is a summary of the GCI's activity during a mission.
+type Summary struct {
+	// Start is when the mission started, or when SkyEye started if it joined the mission partway through.
+	Start time.Time `json:"start"`
+	// End is when the mission ended. If the mission is still in progress, this is the time the summary was generated.
+	End time.Time `json:"end"`
+	// TotalRequests is the number of requests understood by the GCI.
+	TotalRequests int `json:"totalRequests"`
+	// Requests counts the requests understood by the GCI by request type, such as "bogeydope".
+	Requests map[string]int `json:"requests"`
+	// AverageLatencySeconds is the average time between a request and the GCI's response, in seconds.
+	AverageLatencySeconds float64 `json:"averageLatencySeconds"`
+	// BusiestCallsigns are the callsigns which made the most requests, busiest first.
+	BusiestCallsigns []CallsignCount `json:"busiestCallsigns"`
+	// Losses counts the objects destroyed during the mission by coalition.
+	Losses []LossCount `json:"losses"`
+}
+
+// CallsignCount is the number of requests made by a callsign.
+type CallsignCount struct {
+	Callsign string `json:"callsign"`
+	Requests int    `json:"requests"`
+}
+
+// LossCount is the number of objects a coalition lost.
+type LossCount struct {
+	Coalition string `json:"coalition"`
+	// Aircraft is the number of airplanes and helicopters lost.
+	Aircraft int `json:"aircraft"`
+	// Surface is the number of other objects lost, such as ground vehicles, ships and structures.
+	Surface int `json:"surface"`
+}
+
+// IsEmpty returns true if nothing happened during the mission.
+func (s Summary) IsEmpty() bool {
+	return s.TotalRequests == 0 && len(s.Losses) == 0
+}
+
+// String formats the summary as a short report suitable for a chat message.
+func (s Summary) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(
+		&builder,
+		"Mission summary: %s to %s UTC (%s)\n",
+		s.Start.UTC().Format("2006-01-02 15:04"),
+		s.End.UTC().Format("15:04"),
+		s.End.Sub(s.Start).Round(time.Minute),
+	)
+
+	fmt.Fprintf(&builder, "Requests: %d", s.TotalRequests)
+	if s.TotalRequests > 0 {
+		types := make([]string, 0, len(s.Requests))
+		for requestType := range s.Requests {
+			types = append(types, requestType)
+		}
+		slices.SortFunc(types, func(a, b string) int {
+			return cmp.Or(cmp.Compare(s.Requests[b], s.Requests[a]), cmp.Compare(a, b))
+		})
+		counts := make([]string, 0, len(types))
+		for _, requestType := range types {
+			counts = append(counts, fmt.Sprintf("%s %d", requestType, s.Requests[requestType]))
+		}
+		fmt.Fprintf(&builder, " (%s)\nAverage response latency: %.1fs", strings.Join(counts, ", "), s.AverageLatencySeconds)
+	}
+	builder.WriteString("\n")
+
+	if len(s.BusiestCallsigns) > 0 {
+		callsigns := make([]string, 0, len(s.BusiestCallsigns))
+		for _, count := range s.BusiestCallsigns {
+			callsigns = append(callsigns, fmt.Sprintf("%s (%d)", count.Callsign, count.Requests))
+		}
+		fmt.Fprintf(&builder, "Busiest callsigns: %s\n", strings.Join(callsigns, ", "))
+	}
+
+	if len(s.Losses) == 0 {
+		builder.WriteString("Kills: none observed\n")
+	}
+	for _, loss := range s.Losses {
+		fmt.Fprintf(&builder, "%s lost %d aircraft and %d surface units\n", loss.Coalition, loss.Aircraft, loss.Surface)
+	}
+	return builder.String()
+}
+
+// Statistics collects statistics about the GCI's activity during a mission. The zero value is not usable; use
+// NewStatistics.
+type Statistics struct {
+	lock sync.Mutex
+	// start is when collection of the current mission's statistics started.
+	start time.Time
+	// requests counts requests by type.
+	requests map[string]int
+	// callsigns counts requests by callsign.
+	callsigns map[string]int
+	// pending maps callsigns to the time of their oldest unanswered request.
+	pending map[string]time.Time
+	// latency is the total latency of answered requests.
+	latency time.Duration
+	// answered is the number of answered requests.
+	answered int
+	// kills are the objects destroyed during the mission.
+	kills []Kill
+}
+
+// NewStatistics constructs a new Statistics which begins collecting for a mission starting now.
+func NewStatistics() *Statistics {
+	s := &Statistics{}
+	s.reset(time.Now())
+	return s
+}
+
+func (s *Statistics) reset(start time.Time) {
+	s.start = start
+	s.requests = make(map[string]int)
+	s.callsigns = make(map[string]int)
+	s.pending = make(map[string]time.Time)
+	s.latency = 0
+	s.answered = 0
+	s.kills = nil
+}
+
+// Collect records requests and responses published to the transcript until the context is cancelled.
+func (s *Statistics) Collect(ctx context.Context, transcript *Transcript) {
+	events := transcript.Subscribe()
+	defer transcript.Unsubscribe(events)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			s.record(event)
+		}
+	}
+}
+
+// record updates the statistics from a transcript event.
+func (s *Statistics) record(event Event) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	switch event.Type {
+	case RequestEvent:
+		s.requests[event.Request]++
+		if event.Callsign == "" {
+			return
+		}
+		s.callsigns[event.Callsign]++
+		if requested, ok := s.pending[event.Callsign]; !ok || event.Time.Sub(requested) > maxResponseLatency {
+			s.pending[event.Callsign] = event.Time
+		}
+	case ResponseEvent:
+		requested, ok := s.pending[event.Callsign]
+		if !ok {
+			return
+		}
+		delete(s.pending, event.Callsign)
+		if latency := event.Time.Sub(requested); latency <= maxResponseLatency {
+			s.latency += latency
+			s.answered++
+		}
+	}
+}
+
+// SetKills replaces the objects destroyed during the mission.
+func (s *Statistics) SetKills(kills []Kill) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.kills = kills
+}
+
+// Summary returns a summary of the mission so far.
+func (s *Statistics) Summary() Summary {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.summarize(time.Now())
+}
+
+// Finish returns a summary of the mission which just ended, and begins collecting statistics for a new mission.
+func (s *Statistics) Finish() Summary {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := time.Now()
+	summary := s.summarize(now)
+	s.reset(now)
+	return summary
+}
+
+func (s *Statistics) summarize(end time.Time) Summary {
+	summary := Summary{
+		Start:            s.start,
+		End:              end,
+		Requests:         make(map[string]int, len(s.requests)),
+		BusiestCallsigns: make([]CallsignCount, 0, len(s.callsigns)),
+		Losses:           make([]LossCount, 0),
+	}
+	for requestType, count := range s.requests {
+		summary.Requests[requestType] = count
+		summary.TotalRequests += count
+	}
+	if s.answered > 0 {
+		summary.AverageLatencySeconds = (s.latency / time.Duration(s.answered)).Seconds()
+	}
+
+	for callsign, count := range s.callsigns {
+		summary.BusiestCallsigns = append(summary.BusiestCallsigns, CallsignCount{Callsign: callsign, Requests: count})
+	}
+	slices.SortFunc(summary.BusiestCallsigns, func(a, b CallsignCount) int {
+		return cmp.Or(cmp.Compare(b.Requests, a.Requests), cmp.Compare(a.Callsign, b.Callsign))
+	})
+	if len(summary.BusiestCallsigns) > busiestCallsignsLimit {
+		summary.BusiestCallsigns = summary.BusiestCallsigns[:busiestCallsignsLimit]
+	}
+
+	for _, kill := range s.kills {
+		i := slices.IndexFunc(summary.Losses, func(loss LossCount) bool { return loss.Coalition == kill.Coalition })
+		if i == -1 {
+			summary.Losses = append(summary.Losses, LossCount{Coalition: kill.Coalition})
+			i = len(summary.Losses) - 1
+		}
+		if kill.IsAircraft {
+			summary.Losses[i].Aircraft++
+		} else {
+			summary.Losses[i].Surface++
+		}
+	}
+	slices.SortFunc(summary.Losses, func(a, b LossCount) int { return cmp.Compare(a.Coalition, b.Coalition) })
+	return summary
+}
+
+// statsHandler returns a summary of the current mission as JSON.
+func statsHandler(stats *Statistics) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(stats.Summary()); err != nil {
+			log.Error().Err(err).Msg("failed to encode mission summary")
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatisticsSummary(t *testing.T) {
+	t.Parallel()
+	stats := NewStatistics()
+	at := time.Date(2026, 10, 15, 14, 2, 11, 0, time.UTC)
+	for _, event := range []Event{
+		{Type: TransmissionEvent, Time: at, Text: "Focus Mobius 1 bogey dope"},
+		{Type: RequestEvent, Time: at, Request: "bogeydope", Callsign: "mobius 1"},
+		{Type: ResponseEvent, Time: at.Add(2 * time.Second), Callsign: "mobius 1"},
+		{Type: RequestEvent, Time: at.Add(10 * time.Second), Request: "picture", Callsign: "mobius 1"},
+		{Type: ResponseEvent, Time: at.Add(14 * time.Second), Callsign: "mobius 1"},
+		{Type: RequestEvent, Time: at.Add(20 * time.Second), Request: "bogeydope", Callsign: "yellow 13"},
+		{Type: RequestEvent, Time: at.Add(30 * time.Second), Request: "radiocheck", Callsign: "yellow 13"},
+		{Type: ResponseEvent, Time: at.Add(33 * time.Second), Callsign: "yellow 13"},
+		// An unanswered request is not matched with a much later response.
+		{Type: RequestEvent, Time: at.Add(time.Minute), Request: "declare", Callsign: "wardog 1"},
+		{Type: ResponseEvent, Time: at.Add(10 * time.Minute), Callsign: "wardog 1"},
+		{Type: ResponseEvent, Time: at.Add(11 * time.Minute), Text: "Focus, picture clean."},
+	} {
+		stats.record(event)
+	}
+	stats.SetKills([]Kill{
+		{Coalition: "Red", IsAircraft: true},
+		{Coalition: "Red", IsAircraft: false},
+		{Coalition: "Blue", IsAircraft: true},
+		{Coalition: "Red", IsAircraft: true},
+	})
+
+	summary := stats.Summary()
+	assert.Equal(t, 5, summary.TotalRequests)
+	assert.Equal(t, map[string]int{"bogeydope": 2, "picture": 1, "radiocheck": 1, "declare": 1}, summary.Requests)
+	// (2s + 4s + 13s) / 3
+	assert.InDelta(t, 19.0/3, summary.AverageLatencySeconds, 0.001)
+	assert.Equal(t, []CallsignCount{
+		{Callsign: "mobius 1", Requests: 2},
+		{Callsign: "yellow 13", Requests: 2},
+		{Callsign: "wardog 1", Requests: 1},
+	}, summary.BusiestCallsigns)
+	assert.Equal(t, []LossCount{
+		{Coalition: "Blue", Aircraft: 1},
+		{Coalition: "Red", Aircraft: 2, Surface: 1},
+	}, summary.Losses)
+	assert.False(t, summary.IsEmpty())
+
+	finished := stats.Finish()
+	assert.Equal(t, summary.TotalRequests, finished.TotalRequests)
+	assert.True(t, stats.Summary().IsEmpty())
+}
+
+func TestSummaryString(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2026, 10, 15, 14, 0, 0, 0, time.UTC)
+	summary := Summary{
+		Start:                 start,
+		End:                   start.Add(2*time.Hour + 30*time.Minute),
+		TotalRequests:         3,
+		Requests:              map[string]int{"picture": 1, "bogeydope": 2},
+		AverageLatencySeconds: 2.34,
+		BusiestCallsigns:      []CallsignCount{{Callsign: "mobius 1", Requests: 3}},
+		Losses:                []LossCount{{Coalition: "Red", Aircraft: 2, Surface: 1}},
+	}
+	expected := "Mission summary: 2026-10-15 14:00 to 16:30 UTC (2h30m0s)\n" +
+		"Requests: 3 (bogeydope 2, picture 1)\n" +
+		"Average response latency: 2.3s\n" +
+		"Busiest callsigns: mobius 1 (3)\n" +
+		"Red lost 2 aircraft and 1 surface units\n"
+	assert.Equal(t, expected, summary.String())
+
+	empty := Summary{Start: start, End: start.Add(time.Hour)}
+	assert.Equal(t, "Mission summary: 2026-10-15 14:00 to 15:00 UTC (1h0m0s)\nRequests: 0\nKills: none observed\n", empty.String())
+}
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+	stats := NewStatistics()
+	stats.record(Event{Type: RequestEvent, Time: time.Now(), Request: "picture", Callsign: "mobius 1"})
+	server := NewServer("localhost:0", "hunter2", &mockBroadcaster{}, &mockAnnotator{}, &mockTrainer{}, &mockThreatMap{}, &mockSwitchboard{}, NewTranscript(), stats, nil)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/stats", nil)
+	request.Header.Set("Authorization", "Bearer hunter2")
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var summary Summary
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&summary))
+	assert.Equal(t, 1, summary.TotalRequests)
+	assert.Equal(t, map[string]int{"picture": 1}, summary.Requests)
+	assert.Equal(t, []CallsignCount{{Callsign: "mobius 1", Requests: 1}}, summary.BusiestCallsigns)
+}
+
+func TestPostSummary(t *testing.T) {
+	t.Parallel()
+	var received discordMessage
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer webhook.Close()
+
+	start := time.Date(2026, 10, 15, 14, 0, 0, 0, time.UTC)
+	summary := Summary{Start: start, End: start.Add(time.Hour)}
+	require.NoError(t, PostSummary(context.Background(), webhook.Client(), webhook.URL, summary))
+	assert.Equal(t, "```\n"+summary.String()+"```", received.Content)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer failing.Close()
+	assert.Error(t, PostSummary(context.Background(), failing.Client(), failing.URL, summary))
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Subsystem is a part of the GCI which can be turned off while the GCI is running, so that a misbehaving part can be
+// taken out of service without restarting everything else.
+type Subsystem string
+
+const (
+	// Recognition is speech recognition of SRS transmissions. While it is off, transmissions are ignored.
+	Recognition Subsystem = "recognition"
+	// Broadcasts are calls the GCI makes without being asked, such as THREAT, MERGED and automatic PICTURE calls.
+	// While they are off, the GCI still responds to requests.
+	Broadcasts Subsystem = "broadcasts"
+	// Speech is speech synthesis and transmission over SRS. While it is off, responses are still published to the
+	// transcript stream.
+	Speech Subsystem = "speech"
+)
+
+// AllSubsystems lists every subsystem which can be turned off.
+var AllSubsystems = []Subsystem{Recognition, Broadcasts, Speech}
+
+// Switchboard turns subsystems on or off.
+type Switchboard interface {
+	// SetSubsystem turns the given subsystem on or off.
+	SetSubsystem(subsystem Subsystem, enabled bool)
+	// Subsystem reports whether the given subsystem is on.
+	Subsystem(subsystem Subsystem) bool
+}
+
+// SubsystemRequest is the body of a subsystem request.
+type SubsystemRequest struct {
+	// Enabled turns the subsystem on or off.
+	Enabled *bool `json:"enabled"`
+}
+
+// subsystemsHandler reports whether each subsystem is on.
+func subsystemsHandler(switchboard Switchboard) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		states := make(map[Subsystem]bool, len(AllSubsystems))
+		for _, subsystem := range AllSubsystems {
+			states[subsystem] = switchboard.Subsystem(subsystem)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(states); err != nil {
+			log.Error().Err(err).Msg("failed to encode subsystems")
+		}
+	})
+}
+
+// subsystemHandler turns a subsystem on or off.
+func subsystemHandler(switchboard Switchboard, audit *AuditLog) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subsystem := Subsystem(r.PathValue("name"))
+		if !slices.Contains(AllSubsystems, subsystem) {
+			http.Error(w, "unknown subsystem", http.StatusNotFound)
+			return
+		}
+		var request SubsystemRequest
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024))
+		if err := decoder.Decode(&request); err != nil {
+			http.Error(w, "request body must be a JSON object", http.StatusBadRequest)
+			return
+		}
+		if request.Enabled == nil {
+			http.Error(w, "enabled is required", http.StatusBadRequest)
+			return
+		}
+		entry := newAuditEntry(r, "subsystem:"+string(subsystem))
+		entry.Old = switchboard.Subsystem(subsystem)
+		entry.New = *request.Enabled
+		switchboard.SetSubsystem(subsystem, *request.Enabled)
+		audit.Record(entry)
+		log.Info().Str("subsystem", string(subsystem)).Bool("enabled", *request.Enabled).Msg("set subsystem through API")
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSwitchboard struct {
+	disabled map[Subsystem]bool
+}
+
+func (s *mockSwitchboard) SetSubsystem(subsystem Subsystem, enabled bool) {
+	if s.disabled == nil {
+		s.disabled = make(map[Subsystem]bool)
+	}
+	s.disabled[subsystem] = !enabled
+}
+
+func (s *mockSwitchboard) Subsystem(subsystem Subsystem) bool {
+	return !s.disabled[subsystem]
+}
+
+func TestSubsystems(t *testing.T) {
+	t.Parallel()
+	switchboard := &mockSwitchboard{}
+	switchboard.SetSubsystem(Broadcasts, false)
+	server := NewServer("localhost:0", "hunter2", &mockBroadcaster{}, &mockAnnotator{}, &mockTrainer{}, &mockThreatMap{}, switchboard, NewTranscript(), NewStatistics(), nil)
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/subsystems", nil)
+	request.Header.Set("Authorization", "Bearer hunter2")
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var states map[Subsystem]bool
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&states))
+	assert.Equal(t, map[Subsystem]bool{Recognition: true, Broadcasts: false, Speech: true}, states)
+}
+
+func TestSetSubsystem(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		path     string
+		body     string
+		expected int
+		enabled  bool
+	}{
+		{
+			name:     "disable",
+			path:     "/api/v1/subsystems/recognition",
+			body:     `{"enabled": false}`,
+			expected: http.StatusNoContent,
+			enabled:  false,
+		},
+		{
+			name:     "enable",
+			path:     "/api/v1/subsystems/recognition",
+			body:     `{"enabled": true}`,
+			expected: http.StatusNoContent,
+			enabled:  true,
+		},
+		{
+			name:     "missing enabled",
+			path:     "/api/v1/subsystems/recognition",
+			body:     `{}`,
+			expected: http.StatusBadRequest,
+			enabled:  true,
+		},
+		{
+			name:     "unknown subsystem",
+			path:     "/api/v1/subsystems/radar",
+			body:     `{"enabled": false}`,
+			expected: http.StatusNotFound,
+			enabled:  true,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			switchboard := &mockSwitchboard{}
+			audit := NewAuditLog()
+			server := NewServer("localhost:0", "hunter2", &mockBroadcaster{}, &mockAnnotator{}, &mockTrainer{}, &mockThreatMap{}, switchboard, NewTranscript(), NewStatistics(), audit)
+			request := httptest.NewRequest(http.MethodPut, test.path, strings.NewReader(test.body))
+			request.Header.Set("Authorization", "Bearer hunter2")
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, request)
+			assert.Equal(t, test.expected, recorder.Code)
+			assert.Equal(t, test.enabled, switchboard.Subsystem(Recognition))
+			if test.expected == http.StatusNoContent {
+				entries := audit.Recent(1)
+				require.Len(t, entries, 1)
+				assert.Equal(t, "subsystem:recognition", entries[0].Action)
+			}
+		})
+	}
+}
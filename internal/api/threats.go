@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog/log"
+)
+
+// threatRingSegments is the number of straight edges used to approximate each threat ring.
+const threatRingSegments = 72
+
+// ThreatMap exports the threat rings of known SAM sites.
+type ThreatMap interface {
+	// ThreatRings returns a threat ring for each known SAM site.
+	ThreatRings() []ThreatRing
+}
+
+// ThreatRing is the Missile Engagement Zone (MEZ) of a SAM site.
+type ThreatRing struct {
+	// System is the common name of the SAM system, such as "SA-10".
+	System string
+	// Group is the name of the site's group in the mission.
+	Group string
+	// Coalition is the name of the site's coalition, such as "Red".
+	Coalition string
+	// Launchers is the number of launchers at the site.
+	Launchers int
+	// Center of the ring.
+	Center orb.Point
+	// Radius of the ring.
+	Radius unit.Length
+}
+
+// featureCollection is a GeoJSON FeatureCollection.
+// Reference: RFC 7946 section 3.3.
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+// feature is a GeoJSON Feature.
+// Reference: RFC 7946 section 3.2.
+type feature struct {
+	Type       string            `json:"type"`
+	Geometry   polygon           `json:"geometry"`
+	Properties threatRingSummary `json:"properties"`
+}
+
+// polygon is a GeoJSON Polygon geometry.
+// Reference: RFC 7946 section 3.1.6.
+type polygon struct {
+	Type        string     `json:"type"`
+	Coordinates []orb.Ring `json:"coordinates"`
+}
+
+// threatRingSummary are the properties of a threat ring feature.
+type threatRingSummary struct {
+	System    string  `json:"system"`
+	Group     string  `json:"group"`
+	Coalition string  `json:"coalition"`
+	Launchers int     `json:"launchers"`
+	RadiusNM  float64 `json:"radius_nm"`
+}
+
+// feature converts the threat ring to a GeoJSON polygon feature.
+func (r ThreatRing) feature() feature {
+	ring := make(orb.Ring, 0, threatRingSegments+1)
+	for i := range threatRingSegments {
+		bearing := bearings.NewTrueBearing(unit.Angle(i*360/threatRingSegments) * unit.Degree)
+		ring = append(ring, spatial.PointAtBearingAndDistance(r.Center, bearing, r.Radius))
+	}
+	ring = append(ring, ring[0])
+	return feature{
+		Type:     "Feature",
+		Geometry: polygon{Type: "Polygon", Coordinates: []orb.Ring{ring}},
+		Properties: threatRingSummary{
+			System:    r.System,
+			Group:     r.Group,
+			Coalition: r.Coalition,
+			Launchers: r.Launchers,
+			RadiusNM:  r.Radius.NauticalMiles(),
+		},
+	}
+}
+
+// threatsHandler exports the threat rings of known SAM sites as a GeoJSON feature collection.
+func threatsHandler(threatMap ThreatMap) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		collection := featureCollection{Type: "FeatureCollection", Features: []feature{}}
+		for _, ring := range threatMap.ThreatRings() {
+			collection.Features = append(collection.Features, ring.feature())
+		}
+		w.Header().Set("Content-Type", "application/geo+json")
+		if err := json.NewEncoder(w).Encode(collection); err != nil {
+			log.Error().Err(err).Msg("failed to encode threat rings")
+		}
+	})
+}
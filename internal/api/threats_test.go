@@ -0,0 +1,61 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockThreatMap struct {
+	rings []ThreatRing
+}
+
+func (m *mockThreatMap) ThreatRings() []ThreatRing {
+	return m.rings
+}
+
+func TestThreats(t *testing.T) {
+	t.Parallel()
+	center := orb.Point{41.6, 42.1}
+	threatMap := &mockThreatMap{
+		rings: []ThreatRing{
+			{System: "SA-11", Group: "SAM-1", Coalition: "Red", Launchers: 2, Center: center, Radius: 19 * unit.NauticalMile},
+		},
+	}
+	server := NewServer("localhost:0", "hunter2", &mockBroadcaster{}, &mockAnnotator{}, &mockTrainer{}, threatMap, &mockSwitchboard{}, NewTranscript(), NewStatistics(), nil)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/threats", nil)
+	request.Header.Set("Authorization", "Bearer hunter2")
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/geo+json", recorder.Header().Get("Content-Type"))
+
+	var collection featureCollection
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&collection))
+	assert.Equal(t, "FeatureCollection", collection.Type)
+	require.Len(t, collection.Features, 1)
+	actual := collection.Features[0]
+	assert.Equal(t, "Feature", actual.Type)
+	assert.Equal(t, threatRingSummary{System: "SA-11", Group: "SAM-1", Coalition: "Red", Launchers: 2, RadiusNM: 19}, actual.Properties)
+
+	assert.Equal(t, "Polygon", actual.Geometry.Type)
+	require.Len(t, actual.Geometry.Coordinates, 1)
+	ring := actual.Geometry.Coordinates[0]
+	assert.True(t, ring.Closed())
+	for _, point := range ring {
+		assert.InDelta(t, 19, spatial.Distance(center, point).NauticalMiles(), 0.1)
+	}
+
+	request = httptest.NewRequest(http.MethodGet, "/api/v1/threats", nil)
+	recorder = httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrUnknownTrackfile is returned by an Annotator if no trackfile exists with the given ID.
+var ErrUnknownTrackfile = errors.New("unknown trackfile")
+
+const (
+	// maxTags is the maximum number of tags which may be attached to a single trackfile.
+	maxTags = 8
+	// maxTagLength is the maximum length of a single tag, in bytes.
+	maxTagLength = 32
+)
+
+// Annotator exports the GCI's trackfiles and attaches tags to them.
+type Annotator interface {
+	// Trackfiles returns a summary of every trackfile.
+	Trackfiles() []Trackfile
+	// SetTags replaces the tags on the trackfile with the given ID.
+	SetTags(id uint64, tags []string) error
+}
+
+// Trackfile is a summary of a trackfile.
+type Trackfile struct {
+	// ID is the object ID of the aircraft.
+	ID uint64 `json:"id"`
+	// Name is the name of the unit. For players, this is the player's in-game name.
+	Name string `json:"name"`
+	// Coalition is the name of the aircraft's coalition, such as "Blue".
+	Coalition string `json:"coalition"`
+	// Aircraft is the ACMI name of the aircraft type, such as "F-16C_50".
+	Aircraft string `json:"aircraft"`
+	// Tags are the tags attached to the trackfile, such as "HVAA".
+	Tags []string `json:"tags"`
+}
+
+// TagsRequest is the body of a request to set a trackfile's tags.
+type TagsRequest struct {
+	// Tags replace the trackfile's existing tags. An empty list removes all tags.
+	Tags []string `json:"tags"`
+}
+
+// trackfilesHandler exports every trackfile as JSON.
+func trackfilesHandler(annotator Annotator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(annotator.Trackfiles()); err != nil {
+			log.Error().Err(err).Msg("failed to encode trackfiles")
+		}
+	})
+}
+
+// tagsHandler replaces the tags on a trackfile.
+func tagsHandler(annotator Annotator, audit *AuditLog) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+		if err != nil {
+			http.Error(w, "trackfile ID must be an unsigned integer", http.StatusBadRequest)
+			return
+		}
+
+		var request TagsRequest
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4*maxTags*maxTagLength))
+		if err := decoder.Decode(&request); err != nil {
+			http.Error(w, "request body must be a JSON object", http.StatusBadRequest)
+			return
+		}
+		if len(request.Tags) > maxTags {
+			http.Error(w, "too many tags", http.StatusBadRequest)
+			return
+		}
+		for i, tag := range request.Tags {
+			request.Tags[i] = strings.TrimSpace(tag)
+			if request.Tags[i] == "" {
+				http.Error(w, "tags must not be empty", http.StatusBadRequest)
+				return
+			}
+			if len(request.Tags[i]) > maxTagLength {
+				http.Error(w, "tag is too long", http.StatusBadRequest)
+				return
+			}
+		}
+
+		logger := log.With().Uint64("id", id).Strs("tags", request.Tags).Logger()
+		entry := newAuditEntry(r, "tags")
+		entry.Target = strconv.FormatUint(id, 10)
+		for _, trackfile := range annotator.Trackfiles() {
+			if trackfile.ID == id {
+				entry.Old = trackfile.Tags
+			}
+		}
+		entry.New = request.Tags
+		err = annotator.SetTags(id, request.Tags)
+		switch {
+		case errors.Is(err, ErrUnknownTrackfile):
+			logger.Warn().Err(err).Msg("rejecting tags for unknown trackfile")
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case err != nil:
+			logger.Error().Err(err).Msg("failed to set tags")
+			http.Error(w, "failed to set tags", http.StatusInternalServerError)
+		default:
+			logger.Info().Msg("set trackfile tags")
+			audit.Record(entry)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAnnotator struct {
+	trackfiles []Trackfile
+}
+
+func (a *mockAnnotator) Trackfiles() []Trackfile {
+	return a.trackfiles
+}
+
+func (a *mockAnnotator) SetTags(id uint64, tags []string) error {
+	for i := range a.trackfiles {
+		if a.trackfiles[i].ID == id {
+			a.trackfiles[i].Tags = tags
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no trackfile with ID %d", ErrUnknownTrackfile, id)
+}
+
+func newMockAnnotator() *mockAnnotator {
+	return &mockAnnotator{
+		trackfiles: []Trackfile{
+			{ID: 1, Name: "Magic 1", Coalition: "Blue", Aircraft: "E-3A", Tags: []string{}},
+			{ID: 2, Name: "Mobius 1", Coalition: "Blue", Aircraft: "F-16C_50", Tags: []string{}},
+		},
+	}
+}
+
+func TestTrackfiles(t *testing.T) {
+	t.Parallel()
+	annotator := newMockAnnotator()
+	annotator.trackfiles[0].Tags = []string{"HVAA"}
+	server := NewServer("localhost:0", "hunter2", &mockBroadcaster{}, annotator, &mockTrainer{}, &mockThreatMap{}, &mockSwitchboard{}, NewTranscript(), NewStatistics(), nil)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/trackfiles", nil)
+	request.Header.Set("Authorization", "Bearer hunter2")
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var actual []Trackfile
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&actual))
+	assert.Equal(t, annotator.trackfiles, actual)
+
+	request = httptest.NewRequest(http.MethodGet, "/api/v1/trackfiles", nil)
+	recorder = httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestTags(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		token    string
+		path     string
+		body     string
+		expected int
+		tags     []string
+	}{
+		{
+			name:     "set tags",
+			token:    "hunter2",
+			path:     "/api/v1/trackfiles/1/tags",
+			body:     `{"tags": ["HVAA", " escort "]}`,
+			expected: http.StatusNoContent,
+			tags:     []string{"HVAA", "escort"},
+		},
+		{
+			name:     "clear tags",
+			token:    "hunter2",
+			path:     "/api/v1/trackfiles/1/tags",
+			body:     `{"tags": []}`,
+			expected: http.StatusNoContent,
+			tags:     []string{},
+		},
+		{
+			name:     "unknown trackfile",
+			token:    "hunter2",
+			path:     "/api/v1/trackfiles/3/tags",
+			body:     `{"tags": ["HVAA"]}`,
+			expected: http.StatusNotFound,
+			tags:     []string{},
+		},
+		{
+			name:     "invalid ID",
+			token:    "hunter2",
+			path:     "/api/v1/trackfiles/magic/tags",
+			body:     `{"tags": ["HVAA"]}`,
+			expected: http.StatusBadRequest,
+			tags:     []string{},
+		},
+		{
+			name:     "empty tag",
+			token:    "hunter2",
+			path:     "/api/v1/trackfiles/1/tags",
+			body:     `{"tags": ["HVAA", " "]}`,
+			expected: http.StatusBadRequest,
+			tags:     []string{},
+		},
+		{
+			name:     "tag too long",
+			token:    "hunter2",
+			path:     "/api/v1/trackfiles/1/tags",
+			body:     fmt.Sprintf(`{"tags": [%q]}`, strings.Repeat("a", maxTagLength+1)),
+			expected: http.StatusBadRequest,
+			tags:     []string{},
+		},
+		{
+			name:     "too many tags",
+			token:    "hunter2",
+			path:     "/api/v1/trackfiles/1/tags",
+			body:     `{"tags": ["a", "b", "c", "d", "e", "f", "g", "h", "i"]}`,
+			expected: http.StatusBadRequest,
+			tags:     []string{},
+		},
+		{
+			name:     "malformed body",
+			token:    "hunter2",
+			path:     "/api/v1/trackfiles/1/tags",
+			body:     `HVAA`,
+			expected: http.StatusBadRequest,
+			tags:     []string{},
+		},
+		{
+			name:     "wrong token",
+			token:    "hunter3",
+			path:     "/api/v1/trackfiles/1/tags",
+			body:     `{"tags": ["HVAA"]}`,
+			expected: http.StatusUnauthorized,
+			tags:     []string{},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			annotator := newMockAnnotator()
+			server := NewServer("localhost:0", "hunter2", &mockBroadcaster{}, annotator, &mockTrainer{}, &mockThreatMap{}, &mockSwitchboard{}, NewTranscript(), NewStatistics(), nil)
+			request := httptest.NewRequest(http.MethodPut, test.path, strings.NewReader(test.body))
+			request.Header.Set("Authorization", "Bearer "+test.token)
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, request)
+			assert.Equal(t, test.expected, recorder.Code)
+			assert.Equal(t, test.tags, annotator.trackfiles[0].Tags)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Trainer turns training commentary on or off.
+type Trainer interface {
+	// SetTraining sets whether training commentary is given to players who have not turned it on or off themselves.
+	SetTraining(enabled bool)
+	// Training reports whether training commentary is given to players who have not turned it on or off themselves.
+	Training() bool
+}
+
+// TrainingRequest is the body of a training request.
+type TrainingRequest struct {
+	// Enabled turns training commentary on or off.
+	Enabled *bool `json:"enabled"`
+}
+
+// trainingHandler turns training commentary on or off, such as when a mission moves from a training phase into
+// a live phase.
+func trainingHandler(trainer Trainer, audit *AuditLog) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request TrainingRequest
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024))
+		if err := decoder.Decode(&request); err != nil {
+			http.Error(w, "request body must be a JSON object", http.StatusBadRequest)
+			return
+		}
+		if request.Enabled == nil {
+			http.Error(w, "enabled is required", http.StatusBadRequest)
+			return
+		}
+		entry := newAuditEntry(r, "training")
+		entry.Old = trainer.Training()
+		entry.New = *request.Enabled
+		trainer.SetTraining(*request.Enabled)
+		audit.Record(entry)
+		log.Info().Bool("enabled", *request.Enabled).Msg("set training commentary through API")
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTrainer struct {
+	enabled []bool
+}
+
+func (t *mockTrainer) SetTraining(enabled bool) {
+	t.enabled = append(t.enabled, enabled)
+}
+
+func (t *mockTrainer) Training() bool {
+	return len(t.enabled) > 0 && t.enabled[len(t.enabled)-1]
+}
+
+func TestTraining(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		token    string
+		body     string
+		expected int
+		set      []bool
+	}{
+		{
+			name:     "enable",
+			token:    "hunter2",
+			body:     `{"enabled": true}`,
+			expected: http.StatusNoContent,
+			set:      []bool{true},
+		},
+		{
+			name:     "disable",
+			token:    "hunter2",
+			body:     `{"enabled": false}`,
+			expected: http.StatusNoContent,
+			set:      []bool{false},
+		},
+		{
+			name:     "missing enabled",
+			token:    "hunter2",
+			body:     `{}`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "malformed body",
+			token:    "hunter2",
+			body:     `on`,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "wrong token",
+			token:    "hunter3",
+			body:     `{"enabled": true}`,
+			expected: http.StatusUnauthorized,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			trainer := &mockTrainer{}
+			server := NewServer("localhost:0", "hunter2", &mockBroadcaster{}, &mockAnnotator{}, trainer, &mockThreatMap{}, &mockSwitchboard{}, NewTranscript(), NewStatistics(), nil)
+			request := httptest.NewRequest(http.MethodPut, "/api/v1/training", strings.NewReader(test.body))
+			if test.token != "" {
+				request.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, request)
+			assert.Equal(t, test.expected, recorder.Code)
+			assert.Equal(t, test.set, trainer.enabled)
+		})
+	}
+}
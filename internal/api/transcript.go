@@ -0,0 +1,158 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// EventType identifies the kind of transcript event.
+type EventType string
+
+const (
+	// TransmissionEvent is published when speech is recognized in a transmission received by the GCI.
+	TransmissionEvent EventType = "transmission"
+	// RequestEvent is published when recognized text is parsed into a request.
+	RequestEvent EventType = "request"
+	// ResponseEvent is published when the GCI composes a response or broadcast call.
+	ResponseEvent EventType = "response"
+)
+
+// Event is a single entry in the live transcript.
+type Event struct {
+	// Type of event.
+	Type EventType `json:"type"`
+	// Time the event occurred.
+	Time time.Time `json:"time"`
+	// Frequencies the transmission was heard on or the response is spoken on, such as "251.000AM". Empty if a response is
+	// spoken on all of the GCI's frequencies.
+	Frequencies []string `json:"frequencies,omitempty"`
+	// Text of a transmission, or the subtitle of a response.
+	Text string `json:"text,omitempty"`
+	// Confidence is the speech recognizer's confidence in a transmission's text, from 0 to 1.
+	Confidence float64 `json:"confidence,omitempty"`
+	// Request is the type of a parsed request, such as "bogeydope".
+	Request string `json:"request,omitempty"`
+	// Call is the type of a response, such as "bogeydope" or "threat".
+	Call string `json:"call,omitempty"`
+	// Callsign of the player who made a request, or to whom a response is addressed.
+	Callsign string `json:"callsign,omitempty"`
+	// Transmitter is the name of the SRS client which sent a transmission.
+	Transmitter string `json:"transmitter,omitempty"`
+	// Details are the structured contents of a parsed request.
+	Details any `json:"details,omitempty"`
+}
+
+// subscriberBufferSize is how many events may be waiting to be sent to a single subscriber. If a subscriber falls
+// further behind, newer events are dropped for that subscriber.
+const subscriberBufferSize = 64
+
+// websocketPingInterval is how often idle transcript connections are pinged to keep proxies from closing them.
+const websocketPingInterval = 30 * time.Second
+
+// Transcript fans out events to every connected transcript stream. The zero value is not usable; use NewTranscript.
+type Transcript struct {
+	lock        sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+// NewTranscript constructs a new Transcript with no subscribers.
+func NewTranscript() *Transcript {
+	return &Transcript{subscribers: make(map[chan Event]struct{})}
+}
+
+// Publish sends the event to every subscriber. It never blocks; subscribers which are not keeping up miss the event.
+func (t *Transcript) Publish(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for subscriber := range t.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			log.Warn().Str("type", string(event.Type)).Msg("dropping transcript event for slow subscriber")
+		}
+	}
+}
+
+// Subscribe returns a channel which receives every event published from now on. Call Unsubscribe when done.
+func (t *Transcript) Subscribe() chan Event {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	subscriber := make(chan Event, subscriberBufferSize)
+	t.subscribers[subscriber] = struct{}{}
+	return subscriber
+}
+
+// Unsubscribe stops sending events to a channel returned by Subscribe.
+func (t *Transcript) Unsubscribe(subscriber chan Event) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.subscribers, subscriber)
+}
+
+// withQueryToken lets clients which cannot set request headers, such as browsers opening a WebSocket, present the
+// bearer token in the "token" query parameter instead.
+func withQueryToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.URL.Query().Get("token"); token != "" && r.Header.Get("Authorization") == "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// transcriptHandler streams transcript events as JSON messages over a WebSocket.
+func transcriptHandler(transcript *Transcript) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := log.With().Str("remote", r.RemoteAddr).Logger()
+		ws, err := upgradeWebSocket(w, r)
+		if err != nil {
+			logger.Warn().Err(err).Msg("rejecting transcript stream")
+			return
+		}
+		defer ws.close()
+
+		events := transcript.Subscribe()
+		defer transcript.Unsubscribe(events)
+		logger.Info().Msg("opened transcript stream")
+		defer logger.Info().Msg("closed transcript stream")
+
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			ws.readUntilClosed()
+		}()
+
+		ticker := time.NewTicker(websocketPingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case event := <-events:
+				b, err := json.Marshal(event)
+				if err != nil {
+					logger.Error().Err(err).Msg("failed to encode transcript event")
+					continue
+				}
+				if err := ws.writeText(b); err != nil {
+					logger.Debug().Err(err).Msg("failed to send transcript event")
+					return
+				}
+			case <-ticker.C:
+				if err := ws.writeFrame(opcodePing, nil); err != nil {
+					return
+				}
+			case <-closed:
+				return
+			case <-r.Context().Done():
+				_ = ws.writeFrame(opcodeClose, nil)
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebSocketAccept(t *testing.T) {
+	t.Parallel()
+	// Example from RFC 6455 section 1.3.
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", websocketAccept("dGhlIHNhbXBsZSBub25jZQ=="))
+}
+
+// dialTranscript opens a WebSocket connection to the transcript stream.
+func dialTranscript(t *testing.T, server *httptest.Server, query string) (net.Conn, *bufio.Reader, *http.Response) {
+	t.Helper()
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	request := "GET /api/v1/transcript" + query + " HTTP/1.1\r\n" +
+		"Host: " + server.Listener.Addr().String() + "\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: keep-alive, Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n" +
+		"Sec-WebSocket-Version: 13\r\n\r\n"
+	_, err = conn.Write([]byte(request))
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	response, err := http.ReadResponse(reader, nil)
+	require.NoError(t, err)
+	return conn, reader, response
+}
+
+// readServerFrame reads an unmasked frame sent by the server.
+func readServerFrame(t *testing.T, conn net.Conn, reader *bufio.Reader) (byte, []byte) {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var header [2]byte
+	_, err := io.ReadFull(reader, header[:])
+	require.NoError(t, err)
+	assert.Equal(t, byte(0x80), header[0]&0x80, "frame should be final")
+	assert.Equal(t, byte(0), header[1]&0x80, "server frames must not be masked")
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var extended [2]byte
+		_, err = io.ReadFull(reader, extended[:])
+		require.NoError(t, err)
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		_, err = io.ReadFull(reader, extended[:])
+		require.NoError(t, err)
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+	payload := make([]byte, length)
+	_, err = io.ReadFull(reader, payload)
+	require.NoError(t, err)
+	return header[0] & 0x0F, payload
+}
+
+// writeClientFrame writes a masked frame, as a client would.
+func writeClientFrame(t *testing.T, conn net.Conn, opcode byte, payload []byte) {
+	t.Helper()
+	mask := [4]byte{0x12, 0x34, 0x56, 0x78}
+	frame := []byte{0x80 | opcode, 0x80 | byte(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	_, err := conn.Write(frame)
+	require.NoError(t, err)
+}
+
+func TestTranscript(t *testing.T) {
+	t.Parallel()
+	transcript := NewTranscript()
+	server := httptest.NewServer(NewServer("localhost:0", "hunter2", &mockBroadcaster{}, &mockAnnotator{}, &mockTrainer{}, &mockThreatMap{}, &mockSwitchboard{}, transcript, NewStatistics(), nil).Handler())
+	t.Cleanup(server.Close)
+
+	conn, reader, response := dialTranscript(t, server, "?token=hunter2")
+	require.Equal(t, http.StatusSwitchingProtocols, response.StatusCode)
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", response.Header.Get("Sec-WebSocket-Accept"))
+
+	// Wait for the handler to subscribe before publishing.
+	require.Eventually(t, func() bool {
+		transcript.lock.Lock()
+		defer transcript.lock.Unlock()
+		return len(transcript.subscribers) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	expected := []Event{
+		{Type: TransmissionEvent, Frequencies: []string{"251.000AM"}, Text: "anyface mobius 1 bogey dope", Confidence: 0.9},
+		{Type: RequestEvent, Request: "bogeydope", Callsign: "mobius 1", Details: map[string]any{"Callsign": "mobius 1"}},
+		{Type: ResponseEvent, Text: strings.Repeat("Group threat bullseye 090/40. ", 10), Callsign: "mobius 1"},
+	}
+	for _, event := range expected {
+		transcript.Publish(event)
+	}
+	for _, event := range expected {
+		opcode, payload := readServerFrame(t, conn, reader)
+		require.Equal(t, byte(opcodeText), opcode)
+		var actual Event
+		require.NoError(t, json.Unmarshal(payload, &actual))
+		assert.False(t, actual.Time.IsZero())
+		actual.Time = time.Time{}
+		assert.Equal(t, event, actual)
+	}
+
+	writeClientFrame(t, conn, opcodePing, []byte("hello"))
+	opcode, payload := readServerFrame(t, conn, reader)
+	assert.Equal(t, byte(opcodePong), opcode)
+	assert.Equal(t, []byte("hello"), payload)
+
+	writeClientFrame(t, conn, opcodeClose, nil)
+	opcode, _ = readServerFrame(t, conn, reader)
+	assert.Equal(t, byte(opcodeClose), opcode)
+	require.Eventually(t, func() bool {
+		transcript.lock.Lock()
+		defer transcript.lock.Unlock()
+		return len(transcript.subscribers) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestTranscriptRejected(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(NewServer("localhost:0", "hunter2", &mockBroadcaster{}, &mockAnnotator{}, &mockTrainer{}, &mockThreatMap{}, &mockSwitchboard{}, NewTranscript(), NewStatistics(), nil).Handler())
+	t.Cleanup(server.Close)
+
+	_, _, response := dialTranscript(t, server, "?token=hunter3")
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+	_, _, response = dialTranscript(t, server, "")
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+	request, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/transcript", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "Bearer hunter2")
+	plain, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer plain.Body.Close()
+	assert.Equal(t, http.StatusUpgradeRequired, plain.StatusCode)
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // SHA-1 is mandated by the WebSocket handshake, not used for security.
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// This file implements the small subset of RFC 6455 needed to push JSON messages to a browser: the opening handshake,
+// unfragmented server-to-client text frames, and the ping, pong and close control frames. Messages from the client
+// are read only to answer pings and detect disconnection.
+
+// websocketGUID is appended to the client's key to compute the handshake accept value.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	opcodeContinuation = 0x0
+	opcodeText         = 0x1
+	opcodeBinary       = 0x2
+	opcodeClose        = 0x8
+	opcodePing         = 0x9
+	opcodePong         = 0xA
+)
+
+const (
+	// maxClientMessageLength is the maximum length of a frame sent by the client. The client has no reason to send
+	// anything larger than a control frame.
+	maxClientMessageLength = 4096
+	// websocketWriteTimeout is how long a write may block before the client is considered unresponsive.
+	websocketWriteTimeout = 10 * time.Second
+)
+
+var errNotWebSocket = errors.New("not a websocket handshake")
+
+// webSocket is a server-side WebSocket connection.
+type webSocket struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	// writeLock serializes frames written by the sending goroutine and the reading goroutine.
+	writeLock sync.Mutex
+}
+
+// headerContainsToken returns true if the comma-separated header contains the given token, ignoring case.
+func headerContainsToken(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, field := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// websocketAccept computes the Sec-WebSocket-Accept value for the given Sec-WebSocket-Key.
+func websocketAccept(key string) string {
+	hash := sha1.Sum([]byte(key + websocketGUID)) //nolint:gosec // Mandated by RFC 6455.
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// upgradeWebSocket completes the WebSocket opening handshake and takes over the underlying connection. If the request
+// is not a valid handshake, an error response is written and an error is returned.
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (*webSocket, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	isUpgrade := headerContainsToken(r.Header, "Connection", "upgrade") && headerContainsToken(r.Header, "Upgrade", "websocket")
+	if !isUpgrade || key == "" {
+		http.Error(w, "websocket upgrade required", http.StatusUpgradeRequired)
+		return nil, errNotWebSocket
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusUpgradeRequired)
+		return nil, errNotWebSocket
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return nil, errors.New("response writer does not support hijacking")
+	}
+	conn, buffer, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hijack connection: %w", err)
+	}
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + websocketAccept(key) + "\r\n\r\n"
+	if _, err := buffer.WriteString(response); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to write handshake: %w", err)
+	}
+	if err := buffer.Flush(); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to write handshake: %w", err)
+	}
+	return &webSocket{conn: conn, reader: buffer.Reader}, nil
+}
+
+// writeFrame writes a single unfragmented frame.
+func (ws *webSocket) writeFrame(opcode byte, payload []byte) error {
+	ws.writeLock.Lock()
+	defer ws.writeLock.Unlock()
+
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	if err := ws.conn.SetWriteDeadline(time.Now().Add(websocketWriteTimeout)); err != nil {
+		return err
+	}
+	if _, err := ws.conn.Write(append(header, payload...)); err != nil {
+		return fmt.Errorf("failed to write frame: %w", err)
+	}
+	return nil
+}
+
+// writeText sends a text message.
+func (ws *webSocket) writeText(b []byte) error {
+	return ws.writeFrame(opcodeText, b)
+}
+
+// readFrame reads a single frame from the client and returns its opcode and unmasked payload.
+func (ws *webSocket) readFrame() (byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(ws.reader, header[:]); err != nil {
+		return 0, nil, err
+	}
+	opcode := header[0] & 0x0F
+	isMasked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var extended [2]byte
+		if _, err := io.ReadFull(ws.reader, extended[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		if _, err := io.ReadFull(ws.reader, extended[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+	if !isMasked {
+		return 0, nil, errors.New("client frames must be masked")
+	}
+	if length > maxClientMessageLength {
+		return 0, nil, errors.New("client frame is too large")
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(ws.reader, mask[:]); err != nil {
+		return 0, nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(ws.reader, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return opcode, payload, nil
+}
+
+// readUntilClosed reads frames from the client until the connection is closed, answering pings along the way.
+func (ws *webSocket) readUntilClosed() {
+	for {
+		opcode, payload, err := ws.readFrame()
+		if err != nil {
+			return
+		}
+		switch opcode {
+		case opcodeClose:
+			_ = ws.writeFrame(opcodeClose, payload)
+			return
+		case opcodePing:
+			_ = ws.writeFrame(opcodePong, payload)
+		case opcodeText, opcodeBinary, opcodeContinuation, opcodePong:
+			// Messages from the client are ignored.
+		}
+	}
+}
+
+// close closes the underlying connection.
+func (ws *webSocket) close() {
+	_ = ws.conn.Close()
+}
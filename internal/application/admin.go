@@ -0,0 +1,154 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dharmab/skyeye/internal/api"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/composer"
+	"github.com/dharmab/skyeye/pkg/encyclopedia"
+	"github.com/dharmab/skyeye/pkg/middleware"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog/log"
+)
+
+// handleAdmin carries out an admin command, records it in the audit log and responds to the caller. Commands from
+// callers who are not admins are rejected, and the attempt is recorded.
+func (a *app) handleAdmin(ctx context.Context, request *brevity.AdminRequest) {
+	logger := log.With().Str("callsign", request.Callsign).Str("command", string(request.Command)).Logger()
+	entry := api.AuditEntry{
+		Actor:  request.Callsign,
+		Remote: "srs:" + middleware.TransmitterFrom(ctx).GUID,
+		Action: string(request.Command),
+	}
+	response := brevity.AdminResponse{
+		Callsign:     request.Callsign,
+		Command:      request.Command,
+		IsAuthorized: request.IsAuthorized,
+	}
+
+	if !request.IsAuthorized {
+		logger.Warn().Msg("rejecting admin command from unauthorized caller")
+		entry.New = "unauthorized"
+	} else {
+		var err error
+		entry.Old, entry.New, err = a.runAdminCommand(request)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to carry out admin command")
+			entry.New = "failed"
+		} else {
+			logger.Info().Msg("carried out admin command")
+			response.Succeeded = true
+		}
+	}
+	a.audit.Record(entry)
+
+	for _, composed := range a.composeOnNets(a.destination(response), func(c composer.Composer) composer.NaturalLanguageResponse {
+		return c.ComposeAdminResponse(response)
+	}) {
+		composed.isAdmin = true
+		composed.callsign = request.Callsign
+		select {
+		case a.broadcasts <- composed:
+			a.publishResponse(composed, request.Callsign)
+		default:
+			logger.Warn().Msg("unable to respond to admin command because the broadcast queue is full")
+		}
+	}
+}
+
+// runAdminCommand carries out an authorized admin command. It returns the affected value before and after the
+// command, for the audit log.
+func (a *app) runAdminCommand(request *brevity.AdminRequest) (before, after any, err error) {
+	switch request.Command {
+	case brevity.MuteCommand:
+		return a.muted.Swap(true), true, nil
+	case brevity.UnmuteCommand:
+		return a.muted.Swap(false), false, nil
+	case brevity.SetBullseyeCommand:
+		_, trackfile := a.radar.ResolveCallsign(request.Callsign, a.coalition)
+		if trackfile == nil {
+			return nil, nil, fmt.Errorf("no trackfile found for %s", request.Callsign)
+		}
+		point := trackfile.LastKnown().Point
+		previous := a.setBullseyeOverride(&point)
+		a.radar.SetBullseye(point, a.coalition)
+		return previous, point, nil
+	case brevity.ResetBullseyeCommand:
+		return a.setBullseyeOverride(nil), "mission bullseye", nil
+	case brevity.ReloadCommand:
+		return nil, "reloaded", a.reloadConfiguration()
+	default:
+		return nil, nil, fmt.Errorf("unknown admin command %q", request.Command)
+	}
+}
+
+// setBullseyeOverride replaces the GCI coalition's bullseye with the given point, or restores the mission bullseye if
+// it is nil. It returns the previous bullseye for the audit log.
+func (a *app) setBullseyeOverride(point *orb.Point) any {
+	a.bullseyeLock.Lock()
+	defer a.bullseyeLock.Unlock()
+	previous := a.bullseyeOverride
+	a.bullseyeOverride = point
+	if previous == nil {
+		return "mission bullseye"
+	}
+	return *previous
+}
+
+// bullseye returns the coalition's bullseye. This is the mission bullseye, unless an admin has moved the GCI
+// coalition's bullseye.
+func (a *app) bullseye(coalition coalitions.Coalition) (orb.Point, error) {
+	if coalition == a.coalition {
+		a.bullseyeLock.RLock()
+		override := a.bullseyeOverride
+		a.bullseyeLock.RUnlock()
+		if override != nil {
+			return *override, nil
+		}
+	}
+	bullseye, err := a.tacviewClient.Bullseye(coalition)
+	if err != nil {
+		return orb.Point{}, fmt.Errorf("failed to read mission bullseye: %w", err)
+	}
+	return bullseye, nil
+}
+
+// reloadConfiguration reloads the configuration files which can be changed while the GCI is running: the composer
+// templates and the encyclopedia dataset. Other settings require a restart.
+func (a *app) reloadConfiguration() error {
+	var errs []error
+	if a.composerTemplatesFile != "" {
+		templates, err := composer.LoadTemplates(a.composerTemplatesFile)
+		if err == nil {
+			for _, c := range a.composers {
+				if err = c.SetTemplates(templates); err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to reload composer templates: %w", err))
+		} else {
+			log.Info().Str("path", a.composerTemplatesFile).Msg("reloaded composer templates")
+		}
+	}
+	if a.encyclopediaDatasetFile != "" {
+		dataset, err := encyclopedia.LoadDataset(a.encyclopediaDatasetFile)
+		if err == nil {
+			err = encyclopedia.ApplyDataset(dataset)
+		}
+		if err == nil {
+			err = encyclopedia.ApplyOverrides(a.aircraftOverrides)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to reload encyclopedia dataset: %w", err))
+		} else {
+			log.Info().Str("path", a.encyclopediaDatasetFile).Msg("reloaded encyclopedia dataset")
+		}
+	}
+	return errors.Join(errs...)
+}
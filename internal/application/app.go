@@ -5,14 +5,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/dharmab/skyeye/internal/api"
+	"github.com/dharmab/skyeye/internal/bridge"
 	"github.com/dharmab/skyeye/internal/conf"
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
 	"github.com/dharmab/skyeye/pkg/composer"
 	"github.com/dharmab/skyeye/pkg/controller"
+	"github.com/dharmab/skyeye/pkg/datalink"
+	"github.com/dharmab/skyeye/pkg/encyclopedia"
+	"github.com/dharmab/skyeye/pkg/ground"
+	"github.com/dharmab/skyeye/pkg/middleware"
 	"github.com/dharmab/skyeye/pkg/parser"
 	"github.com/dharmab/skyeye/pkg/radar"
 	"github.com/dharmab/skyeye/pkg/recognizer"
@@ -20,7 +31,13 @@ import (
 	"github.com/dharmab/skyeye/pkg/simpleradio"
 	srs "github.com/dharmab/skyeye/pkg/simpleradio/types"
 	"github.com/dharmab/skyeye/pkg/synthesizer/speakers"
+	"github.com/dharmab/skyeye/pkg/synthesizer/voices"
 	tacview "github.com/dharmab/skyeye/pkg/tacview/client"
+	"github.com/dharmab/skyeye/pkg/tacview/recorder"
+	"github.com/dharmab/skyeye/pkg/tankers"
+	"github.com/dharmab/skyeye/pkg/trackfiles/history"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
@@ -32,31 +49,129 @@ type Application interface {
 
 // app implements the Application.
 type app struct {
+	// callsign is the GCI's callsign
+	callsign string
 	// srsClient is a SimpleRadio Standalone client
 	srsClient simpleradio.Client
 	// tacviewClient streams ACMI data
 	tacviewClient tacview.Client
 	// recognizer provides speech-to-text recognition
 	recognizer recognizer.Recognizer
-	// parser converts English brevity text to internal representations
-	parser parser.Parser
+	// ensembleRecognizer recognizes low-confidence critical requests a second time. May be nil.
+	ensembleRecognizer recognizer.Recognizer
+	// ensembleThreshold is the confidence below which critical requests are recognized a second time.
+	ensembleThreshold float64
+	// ensembleRequests are the request types which are recognized a second time.
+	ensembleRequests []string
+	// parsers convert English brevity text to internal representations. Each GCI callsign has its own parser, so that
+	// the GCI on each net only answers requests addressed to its own callsign.
+	parsers map[string]parser.Parser
 	// radar tracks contacts and provides geometric computations
 	radar radar.Radar
+	// groundForces is a picture of the ground forces in the mission
+	groundForces *ground.Picture
 	// controller publishes responses and calls
 	controller controller.Controller
-	// composer converts responses and calls from internal representations to English brevity text
-	composer composer.Composer
-	// speaker provides text-to-speech synthesis
-	speaker speakers.Speaker
+	// handler applies middleware to requests before routing them to the controller
+	handler middleware.Handler
+	// inFlight tracks requests waiting for their responses to be transmitted, so that repeated requests are collapsed
+	inFlight *middleware.InFlight
+	// rateLimiter tracks each callsign's last request. This is nil if requests are not rate limited.
+	rateLimiter *middleware.RateLimiter
+	// checkIns tracks which callsigns have checked in. This is nil if check-in is not required.
+	checkIns *middleware.CheckIns
+	// composers convert responses and calls from internal representations to English brevity text. Each GCI callsign
+	// has its own composer.
+	composers map[string]composer.Composer
+	// speakers provide text-to-speech synthesis in each voice used on any frequency
+	speakers map[voices.Voice]speakers.Speaker
+	// frequencies are the SRS frequencies the GCI listens and speaks on
+	frequencies []simpleradio.RadioFrequency
+	// defaultPersona is the language, voice and callsign used on frequencies without a persona override
+	defaultPersona conf.Persona
+	// personas overrides the language, voice and callsign used on some frequencies
+	personas map[simpleradio.RadioFrequency]conf.Persona
+	// frequenciesLock protects frequencies and personas, which change when a scheduled frequency change occurs.
+	frequenciesLock sync.RWMutex
+	// frequencySchedule tracks scheduled frequency changes. This is nil if no changes are scheduled.
+	frequencySchedule *frequencySchedule
+	// callers maps callsigns to the frequency they were last heard on
+	callers sync.Map
+	// lastResponses maps callsigns to the last response composed for them, so it can be repeated on request. Values are
+	// *rememberedResponse. Responses expire after sayAgainExpiry and are forgotten when a new mission starts.
+	lastResponses sync.Map
+	// lastStandbys maps frequencies to the time of the last standby call on them.
+	lastStandbys sync.Map
 	// enableTranscriptionLogging controls whether transcriptions are included in logs
 	enableTranscriptionLogging bool
+	// timestampBroadcasts controls whether broadcast calls are prefixed with the mission time.
+	timestampBroadcasts bool
+	// scopeBroadcasts controls whether broadcast calls addressed to particular flights are transmitted only on the
+	// frequencies those flights are listening on.
+	scopeBroadcasts bool
+	// frequencyLog is the file to which a transcript of all traffic on the GCI's frequencies is written. This is nil
+	// if the frequency log is disabled.
+	frequencyLog *os.File
+	// api serves the HTTP API. This is nil if the API is disabled.
+	api *api.Server
+	// transcript publishes recognized transmissions, parsed requests and composed responses to the API's transcript
+	// stream.
+	transcript *api.Transcript
+	// broadcasts are mission-scripted messages submitted through the API, waiting to be spoken.
+	broadcasts chan composedResponse
+	// stats collects statistics about the current mission.
+	stats *api.Statistics
+	// coalition is the GCI's coalition.
+	coalition coalitions.Coalition
+	// audit records admin actions taken by voice or through the API.
+	audit *api.AuditLog
+	// subsystems records whether each subsystem is on. The map is not modified after construction, so it may be read
+	// without a lock.
+	subsystems map[api.Subsystem]*atomic.Bool
+	// muted is true while an admin has silenced the GCI. Only responses to admin commands are spoken while muted.
+	muted atomic.Bool
+	// bullseyeOverride replaces the mission bullseye for the GCI's coalition. This is nil unless an admin has moved
+	// the bullseye.
+	bullseyeOverride *orb.Point
+	// bullseyeLock protects bullseyeOverride.
+	bullseyeLock sync.RWMutex
+	// composerTemplatesFile is the path to the composer templates, reloaded by admins. Empty if not set.
+	composerTemplatesFile string
+	// encyclopediaDatasetFile is the path to the encyclopedia dataset, reloaded by admins. Empty if not set.
+	encyclopediaDatasetFile string
+	// aircraftOverrides are reapplied after the encyclopedia dataset is reloaded, so that they still take precedence.
+	aircraftOverrides []encyclopedia.Override
+	// bridge publishes events to a message bus. This is nil if no message bus is configured.
+	bridge *bridge.Bridge
+	// datalink streams the radar picture to external tools. This is nil if the datalink is disabled.
+	datalink *datalink.Server
+	// recorder records the GCI's view of each mission to a debrief file. This is nil if debrief recording is disabled.
+	recorder *recorder.Recorder
+	// history records every trackfile update to disk. This is nil if trackfile history is disabled.
+	history *history.Writer
+	// discordWebhookURL is a Discord webhook to which mission statistics are posted. Empty if disabled.
+	discordWebhookURL string
+	// starts receives mission starts from the telemetry client.
+	starts <-chan sim.Started
+	// radarStarts forwards mission starts to the radar.
+	radarStarts chan<- sim.Started
+	// launches receives weapon launches from the telemetry client.
+	launches <-chan sim.WeaponLaunched
+	// impacts receives weapon impacts from the telemetry client.
+	impacts <-chan sim.WeaponImpacted
+	// sensors receives sensor events from the telemetry client.
+	sensors <-chan sim.SensorEvent
 }
 
 // NewApplication constructs a new Application.
 func NewApplication(ctx context.Context, config conf.Configuration) (Application, error) {
 	starts := make(chan sim.Started)
+	radarStarts := make(chan sim.Started)
 	updates := make(chan sim.Updated)
 	fades := make(chan sim.Faded)
+	launches := make(chan sim.WeaponLaunched)
+	impacts := make(chan sim.WeaponImpacted)
+	sensors := make(chan sim.SensorEvent)
 
 	radios := make([]srs.Radio, 0, len(config.SRSFrequencies))
 	for _, radioFrequency := range config.SRSFrequencies {
@@ -67,6 +182,14 @@ func NewApplication(ctx context.Context, config conf.Configuration) (Application
 		})
 	}
 
+	relays := make([]srs.Relay, 0, len(config.SRSRelays))
+	for _, relay := range config.SRSRelays {
+		relays = append(relays, srs.Relay{
+			A: srs.Radio{Frequency: relay.A.Frequency.Hertz(), Modulation: relay.A.Modulation},
+			B: srs.Radio{Frequency: relay.B.Frequency.Hertz(), Modulation: relay.B.Modulation},
+		})
+	}
+
 	log.Info().
 		Str("address", config.SRSAddress).
 		Stringer("timeout", config.SRSConnectionTimeout).
@@ -75,12 +198,19 @@ func NewApplication(ctx context.Context, config conf.Configuration) (Application
 		Int("modulationID", int(srs.ModulationAM)).
 		Msg("constructing SRS client")
 	srsClient, err := simpleradio.NewClient(srs.ClientConfiguration{
-		Address:                   config.SRSAddress,
-		ConnectionTimeout:         config.SRSConnectionTimeout,
-		ClientName:                config.SRSClientName,
-		ExternalAWACSModePassword: config.SRSExternalAWACSModePassword,
-		Coalition:                 config.Coalition,
-		Radios:                    radios,
+		GUID:                        config.SRSGUID,
+		Address:                     config.SRSAddress,
+		ConnectionTimeout:           config.SRSConnectionTimeout,
+		ClientName:                  config.SRSClientName,
+		ExternalAWACSModePassword:   config.SRSExternalAWACSModePassword,
+		Coalition:                   config.Coalition,
+		Radios:                      radios,
+		Relays:                      relays,
+		RelayTone:                   config.SRSRelayTone,
+		TransmitHoldTime:            config.SRSTransmitHoldTime,
+		MaxTransmissionDuration:     config.SRSMaxTransmissionDuration,
+		LongTransmissionFrameLength: config.SRSLongFrameLength,
+		LazyDecode:                  config.SRSLazyDecode,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct application: %w", err)
@@ -95,6 +225,9 @@ func NewApplication(ctx context.Context, config conf.Configuration) (Application
 			starts,
 			updates,
 			fades,
+			launches,
+			impacts,
+			sensors,
 			config.RadarSweepInterval,
 		)
 	} else {
@@ -110,6 +243,9 @@ func NewApplication(ctx context.Context, config conf.Configuration) (Application
 			starts,
 			updates,
 			fades,
+			launches,
+			impacts,
+			sensors,
 			config.RadarSweepInterval,
 		)
 	}
@@ -118,15 +254,111 @@ func NewApplication(ctx context.Context, config conf.Configuration) (Application
 		return nil, fmt.Errorf("failed to construct application: %w", err)
 	}
 
+	// Each distinct callsign is a separate GCI which shares the radar picture with the others.
+	callsigns := []string{config.Callsign}
+	personas := make(map[simpleradio.RadioFrequency]conf.Persona, len(config.SRSFrequencyPersonas))
+	for frequency, persona := range config.SRSFrequencyPersonas {
+		if persona.Callsign == "" {
+			persona.Callsign = config.Callsign
+		}
+		personas[frequency] = persona
+		if !slices.Contains(callsigns, persona.Callsign) {
+			callsigns = append(callsigns, persona.Callsign)
+		}
+	}
+
 	log.Info().Msg("constructing speech-to-text recognizer")
-	recognizer := recognizer.NewWhisperRecognizer(config.WhisperModel, config.Callsign)
+	// The frequency log needs every transmission to be recognized, so keyword spotting cannot be used with it.
+	isKeywordSpottingEnabled := config.KeywordSpottingModel != nil && config.FrequencyLog == ""
+	withKeywordSpotting := func(r recognizer.Recognizer) recognizer.Recognizer {
+		if !isKeywordSpottingEnabled {
+			return r
+		}
+		return recognizer.NewKeywordFilter(
+			recognizer.NewWhisperRecognizer(config.KeywordSpottingModel, config.Callsign),
+			r,
+			func(text string) bool {
+				return slices.ContainsFunc(callsigns, func(callsign string) bool {
+					return parser.HasWakePhrase(text, callsign)
+				})
+			},
+		)
+	}
+	if isKeywordSpottingEnabled {
+		log.Info().Msg("enabling keyword spotting")
+	} else if config.KeywordSpottingModel != nil {
+		log.Warn().Msg("keyword spotting is disabled because the frequency log requires every transmission to be recognized")
+	}
+	var fallbackRecognizer recognizer.Recognizer
+	if config.FallbackWhisperModel != nil {
+		log.Info().Int("queueDepth", config.RecognizerFallbackQueueDepth).Msg("enabling fallback speech recognition model")
+		fallbackRecognizer = withKeywordSpotting(recognizer.NewWhisperRecognizer(config.FallbackWhisperModel, config.Callsign))
+	}
+	log.Info().
+		Int("maxConcurrency", config.RecognizerMaxConcurrency).
+		Int("maxQueue", config.RecognizerMaxQueue).
+		Msg("setting speech recognition resource budget")
+	rcgnzr := recognizer.NewBudgetedRecognizer(
+		withKeywordSpotting(recognizer.NewWhisperRecognizer(config.WhisperModel, config.Callsign)),
+		fallbackRecognizer,
+		config.RecognizerMaxConcurrency,
+		config.RecognizerMaxQueue,
+		config.RecognizerFallbackQueueDepth,
+	)
+
+	var ensembleRecognizer recognizer.Recognizer
+	if config.EnsembleWhisperModel != nil {
+		log.Info().
+			Float64("threshold", config.RecognizerEnsembleThreshold).
+			Strs("requests", config.RecognizerEnsembleRequests).
+			Msg("enabling ensemble speech recognition for critical requests")
+		ensembleRecognizer = recognizer.NewBudgetedRecognizer(
+			recognizer.NewWhisperRecognizer(config.EnsembleWhisperModel, config.Callsign),
+			nil,
+			config.RecognizerMaxConcurrency,
+			config.RecognizerMaxQueue,
+			config.RecognizerFallbackQueueDepth,
+		)
+	}
 
-	log.Info().Msg("constructing text parser")
-	parser := parser.New(config.Callsign, config.EnableTranscriptionLogging)
+	log.Info().Strs("callsigns", callsigns).Msg("constructing text parsers")
+	parsers := make(map[string]parser.Parser, len(callsigns))
+	for _, callsign := range callsigns {
+		parsers[callsign] = parser.New(callsign, config.EnableTranscriptionLogging, config.NamedAreas)
+	}
+
+	if config.EncyclopediaDataset != nil {
+		log.Info().Str("dcsVersion", config.EncyclopediaDataset.DCSVersion).Msg("applying encyclopedia dataset")
+		if err := encyclopedia.ApplyDataset(config.EncyclopediaDataset); err != nil {
+			return nil, fmt.Errorf("failed to construct application: %w", err)
+		}
+	}
+	if len(config.AircraftOverrides) > 0 {
+		log.Info().Int("count", len(config.AircraftOverrides)).Msg("applying aircraft overrides")
+		if err := encyclopedia.ApplyOverrides(config.AircraftOverrides); err != nil {
+			return nil, fmt.Errorf("failed to construct application: %w", err)
+		}
+	}
 
 	log.Info().Msg("constructing radar scope")
 
-	rdr := radar.New(config.Coalition, starts, updates, fades, config.MandatoryThreatRadius)
+	rdr := radar.New(
+		config.Coalition,
+		radarStarts,
+		updates,
+		fades,
+		config.MandatoryThreatRadius,
+		config.FadeTimeout,
+		config.TrackfileRetention,
+		config.FadedWindow,
+		config.FadedRadius,
+		config.ExcludeNonCombatants,
+		config.Terrain,
+		config.Wind,
+		config.GroupingRadii,
+		config.SimulatedSweepInterval,
+	)
+	groundForces := ground.New(config.GroundClutterFilter)
 	log.Info().Msg("constructing GCI controller")
 	controller := controller.New(
 		rdr,
@@ -134,31 +366,197 @@ func NewApplication(ctx context.Context, config conf.Configuration) (Application
 		config.Coalition,
 		config.EnableAutomaticPicture,
 		config.PictureBroadcastInterval,
+		config.QuietPictureWhenClean,
+		config.PictureMaxGroups,
+		config.EnableFullPicture,
 		config.EnableThreatMonitoring,
 		config.ThreatMonitoringInterval,
 		config.ThreatMonitoringRequiresSRS,
+		config.PackageThreats,
+		config.ColdThreats,
+		config.MEZWarningLookahead,
+		config.CommitRange,
+		config.CommitUpdateInterval,
+		config.MergeCooldown,
+		config.HVAACallsigns,
+		config.HVAAProtectionRange,
+		config.EnableTraining,
+		groundForces,
+		config.NamedAreas,
+		tankers.NewRegistry(config.Tankers...),
 	)
 
-	log.Info().Msg("constructing text composer")
-	composer := composer.New(config.Callsign)
+	log.Info().Strs("callsigns", callsigns).Msg("constructing text composers")
+	composers := make(map[string]composer.Composer, len(callsigns))
+	for _, callsign := range callsigns {
+		composers[callsign] = composer.New(callsign, config.AltitudeFormat, config.PlatformPronunciations, config.Dialect, config.ComposerTemplates, config.MaxResponseDuration)
+	}
 
-	log.Info().Msg("constructing text-to-speech synthesizer")
-	synthesizer, err := speakers.NewPiperSpeaker(config.Voice, config.PlaybackSpeed, config.PlaybackPause)
-	if err != nil {
-		return nil, fmt.Errorf("failed to construct application: %w", err)
+	log.Info().Msg("constructing text-to-speech synthesizers")
+	defaultPersona := conf.Persona{Language: recognizer.DefaultLanguage, Voice: config.Voice, Callsign: config.Callsign}
+	synthesizers := make(map[voices.Voice]speakers.Speaker)
+	for _, persona := range append([]conf.Persona{defaultPersona}, slices.Collect(maps.Values(personas))...) {
+		if _, ok := synthesizers[persona.Voice]; ok {
+			continue
+		}
+		synthesizer, err := speakers.NewPiperSpeaker(persona.Voice, config.PlaybackSpeed, config.PlaybackPause)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct application: %w", err)
+		}
+		synthesizers[persona.Voice] = synthesizer
 	}
 
 	log.Info().Msg("constructing application")
 	app := &app{
-		srsClient:     srsClient,
-		tacviewClient: tacviewClient,
-		recognizer:    recognizer,
-		parser:        parser,
-		radar:         rdr,
-		controller:    controller,
-		composer:      composer,
-		speaker:       synthesizer,
+		srsClient:               srsClient,
+		tacviewClient:           tacviewClient,
+		recognizer:              rcgnzr,
+		ensembleRecognizer:      ensembleRecognizer,
+		ensembleThreshold:       config.RecognizerEnsembleThreshold,
+		ensembleRequests:        config.RecognizerEnsembleRequests,
+		parsers:                 parsers,
+		radar:                   rdr,
+		groundForces:            groundForces,
+		controller:              controller,
+		inFlight:                middleware.NewInFlight(),
+		composers:               composers,
+		speakers:                synthesizers,
+		frequencies:             config.SRSFrequencies,
+		defaultPersona:          defaultPersona,
+		personas:                personas,
+		broadcasts:              make(chan composedResponse, maxQueuedBroadcasts),
+		transcript:              api.NewTranscript(),
+		stats:                   api.NewStatistics(),
+		coalition:               config.Coalition,
+		composerTemplatesFile:   config.ComposerTemplatesFile,
+		encyclopediaDatasetFile: config.EncyclopediaDatasetFile,
+		aircraftOverrides:       config.AircraftOverrides,
+		starts:                  starts,
+		radarStarts:             radarStarts,
+		launches:                launches,
+		impacts:                 impacts,
+		sensors:                 sensors,
+		callsign:                config.Callsign,
+		timestampBroadcasts:     config.TimestampBroadcasts,
+		scopeBroadcasts:         config.ScopeBroadcasts,
+		subsystems:              make(map[api.Subsystem]*atomic.Bool, len(api.AllSubsystems)),
+	}
+	for _, subsystem := range api.AllSubsystems {
+		app.subsystems[subsystem] = &atomic.Bool{}
+		app.subsystems[subsystem].Store(true)
+	}
+	if len(config.SRSFrequencyChanges) > 0 {
+		app.frequencySchedule = &frequencySchedule{
+			changes: config.SRSFrequencyChanges,
+			warning: config.SRSFrequencyChangeWarning,
+		}
+	}
+	if config.FrequencyLog != "" {
+		log.Info().Str("path", config.FrequencyLog).Msg("opening frequency log")
+		file, err := os.OpenFile(config.FrequencyLog, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open frequency log: %w", err)
+		}
+		app.frequencyLog = file
+	}
+	app.audit = api.NewAuditLog()
+	if config.APIAuditLog != "" {
+		audit, err := api.OpenAuditLog(config.APIAuditLog)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct application: %w", err)
+		}
+		app.audit = audit
+	}
+	if config.Offline && config.DiscordWebhookURL != "" {
+		log.Warn().Msg("discord-webhook-url is ignored in offline mode")
+	} else {
+		app.discordWebhookURL = config.DiscordWebhookURL
+	}
+	if config.Offline && config.BridgeURL != "" {
+		log.Warn().Msg("bridge-url is ignored in offline mode")
+	} else if config.BridgeURL != "" {
+		log.Info().Msg("constructing message bus bridge")
+		clientID := "skyeye-" + strings.ReplaceAll(strings.ToLower(config.Callsign), " ", "-")
+		messageBridge, err := bridge.New(config.BridgeURL, config.BridgeTopicPrefix, clientID, app.transcript, app)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct application: %w", err)
+		}
+		app.bridge = messageBridge
+	}
+	if config.DatalinkAddress != "" {
+		log.Info().Str("address", config.DatalinkAddress).Msg("constructing datalink server")
+		app.datalink = datalink.NewServer(config.DatalinkAddress, config.DatalinkToken, config.DatalinkInterval, config.DatalinkKeyframeInterval, app.radar)
+	}
+	if config.DebriefDirectory != "" {
+		log.Info().Str("directory", config.DebriefDirectory).Msg("constructing debrief recorder")
+		if err := os.MkdirAll(config.DebriefDirectory, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create debrief directory: %w", err)
+		}
+		app.recorder = recorder.New(config.DebriefDirectory, config.DebriefInterval, app.radar)
+	}
+	if config.HistoryDirectory != "" {
+		log.Info().Str("directory", config.HistoryDirectory).Msg("constructing trackfile history writer")
+		if err := os.MkdirAll(config.HistoryDirectory, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create trackfile history directory: %w", err)
+		}
+		app.history = history.New(config.HistoryDirectory, config.HistoryMaxFileSize, config.HistoryMaxTotalSize)
+		app.radar.SetUpdatedCallback(app.history.Record)
+	}
+	if config.APIAddress != "" {
+		log.Info().Str("address", config.APIAddress).Msg("constructing API server")
+		app.api = api.NewServer(config.APIAddress, config.APIToken, app, app, app, app, app, app.transcript, app.stats, app.audit)
 	}
+
+	policies := []middleware.Middleware{
+		middleware.BindTransmitters(
+			func(callsign string) bool {
+				_, trackfile := app.radar.FindCallsign(callsign, config.Coalition)
+				return trackfile != nil
+			},
+			func(id uint64) (string, bool) {
+				trackfile := app.radar.FindUnit(id)
+				if trackfile == nil || trackfile.Contact.Coalition != config.Coalition {
+					return "", false
+				}
+				return trackfile.Contact.Name, true
+			},
+		),
+		middleware.Metrics(),
+	}
+	if len(config.AdminCallsigns) > 0 || len(config.AdminSRSGUIDs) > 0 {
+		log.Info().Strs("callsigns", config.AdminCallsigns).Int("guids", len(config.AdminSRSGUIDs)).Msg("permitting admin commands by voice")
+		policies = append(policies, middleware.AuthorizeAdmins(config.AdminCallsigns, config.AdminSRSGUIDs))
+	}
+	if len(config.BlockedCallsignWords) > 0 {
+		log.Info().Int("count", len(config.BlockedCallsignWords)).Msg("blocking requests from callsigns containing blocked words")
+		policies = append(policies, middleware.BlockCallsigns(config.BlockedCallsignWords...))
+	}
+	if config.RequestRateLimit > 0 {
+		log.Info().Stringer("interval", config.RequestRateLimit).Msg("rate limiting requests")
+		app.rateLimiter = middleware.NewRateLimiter(config.RequestRateLimit)
+		policies = append(policies, middleware.RateLimit(app.rateLimiter))
+	}
+	if len(config.RequireCheckIn) > 0 {
+		log.Info().Strs("requests", config.RequireCheckIn).Msg("requiring check-in before handling requests")
+		app.checkIns = middleware.NewCheckIns()
+		policies = append(policies, middleware.RequireCheckIn(app.checkIns, config.RequireCheckIn...))
+	}
+	if config.RecognizerConfidenceThreshold > 0 || len(config.RecognizerConfidenceThresholds) > 0 {
+		log.Info().
+			Float64("threshold", config.RecognizerConfidenceThreshold).
+			Any("overrides", config.RecognizerConfidenceThresholds).
+			Msg("requiring confirmation of low-confidence requests")
+		policies = append(policies, middleware.RequireConfidence(config.RecognizerConfidenceThreshold, config.RecognizerConfidenceThresholds))
+	}
+	policies = append(policies, middleware.CollapseDuplicates(app.inFlight, func(callsign string) string {
+		// Responses are addressed to the callsign of the matching trackfile, which may differ from what was said.
+		if foundCallsign, trackfile := app.radar.FindCallsign(callsign, config.Coalition); trackfile != nil {
+			return foundCallsign
+		}
+		return callsign
+	}))
+	app.handler = middleware.Chain(app.route, policies...)
+
 	return app, nil
 }
 
@@ -189,14 +587,20 @@ func (a *app) Run(ctx context.Context, cancel context.CancelFunc, wg *sync.WaitG
 			case <-ticker.C:
 				missionTime := a.tacviewClient.Time()
 				a.radar.SetMissionTime(missionTime)
+				a.updateFrequencies(missionTime)
 				for _, coalition := range []coalitions.Coalition{coalitions.Red, coalitions.Blue} {
-					bullseye, err := a.tacviewClient.Bullseye(coalition)
+					bullseye, err := a.bullseye(coalition)
 					if err != nil {
 						log.Warn().Err(err).Msg("error reading bullseye")
 					} else {
 						a.radar.SetBullseye(bullseye, coalition)
 					}
 				}
+				groundUnits := a.tacviewClient.GroundUnits()
+				a.groundForces.Update(groundUnits)
+				a.radar.SetAirDefenses(groundUnits)
+				a.controller.TrackEjections(a.tacviewClient.Ejections())
+				a.updateKills()
 			}
 		}
 	}()
@@ -213,13 +617,99 @@ func (a *app) Run(ctx context.Context, cancel context.CancelFunc, wg *sync.WaitG
 		}
 	}()
 
-	rxTextChan := make(chan string)
-	requestChan := make(chan any)
+	if a.api != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := a.api.Run(ctx, wg); err != nil {
+				log.Error().Err(err).Msg("error running API server")
+			}
+		}()
+	}
+
+	if a.bridge != nil {
+		log.Info().Msg("starting message bus bridge")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.bridge.Run(ctx)
+		}()
+	}
+
+	if a.datalink != nil {
+		log.Info().Msg("starting datalink server")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := a.datalink.Run(ctx, wg); err != nil {
+				log.Error().Err(err).Msg("error running datalink server")
+			}
+		}()
+	}
+
+	if a.recorder != nil {
+		log.Info().Msg("starting debrief recorder")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.recorder.Run(ctx)
+		}()
+	}
+
+	if a.history != nil {
+		log.Info().Msg("starting trackfile history writer")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.history.Run(ctx)
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		if missing := encyclopedia.MissingAircraft(); len(missing) > 0 {
+			log.Warn().Interface("missing", missing).Msg("some aircraft were missing from the encyclopedia; add them to an encyclopedia dataset to support them")
+		}
+	}()
+
+	rxTextChan := make(chan transcript)
+	requestChan := make(chan parsedRequest)
 	responseAndCallsChan := make(chan any)
-	txTextChan := make(chan composer.NaturalLanguageResponse)
-	txAudioChan := make(chan []float32)
+	txTextChan := make(chan composedResponse)
+	txAudioChan := make(chan synthesizedResponse)
 
 	log.Info().Msg("starting subroutines")
+	if a.frequencyLog != nil {
+		log.Info().Msg("starting frequency log routine")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := a.transcript.WriteFrequencyLog(ctx, a.frequencyLog, a.callsign); err != nil {
+				log.Error().Err(err).Msg("error writing frequency log")
+			}
+			if err := a.frequencyLog.Close(); err != nil {
+				log.Error().Err(err).Msg("error closing frequency log")
+			}
+		}()
+	}
+	log.Info().Msg("starting mission statistics routine")
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.stats.Collect(ctx, a.transcript)
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.trackMissions(ctx)
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.monitorWeapons(ctx)
+	}()
 	log.Info().Msg("starting speech recognition routine")
 	wg.Add(1)
 	go func() {
@@ -266,57 +756,258 @@ func (a *app) Run(ctx context.Context, cancel context.CancelFunc, wg *sync.WaitG
 	return nil
 }
 
+// transcript is text recognized from a transmission.
+type transcript struct {
+	text string
+	// confidence is the recognizer's confidence in the text, from 0 to 1.
+	confidence float64
+	// frequency the transmission was received on.
+	frequency simpleradio.RadioFrequency
+	// transmitter is the SRS client which transmitted.
+	transmitter middleware.Transmitter
+}
+
+// parsedRequest is a brevity request parsed from a transcript.
+type parsedRequest struct {
+	request any
+	// confidence is the recognizer's confidence in the transcript the request was parsed from, from 0 to 1.
+	confidence float64
+	// frequency the request was received on.
+	frequency simpleradio.RadioFrequency
+	// transmitter is the SRS client which transmitted the request.
+	transmitter middleware.Transmitter
+}
+
+// composedResponse is a natural language response to transmit.
+type composedResponse struct {
+	composer.NaturalLanguageResponse
+	// frequencies to transmit on, or nil to transmit on all frequencies.
+	frequencies []simpleradio.RadioFrequency
+	// isAdmin is true for responses to admin commands, which are spoken even while the GCI is muted.
+	isAdmin bool
+	// call is the type of the call, such as "threat". Empty for responses which are not brevity calls.
+	call string
+	// callsign of the caller this responds to. Empty for broadcasts.
+	callsign string
+}
+
+// synthesizedResponse is spoken audio to transmit.
+type synthesizedResponse struct {
+	audio []float32
+	// frequencies to transmit on.
+	frequencies []simpleradio.RadioFrequency
+	// callsign of the caller this answers, set on the final part of a response. Empty for broadcasts.
+	callsign string
+}
+
+// persona returns the language and voice used on the given frequency.
+func (a *app) persona(frequency simpleradio.RadioFrequency) conf.Persona {
+	a.frequenciesLock.RLock()
+	defer a.frequenciesLock.RUnlock()
+	for f, persona := range a.personas {
+		if f.IsSameFrequency(frequency) {
+			return persona
+		}
+	}
+	return a.defaultPersona
+}
+
+// net returns all frequencies which share a persona with the given frequency.
+func (a *app) net(frequency simpleradio.RadioFrequency) []simpleradio.RadioFrequency {
+	persona := a.persona(frequency)
+	frequencies := a.currentFrequencies()
+	net := make([]simpleradio.RadioFrequency, 0, len(frequencies))
+	for _, f := range frequencies {
+		if a.persona(f) == persona {
+			net = append(net, f)
+		}
+	}
+	return net
+}
+
+// callsignNets groups the given frequencies by the GCI callsign used on each. If no frequencies are given, every
+// frequency is grouped. If every frequency uses the same callsign, the frequencies are returned unchanged, so that a
+// response to every frequency is still transmitted on whichever frequencies are current when it is spoken.
+func (a *app) callsignNets(frequencies []simpleradio.RadioFrequency) map[string][]simpleradio.RadioFrequency {
+	all := frequencies
+	if len(all) == 0 {
+		all = a.currentFrequencies()
+	}
+	nets := make(map[string][]simpleradio.RadioFrequency)
+	for _, frequency := range all {
+		callsign := a.persona(frequency).Callsign
+		nets[callsign] = append(nets[callsign], frequency)
+	}
+	if len(nets) > 1 {
+		return nets
+	}
+	callsign := a.defaultPersona.Callsign
+	for c := range nets {
+		callsign = c
+	}
+	return map[string][]simpleradio.RadioFrequency{callsign: frequencies}
+}
+
+// parserOn returns the parser for the GCI callsign used on the given frequency.
+func (a *app) parserOn(frequency simpleradio.RadioFrequency) parser.Parser {
+	return a.parsers[a.persona(frequency).Callsign]
+}
+
+// maxQueuedTransmissions is the maximum number of each SRS client's transmissions waiting to be recognized.
+const maxQueuedTransmissions = 4
+
+// transmissionQueueIdleTimeout is how long an SRS client's transmission queue is kept after its last transmission.
+const transmissionQueueIdleTimeout = time.Minute
+
 // recognize runs speech recognition on audio received from SRS and forwards recognized text to the given channel.
-func (a *app) recognize(ctx context.Context, out chan<- string) {
+// Transmissions from different SRS clients are recognized concurrently, within the recognizer's resource budget, but
+// each client's transmissions are recognized one at a time so that their requests are answered in the order they were
+// made.
+func (a *app) recognize(ctx context.Context, out chan<- transcript) {
+	queues := make(map[srs.GUID]chan simpleradio.Transmission)
+	idle := make(chan srs.GUID)
 	for {
 		select {
 		case <-ctx.Done():
 			log.Info().Msg("stopping speech recognition due to context cancellation")
 			return
-		case sample := <-a.srsClient.Receive():
-			a.recognizeSample(ctx, sample, out)
+		case guid := <-idle:
+			// A transmission may have been queued while the worker was reporting itself idle, in which case the
+			// worker carries on.
+			if queue, ok := queues[guid]; ok && len(queue) == 0 {
+				close(queue)
+				delete(queues, guid)
+			}
+		case transmission := <-a.srsClient.Receive():
+			if !a.Subsystem(api.Recognition) {
+				log.Debug().Stringer("frequency", transmission.Frequency).Msg("ignoring audio sample because speech recognition is turned off")
+				continue
+			}
+			queue, ok := queues[transmission.ClientGUID]
+			if !ok {
+				queue = make(chan simpleradio.Transmission, maxQueuedTransmissions)
+				queues[transmission.ClientGUID] = queue
+				go a.recognizeQueue(ctx, transmission.ClientGUID, queue, idle, out)
+			}
+			select {
+			case queue <- transmission:
+			default:
+				log.Warn().Stringer("frequency", transmission.Frequency).Str("transmitter", transmission.ClientName).Msg("discarding audio sample because too many transmissions from this client are waiting to be recognized")
+				a.standby(transmission.Frequency)
+			}
+		}
+	}
+}
+
+// recognizeQueue recognizes one SRS client's transmissions in order. When the queue has been empty for a while, the
+// worker reports itself idle and exits once the queue is closed.
+func (a *app) recognizeQueue(ctx context.Context, guid srs.GUID, queue <-chan simpleradio.Transmission, idle chan<- srs.GUID, out chan<- transcript) {
+	timer := time.NewTimer(transmissionQueueIdleTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case transmission, ok := <-queue:
+			if !ok {
+				return
+			}
+			a.recognizeSample(ctx, transmission, out)
+			timer.Reset(transmissionQueueIdleTimeout)
+		case <-timer.C:
+			select {
+			case idle <- guid:
+			case <-ctx.Done():
+				return
+			}
+			timer.Reset(transmissionQueueIdleTimeout)
 		}
 	}
 }
 
-func (a *app) recognizeSample(ctx context.Context, sample simpleradio.Audio, out chan<- string) {
+func (a *app) recognizeSample(ctx context.Context, transmission simpleradio.Transmission, out chan<- transcript) {
 	recogCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
-	log.Info().Msg("recognizing audio sample")
+	language := a.persona(transmission.Frequency).Language
+	recogCtx = recognizer.WithLanguage(recogCtx, language)
+	audio := transmission.Decode()
+	if len(audio) == 0 {
+		log.Debug().Stringer("frequency", transmission.Frequency).Msg("decoded audio sample is empty")
+		return
+	}
+	log.Info().Stringer("frequency", transmission.Frequency).Str("language", language).Msg("recognizing audio sample")
 	start := time.Now()
-	text, err := a.recognizer.Recognize(recogCtx, sample, a.enableTranscriptionLogging)
-	logger := log.With().Stringer("clockTime", time.Since(start)).Logger()
+	recognized, err := a.recognizer.Recognize(recogCtx, audio, a.enableTranscriptionLogging)
+	if err == nil {
+		recognized = a.reconsider(recogCtx, transmission.Frequency, audio, recognized)
+	}
+	logger := log.With().Stringer("clockTime", time.Since(start)).Float64("confidence", recognized.Confidence).Logger()
 
-	if err != nil {
+	if errors.Is(err, recognizer.ErrOverloaded) {
+		logger.Warn().Msg("discarded audio sample because speech recognition is overloaded")
+		a.standby(transmission.Frequency)
+		return
+	} else if err != nil {
 		log.Error().Err(err).Msg("error recognizing audio sample")
 	} else if a.enableTranscriptionLogging {
-		logger = logger.With().Str("text", text).Logger()
+		logger = logger.With().Str("text", recognized.Text).Logger()
 	}
-	if text == "" {
+	if recognized.Text == "" {
 		logger.Info().Msg("unable to recognize any words in audio sample")
 	} else {
 		logger.Info().Msg("recognized audio")
-		out <- text
+		a.transcript.Publish(api.Event{
+			Type:        api.TransmissionEvent,
+			Frequencies: []string{transmission.Frequency.String()},
+			Text:        recognized.Text,
+			Confidence:  recognized.Confidence,
+			Transmitter: transmission.ClientName,
+		})
+		if a.recorder != nil {
+			a.recorder.Heard(recognized.Text)
+		}
+		out <- transcript{
+			text:       recognized.Text,
+			confidence: recognized.Confidence,
+			frequency:  transmission.Frequency,
+			transmitter: middleware.Transmitter{
+				GUID:   string(transmission.ClientGUID),
+				Name:   transmission.ClientName,
+				UnitID: transmission.UnitID,
+			},
+		}
 	}
 }
 
 // parse converts incoming brevity from text format to internal representations.
-func (a *app) parse(ctx context.Context, in <-chan string, out chan<- any) {
+func (a *app) parse(ctx context.Context, in <-chan transcript, out chan<- parsedRequest) {
 	for {
 		select {
 		case <-ctx.Done():
 			log.Info().Msg("stopping text parsing due to context cancellation")
 			return
-		case text := <-in:
-			logger := log.Logger
+		case transcript := <-in:
+			logger := log.With().Stringer("frequency", transcript.frequency).Logger()
 			if a.enableTranscriptionLogging {
-				logger = logger.With().Str("text", text).Logger()
+				logger = logger.With().Str("text", transcript.text).Logger()
 			}
 			logger.Info().Msg("parsing text")
-			request := a.parser.Parse(text)
+			request := a.parserOn(transcript.frequency).Parse(transcript.text)
 			if request != nil {
 				logger.Info().Any("request", request).Msg("parsed text")
-				out <- request
+				a.transcript.Publish(api.Event{
+					Type:        api.RequestEvent,
+					Frequencies: []string{transcript.frequency.String()},
+					Request:     middleware.RequestType(request),
+					Callsign:    middleware.Callsign(request),
+					Details:     request,
+				})
+				out <- parsedRequest{
+					request:     request,
+					confidence:  transcript.confidence,
+					frequency:   transcript.frequency,
+					transmitter: transcript.transmitter,
+				}
 			} else {
 				logger.Info().Msg("unable to parse text, could be silence, chatter, missing GCI callsign")
 			}
@@ -325,7 +1016,7 @@ func (a *app) parse(ctx context.Context, in <-chan string, out chan<- any) {
 }
 
 // control routes requests to GCI controller handlers.
-func (a *app) control(ctx context.Context, wg *sync.WaitGroup, in <-chan any, out chan<- any) {
+func (a *app) control(ctx context.Context, wg *sync.WaitGroup, in <-chan parsedRequest, out chan<- any) {
 	log.Info().Msg("running controller")
 	wg.Add(1)
 	go func() {
@@ -337,46 +1028,106 @@ func (a *app) control(ctx context.Context, wg *sync.WaitGroup, in <-chan any, ou
 		case <-ctx.Done():
 			log.Info().Msg("stopping controller request routing due to context cancellation")
 			return
-		case brev := <-in:
-			logger := log.With().Type("type", brev).Logger()
-			logger.Info().Msg("routing request to controller")
-			switch request := brev.(type) {
-			case *brevity.AlphaCheckRequest:
-				logger.Debug().Msg("routing ALPHA CHECK request to controller")
-				a.controller.HandleAlphaCheck(request)
-			case *brevity.BogeyDopeRequest:
-				logger.Debug().Msg("routing BOGEY DOPE request to controller")
-				a.controller.HandleBogeyDope(request)
-			case *brevity.DeclareRequest:
-				logger.Debug().Msg("routing DECLARE request to controller")
-				a.controller.HandleDeclare(request)
-			case *brevity.PictureRequest:
-				logger.Debug().Msg("routing PICTURE request to controller")
-				a.controller.HandlePicture(request)
-			case *brevity.RadioCheckRequest:
-				logger.Debug().Msg("routing RADIO CHECK request to controller")
-				a.controller.HandleRadioCheck(request)
-			case *brevity.SnaplockRequest:
-				logger.Debug().Msg("routing SNAPLOCK request to controller")
-				a.controller.HandleSnaplock(request)
-			case *brevity.SpikedRequest:
-				logger.Debug().Msg("routing SPIKED request to controller")
-				a.controller.HandleSpiked(request)
-			case *brevity.TripwireRequest:
-				logger.Debug().Msg("routing TRIPWIRE request to controller")
-				a.controller.HandleTripwire(request)
-			case *brevity.UnableToUnderstandRequest:
-				logger.Debug().Msg("routing unable to understand request to controller")
-				a.controller.HandleUnableToUnderstand(request)
-			default:
-				logger.Error().Any("request", brev).Msg("unable to route request to handler")
+		case parsed := <-in:
+			requestCtx := middleware.WithConfidence(ctx, parsed.confidence)
+			requestCtx = middleware.WithTransmitter(requestCtx, parsed.transmitter)
+			requestCtx = context.WithValue(requestCtx, frequencyKey{}, parsed.frequency)
+			if parsed.transmitter.UnitID != 0 {
+				// If the caller's callsign is shared by other aircraft, prefer the aircraft the caller's SRS client is
+				// bound to.
+				a.radar.BindUnit(parsed.transmitter.UnitID)
 			}
+			a.handler(requestCtx, parsed.request)
+		}
+	}
+}
+
+// frequencyKey is the context key for the frequency a request was received on.
+type frequencyKey struct{}
+
+// route routes a request to the appropriate controller handler.
+func (a *app) route(ctx context.Context, brev any) {
+	logger := log.With().Type("type", brev).Logger()
+	if frequency, ok := ctx.Value(frequencyKey{}).(simpleradio.RadioFrequency); ok {
+		if callsign := middleware.Callsign(brev); callsign != "" {
+			// Remember where we heard the caller so we can respond on the same net. This is done after middleware
+			// which may have corrected the callsign.
+			a.callers.Store(callsign, frequency)
 		}
 	}
+	logger.Info().Msg("routing request to controller")
+	switch request := brev.(type) {
+	case *brevity.AlphaCheckRequest:
+		logger.Debug().Msg("routing ALPHA CHECK request to controller")
+		a.controller.HandleAlphaCheck(request)
+	case *brevity.BogeyDopeRequest:
+		logger.Debug().Msg("routing BOGEY DOPE request to controller")
+		a.controller.HandleBogeyDope(request)
+	case *brevity.ConfirmRequest:
+		logger.Debug().Msg("routing low-confidence request to controller for confirmation")
+		a.controller.HandleConfirm(request)
+	case *brevity.DeclareRequest:
+		logger.Debug().Msg("routing DECLARE request to controller")
+		a.controller.HandleDeclare(request)
+	case *brevity.PictureRequest:
+		logger.Debug().Msg("routing PICTURE request to controller")
+		a.controller.HandlePicture(request)
+	case *brevity.RadioCheckRequest:
+		logger.Debug().Msg("routing RADIO CHECK request to controller")
+		a.controller.HandleRadioCheck(request)
+	case *brevity.SnaplockRequest:
+		logger.Debug().Msg("routing SNAPLOCK request to controller")
+		a.controller.HandleSnaplock(request)
+	case *brevity.SpikedRequest:
+		logger.Debug().Msg("routing SPIKED request to controller")
+		a.controller.HandleSpiked(request)
+	case *brevity.StatusRequest:
+		logger.Debug().Msg("routing STATUS request to controller")
+		a.controller.HandleStatus(request)
+	case *brevity.TripwireRequest:
+		logger.Debug().Msg("routing TRIPWIRE request to controller")
+		a.controller.HandleTripwire(request)
+	case *brevity.GroundDopeRequest:
+		logger.Debug().Msg("routing TROOPS IN CONTACT request to controller")
+		a.controller.HandleGroundDope(request)
+	case *brevity.SurvivorRequest:
+		logger.Debug().Msg("routing survivor request to controller")
+		a.controller.HandleSurvivor(request)
+	case *brevity.TankerRequest:
+		logger.Debug().Msg("routing tanker request to controller")
+		a.controller.HandleTanker(request)
+	case *brevity.TrainingRequest:
+		logger.Debug().Msg("routing TRAINING request to controller")
+		a.controller.HandleTraining(request)
+	case *brevity.MuteRequest:
+		logger.Debug().Msg("routing mute request to controller")
+		a.controller.HandleMute(request)
+	case *brevity.GameplanRequest:
+		logger.Debug().Msg("routing GAMEPLAN request to controller")
+		a.controller.HandleGameplan(request)
+	case *brevity.SightingRequest:
+		logger.Debug().Msg("routing sighting report to controller")
+		a.controller.HandleSighting(request)
+	case *brevity.SayAgainRequest:
+		logger.Debug().Msg("repeating last response")
+		a.handleSayAgain(request)
+	case *brevity.HealthRequest:
+		logger.Debug().Msg("reporting health")
+		a.handleHealth(ctx)
+	case *brevity.AdminRequest:
+		logger.Debug().Msg("handling admin command")
+		a.handleAdmin(ctx, request)
+	case *brevity.UnableToUnderstandRequest:
+		logger.Debug().Msg("routing unable to understand request to controller")
+		a.controller.HandleUnableToUnderstand(request)
+	default:
+		logger.Error().Any("request", brev).Msg("unable to route request to handler")
+	}
 }
 
-// compose converts outgoing brevity from internal representations to text format.
-func (a *app) compose(ctx context.Context, in <-chan any, out chan<- composer.NaturalLanguageResponse) {
+// compose converts outgoing brevity from internal representations to text format. Calls to every net are composed
+// separately for each GCI callsign.
+func (a *app) compose(ctx context.Context, in <-chan any, out chan<- composedResponse) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -384,76 +1135,243 @@ func (a *app) compose(ctx context.Context, in <-chan any, out chan<- composer.Na
 			return
 		case call := <-in:
 			logger := log.With().Type("type", call).Any("params", call).Logger()
-			logger.Info().Msg("composing brevity call")
-			var response composer.NaturalLanguageResponse
-			switch c := call.(type) {
-			case brevity.AlphaCheckResponse:
-				logger.Debug().Msg("composing ALPHA CHECK call")
-				response = a.composer.ComposeAlphaCheckResponse(c)
-			case brevity.BogeyDopeResponse:
-				logger.Debug().Msg("composing BOGEY DOPE call")
-				response = a.composer.ComposeBogeyDopeResponse(c)
-			case brevity.DeclareResponse:
-				logger.Debug().Msg("composing DECLARE call")
-				response = a.composer.ComposeDeclareResponse(c)
-			case brevity.FadedCall:
-				logger.Debug().Msg("composing FADED call")
-				response = a.composer.ComposeFadedCall(c)
-			case brevity.NegativeRadarContactResponse:
-				logger.Debug().Msg("composing NEGATIVE RADAR CONTACT call")
-				response = a.composer.ComposeNegativeRadarContactResponse(c)
-			case brevity.PictureResponse:
-				logger.Debug().Msg("composing PICTURE call")
-				response = a.composer.ComposePictureResponse(c)
-			case brevity.RadioCheckResponse:
-				logger.Debug().Msg("composing RADIO CHECK call")
-				response = a.composer.ComposeRadioCheckResponse(c)
-			case brevity.SnaplockResponse:
-				logger.Debug().Msg("composing SNAPLOCK call")
-				response = a.composer.ComposeSnaplockResponse(c)
-			case brevity.SpikedResponse:
-				logger.Debug().Msg("composing SPIKED call")
-				response = a.composer.ComposeSpikedResponse(c)
-			case brevity.TripwireResponse:
-				logger.Debug().Msg("composing TRIPWIRE call")
-				response = a.composer.ComposeTripwireResponse(c)
-			case brevity.SunriseCall:
-				logger.Debug().Msg("composing SUNRISE call")
-				response = a.composer.ComposeSunriseCall(c)
-			case brevity.ThreatCall:
-				logger.Debug().Msg("composing THREAT call")
-				response = a.composer.ComposeThreatCall(c)
-			case brevity.MergedCall:
-				logger.Debug().Msg("composing MERGED call")
-				response = a.composer.ComposeMergedCall(c)
-			case brevity.SayAgainResponse:
-				logger.Debug().Msg("composing SAY AGAIN call")
-				response = a.composer.ComposeSayAgainResponse(c)
-			default:
-				logger.Debug().Msg("unable to route call to composition")
+			if isBroadcast(call) && !a.Subsystem(api.Broadcasts) {
+				logger.Info().Msg("not composing brevity call because broadcasts are turned off")
+				continue
 			}
-
-			if response.Speech == "" && response.Subtitle == "" {
-				logger.Warn().Msg("natural language response is empty")
-			} else {
-				logger.Info().Str("speech", response.Speech).Str("subtitle", response.Subtitle).Msg("composed brevity call")
-				out <- response
+			frequencies := a.destination(call)
+			if isBroadcast(call) && a.scopeBroadcasts {
+				var ok bool
+				frequencies, ok = a.scopedFrequencies(addressees(call))
+				if !ok {
+					logger.Info().Msg("not composing brevity call because none of the flights it addresses are on frequency")
+					continue
+				}
+			}
+			logger.Info().Msg("composing brevity call")
+			responses := a.composeOnNets(frequencies, func(c composer.Composer) composer.NaturalLanguageResponse {
+				response := composeCall(&logger, c, call)
+				if isBroadcast(call) {
+					response = a.timestamp(response)
+				}
+				return response
+			})
+			for _, composed := range responses {
+				if composed.Speech == "" && composed.Subtitle == "" {
+					logger.Warn().Msg("natural language response is empty")
+					continue
+				}
+				logger.Info().Str("speech", composed.Speech).Str("subtitle", composed.Subtitle).Msg("composed brevity call")
+				composed.call = middleware.CallType(call)
+				composed.callsign = middleware.Callsign(call)
+				a.publishResponse(composed, middleware.Callsign(call))
+				a.rememberResponse(call, middleware.Callsign(call), composed)
+				out <- composed
 			}
 		}
 	}
 }
 
-// synthesize converts outgoing text to spoken audio.
-func (a *app) synthesize(ctx context.Context, in <-chan composer.NaturalLanguageResponse, out chan<- []float32) {
+// composeCall converts a response or call to text with the given composer.
+func composeCall(logger *zerolog.Logger, c composer.Composer, call any) composer.NaturalLanguageResponse {
+	switch call := call.(type) {
+	case brevity.AlphaCheckResponse:
+		logger.Debug().Msg("composing ALPHA CHECK call")
+		return c.ComposeAlphaCheckResponse(call)
+	case brevity.BogeyDopeResponse:
+		logger.Debug().Msg("composing BOGEY DOPE call")
+		return c.ComposeBogeyDopeResponse(call)
+	case brevity.DeclareResponse:
+		logger.Debug().Msg("composing DECLARE call")
+		return c.ComposeDeclareResponse(call)
+	case brevity.FadedCall:
+		logger.Debug().Msg("composing FADED call")
+		return c.ComposeFadedCall(call)
+	case brevity.NegativeRadarContactResponse:
+		logger.Debug().Msg("composing NEGATIVE RADAR CONTACT call")
+		return c.ComposeNegativeRadarContactResponse(call)
+	case brevity.PictureResponse:
+		logger.Debug().Msg("composing PICTURE call")
+		return c.ComposePictureResponse(call)
+	case brevity.RadioCheckResponse:
+		logger.Debug().Msg("composing RADIO CHECK call")
+		return c.ComposeRadioCheckResponse(call)
+	case brevity.SnaplockResponse:
+		logger.Debug().Msg("composing SNAPLOCK call")
+		return c.ComposeSnaplockResponse(call)
+	case brevity.SpikedResponse:
+		logger.Debug().Msg("composing SPIKED call")
+		return c.ComposeSpikedResponse(call)
+	case brevity.StatusResponse:
+		logger.Debug().Msg("composing STATUS call")
+		return c.ComposeStatusResponse(call)
+	case brevity.TripwireResponse:
+		logger.Debug().Msg("composing TRIPWIRE call")
+		return c.ComposeTripwireResponse(call)
+	case brevity.GroundDopeResponse:
+		logger.Debug().Msg("composing TROOPS IN CONTACT call")
+		return c.ComposeGroundDopeResponse(call)
+	case brevity.SurvivorCall:
+		logger.Debug().Msg("composing ejection alert")
+		return c.ComposeSurvivorCall(call)
+	case brevity.ThreatRingCall:
+		logger.Debug().Msg("composing threat ring call")
+		return c.ComposeThreatRingCall(call)
+	case brevity.MEZWarningCall:
+		logger.Debug().Msg("composing MEZ warning")
+		return c.ComposeMEZWarningCall(call)
+	case brevity.DuplicateCallsignCall:
+		logger.Debug().Msg("composing duplicate callsign call")
+		return c.ComposeDuplicateCallsignCall(call)
+	case brevity.SurvivorResponse:
+		logger.Debug().Msg("composing survivor vector")
+		return c.ComposeSurvivorResponse(call)
+	case brevity.TankerResponse:
+		logger.Debug().Msg("composing tanker vector")
+		return c.ComposeTankerResponse(call)
+	case brevity.TrainingResponse:
+		logger.Debug().Msg("composing TRAINING call")
+		return c.ComposeTrainingResponse(call)
+	case brevity.MuteResponse:
+		logger.Debug().Msg("composing mute response")
+		return c.ComposeMuteResponse(call)
+	case brevity.GameplanResponse:
+		logger.Debug().Msg("composing GAMEPLAN call")
+		return c.ComposeGameplanResponse(call)
+	case brevity.CommentaryCall:
+		logger.Debug().Msg("composing training commentary")
+		return c.ComposeCommentaryCall(call)
+	case brevity.SunriseCall:
+		logger.Debug().Msg("composing SUNRISE call")
+		return c.ComposeSunriseCall(call)
+	case brevity.ThreatCall:
+		logger.Debug().Msg("composing THREAT call")
+		return c.ComposeThreatCall(call)
+	case brevity.HVAAThreatCall:
+		logger.Debug().Msg("composing HVAA THREAT call")
+		return c.ComposeHVAAThreatCall(call)
+	case brevity.MergedCall:
+		logger.Debug().Msg("composing MERGED call")
+		return c.ComposeMergedCall(call)
+	case brevity.CleanCall:
+		logger.Debug().Msg("composing CLEAN call")
+		return c.ComposeCleanCall(call)
+	case brevity.SightingResponse:
+		logger.Debug().Msg("composing sighting acknowledgement")
+		return c.ComposeSightingResponse(call)
+	case brevity.SayAgainResponse:
+		logger.Debug().Msg("composing SAY AGAIN call")
+		return c.ComposeSayAgainResponse(call)
+	case brevity.ConfirmResponse:
+		logger.Debug().Msg("composing CONFIRM call")
+		return c.ComposeConfirmResponse(call)
+	default:
+		logger.Debug().Msg("unable to route call to composition")
+	}
+	return composer.NaturalLanguageResponse{}
+}
+
+// composeOnNets composes a response separately for each group of the given frequencies which share a GCI callsign, so
+// that the GCI on each net uses its own callsign. If no frequencies are given, the response is composed for every
+// frequency.
+func (a *app) composeOnNets(
+	frequencies []simpleradio.RadioFrequency,
+	compose func(composer.Composer) composer.NaturalLanguageResponse,
+) []composedResponse {
+	nets := a.callsignNets(frequencies)
+	responses := make([]composedResponse, 0, len(nets))
+	for callsign, net := range nets {
+		responses = append(responses, composedResponse{
+			NaturalLanguageResponse: compose(a.composers[callsign]),
+			frequencies:             net,
+		})
+	}
+	return responses
+}
+
+// publishResponse publishes a composed response to the transcript stream.
+func (a *app) publishResponse(response composedResponse, callsign string) {
+	frequencies := make([]string, 0, len(response.frequencies))
+	for _, f := range response.frequencies {
+		frequencies = append(frequencies, f.String())
+	}
+	a.transcript.Publish(api.Event{
+		Type:        api.ResponseEvent,
+		Frequencies: frequencies,
+		Text:        response.Subtitle,
+		Call:        response.call,
+		Callsign:    callsign,
+	})
+	if a.recorder != nil {
+		a.recorder.Said(response.Subtitle)
+	}
+}
+
+// destination returns the frequencies a response or call should be transmitted on. Responses to a caller are
+// transmitted on the net where the caller was last heard. Everything else is transmitted on all frequencies.
+func (a *app) destination(call any) []simpleradio.RadioFrequency {
+	callsign := middleware.Callsign(call)
+	if callsign == "" {
+		return nil
+	}
+	frequency, ok := a.callers.Load(callsign)
+	if !ok {
+		return nil
+	}
+	return a.net(frequency.(simpleradio.RadioFrequency))
+}
+
+// synthesize converts outgoing text to spoken audio. The text is spoken in the voice used on each destination
+// frequency.
+func (a *app) synthesize(ctx context.Context, in <-chan composedResponse, out chan<- synthesizedResponse) {
 	for {
 		select {
 		case <-ctx.Done():
 			log.Info().Msg("stopping speech synthesis due to context cancellation")
 			return
 		case response := <-in:
-			log.Info().Str("text", response.Speech).Msg("synthesizing speech")
+			a.synthesizeResponse(response, out)
+		case response := <-a.broadcasts:
+			a.synthesizeResponse(response, out)
+		}
+	}
+}
+
+// synthesizeResponse synthesizes speech in the voice used on each of the response's frequencies.
+func (a *app) synthesizeResponse(response composedResponse, out chan<- synthesizedResponse) {
+	if a.muted.Load() && !response.isAdmin {
+		log.Info().Str("text", response.Subtitle).Msg("not speaking because the GCI is muted")
+		a.inFlight.Answered(response.callsign)
+		return
+	}
+	if !a.Subsystem(api.Speech) {
+		log.Info().Str("text", response.Subtitle).Msg("not speaking because speech is turned off")
+		a.inFlight.Answered(response.callsign)
+		return
+	}
+	frequencies := response.frequencies
+	if len(frequencies) == 0 {
+		frequencies = a.currentFrequencies()
+	}
+	frequenciesByVoice := make(map[voices.Voice][]simpleradio.RadioFrequency)
+	for _, frequency := range frequencies {
+		voice := a.persona(frequency).Voice
+		frequenciesByVoice[voice] = append(frequenciesByVoice[voice], frequency)
+	}
+	// Long responses are split into parts which are transmitted one after another, so that other players can break
+	// in between them.
+	speeches := []string{response.Speech}
+	if len(response.Parts) > 0 {
+		speeches = speeches[:0]
+		for _, part := range response.Parts {
+			speeches = append(speeches, part.Speech)
+		}
+	}
+	for voice, frequencies := range frequenciesByVoice {
+		for i, speech := range speeches {
+			log.Info().Str("text", speech).Int("voice", int(voice)).Msg("synthesizing speech")
 			start := time.Now()
-			audio, err := a.speaker.Say(response.Speech)
+			audio, err := a.speakers[voice].Say(speech)
 			if err != nil {
 				log.Error().Err(err).Msg("error synthesizing speech")
 			} else {
@@ -461,7 +1379,11 @@ func (a *app) synthesize(ctx context.Context, in <-chan composer.NaturalLanguage
 					log.Warn().Msg("synthesized audio is empty")
 				} else {
 					log.Info().Stringer("clockTime", time.Since(start)).Msg("synthesized audio")
-					out <- audio
+					synthesized := synthesizedResponse{audio: audio, frequencies: frequencies}
+					if i == len(speeches)-1 {
+						synthesized.callsign = response.callsign
+					}
+					out <- synthesized
 				}
 			}
 		}
@@ -469,19 +1391,22 @@ func (a *app) synthesize(ctx context.Context, in <-chan composer.NaturalLanguage
 }
 
 // transmit sends audio to SRS for transmission.
-func (a *app) transmit(ctx context.Context, in <-chan []float32) {
+func (a *app) transmit(ctx context.Context, in <-chan synthesizedResponse) {
 	for {
 		select {
 		case <-ctx.Done():
 			log.Info().Msg("stopping audio transmissions due to context cancellation")
 			return
-		case audio := <-in:
-			if len(audio) == 0 {
+		case response := <-in:
+			if len(response.audio) == 0 {
 				log.Warn().Msg("audio to transmit is empty")
 			} else {
-				log.Info().Msg("transmitting audio")
+				log.Info().Any("frequencies", response.frequencies).Msg("transmitting audio")
+			}
+			a.srsClient.Transmit(response.audio, response.frequencies...)
+			if response.callsign != "" {
+				a.inFlight.Answered(response.callsign)
 			}
-			a.srsClient.Transmit(audio)
 		}
 	}
 }
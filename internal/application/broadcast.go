@@ -0,0 +1,80 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dharmab/skyeye/internal/api"
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/composer"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+	"github.com/martinlindhe/unit"
+)
+
+// maxQueuedBroadcasts is the maximum number of mission-scripted broadcasts waiting to be spoken.
+const maxQueuedBroadcasts = 8
+
+// Broadcast implements [api.Broadcaster.Broadcast].
+func (a *app) Broadcast(request api.BroadcastRequest) error {
+	var callsigns []string
+	switch {
+	case request.Package != "":
+		var ok bool
+		callsigns, ok = a.controller.PackageCallsigns(request.Package)
+		if !ok {
+			return fmt.Errorf("%w: no package named %s", api.ErrInvalidBroadcast, request.Package)
+		}
+		if len(callsigns) == 0 {
+			return fmt.Errorf("%w: no players in the %s package are on frequency", api.ErrInvalidBroadcast, request.Package)
+		}
+	case request.Near != nil:
+		point := brevity.NewBullseye(
+			bearings.NewMagneticBearing(unit.Angle(request.Near.Bearing)*unit.Degree),
+			unit.Length(request.Near.Range)*unit.NauticalMile,
+		)
+		callsigns = a.controller.NearbyCallsigns(*point, unit.Length(request.Near.Radius)*unit.NauticalMile)
+		if len(callsigns) == 0 {
+			return fmt.Errorf("%w: no players %s are on frequency", api.ErrInvalidBroadcast, request.Near)
+		}
+	}
+	text := request.Text
+	if len(callsigns) > 0 {
+		text = fmt.Sprintf("%s, %s", strings.Join(callsigns, ", "), text)
+	}
+
+	response := composedResponse{
+		NaturalLanguageResponse: a.timestamp(composer.NaturalLanguageResponse{
+			Subtitle: text,
+			Speech:   text,
+		}),
+	}
+	if frequency := request.Frequency; frequency != "" {
+		parsed, err := simpleradio.ParseRadioFrequency(frequency)
+		if err != nil {
+			return fmt.Errorf("%w: %w", api.ErrInvalidBroadcast, err)
+		}
+		for _, f := range a.currentFrequencies() {
+			if f.IsSameFrequency(*parsed) {
+				response.frequencies = []simpleradio.RadioFrequency{f}
+			}
+		}
+		if response.frequencies == nil {
+			return fmt.Errorf("%w: %s is not one of the GCI's frequencies", api.ErrInvalidBroadcast, frequency)
+		}
+	} else if a.scopeBroadcasts && len(callsigns) > 0 {
+		frequencies, ok := a.scopedFrequencies(callsigns)
+		if !ok {
+			return fmt.Errorf("%w: none of the addressed players are on frequency", api.ErrInvalidBroadcast)
+		}
+		response.frequencies = frequencies
+	}
+
+	select {
+	case a.broadcasts <- response:
+		a.publishResponse(response, "")
+		return nil
+	default:
+		return api.ErrBroadcastQueueFull
+	}
+}
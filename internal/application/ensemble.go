@@ -0,0 +1,49 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/dharmab/skyeye/pkg/middleware"
+	"github.com/dharmab/skyeye/pkg/recognizer"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+	"github.com/rs/zerolog/log"
+)
+
+// reconsider recognizes a low-confidence transcript of a critical request, such as a DECLARE, a second time with the
+// ensemble model and merges the two transcripts. This trades latency for accuracy on requests where a misheard number
+// is costly. Other transcripts are returned unchanged.
+func (a *app) reconsider(ctx context.Context, frequency simpleradio.RadioFrequency, audio []float32, first recognizer.Transcript) recognizer.Transcript {
+	if a.ensembleRecognizer == nil || first.Text == "" || first.Confidence >= a.ensembleThreshold {
+		return first
+	}
+	p := a.parserOn(frequency)
+	requestType := middleware.RequestType(p.Parse(first.Text))
+	if !slices.Contains(a.ensembleRequests, requestType) {
+		return first
+	}
+
+	logger := log.With().Str("request", requestType).Float64("confidence", first.Confidence).Logger()
+	logger.Info().Msg("recognizing low-confidence request a second time")
+	second, err := a.ensembleRecognizer.Recognize(ctx, audio, a.enableTranscriptionLogging)
+	if errors.Is(err, recognizer.ErrOverloaded) {
+		logger.Warn().Msg("skipping second recognition pass because speech recognition is overloaded")
+		return first
+	} else if err != nil {
+		logger.Error().Err(err).Msg("error in second recognition pass")
+		return first
+	}
+	// Don't trade a request for a transcript that isn't the same kind of request.
+	if middleware.RequestType(p.Parse(second.Text)) != requestType {
+		logger.Info().Float64("secondConfidence", second.Confidence).Msg("second recognition pass did not recognize the same request")
+		return first
+	}
+
+	merged := recognizer.Merge(first, second)
+	logger.Info().
+		Float64("secondConfidence", second.Confidence).
+		Float64("mergedConfidence", merged.Confidence).
+		Msg("merged recognition passes")
+	return merged
+}
@@ -0,0 +1,113 @@
+package application
+
+import (
+	"slices"
+	"time"
+
+	"github.com/dharmab/skyeye/internal/conf"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+	"github.com/rs/zerolog/log"
+)
+
+// frequencySchedule tracks scheduled frequency changes against the mission time. It is only accessed from the
+// goroutine which updates the mission time, so it is not safe for concurrent use.
+type frequencySchedule struct {
+	// changes are the scheduled changes, ordered by time.
+	changes []conf.FrequencyChange
+	// warning is how long before a change it is announced.
+	warning time.Duration
+	// last is the mission time of day when the schedule was last checked.
+	last time.Duration
+	// isStarted is true once the schedule has been checked at least once.
+	isStarted bool
+}
+
+// check returns the changes which should be announced and the changes which are due at the given mission time of day.
+// Changes which were already due the first time the schedule is checked are returned as due, so that the GCI catches
+// up with the comm plan if it starts partway through a mission.
+func (s *frequencySchedule) check(now time.Duration) (announce, due []conf.FrequencyChange) {
+	isPassed := func(t time.Duration) bool {
+		if !s.isStarted {
+			return t <= now
+		}
+		return s.last < t && t <= now
+	}
+	for _, change := range s.changes {
+		if isPassed(change.At) {
+			due = append(due, change)
+		} else if isPassed(change.At - s.warning) {
+			announce = append(announce, change)
+		}
+	}
+	s.last = now
+	s.isStarted = true
+	return announce, due
+}
+
+// currentFrequencies returns the frequencies the GCI is currently on.
+func (a *app) currentFrequencies() []simpleradio.RadioFrequency {
+	a.frequenciesLock.RLock()
+	defer a.frequenciesLock.RUnlock()
+	return slices.Clone(a.frequencies)
+}
+
+// updateFrequencies announces and performs any scheduled frequency changes at the given mission time.
+func (a *app) updateFrequencies(missionTime time.Time) {
+	if a.frequencySchedule == nil || missionTime.IsZero() {
+		return
+	}
+	year, month, day := missionTime.Date()
+	now := missionTime.Sub(time.Date(year, month, day, 0, 0, 0, 0, missionTime.Location()))
+	announce, due := a.frequencySchedule.check(now)
+	for _, change := range announce {
+		a.announceFrequencyChange(change, change.At-now)
+	}
+	for _, change := range due {
+		a.changeFrequency(change)
+	}
+}
+
+// announceFrequencyChange broadcasts an upcoming frequency change on the frequency the GCI is leaving.
+func (a *app) announceFrequencyChange(change conf.FrequencyChange, in time.Duration) {
+	response := composedResponse{
+		NaturalLanguageResponse: a.timestamp(a.composers[a.persona(change.From).Callsign].ComposeFrequencyChangeCall(brevity.FrequencyChangeCall{
+			From: change.From.Frequency,
+			To:   change.To.Frequency,
+			In:   in,
+		})),
+		frequencies: []simpleradio.RadioFrequency{change.From},
+	}
+	select {
+	case a.broadcasts <- response:
+		a.publishResponse(response, "")
+	default:
+		log.Warn().Stringer("from", change.From).Stringer("to", change.To).Msg("unable to announce frequency change because the broadcast queue is full")
+	}
+}
+
+// changeFrequency retunes the GCI's radio from one frequency to another. The language and voice used on the old
+// frequency carry over to the new frequency.
+func (a *app) changeFrequency(change conf.FrequencyChange) {
+	logger := log.With().Stringer("from", change.From).Stringer("to", change.To).Logger()
+	if err := a.srsClient.Retune(change.From, change.To); err != nil {
+		logger.Error().Err(err).Msg("failed to change frequency")
+		return
+	}
+
+	a.frequenciesLock.Lock()
+	defer a.frequenciesLock.Unlock()
+	a.frequencies = slices.Clone(a.frequencies)
+	for i, f := range a.frequencies {
+		if f.IsSameFrequency(change.From) {
+			a.frequencies[i] = change.To
+		}
+	}
+	for f, persona := range a.personas {
+		if f.IsSameFrequency(change.From) {
+			delete(a.personas, f)
+			a.personas[change.To] = persona
+		}
+	}
+	logger.Info().Msg("changed frequency")
+}
@@ -0,0 +1,45 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/internal/conf"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrequencyScheduleCheck(t *testing.T) {
+	t.Parallel()
+	frequency := func(mhz float64) simpleradio.RadioFrequency {
+		return simpleradio.RadioFrequency{Frequency: unit.Frequency(mhz) * unit.Megahertz, Modulation: types.ModulationAM}
+	}
+	early := conf.FrequencyChange{At: 8 * time.Hour, From: frequency(251), To: frequency(252)}
+	late := conf.FrequencyChange{At: 9 * time.Hour, From: frequency(252), To: frequency(253)}
+	schedule := &frequencySchedule{
+		changes: []conf.FrequencyChange{early, late},
+		warning: 5 * time.Minute,
+	}
+
+	announce, due := schedule.check(8*time.Hour + 30*time.Minute)
+	assert.Empty(t, announce)
+	assert.Equal(t, []conf.FrequencyChange{early}, due, "changes which already passed should be caught up on the first check")
+
+	announce, due = schedule.check(8*time.Hour + 56*time.Minute)
+	assert.Equal(t, []conf.FrequencyChange{late}, announce)
+	assert.Empty(t, due)
+
+	announce, due = schedule.check(8*time.Hour + 58*time.Minute)
+	assert.Empty(t, announce, "announcements should not repeat")
+	assert.Empty(t, due)
+
+	announce, due = schedule.check(9 * time.Hour)
+	assert.Empty(t, announce)
+	assert.Equal(t, []conf.FrequencyChange{late}, due)
+
+	announce, due = schedule.check(9*time.Hour + time.Minute)
+	assert.Empty(t, announce)
+	assert.Empty(t, due, "changes should only be due once")
+}
@@ -0,0 +1,28 @@
+package application
+
+import (
+	"github.com/dharmab/skyeye/internal/bridge"
+	"github.com/dharmab/skyeye/internal/conf"
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// Groups implements [bridge.Source.Groups].
+func (a *app) Groups() []bridge.Group {
+	groups := a.radar.GetPicture(conf.DefaultPictureRadius, a.coalition.Opposite(), brevity.Aircraft)
+	result := make([]bridge.Group, 0, len(groups))
+	for _, group := range groups {
+		summary := bridge.Group{
+			ObjectIDs:   group.ObjectIDs(),
+			Platforms:   group.Platforms(),
+			Altitude:    group.Altitude().Feet(),
+			Track:       string(group.Track()),
+			Declaration: string(group.Declaration()),
+		}
+		if bullseye := group.Bullseye(); bullseye != nil {
+			summary.Bearing = bullseye.Bearing().Degrees()
+			summary.Range = bullseye.Distance().NauticalMiles()
+		}
+		result = append(result, summary)
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/dharmab/skyeye/internal/api"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/composer"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+	"github.com/rs/zerolog/log"
+)
+
+// subsystemNames are how each subsystem is described on the radio when it is turned off.
+var subsystemNames = map[api.Subsystem]string{
+	api.Recognition: "speech recognition",
+	api.Broadcasts:  "broadcasts",
+	api.Speech:      "speech",
+}
+
+// handleHealth reports the GCI's own health on the net where the request was heard.
+func (a *app) handleHealth(ctx context.Context) {
+	response := brevity.HealthResponse{
+		Contacts: len(a.radar.Trackfiles()),
+	}
+	if lastUpdate := a.tacviewClient.LastUpdate(); !lastUpdate.IsZero() {
+		response.HasTelemetry = true
+		response.TelemetryAge = time.Since(lastUpdate)
+	}
+	for _, frequency := range a.currentFrequencies() {
+		response.Frequencies = append(response.Frequencies, frequency.Frequency)
+	}
+	for _, subsystem := range api.AllSubsystems {
+		if !a.Subsystem(subsystem) {
+			response.Degraded = append(response.Degraded, subsystemNames[subsystem])
+		}
+	}
+	if a.muted.Load() {
+		response.Degraded = append(response.Degraded, "muted")
+	}
+
+	log.Info().
+		Bool("hasTelemetry", response.HasTelemetry).
+		Stringer("telemetryAge", response.TelemetryAge).
+		Int("contacts", response.Contacts).
+		Strs("degraded", response.Degraded).
+		Msg("reporting health")
+
+	var frequencies []simpleradio.RadioFrequency
+	if frequency, ok := ctx.Value(frequencyKey{}).(simpleradio.RadioFrequency); ok {
+		frequencies = a.net(frequency)
+	}
+	for _, composed := range a.composeOnNets(frequencies, func(c composer.Composer) composer.NaturalLanguageResponse {
+		return c.ComposeHealthResponse(response)
+	}) {
+		composed.call = "health"
+		select {
+		case a.broadcasts <- composed:
+			a.publishResponse(composed, "")
+		default:
+			log.Warn().Msg("unable to report health because the broadcast queue is full")
+		}
+	}
+}
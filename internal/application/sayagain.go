@@ -0,0 +1,79 @@
+package application
+
+import (
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/composer"
+	"github.com/rs/zerolog/log"
+)
+
+// sayAgainExpiry is how long a response can be repeated after it was composed. Older responses are likely stale, and
+// forgetting them keeps the cache from growing with every callsign heard during a long mission.
+const sayAgainExpiry = 5 * time.Minute
+
+// rememberedResponse is a response cached for SAY AGAIN.
+type rememberedResponse struct {
+	response composedResponse
+	at       time.Time
+}
+
+// rememberResponse caches a response to a caller so that it can be repeated if they ask us to say again. Requests to
+// say again are not cached, so that a garbled request to repeat does not replace the response the caller wanted.
+func (a *app) rememberResponse(call any, callsign string, response composedResponse) {
+	if callsign == "" {
+		return
+	}
+	if _, ok := call.(brevity.SayAgainResponse); ok {
+		return
+	}
+	now := time.Now()
+	a.lastResponses.Range(func(key, value any) bool {
+		if now.Sub(value.(*rememberedResponse).at) > sayAgainExpiry {
+			a.lastResponses.Delete(key)
+		}
+		return true
+	})
+	a.lastResponses.Store(callsign, &rememberedResponse{response: response, at: now})
+}
+
+// recallResponse returns the cached response to the caller, if it has not expired.
+func (a *app) recallResponse(callsign string) (composedResponse, bool) {
+	cached, ok := a.lastResponses.Load(callsign)
+	if !ok {
+		return composedResponse{}, false
+	}
+	remembered := cached.(*rememberedResponse)
+	if time.Since(remembered.at) > sayAgainExpiry {
+		// Compare by pointer so that a response cached concurrently is not forgotten.
+		a.lastResponses.CompareAndDelete(callsign, remembered)
+		return composedResponse{}, false
+	}
+	return remembered.response, true
+}
+
+// handleSayAgain repeats the last response to the caller exactly as it was originally composed, without asking the
+// controller to query the radar again. The repeat is transmitted on the net where the caller was most recently heard.
+func (a *app) handleSayAgain(request *brevity.SayAgainRequest) {
+	logger := log.With().Str("callsign", request.Callsign).Logger()
+	var responses []composedResponse
+	if composed, ok := a.recallResponse(request.Callsign); ok {
+		logger.Info().Msg("repeating last response")
+		composed.frequencies = a.destination(request)
+		responses = []composedResponse{composed}
+	} else {
+		logger.Info().Msg("no response to repeat")
+		responses = a.composeOnNets(a.destination(request), func(c composer.Composer) composer.NaturalLanguageResponse {
+			return c.ComposeNothingToRepeatResponse(brevity.NothingToRepeatResponse{Callsign: request.Callsign})
+		})
+	}
+	for _, composed := range responses {
+		composed.callsign = request.Callsign
+		select {
+		case a.broadcasts <- composed:
+			a.publishResponse(composed, request.Callsign)
+		default:
+			logger.Warn().Msg("unable to repeat response because the broadcast queue is full")
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/composer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRememberResponse(t *testing.T) {
+	t.Parallel()
+	a := &app{}
+	picture := composedResponse{NaturalLanguageResponse: composer.NaturalLanguageResponse{Subtitle: "Focus, picture clean."}}
+	a.rememberResponse(brevity.PictureResponse{}, "mobius 1", picture)
+	recalled, ok := a.recallResponse("mobius 1")
+	require.True(t, ok)
+	assert.Equal(t, picture, recalled)
+
+	// Requests to say again do not replace the cached response.
+	a.rememberResponse(brevity.SayAgainResponse{}, "mobius 1", composedResponse{})
+	recalled, ok = a.recallResponse("mobius 1")
+	require.True(t, ok)
+	assert.Equal(t, picture, recalled)
+
+	_, ok = a.recallResponse("yellow 13")
+	assert.False(t, ok)
+
+	// Expired responses are not repeated, and are forgotten when another response is cached.
+	a.lastResponses.Store("yellow 13", &rememberedResponse{response: picture, at: time.Now().Add(-2 * sayAgainExpiry)})
+	_, ok = a.recallResponse("yellow 13")
+	assert.False(t, ok)
+	_, ok = a.lastResponses.Load("yellow 13")
+	assert.False(t, ok)
+	a.lastResponses.Store("yellow 13", &rememberedResponse{response: picture, at: time.Now().Add(-2 * sayAgainExpiry)})
+	a.rememberResponse(brevity.PictureResponse{}, "mobius 1", picture)
+	_, ok = a.lastResponses.Load("yellow 13")
+	assert.False(t, ok)
+}
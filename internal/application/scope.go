@@ -0,0 +1,44 @@
+package application
+
+import (
+	"slices"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/parser"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+)
+
+// addressees returns the callsigns of the flights a broadcast call is addressed to, or nil if the call is addressed to
+// everyone on frequency.
+func addressees(call any) []string {
+	switch c := call.(type) {
+	case brevity.ThreatCall:
+		return c.Callsigns
+	case brevity.HVAAThreatCall:
+		return c.Callsigns
+	case brevity.MergedCall:
+		return c.Callsigns
+	case brevity.CleanCall:
+		return c.Callsigns
+	}
+	return nil
+}
+
+// scopedFrequencies returns the GCI's frequencies on which any of the given flights are listening. If no callsigns are
+// given, it returns nil, meaning every frequency. The second return value is false if callsigns are given but none of
+// the flights are listening on any of the GCI's frequencies.
+func (a *app) scopedFrequencies(callsigns []string) ([]simpleradio.RadioFrequency, bool) {
+	if len(callsigns) == 0 {
+		return nil, true
+	}
+	frequencies := make([]simpleradio.RadioFrequency, 0)
+	for _, frequency := range a.currentFrequencies() {
+		for _, name := range a.srsClient.Listeners(frequency) {
+			if callsign, ok := parser.ParsePilotCallsign(name); ok && slices.Contains(callsigns, callsign) {
+				frequencies = append(frequencies, frequency)
+				break
+			}
+		}
+	}
+	return frequencies, len(frequencies) > 0
+}
@@ -0,0 +1,35 @@
+package application
+
+import (
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/composer"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+	"github.com/rs/zerolog/log"
+)
+
+// standbyInterval is the minimum time between standby calls on a frequency, so that an overloaded GCI does not add to
+// the congestion with a standby for every discarded transmission.
+const standbyInterval = 30 * time.Second
+
+// standby tells callers on the given frequency that a transmission was discarded because speech recognition is
+// overloaded, so that the caller repeats their request rather than waiting for an answer which will never come.
+func (a *app) standby(frequency simpleradio.RadioFrequency) {
+	now := time.Now()
+	if last, ok := a.lastStandbys.Load(frequency); ok && now.Sub(last.(time.Time)) < standbyInterval {
+		return
+	}
+	a.lastStandbys.Store(frequency, now)
+	for _, composed := range a.composeOnNets(a.net(frequency), func(c composer.Composer) composer.NaturalLanguageResponse {
+		return c.ComposeStandbyResponse(brevity.StandbyResponse{})
+	}) {
+		composed.call = "standby"
+		select {
+		case a.broadcasts <- composed:
+			a.publishResponse(composed, "")
+		default:
+			log.Warn().Stringer("frequency", frequency).Msg("unable to call standby because the broadcast queue is full")
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/dharmab/skyeye/internal/api"
+	"github.com/rs/zerolog/log"
+)
+
+// discordTimeout is how long to wait for Discord to accept a mission summary.
+const discordTimeout = 10 * time.Second
+
+// trackMissions forwards mission starts from the telemetry client to the radar. When a new mission starts, and when
+// SkyEye shuts down, the previous mission's statistics are summarized. When a new mission starts, responses cached for
+// SAY AGAIN, rate limits and check-ins are forgotten, and the debrief recorder and the trackfile history start new
+// files.
+func (a *app) trackMissions(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			// The context is already cancelled, so posting the final summary needs its own deadline.
+			finishCtx, cancel := context.WithTimeout(context.Background(), discordTimeout)
+			defer cancel()
+			a.finishMission(finishCtx)
+			return
+		case start := <-a.starts:
+			a.finishMission(ctx)
+			a.lastResponses.Clear()
+			if a.rateLimiter != nil {
+				a.rateLimiter.Reset()
+			}
+			if a.checkIns != nil {
+				a.checkIns.Reset()
+			}
+			if a.recorder != nil {
+				if err := a.recorder.Restart(); err != nil {
+					log.Error().Err(err).Msg("failed to finish ACMI debrief")
+				}
+			}
+			if a.history != nil {
+				if err := a.history.Restart(); err != nil {
+					log.Error().Err(err).Msg("failed to finish trackfile history")
+				}
+			}
+			select {
+			case a.radarStarts <- start:
+			case <-ctx.Done():
+			}
+		}
+	}
+}
+
+// finishMission logs the statistics summary of the mission which just ended and posts it to Discord, then begins
+// collecting statistics for the next mission. Nothing is reported if the mission was empty.
+func (a *app) finishMission(ctx context.Context) {
+	summary := a.stats.Finish()
+	if summary.IsEmpty() {
+		return
+	}
+	log.Info().Any("summary", summary).Msg("mission ended")
+	if a.discordWebhookURL == "" {
+		return
+	}
+	postCtx, cancel := context.WithTimeout(ctx, discordTimeout)
+	defer cancel()
+	if err := api.PostSummary(postCtx, http.DefaultClient, a.discordWebhookURL, summary); err != nil {
+		log.Warn().Err(err).Msg("failed to post mission summary to Discord")
+		return
+	}
+	log.Info().Msg("posted mission summary to Discord")
+}
+
+// updateKills updates the mission statistics with the objects destroyed during the mission.
+func (a *app) updateKills() {
+	kills := a.tacviewClient.Kills()
+	stats := make([]api.Kill, 0, len(kills))
+	for _, kill := range kills {
+		stats = append(stats, api.Kill{
+			Coalition:  kill.Coalition.String(),
+			IsAircraft: kill.IsAircraft,
+		})
+	}
+	a.stats.SetKills(stats)
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dharmab/skyeye/internal/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFinishMission(t *testing.T) {
+	t.Parallel()
+	var posts atomic.Int32
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer webhook.Close()
+
+	a := &app{stats: api.NewStatistics(), discordWebhookURL: webhook.URL}
+	a.finishMission(context.Background())
+	assert.Equal(t, int32(0), posts.Load(), "empty missions should not be posted")
+
+	a.stats.SetKills([]api.Kill{{Coalition: "Red", IsAircraft: true}})
+	a.finishMission(context.Background())
+	assert.Equal(t, int32(1), posts.Load())
+
+	a.finishMission(context.Background())
+	assert.Equal(t, int32(1), posts.Load(), "statistics should be reset for the next mission")
+
+	a = &app{stats: api.NewStatistics()}
+	a.stats.SetKills([]api.Kill{{Coalition: "Red", IsAircraft: true}})
+	a.finishMission(context.Background())
+	assert.Equal(t, int32(1), posts.Load(), "summaries should not be posted without a webhook")
+}
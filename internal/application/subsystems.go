@@ -0,0 +1,16 @@
+package application
+
+import "github.com/dharmab/skyeye/internal/api"
+
+// SetSubsystem implements [api.Switchboard.SetSubsystem].
+func (a *app) SetSubsystem(subsystem api.Subsystem, enabled bool) {
+	if state, ok := a.subsystems[subsystem]; ok {
+		state.Store(enabled)
+	}
+}
+
+// Subsystem implements [api.Switchboard.Subsystem].
+func (a *app) Subsystem(subsystem api.Subsystem) bool {
+	state, ok := a.subsystems[subsystem]
+	return !ok || state.Load()
+}
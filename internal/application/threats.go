@@ -0,0 +1,20 @@
+package application
+
+import "github.com/dharmab/skyeye/internal/api"
+
+// ThreatRings implements [api.ThreatMap.ThreatRings].
+func (a *app) ThreatRings() []api.ThreatRing {
+	sites := a.groundForces.SAMSites()
+	rings := make([]api.ThreatRing, 0, len(sites))
+	for _, site := range sites {
+		rings = append(rings, api.ThreatRing{
+			System:    site.SAM.System,
+			Group:     site.Group,
+			Coalition: site.Coalition.String(),
+			Launchers: site.Launchers,
+			Center:    site.Point,
+			Radius:    site.SAM.ThreatRadius,
+		})
+	}
+	return rings
+}
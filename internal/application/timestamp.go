@@ -0,0 +1,30 @@
+package application
+
+import (
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/composer"
+)
+
+// isBroadcast returns true if the call is broadcast to everyone on frequency, rather than a response to a caller.
+func isBroadcast(call any) bool {
+	switch c := call.(type) {
+	case brevity.PictureResponse:
+		return c.Callsign == ""
+	case brevity.ThreatCall, brevity.HVAAThreatCall, brevity.MergedCall, brevity.CleanCall, brevity.FadedCall, brevity.SunriseCall, brevity.SurvivorCall, brevity.ThreatRingCall, brevity.MEZWarningCall:
+		return true
+	}
+	return false
+}
+
+// timestamp prefixes a broadcast with the current mission time, if broadcast timestamps are enabled. The broadcast is
+// returned unchanged if the mission time is not yet known.
+func (a *app) timestamp(response composer.NaturalLanguageResponse) composer.NaturalLanguageResponse {
+	if !a.timestampBroadcasts {
+		return response
+	}
+	missionTime := a.tacviewClient.Time()
+	if missionTime.IsZero() {
+		return response
+	}
+	return composer.WithMissionTime(response, missionTime)
+}
@@ -0,0 +1,33 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/internal/api"
+)
+
+// Trackfiles implements [api.Annotator.Trackfiles].
+func (a *app) Trackfiles() []api.Trackfile {
+	trackfiles := a.radar.Trackfiles()
+	result := make([]api.Trackfile, 0, len(trackfiles))
+	for _, trackfile := range trackfiles {
+		result = append(result, api.Trackfile{
+			ID:        trackfile.Contact.ID,
+			Name:      trackfile.Contact.Name,
+			Coalition: trackfile.Contact.Coalition.String(),
+			Aircraft:  trackfile.Contact.ACMIName,
+			Tags:      trackfile.Tags(),
+		})
+	}
+	return result
+}
+
+// SetTags implements [api.Annotator.SetTags].
+func (a *app) SetTags(id uint64, tags []string) error {
+	trackfile := a.radar.FindUnit(id)
+	if trackfile == nil {
+		return fmt.Errorf("%w: no trackfile with ID %d", api.ErrUnknownTrackfile, id)
+	}
+	trackfile.SetTags(tags...)
+	return nil
+}
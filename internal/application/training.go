@@ -0,0 +1,11 @@
+package application
+
+// SetTraining implements [api.Trainer.SetTraining].
+func (a *app) SetTraining(enabled bool) {
+	a.controller.SetTraining(enabled)
+}
+
+// Training implements [api.Trainer.Training].
+func (a *app) Training() bool {
+	return a.controller.Training()
+}
@@ -0,0 +1,37 @@
+package application
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// monitorWeapons receives weapon and sensor events from the telemetry client. The events must be received even if
+// nothing acts on them, or the telemetry stream stalls.
+func (a *app) monitorWeapons(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case launch := <-a.launches:
+			log.Debug().
+				Uint64("id", launch.ID).
+				Str("name", launch.Name).
+				Stringer("coalition", launch.Coalition).
+				Uint64("parentID", launch.ParentID).
+				Msg("observed weapon launch")
+		case impact := <-a.impacts:
+			log.Debug().
+				Uint64("id", impact.ID).
+				Str("name", impact.Name).
+				Stringer("coalition", impact.Coalition).
+				Msg("observed weapon impact")
+		case event := <-a.sensors:
+			log.Trace().
+				Uint64("id", event.ID).
+				Str("kind", string(event.Kind)).
+				Uint64("targetID", event.TargetID).
+				Msg("observed sensor event")
+		}
+	}
+}
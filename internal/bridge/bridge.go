@@ -0,0 +1,195 @@
+// Package bridge publishes the GCI's events to an MQTT broker or NATS server, so that integrators can build automations
+// without linking against SkyEye.
+package bridge
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dharmab/skyeye/internal/api"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// snapshotInterval is how often the bridge publishes a snapshot of every trackfile and group.
+	snapshotInterval = 10 * time.Second
+	// pingInterval is how often the bridge pings the server to keep the connection alive.
+	pingInterval = 30 * time.Second
+	// minBackoff and maxBackoff bound how long the bridge waits before reconnecting after losing its connection.
+	minBackoff = 5 * time.Second
+	maxBackoff = 2 * time.Minute
+)
+
+// threatCalls are the types of responses which are also published to the threats topic.
+var threatCalls = []string{"threat", "hvaathreat", "threatring", "mezwarning"}
+
+// Source provides the state which the bridge publishes periodically.
+type Source interface {
+	// Trackfiles returns a summary of every trackfile.
+	Trackfiles() []api.Trackfile
+	// Groups returns a summary of every hostile group on the scope.
+	Groups() []Group
+}
+
+// Group is a summary of a hostile group.
+type Group struct {
+	// ObjectIDs are the IDs of the group's contacts.
+	ObjectIDs []uint64 `json:"objectIds"`
+	// Platforms are the reporting names of the group's aircraft types, such as "Flanker".
+	Platforms []string `json:"platforms"`
+	// Bearing from the bullseye to the group, in magnetic degrees.
+	Bearing float64 `json:"bearing"`
+	// Range from the bullseye to the group, in nautical miles.
+	Range float64 `json:"range"`
+	// Altitude of the group, in feet.
+	Altitude float64 `json:"altitude"`
+	// Track is the group's direction of travel, such as "north".
+	Track string `json:"track"`
+	// Declaration of the group, such as "hostile".
+	Declaration string `json:"declaration"`
+}
+
+// Bridge publishes the GCI's events to a message bus. Transcript events are published as they happen, and the
+// trackfiles and groups are published periodically. Messages are published at most once; anything which happens while
+// the bridge is disconnected is lost.
+type Bridge struct {
+	url      *url.URL
+	prefix   string
+	clientID string
+	// tlsConfig is used for encrypted connections. If nil, the host's root certificates are trusted.
+	tlsConfig *tls.Config
+	// transcript is the source of transmission, request and response events.
+	transcript *api.Transcript
+	// source is the source of trackfiles and groups.
+	source Source
+}
+
+// New constructs a bridge to the message bus at the given mqtt://, mqtts://, nats:// or tls:// URL. Topics begin
+// with the given prefix.
+func New(rawURL, prefix, clientID string, transcript *api.Transcript, source Source) (*Bridge, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse message bus URL: %w", err)
+	}
+	if !slices.Contains([]string{mqttScheme, mqttTLSScheme, natsScheme, natsTLSScheme}, u.Scheme) {
+		return nil, fmt.Errorf("message bus URL must begin with mqtt://, mqtts://, nats:// or tls://, not %s://", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("message bus URL %q is missing a host", u.Redacted())
+	}
+	return &Bridge{
+		url:        u,
+		prefix:     prefix,
+		clientID:   clientID,
+		transcript: transcript,
+		source:     source,
+	}, nil
+}
+
+// Run publishes events until the context is cancelled, reconnecting whenever the connection is lost.
+func (b *Bridge) Run(ctx context.Context) {
+	logger := log.With().Str("url", b.url.Redacted()).Logger()
+	backoff := minBackoff
+	for {
+		bus, err := dialBus(ctx, b.url, b.clientID, b.tlsConfig)
+		if err != nil {
+			logger.Warn().Err(err).Stringer("retryIn", backoff).Msg("failed to connect to message bus")
+		} else {
+			logger.Info().Msg("connected to message bus")
+			backoff = minBackoff
+			err = b.publishUntilDisconnected(ctx, bus)
+			_ = bus.close()
+			if ctx.Err() != nil {
+				logger.Info().Msg("stopping message bus bridge due to context cancellation")
+				return
+			}
+			logger.Warn().Err(err).Stringer("retryIn", backoff).Msg("lost connection to message bus")
+		}
+		select {
+		case <-ctx.Done():
+			logger.Info().Msg("stopping message bus bridge due to context cancellation")
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
+
+// publishUntilDisconnected publishes events over the given connection until it is lost or the context is cancelled.
+func (b *Bridge) publishUntilDisconnected(ctx context.Context, bus busConn) error {
+	events := b.transcript.Subscribe()
+	defer b.transcript.Unsubscribe(events)
+	snapshotTicker := time.NewTicker(snapshotInterval)
+	defer snapshotTicker.Stop()
+	pingTicker := time.NewTicker(pingInterval)
+	defer pingTicker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-bus.done():
+			return errors.New("connection closed by server")
+		case <-pingTicker.C:
+			if err := bus.ping(); err != nil {
+				return fmt.Errorf("failed to ping message bus: %w", err)
+			}
+		case <-snapshotTicker.C:
+			if err := b.publishJSON(bus, "trackfiles", b.source.Trackfiles()); err != nil {
+				return err
+			}
+			if err := b.publishJSON(bus, "groups", b.source.Groups()); err != nil {
+				return err
+			}
+		case event := <-events:
+			for _, topic := range eventTopics(event) {
+				if err := b.publishJSON(bus, topic, event); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// eventTopics returns the topics a transcript event is published to, relative to the bridge's prefix.
+func eventTopics(event api.Event) []string {
+	switch event.Type {
+	case api.TransmissionEvent:
+		return []string{"transmissions"}
+	case api.RequestEvent:
+		return []string{"requests"}
+	case api.ResponseEvent:
+		if slices.Contains(threatCalls, event.Call) {
+			return []string{"responses", "threats"}
+		}
+		return []string{"responses"}
+	default:
+		return nil
+	}
+}
+
+// publishJSON publishes the value as JSON to the given topic, relative to the bridge's prefix.
+func (b *Bridge) publishJSON(bus busConn, topic string, v any) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s message: %w", topic, err)
+	}
+	if err := bus.publish(b.topic(bus, topic), payload); err != nil {
+		return fmt.Errorf("failed to publish %s message: %w", topic, err)
+	}
+	return nil
+}
+
+// topic returns the full name of the given topic, using the connection's separator.
+func (b *Bridge) topic(bus busConn, topic string) string {
+	if b.prefix == "" {
+		return topic
+	}
+	return strings.Join([]string{b.prefix, topic}, bus.separator())
+}
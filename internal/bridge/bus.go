@@ -0,0 +1,389 @@
+package bridge
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// This file implements the small subset of MQTT 3.1.1 and the NATS client protocol needed to publish messages:
+// connecting with optional credentials and TLS, publishing at most once, and keeping the connection alive. Nothing is
+// subscribed to, so messages from the server are read only to answer pings and detect disconnection. Reconnection is
+// handled by [Bridge.Run].
+
+// URL schemes of the supported message buses.
+const (
+	mqttScheme    = "mqtt"
+	mqttTLSScheme = "mqtts"
+	natsScheme    = "nats"
+	// natsTLSScheme requires TLS, as in the official NATS clients. TLS is also used with a nats:// URL if the server
+	// requires it.
+	natsTLSScheme = "tls"
+)
+
+const (
+	// busDialTimeout is how long connecting to a message bus may take.
+	busDialTimeout = 10 * time.Second
+	// busWriteTimeout is how long a write may block before the server is considered unresponsive.
+	busWriteTimeout = 10 * time.Second
+	// mqttKeepAlive is the keep alive interval sent to MQTT brokers. The bridge pings more often than this.
+	mqttKeepAlive = 60 * time.Second
+)
+
+// busConn is a connection to a message bus which can publish messages.
+type busConn interface {
+	// publish sends the payload to the given topic or subject.
+	publish(topic string, payload []byte) error
+	// ping tells the server the connection is still alive.
+	ping() error
+	// separator is the character which separates the levels of a topic or subject.
+	separator() string
+	// done is closed when the connection is lost.
+	done() <-chan struct{}
+	// close disconnects from the server.
+	close() error
+}
+
+// dialBus connects to the message bus at the given URL. The scheme selects the protocol: mqtt:// or mqtts:// for MQTT,
+// nats:// or tls:// for NATS. If tlsConfig is nil, the host's root certificates are trusted.
+func dialBus(ctx context.Context, u *url.URL, clientID string, tlsConfig *tls.Config) (busConn, error) {
+	var defaultPort string
+	switch u.Scheme {
+	case mqttScheme:
+		defaultPort = "1883"
+	case mqttTLSScheme:
+		defaultPort = "8883"
+	case natsScheme, natsTLSScheme:
+		defaultPort = "4222"
+	default:
+		return nil, fmt.Errorf("unsupported message bus scheme %q", u.Scheme)
+	}
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), defaultPort)
+	}
+	dialer := &net.Dialer{Timeout: busDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to message bus: %w", err)
+	}
+
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	tlsConfig = tlsConfig.Clone()
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = u.Hostname()
+	}
+	upgrade := func(conn net.Conn) (net.Conn, error) {
+		tlsConn := tls.Client(conn, tlsConfig)
+		handshakeCtx, cancel := context.WithTimeout(ctx, busDialTimeout)
+		defer cancel()
+		if err := tlsConn.HandshakeContext(handshakeCtx); err != nil {
+			return nil, fmt.Errorf("TLS handshake with message bus failed: %w", err)
+		}
+		return tlsConn, nil
+	}
+
+	var bus busConn
+	switch u.Scheme {
+	case mqttScheme:
+		bus, err = connectMQTT(conn, u.User, clientID)
+	case mqttTLSScheme:
+		var tlsConn net.Conn
+		tlsConn, err = upgrade(conn)
+		if err == nil {
+			conn = tlsConn
+			bus, err = connectMQTT(conn, u.User, clientID)
+		}
+	default:
+		bus, err = connectNATS(conn, u.User, clientID, u.Scheme == natsTLSScheme, upgrade)
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return bus, nil
+}
+
+// streamConn is the part of a bus connection common to both protocols.
+type streamConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	// writeLock serializes messages written by the publishing goroutine and the reading goroutine.
+	writeLock sync.Mutex
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newStreamConn(conn net.Conn) *streamConn {
+	return &streamConn{
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+		closed: make(chan struct{}),
+	}
+}
+
+func (c *streamConn) write(b []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	if err := c.conn.SetWriteDeadline(time.Now().Add(busWriteTimeout)); err != nil {
+		return err
+	}
+	_, err := c.conn.Write(b)
+	return err
+}
+
+func (c *streamConn) done() <-chan struct{} {
+	return c.closed
+}
+
+func (c *streamConn) markClosed() {
+	c.closeOnce.Do(func() { close(c.closed) })
+}
+
+// mqttConn is a connection to an MQTT broker.
+type mqttConn struct {
+	*streamConn
+}
+
+const (
+	mqttConnect    = 0x10
+	mqttConnAck    = 0x20
+	mqttPublish    = 0x30
+	mqttPingReq    = 0xC0
+	mqttDisconnect = 0xE0
+)
+
+// appendMQTTString appends a length-prefixed UTF-8 string.
+func appendMQTTString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s))) //nolint:gosec // Lengths are checked by the caller.
+	return append(b, s...)
+}
+
+// mqttPacket frames a packet with the given fixed header byte and body.
+func mqttPacket(header byte, body []byte) []byte {
+	packet := []byte{header}
+	length := len(body)
+	for {
+		digit := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			digit |= 0x80
+		}
+		packet = append(packet, digit)
+		if length == 0 {
+			break
+		}
+	}
+	return append(packet, body...)
+}
+
+func connectMQTT(conn net.Conn, user *url.Userinfo, clientID string) (*mqttConn, error) {
+	c := &mqttConn{newStreamConn(conn)}
+	body := appendMQTTString(nil, "MQTT")
+	// Protocol level 4 is MQTT 3.1.1.
+	body = append(body, 4)
+	// Always start a clean session, since nothing is subscribed.
+	flags := byte(0x02)
+	password, hasPassword := user.Password()
+	if user.Username() != "" {
+		flags |= 0x80
+		if hasPassword {
+			flags |= 0x40
+		}
+	}
+	body = append(body, flags)
+	body = binary.BigEndian.AppendUint16(body, uint16(mqttKeepAlive.Seconds()))
+	body = appendMQTTString(body, clientID)
+	if flags&0x80 != 0 {
+		body = appendMQTTString(body, user.Username())
+	}
+	if flags&0x40 != 0 {
+		body = appendMQTTString(body, password)
+	}
+	if err := c.write(mqttPacket(mqttConnect, body)); err != nil {
+		return nil, fmt.Errorf("failed to send MQTT CONNECT: %w", err)
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(busDialTimeout)); err != nil {
+		return nil, err
+	}
+	ack := make([]byte, 4)
+	if _, err := io.ReadFull(c.reader, ack); err != nil {
+		return nil, fmt.Errorf("failed to read MQTT CONNACK: %w", err)
+	}
+	if ack[0] != mqttConnAck || ack[1] != 2 {
+		return nil, errors.New("unexpected response to MQTT CONNECT")
+	}
+	if ack[3] != 0 {
+		return nil, fmt.Errorf("MQTT broker refused connection with return code %d", ack[3])
+	}
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		// Ping responses are the only packets the broker sends to a client with no subscriptions.
+		_, _ = io.Copy(io.Discard, c.reader)
+		c.markClosed()
+	}()
+	return c, nil
+}
+
+func (c *mqttConn) publish(topic string, payload []byte) error {
+	if len(topic) > 0xFFFF {
+		return errors.New("MQTT topic is too long")
+	}
+	body := appendMQTTString(nil, topic)
+	body = append(body, payload...)
+	return c.write(mqttPacket(mqttPublish, body))
+}
+
+func (c *mqttConn) ping() error {
+	return c.write([]byte{mqttPingReq, 0})
+}
+
+func (*mqttConn) separator() string {
+	return "/"
+}
+
+func (c *mqttConn) close() error {
+	_ = c.write([]byte{mqttDisconnect, 0})
+	return c.conn.Close()
+}
+
+// natsConn is a connection to a NATS server.
+type natsConn struct {
+	*streamConn
+}
+
+// natsServerInfo is the part of a NATS INFO message which the bridge uses.
+type natsServerInfo struct {
+	TLSRequired bool `json:"tls_required"`
+}
+
+// natsConnectOptions is the body of a NATS CONNECT message.
+type natsConnectOptions struct {
+	Verbose     bool   `json:"verbose"`
+	Pedantic    bool   `json:"pedantic"`
+	Name        string `json:"name"`
+	Lang        string `json:"lang"`
+	Version     string `json:"version"`
+	User        string `json:"user,omitempty"`
+	Pass        string `json:"pass,omitempty"`
+	AuthToken   string `json:"auth_token,omitempty"`
+	TLSRequired bool   `json:"tls_required"`
+}
+
+// connectNATS connects to a NATS server. The server greets the client in plain text, after which the connection is
+// upgraded to TLS if either side requires it.
+func connectNATS(conn net.Conn, user *url.Userinfo, clientID string, requireTLS bool, upgrade func(net.Conn) (net.Conn, error)) (*natsConn, error) {
+	if err := conn.SetReadDeadline(time.Now().Add(busDialTimeout)); err != nil {
+		return nil, err
+	}
+	// The server sends nothing after INFO until the client responds, so nothing is lost by discarding this reader.
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NATS INFO: %w", err)
+	}
+	payload, ok := strings.CutPrefix(line, "INFO ")
+	if !ok {
+		return nil, errors.New("unexpected greeting from NATS server")
+	}
+	var info natsServerInfo
+	if err := json.Unmarshal([]byte(payload), &info); err != nil {
+		return nil, fmt.Errorf("failed to parse NATS INFO: %w", err)
+	}
+	isTLS := requireTLS || info.TLSRequired
+	if isTLS {
+		conn, err = upgrade(conn)
+		if err != nil {
+			return nil, err
+		}
+		if err := conn.SetReadDeadline(time.Now().Add(busDialTimeout)); err != nil {
+			return nil, err
+		}
+	}
+	c := &natsConn{newStreamConn(conn)}
+
+	options := natsConnectOptions{Name: clientID, Lang: "go", Version: "1.0.0", TLSRequired: isTLS}
+	if user != nil {
+		if password, ok := user.Password(); ok {
+			options.User = user.Username()
+			options.Pass = password
+		} else {
+			options.AuthToken = user.Username()
+		}
+	}
+	b, err := json.Marshal(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode NATS CONNECT: %w", err)
+	}
+	if err := c.write([]byte("CONNECT " + string(b) + "\r\nPING\r\n")); err != nil {
+		return nil, fmt.Errorf("failed to send NATS CONNECT: %w", err)
+	}
+	reply, err := c.reader.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NATS CONNECT response: %w", err)
+	}
+	if strings.HasPrefix(reply, "-ERR") {
+		return nil, fmt.Errorf("NATS server refused connection: %s", strings.TrimSpace(strings.TrimPrefix(reply, "-ERR")))
+	}
+	if !strings.HasPrefix(reply, "PONG") {
+		return nil, errors.New("unexpected response to NATS CONNECT")
+	}
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+
+	go c.readUntilClosed()
+	return c, nil
+}
+
+// readUntilClosed answers the server's pings and logs its errors until the connection is lost.
+func (c *natsConn) readUntilClosed() {
+	defer c.markClosed()
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		switch {
+		case strings.HasPrefix(line, "PING"):
+			if err := c.write([]byte("PONG\r\n")); err != nil {
+				return
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			log.Warn().Str("error", strings.TrimSpace(strings.TrimPrefix(line, "-ERR"))).Msg("NATS server reported an error")
+		}
+	}
+}
+
+func (c *natsConn) publish(subject string, payload []byte) error {
+	return c.write(fmt.Appendf(nil, "PUB %s %d\r\n%s\r\n", subject, len(payload), payload))
+}
+
+func (c *natsConn) ping() error {
+	return c.write([]byte("PING\r\n"))
+}
+
+func (*natsConn) separator() string {
+	return "."
+}
+
+func (c *natsConn) close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// DoctrineSettings are the flags which a doctrine profile may set.
+var DoctrineSettings = []string{
+	"dialect",
+	"composer-templates",
+	"auto-picture",
+	"auto-picture-interval",
+	"auto-picture-quiet-when-clean",
+	"picture-max-groups",
+	"full-picture",
+	"threat-monitoring",
+	"threat-monitoring-interval",
+	"mandatory-threat-radius",
+	"exclude-non-combatants",
+	"package-threats",
+	"cold-threats",
+	"mez-warning-lookahead",
+	"commit-range",
+	"commit-update-interval",
+	"merge-cooldown",
+}
+
+// Doctrine is a named set of settings describing how one coalition's GCI controls, such as its threat radius, commit
+// criteria, PICTURE cadence and phraseology.
+type Doctrine struct {
+	// Name of the profile, for logging.
+	Name string
+	// Settings maps flag names to values.
+	Settings map[string]string
+}
+
+// LoadDoctrine reads a YAML or JSON doctrine file and returns the profile for the given coalition. The file maps
+// coalition names to profiles, so that one file can describe the doctrine of every coalition. The second return value
+// is false if the file has no profile for the coalition.
+func LoadDoctrine(path string, coalition string) (Doctrine, bool, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Doctrine{}, false, fmt.Errorf("failed to read doctrine file: %w", err)
+	}
+	var profiles map[string]map[string]any
+	if err := yaml.Unmarshal(b, &profiles); err != nil {
+		return Doctrine{}, false, fmt.Errorf("failed to parse doctrine file: %w", err)
+	}
+
+	for name, profile := range profiles {
+		if !strings.EqualFold(name, coalition) {
+			continue
+		}
+		doctrine := Doctrine{Name: name, Settings: make(map[string]string, len(profile))}
+		var errs []error
+		for key, value := range profile {
+			if key == "name" {
+				doctrine.Name = fmt.Sprint(value)
+				continue
+			}
+			if !slices.Contains(DoctrineSettings, key) {
+				errs = append(errs, fmt.Errorf("%s is not a doctrine setting", key))
+				continue
+			}
+			doctrine.Settings[key] = fmt.Sprint(value)
+		}
+		if len(errs) > 0 {
+			return Doctrine{}, false, fmt.Errorf("invalid %s doctrine profile: %w", name, errors.Join(errs...))
+		}
+		return doctrine, true, nil
+	}
+	return Doctrine{}, false, nil
+}
+
+// ApplyDoctrine sets each flag in the doctrine profile, except flags which were already set. The names of the flags
+// which were set are returned.
+func ApplyDoctrine(flags *pflag.FlagSet, doctrine Doctrine) ([]string, error) {
+	applied := make([]string, 0, len(doctrine.Settings))
+	for name, value := range doctrine.Settings {
+		flag := flags.Lookup(name)
+		if flag == nil {
+			return applied, fmt.Errorf("no flag named %s", name)
+		}
+		if flag.Changed {
+			continue
+		}
+		if err := flags.Set(name, value); err != nil {
+			return applied, fmt.Errorf("failed to set %s from doctrine profile: %w", name, err)
+		}
+		applied = append(applied, name)
+	}
+	slices.Sort(applied)
+	return applied, nil
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Problem is a single invalid setting found while validating the configuration.
+type Problem struct {
+	// Setting is the name of the flag or config file key.
+	Setting string
+	// Message describes what is wrong.
+	Message string
+	// Hint suggests how to fix it.
+	Hint string
+}
+
+// Validator checks the configuration before the application starts, collecting every problem so that they can be
+// reported at once rather than failing on the first one deep inside a subsystem.
+type Validator struct {
+	problems []Problem
+}
+
+// Add records a problem with the given setting.
+func (v *Validator) Add(setting, message, hint string) {
+	v.problems = append(v.problems, Problem{Setting: setting, Message: message, Hint: hint})
+}
+
+// Check records a problem with the given setting if the condition is false.
+func (v *Validator) Check(isValid bool, setting, message, hint string) {
+	if !isValid {
+		v.Add(setting, message, hint)
+	}
+}
+
+// CheckFile records a problem if the path is set but is not a readable file.
+func (v *Validator) CheckFile(setting, path, hint string) {
+	if path == "" {
+		return
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		v.Add(setting, fmt.Sprintf("cannot read %s: %v", path, err), hint)
+		return
+	}
+	if info.IsDir() {
+		v.Add(setting, fmt.Sprintf("%s is a directory, not a file", path), hint)
+	}
+}
+
+// CheckDirectory records a problem if the path is set but is not a directory.
+func (v *Validator) CheckDirectory(setting, path, hint string) {
+	if path == "" {
+		return
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		v.Add(setting, fmt.Sprintf("cannot read %s: %v", path, err), hint)
+		return
+	}
+	if !info.IsDir() {
+		v.Add(setting, fmt.Sprintf("%s is a file, not a directory", path), hint)
+	}
+}
+
+// CheckAddress records a problem if the address is not in the format HOST:PORT.
+func (v *Validator) CheckAddress(setting, address string) bool {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		v.Add(setting, fmt.Sprintf("%q is not a valid address: %v", address, err), "use the format HOST:PORT, e.g. localhost:5002")
+		return false
+	}
+	return true
+}
+
+// CheckReachable records a problem if a TCP connection cannot be made to the address within the timeout.
+func (v *Validator) CheckReachable(setting, address string, timeout time.Duration, hint string) {
+	connection, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		v.Add(setting, fmt.Sprintf("cannot connect to %s: %v", address, err), hint)
+		return
+	}
+	_ = connection.Close()
+}
+
+// Problems returns the problems found so far.
+func (v *Validator) Problems() []Problem {
+	return v.problems
+}
+
+// Report logs each problem found, and returns an error if there were any.
+func (v *Validator) Report() error {
+	for _, problem := range v.problems {
+		log.Error().Str("setting", problem.Setting).Str("hint", problem.Hint).Msg(problem.Message)
+	}
+	if len(v.problems) > 0 {
+		return fmt.Errorf("found %d problems with the configuration", len(v.problems))
+	}
+	return nil
+}
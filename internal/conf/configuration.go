@@ -3,15 +3,24 @@ package conf
 import (
 	"time"
 
+	"github.com/dharmab/skyeye/pkg/areas"
 	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/composer"
+	"github.com/dharmab/skyeye/pkg/encyclopedia"
 	"github.com/dharmab/skyeye/pkg/simpleradio"
 	"github.com/dharmab/skyeye/pkg/synthesizer/voices"
+	"github.com/dharmab/skyeye/pkg/tankers"
+	"github.com/dharmab/skyeye/pkg/terrain"
+	"github.com/dharmab/skyeye/pkg/weather"
 	"github.com/ggerganov/whisper.cpp/bindings/go/pkg/whisper"
 	"github.com/martinlindhe/unit"
 )
 
 // Configuration for the SkyEye application.
 type Configuration struct {
+	// Offline guarantees that SkyEye makes no network connections other than to the SRS server and the real-time
+	// telemetry server. Any feature which would connect to another network service is disabled.
+	Offline bool
 	// ACMIFile is the path to the ACMI file
 	ACMIFile string
 	// TelemetryAddress is the network address of the real-time telemetry server (including port)
@@ -28,12 +37,39 @@ type Configuration struct {
 	SRSConnectionTimeout time.Duration
 	// SRSClientName is the name of the bot that will appear in the client list and in in-game transmissions
 	SRSClientName string
+	// SRSGUID is the GUID the bot uses to identify itself to the SimpleRadio Standalone server. Reusing the same GUID
+	// across restarts makes the bot reappear as the same client. If empty, a new GUID is generated on each start.
+	SRSGUID string
 	// SRSExternalAWACSModePassword is the password for connecting to the SimpleRadio Standalone server using External AWACS Mode
 	SRSExternalAWACSModePassword string
 	// SRSFrequencies that the bot simultaneously receives and transmits on
 	SRSFrequencies []simpleradio.RadioFrequency
+	// SRSFrequencyPersonas overrides the language and voice used on some SRS frequencies. Frequencies not in this map
+	// use the default language and Voice.
+	SRSFrequencyPersonas map[simpleradio.RadioFrequency]Persona
+	// SRSRelays are pairs of SRS frequencies between which the bot retransmits received audio.
+	SRSRelays []Relay
+	// SRSRelayTone is the frequency of the tone played before relayed audio. If zero, no tone is played.
+	SRSRelayTone unit.Frequency
+	// SRSFrequencyChanges are scheduled changes of the GCI's SRS frequencies, ordered by time.
+	SRSFrequencyChanges []FrequencyChange
+	// SRSFrequencyChangeWarning is how long before a scheduled frequency change the GCI announces it.
+	SRSFrequencyChangeWarning time.Duration
+	// SRSTransmitHoldTime is the maximum time an outgoing transmission is delayed while another station is transmitting
+	// on the same frequency. Zero disables the delay.
+	SRSTransmitHoldTime time.Duration
+	// SRSMaxTransmissionDuration is the longest another SRS client may transmit continuously before its audio is
+	// ignored. Zero disables this.
+	SRSMaxTransmissionDuration time.Duration
+	// SRSLongFrameLength is the Opus frame length used for long transmissions, if the SRS server supports it.
+	SRSLongFrameLength time.Duration
+	// SRSLazyDecode defers decoding received audio until speech recognition needs it.
+	SRSLazyDecode bool
 	// EnableTranscriptionLogging controls whether transcriptions are included in logs.
 	EnableTranscriptionLogging bool
+	// FrequencyLog is the path to a file to which a transcript of every transmission on the GCI's frequencies is
+	// appended. If empty, no frequency log is written.
+	FrequencyLog string
 	// Callsign is the GCI callsign used on SRS
 	Callsign string
 	// Coalition is the coalition that the bot will act on
@@ -41,8 +77,47 @@ type Configuration struct {
 	// RadarSweepInterval is the rate at which the radar will update. This does not impact performance - ACMI data is still streamed at the same rate.
 	// It only impacts the update rate of the GCI radar picture.
 	RadarSweepInterval time.Duration
+	// FadeTimeout is how long a trackfile may go without a telemetry update before it is considered faded.
+	FadeTimeout time.Duration
+	// TrackfileRetention is how long a trackfile may go without a telemetry update before it is removed from the radar scope.
+	TrackfileRetention time.Duration
+	// FadedWindow is how long to wait after a contact fades for further contacts to fade, so that contacts which fade
+	// together are reported in a single FADED call.
+	FadedWindow time.Duration
+	// FadedRadius is the distance within which contacts which fade together are reported as a single group. Zero
+	// reports each faded contact separately.
+	FadedRadius unit.Length
+	// SimulatedSweepInterval is the rotation period of a simulated search radar. Contacts are painted once per sweep,
+	// and new contacts only appear after a full sweep. Zero disables the simulation, and every telemetry update is used.
+	SimulatedSweepInterval time.Duration
 	// WhisperModel is a whisper.cpp model used for Speech To Text
 	WhisperModel *whisper.Model
+	// KeywordSpottingModel is an optional smaller whisper.cpp model used to check if a transmission is addressed to the GCI
+	// before running full Speech To Text. If nil, keyword spotting is disabled.
+	KeywordSpottingModel *whisper.Model
+	// FallbackWhisperModel is an optional smaller whisper.cpp model used for Speech To Text when speech recognition is
+	// running behind. If nil, the main model is always used.
+	FallbackWhisperModel *whisper.Model
+	// RecognizerMaxConcurrency is the maximum number of audio samples recognized at the same time.
+	RecognizerMaxConcurrency int
+	// RecognizerMaxQueue is the maximum number of audio samples waiting for speech recognition. Further samples are discarded.
+	RecognizerMaxQueue int
+	// RecognizerConfidenceThreshold is the minimum speech recognition confidence, from 0 to 1, at which the GCI acts on
+	// a request without asking the caller to confirm it. Zero disables confirmation.
+	RecognizerConfidenceThreshold float64
+	// RecognizerConfidenceThresholds overrides RecognizerConfidenceThreshold for some request types (e.g. "declare").
+	RecognizerConfidenceThresholds map[string]float64
+	// RecognizerFallbackQueueDepth is the number of waiting audio samples at which the fallback model is used.
+	RecognizerFallbackQueueDepth int
+	// EnsembleWhisperModel is an optional whisper.cpp model used to recognize low-confidence critical requests a second
+	// time. The two transcripts are merged. If nil, each audio sample is recognized once.
+	EnsembleWhisperModel *whisper.Model
+	// RecognizerEnsembleThreshold is the speech recognition confidence, from 0 to 1, below which critical requests are
+	// recognized a second time with the ensemble model.
+	RecognizerEnsembleThreshold float64
+	// RecognizerEnsembleRequests are the request types (e.g. "declare") which are recognized a second time with the
+	// ensemble model.
+	RecognizerEnsembleRequests []string
 	// Voice is the voice used for SRS transmissions
 	Voice voices.Voice
 	// Mute disables SRS transmissions
@@ -51,19 +126,194 @@ type Configuration struct {
 	PlaybackSpeed float32
 	// Piper playback pause after every sentence in seconds (default is 0.2)
 	PlaybackPause time.Duration
+	// AltitudeFormat selects how the GCI describes altitudes.
+	AltitudeFormat composer.AltitudeFormat
+	// PlatformPronunciations overrides how some aircraft platform names are spoken.
+	PlatformPronunciations map[string]string
+	// Dialect selects the phraseology the GCI uses.
+	Dialect composer.Dialect
+	// ComposerTemplates overrides the phrasing variants of some response types. May be nil.
+	ComposerTemplates *composer.Templates
+	// ComposerTemplatesFile is the path ComposerTemplates were loaded from, so that they can be reloaded by an admin.
+	// Empty if ComposerTemplates is nil.
+	ComposerTemplatesFile string
+	// MaxResponseDuration is the longest a single response should take to speak. Zero means no limit.
+	MaxResponseDuration time.Duration
+	// TimestampBroadcasts controls whether broadcast calls are prefixed with the mission time.
+	TimestampBroadcasts bool
+	// ScopeBroadcasts controls whether broadcast calls addressed to particular flights are transmitted only on the
+	// frequencies those flights are listening on.
+	ScopeBroadcasts bool
+	// EncyclopediaDataset adds aircraft and renamed ACMI names to the built-in aircraft data. May be nil.
+	EncyclopediaDataset *encyclopedia.Dataset
+	// EncyclopediaDatasetFile is the path EncyclopediaDataset was loaded from, so that it can be reloaded by an admin.
+	// Empty if EncyclopediaDataset is nil.
+	EncyclopediaDatasetFile string
+	// AircraftOverrides change how some aircraft are classified. They are applied after EncyclopediaDataset.
+	AircraftOverrides []encyclopedia.Override
 	// EnableAutomaticPicture controls whether the controller will automatically broadcast a PICTURE at regular intervals.
 	EnableAutomaticPicture bool
 	// PictureBroadcastInterval is the interval at which the controller will automatically broadcast a PICTURE.
 	PictureBroadcastInterval time.Duration
+	// QuietPictureWhenClean stops automatic PICTURE broadcasts while the picture is clean.
+	QuietPictureWhenClean bool
+	// PictureMaxGroups is the maximum number of groups described in detail in a PICTURE. Any further groups are summarized.
+	PictureMaxGroups int
+	// EnableFullPicture controls whether players may request a FULL PICTURE which describes every group.
+	EnableFullPicture bool
 	// EnableThreatMonitoring controls whether the controller will broadcast THREAT calls.
 	EnableThreatMonitoring bool
 	// ThreatMonitoringInterval is the cooldown period between THREAT calls.
 	ThreatMonitoringInterval time.Duration
+	// ExcludeNonCombatants controls whether non-combatant aircraft, such as transports, tankers and trainers, are left
+	// out of PICTURE and THREAT calls. They may still be identified with a DECLARE.
+	ExcludeNonCombatants bool
+	// Terrain provides terrain elevation, so that low flying groups can be described by their height above ground
+	// level. May be nil.
+	Terrain terrain.Model
+	// Wind is the mission's wind, so that the speed of groups can be judged by their true airspeed rather than their
+	// ground speed. May be nil.
+	Wind *weather.Wind
 	// MandatoryThreatRadius is the brief range at which a THREAT call is mandatory.
 	MandatoryThreatRadius unit.Length
+	// GroupingRadii controls how far apart aircraft may be to be grouped together, depending on their range from the
+	// requester. Ordered by increasing range.
+	GroupingRadii []GroupingRadius
 	// ThreatMonitoringRequiresSRS controls whether threat calls are issued to aircraft that are not on an SRS frequency. This is mostly
 	// for debugging.
 	ThreatMonitoringRequiresSRS bool
+	// PackageThreats controls whether THREAT calls are extended to every player in the threatened aircraft's package.
+	PackageThreats bool
+	// ColdThreats controls how THREAT calls handle hostile groups which are cold to the threatened aircraft. Cold groups
+	// are still described in PICTURE calls.
+	ColdThreats ColdThreats
+	// MEZWarningLookahead is how far ahead friendly aircraft's tracks are projected to warn pilots who are about to fly
+	// into the MEZ of a hostile SAM site. Zero disables MEZ warnings.
+	MEZWarningLookahead time.Duration
+	// CommitRange is the range from a fighter to its target group within which the fighter is considered committed.
+	// While any fighter is committed, merges are evaluated every CommitUpdateInterval. Zero disables this.
+	CommitRange unit.Length
+	// CommitUpdateInterval is how often merges are evaluated while any fighter is committed.
+	CommitUpdateInterval time.Duration
+	// MergeCooldown is how long a friendly must be clear of every hostile before its merge is over. Contacts which
+	// re-enter the merge during the cooldown are not called MERGED again, and CLEAN is called once it elapses.
+	MergeCooldown time.Duration
+	// HVAACallsigns are the callsigns of friendly High Value Airborne Assets, such as tankers and AWACS, which fighters
+	// are directed to protect. Aircraft may also be designated as HVAAs by tagging them through the API.
+	HVAACallsigns []string
+	// HVAAProtectionRange is the range from an HVAA within which hostile groups trigger protection alerts. Zero disables
+	// HVAA protection.
+	HVAAProtectionRange unit.Length
+	// EnableTraining follows up some calls with plain language commentary for new pilots, unless the pilot turns it off.
+	EnableTraining bool
+	// GroundClutterFilter is the minimum number of units in a ground group for it to be reported in response to
+	// TROOPS IN CONTACT. Ships and air defenses are exempt.
+	GroundClutterFilter int
+	// NamedAreas are mission-defined areas which players may reference by name in DECLARE requests. May be nil.
+	NamedAreas *areas.Areas
+	// Tankers are the TACAN channels and frequencies of friendly tankers, reported with vectors to the nearest tanker.
+	Tankers []tankers.Tanker
+	// RequestRateLimit is the minimum interval between requests from the same callsign. Requests arriving sooner are
+	// ignored. Zero disables rate limiting.
+	RequestRateLimit time.Duration
+	// RequireCheckIn is a list of request types (e.g. "picture") which are ignored until the requesting callsign has
+	// checked in with a RADIO CHECK or ALPHA CHECK.
+	RequireCheckIn []string
+	// BlockedCallsignWords is a list of words. Requests from callsigns containing any of these words are ignored.
+	BlockedCallsignWords []string
+	// AdminCallsigns is a list of callsigns permitted to issue admin commands by voice.
+	AdminCallsigns []string
+	// AdminSRSGUIDs is a list of SRS client GUIDs permitted to issue admin commands by voice.
+	AdminSRSGUIDs []string
+	// APIAddress is the address on which to serve the HTTP API. If empty, the API is disabled.
+	APIAddress string
+	// APIToken is the bearer token clients must present to use the HTTP API.
+	APIToken string
+	// APIAuditLog is the path to an append-only file recording admin actions taken through the HTTP API. If empty,
+	// recent actions are only kept in memory.
+	APIAuditLog string
+	// DiscordWebhookURL is a Discord webhook to which a statistics summary is posted at the end of each mission. If
+	// empty, summaries are only logged and served by the HTTP API. Ignored if Offline is set.
+	DiscordWebhookURL string
+	// BridgeURL is the mqtt://, mqtts://, nats:// or tls:// URL of a message bus to which events are published. If
+	// empty, events are not published to a message bus. Ignored if Offline is set.
+	BridgeURL string
+	// BridgeTopicPrefix is the prefix of the topics or subjects to which events are published on the message bus.
+	BridgeTopicPrefix string
+	// DatalinkAddress is the address on which the radar picture is streamed to datalink clients. If empty, the
+	// datalink is disabled.
+	DatalinkAddress string
+	// DatalinkToken is the token datalink clients must send before they receive the radar picture. It must be set if
+	// the datalink is enabled.
+	DatalinkToken string
+	// DatalinkInterval is how often the radar picture is sent to datalink clients.
+	DatalinkInterval time.Duration
+	// DatalinkKeyframeInterval is how often datalink clients which subscribe to deltas are sent the full radar picture.
+	DatalinkKeyframeInterval time.Duration
+	// DebriefDirectory is the directory in which the GCI's view of each mission is recorded as a Tacview ACMI file. If
+	// empty, debrief recording is disabled.
+	DebriefDirectory string
+	// DebriefInterval is how often a frame is recorded to the debrief file.
+	DebriefInterval time.Duration
+	// HistoryDirectory is the directory in which every trackfile update is recorded as compressed JSON Lines. If empty,
+	// trackfile history is disabled.
+	HistoryDirectory string
+	// HistoryMaxFileSize is the size in bytes at which a trackfile history file is rotated. Zero disables rotation.
+	HistoryMaxFileSize int64
+	// HistoryMaxTotalSize is the total size in bytes of trackfile history files to keep. Zero disables the limit.
+	HistoryMaxTotalSize int64
+}
+
+// Persona is the language, voice and callsign the GCI uses on a frequency. Frequencies with the same persona form a
+// net, and frequencies with different callsigns behave as separate GCIs which share a radar picture.
+type Persona struct {
+	// Language is the two-letter ISO 639-1 code of the language players speak on the frequency. It is used as a hint
+	// for speech recognition. Non-English speech is translated to English, so this requires a multilingual model.
+	Language string
+	// Voice is the voice used for transmissions on the frequency.
+	Voice voices.Voice
+	// Callsign is the GCI's callsign on the frequency. Players on the frequency must address the GCI by this callsign
+	// or ANYFACE.
+	Callsign string
+}
+
+// Relay is a pair of SRS frequencies between which the GCI retransmits received audio in both directions.
+type Relay struct {
+	A simpleradio.RadioFrequency
+	B simpleradio.RadioFrequency
+}
+
+// FrequencyChange is a scheduled change of one of the GCI's SRS frequencies, such as a comm plan rotation.
+type FrequencyChange struct {
+	// At is the mission time of day when the frequency changes.
+	At time.Duration
+	// From is the frequency the GCI leaves.
+	From simpleradio.RadioFrequency
+	// To is the frequency the GCI moves to.
+	To simpleradio.RadioFrequency
+}
+
+// ColdThreats selects how automatic THREAT calls handle hostile groups which are cold to the friendly aircraft they
+// threaten. A group is cold to an aircraft if it is in the drag aspect and the range between them is opening.
+type ColdThreats string
+
+const (
+	// ReportColdThreats calls cold groups the same as any other threat.
+	ReportColdThreats ColdThreats = "report"
+	// DemoteColdThreats calls groups which are cold to every threatened aircraft after all other threats, and half as
+	// often.
+	DemoteColdThreats ColdThreats = "demote"
+	// ExcludeColdThreats omits aircraft from THREAT calls about groups which are cold to them.
+	ExcludeColdThreats ColdThreats = "exclude"
+)
+
+// GroupingRadius is a breakpoint on the curve of grouping radius against range. Between breakpoints, the radius is
+// interpolated linearly.
+type GroupingRadius struct {
+	// Range from the requester to the group.
+	Range unit.Length
+	// Radius within which aircraft are grouped together at this range.
+	Radius unit.Length
 }
 
 var DefaultCallsigns = []string{"Sky Eye", "Thunderhead", "Eagle Eye", "Ghost Eye", "Sky Keeper", "Bandog", "Long Caster", "Galaxy"}
@@ -0,0 +1,7 @@
+//go:build offline
+
+package conf
+
+// ForceOffline is true when SkyEye is built with the offline build tag. In this build, offline mode is always enabled
+// and cannot be disabled by configuration.
+const ForceOffline = true
@@ -0,0 +1,109 @@
+// package alerts deduplicates the automatic calls made by the GCI's monitors, such as THREAT, MERGED, CLEAN, MEZ
+// warning and HVAA protection calls, so that each monitor does not need its own cooldown bookkeeping.
+package alerts
+
+import (
+	"sync"
+	"time"
+)
+
+// record is the most recent alert for a key.
+type record struct {
+	// level is the severity of the alert.
+	level int
+	// expires is when the alert's cooldown ends.
+	expires time.Time
+}
+
+// Tracker tracks the alerts made for each key, so that an alert is repeated only when its cooldown has expired or it
+// escalates to a higher level. Keys identify what an alert is about, such as a hostile group or a pair of aircraft.
+// Monitors which have a single severity should always use level 0.
+type Tracker[K comparable] struct {
+	// cooldown is the default interval between alerts for the same key at the same level.
+	cooldown time.Duration
+	// records maps keys to the most recent alert.
+	records map[K]record
+	// lock used to synchronize access to the records map.
+	lock sync.Mutex
+}
+
+// NewTracker constructs a tracker with the given default cooldown.
+func NewTracker[K comparable](cooldown time.Duration) *Tracker[K] {
+	return &Tracker[K]{
+		cooldown: cooldown,
+		records:  make(map[K]record),
+	}
+}
+
+// Alert returns true if an alert should be made for the given key at the given level. If so, the alert is recorded
+// with the default cooldown.
+func (t *Tracker[K]) Alert(key K, level int, now time.Time) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.isSuppressed(key, level, now) {
+		return false
+	}
+	t.records[key] = record{level: level, expires: now.Add(t.cooldown)}
+	return true
+}
+
+// IsSuppressed returns true if an alert for the given key at the given level would be suppressed, without recording
+// anything. Use this with [Tracker.Record] when the decision to alert depends on more than the key.
+func (t *Tracker[K]) IsSuppressed(key K, level int, now time.Time) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.isSuppressed(key, level, now)
+}
+
+// isSuppressed implements IsSuppressed. The caller must hold the lock.
+func (t *Tracker[K]) isSuppressed(key K, level int, now time.Time) bool {
+	previous, ok := t.records[key]
+	if !ok {
+		return false
+	}
+	isEscalation := level > previous.level
+	isExpired := !now.Before(previous.expires)
+	return !isEscalation && !isExpired
+}
+
+// Record records an alert for the given key at the given level, with the given cooldown instead of the default.
+func (t *Tracker[K]) Record(key K, level int, now time.Time, cooldown time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.records[key] = record{level: level, expires: now.Add(cooldown)}
+}
+
+// Reset forgets the alert for the given key, so that the next alert is made regardless of the cooldown. Call this
+// when the condition which caused the alert has cleared.
+func (t *Tracker[K]) Reset(key K) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.records, key)
+}
+
+// ResetFunc forgets the alerts for every key for which the given function returns true. This is useful for
+// forgetting every alert involving an aircraft which has been removed from the scope.
+func (t *Tracker[K]) ResetFunc(del func(K) bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for key := range t.records {
+		if del(key) {
+			delete(t.records, key)
+		}
+	}
+}
+
+// Expire forgets and returns the keys whose cooldowns have expired. This is useful for monitors which make a follow-up
+// call once a condition has stayed clear for the cooldown, such as a CLEAN call after a merge.
+func (t *Tracker[K]) Expire(now time.Time) []K {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	expired := make([]K, 0)
+	for key, previous := range t.records {
+		if !now.Before(previous.expires) {
+			expired = append(expired, key)
+			delete(t.records, key)
+		}
+	}
+	return expired
+}
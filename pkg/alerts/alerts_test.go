@@ -0,0 +1,90 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackerAlert(t *testing.T) {
+	t.Parallel()
+	tracker := NewTracker[string](3 * time.Minute)
+	now := time.Now()
+
+	assert.True(t, tracker.Alert("a", 0, now), "first alert")
+	assert.False(t, tracker.Alert("a", 0, now.Add(time.Minute)), "same level within cooldown")
+	assert.True(t, tracker.Alert("a", 1, now.Add(time.Minute)), "escalation")
+	assert.False(t, tracker.Alert("a", 0, now.Add(2*time.Minute)), "de-escalation within cooldown")
+	assert.True(t, tracker.Alert("a", 2, now.Add(2*time.Minute)), "further escalation")
+	assert.True(t, tracker.Alert("a", 2, now.Add(5*time.Minute)), "cooldown expired")
+	assert.True(t, tracker.Alert("b", 0, now), "different key")
+}
+
+func TestTrackerRecord(t *testing.T) {
+	t.Parallel()
+	tracker := NewTracker[uint64](time.Minute)
+	now := time.Now()
+
+	assert.False(t, tracker.IsSuppressed(1, 0, now), "never alerted")
+	tracker.Record(1, 0, now, 5*time.Minute)
+	assert.True(t, tracker.IsSuppressed(1, 0, now.Add(2*time.Minute)), "custom cooldown")
+	assert.False(t, tracker.IsSuppressed(1, 1, now.Add(2*time.Minute)), "escalation")
+	assert.False(t, tracker.IsSuppressed(1, 0, now.Add(5*time.Minute)), "custom cooldown expired")
+	assert.True(t, tracker.IsSuppressed(1, 0, now.Add(2*time.Minute)), "checking does not record")
+}
+
+func TestTrackerReset(t *testing.T) {
+	t.Parallel()
+	tracker := NewTracker[[2]uint64](time.Hour)
+	now := time.Now()
+	tracker.Alert([2]uint64{1, 2}, 0, now)
+	tracker.Alert([2]uint64{1, 3}, 0, now)
+	tracker.Alert([2]uint64{4, 5}, 0, now)
+
+	tracker.Reset([2]uint64{4, 5})
+	assert.True(t, tracker.Alert([2]uint64{4, 5}, 0, now), "reset key")
+
+	tracker.ResetFunc(func(key [2]uint64) bool { return key[0] == 1 })
+	assert.True(t, tracker.Alert([2]uint64{1, 2}, 0, now), "reset by function")
+	assert.True(t, tracker.Alert([2]uint64{1, 3}, 0, now), "reset by function")
+	assert.False(t, tracker.Alert([2]uint64{4, 5}, 0, now), "retained")
+}
+
+func TestTrackerExpire(t *testing.T) {
+	t.Parallel()
+	tracker := NewTracker[uint64](time.Minute)
+	now := time.Now()
+	tracker.Alert(1, 0, now)
+	tracker.Alert(2, 0, now.Add(30*time.Second))
+	tracker.Record(3, 0, now, 5*time.Minute)
+	assert.Empty(t, tracker.Expire(now.Add(59*time.Second)))
+	assert.ElementsMatch(t, []uint64{1}, tracker.Expire(now.Add(time.Minute)))
+	assert.Empty(t, tracker.Expire(now.Add(time.Minute)), "expired keys are forgotten")
+	assert.ElementsMatch(t, []uint64{2, 3}, tracker.Expire(now.Add(5*time.Minute)))
+	assert.True(t, tracker.Alert(1, 0, now.Add(5*time.Minute)), "expired keys may alert again")
+}
+
+func TestHysteresis(t *testing.T) {
+	t.Parallel()
+	h := Hysteresis[float64]{Enter: 3, Exit: 5}
+	testCases := []struct {
+		name     string
+		isActive bool
+		value    float64
+		expected bool
+	}{
+		{"inactive outside", false, 6, false},
+		{"inactive between thresholds", false, 4, false},
+		{"inactive inside", false, 2, true},
+		{"active inside", true, 2, true},
+		{"active between thresholds", true, 4, true},
+		{"active outside", true, 6, false},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, h.Update(test.isActive, test.value))
+		})
+	}
+}
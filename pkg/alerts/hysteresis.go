@@ -0,0 +1,22 @@
+package alerts
+
+import "cmp"
+
+// Hysteresis is a condition which becomes active when a value falls below the enter threshold, and becomes inactive
+// only once the value rises above the exit threshold. The gap between the thresholds stops the condition from
+// flapping while the value hovers near either one. For example, two aircraft may enter the merge within 3 miles but
+// only exit it beyond 5 miles.
+type Hysteresis[T cmp.Ordered] struct {
+	// Enter is the value below which the condition becomes active.
+	Enter T
+	// Exit is the value above which the condition becomes inactive. It should be greater than or equal to Enter.
+	Exit T
+}
+
+// Update returns whether the condition is active given its previous state and the current value.
+func (h Hysteresis[T]) Update(isActive bool, value T) bool {
+	if isActive {
+		return value <= h.Exit
+	}
+	return value < h.Enter
+}
@@ -0,0 +1,168 @@
+// Package areas resolves named geographic areas configured by the mission maker, such as map keypads and landmarks.
+package areas
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	fuzz "github.com/hbollon/go-edlib"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geo"
+	"github.com/paulmach/orb/planar"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// Area is a named polygon on the map.
+type Area struct {
+	// Name of the area, as it is spoken on the radio.
+	Name string
+	// Aliases are other names the area may be called by.
+	Aliases []string
+	// Polygon is the boundary of the area.
+	Polygon orb.Polygon
+}
+
+// Center returns the centroid of the area.
+func (a Area) Center() orb.Point {
+	center, _ := planar.CentroidArea(a.Polygon)
+	return center
+}
+
+// Contains returns true if the given point is within the area.
+func (a Area) Contains(point orb.Point) bool {
+	return planar.PolygonContains(a.Polygon, point)
+}
+
+// Radius returns the distance from the center of the area to its furthest vertex.
+func (a Area) Radius() unit.Length {
+	center := a.Center()
+	var radius unit.Length
+	for _, ring := range a.Polygon {
+		for _, point := range ring {
+			radius = max(radius, unit.Length(geo.Distance(center, point))*unit.Meter)
+		}
+	}
+	return radius
+}
+
+// Areas is a set of named areas.
+type Areas struct {
+	areas []Area
+}
+
+// New returns a set of the given areas.
+func New(areas ...Area) *Areas {
+	return &Areas{areas: areas}
+}
+
+// point is a point in a named areas file.
+type point struct {
+	Lat float64 `yaml:"lat"`
+	Lon float64 `yaml:"lon"`
+}
+
+// file is the format of a named areas file.
+type file struct {
+	Areas []struct {
+		Name    string   `yaml:"name"`
+		Aliases []string `yaml:"aliases"`
+		Points  []point  `yaml:"points"`
+	} `yaml:"areas"`
+}
+
+// Load reads a YAML or JSON named areas file.
+func Load(path string) (*Areas, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read named areas: %w", err)
+	}
+	var f file
+	if err := yaml.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse named areas: %w", err)
+	}
+	areas := make([]Area, 0, len(f.Areas))
+	for _, a := range f.Areas {
+		if a.Name == "" {
+			return nil, errors.New("named area is missing a name")
+		}
+		if len(a.Points) < 3 {
+			return nil, fmt.Errorf("named area %q must have at least 3 points", a.Name)
+		}
+		ring := make(orb.Ring, 0, len(a.Points)+1)
+		for _, p := range a.Points {
+			ring = append(ring, orb.Point{p.Lon, p.Lat})
+		}
+		if !ring.Closed() {
+			ring = append(ring, ring[0])
+		}
+		areas = append(areas, Area{
+			Name:    a.Name,
+			Aliases: a.Aliases,
+			Polygon: orb.Polygon{ring},
+		})
+	}
+	return New(areas...), nil
+}
+
+// Len returns the number of areas.
+func (a *Areas) Len() int {
+	if a == nil {
+		return 0
+	}
+	return len(a.areas)
+}
+
+// fillerWords are words which commonly precede an area's name on the radio but are not part of it.
+var fillerWords = []string{"over", "the", "near", "at", "in", "around", "vicinity", "of", "grid", "keypad"}
+
+// normalize lowercases the phrase and strips filler words and punctuation.
+func normalize(phrase string) string {
+	fields := strings.FieldsFunc(strings.ToLower(phrase), func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9')
+	})
+	fields = slices.DeleteFunc(fields, func(field string) bool {
+		return slices.Contains(fillerWords, field)
+	})
+	return strings.Join(fields, " ")
+}
+
+// Find returns the area which best matches the spoken phrase. Exact matches on an area's name or alias are preferred;
+// otherwise the most similar name is used, if it is similar enough.
+func (a *Areas) Find(phrase string) (Area, bool) {
+	if a == nil {
+		return Area{}, false
+	}
+	phrase = normalize(phrase)
+	if phrase == "" {
+		return Area{}, false
+	}
+
+	var best Area
+	bestSimilarity := float32(0)
+	for _, area := range a.areas {
+		for _, name := range append([]string{area.Name}, area.Aliases...) {
+			name = normalize(name)
+			if name == phrase {
+				return area, true
+			}
+			similarity, err := fuzz.StringsSimilarity(phrase, name, fuzz.Levenshtein)
+			if err != nil {
+				log.Error().Err(err).Str("phrase", phrase).Str("name", name).Msg("failed to calculate similarity")
+				continue
+			}
+			if similarity > bestSimilarity {
+				best = area
+				bestSimilarity = similarity
+			}
+		}
+	}
+	if bestSimilarity > 0.6 {
+		return best, true
+	}
+	return Area{}, false
+}
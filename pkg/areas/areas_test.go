@@ -0,0 +1,99 @@
+package areas
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "areas.yaml")
+	data := `
+areas:
+  - name: the lake
+    aliases: [reservoir]
+    points:
+      - {lat: 42.0, lon: 41.0}
+      - {lat: 42.0, lon: 41.2}
+      - {lat: 42.2, lon: 41.2}
+      - {lat: 42.2, lon: 41.0}
+  - name: kilo uniform
+    points:
+      - {lat: 43.0, lon: 42.0}
+      - {lat: 43.0, lon: 42.5}
+      - {lat: 43.5, lon: 42.5}
+`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	areas, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, areas.Len())
+
+	area, ok := areas.Find("over the lake")
+	require.True(t, ok)
+	assert.Equal(t, "the lake", area.Name)
+	assert.True(t, area.Polygon[0].Closed())
+	assert.True(t, area.Contains(orb.Point{41.1, 42.1}))
+	assert.False(t, area.Contains(orb.Point{41.3, 42.1}))
+	assert.InDelta(t, 41.1, area.Center().Lon(), 0.01)
+	assert.InDelta(t, 42.1, area.Center().Lat(), 0.01)
+	assert.InDelta(t, 7.5, area.Radius().NauticalMiles(), 0.5)
+}
+
+func TestLoadInvalid(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "areas.yaml")
+	data := `
+areas:
+  - name: line
+    points:
+      - {lat: 42.0, lon: 41.0}
+      - {lat: 42.0, lon: 41.2}
+`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	_, err := Load(path)
+	require.Error(t, err)
+}
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+	square := orb.Polygon{{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}}
+	areas := New(
+		Area{Name: "Lake", Aliases: []string{"reservoir"}, Polygon: square},
+		Area{Name: "Grid KU", Aliases: []string{"kilo uniform"}, Polygon: square},
+		Area{Name: "Snake Ridge", Polygon: square},
+	)
+	testCases := []struct {
+		phrase   string
+		expected string
+		ok       bool
+	}{
+		{phrase: "over the lake", expected: "Lake", ok: true},
+		{phrase: "near the reservoir", expected: "Lake", ok: true},
+		{phrase: "grid kilo uniform", expected: "Grid KU", ok: true},
+		{phrase: "keypad KU", expected: "Grid KU", ok: true},
+		{phrase: "snake ridges", expected: "Snake Ridge", ok: true},
+		{phrase: "the airfield", ok: false},
+		{phrase: "over the", ok: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.phrase, func(t *testing.T) {
+			t.Parallel()
+			area, ok := areas.Find(test.phrase)
+			require.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, area.Name)
+		})
+	}
+}
+
+func TestFindNil(t *testing.T) {
+	t.Parallel()
+	var areas *Areas
+	_, ok := areas.Find("lake")
+	assert.False(t, ok)
+	assert.Equal(t, 0, areas.Len())
+}
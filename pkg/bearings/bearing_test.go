@@ -46,6 +46,22 @@ func TestNormalize(t *testing.T) {
 			input:    22.5,
 			expected: 22.5,
 		},
+		{
+			input:    -360,
+			expected: 360,
+		},
+		{
+			input:    -720 - 45,
+			expected: 315,
+		},
+		{
+			input:    1e9,
+			expected: 280,
+		},
+		{
+			input:    -1e9,
+			expected: 80,
+		},
 	}
 
 	for _, test := range tests {
@@ -155,3 +171,55 @@ var testCompassBearings = []struct {
 	{34.5, 34.5, "035"},
 	{33.49, 33.49, "033"},
 }
+
+func TestDifference(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		a        float64
+		b        float64
+		expected float64
+	}{
+		{a: 90, b: 90, expected: 0},
+		{a: 90, b: 100, expected: 10},
+		{a: 100, b: 90, expected: 10},
+		{a: 350, b: 10, expected: 20},
+		{a: 10, b: 350, expected: 20},
+		{a: 360, b: 180, expected: 180},
+		{a: 90, b: 271, expected: 179},
+		{a: 0.5, b: 359.5, expected: 1},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%v-%v", test.a, test.b), func(t *testing.T) {
+			t.Parallel()
+			a := NewTrueBearing(unit.Angle(test.a) * unit.Degree)
+			b := NewTrueBearing(unit.Angle(test.b) * unit.Degree)
+			require.InDelta(t, test.expected, Difference(a, b).Degrees(), 0.001)
+		})
+	}
+}
+
+func TestSignedDifference(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		a        float64
+		b        float64
+		expected float64
+	}{
+		{a: 90, b: 90, expected: 0},
+		{a: 100, b: 90, expected: 10},
+		{a: 90, b: 100, expected: -10},
+		{a: 10, b: 350, expected: 20},
+		{a: 350, b: 10, expected: -20},
+		{a: 270, b: 90, expected: 180},
+		{a: 90, b: 270, expected: 180},
+		{a: 0.5, b: 359.5, expected: 1},
+	}
+	for _, test := range testCases {
+		t.Run(fmt.Sprintf("%v-%v", test.a, test.b), func(t *testing.T) {
+			t.Parallel()
+			a := NewTrueBearing(unit.Angle(test.a) * unit.Degree)
+			b := NewTrueBearing(unit.Angle(test.b) * unit.Degree)
+			require.InDelta(t, test.expected, SignedDifference(a, b).Degrees(), 0.001)
+		})
+	}
+}
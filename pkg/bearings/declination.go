@@ -2,6 +2,8 @@ package bearings
 
 import (
 	"fmt"
+	"math"
+	"sync"
 	"time"
 
 	"github.com/martinlindhe/unit"
@@ -12,7 +14,49 @@ import (
 
 var igrfData = igrf.New()
 
-// Declination returns the magnetic declination at the given point and time.
+// declinationCellSize is the size of the grid cells in which declination is cached, in degrees of latitude and
+// longitude. Declination changes by much less than a degree across a cell except very close to the magnetic poles.
+const declinationCellSize = 0.1
+
+// maxCachedDeclinations bounds the size of the declination cache. A busy mission on a single map uses a few thousand
+// cells.
+const maxCachedDeclinations = 1 << 16
+
+// declinationKey identifies a grid cell on a day.
+type declinationKey struct {
+	lat  int
+	lon  int
+	year int
+	day  int
+}
+
+// declinationCache caches declination per grid cell and day, because computing the IGRF model is expensive and
+// declination is needed for every bearing in every call.
+type declinationCache struct {
+	lock    sync.RWMutex
+	entries map[declinationKey]unit.Angle
+}
+
+var declinations = &declinationCache{entries: make(map[declinationKey]unit.Angle)}
+
+func (c *declinationCache) get(key declinationKey) (unit.Angle, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	declination, ok := c.entries[key]
+	return declination, ok
+}
+
+func (c *declinationCache) set(key declinationKey, declination unit.Angle) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.entries) >= maxCachedDeclinations {
+		clear(c.entries)
+	}
+	c.entries[key] = declination
+}
+
+// Declination returns the magnetic declination at the given point and time. Results are cached per 0.1 degree grid
+// cell and day, and computed at the center of the cell.
 func Declination(p orb.Point, t time.Time) (unit.Angle, error) {
 	if t.Year() < 1900 {
 		log.Warn().Msg("date is too early for IGRF model, replacing with real-time date")
@@ -22,9 +66,33 @@ func Declination(p orb.Point, t time.Time) (unit.Angle, error) {
 		log.Warn().Msg("year is too late for IGRF model, replacing with 2025")
 		t = time.Date(2025, t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
 	}
-	field, err := igrfData.IGRF(p.Lat(), p.Lon(), 0, float64(t.Year())+float64(t.YearDay())/366)
+	key := declinationKey{
+		lat:  int(math.Floor(p.Lat() / declinationCellSize)),
+		lon:  int(math.Floor(p.Lon() / declinationCellSize)),
+		year: t.Year(),
+		day:  t.YearDay(),
+	}
+	if declination, ok := declinations.get(key); ok {
+		return declination, nil
+	}
+	lat := math.Min(90, (float64(key.lat)+0.5)*declinationCellSize)
+	lon := (float64(key.lon) + 0.5) * declinationCellSize
+	field, err := igrfData.IGRF(lat, lon, 0, float64(t.Year())+float64(t.YearDay())/366)
 	if err != nil {
 		return 0, fmt.Errorf("failed to compute magnetic declination: %w", err)
 	}
-	return normalize(unit.Angle(field.Declination) * unit.Degree), nil
+	declination := normalize(unit.Angle(field.Declination) * unit.Degree)
+	declinations.set(key, declination)
+	return declination, nil
+}
+
+// ToMagnetic converts many bearings to magnetic bearings using the same declination, such as the bearings from a
+// single origin to each group in a PICTURE. Look up the declination once with Declination and pass it here, rather
+// than looking it up for each bearing.
+func ToMagnetic(bearings []Bearing, declination unit.Angle) []Bearing {
+	magnetic := make([]Bearing, len(bearings))
+	for i, bearing := range bearings {
+		magnetic[i] = bearing.Magnetic(declination)
+	}
+	return magnetic
 }
@@ -0,0 +1,61 @@
+package bearings
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeclinationCache(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, point := range []orb.Point{
+		{41.6, 42.2},    // Caucasus
+		{-115.1, 36.2},  // Nevada
+		{145.7, 15.1},   // Marianas
+		{-179.99, 15.1}, // Antimeridian
+		{10, 89.99},     // North pole
+		{-60, -89.99},   // South pole
+	} {
+		t.Run(fmt.Sprint(point), func(t *testing.T) {
+			t.Parallel()
+			field, err := igrfData.IGRF(point.Lat(), point.Lon(), 0, float64(now.Year())+float64(now.YearDay())/366)
+			require.NoError(t, err)
+			expected := NewTrueBearing(unit.Angle(field.Declination) * unit.Degree)
+
+			first, err := Declination(point, now)
+			require.NoError(t, err)
+			second, err := Declination(point, now.Add(time.Hour))
+			require.NoError(t, err)
+			assert.InDelta(t, first.Degrees(), second.Degrees(), 0.0001)
+
+			tolerance := 0.2
+			if point.Lat() > 89 || point.Lat() < -89 {
+				// Declination changes rapidly near the poles.
+				tolerance = 10
+			}
+			assert.InDelta(t, 0, Difference(expected, NewTrueBearing(first)).Degrees(), tolerance)
+		})
+	}
+}
+
+func TestToMagnetic(t *testing.T) {
+	t.Parallel()
+	declination := 6 * unit.Degree
+	trueBearings := []Bearing{
+		NewTrueBearing(90 * unit.Degree),
+		NewTrueBearing(3 * unit.Degree),
+		NewMagneticBearing(180 * unit.Degree),
+	}
+	magnetic := ToMagnetic(trueBearings, declination)
+	require.Len(t, magnetic, 3)
+	for i, expected := range []float64{84, 357, 180} {
+		assert.True(t, magnetic[i].IsMagnetic())
+		assert.InDelta(t, expected, magnetic[i].Degrees(), 0.0001)
+	}
+}
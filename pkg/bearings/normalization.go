@@ -9,13 +9,29 @@ import (
 
 // normalize returns the normalized angle in the range (0, 360] degrees.
 func normalize(a unit.Angle) unit.Angle {
-	θ := a.Degrees()
-	for θ < 0 {
+	θ := math.Mod(a.Degrees(), 360)
+	if θ <= 0 {
 		θ += 360
 	}
-	θ = math.Mod(θ, 360)
-	if θ == 0 {
-		θ = 360
+	return unit.Angle(θ) * unit.Degree
+}
+
+// Difference returns the smallest angle between two bearings, in the range [0, 180] degrees. This accounts for
+// wrapping around north, so the difference between 350 and 010 is 20 degrees. Both bearings should be of the same kind.
+func Difference(a, b Bearing) unit.Angle {
+	θ := math.Mod(math.Abs(a.Degrees()-b.Degrees()), 360)
+	return unit.Angle(min(θ, 360-θ)) * unit.Degree
+}
+
+// SignedDifference returns the angle from b to a, in the range (-180, 180] degrees. Positive values are clockwise. Both
+// bearings should be of the same kind.
+func SignedDifference(a, b Bearing) unit.Angle {
+	θ := math.Mod(a.Degrees()-b.Degrees(), 360)
+	switch {
+	case θ > 180:
+		θ -= 360
+	case θ <= -180:
+		θ += 360
 	}
 	return unit.Angle(θ) * unit.Degree
 }
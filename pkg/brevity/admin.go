@@ -0,0 +1,40 @@
+package brevity
+
+// AdminCommand is a command which controls the GCI itself. This is not standard brevity.
+type AdminCommand string
+
+const (
+	// MuteCommand stops the GCI from transmitting anything other than responses to admin commands.
+	MuteCommand AdminCommand = "mute"
+	// UnmuteCommand resumes transmissions after a MuteCommand.
+	UnmuteCommand AdminCommand = "unmute"
+	// SetBullseyeCommand moves the bullseye to the admin's current position.
+	SetBullseyeCommand AdminCommand = "set bullseye"
+	// ResetBullseyeCommand moves the bullseye back to the mission's bullseye.
+	ResetBullseyeCommand AdminCommand = "reset bullseye"
+	// ReloadCommand reloads configuration files which can be changed while the GCI is running.
+	ReloadCommand AdminCommand = "reload"
+)
+
+// AdminRequest is a spoken command to control the GCI, which is only carried out for admins.
+type AdminRequest struct {
+	// Callsign of the friendly aircraft making the request.
+	Callsign string
+	// Command to carry out.
+	Command AdminCommand
+	// IsAuthorized is true if the caller has been verified as an admin. Requests are unauthorized until a policy
+	// verifies the caller.
+	IsAuthorized bool
+}
+
+// AdminResponse reports the outcome of an admin command.
+type AdminResponse struct {
+	// Callsign of the friendly aircraft which made the request.
+	Callsign string
+	// Command which was requested.
+	Command AdminCommand
+	// IsAuthorized is false if the command was rejected because the caller is not an admin.
+	IsAuthorized bool
+	// Succeeded is true if the command was carried out.
+	Succeeded bool
+}
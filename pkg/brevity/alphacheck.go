@@ -1,5 +1,19 @@
 package brevity
 
+import "github.com/paulmach/orb"
+
+// PositionFormat selects how a position is reported.
+type PositionFormat string
+
+const (
+	// BullseyeFormat reports a position as a bearing and range from the bullseye. This is the default.
+	BullseyeFormat PositionFormat = "bullseye"
+	// GridFormat reports a position as an MGRS grid reference, e.g. for coordinating with ground forces.
+	GridFormat PositionFormat = "grid"
+	// LatLongFormat reports a position as decimal degrees of latitude and longitude.
+	LatLongFormat PositionFormat = "latlong"
+)
+
 // AlphaCheckRequest is a request for an ALPHA CHECK.
 // An ALPHA CHECK is a request for the friendly aircraft's position.
 // It is used by aircrews to check their position equipment, especially for aircraft without GPS.
@@ -7,6 +21,8 @@ package brevity
 type AlphaCheckRequest struct {
 	// Callsign of the friendly aircraft requesting the ALPHA CHECK.
 	Callsign string
+	// Format the position is requested in. If empty, the position is reported relative to the bullseye.
+	Format PositionFormat
 }
 
 // AlphaCheckResponse is a response to an ALPHA CHECK.
@@ -17,4 +33,8 @@ type AlphaCheckResponse struct {
 	Status bool
 	// Location of the friendly aircraft. If Status is false, this may be nil.
 	Location Bullseye
+	// Format the position was requested in. If empty, the position is reported relative to the bullseye.
+	Format PositionFormat
+	// Point is the position of the friendly aircraft, for formats other than bullseye.
+	Point orb.Point
 }
@@ -22,6 +22,21 @@ const (
 	Drag = "drag"
 )
 
+// AspectTrend describes how a contact's aspect is changing. This is not standard brevity; it follows the aspect in
+// BRAA calls, e.g. "flank, turning hot".
+type AspectTrend string
+
+const (
+	// SteadyAspect means the aspect is not changing significantly. It is not described.
+	SteadyAspect AspectTrend = ""
+	// TurningHot means the contact is turning toward the fighter.
+	TurningHot AspectTrend = "turning hot"
+	// TurningCold means the contact is turning away from the fighter.
+	TurningCold AspectTrend = "turning cold"
+	// Opening means the contact is pointed away from the fighter and the range is increasing.
+	Opening AspectTrend = "opening"
+)
+
 // AspectFromAngle computes target aspect based on the magnetic bearing from an aircraft to the target and the track direction of the target.
 func AspectFromAngle(bearing bearings.Bearing, track bearings.Bearing) Aspect {
 	if !bearing.IsMagnetic() || !track.IsMagnetic() {
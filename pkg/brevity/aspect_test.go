@@ -413,3 +413,11 @@ func TestAspectFromAngle(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkAspectFromAngle(b *testing.B) {
+	bearing := bearings.NewMagneticBearing(135 * unit.Degree)
+	track := bearings.NewMagneticBearing(290 * unit.Degree)
+	for range b.N {
+		_ = AspectFromAngle(bearing, track)
+	}
+}
@@ -1,11 +1,21 @@
 package brevity
 
+import (
+	"math"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+)
+
 type ContactCategory int
 
 const (
 	Aircraft ContactCategory = iota
 	FixedWing
 	RotaryWing
+	// Surface is ships and ground units. The radar tracks only aircraft, so surface contacts come from the ground
+	// picture instead.
+	Surface
 )
 
 // BogeyDopeRequest is a request for a BOGEY DOPE.
@@ -15,6 +25,12 @@ type BogeyDopeRequest struct {
 	Callsign string
 	// Filter for the type of aircraft to include in the BOGEY DOPE.
 	Filter ContactCategory
+	// Nose limits the BOGEY DOPE to groups within this angle either side of the requester's nose. If zero, groups in
+	// any direction are included.
+	Nose unit.Angle
+	// Groups is the number of groups requested, e.g. "bogey dope, two groups". Zero or one requests only the nearest
+	// group.
+	Groups int
 }
 
 type BogeyDopeResponse struct {
@@ -22,4 +38,34 @@ type BogeyDopeResponse struct {
 	Callsign string
 	// Group which is closest to the fighter. If there are no eligible groups, this may be nil.
 	Group Group
+	// Groups are the nearest groups, ordered from nearest to furthest, if more than one group was requested and found.
+	// Group is the first of these.
+	Groups []Group
+	// Separations describe each group in Groups after the first relative to the group before it.
+	Separations []Separation
+	// Surface is the nearest hostile surface group, if surface contacts were requested and one was found. Group is nil
+	// when this is set.
+	Surface *GroundGroup
+}
+
+// Separation is the distance and direction from one group to another.
+type Separation struct {
+	// Range between the groups.
+	Range unit.Length
+	// Direction from the first group to the second.
+	Direction Track
+}
+
+// NewSeparation computes the separation between two groups from their BRAAs. Both BRAAs must be relative to the same
+// origin.
+func NewSeparation(from, to BRAA) Separation {
+	x := func(b BRAA) float64 { return b.Range().Meters() * math.Sin(b.Bearing().Value().Radians()) }
+	y := func(b BRAA) float64 { return b.Range().Meters() * math.Cos(b.Bearing().Value().Radians()) }
+	dx := x(to) - x(from)
+	dy := y(to) - y(from)
+	direction := bearings.NewMagneticBearing(unit.Angle(math.Atan2(dx, dy)) * unit.Radian)
+	return Separation{
+		Range:     unit.Length(math.Hypot(dx, dy)) * unit.Meter,
+		Direction: TrackFromBearing(direction),
+	}
 }
@@ -0,0 +1,43 @@
+package brevity
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSeparation(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name              string
+		from              BRAA
+		to                BRAA
+		expectedRange     unit.Length
+		expectedDirection Track
+	}{
+		{
+			name:              "in trail",
+			from:              NewBRAA(bearings.NewMagneticBearing(90*unit.Degree), 20*unit.NauticalMile, []unit.Length{20000 * unit.Foot}, Hot),
+			to:                NewBRAA(bearings.NewMagneticBearing(90*unit.Degree), 35*unit.NauticalMile, []unit.Length{20000 * unit.Foot}, Hot),
+			expectedRange:     15 * unit.NauticalMile,
+			expectedDirection: East,
+		},
+		{
+			name:              "abreast",
+			from:              NewBRAA(bearings.NewMagneticBearing(360*unit.Degree), 30*unit.NauticalMile, []unit.Length{20000 * unit.Foot}, Hot),
+			to:                NewBRAA(bearings.NewMagneticBearing(270*unit.Degree), 30*unit.NauticalMile, []unit.Length{20000 * unit.Foot}, Hot),
+			expectedRange:     42.43 * unit.NauticalMile,
+			expectedDirection: Southwest,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			separation := NewSeparation(test.from, test.to)
+			assert.InDelta(t, test.expectedRange.NauticalMiles(), separation.Range.NauticalMiles(), 0.01)
+			assert.Equal(t, test.expectedDirection, separation.Direction)
+		})
+	}
+}
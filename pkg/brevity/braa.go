@@ -15,6 +15,8 @@ type BRAA interface {
 	BRA
 	// Aspect of the contact.
 	Aspect() Aspect
+	// Trend describes how the contact's aspect is changing.
+	Trend() AspectTrend
 }
 
 // BRA is an abbreviated form of BRAA without aspect.
@@ -72,15 +74,22 @@ func (b *bra) Stacks() []Stack {
 type braa struct {
 	bra    BRA
 	aspect Aspect
+	trend  AspectTrend
 }
 
 func NewBRAA(b bearings.Bearing, r unit.Length, a []unit.Length, aspect Aspect) BRAA {
+	return NewBRAAWithTrend(b, r, a, aspect, SteadyAspect)
+}
+
+// NewBRAAWithTrend returns a BRAA which also describes how the contact's aspect is changing.
+func NewBRAAWithTrend(b bearings.Bearing, r unit.Length, a []unit.Length, aspect Aspect, trend AspectTrend) BRAA {
 	if !b.IsMagnetic() {
 		log.Warn().Stringer("bearing", b).Msg("bearing provided to NewBRAA should be magnetic")
 	}
 	return &braa{
 		bra:    NewBRA(b, r, a...),
 		aspect: aspect,
+		trend:  trend,
 	}
 }
 
@@ -108,3 +117,8 @@ func (b *braa) Stacks() []Stack {
 func (b *braa) Aspect() Aspect {
 	return b.aspect
 }
+
+// Trend implements [BRAA.Trend].
+func (b *braa) Trend() AspectTrend {
+	return b.trend
+}
@@ -0,0 +1,12 @@
+package brevity
+
+// DuplicateCallsignCall tells a pilot that several aircraft share their callsign, and which of them the GCI is treating
+// as the pilot's aircraft. This is not standard brevity.
+type DuplicateCallsignCall struct {
+	// Callsign shared by the aircraft.
+	Callsign string
+	// Count is the number of aircraft sharing the callsign.
+	Count int
+	// Bullseye is the location of the aircraft the GCI chose.
+	Bullseye Bullseye
+}
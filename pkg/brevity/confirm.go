@@ -0,0 +1,18 @@
+package brevity
+
+// ConfirmRequest is a request which was recognized with low confidence. The GCI asks the caller to repeat it before
+// acting on it.
+type ConfirmRequest struct {
+	// Callsign of the friendly aircraft that made the request.
+	Callsign string
+	// RequestType is the type of the unconfirmed request, e.g. "declare".
+	RequestType string
+}
+
+// ConfirmResponse asks the caller to repeat a request to confirm it.
+type ConfirmResponse struct {
+	// Callsign of the friendly aircraft that made the request.
+	Callsign string
+	// RequestType is the type of the unconfirmed request, e.g. "declare".
+	RequestType string
+}
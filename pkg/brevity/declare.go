@@ -48,6 +48,8 @@ type DeclareRequest struct {
 	Bearing bearings.Bearing
 	/// Range to the contact, if provided using BRAA.
 	Range unit.Length
+	// Area is the name of a mission-defined area the contact is in, if provided by name instead of Bullseye or BRAA.
+	Area string
 	// Altitude of the contact above sea level, rounded to the nearest thousands of feet.
 	Altitude unit.Length
 	// Track direction. Optional, used to discriminate between multiple contacts at the same location.
@@ -5,4 +5,7 @@ package brevity
 type FadedCall struct {
 	// Group which has faded.
 	Group Group
+	// Extrapolated is the bullseye of the group's estimated current position, based on its last known course and
+	// speed. Nil if the position could not be estimated.
+	Extrapolated *Bullseye
 }
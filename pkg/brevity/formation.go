@@ -0,0 +1,25 @@
+package brevity
+
+import "github.com/martinlindhe/unit"
+
+// RelativeAltitude describes an element's altitude relative to another element of the same group.
+type RelativeAltitude string
+
+const (
+	// Level means the elements are at about the same altitude.
+	Level RelativeAltitude = "level"
+	// High means the element is above the other element.
+	High RelativeAltitude = "high"
+	// Low means the element is below the other element.
+	Low RelativeAltitude = "low"
+)
+
+// LeadTrail describes a group whose contacts are split into two elements, one behind the other along the group's
+// track.
+// Reference: ATP 3-52.4 Chapter IV section 3.
+type LeadTrail struct {
+	// Separation is the range between the lead and trail elements, measured along the group's track.
+	Separation unit.Length
+	// TrailAltitude is the trail element's altitude relative to the lead element.
+	TrailAltitude RelativeAltitude
+}
@@ -0,0 +1,17 @@
+package brevity
+
+import (
+	"time"
+
+	"github.com/martinlindhe/unit"
+)
+
+// FrequencyChangeCall announces that the GCI will PUSH to a new frequency, such as when a comm plan rotates.
+type FrequencyChangeCall struct {
+	// From is the frequency the GCI is leaving.
+	From unit.Frequency
+	// To is the frequency the GCI will listen and transmit on instead.
+	To unit.Frequency
+	// In is how long until the change.
+	In time.Duration
+}
@@ -0,0 +1,24 @@
+package brevity
+
+import "github.com/martinlindhe/unit"
+
+// GameplanRequest is a request to store a flight's briefed gameplan, or to recall it. This is not standard brevity.
+type GameplanRequest struct {
+	// Callsign of the friendly aircraft making the request.
+	Callsign string
+	// Gameplan is the briefed gameplan in the flight's own words, e.g. "skate 30 mile commit". If empty, the flight is
+	// asking the GCI to recall its stored gameplan.
+	Gameplan string
+	// CommitRange is the commit range briefed in the gameplan, or zero if none was briefed.
+	CommitRange unit.Length
+}
+
+// GameplanResponse confirms a flight's stored gameplan.
+type GameplanResponse struct {
+	// Callsign of the friendly aircraft which made the request.
+	Callsign string
+	// Gameplan is the flight's stored gameplan. If empty, the flight has no gameplan on file.
+	Gameplan string
+	// IsRecall is true if the flight asked the GCI to recall its gameplan, or false if it stored a new gameplan.
+	IsRecall bool
+}
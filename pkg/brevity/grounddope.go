@@ -0,0 +1,36 @@
+package brevity
+
+// GroundDopeRequest is a request for the nearest hostile ground group to the requesting aircraft, such as from an
+// attack aircraft or helicopter flying close air support. This is not standard brevity; it is loosely based on the
+// TROOPS IN CONTACT call used by ground forces requesting close air support.
+type GroundDopeRequest struct {
+	// Callsign of the friendly aircraft making the request.
+	Callsign string
+}
+
+// GroundDopeResponse reports the nearest hostile ground group to the requesting aircraft.
+type GroundDopeResponse struct {
+	// Callsign of the friendly aircraft which made the request.
+	Callsign string
+	// Group is the nearest hostile ground group. If there are no hostile ground groups nearby, this is nil.
+	Group *GroundGroup
+}
+
+// GroundGroup describes the location and composition of a group of ground units.
+type GroundGroup struct {
+	// BRA from the requesting aircraft to the center of the group. The altitude is the requesting aircraft's altitude
+	// and should not be reported.
+	BRA BRA
+	// Bullseye of the center of the group. This may be nil if the bullseye is unknown.
+	Bullseye *Bullseye
+	// Grid is the MGRS grid reference of the center of the group. This may be empty if the group is outside of the
+	// MGRS grid.
+	Grid string
+	// Units is the number of units in the group.
+	Units int
+	// Kinds are broad categories of the units in the group, such as "armor" or "air defense", ordered from most to
+	// least important to aircraft.
+	Kinds []string
+	// Platforms are the unit types in the group, such as "SA-11" or "Moskva", from most to least numerous.
+	Platforms []string
+}
@@ -35,6 +35,8 @@ type Group interface {
 	Declaration() Declaration
 	// SetDeclaration sets the group's friend or foe status.
 	SetDeclaration(Declaration)
+	// LeadTrail describes the group's lead-trail formation. This is nil if the group is not in a lead-trail formation.
+	LeadTrail() *LeadTrail
 	// Heavy is true if the group contacts 3 or more contacts.
 	Heavy() bool
 	// Platforms are the NATO reporting names of the group's aircraft platforms (for Soviet/Russian/Chinese aircraft) or
@@ -42,6 +44,9 @@ type Group interface {
 	Platforms() []string
 	// High is true if the aircraft altitude is above 40,000 feet.
 	High() bool
+	// AboveGroundLevel is the height of the group's highest contact above the terrain beneath it. The second return
+	// value is false if the terrain elevation is unknown.
+	AboveGroundLevel() (unit.Length, bool)
 	// Fast is true if the group's speed is 600-900kts ground speed or 1.0-1.5 Mach.
 	Fast() bool
 	// VeryFast is true is the group's speed is above 900kts ground speed or 1.5 Mach.
@@ -54,4 +59,13 @@ type Group interface {
 	String() string
 	// ObjectIDs returns the object IDs of all contacts in the group.
 	ObjectIDs() []uint64
+	// Tags returns the tags attached to any of the group's contacts, in alphabetical order.
+	Tags() []string
 }
+
+const (
+	// TagHVAA marks a High Value Airborne Asset, such as an AWACS or tanker, whose protection takes priority.
+	TagHVAA = "HVAA"
+	// TagDoNotEngage marks a contact which friendly aircraft must not engage.
+	TagDoNotEngage = "DO NOT ENGAGE"
+)
@@ -0,0 +1,25 @@
+package brevity
+
+import (
+	"time"
+
+	"github.com/martinlindhe/unit"
+)
+
+// HealthRequest is a request for the GCI to report its own health, made by calling the GCI without a pilot callsign,
+// e.g. "SKYEYE, STATUS".
+type HealthRequest struct{}
+
+// HealthResponse is a brief summary of the GCI's health.
+type HealthResponse struct {
+	// HasTelemetry is false if no telemetry has been received since the GCI started.
+	HasTelemetry bool
+	// TelemetryAge is how long ago the telemetry was last updated.
+	TelemetryAge time.Duration
+	// Contacts is the number of contacts being tracked.
+	Contacts int
+	// Frequencies the GCI is monitoring.
+	Frequencies []unit.Frequency
+	// Degraded are the names of the parts of the GCI which are turned off or not working, e.g. "broadcasts".
+	Degraded []string
+}
@@ -0,0 +1,26 @@
+package brevity
+
+// HVAAUrgency describes how closely a hostile group threatens a High Value Airborne Asset.
+type HVAAUrgency int
+
+const (
+	// HVAAThreatened means a hostile group is within the HVAA's protection range.
+	HVAAThreatened HVAAUrgency = iota
+	// HVAAThreatClosing means a hostile group has closed to within half of the protection range.
+	HVAAThreatClosing
+	// HVAAThreatImminent means a hostile group has closed to within a quarter of the protection range.
+	HVAAThreatImminent
+)
+
+// HVAAThreatCall directs friendly fighters to protect a High Value Airborne Asset (HVAA), such as a tanker or AWACS,
+// from a hostile group.
+type HVAAThreatCall struct {
+	// Callsigns of the friendly fighters which should protect the HVAA.
+	Callsigns []string
+	// HVAA is the callsign of the threatened asset.
+	HVAA string
+	// Group that is threatening the HVAA.
+	Group Group
+	// Urgency increases as the hostile group closes on the HVAA.
+	Urgency HVAAUrgency
+}
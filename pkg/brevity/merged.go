@@ -11,6 +11,12 @@ type MergedCall struct {
 	Group Group
 }
 
+// CleanCall announces that friendly aircraft which were in the merge are now clear of hostile contacts.
+type CleanCall struct {
+	// Callsigns of the friendly aircraft which have left the merge.
+	Callsigns []string
+}
+
 const (
 	// MergeEntryDistance is the distance at which contacts are considered to enter the merge.
 	MergeEntryDistance = 3 * unit.NauticalMile
@@ -0,0 +1,28 @@
+package brevity
+
+import "github.com/martinlindhe/unit"
+
+// RelativePosition is the position of a point relative to an aircraft's nose.
+type RelativePosition int
+
+const (
+	// OnNose means the point is within 30 degrees either side of the aircraft's nose.
+	OnNose RelativePosition = iota
+	// OffLeft means the point is more than 30 degrees left of the aircraft's nose.
+	OffLeft
+	// OffRight means the point is more than 30 degrees right of the aircraft's nose.
+	OffRight
+)
+
+// MEZWarningCall warns a friendly aircraft that its current track will take it into the Missile Engagement Zone (MEZ)
+// of a hostile SAM site. This is not standard brevity; it is loosely based on the THREAT call.
+type MEZWarningCall struct {
+	// Callsign of the friendly aircraft approaching the MEZ.
+	Callsign string
+	// System is the common name of the SAM system, such as "SA-10".
+	System string
+	// Position of the site relative to the friendly aircraft's nose.
+	Position RelativePosition
+	// Range along the friendly aircraft's track to the edge of the MEZ.
+	Range unit.Length
+}
@@ -0,0 +1,18 @@
+package brevity
+
+// MuteRequest is a request to stop or resume THREAT calls and PICTURE broadcasts to the requesting flight. Requests
+// made by the flight are still answered while broadcasts are stopped. This is not standard brevity.
+type MuteRequest struct {
+	// Callsign of the friendly aircraft making the request.
+	Callsign string
+	// Muted is true to stop broadcasts, or false to resume them.
+	Muted bool
+}
+
+// MuteResponse confirms that broadcasts to a flight were stopped or resumed.
+type MuteResponse struct {
+	// Callsign of the friendly aircraft which made the request.
+	Callsign string
+	// Muted is true if broadcasts to the flight are now stopped.
+	Muted bool
+}
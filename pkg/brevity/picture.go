@@ -4,13 +4,26 @@ package brevity
 type PictureRequest struct {
 	// Callsign of the friendly aircraft requesting the PICTURE.
 	Callsign string
+	// BRAA is true if the caller asked for a PICTURE relative to their own aircraft instead of BULLSEYE.
+	BRAA bool
+	// Full is true if the caller asked for every group to be described in detail, rather than only the highest
+	// priority groups.
+	Full bool
 }
 
 // PICTURE is a report to establish a tactical air image.
 // Reference: ATP 3-52.4 Chapter IV section 9.
 type PictureResponse struct {
+	// Callsign of the friendly aircraft the PICTURE is relative to. This is empty for a PICTURE relative to BULLSEYE,
+	// which is broadcast to all players.
+	Callsign string
 	// Count is the total number of groups in the PICTURE.
 	Count int
-	// Groups included in the PICTURE. This is a maximum of 3 groups.
+	// Groups included in the PICTURE, ordered from highest to lowest priority. Group locations are given as BRAA if
+	// Callsign is set, otherwise as BULLSEYE. This may be fewer than Count, in which case the remaining groups are
+	// summarized.
 	Groups []Group
+	// FurthestBullseye is the location of the group furthest from BULLSEYE among the groups not included in Groups.
+	// This is nil if all groups are included.
+	FurthestBullseye *Bullseye
 }
@@ -0,0 +1,13 @@
+package brevity
+
+// SayAgainRequest is a request for the GCI to repeat its last transmission to the caller.
+type SayAgainRequest struct {
+	// Callsign of the friendly aircraft that made the request.
+	Callsign string
+}
+
+// NothingToRepeatResponse reports that the GCI has not transmitted anything to the caller which it could repeat.
+type NothingToRepeatResponse struct {
+	// Callsign of the friendly aircraft that made the request.
+	Callsign string
+}
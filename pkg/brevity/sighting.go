@@ -0,0 +1,34 @@
+package brevity
+
+// Sighting is a pilot's report of whether they can see a hostile or friendly group.
+type Sighting string
+
+const (
+	// Tally means the pilot has sighted the target group.
+	Tally Sighting = "tally"
+	// NoJoy means the pilot has not sighted the target group, or has lost sight of it.
+	NoJoy Sighting = "no joy"
+	// Visual means the pilot has sighted a friendly aircraft.
+	Visual Sighting = "visual"
+	// Blind means the pilot has not sighted a friendly aircraft.
+	Blind Sighting = "blind"
+	// Press means the pilot is continuing the attack, which implies a tally on the target group.
+	Press Sighting = "press"
+)
+
+// SightingRequest is a pilot's report of a sighting. A TALLY or PRESS on the target group stops further THREAT calls
+// about that group to the pilot, since the pilot can see it. A NO JOY resumes them and asks for an updated BRAA.
+type SightingRequest struct {
+	// Callsign of the friendly aircraft making the report.
+	Callsign string
+	// Sighting reported by the pilot.
+	Sighting Sighting
+}
+
+// SightingResponse acknowledges a pilot's report of a sighting.
+type SightingResponse struct {
+	// Callsign of the friendly aircraft which made the report.
+	Callsign string
+	// Sighting reported by the pilot.
+	Sighting Sighting
+}
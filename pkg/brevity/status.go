@@ -0,0 +1,27 @@
+package brevity
+
+import "github.com/martinlindhe/unit"
+
+// StatusRequest is a request from a fighter for an update on the group it was last given, e.g. by a BOGEY DOPE or
+// SNAPLOCK. Rather than repeating the full BRAA, the response describes what has changed since the last call.
+type StatusRequest struct {
+	// Callsign of the friendly aircraft requesting the STATUS.
+	Callsign string
+}
+
+// StatusResponse describes changes to a fighter's target group since the last call.
+type StatusResponse struct {
+	// Callsign of the friendly aircraft requesting the STATUS.
+	Callsign string
+	// Group is the target group, with BRAA relative to the fighter. If the group is no longer on the scope, this is nil.
+	Group Group
+	// RangeChange is the change in range to the group since the last call. Negative values mean the range has closed.
+	RangeChange unit.Length
+	// PreviousAspect is the group's aspect at the last call.
+	PreviousAspect Aspect
+	// AltitudeChange is the change in the group's altitude since the last call.
+	AltitudeChange unit.Length
+	// NewThreats are other groups which have entered THREAT range of the fighter since the last call, with BRAA
+	// relative to the fighter.
+	NewThreats []Group
+}
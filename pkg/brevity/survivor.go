@@ -0,0 +1,39 @@
+package brevity
+
+// SurvivorCall alerts friendly aircraft that a friendly pilot has ejected, so that a Combat Search and Rescue (CSAR)
+// effort can begin. This is not standard brevity.
+type SurvivorCall struct {
+	// Callsign of the aircraft the pilot ejected from. This may be empty if the aircraft could not be identified.
+	Callsign string
+	// Bullseye of the ejection.
+	Bullseye Bullseye
+}
+
+// SurvivorRequest is a request for a vector to the nearest downed friendly pilot.
+type SurvivorRequest struct {
+	// Callsign of the friendly aircraft making the request.
+	Callsign string
+}
+
+// SurvivorResponse reports a vector to the nearest downed friendly pilot.
+type SurvivorResponse struct {
+	// Callsign of the friendly aircraft which made the request.
+	Callsign string
+	// Survivor is the nearest downed friendly pilot. If there are no downed friendly pilots, this is nil.
+	Survivor *Survivor
+}
+
+// Survivor describes the location of a downed friendly pilot.
+type Survivor struct {
+	// Callsign of the aircraft the pilot ejected from. This may be empty if the aircraft could not be identified.
+	Callsign string
+	// IsOverhead is true if the requesting aircraft is overhead the survivor. If true, BRA is not reported.
+	IsOverhead bool
+	// BRA from the requesting aircraft to the survivor. The altitude is the requesting aircraft's altitude and should
+	// not be reported.
+	BRA BRA
+	// Bullseye of the survivor.
+	Bullseye *Bullseye
+	// Grid is the MGRS grid reference of the survivor. This may be empty if the survivor is outside of the MGRS grid.
+	Grid string
+}
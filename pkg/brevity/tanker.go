@@ -0,0 +1,31 @@
+package brevity
+
+import "github.com/martinlindhe/unit"
+
+// TankerRequest is a request for a vector to the nearest friendly tanker. This is not standard brevity.
+type TankerRequest struct {
+	// Callsign of the friendly aircraft making the request.
+	Callsign string
+}
+
+// TankerResponse reports a vector to the nearest friendly tanker.
+type TankerResponse struct {
+	// Callsign of the friendly aircraft which made the request.
+	Callsign string
+	// Tanker is the nearest friendly tanker. If there are no friendly tankers, this is nil.
+	Tanker *Tanker
+}
+
+// Tanker describes the location of a friendly tanker.
+type Tanker struct {
+	// Callsign of the tanker. This may be empty if the tanker's callsign could not be parsed.
+	Callsign string
+	// Platform is the tanker's aircraft type, e.g. "KC-135". This may be empty if the type is unknown.
+	Platform string
+	// BRAA from the requesting aircraft to the tanker.
+	BRAA BRAA
+	// TACAN channel of the tanker, e.g. "51X". Empty if not configured.
+	TACAN string
+	// Frequency the tanker monitors for refueling. Zero if not configured.
+	Frequency unit.Frequency
+}
@@ -0,0 +1,13 @@
+package brevity
+
+// ThreatRingCall warns a friendly aircraft that it has flown into the engagement zone of a hostile SAM or AAA site.
+// This is not standard brevity; it is loosely based on the THREAT call.
+type ThreatRingCall struct {
+	// Callsign of the friendly aircraft inside the engagement zone.
+	Callsign string
+	// System is the common name of the SAM or AAA system, such as "SA-11".
+	System string
+	// BRA from the friendly aircraft to the site. The altitude is the friendly aircraft's altitude and should not be
+	// reported.
+	BRA BRA
+}
@@ -0,0 +1,29 @@
+package brevity
+
+// TrainingRequest is a request to turn training commentary on or off for the requesting flight.
+type TrainingRequest struct {
+	// Callsign of the friendly aircraft making the request.
+	Callsign string
+	// Enabled is true to turn training commentary on, or false to turn it off.
+	Enabled bool
+}
+
+// TrainingResponse confirms a change to a flight's training commentary.
+type TrainingResponse struct {
+	// Callsign of the friendly aircraft which made the request.
+	Callsign string
+	// Enabled is true if training commentary is now on for the flight.
+	Enabled bool
+}
+
+// CommentaryCall is instructional commentary for new pilots about a group the GCI just described. It explains why
+// the group matters and suggests a gameplan. This is not standard brevity.
+type CommentaryCall struct {
+	// Callsign of the friendly aircraft the commentary is addressed to. If empty, the commentary follows a broadcast
+	// and is addressed to everyone on frequency.
+	Callsign string
+	// Group the commentary is about.
+	Group Group
+	// IsPriority is true if the group was described first in a PICTURE because it is the highest priority.
+	IsPriority bool
+}
@@ -13,3 +13,8 @@ type SayAgainResponse struct {
 	// This may be empty if the GCI is unsure of the caller's identity.
 	Callsign string
 }
+
+// StandbyResponse tells callers on a frequency that a transmission was discarded because the GCI is too busy to
+// recognize it, so that the caller knows to repeat their request rather than wait for an answer. The caller is unknown,
+// since the transmission was never recognized.
+type StandbyResponse struct{}
@@ -0,0 +1,35 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeAdminResponse implements [Composer.ComposeAdminResponse].
+func (c *composer) ComposeAdminResponse(response brevity.AdminResponse) NaturalLanguageResponse {
+	var outcome string
+	switch {
+	case !response.IsAuthorized:
+		outcome = "unable, you are not authorized"
+	case !response.Succeeded:
+		outcome = fmt.Sprintf("unable to %s", response.Command)
+	case response.Command == brevity.MuteCommand:
+		outcome = "going silent"
+	case response.Command == brevity.UnmuteCommand:
+		outcome = "back on the air"
+	case response.Command == brevity.SetBullseyeCommand:
+		outcome = "bullseye set to your position"
+	case response.Command == brevity.ResetBullseyeCommand:
+		outcome = "bullseye reset to mission bullseye"
+	case response.Command == brevity.ReloadCommand:
+		outcome = "configuration reloaded"
+	default:
+		outcome = "copy"
+	}
+	reply := fmt.Sprintf("%s, %s, %s.", response.Callsign, c.callsign, outcome)
+	return NaturalLanguageResponse{
+		Subtitle: reply,
+		Speech:   reply,
+	}
+}
@@ -10,6 +10,12 @@ import (
 // ComposeAlphaCheckResponse implements [Composer.ComposeAlphaCheckResponse].
 func (c *composer) ComposeAlphaCheckResponse(response brevity.AlphaCheckResponse) NaturalLanguageResponse {
 	if response.Status {
+		if position, ok := c.composePosition(response); ok {
+			return NaturalLanguageResponse{
+				Subtitle: fmt.Sprintf("%s, %s, contact, %s", response.Callsign, c.callsign, position.Subtitle),
+				Speech:   fmt.Sprintf("%s, %s, contact, %s", response.Callsign, c.callsign, position.Speech),
+			}
+		}
 		if !response.Location.Bearing().IsMagnetic() {
 			log.Error().Stringer("bearing", response.Location.Bearing()).Msg("bearing provided to ComposeAlphaCheckResponse should be magnetic")
 		}
@@ -19,14 +25,14 @@ func (c *composer) ComposeAlphaCheckResponse(response brevity.AlphaCheckResponse
 				response.Callsign,
 				c.callsign,
 				response.Location.Bearing().String(),
-				int(response.Location.Distance().NauticalMiles()),
+				c.composeRange(response.Location.Distance()),
 			),
 			Speech: fmt.Sprintf(
 				"%s, %s, contact, alpha check bullseye %s, %d",
 				response.Callsign,
 				c.callsign,
-				PronounceBearing(response.Location.Bearing()),
-				int(response.Location.Distance().NauticalMiles()),
+				c.pronounceBearing(response.Location.Bearing()),
+				c.composeRange(response.Location.Distance()),
 			),
 		}
 	}
@@ -37,3 +43,20 @@ func (c *composer) ComposeAlphaCheckResponse(response brevity.AlphaCheckResponse
 		Speech:   reply,
 	}
 }
+
+// composePosition composes the caller's position in the requested format. The second return value is false if the
+// position should be reported relative to the bullseye instead.
+func (c *composer) composePosition(response brevity.AlphaCheckResponse) (NaturalLanguageResponse, bool) {
+	switch response.Format {
+	case brevity.GridFormat:
+		return composeGridPoint(response.Point)
+	case brevity.LatLongFormat:
+		position := composeLatLong(response.Point)
+		return NaturalLanguageResponse{
+			Subtitle: "position " + position.Subtitle,
+			Speech:   "position " + position.Speech,
+		}, true
+	default:
+		return NaturalLanguageResponse{}, false
+	}
+}
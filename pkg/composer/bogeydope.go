@@ -2,6 +2,7 @@ package composer
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/rs/zerolog/log"
@@ -9,8 +10,11 @@ import (
 
 // ComposeBogeyDopeResponse implements [Composer.ComposeBogeyDopeResponse].
 func (c *composer) ComposeBogeyDopeResponse(response brevity.BogeyDopeResponse) NaturalLanguageResponse {
+	if response.Surface != nil {
+		return c.composeSurfaceBogeyDope(response.Callsign, response.Surface)
+	}
 	if response.Group == nil {
-		reply := fmt.Sprintf("%s, %s", response.Callsign, brevity.Clean)
+		reply := fmt.Sprintf("%s, %s", response.Callsign, c.clean())
 		return NaturalLanguageResponse{
 			Subtitle: reply,
 			Speech:   reply,
@@ -20,8 +24,56 @@ func (c *composer) ComposeBogeyDopeResponse(response brevity.BogeyDopeResponse)
 		log.Error().Stringer("bearing", response.Group.BRAA().Bearing()).Msg("bearing provided to ComposeBogeyDopeResponse should be magnetic")
 	}
 	info := c.ComposeCoreInformationFormat(response.Group)
-	return NaturalLanguageResponse{
-		Subtitle: fmt.Sprintf("%s, %s", response.Callsign, info.Subtitle),
-		Speech:   fmt.Sprintf("%s, %s", response.Callsign, info.Speech),
+	if len(response.Groups) > 1 {
+		info = c.composeBogeyDopeGroups(response.Groups, response.Separations)
+	}
+	return c.templates.Load().renderGroup(BogeyDopeTemplate, response.Callsign, info)
+}
+
+// ordinals are used to refer to groups in a multi-group BOGEY DOPE.
+var ordinals = []string{"First", "Second", "Third"}
+
+// composeBogeyDopeGroups describes several groups from nearest to furthest, followed by the separation between each
+// group and the one before it.
+func (c *composer) composeBogeyDopeGroups(groups []brevity.Group, separations []brevity.Separation) NaturalLanguageResponse {
+	fillIn := fmt.Sprintf("%d %s, nearest first. ", len(groups), c.groupNoun(len(groups)))
+	info := c.ComposeCoreInformationFormat(groups...)
+	response := NaturalLanguageResponse{
+		Subtitle: fillIn + info.Subtitle,
+		Speech:   fillIn + info.Speech,
+	}
+	noun := c.groupNoun(1)
+	for i, separation := range separations {
+		if i+1 >= len(ordinals) {
+			break
+		}
+		s := fmt.Sprintf(
+			"%s %s %d %s %s of %s %s. ",
+			ordinals[i+1],
+			noun,
+			c.composeRange(separation.Range),
+			c.rangeUnitWord(),
+			separation.Direction,
+			strings.ToLower(ordinals[i]),
+			noun,
+		)
+		response.Subtitle += s
+		response.Speech += s
+	}
+	return response
+}
+
+// composeSurfaceBogeyDope describes the nearest hostile surface group, including its platforms.
+func (c *composer) composeSurfaceBogeyDope(callsign string, group *brevity.GroundGroup) NaturalLanguageResponse {
+	response := c.composeGroundGroup(fmt.Sprintf("%s, %s, nearest hostile surface %s", callsign, c.callsign, c.groupNoun(1)), group)
+	if len(group.Platforms) == 0 {
+		return response
+	}
+	speech := make([]string, 0, len(group.Platforms))
+	for _, platform := range group.Platforms {
+		speech = append(speech, c.pronounce(platform))
 	}
+	response.Subtitle = strings.TrimSuffix(response.Subtitle, ".") + ", " + joinPhrases(group.Platforms) + "."
+	response.Speech = strings.TrimSuffix(response.Speech, ".") + ", " + joinPhrases(speech) + "."
+	return response
 }
@@ -12,15 +12,45 @@ func (c *composer) ComposeBRAA(braa brevity.BRAA, declaration brevity.Declaratio
 	if !braa.Bearing().IsMagnetic() {
 		log.Error().Stringer("bearing", braa.Bearing()).Msg("bearing provided to ComposeBRAA should be magnetic")
 	}
-	bearing := PronounceBearing(braa.Bearing())
+	response := c.composeBRAA(braa, c.ComposeAltitude(braa.Altitude(), declaration))
+	if trend := braa.Trend(); trend != brevity.SteadyAspect {
+		response.Subtitle += ", " + string(trend)
+		response.Speech += ", " + string(trend)
+	}
+	return response
+}
+
+// composeBRAA composes a BRAA with the given description of the altitude.
+func (c *composer) composeBRAA(braa brevity.BRAA, altitude string) NaturalLanguageResponse {
+	bearing := c.pronounceBearing(braa.Bearing())
 	var aspect string
 	if braa.Aspect() != brevity.UnknownAspect {
 		aspect = string(braa.Aspect())
 	}
-	_range := int(braa.Range().NauticalMiles())
-	altitude := c.ComposeAltitude(braa.Altitude(), declaration)
+	_range := c.composeRange(braa.Range())
+	if c.dialect == RedforDialect {
+		return NaturalLanguageResponse{
+			Subtitle: fmt.Sprintf("azimuth %s, range %d, %s, %s", braa.Bearing().String(), _range, altitude, aspect),
+			Speech:   fmt.Sprintf("azimuth %s, range %d, %s, %s", bearing, _range, altitude, aspect),
+		}
+	}
 	return NaturalLanguageResponse{
 		Subtitle: fmt.Sprintf("BRAA %s/%d, %s, %s", braa.Bearing().String(), _range, altitude, aspect),
 		Speech:   fmt.Sprintf("BRAA %s, %d, %s, %s", bearing, _range, altitude, aspect),
 	}
 }
+
+// composeBRA composes a bearing and range to a point on the ground, without altitude or aspect.
+func (c *composer) composeBRA(bra brevity.BRA) NaturalLanguageResponse {
+	_range := c.composeRange(bra.Range())
+	if c.dialect == RedforDialect {
+		return NaturalLanguageResponse{
+			Subtitle: fmt.Sprintf("azimuth %s, range %d", bra.Bearing().String(), _range),
+			Speech:   fmt.Sprintf("azimuth %s, range %d", c.pronounceBearing(bra.Bearing()), _range),
+		}
+	}
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("BRA %s/%d", bra.Bearing().String(), _range),
+		Speech:   fmt.Sprintf("BRA %s, %d", c.pronounceBearing(bra.Bearing()), _range),
+	}
+}
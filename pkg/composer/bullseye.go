@@ -22,12 +22,12 @@ func (c *composer) ComposeBullseye(bullseye brevity.Bullseye) NaturalLanguageRes
 		Subtitle: fmt.Sprintf(
 			"bullseye %s/%d",
 			bullseye.Bearing().String(),
-			int(bullseye.Distance().NauticalMiles()),
+			c.composeRange(bullseye.Distance()),
 		),
 		Speech: fmt.Sprintf(
 			"bullseye %s, %d",
-			PronounceBearing(bullseye.Bearing()),
-			int(bullseye.Distance().NauticalMiles()),
+			c.pronounceBearing(bullseye.Bearing()),
+			c.composeRange(bullseye.Distance()),
 		),
 	}
 }
@@ -0,0 +1,17 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeDuplicateCallsignCall implements [Composer.ComposeDuplicateCallsignCall].
+func (c *composer) ComposeDuplicateCallsignCall(call brevity.DuplicateCallsignCall) NaturalLanguageResponse {
+	bullseye := c.ComposeBullseye(call.Bullseye)
+	reply := fmt.Sprintf("%s, %s, %d aircraft share your callsign. I have you at", call.Callsign, c.callsign, call.Count)
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s %s.", reply, bullseye.Subtitle),
+		Speech:   fmt.Sprintf("%s %s.", reply, bullseye.Speech),
+	}
+}
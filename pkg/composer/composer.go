@@ -2,7 +2,12 @@
 package composer
 
 import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
 	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
 )
 
 // Composer converts brevity responses from structured forms into natural language.
@@ -12,10 +17,16 @@ type Composer interface {
 	ComposeAlphaCheckResponse(brevity.AlphaCheckResponse) NaturalLanguageResponse
 	// ComposeBogeyDopeResponse constructs natural language brevity for responding to a BOGEY DOPE call.
 	ComposeBogeyDopeResponse(brevity.BogeyDopeResponse) NaturalLanguageResponse
+	// ComposeConfirmResponse constructs natural language brevity for asking a caller to repeat a request to confirm it.
+	ComposeConfirmResponse(brevity.ConfirmResponse) NaturalLanguageResponse
 	// ComposeDeclareResponse constructs natural language brevity for responding to a DECLARE call.
 	ComposeDeclareResponse(brevity.DeclareResponse) NaturalLanguageResponse
 	// ComposeFadedCall constructs natural language brevity for announcing a contact has faded.
 	ComposeFadedCall(brevity.FadedCall) NaturalLanguageResponse
+	// ComposeFrequencyChangeCall constructs natural language brevity for announcing the GCI will change frequency.
+	ComposeFrequencyChangeCall(brevity.FrequencyChangeCall) NaturalLanguageResponse
+	// ComposeGroundDopeResponse constructs natural language for responding to a TROOPS IN CONTACT request.
+	ComposeGroundDopeResponse(brevity.GroundDopeResponse) NaturalLanguageResponse
 	// ComposeNegativeRadarContactResponse constructs natural language brevity for saying the controller cannot find a contact on the radar.
 	ComposeNegativeRadarContactResponse(brevity.NegativeRadarContactResponse) NaturalLanguageResponse
 	// ComposePictureResponse constructs natural language brevity for responding to a PICTURE call.
@@ -26,16 +37,61 @@ type Composer interface {
 	ComposeSnaplockResponse(brevity.SnaplockResponse) NaturalLanguageResponse
 	// ComposeSpikedResponse constructs natural language brevity for responding to a SPIKED call.
 	ComposeSpikedResponse(brevity.SpikedResponse) NaturalLanguageResponse
+	// ComposeStatusResponse constructs natural language brevity for responding to a STATUS call.
+	ComposeStatusResponse(brevity.StatusResponse) NaturalLanguageResponse
 	// ComposeSunriseCall constructs natural language brevity for announcing GCI services are online.
 	ComposeSunriseCall(brevity.SunriseCall) NaturalLanguageResponse
 	// ComposeThreatCall constructs natural language brevity for announcing a threat.
 	ComposeThreatCall(brevity.ThreatCall) NaturalLanguageResponse
+	// ComposeThreatRingCall constructs natural language brevity for warning a pilot who has entered a SAM or AAA
+	// threat ring.
+	ComposeThreatRingCall(brevity.ThreatRingCall) NaturalLanguageResponse
+	// ComposeMEZWarningCall constructs natural language brevity for warning a pilot whose track will take them into a
+	// SAM MEZ.
+	ComposeMEZWarningCall(brevity.MEZWarningCall) NaturalLanguageResponse
+	// ComposeDuplicateCallsignCall constructs natural language brevity for telling a pilot which of several aircraft
+	// sharing their callsign the GCI is treating as theirs.
+	ComposeDuplicateCallsignCall(brevity.DuplicateCallsignCall) NaturalLanguageResponse
+	// ComposeHVAAThreatCall constructs natural language brevity for directing fighters to protect a threatened HVAA.
+	ComposeHVAAThreatCall(brevity.HVAAThreatCall) NaturalLanguageResponse
 	// ComposeMergedCall constructs natural language brevity for announcing a merge.
 	ComposeMergedCall(brevity.MergedCall) NaturalLanguageResponse
+	// ComposeCleanCall constructs natural language brevity for announcing that aircraft have left the merge.
+	ComposeCleanCall(brevity.CleanCall) NaturalLanguageResponse
 	// ComposeSayAgainResponse constructs natural language brevity for asking a caller to repeat their last transmission.
 	ComposeSayAgainResponse(brevity.SayAgainResponse) NaturalLanguageResponse
+	// ComposeStandbyResponse constructs natural language brevity for telling callers that the GCI is too busy to
+	// recognize their transmission.
+	ComposeStandbyResponse(brevity.StandbyResponse) NaturalLanguageResponse
+	// ComposeSightingResponse constructs natural language brevity for acknowledging a pilot's TALLY, NO JOY, VISUAL,
+	// BLIND or PRESS.
+	ComposeSightingResponse(brevity.SightingResponse) NaturalLanguageResponse
+	// ComposeHealthResponse constructs natural language for reporting the GCI's own health.
+	ComposeHealthResponse(brevity.HealthResponse) NaturalLanguageResponse
+	// ComposeNothingToRepeatResponse constructs natural language brevity for telling a caller who asked for a repeat
+	// that there is nothing to repeat.
+	ComposeNothingToRepeatResponse(brevity.NothingToRepeatResponse) NaturalLanguageResponse
 	// ComposeTripwireResponse constructs natural language brevity for educating a caller about threat monitoring.
 	ComposeTripwireResponse(brevity.TripwireResponse) NaturalLanguageResponse
+	// ComposeSurvivorCall constructs natural language for alerting friendly aircraft that a friendly pilot has ejected.
+	ComposeSurvivorCall(brevity.SurvivorCall) NaturalLanguageResponse
+	// ComposeSurvivorResponse constructs natural language for responding to a request for a vector to a survivor.
+	ComposeSurvivorResponse(brevity.SurvivorResponse) NaturalLanguageResponse
+	// ComposeTankerResponse constructs natural language for responding to a request for a vector to a tanker.
+	ComposeTankerResponse(brevity.TankerResponse) NaturalLanguageResponse
+	// ComposeMuteResponse constructs natural language for acknowledging a request to stop or resume broadcasts.
+	ComposeMuteResponse(brevity.MuteResponse) NaturalLanguageResponse
+	// ComposeTrainingResponse constructs natural language for acknowledging a request to turn training commentary on or off.
+	ComposeTrainingResponse(brevity.TrainingResponse) NaturalLanguageResponse
+	// ComposeCommentaryCall constructs plain language commentary explaining a previous call to a new pilot.
+	ComposeCommentaryCall(brevity.CommentaryCall) NaturalLanguageResponse
+	// ComposeGameplanResponse constructs natural language for confirming or recalling a flight's briefed gameplan.
+	ComposeGameplanResponse(brevity.GameplanResponse) NaturalLanguageResponse
+	// ComposeAdminResponse constructs natural language for reporting the outcome of an admin command.
+	ComposeAdminResponse(brevity.AdminResponse) NaturalLanguageResponse
+	// SetTemplates replaces the phrasing variants of some response types, as for New. If the templates are invalid,
+	// the current templates are kept and an error is returned.
+	SetTemplates(*Templates) error
 }
 
 // NaturalLanguageResponse contains the composer's responses in text form.
@@ -44,13 +100,67 @@ type NaturalLanguageResponse struct {
 	Subtitle string
 	// Speech is the input to the TTS provider.
 	Speech string
+	// Parts split a response which is too long to speak in one transmission into sequential transmissions. Subtitle
+	// and Speech always contain the entire response. If empty, the response is spoken in a single transmission.
+	Parts []NaturalLanguageResponse
 }
 
+// AltitudeFormat selects how altitudes are described.
+type AltitudeFormat string
+
+const (
+	// StandardAltitudeFormat describes friendly altitudes in angels and cherubs (e.g. "angels 12") and all other
+	// altitudes in feet (e.g. "12000"), following the multi-service brevity standard.
+	StandardAltitudeFormat AltitudeFormat = "standard"
+	// AngelsAltitudeFormat describes all altitudes in angels and cherubs, including hostile altitudes.
+	AngelsAltitudeFormat AltitudeFormat = "angels"
+	// FeetAltitudeFormat describes all altitudes in feet, including friendly altitudes.
+	FeetAltitudeFormat AltitudeFormat = "feet"
+)
+
 type composer struct {
 	// callsign of the GCI controller
 	callsign string
+	// altitudeFormat selects how altitudes are described.
+	altitudeFormat AltitudeFormat
+	// pronunciations maps lowercase aircraft platform names to how they should be spoken.
+	pronunciations map[string]string
+	// dialect selects the phraseology used.
+	dialect Dialect
+	// templates are the phrasing variants of some response types. They may be replaced while the composer is in use.
+	templates atomic.Pointer[templateSet]
+	// maxDuration is the longest a single transmission should take to speak. Zero means no limit.
+	maxDuration time.Duration
+}
+
+// New constructs a composer. The given pronunciations override or extend the default pronunciations of aircraft
+// platform names. The given templates override the built-in phrasing variants of some response types; they may be nil.
+// Templates should be loaded with LoadTemplates, which validates them. If they are invalid, the built-in variants are
+// used instead. Long responses which would take more than maxDuration to speak are shortened or split into several
+// transmissions; zero disables this.
+func New(callsign string, altitudeFormat AltitudeFormat, pronunciations map[string]string, dialect Dialect, templates *Templates, maxDuration time.Duration) Composer {
+	set, err := newTemplateSet(templates)
+	if err != nil {
+		log.Error().Err(err).Msg("invalid response templates; using built-in templates")
+		set, _ = newTemplateSet(nil)
+	}
+	c := &composer{
+		callsign:       callsign,
+		altitudeFormat: altitudeFormat,
+		pronunciations: newPronunciations(pronunciations),
+		dialect:        dialect,
+		maxDuration:    maxDuration,
+	}
+	c.templates.Store(set)
+	return c
 }
 
-func New(callsign string) Composer {
-	return &composer{callsign: callsign}
+// SetTemplates implements [Composer.SetTemplates].
+func (c *composer) SetTemplates(templates *Templates) error {
+	set, err := newTemplateSet(templates)
+	if err != nil {
+		return fmt.Errorf("invalid response templates: %w", err)
+	}
+	c.templates.Store(set)
+	return nil
 }
@@ -0,0 +1,33 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// requestNames are the spoken names of request types, keyed by the request type name used in configuration.
+var requestNames = map[string]string{
+	"alphacheck": "alpha check",
+	"bogeydope":  "bogey dope",
+	"declare":    "declare",
+	"picture":    "picture",
+	"radiocheck": "radio check",
+	"snaplock":   "snaplock",
+	"spiked":     "spiked",
+	"status":     "status",
+	"tripwire":   "tripwire",
+}
+
+// ComposeConfirmResponse implements [Composer.ComposeConfirmResponse].
+func (c *composer) ComposeConfirmResponse(response brevity.ConfirmResponse) NaturalLanguageResponse {
+	name, ok := requestNames[response.RequestType]
+	if !ok {
+		name = response.RequestType
+	}
+	reply := fmt.Sprintf("%s, confirm %s? Say again to confirm.", response.Callsign, name)
+	return NaturalLanguageResponse{
+		Subtitle: reply,
+		Speech:   reply,
+	}
+}
@@ -0,0 +1,110 @@
+package composer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+)
+
+// Dialect selects the phraseology the GCI uses.
+type Dialect string
+
+const (
+	// StandardDialect follows the multi-service brevity standard: ranges in nautical miles, altitudes in feet or
+	// angels, and bearings read digit by digit.
+	StandardDialect Dialect = "standard"
+	// RedforDialect approximates Soviet-style GCI phraseology: groups are called targets, bearings are azimuths read
+	// as whole numbers, ranges are in kilometers and altitudes are in meters.
+	RedforDialect Dialect = "redfor"
+)
+
+// groupLabel is the word which introduces a group in core information format.
+func (c *composer) groupLabel(threat bool) string {
+	label := "Group"
+	if c.dialect == RedforDialect {
+		label = "Target"
+	}
+	if threat {
+		label += " threat"
+	}
+	return label
+}
+
+// groupNoun is the lowercase word for a number of groups, e.g. "group" or "groups".
+func (c *composer) groupNoun(n int) string {
+	noun := "group"
+	if c.dialect == RedforDialect {
+		noun = "target"
+	}
+	if n != 1 {
+		noun += "s"
+	}
+	return noun
+}
+
+// targetGroup refers to the group a caller is committed on.
+func (c *composer) targetGroup() string {
+	if c.dialect == RedforDialect {
+		return "target"
+	}
+	return "target group"
+}
+
+// trackWord is the word which introduces a group's track direction.
+func (c *composer) trackWord() string {
+	if c.dialect == RedforDialect {
+		return "course"
+	}
+	return "track"
+}
+
+// clean is said when there are no groups to report.
+func (c *composer) clean() string {
+	if c.dialect == RedforDialect {
+		return "no targets"
+	}
+	return string(brevity.Clean)
+}
+
+// rangeUnits converts a range to the dialect's unit of distance.
+func (c *composer) rangeUnits(length unit.Length) float64 {
+	if c.dialect == RedforDialect {
+		return length.Kilometers()
+	}
+	return length.NauticalMiles()
+}
+
+// rangeUnitWord is the dialect's unit of distance, for the few calls which state it.
+func (c *composer) rangeUnitWord() string {
+	if c.dialect == RedforDialect {
+		return "kilometers"
+	}
+	return "miles"
+}
+
+// composeRange converts a range to a whole number in the dialect's unit of distance.
+func (c *composer) composeRange(length unit.Length) int {
+	return int(c.rangeUnits(length))
+}
+
+// pronounceBearing composes the spoken form of a bearing. The standard dialect reads each digit; the REDFOR dialect
+// reads the bearing as a whole number.
+func (c *composer) pronounceBearing(bearing bearings.Bearing) string {
+	if c.dialect == RedforDialect {
+		return strconv.Itoa(int(bearing.RoundedDegrees()))
+	}
+	return PronounceBearing(bearing)
+}
+
+// composeMetricAltitude describes an altitude in meters, rounded to the nearest hundred meters.
+func composeMetricAltitude(altitude unit.Length) string {
+	hundreds := int(math.Round(altitude.Meters() / 100))
+	if hundreds == 0 {
+		return "altitude unknown"
+	}
+	return fmt.Sprintf("%d meters", hundreds*100)
+}
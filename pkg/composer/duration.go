@@ -0,0 +1,72 @@
+package composer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// wordsPerSecond is a rough estimate of how quickly the TTS voice speaks. Digits are spoken individually in speech
+// text, so counting space-separated words is close enough for bearings and altitudes too.
+const wordsPerSecond = 2.5
+
+// estimateDuration estimates how long it takes to speak the given speech text.
+func estimateDuration(speech string) time.Duration {
+	words := len(strings.Fields(speech))
+	return time.Duration(float64(words) / wordsPerSecond * float64(time.Second))
+}
+
+// fits returns true if the response can be spoken within the maximum response duration.
+func (c *composer) fits(response NaturalLanguageResponse) bool {
+	return c.maxDuration == 0 || estimateDuration(response.Speech) <= c.maxDuration
+}
+
+// joinResponses joins the given responses into sentences separated by single spaces.
+func joinResponses(pieces ...NaturalLanguageResponse) NaturalLanguageResponse {
+	var subtitles, speeches []string
+	for _, piece := range pieces {
+		if subtitle := strings.TrimSpace(piece.Subtitle); subtitle != "" {
+			subtitles = append(subtitles, subtitle)
+		}
+		if speech := strings.TrimSpace(piece.Speech); speech != "" {
+			speeches = append(speeches, speech)
+		}
+	}
+	return NaturalLanguageResponse{
+		Subtitle: strings.Join(subtitles, " "),
+		Speech:   strings.Join(speeches, " "),
+	}
+}
+
+// splitResponse joins the given pieces into as few transmissions as possible which each fit within the maximum
+// response duration. The first piece should identify the response; each following transmission is prefixed with the
+// given addressing so that listeners can tell it is a continuation. A piece is never split, so a transmission may
+// still run long if a single piece is longer than the limit.
+func (c *composer) splitResponse(addressing string, pieces []NaturalLanguageResponse) NaturalLanguageResponse {
+	continuation := NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, continued.", addressing),
+		Speech:   fmt.Sprintf("%s, continued.", addressing),
+	}
+	var parts []NaturalLanguageResponse
+	var current []NaturalLanguageResponse
+	// isEmpty is true if the current transmission has no pieces other than the continuation prefix.
+	isEmpty := true
+	for _, piece := range pieces {
+		candidate := append(slices.Clone(current), piece)
+		if isEmpty || c.fits(joinResponses(candidate...)) {
+			current = candidate
+			isEmpty = false
+			continue
+		}
+		parts = append(parts, joinResponses(current...))
+		current = []NaturalLanguageResponse{continuation, piece}
+	}
+	parts = append(parts, joinResponses(current...))
+
+	response := joinResponses(pieces...)
+	if len(parts) > 1 {
+		response.Parts = parts
+	}
+	return response
+}
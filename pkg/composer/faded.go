@@ -29,7 +29,7 @@ func (c *composer) ComposeFadedCall(call brevity.FadedCall) NaturalLanguageRespo
 	}
 
 	if call.Group.Track() != brevity.UnknownDirection {
-		writeBoth(fmt.Sprintf(", track %s", call.Group.Track()))
+		writeBoth(fmt.Sprintf(", %s %s", c.trackWord(), call.Group.Track()))
 	}
 
 	if call.Group.Declaration() != brevity.Unable {
@@ -37,7 +37,14 @@ func (c *composer) ComposeFadedCall(call brevity.FadedCall) NaturalLanguageRespo
 	}
 
 	for _, platform := range call.Group.Platforms() {
-		writeBoth(", " + platform)
+		subtitle.WriteString(", " + platform)
+		speech.WriteString(", " + c.pronounce(platform))
+	}
+
+	if call.Extrapolated != nil {
+		bullseye := c.ComposeBullseye(*call.Extrapolated)
+		subtitle.WriteString(", last vector suggests now " + bullseye.Subtitle)
+		speech.WriteString(", last vector suggests now " + bullseye.Speech)
 	}
 
 	writeBoth(".")
@@ -76,3 +76,29 @@ func PronounceNumbers(s string) string {
 	}
 	return builder.String()
 }
+
+// phoneticAlphabet maps letters to their ICAO radiotelephony spelling.
+var phoneticAlphabet = map[rune]string{
+	'A': "alpha", 'B': "bravo", 'C': "charlie", 'D': "delta", 'E': "echo", 'F': "foxtrot", 'G': "golf",
+	'H': "hotel", 'I': "india", 'J': "juliett", 'K': "kilo", 'L': "lima", 'M': "mike", 'N': "november",
+	'O': "oscar", 'P': "papa", 'Q': "quebec", 'R': "romeo", 'S': "sierra", 'T': "tango", 'U': "uniform",
+	'V': "victor", 'W': "whiskey", 'X': "x-ray", 'Y': "yankee", 'Z': "zulu",
+}
+
+// PronounceGrid composes a text representation of an MGRS grid reference, reading each digit and spelling each letter
+// phonetically. Each space-separated part of the grid reference is separated by a pause.
+func PronounceGrid(grid string) string {
+	parts := strings.Fields(grid)
+	for i, part := range parts {
+		words := make([]string, 0, len(part))
+		for _, char := range strings.ToUpper(part) {
+			if word, ok := phoneticAlphabet[char]; ok {
+				words = append(words, word)
+			} else if unicode.IsDigit(char) {
+				words = append(words, string(char))
+			}
+		}
+		parts[i] = strings.Join(words, " ")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -71,3 +71,16 @@ func TestPronounceDecimal(t *testing.T) {
 		})
 	}
 }
+
+func TestPronounceGrid(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "3 7 tango, golf golf, 1 2 3 4, 5 6 7 8", PronounceGrid("37T GG 1234 5678"))
+	require.Equal(t, "4 sierra, x-ray alpha, 0, 0", PronounceGrid("4S XA 0 0"))
+}
+
+func TestPronounceCoordinate(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "4 2 point 0 1 2 3", PronounceCoordinate(42.0123))
+	require.Equal(t, "1 1 5 point 0 3 1 2", PronounceCoordinate(-115.0312))
+	require.Equal(t, "0 point 5 0 0 0", PronounceCoordinate(0.5))
+}
@@ -0,0 +1,25 @@
+package composer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeFrequencyChangeCall implements [Composer.ComposeFrequencyChangeCall].
+func (c *composer) ComposeFrequencyChangeCall(call brevity.FrequencyChangeCall) NaturalLanguageResponse {
+	from := composeFrequency(call.From)
+	to := composeFrequency(call.To)
+	action := "pushing to %s now"
+	if minutes := int(math.Round(call.In.Minutes())); minutes == 1 {
+		action = "will push to %s in 1 minute"
+	} else if minutes > 1 {
+		action = "will push to %s in " + strconv.Itoa(minutes) + " minutes"
+	}
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("All players on %s: GCI %s %s.", from.Subtitle, c.callsign, fmt.Sprintf(action, to.Subtitle)),
+		Speech:   fmt.Sprintf("All players on %s, GCI %s %s.", from.Speech, c.callsign, fmt.Sprintf(action, to.Speech)),
+	}
+}
@@ -0,0 +1,24 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeGameplanResponse implements [Composer.ComposeGameplanResponse].
+func (c *composer) ComposeGameplanResponse(response brevity.GameplanResponse) NaturalLanguageResponse {
+	var reply string
+	switch {
+	case response.Gameplan == "":
+		reply = fmt.Sprintf("%s, %s, no gameplan on file.", response.Callsign, c.callsign)
+	case response.IsRecall:
+		reply = fmt.Sprintf("%s, %s, your gameplan is %s.", response.Callsign, c.callsign, response.Gameplan)
+	default:
+		reply = fmt.Sprintf("%s, %s, copy gameplan, %s.", response.Callsign, c.callsign, response.Gameplan)
+	}
+	return NaturalLanguageResponse{
+		Subtitle: reply,
+		Speech:   reply,
+	}
+}
@@ -0,0 +1,1422 @@
+package composer
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update rewrites the golden files with the current output of the composer. Run `go test ./pkg/composer -update` after
+// an intentional change to the phrasing, and review the diff of the testdata directory before committing.
+var update = flag.Bool("update", false, "update golden files")
+
+const goldenDir = "testdata"
+
+// goldenCallsign is the GCI callsign used in golden tests.
+const goldenCallsign = "Focus"
+
+// testGroup is a [brevity.Group] with fixed values, for use in tests.
+type testGroup struct {
+	threat      bool
+	contacts    int
+	bullseye    *brevity.Bullseye
+	stacks      []brevity.Stack
+	track       brevity.Track
+	aspect      brevity.Aspect
+	braa        brevity.BRAA
+	declaration brevity.Declaration
+	leadTrail   *brevity.LeadTrail
+	heavy       bool
+	platforms   []string
+	high        bool
+	agl         *unit.Length
+	fast        bool
+	veryFast    bool
+	mergedWith  int
+	objectIDs   []uint64
+	tags        []string
+}
+
+var _ brevity.Group = &testGroup{}
+
+func (g *testGroup) Threat() bool                     { return g.threat }
+func (g *testGroup) SetThreat(threat bool)            { g.threat = threat }
+func (g *testGroup) Contacts() int                    { return g.contacts }
+func (g *testGroup) Bullseye() *brevity.Bullseye      { return g.bullseye }
+func (g *testGroup) Stacks() []brevity.Stack          { return g.stacks }
+func (g *testGroup) Track() brevity.Track             { return g.track }
+func (g *testGroup) Aspect() brevity.Aspect           { return g.aspect }
+func (g *testGroup) BRAA() brevity.BRAA               { return g.braa }
+func (g *testGroup) Declaration() brevity.Declaration { return g.declaration }
+func (g *testGroup) SetDeclaration(d brevity.Declaration) {
+	g.declaration = d
+}
+func (g *testGroup) LeadTrail() *brevity.LeadTrail {
+	return g.leadTrail
+}
+func (g *testGroup) Heavy() bool         { return g.heavy }
+func (g *testGroup) Platforms() []string { return g.platforms }
+func (g *testGroup) High() bool          { return g.high }
+func (g *testGroup) Fast() bool          { return g.fast }
+func (g *testGroup) VeryFast() bool      { return g.veryFast }
+func (g *testGroup) MergedWith() int     { return g.mergedWith }
+func (g *testGroup) SetMergedWith(n int) { g.mergedWith = n }
+func (g *testGroup) String() string      { return fmt.Sprintf("%+v", *g) }
+func (g *testGroup) ObjectIDs() []uint64 { return g.objectIDs }
+func (g *testGroup) Tags() []string      { return g.tags }
+
+func (g *testGroup) AboveGroundLevel() (unit.Length, bool) {
+	if g.agl == nil {
+		return 0, false
+	}
+	return *g.agl, true
+}
+
+func (g *testGroup) Altitude() unit.Length {
+	if len(g.stacks) == 0 {
+		return 0
+	}
+	return g.stacks[0].Altitude
+}
+
+func magnetic(θ float64) bearings.Bearing {
+	return bearings.NewMagneticBearing(unit.Angle(θ) * unit.Degree)
+}
+
+// formatGolden renders a response into the golden file format.
+func formatGolden(response NaturalLanguageResponse) string {
+	s := fmt.Sprintf("subtitle: %s\nspeech: %s\n", response.Subtitle, response.Speech)
+	for i, part := range response.Parts {
+		s += fmt.Sprintf("part %d subtitle: %s\npart %d speech: %s\n", i+1, part.Subtitle, i+1, part.Speech)
+	}
+	return s
+}
+
+// assertGolden compares the response to the golden file with the given name, or rewrites the golden file if the
+// -update flag is set.
+func assertGolden(t *testing.T, name string, response NaturalLanguageResponse) {
+	t.Helper()
+	path := filepath.Join(goldenDir, name+".golden")
+	actual := formatGolden(response)
+	if *update {
+		require.NoError(t, os.MkdirAll(goldenDir, 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(actual), 0o600))
+		return
+	}
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "golden file %s is missing; run `go test ./pkg/composer -update` to create it", path)
+	assert.Equal(t, string(expected), actual, "output differs from %s; if this change is intentional, run `go test ./pkg/composer -update` and review the diff", path)
+}
+
+// goldenTestCase is a structured response which is composed and compared to an approved golden file.
+type goldenTestCase struct {
+	name    string
+	compose func(Composer) NaturalLanguageResponse
+}
+
+// runGoldenTestCases composes each test case in the standard dialect and compares it to its golden file.
+func runGoldenTestCases(t *testing.T, testCases []goldenTestCase) {
+	t.Helper()
+	runDialectGoldenTestCases(t, StandardDialect, testCases)
+}
+
+// runDialectGoldenTestCases composes each test case in the given dialect and compares it to its golden file.
+func runDialectGoldenTestCases(t *testing.T, dialect Dialect, testCases []goldenTestCase) {
+	t.Helper()
+	c := New(goldenCallsign, StandardAltitudeFormat, nil, dialect, nil, 0)
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assertGolden(t, test.name, test.compose(c))
+		})
+	}
+}
+
+func TestGoldenPicture(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "picture_clean",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposePictureResponse(brevity.PictureResponse{Count: 0})
+			},
+		},
+		{
+			name: "picture_single_group",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposePictureResponse(brevity.PictureResponse{
+					Count: 1,
+					Groups: []brevity.Group{
+						&testGroup{
+							contacts:    2,
+							bullseye:    brevity.NewBullseye(magnetic(90), 40*unit.NauticalMile),
+							stacks:      brevity.Stacks(24000 * unit.Foot),
+							track:       brevity.West,
+							declaration: brevity.Hostile,
+							platforms:   []string{"Flanker"},
+						},
+					},
+				})
+			},
+		},
+		{
+			name: "picture_pronunciation",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposePictureResponse(brevity.PictureResponse{
+					Count: 1,
+					Groups: []brevity.Group{
+						&testGroup{
+							contacts:    2,
+							bullseye:    brevity.NewBullseye(magnetic(45), 30*unit.NauticalMile),
+							stacks:      brevity.Stacks(20000 * unit.Foot),
+							track:       brevity.Southwest,
+							declaration: brevity.Hostile,
+							platforms:   []string{"MB-339", "Mirage 2000"},
+						},
+					},
+				})
+			},
+		},
+		{
+			name: "picture_do_not_engage",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposePictureResponse(brevity.PictureResponse{
+					Count: 1,
+					Groups: []brevity.Group{
+						&testGroup{
+							contacts:    1,
+							bullseye:    brevity.NewBullseye(magnetic(120), 60*unit.NauticalMile),
+							stacks:      brevity.Stacks(28000 * unit.Foot),
+							track:       brevity.North,
+							declaration: brevity.Hostile,
+							platforms:   []string{"Mainstay"},
+							tags:        []string{brevity.TagDoNotEngage},
+						},
+					},
+				})
+			},
+		},
+		{
+			name: "picture_lead_trail",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposePictureResponse(brevity.PictureResponse{
+					Count: 1,
+					Groups: []brevity.Group{
+						&testGroup{
+							contacts:    4,
+							bullseye:    brevity.NewBullseye(magnetic(60), 45*unit.NauticalMile),
+							stacks:      brevity.Stacks(25000*unit.Foot, 20000*unit.Foot),
+							track:       brevity.Southwest,
+							declaration: brevity.Hostile,
+							heavy:       true,
+							platforms:   []string{"Flanker"},
+							leadTrail: &brevity.LeadTrail{
+								Separation:    5 * unit.NauticalMile,
+								TrailAltitude: brevity.High,
+							},
+						},
+					},
+				})
+			},
+		},
+		{
+			name: "picture_multiple_groups",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposePictureResponse(brevity.PictureResponse{
+					Count: 4,
+					Groups: []brevity.Group{
+						&testGroup{
+							contacts:    4,
+							bullseye:    brevity.NewBullseye(magnetic(30), 25*unit.NauticalMile),
+							stacks:      brevity.Stacks(32000*unit.Foot, 18000*unit.Foot),
+							track:       brevity.Southwest,
+							declaration: brevity.Hostile,
+							heavy:       true,
+							platforms:   []string{"Fulcrum", "Flanker"},
+							fast:        true,
+						},
+						&testGroup{
+							contacts:    1,
+							bullseye:    brevity.NewBullseye(magnetic(180), 3*unit.NauticalMile),
+							stacks:      brevity.Stacks(45000 * unit.Foot),
+							track:       brevity.North,
+							declaration: brevity.Hostile,
+							platforms:   []string{"Foxhound"},
+							high:        true,
+							veryFast:    true,
+						},
+						&testGroup{
+							contacts:    2,
+							bullseye:    brevity.NewBullseye(magnetic(270), 60*unit.NauticalMile),
+							stacks:      brevity.Stacks(500 * unit.Foot),
+							track:       brevity.UnknownDirection,
+							declaration: brevity.Hostile,
+							platforms:   []string{"Hind"},
+						},
+					},
+				})
+			},
+		},
+		{
+			name: "picture_additional_groups",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposePictureResponse(brevity.PictureResponse{
+					Count: 6,
+					Groups: []brevity.Group{
+						&testGroup{
+							contacts:    2,
+							bullseye:    brevity.NewBullseye(magnetic(90), 40*unit.NauticalMile),
+							stacks:      brevity.Stacks(24000 * unit.Foot),
+							track:       brevity.West,
+							declaration: brevity.Hostile,
+							platforms:   []string{"Flanker"},
+						},
+						&testGroup{
+							contacts:    1,
+							bullseye:    brevity.NewBullseye(magnetic(120), 45*unit.NauticalMile),
+							stacks:      brevity.Stacks(30000 * unit.Foot),
+							track:       brevity.West,
+							declaration: brevity.Hostile,
+							platforms:   []string{"Fulcrum"},
+						},
+					},
+					FurthestBullseye: brevity.NewBullseye(magnetic(330), 80*unit.NauticalMile),
+				})
+			},
+		},
+		{
+			name: "picture_braa",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposePictureResponse(brevity.PictureResponse{
+					Callsign: "mobius 1",
+					Count:    3,
+					Groups: []brevity.Group{
+						&testGroup{
+							contacts:    2,
+							braa:        brevity.NewBRAA(magnetic(60), 35*unit.NauticalMile, []unit.Length{22000 * unit.Foot}, brevity.Hot),
+							stacks:      brevity.Stacks(22000 * unit.Foot),
+							track:       brevity.Southwest,
+							declaration: brevity.Hostile,
+							platforms:   []string{"Flanker"},
+						},
+						&testGroup{
+							contacts:    1,
+							braa:        brevity.NewBRAA(magnetic(350), 70*unit.NauticalMile, []unit.Length{30000 * unit.Foot}, brevity.Flank),
+							stacks:      brevity.Stacks(30000 * unit.Foot),
+							track:       brevity.East,
+							declaration: brevity.Hostile,
+							platforms:   []string{"Fulcrum"},
+						},
+					},
+				})
+			},
+		},
+		{
+			name: "picture_braa_clean",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposePictureResponse(brevity.PictureResponse{Callsign: "mobius 1", Count: 0})
+			},
+		},
+	})
+}
+
+func TestGoldenBogeyDope(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "bogey_dope_clean",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeBogeyDopeResponse(brevity.BogeyDopeResponse{Callsign: "mobius 1"})
+			},
+		},
+		{
+			name: "bogey_dope_surface",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeBogeyDopeResponse(brevity.BogeyDopeResponse{
+					Callsign: "mobius 1",
+					Surface: &brevity.GroundGroup{
+						BRA:       brevity.NewBRA(magnetic(200), 38*unit.NauticalMile),
+						Bullseye:  brevity.NewBullseye(magnetic(180), 55*unit.NauticalMile),
+						Units:     2,
+						Kinds:     []string{"ships"},
+						Platforms: []string{"MOSCOW", "NEUSTRASH"},
+					},
+				})
+			},
+		},
+		{
+			name: "bogey_dope_hot",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeBogeyDopeResponse(brevity.BogeyDopeResponse{
+					Callsign: "mobius 1",
+					Group: &testGroup{
+						contacts:    2,
+						braa:        brevity.NewBRAA(magnetic(45), 30*unit.NauticalMile, []unit.Length{20000 * unit.Foot}, brevity.Hot),
+						stacks:      brevity.Stacks(20000 * unit.Foot),
+						track:       brevity.Southwest,
+						declaration: brevity.Hostile,
+						platforms:   []string{"Fishbed"},
+					},
+				})
+			},
+		},
+		{
+			name: "bogey_dope_flank",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeBogeyDopeResponse(brevity.BogeyDopeResponse{
+					Callsign: "mobius 1",
+					Group: &testGroup{
+						contacts:    1,
+						braa:        brevity.NewBRAA(magnetic(5), 12*unit.NauticalMile, []unit.Length{8000 * unit.Foot}, brevity.Flank),
+						stacks:      brevity.Stacks(8000 * unit.Foot),
+						track:       brevity.East,
+						declaration: brevity.Hostile,
+						platforms:   []string{"Frogfoot"},
+					},
+				})
+			},
+		},
+		{
+			name: "bogey_dope_turning_hot",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeBogeyDopeResponse(brevity.BogeyDopeResponse{
+					Callsign: "mobius 1",
+					Group: &testGroup{
+						contacts:    1,
+						braa:        brevity.NewBRAAWithTrend(magnetic(5), 12*unit.NauticalMile, []unit.Length{8000 * unit.Foot}, brevity.Flank, brevity.TurningHot),
+						stacks:      brevity.Stacks(8000 * unit.Foot),
+						track:       brevity.East,
+						declaration: brevity.Hostile,
+						platforms:   []string{"Frogfoot"},
+					},
+				})
+			},
+		},
+		{
+			name: "bogey_dope_opening",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeBogeyDopeResponse(brevity.BogeyDopeResponse{
+					Callsign: "mobius 1",
+					Group: &testGroup{
+						contacts:    2,
+						braa:        brevity.NewBRAAWithTrend(magnetic(45), 30*unit.NauticalMile, []unit.Length{20000 * unit.Foot}, brevity.Drag, brevity.Opening),
+						stacks:      brevity.Stacks(20000 * unit.Foot),
+						track:       brevity.Northeast,
+						declaration: brevity.Hostile,
+						platforms:   []string{"Fishbed"},
+					},
+				})
+			},
+		},
+		{
+			name: "bogey_dope_groups",
+			compose: func(c Composer) NaturalLanguageResponse {
+				groups := []brevity.Group{
+					&testGroup{
+						contacts:    2,
+						braa:        brevity.NewBRAA(magnetic(45), 30*unit.NauticalMile, []unit.Length{20000 * unit.Foot}, brevity.Hot),
+						stacks:      brevity.Stacks(20000 * unit.Foot),
+						track:       brevity.Southwest,
+						declaration: brevity.Hostile,
+						platforms:   []string{"Fishbed"},
+					},
+					&testGroup{
+						contacts:    1,
+						braa:        brevity.NewBRAA(magnetic(90), 45*unit.NauticalMile, []unit.Length{25000 * unit.Foot}, brevity.Flank),
+						stacks:      brevity.Stacks(25000 * unit.Foot),
+						track:       brevity.North,
+						declaration: brevity.Hostile,
+						platforms:   []string{"Fulcrum"},
+					},
+				}
+				return c.ComposeBogeyDopeResponse(brevity.BogeyDopeResponse{
+					Callsign:    "mobius 1",
+					Group:       groups[0],
+					Groups:      groups,
+					Separations: []brevity.Separation{brevity.NewSeparation(groups[0].BRAA(), groups[1].BRAA())},
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenStatus(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "status_faded",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeStatusResponse(brevity.StatusResponse{Callsign: "mobius 1", PreviousAspect: brevity.Hot})
+			},
+		},
+		{
+			name: "status_closing",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeStatusResponse(brevity.StatusResponse{
+					Callsign: "mobius 1",
+					Group: &testGroup{
+						contacts:    2,
+						braa:        brevity.NewBRAA(magnetic(45), 22*unit.NauticalMile, []unit.Length{23000 * unit.Foot}, brevity.Hot),
+						stacks:      brevity.Stacks(23000 * unit.Foot),
+						track:       brevity.Southwest,
+						declaration: brevity.Hostile,
+						platforms:   []string{"Fishbed"},
+					},
+					RangeChange:    -8 * unit.NauticalMile,
+					PreviousAspect: brevity.Flank,
+					AltitudeChange: 3000 * unit.Foot,
+				})
+			},
+		},
+		{
+			name: "status_new_threat",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeStatusResponse(brevity.StatusResponse{
+					Callsign: "mobius 1",
+					Group: &testGroup{
+						contacts:    1,
+						braa:        brevity.NewBRAA(magnetic(5), 12*unit.NauticalMile, []unit.Length{8000 * unit.Foot}, brevity.Drag),
+						stacks:      brevity.Stacks(8000 * unit.Foot),
+						track:       brevity.North,
+						declaration: brevity.Hostile,
+						platforms:   []string{"Frogfoot"},
+					},
+					RangeChange:    2 * unit.NauticalMile,
+					PreviousAspect: brevity.Drag,
+					NewThreats: []brevity.Group{
+						&testGroup{
+							threat:      true,
+							contacts:    1,
+							braa:        brevity.NewBRAA(magnetic(270), 15*unit.NauticalMile, []unit.Length{30000 * unit.Foot}, brevity.Hot),
+							stacks:      brevity.Stacks(30000 * unit.Foot),
+							track:       brevity.East,
+							declaration: brevity.Hostile,
+							platforms:   []string{"Flanker"},
+						},
+					},
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenDeclare(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "declare_furball",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeDeclareResponse(brevity.DeclareResponse{Callsign: "mobius 1", Declaration: brevity.Furball})
+			},
+		},
+		{
+			name: "declare_friendly",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeDeclareResponse(brevity.DeclareResponse{
+					Callsign:    "mobius 1",
+					Declaration: brevity.Friendly,
+					Group: &testGroup{
+						contacts:    2,
+						bullseye:    brevity.NewBullseye(magnetic(120), 15*unit.NauticalMile),
+						stacks:      brevity.Stacks(26000 * unit.Foot),
+						track:       brevity.East,
+						declaration: brevity.Friendly,
+						platforms:   []string{"Eagle"},
+					},
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenAltitudeFormat(t *testing.T) {
+	t.Parallel()
+	declare := func(format AltitudeFormat, declaration brevity.Declaration) func(Composer) NaturalLanguageResponse {
+		return func(Composer) NaturalLanguageResponse {
+			return New(goldenCallsign, format, nil, StandardDialect, nil, 0).ComposeDeclareResponse(brevity.DeclareResponse{
+				Callsign:    "mobius 1",
+				Declaration: declaration,
+				Group: &testGroup{
+					contacts:    2,
+					bullseye:    brevity.NewBullseye(magnetic(120), 15*unit.NauticalMile),
+					stacks:      brevity.Stacks(26000*unit.Foot, 800*unit.Foot),
+					track:       brevity.East,
+					declaration: declaration,
+					platforms:   []string{"Flanker"},
+				},
+			})
+		}
+	}
+	runGoldenTestCases(t, []goldenTestCase{
+		{name: "altitude_angels_hostile", compose: declare(AngelsAltitudeFormat, brevity.Hostile)},
+		{name: "altitude_feet_friendly", compose: declare(FeetAltitudeFormat, brevity.Friendly)},
+	})
+}
+
+func TestGoldenThreat(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "threat_bullseye",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeThreatCall(brevity.ThreatCall{
+					Callsigns: []string{"mobius 1", "mobius 2"},
+					Group: &testGroup{
+						threat:      true,
+						contacts:    2,
+						bullseye:    brevity.NewBullseye(magnetic(200), 35*unit.NauticalMile),
+						stacks:      brevity.Stacks(30000 * unit.Foot),
+						track:       brevity.Northeast,
+						declaration: brevity.Hostile,
+						platforms:   []string{"Flanker"},
+					},
+				})
+			},
+		},
+		{
+			name: "threat_braa",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeThreatCall(brevity.ThreatCall{
+					Callsigns: []string{"mobius 1"},
+					Group: &testGroup{
+						threat:      true,
+						contacts:    1,
+						braa:        brevity.NewBRAA(magnetic(330), 18*unit.NauticalMile, []unit.Length{15000 * unit.Foot}, brevity.Hot),
+						stacks:      brevity.Stacks(15000 * unit.Foot),
+						track:       brevity.South,
+						declaration: brevity.Hostile,
+						platforms:   []string{"Fulcrum"},
+					},
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenHVAAThreat(t *testing.T) {
+	t.Parallel()
+	group := func() brevity.Group {
+		return &testGroup{
+			threat:      true,
+			contacts:    2,
+			bullseye:    brevity.NewBullseye(magnetic(310), 55*unit.NauticalMile),
+			stacks:      brevity.Stacks(33000 * unit.Foot),
+			track:       brevity.East,
+			declaration: brevity.Hostile,
+			platforms:   []string{"Foxhound"},
+		}
+	}
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "hvaa_threatened",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeHVAAThreatCall(brevity.HVAAThreatCall{
+					Callsigns: []string{"mobius 1"},
+					HVAA:      "magic 1",
+					Group:     group(),
+					Urgency:   brevity.HVAAThreatened,
+				})
+			},
+		},
+		{
+			name: "hvaa_closing",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeHVAAThreatCall(brevity.HVAAThreatCall{
+					Callsigns: []string{"mobius 1", "mobius 2"},
+					HVAA:      "magic 1",
+					Group:     group(),
+					Urgency:   brevity.HVAAThreatClosing,
+				})
+			},
+		},
+		{
+			name: "hvaa_imminent",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeHVAAThreatCall(brevity.HVAAThreatCall{
+					Callsigns: []string{"mobius 1"},
+					HVAA:      "texaco 1",
+					Group:     group(),
+					Urgency:   brevity.HVAAThreatImminent,
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenMerged(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "merged_single",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeMergedCall(brevity.MergedCall{
+					Callsigns: []string{"mobius 1"},
+					Group:     &testGroup{contacts: 1, declaration: brevity.Hostile},
+				})
+			},
+		},
+		{
+			name: "merged_multiple",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeMergedCall(brevity.MergedCall{
+					Callsigns: []string{"mobius 1", "mobius 2"},
+					Group:     &testGroup{contacts: 3, declaration: brevity.Hostile, mergedWith: 2},
+				})
+			},
+		},
+		{
+			name: "merge_clean",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeCleanCall(brevity.CleanCall{Callsigns: []string{"mobius 1", "mobius 2"}})
+			},
+		},
+	})
+}
+
+func TestGoldenFaded(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "faded_single",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeFadedCall(brevity.FadedCall{
+					Group: &testGroup{
+						contacts:    1,
+						bullseye:    brevity.NewBullseye(magnetic(10), 50*unit.NauticalMile),
+						track:       brevity.North,
+						declaration: brevity.Hostile,
+						platforms:   []string{"Fencer"},
+					},
+				})
+			},
+		},
+		{
+			name: "faded_extrapolated",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeFadedCall(brevity.FadedCall{
+					Group: &testGroup{
+						contacts:    2,
+						bullseye:    brevity.NewBullseye(magnetic(270), 30*unit.NauticalMile),
+						track:       brevity.West,
+						declaration: brevity.Hostile,
+						platforms:   []string{"Flanker"},
+					},
+					Extrapolated: brevity.NewBullseye(magnetic(265), 42*unit.NauticalMile),
+				})
+			},
+		},
+		{
+			name: "faded_multiple",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeFadedCall(brevity.FadedCall{
+					Group: &testGroup{
+						contacts:    2,
+						bullseye:    brevity.NewBullseye(magnetic(300), 20*unit.NauticalMile),
+						track:       brevity.UnknownDirection,
+						declaration: brevity.Unable,
+					},
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenAlphaCheck(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "alpha_check_contact",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeAlphaCheckResponse(brevity.AlphaCheckResponse{
+					Callsign: "mobius 1",
+					Status:   true,
+					Location: *brevity.NewBullseye(magnetic(77), 42*unit.NauticalMile),
+				})
+			},
+		},
+		{
+			name: "alpha_check_grid",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeAlphaCheckResponse(brevity.AlphaCheckResponse{
+					Callsign: "dustoff 1",
+					Status:   true,
+					Location: *brevity.NewBullseye(magnetic(77), 42*unit.NauticalMile),
+					Format:   brevity.GridFormat,
+					Point:    orb.Point{41.6, 42.1},
+				})
+			},
+		},
+		{
+			name: "alpha_check_lat_long",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeAlphaCheckResponse(brevity.AlphaCheckResponse{
+					Callsign: "sandy 1",
+					Status:   true,
+					Location: *brevity.NewBullseye(magnetic(77), 42*unit.NauticalMile),
+					Format:   brevity.LatLongFormat,
+					Point:    orb.Point{-115.0312, 36.2345},
+				})
+			},
+		},
+		{
+			name: "alpha_check_negative_contact",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeAlphaCheckResponse(brevity.AlphaCheckResponse{Callsign: "mobius 1"})
+			},
+		},
+	})
+}
+
+func TestGoldenConfirm(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "confirm_declare",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeConfirmResponse(brevity.ConfirmResponse{Callsign: "mobius 1", RequestType: "declare"})
+			},
+		},
+		{
+			name: "confirm_bogey_dope",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeConfirmResponse(brevity.ConfirmResponse{Callsign: "mobius 1", RequestType: "bogeydope"})
+			},
+		},
+	})
+}
+
+func TestGoldenSpiked(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "spiked_status",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeSpikedResponse(brevity.SpikedResponse{
+					Callsign:    "mobius 1",
+					Status:      true,
+					Range:       22 * unit.NauticalMile,
+					Altitude:    19000 * unit.Foot,
+					Aspect:      brevity.Beam,
+					Track:       brevity.South,
+					Declaration: brevity.Hostile,
+					Contacts:    2,
+				})
+			},
+		},
+		{
+			name: "spiked_clean",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeSpikedResponse(brevity.SpikedResponse{
+					Callsign: "mobius 1",
+					Bearing:  magnetic(90),
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenSunrise(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "sunrise",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeSunriseCall(brevity.SunriseCall{
+					Frequencies: []unit.Frequency{251 * unit.Megahertz, 133 * unit.Megahertz, 30 * unit.Megahertz},
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenFrequencyChange(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "frequency_change",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeFrequencyChangeCall(brevity.FrequencyChangeCall{
+					From: 251 * unit.Megahertz,
+					To:   264.225 * unit.Megahertz,
+					In:   2 * time.Minute,
+				})
+			},
+		},
+		{
+			name: "frequency_change_now",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeFrequencyChangeCall(brevity.FrequencyChangeCall{
+					From: 251 * unit.Megahertz,
+					To:   264 * unit.Megahertz,
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenMute(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "mute_stopped",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeMuteResponse(brevity.MuteResponse{Callsign: "mobius 1", Muted: true})
+			},
+		},
+		{
+			name: "mute_resumed",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeMuteResponse(brevity.MuteResponse{Callsign: "mobius 1", Muted: false})
+			},
+		},
+	})
+}
+
+func TestGoldenTraining(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "training_response",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeTrainingResponse(brevity.TrainingResponse{Callsign: "mobius 1", Enabled: true})
+			},
+		},
+		{
+			name: "training_commentary_hot",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeCommentaryCall(brevity.CommentaryCall{
+					Callsign: "mobius 1",
+					Group: &testGroup{
+						contacts:    2,
+						braa:        brevity.NewBRAA(magnetic(45), 25*unit.NauticalMile, []unit.Length{20000 * unit.Foot}, brevity.Hot),
+						stacks:      brevity.Stacks(20000 * unit.Foot),
+						track:       brevity.Southwest,
+						declaration: brevity.Hostile,
+					},
+				})
+			},
+		},
+		{
+			name: "training_commentary_picture",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeCommentaryCall(brevity.CommentaryCall{
+					IsPriority: true,
+					Group: &testGroup{
+						contacts:    3,
+						bullseye:    brevity.NewBullseye(magnetic(270), 20*unit.NauticalMile),
+						stacks:      brevity.Stacks(34000 * unit.Foot),
+						track:       brevity.East,
+						declaration: brevity.Bogey,
+						heavy:       true,
+						high:        true,
+					},
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenAboveGroundLevel(t *testing.T) {
+	t.Parallel()
+	agl := func(height unit.Length) *unit.Length { return &height }
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "agl_on_the_deck",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposePictureResponse(brevity.PictureResponse{
+					Count: 2,
+					Groups: []brevity.Group{
+						&testGroup{
+							contacts:    2,
+							bullseye:    brevity.NewBullseye(magnetic(200), 30*unit.NauticalMile),
+							stacks:      brevity.Stacks(6000 * unit.Foot),
+							agl:         agl(200 * unit.Foot),
+							track:       brevity.North,
+							declaration: brevity.Hostile,
+							platforms:   []string{"Hind"},
+						},
+						&testGroup{
+							contacts:    1,
+							bullseye:    brevity.NewBullseye(magnetic(90), 20*unit.NauticalMile),
+							stacks:      brevity.Stacks(7000 * unit.Foot),
+							agl:         agl(300 * unit.Foot),
+							track:       brevity.North,
+							declaration: brevity.Friendly,
+						},
+					},
+				})
+			},
+		},
+		{
+			name: "agl_bogey_dope",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeBogeyDopeResponse(brevity.BogeyDopeResponse{
+					Callsign: "mobius 1",
+					Group: &testGroup{
+						contacts:    1,
+						braa:        brevity.NewBRAA(magnetic(45), 15*unit.NauticalMile, []unit.Length{9000 * unit.Foot}, brevity.Hot),
+						stacks:      brevity.Stacks(9000 * unit.Foot),
+						agl:         agl(2200 * unit.Foot),
+						track:       brevity.Southwest,
+						declaration: brevity.Hostile,
+						platforms:   []string{"Frogfoot"},
+					},
+				})
+			},
+		},
+	})
+}
+
+func TestFormatGolden(t *testing.T) {
+	t.Parallel()
+	actual := formatGolden(NaturalLanguageResponse{Subtitle: "a/b", Speech: "a, b"})
+	assert.Equal(t, "subtitle: a/b\nspeech: a, b\n", actual)
+}
+
+func TestGoldenMaxResponseDuration(t *testing.T) {
+	t.Parallel()
+	picture := func(maxDuration time.Duration) func(Composer) NaturalLanguageResponse {
+		return func(Composer) NaturalLanguageResponse {
+			return New(goldenCallsign, StandardAltitudeFormat, nil, StandardDialect, nil, maxDuration).ComposePictureResponse(brevity.PictureResponse{
+				Count: 3,
+				Groups: []brevity.Group{
+					&testGroup{
+						contacts:    4,
+						bullseye:    brevity.NewBullseye(magnetic(30), 25*unit.NauticalMile),
+						stacks:      brevity.Stacks(32000*unit.Foot, 18000*unit.Foot),
+						track:       brevity.Southwest,
+						declaration: brevity.Hostile,
+						heavy:       true,
+						platforms:   []string{"Fulcrum", "Flanker"},
+					},
+					&testGroup{
+						contacts:    2,
+						bullseye:    brevity.NewBullseye(magnetic(270), 60*unit.NauticalMile),
+						stacks:      brevity.Stacks(500 * unit.Foot),
+						track:       brevity.East,
+						declaration: brevity.Hostile,
+						platforms:   []string{"Hind"},
+					},
+					&testGroup{
+						contacts:    1,
+						bullseye:    brevity.NewBullseye(magnetic(180), 40*unit.NauticalMile),
+						stacks:      brevity.Stacks(30000 * unit.Foot),
+						track:       brevity.North,
+						declaration: brevity.Hostile,
+						platforms:   []string{"Foxbat"},
+					},
+				},
+			})
+		}
+	}
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name:    "max_duration_trimmed",
+			compose: picture(18 * time.Second),
+		},
+		{
+			name:    "max_duration_split",
+			compose: picture(10 * time.Second),
+		},
+	})
+}
+
+func TestGoldenRedforDialect(t *testing.T) {
+	t.Parallel()
+	runDialectGoldenTestCases(t, RedforDialect, []goldenTestCase{
+		{
+			name: "redfor_picture",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposePictureResponse(brevity.PictureResponse{
+					Count: 3,
+					Groups: []brevity.Group{
+						&testGroup{
+							contacts:    4,
+							bullseye:    brevity.NewBullseye(magnetic(30), 25*unit.NauticalMile),
+							stacks:      brevity.Stacks(32000*unit.Foot, 18000*unit.Foot),
+							track:       brevity.Southwest,
+							declaration: brevity.Hostile,
+							heavy:       true,
+							platforms:   []string{"F-15C", "F-16C"},
+						},
+						&testGroup{
+							contacts:    1,
+							bullseye:    brevity.NewBullseye(magnetic(270), 60*unit.NauticalMile),
+							stacks:      brevity.Stacks(24000 * unit.Foot),
+							track:       brevity.East,
+							declaration: brevity.Hostile,
+							platforms:   []string{"F/A-18C"},
+						},
+					},
+					FurthestBullseye: brevity.NewBullseye(magnetic(330), 80*unit.NauticalMile),
+				})
+			},
+		},
+		{
+			name: "redfor_picture_clean",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposePictureResponse(brevity.PictureResponse{Count: 0})
+			},
+		},
+		{
+			name: "redfor_bogey_dope",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeBogeyDopeResponse(brevity.BogeyDopeResponse{
+					Callsign: "mobius 1",
+					Group: &testGroup{
+						contacts:    2,
+						braa:        brevity.NewBRAA(magnetic(45), 30*unit.NauticalMile, []unit.Length{20000 * unit.Foot}, brevity.Hot),
+						stacks:      brevity.Stacks(20000 * unit.Foot),
+						track:       brevity.Southwest,
+						declaration: brevity.Hostile,
+						platforms:   []string{"F-16C"},
+					},
+				})
+			},
+		},
+		{
+			name: "redfor_status",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeStatusResponse(brevity.StatusResponse{
+					Callsign: "mobius 1",
+					Group: &testGroup{
+						contacts:    2,
+						braa:        brevity.NewBRAA(magnetic(45), 22*unit.NauticalMile, []unit.Length{23000 * unit.Foot}, brevity.Hot),
+						stacks:      brevity.Stacks(23000 * unit.Foot),
+						track:       brevity.Southwest,
+						declaration: brevity.Hostile,
+						platforms:   []string{"F-16C"},
+					},
+					RangeChange:    -8 * unit.NauticalMile,
+					PreviousAspect: brevity.Flank,
+					AltitudeChange: 3000 * unit.Foot,
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenGroundDope(t *testing.T) {
+	t.Parallel()
+	testCases := []goldenTestCase{
+		{
+			name: "ground_dope_none",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeGroundDopeResponse(brevity.GroundDopeResponse{Callsign: "hog 1"})
+			},
+		},
+		{
+			name: "ground_dope_group",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeGroundDopeResponse(brevity.GroundDopeResponse{
+					Callsign: "hog 1",
+					Group: &brevity.GroundGroup{
+						BRA:      brevity.NewBRA(magnetic(270), 12*unit.NauticalMile),
+						Bullseye: brevity.NewBullseye(magnetic(90), 40*unit.NauticalMile),
+						Grid:     "37T GG 1234 5678",
+						Units:    6,
+						Kinds:    []string{"air defense", "armor"},
+					},
+				})
+			},
+		},
+	}
+	runGoldenTestCases(t, testCases)
+	runDialectGoldenTestCases(t, RedforDialect, []goldenTestCase{
+		{
+			name:    "ground_dope_group_redfor",
+			compose: testCases[1].compose,
+		},
+	})
+}
+
+func TestGoldenThreatRing(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "threat_ring_call",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeThreatRingCall(brevity.ThreatRingCall{
+					Callsign: "mobius 1",
+					System:   "SA-11",
+					BRA:      brevity.NewBRA(magnetic(45), 17*unit.NauticalMile),
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenMEZWarning(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "mez_warning_nose",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeMEZWarningCall(brevity.MEZWarningCall{
+					Callsign: "mobius 1",
+					System:   "SA-10",
+					Position: brevity.OnNose,
+					Range:    25 * unit.NauticalMile,
+				})
+			},
+		},
+		{
+			name: "mez_warning_left",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeMEZWarningCall(brevity.MEZWarningCall{
+					Callsign: "mobius 1",
+					System:   "SA-11",
+					Position: brevity.OffLeft,
+					Range:    12 * unit.NauticalMile,
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenDuplicateCallsign(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "duplicate_callsign_call",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeDuplicateCallsignCall(brevity.DuplicateCallsignCall{
+					Callsign: "mobius 1",
+					Count:    2,
+					Bullseye: *brevity.NewBullseye(magnetic(90), 20*unit.NauticalMile),
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenSurvivor(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "survivor_call",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeSurvivorCall(brevity.SurvivorCall{
+					Callsign: "mobius 1",
+					Bullseye: *brevity.NewBullseye(magnetic(135), 32*unit.NauticalMile),
+				})
+			},
+		},
+		{
+			name: "survivor_call_unknown",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeSurvivorCall(brevity.SurvivorCall{
+					Bullseye: *brevity.NewBullseye(magnetic(135), 32*unit.NauticalMile),
+				})
+			},
+		},
+		{
+			name: "survivor_none",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeSurvivorResponse(brevity.SurvivorResponse{Callsign: "sandy 1"})
+			},
+		},
+		{
+			name: "survivor_vector",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeSurvivorResponse(brevity.SurvivorResponse{
+					Callsign: "sandy 1",
+					Survivor: &brevity.Survivor{
+						Callsign: "mobius 1",
+						BRA:      brevity.NewBRA(magnetic(90), 18*unit.NauticalMile),
+						Bullseye: brevity.NewBullseye(magnetic(135), 32*unit.NauticalMile),
+						Grid:     "37T GG 1499 6415",
+					},
+				})
+			},
+		},
+		{
+			name: "survivor_overhead",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeSurvivorResponse(brevity.SurvivorResponse{
+					Callsign: "sandy 1",
+					Survivor: &brevity.Survivor{
+						Callsign:   "mobius 1",
+						IsOverhead: true,
+						Bullseye:   brevity.NewBullseye(magnetic(135), 32*unit.NauticalMile),
+						Grid:       "37T GG 1499 6415",
+					},
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenTanker(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "tanker_none",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeTankerResponse(brevity.TankerResponse{Callsign: "mobius 1"})
+			},
+		},
+		{
+			name: "tanker_vector",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeTankerResponse(brevity.TankerResponse{
+					Callsign: "mobius 1",
+					Tanker: &brevity.Tanker{
+						Callsign:  "texaco 1",
+						Platform:  "KC-135",
+						BRAA:      brevity.NewBRAA(magnetic(90), 40*unit.NauticalMile, []unit.Length{22000 * unit.Foot}, brevity.Beam),
+						TACAN:     "51X",
+						Frequency: 251 * unit.Megahertz,
+					},
+				})
+			},
+		},
+		{
+			name: "tanker_unconfigured",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeTankerResponse(brevity.TankerResponse{
+					Callsign: "mobius 1",
+					Tanker: &brevity.Tanker{
+						Platform: "Il-78",
+						BRAA:     brevity.NewBRAA(magnetic(270), 25*unit.NauticalMile, []unit.Length{18000 * unit.Foot}, brevity.Drag),
+					},
+				})
+			},
+		},
+	})
+}
+
+func TestGoldenGameplan(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "gameplan_stored",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeGameplanResponse(brevity.GameplanResponse{Callsign: "mobius 1", Gameplan: "skate 30 mile commit"})
+			},
+		},
+		{
+			name: "gameplan_recall",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeGameplanResponse(brevity.GameplanResponse{Callsign: "mobius 1", Gameplan: "skate 30 mile commit", IsRecall: true})
+			},
+		},
+		{
+			name: "gameplan_none",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeGameplanResponse(brevity.GameplanResponse{Callsign: "mobius 1", IsRecall: true})
+			},
+		},
+	})
+}
+
+func TestGoldenAdmin(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "admin_mute",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeAdminResponse(brevity.AdminResponse{Callsign: "mobius 1", Command: brevity.MuteCommand, IsAuthorized: true, Succeeded: true})
+			},
+		},
+		{
+			name: "admin_set_bullseye_failed",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeAdminResponse(brevity.AdminResponse{Callsign: "mobius 1", Command: brevity.SetBullseyeCommand, IsAuthorized: true})
+			},
+		},
+		{
+			name: "admin_unauthorized",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeAdminResponse(brevity.AdminResponse{Callsign: "yellow 13", Command: brevity.ReloadCommand})
+			},
+		},
+	})
+}
+
+func TestGoldenNothingToRepeat(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "nothing_to_repeat",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeNothingToRepeatResponse(brevity.NothingToRepeatResponse{Callsign: "mobius 1"})
+			},
+		},
+	})
+}
+
+func TestGoldenStandby(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "standby",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeStandbyResponse(brevity.StandbyResponse{})
+			},
+		},
+	})
+}
+
+func TestGoldenHealth(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "health_normal",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeHealthResponse(brevity.HealthResponse{
+					HasTelemetry: true,
+					TelemetryAge: 2 * time.Second,
+					Contacts:     14,
+					Frequencies:  []unit.Frequency{251 * unit.Megahertz, 133 * unit.Megahertz},
+				})
+			},
+		},
+		{
+			name: "health_degraded",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeHealthResponse(brevity.HealthResponse{
+					HasTelemetry: true,
+					TelemetryAge: 45 * time.Second,
+					Contacts:     1,
+					Frequencies:  []unit.Frequency{251 * unit.Megahertz},
+					Degraded:     []string{"broadcasts"},
+				})
+			},
+		},
+		{
+			name: "health_no_telemetry",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeHealthResponse(brevity.HealthResponse{})
+			},
+		},
+	})
+}
+
+func TestGoldenSighting(t *testing.T) {
+	t.Parallel()
+	runGoldenTestCases(t, []goldenTestCase{
+		{
+			name: "sighting_tally",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeSightingResponse(brevity.SightingResponse{Callsign: "mobius 1", Sighting: brevity.Tally})
+			},
+		},
+		{
+			name: "sighting_blind",
+			compose: func(c Composer) NaturalLanguageResponse {
+				return c.ComposeSightingResponse(brevity.SightingResponse{Callsign: "mobius 1", Sighting: brevity.Blind})
+			},
+		},
+	})
+}
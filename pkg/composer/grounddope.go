@@ -0,0 +1,56 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
+)
+
+// ComposeGroundDopeResponse implements [Composer.ComposeGroundDopeResponse].
+func (c *composer) ComposeGroundDopeResponse(response brevity.GroundDopeResponse) NaturalLanguageResponse {
+	if response.Group == nil {
+		reply := fmt.Sprintf("%s, %s, no hostile ground forces.", response.Callsign, c.callsign)
+		return NaturalLanguageResponse{
+			Subtitle: reply,
+			Speech:   reply,
+		}
+	}
+
+	return c.composeGroundGroup(fmt.Sprintf("%s, %s, nearest hostile ground %s", response.Callsign, c.callsign, c.groupNoun(1)), response.Group)
+}
+
+// composeGroundGroup describes a ground group's position and composition after the given introduction.
+func (c *composer) composeGroundGroup(intro string, group *brevity.GroundGroup) NaturalLanguageResponse {
+	if !group.BRA.Bearing().IsMagnetic() {
+		log.Error().Stringer("bearing", group.BRA.Bearing()).Msg("bearing provided to composeGroundGroup should be magnetic")
+	}
+	bra := c.composeBRA(group.BRA)
+	subtitle := []string{intro, bra.Subtitle}
+	speech := []string{intro, bra.Speech}
+	if group.Bullseye != nil {
+		bullseye := c.ComposeBullseye(*group.Bullseye)
+		subtitle = append(subtitle, bullseye.Subtitle)
+		speech = append(speech, bullseye.Speech)
+	}
+	if group.Grid != "" {
+		grid := composeGrid(group.Grid)
+		subtitle = append(subtitle, grid.Subtitle)
+		speech = append(speech, grid.Speech)
+	}
+	composition := fmt.Sprintf("%d units", group.Units)
+	if group.Units == 1 {
+		composition = "1 unit"
+	}
+	if len(group.Kinds) > 0 {
+		composition += ", " + joinPhrases(group.Kinds)
+	}
+	subtitle = append(subtitle, composition)
+	speech = append(speech, composition)
+
+	return NaturalLanguageResponse{
+		Subtitle: strings.Join(subtitle, ", ") + ".",
+		Speech:   strings.Join(speech, ", ") + ".",
+	}
+}
@@ -17,8 +17,8 @@ import (
 func (c *composer) ComposeCoreInformationFormat(groups ...brevity.Group) NaturalLanguageResponse {
 	if len(groups) == 0 {
 		return NaturalLanguageResponse{
-			Subtitle: string(brevity.Clean),
-			Speech:   string(brevity.Clean),
+			Subtitle: c.clean(),
+			Speech:   c.clean(),
 		}
 	}
 
@@ -33,6 +33,12 @@ func (c *composer) ComposeCoreInformationFormat(groups ...brevity.Group) Natural
 }
 
 func (c *composer) ComposeGroup(group brevity.Group) NaturalLanguageResponse {
+	return c.composeGroup(group, false)
+}
+
+// composeGroup describes a group. If brief is true, optional fill-ins such as altitude fill-ins and platforms are
+// omitted to shorten the description.
+func (c *composer) composeGroup(group brevity.Group, brief bool) NaturalLanguageResponse {
 	if group.BRAA() != nil && !group.BRAA().Bearing().IsMagnetic() {
 		log.Error().Stringer("bearing", group.BRAA().Bearing()).Msg("bearing provided to ComposeGroup should be magnetic")
 	}
@@ -45,23 +51,27 @@ func (c *composer) ComposeGroup(group brevity.Group) NaturalLanguageResponse {
 		subtitle.WriteString(s)
 	}
 
-	label := "Group"
-	if group.Threat() {
-		label = "Group threat"
-	}
+	label := c.groupLabel(group.Threat())
 
 	// Group location, altitude, and track direction or specific aspect
 	stacks := group.Stacks()
 	if bullseye := group.Bullseye(); bullseye != nil {
 		bullseye := c.ComposeBullseye(*bullseye)
 		altitude := c.ComposeAltitudeStacks(stacks, group.Declaration())
+		if height, ok := c.composeHeightAboveGround(group); ok {
+			altitude = height
+		}
 		speech.WriteString(fmt.Sprintf("%s %s, %s", label, bullseye.Speech, altitude))
 		subtitle.WriteString(fmt.Sprintf("%s %s, %s", label, bullseye.Subtitle, altitude))
 		if group.Track() != brevity.UnknownDirection {
-			writeBoth(fmt.Sprintf(", track %s", group.Track()))
+			writeBoth(fmt.Sprintf(", %s %s", c.trackWord(), group.Track()))
 		}
 	} else if group.BRAA() != nil {
-		braa := c.ComposeBRAA(group.BRAA(), group.Declaration())
+		altitude := c.ComposeAltitude(group.BRAA().Altitude(), group.Declaration())
+		if height, ok := c.composeHeightAboveGround(group); ok {
+			altitude = height
+		}
+		braa := c.composeBRAA(group.BRAA(), altitude)
 		speech.WriteString(fmt.Sprintf("%s %s", label, braa.Speech))
 		subtitle.WriteString(fmt.Sprintf("%s %s", label, braa.Subtitle))
 		isCardinalAspect := slices.Contains([]brevity.Aspect{brevity.Flank, brevity.Beam, brevity.Drag}, group.BRAA().Aspect())
@@ -70,6 +80,9 @@ func (c *composer) ComposeGroup(group brevity.Group) NaturalLanguageResponse {
 		if isCardinalAspect && isTrackKnown && !isFurball {
 			writeBoth(fmt.Sprintf(" %s", group.Track()))
 		}
+		if trend := group.BRAA().Trend(); trend != brevity.SteadyAspect && !isFurball {
+			writeBoth(", " + string(trend))
+		}
 	}
 
 	// Declaration
@@ -80,6 +93,9 @@ func (c *composer) ComposeGroup(group brevity.Group) NaturalLanguageResponse {
 	if group.MergedWith() > 1 {
 		writeBoth(fmt.Sprintf(", merged with %d friendlies", group.MergedWith()))
 	}
+	if slices.Contains(group.Tags(), brevity.TagDoNotEngage) {
+		writeBoth(", do not engage")
+	}
 
 	// Fill-in information
 
@@ -91,16 +107,22 @@ func (c *composer) ComposeGroup(group brevity.Group) NaturalLanguageResponse {
 	subtitle.WriteString(contacts.Subtitle)
 	speech.WriteString(contacts.Speech)
 
-	if !group.High() {
+	if leadTrail := group.LeadTrail(); leadTrail != nil && !brief {
+		writeBoth(", " + c.composeLeadTrail(leadTrail))
+	}
+
+	if !group.High() && !brief {
 		if len(stacks) > 1 {
 			writeBoth(", " + c.ComposeAltitudeFillIns(stacks))
 		}
 	}
 
 	// Platform
-	if len(group.Platforms()) > 0 {
-		writeBoth(", ")
-		writeBoth(strings.Join(group.Platforms(), ", "))
+	if !brief {
+		for _, platform := range group.Platforms() {
+			subtitle.WriteString(", " + platform)
+			speech.WriteString(", " + c.pronounce(platform))
+		}
 	}
 
 	// High
@@ -123,6 +145,16 @@ func (c *composer) ComposeGroup(group brevity.Group) NaturalLanguageResponse {
 	}
 }
 
+// composeLeadTrail describes the spacing between the lead and trail elements of a group, and the trail element's
+// altitude relative to the lead element if they are not level.
+func (c *composer) composeLeadTrail(leadTrail *brevity.LeadTrail) string {
+	s := fmt.Sprintf("lead trail, %d %s", c.composeRange(leadTrail.Separation), c.rangeUnitWord())
+	if leadTrail.TrailAltitude != brevity.Level {
+		s += fmt.Sprintf(", trail %s", leadTrail.TrailAltitude)
+	}
+	return s
+}
+
 // ComposeMergedWithGroup is a short form of describing a group for use in merge calls.
 func (c *composer) ComposeMergedWithGroup(group brevity.Group) NaturalLanguageResponse {
 	var speech, subtitle strings.Builder
@@ -189,14 +221,54 @@ func (c *composer) ComposeAltitudeFillIns(stacks []brevity.Stack) string {
 	return ""
 }
 
+const (
+	// onTheDeck is the height above ground level below which a group is described as on the deck.
+	onTheDeck = 500 * unit.Foot
+	// lowLevel is the height above ground level below which a group's altitude is described relative to the terrain
+	// beneath it rather than to sea level.
+	lowLevel = 5000 * unit.Foot
+)
+
+// composeHeightAboveGround describes the altitude of a low flying group relative to the terrain beneath it. Over high
+// terrain, this is more useful than altitude above sea level when hunting helicopters and low level strikers. The
+// second return value is false if the group's altitude should be described relative to sea level, either because the
+// terrain elevation is unknown, the group is not low, or the group is friendly.
+func (c *composer) composeHeightAboveGround(group brevity.Group) (string, bool) {
+	if group.Declaration() == brevity.Friendly || len(group.Stacks()) > 1 {
+		return "", false
+	}
+	height, ok := group.AboveGroundLevel()
+	if !ok || height >= lowLevel {
+		return "", false
+	}
+	if height < onTheDeck {
+		return "on the deck", true
+	}
+	if c.dialect == RedforDialect {
+		return composeMetricAltitude(height) + " above ground", true
+	}
+	return fmt.Sprintf("%d above ground", int(math.Round(height.Feet()/1000))*1000), true
+}
+
 func (c *composer) ComposeAltitude(altitude unit.Length, declaration brevity.Declaration) string {
 	hundreds := int(math.Round(altitude.Feet() / 100))
 	thousands := int(math.Round(altitude.Feet() / 1000))
 	if hundreds == 0 {
 		return "altitude unknown"
 	}
+	if c.dialect == RedforDialect {
+		return composeMetricAltitude(altitude)
+	}
+
+	useAngels := declaration == brevity.Friendly
+	switch c.altitudeFormat {
+	case AngelsAltitudeFormat:
+		useAngels = true
+	case FeetAltitudeFormat:
+		useAngels = false
+	}
 
-	if declaration == brevity.Friendly {
+	if useAngels {
 		if altitude < 1000*unit.Foot {
 			return fmt.Sprintf("cherubs %d", hundreds)
 		}
@@ -0,0 +1,75 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// staleTelemetryAge is the age after which telemetry is reported as stale.
+const staleTelemetryAge = 30 * time.Second
+
+// ComposeHealthResponse implements [Composer.ComposeHealthResponse].
+func (c *composer) ComposeHealthResponse(response brevity.HealthResponse) NaturalLanguageResponse {
+	reply := NaturalLanguageResponse{
+		Subtitle: c.callsign + " status, ",
+		Speech:   c.callsign + " status, ",
+	}
+	writeBoth := func(s string) {
+		reply.Subtitle += s
+		reply.Speech += s
+	}
+
+	isTelemetryCurrent := false
+	switch {
+	case !response.HasTelemetry:
+		writeBoth("no telemetry")
+	case response.TelemetryAge > staleTelemetryAge:
+		writeBoth("telemetry stale " + composeTelemetryAge(response.TelemetryAge))
+	default:
+		writeBoth("telemetry current")
+		isTelemetryCurrent = true
+	}
+
+	if response.Contacts == 1 {
+		writeBoth(", tracking 1 contact")
+	} else {
+		writeBoth(fmt.Sprintf(", tracking %d contacts", response.Contacts))
+	}
+
+	if len(response.Frequencies) > 0 {
+		writeBoth(", monitoring ")
+		for i, f := range response.Frequencies {
+			if i > 0 {
+				if i == len(response.Frequencies)-1 {
+					writeBoth(" and ")
+				} else {
+					writeBoth(", ")
+				}
+			}
+			frequency := composeFrequency(f)
+			reply.Subtitle += frequency.Subtitle
+			reply.Speech += frequency.Speech
+		}
+	}
+
+	switch {
+	case len(response.Degraded) > 0:
+		writeBoth(fmt.Sprintf(", degraded: %s.", strings.Join(response.Degraded, ", ")))
+	case isTelemetryCurrent:
+		writeBoth(", all systems normal.")
+	default:
+		writeBoth(".")
+	}
+	return reply
+}
+
+// composeTelemetryAge describes how old the telemetry is in whole seconds or minutes.
+func composeTelemetryAge(age time.Duration) string {
+	if age < 2*time.Minute {
+		return fmt.Sprintf("%d seconds", int(age.Seconds()))
+	}
+	return fmt.Sprintf("%d minutes", int(age.Minutes()))
+}
@@ -0,0 +1,27 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeHVAAThreatCall implements [Composer.ComposeHVAAThreatCall].
+func (c *composer) ComposeHVAAThreatCall(call brevity.HVAAThreatCall) NaturalLanguageResponse {
+	callsignList := strings.Join(call.Callsigns, ", ")
+	var directive string
+	switch call.Urgency {
+	case brevity.HVAAThreatImminent:
+		directive = fmt.Sprintf("%s threat imminent, commit", call.HVAA)
+	case brevity.HVAAThreatClosing:
+		directive = fmt.Sprintf("%s threat closing, protect %s", call.HVAA, call.HVAA)
+	default:
+		directive = "protect " + call.HVAA
+	}
+	group := c.ComposeGroup(call.Group)
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, %s. %s", callsignList, directive, group.Subtitle),
+		Speech:   fmt.Sprintf("%s, %s. %s", callsignList, directive, group.Speech),
+	}
+}
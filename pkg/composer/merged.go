@@ -7,13 +7,18 @@ import (
 	"github.com/dharmab/skyeye/pkg/brevity"
 )
 
+// ComposeMergedCall implements [Composer.ComposeMergedCall].
 func (c *composer) ComposeMergedCall(call brevity.MergedCall) NaturalLanguageResponse {
 	callsignList := strings.Join(call.Callsigns, ", ")
 	group := c.ComposeMergedWithGroup(call.Group)
-	template := "%s, merged. %s"
+	return c.templates.Load().renderGroup(MergedTemplate, callsignList, group)
+}
 
+// ComposeCleanCall implements [Composer.ComposeCleanCall].
+func (c *composer) ComposeCleanCall(call brevity.CleanCall) NaturalLanguageResponse {
+	reply := fmt.Sprintf("%s, %s, clean.", strings.Join(call.Callsigns, ", "), c.callsign)
 	return NaturalLanguageResponse{
-		Subtitle: fmt.Sprintf(template, callsignList, group.Subtitle),
-		Speech:   fmt.Sprintf(template, callsignList, group.Speech),
+		Subtitle: reply,
+		Speech:   reply,
 	}
 }
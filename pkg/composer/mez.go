@@ -0,0 +1,25 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeMEZWarningCall implements [Composer.ComposeMEZWarningCall].
+func (c *composer) ComposeMEZWarningCall(call brevity.MEZWarningCall) NaturalLanguageResponse {
+	var position string
+	switch call.Position {
+	case brevity.OffLeft:
+		position = "off your left"
+	case brevity.OffRight:
+		position = "off your right"
+	default:
+		position = "at your nose"
+	}
+	_range := c.composeRange(call.Range)
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, %s, caution, %s MEZ %s, %d %s.", call.Callsign, c.callsign, call.System, position, _range, c.rangeUnitWord()),
+		Speech:   fmt.Sprintf("%s, %s, caution, %s MEZ %s, %d %s.", call.Callsign, c.callsign, c.pronounce(call.System), position, _range, c.rangeUnitWord()),
+	}
+}
@@ -0,0 +1,19 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeMuteResponse implements [Composer.ComposeMuteResponse].
+func (c *composer) ComposeMuteResponse(response brevity.MuteResponse) NaturalLanguageResponse {
+	reply := fmt.Sprintf("%s, %s, broadcasts resumed.", response.Callsign, c.callsign)
+	if response.Muted {
+		reply = fmt.Sprintf("%s, %s, broadcasts stopped. Call for a picture or bogey dope as needed.", response.Callsign, c.callsign)
+	}
+	return NaturalLanguageResponse{
+		Subtitle: reply,
+		Speech:   reply,
+	}
+}
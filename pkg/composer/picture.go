@@ -2,31 +2,74 @@ package composer
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/dharmab/skyeye/pkg/brevity"
 )
 
 // ComposePictureResponse implements [Composer.ComposePictureResponse].
 func (c *composer) ComposePictureResponse(response brevity.PictureResponse) NaturalLanguageResponse {
-	info := c.ComposeCoreInformationFormat(response.Groups...)
+	// A BULLSEYE PICTURE is broadcast to everyone, while a BRAA PICTURE is addressed to the caller.
+	addressing := c.callsign
+	if response.Callsign != "" {
+		addressing = fmt.Sprintf("%s, %s", response.Callsign, c.callsign)
+	}
 	if response.Count == 0 {
 		return NaturalLanguageResponse{
-			Subtitle: fmt.Sprintf("%s, %s.", c.callsign, brevity.Clean),
-			Speech:   fmt.Sprintf("%s, %s", c.callsign, brevity.Clean),
+			Subtitle: fmt.Sprintf("%s, %s.", addressing, c.clean()),
+			Speech:   fmt.Sprintf("%s, %s", addressing, c.clean()),
 		}
 	}
 
-	groupCountFillIn := "single group."
+	groupCountFillIn := fmt.Sprintf("single %s.", c.groupNoun(1))
 	if response.Count > 1 {
-		groupCountFillIn = fmt.Sprintf("%d groups.", response.Count)
+		groupCountFillIn = fmt.Sprintf("%d %s.", response.Count, c.groupNoun(response.Count))
+	}
+	header := NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, %s", addressing, groupCountFillIn),
+		Speech:   fmt.Sprintf("%s, %s", addressing, groupCountFillIn),
 	}
 
-	info.Speech = strings.TrimSpace(info.Speech)
-	info.Subtitle = strings.TrimSpace(info.Subtitle)
+	pieces := func(brief bool) []NaturalLanguageResponse {
+		pieces := []NaturalLanguageResponse{header}
+		for _, group := range response.Groups {
+			pieces = append(pieces, c.composeGroup(group, brief))
+		}
+		if additional := response.Count - len(response.Groups); additional > 0 {
+			pieces = append(pieces, c.composeAdditionalGroups(additional, response.FurthestBullseye))
+		}
+		return pieces
+	}
 
-	return NaturalLanguageResponse{
-		Subtitle: fmt.Sprintf("%s, %s %s", c.callsign, groupCountFillIn, info.Subtitle),
-		Speech:   fmt.Sprintf("%s, %s %s", c.callsign, groupCountFillIn, info.Speech),
+	// If the PICTURE is too long to speak in one transmission, first drop optional fill-ins, then split it into
+	// several transmissions. Only the speech is trimmed; the subtitles keep every detail.
+	full := pieces(false)
+	if joined := joinResponses(full...); c.fits(joined) {
+		return joined
+	}
+	brief := pieces(true)
+	for i := range brief {
+		brief[i].Subtitle = full[i].Subtitle
+	}
+	if trimmed := joinResponses(brief...); c.fits(trimmed) {
+		return trimmed
+	}
+	return c.splitResponse(addressing, brief)
+}
+
+// composeAdditionalGroups summarizes the groups which were not described in detail, so that a large PICTURE is not
+// silently truncated.
+func (c *composer) composeAdditionalGroups(count int, furthest *brevity.Bullseye) NaturalLanguageResponse {
+	noun := c.groupNoun(count)
+	response := NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("Plus %d additional %s", count, noun),
+		Speech:   fmt.Sprintf("Plus %d additional %s", count, noun),
+	}
+	if furthest != nil {
+		bullseye := c.ComposeBullseye(*furthest)
+		response.Subtitle += fmt.Sprintf(", furthest %s", bullseye.Subtitle)
+		response.Speech += fmt.Sprintf(", furthest %s", bullseye.Speech)
 	}
+	response.Subtitle += "."
+	response.Speech += "."
+	return response
 }
@@ -0,0 +1,72 @@
+package composer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/paulmach/orb"
+)
+
+// gridPrecision is the number of digits in each of the easting and northing of a reported grid reference. Four digits
+// locates a position to within 10 meters.
+const gridPrecision = 4
+
+// latLongPrecision is the number of decimal places in reported latitudes and longitudes. Four decimal places locates
+// a position to within about 10 meters.
+const latLongPrecision = 4
+
+// composeGridPoint composes an MGRS grid reference for a point. The second return value is false if the point is
+// outside of the MGRS grid, near the poles.
+func composeGridPoint(point orb.Point) (NaturalLanguageResponse, bool) {
+	grid, ok := spatial.MGRS(point, gridPrecision)
+	if !ok {
+		return NaturalLanguageResponse{}, false
+	}
+	return composeGrid(grid), true
+}
+
+// composeGrid composes an MGRS grid reference, e.g. "grid 37T GG 1234 5678".
+func composeGrid(grid string) NaturalLanguageResponse {
+	return NaturalLanguageResponse{
+		Subtitle: "grid " + grid,
+		Speech:   "grid " + PronounceGrid(grid),
+	}
+}
+
+// composeLatLong composes the decimal latitude and longitude of a point, e.g. "42.1234 N, 41.5678 E".
+func composeLatLong(point orb.Point) NaturalLanguageResponse {
+	latitude := hemisphere(point.Lat(), "N", "S")
+	longitude := hemisphere(point.Lon(), "E", "W")
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf(
+			"%.*f %s, %.*f %s",
+			latLongPrecision, math.Abs(point.Lat()), latitude,
+			latLongPrecision, math.Abs(point.Lon()), longitude,
+		),
+		Speech: fmt.Sprintf(
+			"%s %s, %s %s",
+			PronounceCoordinate(point.Lat()), hemisphereWords[latitude],
+			PronounceCoordinate(point.Lon()), hemisphereWords[longitude],
+		),
+	}
+}
+
+// hemisphere returns the positive hemisphere if the given degrees are positive or zero, otherwise the negative one.
+func hemisphere(degrees float64, positive, negative string) string {
+	if degrees < 0 {
+		return negative
+	}
+	return positive
+}
+
+// hemisphereWords are how hemispheres are spoken.
+var hemisphereWords = map[string]string{"N": "north", "S": "south", "E": "east", "W": "west"}
+
+// PronounceCoordinate composes a text representation of a latitude or longitude as a sequence of digits, ignoring the
+// sign. Unlike PronounceDecimal, leading zeroes after the decimal point are read.
+func PronounceCoordinate(degrees float64) string {
+	whole, fraction, _ := strings.Cut(fmt.Sprintf("%.*f", latLongPrecision, math.Abs(degrees)), ".")
+	return strings.TrimSpace(PronounceNumbers(whole)) + " " + defaultDecimalSeparator + " " + strings.TrimSpace(PronounceNumbers(fraction))
+}
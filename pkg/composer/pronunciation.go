@@ -0,0 +1,45 @@
+package composer
+
+import "strings"
+
+// defaultPronunciations maps aircraft platform names to how they should be spoken. The keys are the names which
+// [brevity.Group.Platforms] reports, i.e. each aircraft's reporting name, and the platform designations which TANKER
+// responses report. Text-to-speech engines tend to spell out designations letter-by-letter or read the digits as a
+// single large number, and stumble over run-together or abbreviated names, so these spell out the way aviators
+// actually say them.
+var defaultPronunciations = map[string]string{
+	"Albatros":     "albatross",
+	"Aviojet":      "avio jet",
+	"Fagot":        "fa got",
+	"Herc":         "herk",
+	"Il-78":        "ilyushin seventy eight",
+	"KC-130":       "K C one thirty",
+	"KC-135":       "K C one thirty five",
+	"Mainring":     "main ring",
+	"MB-339":       "M B three three nine",
+	"Mirage 2000":  "mirage two thousand",
+	"Mirage F1":    "mirage F one",
+	"S-3":          "S three",
+	"Stratotanker": "strato tanker",
+	"SuperCobra":   "super cobra",
+}
+
+// newPronunciations combines the default pronunciations with the given overrides. Lookups are case-insensitive.
+func newPronunciations(overrides map[string]string) map[string]string {
+	pronunciations := make(map[string]string, len(defaultPronunciations)+len(overrides))
+	for name, pronunciation := range defaultPronunciations {
+		pronunciations[strings.ToLower(name)] = pronunciation
+	}
+	for name, pronunciation := range overrides {
+		pronunciations[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(pronunciation)
+	}
+	return pronunciations
+}
+
+// pronounce returns how the given platform name should be spoken.
+func (c *composer) pronounce(platform string) string {
+	if pronunciation, ok := c.pronunciations[strings.ToLower(platform)]; ok {
+		return pronunciation
+	}
+	return platform
+}
@@ -0,0 +1,64 @@
+package composer
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/encyclopedia"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPronounce(t *testing.T) {
+	t.Parallel()
+	c := New(goldenCallsign, StandardAltitudeFormat, map[string]string{
+		"Flanker": "flanker",
+		"fulcrum": "full crum",
+		" Tejas ": " tay jus ",
+	}, StandardDialect, nil, 0).(*composer)
+	testCases := []struct {
+		platform string
+		expected string
+	}{
+		{"SuperCobra", "super cobra"},
+		{"supercobra", "super cobra"},
+		{"Fulcrum", "full crum"},
+		{"Flanker", "flanker"},
+		{"Tejas", "tay jus"},
+		{"Fishbed", "Fishbed"},
+		{"", ""},
+	}
+	for _, test := range testCases {
+		t.Run(test.platform, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, c.pronounce(test.platform))
+		})
+	}
+}
+
+func TestPronounceReportingName(t *testing.T) {
+	t.Parallel()
+	c := New(goldenCallsign, StandardAltitudeFormat, nil, StandardDialect, nil, 0).(*composer)
+	testCases := []struct {
+		acmiName string
+		expected string
+	}{
+		{"MB-339A", "M B three three nine"},
+		{"M-2000C", "mirage two thousand"},
+		{"Mirage-F1CE", "mirage F one"},
+		{"AH-1W", "super cobra"},
+		{"KC130", "herk"},
+		{"KJ-2000", "main ring"},
+		{"KC-135", "strato tanker"},
+		{"L-39ZA", "albatross"},
+		{"C-101CC", "avio jet"},
+		{"Su-27", "Flanker"},
+	}
+	for _, test := range testCases {
+		t.Run(test.acmiName, func(t *testing.T) {
+			t.Parallel()
+			data, ok := encyclopedia.GetAircraftData(test.acmiName)
+			require.True(t, ok)
+			assert.Equal(t, test.expected, c.pronounce(data.ReportingName()))
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeNothingToRepeatResponse implements [Composer.ComposeNothingToRepeatResponse].
+func (c *composer) ComposeNothingToRepeatResponse(response brevity.NothingToRepeatResponse) NaturalLanguageResponse {
+	reply := fmt.Sprintf("%s, %s, nothing to repeat.", response.Callsign, c.callsign)
+	return NaturalLanguageResponse{
+		Subtitle: reply,
+		Speech:   reply,
+	}
+}
@@ -0,0 +1,16 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeSightingResponse implements [Composer.ComposeSightingResponse].
+func (c *composer) ComposeSightingResponse(response brevity.SightingResponse) NaturalLanguageResponse {
+	reply := fmt.Sprintf("%s, %s, copy %s.", response.Callsign, c.callsign, response.Sighting)
+	return NaturalLanguageResponse{
+		Subtitle: reply,
+		Speech:   reply,
+	}
+}
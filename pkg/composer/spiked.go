@@ -13,7 +13,7 @@ func (c *composer) ComposeSpikedResponse(response brevity.SpikedResponse) Natura
 		reply := fmt.Sprintf(
 			"%s, spike range %d, %s, %s",
 			response.Callsign,
-			int(response.Range.NauticalMiles()),
+			c.composeRange(response.Range),
 			c.ComposeAltitude(response.Altitude, brevity.Bogey),
 			response.Aspect)
 		isCardinalAspect := slices.Contains([]brevity.Aspect{brevity.Flank, brevity.Beam, brevity.Drag}, response.Aspect)
@@ -41,6 +41,6 @@ func (c *composer) ComposeSpikedResponse(response brevity.SpikedResponse) Natura
 	}
 	return NaturalLanguageResponse{
 		Subtitle: fmt.Sprintf("%s, %s clean %d.", response.Callsign, c.callsign, int(response.Bearing.Degrees())),
-		Speech:   fmt.Sprintf("%s, %s, clean - %s", response.Callsign, c.callsign, PronounceBearing(response.Bearing)),
+		Speech:   fmt.Sprintf("%s, %s, clean - %s", response.Callsign, c.callsign, c.pronounceBearing(response.Bearing)),
 	}
 }
@@ -0,0 +1,66 @@
+package composer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// ComposeStatusResponse implements [Composer.ComposeStatusResponse].
+func (c *composer) ComposeStatusResponse(response brevity.StatusResponse) NaturalLanguageResponse {
+	var speech, subtitle strings.Builder
+	writeBoth := func(s string) {
+		speech.WriteString(s)
+		subtitle.WriteString(s)
+	}
+
+	writeBoth(response.Callsign + ", ")
+	if response.Group == nil {
+		writeBoth(c.targetGroup() + " faded. ")
+	} else {
+		braa := response.Group.BRAA()
+		details := make([]string, 0)
+
+		_range := c.composeRange(braa.Range())
+		rangeChange := int(math.Round(c.rangeUnits(response.RangeChange)))
+		target := c.targetGroup()
+		if rangeChange < 0 {
+			details = append(details, fmt.Sprintf("%s closed %d, range %d", target, -rangeChange, _range))
+		} else if rangeChange > 0 {
+			details = append(details, fmt.Sprintf("%s opened %d, range %d", target, rangeChange, _range))
+		} else {
+			details = append(details, fmt.Sprintf("%s range %d", target, _range))
+		}
+
+		if braa.Aspect() != brevity.UnknownAspect {
+			aspect := string(braa.Aspect())
+			if response.PreviousAspect != brevity.UnknownAspect && response.PreviousAspect != braa.Aspect() {
+				aspect = fmt.Sprintf("%s, was %s", aspect, response.PreviousAspect)
+			}
+			details = append(details, aspect)
+		}
+
+		altitudeChange := int(math.Round(response.AltitudeChange.Feet()/1000)) * 1000
+		altitude := c.ComposeAltitude(braa.Altitude(), response.Group.Declaration())
+		if altitudeChange >= 1000 {
+			details = append(details, fmt.Sprintf("climbed to %s", altitude))
+		} else if altitudeChange <= -1000 {
+			details = append(details, fmt.Sprintf("descended to %s", altitude))
+		}
+
+		writeBoth(strings.Join(details, ", ") + ". ")
+	}
+
+	for _, threat := range response.NewThreats {
+		group := c.ComposeGroup(threat)
+		speech.WriteString(group.Speech)
+		subtitle.WriteString(group.Subtitle)
+	}
+
+	return NaturalLanguageResponse{
+		Subtitle: strings.TrimSpace(subtitle.String()),
+		Speech:   strings.TrimSpace(speech.String()),
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
 )
 
 // ComposeSunriseCall implements [Composer.ComposeSunriseCall].
@@ -20,15 +21,9 @@ func (c *composer) ComposeSunriseCall(call brevity.SunriseCall) NaturalLanguageR
 	}
 
 	for i := range len(call.Frequencies) {
-		frequency := call.Frequencies[i]
-		decimal := fmt.Sprintf("%.3f", frequency.Megahertz())
-		decimal = strings.TrimRight(decimal, "0")
-		if strings.HasSuffix(decimal, ".") {
-			decimal += "0"
-		}
-		message.Subtitle += decimal
-		splits := strings.Split(decimal, ".")
-		message.Speech += PronounceDecimal(frequency.Megahertz(), len(splits[1]), "point")
+		frequency := composeFrequency(call.Frequencies[i])
+		message.Subtitle += frequency.Subtitle
+		message.Speech += frequency.Speech
 		if len(call.Frequencies) > 1 {
 			if i == len(call.Frequencies)-2 {
 				writeBoth(" and ")
@@ -41,6 +36,20 @@ func (c *composer) ComposeSunriseCall(call brevity.SunriseCall) NaturalLanguageR
 	return message
 }
 
+// composeFrequency describes a radio frequency in megahertz, e.g. "251.0" or "133.225".
+func composeFrequency(frequency unit.Frequency) NaturalLanguageResponse {
+	decimal := fmt.Sprintf("%.3f", frequency.Megahertz())
+	decimal = strings.TrimRight(decimal, "0")
+	if strings.HasSuffix(decimal, ".") {
+		decimal += "0"
+	}
+	splits := strings.Split(decimal, ".")
+	return NaturalLanguageResponse{
+		Subtitle: decimal,
+		Speech:   PronounceDecimal(frequency.Megahertz(), len(splits[1]), "point"),
+	}
+}
+
 func (c *composer) ComposeMidnightCall(call brevity.MidnightCall) NaturalLanguageResponse {
 	return NaturalLanguageResponse{
 		Subtitle: fmt.Sprintf("All players: GCI %s midnight. See ya!", c.callsign),
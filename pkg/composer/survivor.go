@@ -0,0 +1,70 @@
+package composer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
+)
+
+// ComposeSurvivorCall implements [Composer.ComposeSurvivorCall].
+func (c *composer) ComposeSurvivorCall(call brevity.SurvivorCall) NaturalLanguageResponse {
+	subtitle := []string{c.callsign, "pilot down"}
+	if call.Callsign != "" {
+		subtitle = append(subtitle, call.Callsign)
+	}
+	speech := slices.Clone(subtitle)
+	bullseye := c.ComposeBullseye(call.Bullseye)
+	subtitle = append(subtitle, bullseye.Subtitle)
+	speech = append(speech, bullseye.Speech)
+	return NaturalLanguageResponse{
+		Subtitle: strings.Join(subtitle, ", ") + ".",
+		Speech:   strings.Join(speech, ", ") + ".",
+	}
+}
+
+// ComposeSurvivorResponse implements [Composer.ComposeSurvivorResponse].
+func (c *composer) ComposeSurvivorResponse(response brevity.SurvivorResponse) NaturalLanguageResponse {
+	if response.Survivor == nil {
+		reply := fmt.Sprintf("%s, %s, no survivors.", response.Callsign, c.callsign)
+		return NaturalLanguageResponse{
+			Subtitle: reply,
+			Speech:   reply,
+		}
+	}
+
+	s := response.Survivor
+	survivor := "survivor"
+	if s.Callsign != "" {
+		survivor += " " + s.Callsign
+	}
+	subtitle := []string{response.Callsign, c.callsign}
+	speech := []string{response.Callsign, c.callsign}
+	if s.IsOverhead {
+		subtitle = append(subtitle, survivor+" is overhead")
+		speech = append(speech, survivor+" is overhead")
+	} else {
+		if !s.BRA.Bearing().IsMagnetic() {
+			log.Error().Stringer("bearing", s.BRA.Bearing()).Msg("bearing provided to ComposeSurvivorResponse should be magnetic")
+		}
+		bra := c.composeBRA(s.BRA)
+		subtitle = append(subtitle, survivor, bra.Subtitle)
+		speech = append(speech, survivor, bra.Speech)
+	}
+	if s.Bullseye != nil {
+		bullseye := c.ComposeBullseye(*s.Bullseye)
+		subtitle = append(subtitle, bullseye.Subtitle)
+		speech = append(speech, bullseye.Speech)
+	}
+	if s.Grid != "" {
+		grid := composeGrid(s.Grid)
+		subtitle = append(subtitle, grid.Subtitle)
+		speech = append(speech, grid.Speech)
+	}
+	return NaturalLanguageResponse{
+		Subtitle: strings.Join(subtitle, ", ") + ".",
+		Speech:   strings.Join(speech, ", ") + ".",
+	}
+}
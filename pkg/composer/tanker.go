@@ -0,0 +1,68 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
+)
+
+// ComposeTankerResponse implements [Composer.ComposeTankerResponse].
+func (c *composer) ComposeTankerResponse(response brevity.TankerResponse) NaturalLanguageResponse {
+	if response.Tanker == nil {
+		reply := fmt.Sprintf("%s, %s, no tankers.", response.Callsign, c.callsign)
+		return NaturalLanguageResponse{
+			Subtitle: reply,
+			Speech:   reply,
+		}
+	}
+
+	t := response.Tanker
+	tanker := "tanker"
+	if t.Callsign != "" {
+		tanker += " " + t.Callsign
+	}
+	subtitle := []string{response.Callsign, c.callsign, tanker}
+	speech := []string{response.Callsign, c.callsign, tanker}
+	if t.Platform != "" {
+		subtitle = append(subtitle, t.Platform)
+		speech = append(speech, c.pronounce(t.Platform))
+	}
+	if !t.BRAA.Bearing().IsMagnetic() {
+		log.Error().Stringer("bearing", t.BRAA.Bearing()).Msg("bearing provided to ComposeTankerResponse should be magnetic")
+	}
+	braa := c.composeBRAA(t.BRAA, c.ComposeAltitude(t.BRAA.Altitude(), brevity.Friendly))
+	subtitle = append(subtitle, braa.Subtitle)
+	speech = append(speech, braa.Speech)
+	if t.TACAN != "" {
+		subtitle = append(subtitle, "TACAN "+t.TACAN)
+		speech = append(speech, "TACAN "+pronounceTACAN(t.TACAN))
+	}
+	if t.Frequency > 0 {
+		frequency := composeFrequency(t.Frequency)
+		subtitle = append(subtitle, "frequency "+frequency.Subtitle)
+		speech = append(speech, "frequency "+frequency.Speech)
+	}
+	return NaturalLanguageResponse{
+		Subtitle: strings.Join(subtitle, ", ") + ".",
+		Speech:   strings.Join(speech, ", ") + ".",
+	}
+}
+
+// pronounceTACAN pronounces a TACAN channel with its band spelled phonetically, e.g. "51X" as "51 x-ray".
+func pronounceTACAN(channel string) string {
+	channel = strings.ToUpper(channel)
+	digits := strings.TrimRightFunc(channel, unicode.IsLetter)
+	words := make([]string, 0, 2)
+	if digits != "" {
+		words = append(words, digits)
+	}
+	for _, char := range channel[len(digits):] {
+		if word, ok := phoneticAlphabet[char]; ok {
+			words = append(words, word)
+		}
+	}
+	return strings.Join(words, " ")
+}
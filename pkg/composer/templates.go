@@ -0,0 +1,297 @@
+package composer
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// Selection selects how one of a response's variants is chosen.
+type Selection string
+
+const (
+	// RandomSelection chooses a variant at random each time.
+	RandomSelection Selection = "random"
+	// RotateSelection chooses each variant in turn.
+	RotateSelection Selection = "rotate"
+)
+
+// Keys of the response types which support phrasing variants.
+const (
+	RadioCheckTemplate           = "radio-check"
+	RadioCheckNoContactTemplate  = "radio-check-no-contact"
+	NegativeRadarContactTemplate = "negative-radar-contact"
+	SayAgainTemplate             = "say-again"
+	SayAgainNoCallsignTemplate   = "say-again-no-callsign"
+	TripwireTemplate             = "tripwire"
+	BogeyDopeTemplate            = "bogey-dope"
+	ThreatTemplate               = "threat"
+	MergedTemplate               = "merged"
+)
+
+// callsignData is the data available to variants which address a caller.
+type callsignData struct {
+	// Callsign of the caller.
+	Callsign string
+}
+
+// groupData is the data available to variants which describe a group.
+type groupData struct {
+	// Callsign of the caller, or a list of callsigns.
+	Callsign string
+	// Group is the description of the group.
+	Group string
+}
+
+// templateData maps each template key to the data its variants are executed with. Every variant of a response type
+// receives the same data, so variants change only the phrasing.
+var templateData = map[string]any{
+	RadioCheckTemplate:           callsignData{},
+	RadioCheckNoContactTemplate:  callsignData{},
+	NegativeRadarContactTemplate: callsignData{},
+	SayAgainTemplate:             callsignData{},
+	SayAgainNoCallsignTemplate:   struct{}{},
+	TripwireTemplate:             callsignData{},
+	BogeyDopeTemplate:            groupData{},
+	ThreatTemplate:               groupData{},
+	MergedTemplate:               groupData{},
+}
+
+// sampleTemplateData maps each template key to populated example data, used alongside templateData to exercise both
+// sides of any conditionals when variants are validated.
+var sampleTemplateData = map[string]any{
+	RadioCheckTemplate:           callsignData{Callsign: "mobius 1"},
+	RadioCheckNoContactTemplate:  callsignData{Callsign: "mobius 1"},
+	NegativeRadarContactTemplate: callsignData{Callsign: "mobius 1"},
+	SayAgainTemplate:             callsignData{Callsign: "mobius 1"},
+	SayAgainNoCallsignTemplate:   struct{}{},
+	TripwireTemplate:             callsignData{Callsign: "mobius 1"},
+	BogeyDopeTemplate:            groupData{Callsign: "mobius 1", Group: "BRAA 090/20, 15000, hot, hostile"},
+	ThreatTemplate:               groupData{Callsign: "mobius 1, yellow 13", Group: "group threat BRAA 090/20, 15000, hot, hostile"},
+	MergedTemplate:               groupData{Callsign: "mobius 1", Group: "merged"},
+}
+
+// Templates are phrasing variants for some response types, usually loaded from a YAML file with LoadTemplates.
+type Templates struct {
+	// Selection selects how variants are chosen. Defaults to RandomSelection.
+	Selection Selection `yaml:"selection"`
+	// Variants maps template keys to lists of variants. Each variant is a Go text/template. Response types which are
+	// not listed use the built-in variants.
+	Variants map[string][]string `yaml:"variants"`
+}
+
+// LoadTemplates reads and validates templates from a YAML file.
+func LoadTemplates(path string) (*Templates, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates: %w", err)
+	}
+	var templates Templates
+	if err := yaml.Unmarshal(b, &templates); err != nil {
+		return nil, fmt.Errorf("failed to parse templates: %w", err)
+	}
+	if _, err := newTemplateSet(&templates); err != nil {
+		return nil, err
+	}
+	return &templates, nil
+}
+
+// templateSet holds the parsed variants of every response type.
+type templateSet struct {
+	selection Selection
+	variants  map[string][]*template.Template
+	// fallbacks are built-in variants of each response type, used if a variant fails to render.
+	fallbacks map[string]*template.Template
+	// counters count how many times each response type has been rendered, for rotation.
+	counters map[string]*atomic.Uint64
+}
+
+// newTemplateSet parses the built-in variants, overridden by any given templates.
+func newTemplateSet(templates *Templates) (*templateSet, error) {
+	set := &templateSet{
+		selection: RandomSelection,
+		variants:  make(map[string][]*template.Template, len(defaultVariants)),
+		fallbacks: make(map[string]*template.Template, len(defaultVariants)),
+		counters:  make(map[string]*atomic.Uint64, len(defaultVariants)),
+	}
+	sources := maps.Clone(defaultVariants)
+	if templates != nil {
+		switch templates.Selection {
+		case "":
+		case RandomSelection, RotateSelection:
+			set.selection = templates.Selection
+		default:
+			return nil, fmt.Errorf("unknown template selection %q; must be %s or %s", templates.Selection, RandomSelection, RotateSelection)
+		}
+		for key, variants := range templates.Variants {
+			if _, ok := templateData[key]; !ok {
+				return nil, fmt.Errorf("unknown template %q; must be one of %s", key, strings.Join(slices.Sorted(maps.Keys(templateData)), ", "))
+			}
+			if len(variants) == 0 {
+				return nil, fmt.Errorf("template %q has no variants", key)
+			}
+			sources[key] = variants
+		}
+	}
+
+	for key, variants := range sources {
+		for i, variant := range variants {
+			tmpl, err := parseTemplate(fmt.Sprintf("%s[%d]", key, i), variant, key)
+			if err != nil {
+				return nil, err
+			}
+			set.variants[key] = append(set.variants[key], tmpl)
+		}
+		fallback, err := parseTemplate(key+"[fallback]", defaultVariants[key][0], key)
+		if err != nil {
+			return nil, err
+		}
+		set.fallbacks[key] = fallback
+		set.counters[key] = &atomic.Uint64{}
+	}
+	return set, nil
+}
+
+// parseTemplate parses and validates a variant of the given response type.
+func parseTemplate(name, variant, key string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(variant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	// Check every field reference, including those in branches which the example data below does not reach.
+	if err := checkFields(tmpl.Root, reflect.TypeOf(templateData[key])); err != nil {
+		return nil, fmt.Errorf("invalid template %s: %w", name, err)
+	}
+	for _, data := range []any{templateData[key], sampleTemplateData[key]} {
+		if err := tmpl.Execute(&strings.Builder{}, data); err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+	}
+	return tmpl, nil
+}
+
+// checkFields walks a template's parse tree and checks that every field it references exists on the data type.
+func checkFields(node parse.Node, dataType reflect.Type) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkFields(child, dataType); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return checkFields(n.Pipe, dataType)
+	case *parse.IfNode:
+		return checkBranch(&n.BranchNode, dataType)
+	case *parse.RangeNode:
+		return checkBranch(&n.BranchNode, dataType)
+	case *parse.WithNode:
+		return checkBranch(&n.BranchNode, dataType)
+	case *parse.TemplateNode:
+		return checkFields(n.Pipe, dataType)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			if err := checkFields(cmd, dataType); err != nil {
+				return err
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if err := checkFields(arg, dataType); err != nil {
+				return err
+			}
+		}
+	case *parse.FieldNode:
+		return checkFieldChain(n.Ident, dataType)
+	case *parse.VariableNode:
+		// $ is the data passed to the template; fields of other variables cannot be checked statically.
+		if n.Ident[0] == "$" {
+			return checkFieldChain(n.Ident[1:], dataType)
+		}
+	}
+	return nil
+}
+
+// checkBranch checks the pipeline and both lists of an if, range or with action.
+func checkBranch(n *parse.BranchNode, dataType reflect.Type) error {
+	return errors.Join(
+		checkFields(n.Pipe, dataType),
+		checkFields(n.List, dataType),
+		checkFields(n.ElseList, dataType),
+	)
+}
+
+// checkFieldChain checks that a chain of field names such as .A.B resolves on the data type.
+func checkFieldChain(idents []string, dataType reflect.Type) error {
+	t := dataType
+	for _, ident := range idents {
+		if t.Kind() != reflect.Struct {
+			return fmt.Errorf("can't evaluate field %s in type %s", ident, t)
+		}
+		field, ok := t.FieldByName(ident)
+		if !ok || !field.IsExported() {
+			return fmt.Errorf("can't evaluate field %s in type %s", ident, t)
+		}
+		t = field.Type
+	}
+	return nil
+}
+
+// choose selects a variant of the given response type.
+func (s *templateSet) choose(key string) *template.Template {
+	variants := s.variants[key]
+	if s.selection == RotateSelection {
+		n := s.counters[key].Add(1) - 1
+		return variants[n%uint64(len(variants))]
+	}
+	return variants[rand.IntN(len(variants))]
+}
+
+// render chooses a variant of the given response type and executes it with the given data.
+func (s *templateSet) render(key string, data any) string {
+	return s.execute(key, s.choose(key), data)
+}
+
+// renderGroup renders a variant which describes a group in both subtitle and speech form, using the same variant for
+// both.
+func (s *templateSet) renderGroup(key string, callsign string, group NaturalLanguageResponse) NaturalLanguageResponse {
+	tmpl := s.choose(key)
+	return NaturalLanguageResponse{
+		Subtitle: s.execute(key, tmpl, groupData{Callsign: callsign, Group: group.Subtitle}),
+		Speech:   s.execute(key, tmpl, groupData{Callsign: callsign, Group: group.Speech}),
+	}
+}
+
+// execute executes a variant of the given response type with the given data. Variants are validated when they are
+// parsed, but if one fails to render anyway, the error is logged and a built-in variant is rendered instead.
+func (s *templateSet) execute(key string, tmpl *template.Template, data any) string {
+	var builder strings.Builder
+	err := tmpl.Execute(&builder, data)
+	if err == nil {
+		return builder.String()
+	}
+	log.Error().Err(err).Str("template", tmpl.Name()).Msg("failed to render response template; using built-in template")
+	builder.Reset()
+	if err := s.fallbacks[key].Execute(&builder, data); err != nil {
+		log.Error().Err(err).Str("template", key).Msg("failed to render built-in response template")
+		return ""
+	}
+	return builder.String()
+}
@@ -0,0 +1,159 @@
+package composer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultTemplates(t *testing.T) {
+	t.Parallel()
+	set, err := newTemplateSet(nil)
+	require.NoError(t, err)
+	for key := range templateData {
+		assert.NotEmpty(t, set.variants[key], key)
+	}
+}
+
+func TestTemplatesRotate(t *testing.T) {
+	t.Parallel()
+	set, err := newTemplateSet(&Templates{
+		Selection: RotateSelection,
+		Variants: map[string][]string{
+			RadioCheckTemplate: {
+				"{{.Callsign}}, 5 by 5.",
+				"{{.Callsign}}, loud and clear.",
+			},
+		},
+	})
+	require.NoError(t, err)
+	data := callsignData{Callsign: "Mobius 1"}
+	assert.Equal(t, "Mobius 1, 5 by 5.", set.render(RadioCheckTemplate, data))
+	assert.Equal(t, "Mobius 1, loud and clear.", set.render(RadioCheckTemplate, data))
+	assert.Equal(t, "Mobius 1, 5 by 5.", set.render(RadioCheckTemplate, data))
+	// Response types which are not overridden keep the built-in variants.
+	assert.Len(t, set.variants[SayAgainTemplate], len(defaultVariants[SayAgainTemplate]))
+}
+
+func TestTemplatesRenderGroup(t *testing.T) {
+	t.Parallel()
+	set, err := newTemplateSet(&Templates{
+		Variants: map[string][]string{
+			BogeyDopeTemplate: {"{{.Callsign}}, bandits, {{.Group}}"},
+		},
+	})
+	require.NoError(t, err)
+	response := set.renderGroup(BogeyDopeTemplate, "Mobius 1", NaturalLanguageResponse{
+		Subtitle: "BRAA 090/20",
+		Speech:   "BRAA 0 9 0, 20",
+	})
+	assert.Equal(t, "Mobius 1, bandits, BRAA 090/20", response.Subtitle)
+	assert.Equal(t, "Mobius 1, bandits, BRAA 0 9 0, 20", response.Speech)
+}
+
+func TestTemplatesInvalid(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name      string
+		templates Templates
+	}{
+		{
+			name:      "unknown selection",
+			templates: Templates{Selection: "shuffle"},
+		},
+		{
+			name:      "unknown key",
+			templates: Templates{Variants: map[string][]string{"declare": {"{{.Callsign}}, clean."}}},
+		},
+		{
+			name:      "no variants",
+			templates: Templates{Variants: map[string][]string{RadioCheckTemplate: {}}},
+		},
+		{
+			name:      "syntax error",
+			templates: Templates{Variants: map[string][]string{RadioCheckTemplate: {"{{.Callsign, 5 by 5."}}},
+		},
+		{
+			name:      "unknown field",
+			templates: Templates{Variants: map[string][]string{RadioCheckTemplate: {"{{.Callsign}}, {{.Group}}"}}},
+		},
+		{
+			name:      "unknown field in unreached branch",
+			templates: Templates{Variants: map[string][]string{RadioCheckTemplate: {`{{if eq .Callsign "nobody"}}{{.Group}}{{else}}{{.Callsign}}{{end}}, 5 by 5.`}}},
+		},
+		{
+			name:      "unknown field of a field",
+			templates: Templates{Variants: map[string][]string{BogeyDopeTemplate: {"{{$.Group.Bullseye}}"}}},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := newTemplateSet(&test.templates)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTemplatesConditional(t *testing.T) {
+	t.Parallel()
+	set, err := newTemplateSet(&Templates{
+		Variants: map[string][]string{
+			SayAgainTemplate: {`{{if .Callsign}}{{.Callsign}}, say again{{else}}say again{{end}}.`},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Mobius 1, say again.", set.render(SayAgainTemplate, callsignData{Callsign: "Mobius 1"}))
+	assert.Equal(t, "say again.", set.render(SayAgainTemplate, callsignData{}))
+}
+
+func TestTemplatesFallback(t *testing.T) {
+	t.Parallel()
+	set, err := newTemplateSet(nil)
+	require.NoError(t, err)
+	// Bypass validation to simulate a variant which fails to render.
+	set.variants[RadioCheckTemplate] = []*template.Template{
+		template.Must(template.New("broken").Option("missingkey=error").Parse("{{.Callsign}}, {{.Group}}")),
+	}
+	assert.Equal(t, "Mobius 1, 5 by 5.", set.render(RadioCheckTemplate, callsignData{Callsign: "Mobius 1"}))
+}
+
+func TestLoadTemplates(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "templates.yaml")
+	data := `
+selection: rotate
+variants:
+  say-again:
+    - "{{.Callsign}}, say again."
+`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	templates, err := LoadTemplates(path)
+	require.NoError(t, err)
+	assert.Equal(t, RotateSelection, templates.Selection)
+	assert.Equal(t, []string{"{{.Callsign}}, say again."}, templates.Variants[SayAgainTemplate])
+
+	c := New(goldenCallsign, StandardAltitudeFormat, nil, StandardDialect, templates, 0).(*composer)
+	assert.Equal(t, "Mobius 1, say again.", c.templates.Load().render(SayAgainTemplate, callsignData{Callsign: "Mobius 1"}))
+
+	require.NoError(t, os.WriteFile(path, []byte("variants:\n  unknown:\n    - hello\n"), 0o600))
+	_, err = LoadTemplates(path)
+	assert.Error(t, err)
+}
+
+func TestSetTemplates(t *testing.T) {
+	t.Parallel()
+	c := New(goldenCallsign, StandardAltitudeFormat, nil, StandardDialect, nil, 0)
+	require.NoError(t, c.SetTemplates(&Templates{
+		Variants: map[string][]string{SayAgainTemplate: {"{{.Callsign}}, say again."}},
+	}))
+	assert.Equal(t, "mobius 1, say again.", c.ComposeSayAgainResponse(brevity.SayAgainResponse{Callsign: "mobius 1"}).Subtitle)
+
+	assert.Error(t, c.SetTemplates(&Templates{Selection: "shuffle"}))
+	assert.Equal(t, "mobius 1, say again.", c.ComposeSayAgainResponse(brevity.SayAgainResponse{Callsign: "mobius 1"}).Subtitle)
+}
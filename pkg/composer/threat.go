@@ -1,7 +1,6 @@
 package composer
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/dharmab/skyeye/pkg/brevity"
@@ -12,8 +11,5 @@ func (c *composer) ComposeThreatCall(call brevity.ThreatCall) NaturalLanguageRes
 	group := c.ComposeGroup(call.Group)
 	callsignList := strings.Join(call.Callsigns, ", ")
 
-	return NaturalLanguageResponse{
-		Subtitle: fmt.Sprintf("%s, %s", callsignList, group.Subtitle),
-		Speech:   fmt.Sprintf("%s, %s", callsignList, group.Speech),
-	}
+	return c.templates.Load().renderGroup(ThreatTemplate, callsignList, group)
 }
@@ -0,0 +1,20 @@
+package composer
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
+)
+
+// ComposeThreatRingCall implements [Composer.ComposeThreatRingCall].
+func (c *composer) ComposeThreatRingCall(call brevity.ThreatRingCall) NaturalLanguageResponse {
+	if !call.BRA.Bearing().IsMagnetic() {
+		log.Error().Stringer("bearing", call.BRA.Bearing()).Msg("bearing provided to ComposeThreatRingCall should be magnetic")
+	}
+	bra := c.composeBRA(call.BRA)
+	return NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("%s, %s, inside %s threat ring, site %s.", call.Callsign, c.callsign, call.System, bra.Subtitle),
+		Speech:   fmt.Sprintf("%s, %s, inside %s threat ring, site %s.", call.Callsign, c.callsign, c.pronounce(call.System), bra.Speech),
+	}
+}
@@ -0,0 +1,22 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WithMissionTime prefixes the response with the given mission time, as 24-hour clock time to the minute. If the
+// response is split into parts, only the first part is prefixed.
+func WithMissionTime(response NaturalLanguageResponse, missionTime time.Time) NaturalLanguageResponse {
+	clock := missionTime.Format("1504")
+	timestamp := NaturalLanguageResponse{
+		Subtitle: fmt.Sprintf("Time %s:%s,", clock[:2], clock[2:]),
+		Speech:   fmt.Sprintf("time %s,", strings.TrimSpace(PronounceNumbers(clock))),
+	}
+	timestamped := joinResponses(timestamp, response)
+	if len(response.Parts) > 0 {
+		timestamped.Parts = append([]NaturalLanguageResponse{joinResponses(timestamp, response.Parts[0])}, response.Parts[1:]...)
+	}
+	return timestamped
+}
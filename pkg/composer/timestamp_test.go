@@ -0,0 +1,32 @@
+package composer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMissionTime(t *testing.T) {
+	t.Parallel()
+	missionTime := time.Date(2024, 6, 1, 9, 5, 42, 0, time.UTC)
+	response := NaturalLanguageResponse{
+		Subtitle: "Focus, 2 groups.",
+		Speech:   "Focus, 2 groups.",
+	}
+
+	actual := WithMissionTime(response, missionTime)
+	assert.Equal(t, "Time 09:05, Focus, 2 groups.", actual.Subtitle)
+	assert.Equal(t, "time 0 9 0 5, Focus, 2 groups.", actual.Speech)
+	assert.Empty(t, actual.Parts)
+
+	response.Parts = []NaturalLanguageResponse{
+		{Subtitle: "Focus, 2 groups.", Speech: "Focus, 2 groups."},
+		{Subtitle: "Focus, continued.", Speech: "Focus, continued."},
+	}
+	actual = WithMissionTime(response, missionTime)
+	require.Len(t, actual.Parts, 2)
+	assert.Equal(t, "Time 09:05, Focus, 2 groups.", actual.Parts[0].Subtitle)
+	assert.Equal(t, "Focus, continued.", actual.Parts[1].Subtitle)
+}
@@ -0,0 +1,108 @@
+package composer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+)
+
+// ComposeTrainingResponse implements [Composer.ComposeTrainingResponse].
+func (c *composer) ComposeTrainingResponse(response brevity.TrainingResponse) NaturalLanguageResponse {
+	state := "off"
+	if response.Enabled {
+		state = "on"
+	}
+	reply := fmt.Sprintf("%s, %s, training commentary %s.", response.Callsign, c.callsign, state)
+	return NaturalLanguageResponse{
+		Subtitle: reply,
+		Speech:   reply,
+	}
+}
+
+// ComposeCommentaryCall implements [Composer.ComposeCommentaryCall].
+func (c *composer) ComposeCommentaryCall(call brevity.CommentaryCall) NaturalLanguageResponse {
+	addressing := c.callsign
+	if call.Callsign != "" {
+		addressing = call.Callsign
+	}
+	sentences := []string{addressing + ", training."}
+
+	reasons := c.priorityReasons(call.Group)
+	switch {
+	case call.IsPriority && len(reasons) > 0:
+		sentences = append(sentences, fmt.Sprintf("That %s was called first because %s.", c.groupNoun(1), joinPhrases(reasons)))
+	case call.IsPriority:
+		sentences = append(sentences, fmt.Sprintf("That %s was called first because it is the highest priority.", c.groupNoun(1)))
+	case len(reasons) > 0:
+		sentences = append(sentences, fmt.Sprintf("That %s matters because %s.", c.groupNoun(1), joinPhrases(reasons)))
+	}
+	sentences = append(sentences, c.gameplan(call.Group))
+
+	text := strings.Join(sentences, " ")
+	return NaturalLanguageResponse{
+		Subtitle: text,
+		Speech:   text,
+	}
+}
+
+// priorityReasons explains in plain language why a group is important.
+func (c *composer) priorityReasons(group brevity.Group) []string {
+	var reasons []string
+	switch group.Declaration() {
+	case brevity.Hostile:
+		reasons = append(reasons, "it is hostile")
+	case brevity.Bogey:
+		reasons = append(reasons, "it has not been identified yet")
+	}
+	if group.Threat() {
+		reasons = append(reasons, "it is close enough to threaten friendly aircraft")
+	}
+	if braa := group.BRAA(); braa != nil && braa.Aspect() == brevity.Hot {
+		reasons = append(reasons, "it is pointed at you")
+	}
+	if group.Heavy() {
+		reasons = append(reasons, "it has three or more aircraft")
+	}
+	if group.Fast() || group.VeryFast() {
+		reasons = append(reasons, "it is fast")
+	}
+	if group.High() {
+		reasons = append(reasons, "it is high, which gives its missiles more range")
+	}
+	return reasons
+}
+
+// joinPhrases joins phrases into a list, e.g. "a, b and c".
+func joinPhrases(phrases []string) string {
+	if len(phrases) == 1 {
+		return phrases[0]
+	}
+	return strings.Join(phrases[:len(phrases)-1], ", ") + " and " + phrases[len(phrases)-1]
+}
+
+// gameplan suggests what a new pilot might do about a group.
+func (c *composer) gameplan(group brevity.Group) string {
+	braa := group.BRAA()
+	if braa == nil {
+		return "For a bearing and range from your own aircraft, ask for BOGEY DOPE."
+	}
+	distance := fmt.Sprintf("%d %s", c.composeRange(braa.Range()), c.rangeUnitWord())
+	switch braa.Aspect() {
+	case brevity.Hot:
+		switch {
+		case braa.Range() <= 10*unit.NauticalMile:
+			return fmt.Sprintf("At %s and closing, expect a merge soon. Be ready to defend, or press to the merge.", distance)
+		case braa.Range() <= 30*unit.NauticalMile:
+			return fmt.Sprintf("At %s and closing, you are near missile range. Target the %s with your radar and be ready to shoot first.", distance, c.groupNoun(1))
+		default:
+			return fmt.Sprintf("At %s, you have time. Climb, build speed and find the %s on your radar before it closes.", distance, c.groupNoun(1))
+		}
+	case brevity.Flank, brevity.Beam:
+		return "It is not pointed at you. This is a chance to attack from its side before it turns toward you."
+	case brevity.Drag:
+		return "It is flying away from you. Chasing it will cost fuel, so consider whether it is worth it."
+	}
+	return fmt.Sprintf("Watch the %s's aspect; ask for BOGEY DOPE again for an update.", c.groupNoun(1))
+}
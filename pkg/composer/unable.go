@@ -2,39 +2,29 @@ package composer
 
 import (
 	"fmt"
-	"math/rand/v2"
 
 	"github.com/dharmab/skyeye/pkg/brevity"
 )
 
 // ComposeSayAgainResponse implements [Composer.ComposeSayAgainResponse].
 func (c *composer) ComposeSayAgainResponse(response brevity.SayAgainResponse) NaturalLanguageResponse {
-	replies := map[bool][]string{
-		true: {
-			"%s, sorry, I didn't understand. Say again.",
-			"%s, I didn't catch that. Say again.",
-			"%s, I didn't understand. Say again.",
-			"%s, say again.",
-			"%s, I didn't get that. Say again.",
-			"%s, I only got the first part of that. Say again.",
-		},
-		false: {
-			"I heard my callsign, but I did not understand the request. Say again.",
-			"I heard someone call me, but I didn't understand what they said. Say again.",
-			"I only got the first part of that. Say again.",
-			"Sorry, I only caught part of that. Say again.",
-		},
-	}
-	haveCallsign := response.Callsign != ""
-	variation := replies[haveCallsign][rand.IntN(len(replies[haveCallsign]))]
-	reply := ""
-	if haveCallsign {
-		reply = fmt.Sprintf(variation, response.Callsign)
+	var reply string
+	if response.Callsign != "" {
+		reply = c.templates.Load().render(SayAgainTemplate, callsignData{Callsign: response.Callsign})
 	} else {
-		reply = variation
+		reply = c.templates.Load().render(SayAgainNoCallsignTemplate, struct{}{})
 	}
 	return NaturalLanguageResponse{
 		Subtitle: reply,
 		Speech:   reply,
 	}
 }
+
+// ComposeStandbyResponse implements [Composer.ComposeStandbyResponse].
+func (c *composer) ComposeStandbyResponse(brevity.StandbyResponse) NaturalLanguageResponse {
+	reply := fmt.Sprintf("Last caller, %s, standby. Busy, say again shortly.", c.callsign)
+	return NaturalLanguageResponse{
+		Subtitle: reply,
+		Speech:   reply,
+	}
+}
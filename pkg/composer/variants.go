@@ -0,0 +1,108 @@
+package composer
+
+// defaultVariants are the built-in phrasing variants of each response type.
+var defaultVariants = map[string][]string{
+	RadioCheckTemplate: {
+		"{{.Callsign}}, 5 by 5.",
+		"{{.Callsign}}, 5 by 5!",
+		"{{.Callsign}}, I read you 5 by 5.",
+		"{{.Callsign}}, I've got you 5 by 5.",
+		"{{.Callsign}}, loud and clear.",
+		"{{.Callsign}}, I read you loud and clear.",
+		"{{.Callsign}}, I've got you loud and clear.",
+		"{{.Callsign}}, Lima Charlie.",
+		"{{.Callsign}}, Lima Charlie!",
+	},
+	RadioCheckNoContactTemplate: combine(
+		[]string{
+			"{{.Callsign}}, I've got you 5 by 5",
+			"{{.Callsign}}, I read you 5 by 5",
+			"{{.Callsign}}, I've got you loud and clear",
+			"{{.Callsign}}, I read you loud and clear",
+			"{{.Callsign}}, I heard you",
+		},
+		[]string{
+			"but I don't see you on the scope.",
+			"but I don't see you on the radar.",
+			"but I don't see you on the scope.",
+			"but I don't see you on the radar.",
+			"but you are not on the scope.",
+			"but you are not on my radar.",
+		},
+		", ",
+	),
+	NegativeRadarContactTemplate: {
+		"{{.Callsign}}, negative radar contact. Double check your callsign.",
+		"{{.Callsign}}, negative radar contact. Check your callsign.",
+		"{{.Callsign}}, negative radar contact. Verify your callsign.",
+		"{{.Callsign}}, negative radar contact. Confirm your callsign.",
+		"{{.Callsign}}, negative radar contact. Send it again for me.",
+		"{{.Callsign}}, negative radar contact. I might have misheard your callsign.",
+		"{{.Callsign}}, negative radar contact. Is that the right callsign?",
+		"{{.Callsign}}, negative radar contact. Possible I misheard the callsign.",
+		"{{.Callsign}}, negative radar contact. No contact with that callsign on scope.",
+		"{{.Callsign}}, negative radar contact. Can't find that callsign on scope.",
+		"{{.Callsign}}, negative radar contact. I don't see that callsign on scope.",
+		"{{.Callsign}}, negative radar contact. I don't have that callsign on scope.",
+		"{{.Callsign}}, negative radar contact. I do not have that callsign on scope.",
+	},
+	SayAgainTemplate: {
+		"{{.Callsign}}, sorry, I didn't understand. Say again.",
+		"{{.Callsign}}, I didn't catch that. Say again.",
+		"{{.Callsign}}, I didn't understand. Say again.",
+		"{{.Callsign}}, say again.",
+		"{{.Callsign}}, I didn't get that. Say again.",
+		"{{.Callsign}}, I only got the first part of that. Say again.",
+	},
+	SayAgainNoCallsignTemplate: {
+		"I heard my callsign, but I did not understand the request. Say again.",
+		"I heard someone call me, but I didn't understand what they said. Say again.",
+		"I only got the first part of that. Say again.",
+		"Sorry, I only caught part of that. Say again.",
+	},
+	TripwireTemplate: combine(
+		[]string{
+			"{{.Callsign}}, I've got my copy of MULTI-SERVICE TACTICS TECHNIQUES AND PROCEDURES for Air Control Communication right here, and I don't see anything in here about a so-called TRIPWIRE.",
+			"{{.Callsign}}, I'm not sure what you mean by TRIPWIRE. I don't see that term in MULTI-SERVICE TACTICS TECHNIQUES AND PROCEDURES for Air Control Communication.",
+			"{{.Callsign}}, TRIPWIRE is not a term we use in Air Battle Management.",
+			"{{.Callsign}}, I'm not sure what you mean by TRIPWIRE.",
+			"{{.Callsign}}, I don't see anything about a TRIPWIRE in MULTI-SERVICE TACTICS TECHNIQUES AND PROCEDURES for Air Control Communication.",
+			"{{.Callsign}}, I'm not sure what you mean by TRIPWIRE. I don't see that term in MULTI-SERVICE TACTICS TECHNIQUES AND PROCEDURES for Air Control Communication.",
+			"{{.Callsign}}, give me a second, I'm just searching my copy of MULTI-SERVICE TACTICS TECHNIQUES AND PROCEDURES for Air Control Communication for what a TRIPWIRE is. Nope, I couldn't find it in there.",
+			"{{.Callsign}}, I have no idea what a TRIPWIRE is. Frankly, I don't want to know.",
+			"{{.Callsign}}, I think you have me confused with someone else.",
+			"{{.Callsign}}, did you know how many times the word TRIPWIRE appears in MULTI-SERVICE TACTICS TECHNIQUES AND PROCEDURES for Air Control Communication? I'll give you a hint: it's less than once. ",
+			"{{.Callsign}}, TRIPWIRE ain't no brevity I ever heard of!",
+			"{{.Callsign}}, please refer to MULTI-SERVICE TACTICS TECHNIQUES AND PROCEDURES for Air Control Communication. You will find that it does not contain any so-called TRIPWIRE.",
+		},
+		[]string{
+			"Look, I'm watching you on the radar, and I'll let you know if I see any threats, okay?",
+			"Look, I'm watching you on the radar, and I'll let you know if I see any threats.",
+			"I'll let you know with a THREAT call if I see anything that could be a danger to you, and you can ask me for an updated PICTURE at any time.",
+			"I'm watching the radar for threats, and I'll let you know if I see anything that could be a danger to you.",
+			"I'll keep watching you on the radar and let you know if I see anything that could be a threat.",
+			"I'm monitoring you on my radar scope, and will let you know about any threats.",
+			"I am monitoring you on the radar and will automatically inform you about any threats.",
+			"Why don't you just focus on flying, and I'll focus on watching the radar for threats?",
+			"Let's keep it simple: you fly the plane, I watch the radar for threats, and I'll let you know if I see anything.",
+			"I'm watching for threats on the radar, and I'll inform you if anything needs your attention.",
+			"I am following you on the radar and will tell you about any threats.",
+			"I'm monitoring you on the radar. I will inform you if I see any threats, and you can ask me for an updated PICTURE at any time.",
+		},
+		" ",
+	),
+	BogeyDopeTemplate: {"{{.Callsign}}, {{.Group}}"},
+	ThreatTemplate:    {"{{.Callsign}}, {{.Group}}"},
+	MergedTemplate:    {"{{.Callsign}}, merged. {{.Group}}"},
+}
+
+// combine returns every combination of a first part and a second part, joined by the separator.
+func combine(first, second []string, separator string) []string {
+	combinations := make([]string, 0, len(first)*len(second))
+	for _, a := range first {
+		for _, b := range second {
+			combinations = append(combinations, a+separator+b)
+		}
+	}
+	return combinations
+}
@@ -10,7 +10,7 @@ func (c *controller) HandleAlphaCheck(request *brevity.AlphaCheckRequest) {
 	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
 	logger.Debug().Msg("handling request")
 
-	foundCallsign, trackfile := c.scope.FindCallsign(request.Callsign, c.coalition)
+	foundCallsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition)
 	if trackfile == nil {
 		logger.Debug().Msg("no trackfile found for requestor")
 		c.out <- brevity.AlphaCheckResponse{
@@ -27,5 +27,7 @@ func (c *controller) HandleAlphaCheck(request *brevity.AlphaCheckRequest) {
 		Callsign: foundCallsign,
 		Status:   true,
 		Location: location,
+		Format:   request.Format,
+		Point:    trackfile.LastKnown().Point,
 	}
 }
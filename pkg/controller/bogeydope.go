@@ -1,8 +1,13 @@
 package controller
 
 import (
+	"slices"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
 	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
 	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
 	"github.com/rs/zerolog/log"
 )
 
@@ -11,7 +16,7 @@ func (c *controller) HandleBogeyDope(request *brevity.BogeyDopeRequest) {
 	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Any("filter", request.Filter).Logger()
 	logger.Debug().Msg("handling request")
 
-	foundCallsign, trackfile := c.scope.FindCallsign(request.Callsign, c.coalition)
+	foundCallsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition)
 	if trackfile == nil {
 		logger.Info().Msg("no trackfile found for requestor")
 		c.out <- brevity.NegativeRadarContactResponse{Callsign: request.Callsign}
@@ -23,22 +28,52 @@ func (c *controller) HandleBogeyDope(request *brevity.BogeyDopeRequest) {
 
 	origin := trackfile.LastKnown().Point
 	radius := 300 * unit.NauticalMile
-	nearestGroup := c.scope.FindNearestGroupWithBRAA(
-		origin,
-		lowestAltitude,
-		highestAltitude,
-		radius,
-		c.coalition.Opposite(),
-		request.Filter,
-	)
+	if request.Filter == brevity.Surface {
+		c.respondBogeyDopeSurface(foundCallsign, origin, radius)
+		return
+	}
+	if request.Groups > 1 {
+		c.respondBogeyDopeGroups(request, foundCallsign, trackfile)
+		return
+	}
+
+	var nearestGroup brevity.Group
+	if request.Nose > 0 {
+		nose := trackfile.Course()
+		logger.Info().Stringer("nose", nose).Float64("arc", request.Nose.Degrees()).Msg("searching sector on requestor's nose")
+		nearestGroup = c.scope.FindNearestGroupInSector(
+			origin,
+			lowestAltitude,
+			highestAltitude,
+			radius,
+			nose,
+			2*request.Nose,
+			c.coalition.Opposite(),
+			request.Filter,
+		)
+	} else {
+		nearestGroup = c.scope.FindNearestGroupWithBRAA(
+			origin,
+			lowestAltitude,
+			highestAltitude,
+			radius,
+			c.coalition.Opposite(),
+			request.Filter,
+		)
+	}
 
 	if nearestGroup == nil {
 		logger.Info().Msg("no hostile groups found")
+		c.engagements.record(foundCallsign, nil, nil)
 		c.out <- brevity.BogeyDopeResponse{Callsign: foundCallsign, Group: nil}
 		return
 	}
 
 	nearestGroup.SetDeclaration(brevity.Hostile)
+	c.engagements.record(foundCallsign, nearestGroup, c.threatIDs(trackfile.Contact.ID))
+	if c.respondIfInsideGroup(foundCallsign, trackfile, nearestGroup) {
+		return
+	}
 	c.fillInMergeDetails(nearestGroup)
 
 	logger.Info().
@@ -46,4 +81,79 @@ func (c *controller) HandleBogeyDope(request *brevity.BogeyDopeRequest) {
 		Str("aspect", string(nearestGroup.Aspect())).
 		Msg("found nearest hostile group")
 	c.out <- brevity.BogeyDopeResponse{Callsign: foundCallsign, Group: nearestGroup}
+	c.commentate(foundCallsign, nearestGroup, false)
+}
+
+// respondBogeyDopeSurface responds to a BOGEY DOPE for surface contacts with the nearest hostile group of ships or
+// air defenses.
+func (c *controller) respondBogeyDopeSurface(callsign string, origin orb.Point, radius unit.Length) {
+	logger := log.With().Str("callsign", callsign).Logger()
+	group, ok := c.groundForces.NearestThreat(origin, c.coalition.Opposite(), radius)
+	if !ok {
+		logger.Info().Msg("no hostile surface groups found")
+		c.out <- brevity.BogeyDopeResponse{Callsign: callsign}
+		return
+	}
+	logger.Info().Str("group", group.Name).Strs("platforms", group.Platforms).Msg("found nearest hostile surface group")
+	c.out <- brevity.BogeyDopeResponse{
+		Callsign: callsign,
+		Surface:  c.describeGroundGroup(origin, group),
+	}
+}
+
+// respondBogeyDopeGroups responds to a BOGEY DOPE which asks for more than one group, with the nearest groups ordered
+// from nearest to furthest and the separation between each group and the one before it.
+func (c *controller) respondBogeyDopeGroups(request *brevity.BogeyDopeRequest, callsign string, trackfile *trackfiles.Trackfile) {
+	logger := log.With().Str("callsign", callsign).Int("groups", request.Groups).Logger()
+
+	origin := trackfile.LastKnown().Point
+	groups := c.scope.FindNearbyGroupsWithBRAA(
+		origin,
+		origin,
+		lowestAltitude,
+		highestAltitude,
+		300*unit.NauticalMile,
+		c.coalition.Opposite(),
+		request.Filter,
+		[]uint64{trackfile.Contact.ID},
+	)
+	if request.Nose > 0 {
+		nose := trackfile.Course().Magnetic(c.scope.Declination(origin))
+		logger.Info().Stringer("nose", nose).Float64("arc", request.Nose.Degrees()).Msg("searching sector on requestor's nose")
+		groups = slices.DeleteFunc(groups, func(group brevity.Group) bool {
+			return bearings.Difference(group.BRAA().Bearing(), nose) > request.Nose
+		})
+	}
+	groups = groups[:min(len(groups), request.Groups)]
+
+	if len(groups) == 0 {
+		logger.Info().Msg("no hostile groups found")
+		c.engagements.record(callsign, nil, nil)
+		c.out <- brevity.BogeyDopeResponse{Callsign: callsign, Group: nil}
+		return
+	}
+
+	groups[0].SetDeclaration(brevity.Hostile)
+	c.engagements.record(callsign, groups[0], c.threatIDs(trackfile.Contact.ID))
+	if c.respondIfInsideGroup(callsign, trackfile, groups[0]) {
+		return
+	}
+
+	separations := make([]brevity.Separation, 0, len(groups)-1)
+	for i, group := range groups {
+		group.SetDeclaration(brevity.Hostile)
+		c.fillInMergeDetails(group)
+		if i > 0 {
+			separations = append(separations, brevity.NewSeparation(groups[i-1].BRAA(), group.BRAA()))
+		}
+	}
+
+	logger.Info().Int("found", len(groups)).Msg("found nearest hostile groups")
+	response := brevity.BogeyDopeResponse{Callsign: callsign, Group: groups[0]}
+	if len(groups) > 1 {
+		response.Groups = groups
+		response.Separations = separations
+	}
+	c.out <- response
+	c.commentate(callsign, groups[0], false)
 }
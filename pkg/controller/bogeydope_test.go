@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleBogeyDopeGroups(t *testing.T) {
+	t.Parallel()
+	fighter := orb.Point{41.0, 42.0}
+	at := func(bearing unit.Angle, distance unit.Length) orb.Point {
+		return spatial.PointAtBearingAndDistance(fighter, bearings.NewTrueBearing(bearing), distance)
+	}
+	newController := func() (*controller, chan any) {
+		scope := newFakeRadar()
+		scope.add(1, "mobius 1", coalitions.Blue, fighter, 20000*unit.Foot)
+		scope.add(2, "near bandit", coalitions.Red, at(0, 20*unit.NauticalMile), 20000*unit.Foot)
+		scope.add(3, "middle bandit", coalitions.Red, at(180*unit.Degree, 30*unit.NauticalMile), 20000*unit.Foot)
+		scope.add(4, "far bandit", coalitions.Red, at(10*unit.Degree, 40*unit.NauticalMile), 20000*unit.Foot)
+		out := make(chan any, 10)
+		return &controller{
+			coalition:   coalitions.Blue,
+			scope:       scope,
+			engagements: newEngagementTracker(),
+			threats:     newThreatTracker(),
+			merges:      newMergeTracker(time.Minute),
+			training:    newTrainingTracker(false),
+			out:         out,
+		}, out
+	}
+	receive := func(t *testing.T, out chan any) brevity.BogeyDopeResponse {
+		t.Helper()
+		require.Len(t, out, 1)
+		response, ok := (<-out).(brevity.BogeyDopeResponse)
+		require.True(t, ok)
+		return response
+	}
+	objectIDs := func(groups []brevity.Group) [][]uint64 {
+		ids := make([][]uint64, 0, len(groups))
+		for _, group := range groups {
+			ids = append(ids, group.ObjectIDs())
+		}
+		return ids
+	}
+
+	t.Run("nearest groups", func(t *testing.T) {
+		t.Parallel()
+		c, out := newController()
+		c.HandleBogeyDope(&brevity.BogeyDopeRequest{Callsign: "mobius 1", Filter: brevity.Aircraft, Groups: 2})
+		response := receive(t, out)
+		assert.Equal(t, "mobius 1", response.Callsign)
+		assert.Equal(t, []uint64{2}, response.Group.ObjectIDs())
+		assert.Equal(t, [][]uint64{{2}, {3}}, objectIDs(response.Groups))
+		require.Len(t, response.Separations, 1)
+		for _, group := range response.Groups {
+			assert.Equal(t, brevity.Hostile, group.Declaration())
+		}
+	})
+
+	t.Run("more groups than found", func(t *testing.T) {
+		t.Parallel()
+		c, out := newController()
+		c.HandleBogeyDope(&brevity.BogeyDopeRequest{Callsign: "mobius 1", Filter: brevity.Aircraft, Groups: 5})
+		response := receive(t, out)
+		assert.Equal(t, [][]uint64{{2}, {3}, {4}}, objectIDs(response.Groups))
+		assert.Len(t, response.Separations, 2)
+	})
+
+	t.Run("on the nose", func(t *testing.T) {
+		t.Parallel()
+		c, out := newController()
+		c.scope.(*fakeRadar).move("mobius 1", at(0, 1*unit.NauticalMile))
+		c.HandleBogeyDope(&brevity.BogeyDopeRequest{Callsign: "mobius 1", Filter: brevity.Aircraft, Groups: 2, Nose: 30 * unit.Degree})
+		response := receive(t, out)
+		assert.Equal(t, [][]uint64{{2}, {4}}, objectIDs(response.Groups), "the group behind the requester should be excluded")
+	})
+
+	t.Run("single group found", func(t *testing.T) {
+		t.Parallel()
+		c, out := newController()
+		c.scope.(*fakeRadar).move("mobius 1", at(0, 1*unit.NauticalMile))
+		c.HandleBogeyDope(&brevity.BogeyDopeRequest{Callsign: "mobius 1", Filter: brevity.Aircraft, Groups: 2, Nose: 5 * unit.Degree})
+		response := receive(t, out)
+		assert.Equal(t, []uint64{2}, response.Group.ObjectIDs())
+		assert.Empty(t, response.Groups)
+		assert.Empty(t, response.Separations)
+	})
+
+	t.Run("no groups found", func(t *testing.T) {
+		t.Parallel()
+		c, out := newController()
+		for _, callsign := range []string{"near bandit", "middle bandit", "far bandit"} {
+			delete(c.scope.(*fakeRadar).contacts, callsign)
+		}
+		c.HandleBogeyDope(&brevity.BogeyDopeRequest{Callsign: "mobius 1", Filter: brevity.Aircraft, Groups: 2})
+		assert.Equal(t, brevity.BogeyDopeResponse{Callsign: "mobius 1"}, receive(t, out))
+	})
+}
@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/rs/zerolog/log"
+)
+
+// announceDuplicateCallsign tells a friendly pilot which of several aircraft sharing their callsign the controller is
+// treating as theirs, so that they can tell if the following response is about someone else.
+func (c *controller) announceDuplicateCallsign(callsign string, trackfile *trackfiles.Trackfile, count int) {
+	if trackfile.Contact.Coalition != c.coalition {
+		return
+	}
+	bullseye := c.bullseyeOf(c.scope.Bullseye(c.coalition), trackfile.LastKnown().Point)
+	log.Info().Str("callsign", callsign).Int("count", count).Uint64("id", trackfile.Contact.ID).Msg("announcing choice between aircraft sharing a callsign")
+	c.out <- brevity.DuplicateCallsignCall{
+		Callsign: callsign,
+		Count:    count,
+		Bullseye: *bullseye,
+	}
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+)
+
+// coldLookahead is how far ahead the positions of a hostile group and a friendly aircraft are extrapolated to check if
+// the range between them is opening.
+const coldLookahead = 10 * time.Second
+
+// isCold returns true if the hostile aircraft is in the drag aspect relative to the friendly aircraft and the range
+// between them is opening.
+func isCold(friendly, hostile *trackfiles.Trackfile, declination unit.Angle) bool {
+	if hostile.Direction() == brevity.UnknownDirection {
+		return false
+	}
+	friendlyPoint := friendly.LastKnown().Point
+	hostilePoint := hostile.LastKnown().Point
+	bearing := spatial.TrueBearing(friendlyPoint, hostilePoint).Magnetic(declination)
+	if brevity.AspectFromAngle(bearing, hostile.Course()) != brevity.Drag {
+		return false
+	}
+	at := hostile.LastKnown().Time.Add(coldLookahead)
+	distance := spatial.Distance(friendlyPoint, hostilePoint)
+	future := spatial.Distance(friendly.Extrapolate(at, coldLookahead), hostile.Extrapolate(at, coldLookahead))
+	return future > distance
+}
+
+// isColdTo returns true if the hostile group is cold to the friendly aircraft with the given ID. The group's first
+// contact is taken as representative of the group.
+func (c *controller) isColdTo(hostileGroup brevity.Group, friendID uint64) bool {
+	ids := hostileGroup.ObjectIDs()
+	if len(ids) == 0 {
+		return false
+	}
+	hostile := c.scope.FindUnit(ids[0])
+	friendly := c.scope.FindUnit(friendID)
+	if hostile == nil || friendly == nil {
+		return false
+	}
+	return isCold(friendly, hostile, c.scope.Declination(friendly.LastKnown().Point))
+}
+
+// isColdToAll returns true if the hostile group is cold to every friendly aircraft with the given IDs.
+func (c *controller) isColdToAll(hostileGroup brevity.Group, friendIDs []uint64) bool {
+	if len(friendIDs) == 0 {
+		return false
+	}
+	for _, id := range friendIDs {
+		if !c.isColdTo(hostileGroup, id) {
+			return false
+		}
+	}
+	return true
+}
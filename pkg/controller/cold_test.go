@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsCold(t *testing.T) {
+	t.Parallel()
+	center := orb.Point{42.5, 43.5}
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	newTrackfile := func(start orb.Point, course unit.Angle, speed unit.Speed) *trackfiles.Trackfile {
+		trackfile := trackfiles.NewTrackfile(trackfiles.Labels{})
+		trackfile.Update(trackfiles.Frame{Time: now.Add(-time.Second), Point: start, Heading: unit.Angle(course.Degrees())})
+		trackfile.Update(trackfiles.Frame{
+			Time:    now,
+			Point:   spatial.PointAtBearingAndDistance(start, bearings.NewTrueBearing(course), unit.Length(speed.MetersPerSecond())*unit.Meter),
+			Heading: unit.Angle(course.Degrees()),
+		})
+		return trackfile
+	}
+	ahead := spatial.PointAtBearingAndDistance(center, bearings.NewTrueBearing(0), 20*unit.NauticalMile)
+	friendly := newTrackfile(center, 0, 200*unit.MetersPerSecond)
+
+	testCases := []struct {
+		name     string
+		hostile  *trackfiles.Trackfile
+		expected bool
+	}{
+		{"hot", newTrackfile(ahead, 180*unit.Degree, 250*unit.MetersPerSecond), false},
+		{"beam", newTrackfile(ahead, 90*unit.Degree, 250*unit.MetersPerSecond), false},
+		{"cold and opening", newTrackfile(ahead, 0, 250*unit.MetersPerSecond), true},
+		{"cold but closing", newTrackfile(ahead, 0, 150*unit.MetersPerSecond), false},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, isCold(friendly, test.hostile, 0))
+		})
+	}
+}
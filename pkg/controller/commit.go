@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/rs/zerolog/log"
+)
+
+// isAnyFighterCommitted returns true if any fighter is within the commit range of the target group most recently
+// described to it, or has called TALLY on that group. This only speeds up MERGED calls; the GCI does not make range
+// countdown calls.
+func (c *controller) isAnyFighterCommitted() bool {
+	if c.commitRange <= 0 {
+		return false
+	}
+	for callsign, e := range c.engagements.all() {
+		_, fighter := c.scope.FindCallsign(callsign, c.coalition)
+		if fighter == nil {
+			continue
+		}
+		if c.tallies.isTally(fighter.Contact.ID, e.targetIDs) {
+			log.Debug().Str("callsign", callsign).Msg("fighter is committed with a tally on its target group")
+			return true
+		}
+		for _, id := range e.targetIDs {
+			target := c.scope.FindUnit(id)
+			if target == nil {
+				continue
+			}
+			if spatial.Distance(fighter.LastKnown().Point, target.LastKnown().Point) < c.commitRange {
+				log.Debug().Str("callsign", callsign).Uint64("targetID", id).Msg("fighter is committed")
+				return true
+			}
+		}
+	}
+	return false
+}
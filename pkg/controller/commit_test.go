@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAnyFighterCommitted(t *testing.T) {
+	t.Parallel()
+	fighter := orb.Point{41.0, 42.0}
+	at := func(distance unit.Length) orb.Point {
+		return spatial.PointAtBearingAndDistance(fighter, bearings.NewTrueBearing(90*unit.Degree), distance)
+	}
+	scope := newFakeRadar()
+	scope.add(1, "mobius 1", coalitions.Blue, fighter, 20000*unit.Foot)
+	scope.add(2, "bandit", coalitions.Red, at(30*unit.NauticalMile), 20000*unit.Foot)
+	c := &controller{
+		coalition:   coalitions.Blue,
+		scope:       scope,
+		engagements: newEngagementTracker(),
+		threats:     newThreatTracker(),
+		tallies:     newTallyTracker(),
+		commitRange: 20 * unit.NauticalMile,
+	}
+	assert.False(t, c.isAnyFighterCommitted(), "no engagements")
+
+	c.engagements.engagements["mobius 1"] = engagement{targetIDs: []uint64{2}}
+	assert.False(t, c.isAnyFighterCommitted(), "outside commit range")
+
+	scope.move("bandit", at(15*unit.NauticalMile))
+	assert.True(t, c.isAnyFighterCommitted(), "entered commit range")
+
+	scope.move("bandit", at(25*unit.NauticalMile))
+	assert.False(t, c.isAnyFighterCommitted(), "left commit range")
+
+	c.tallies.set(1, []uint64{2})
+	assert.True(t, c.isAnyFighterCommitted(), "tally on target group")
+	c.tallies.clear(1)
+
+	scope.move("bandit", at(15*unit.NauticalMile))
+	c.commitRange = 0
+	assert.False(t, c.isAnyFighterCommitted(), "disabled")
+}
@@ -0,0 +1,16 @@
+package controller
+
+import (
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleConfirm implements Controller.HandleConfirm.
+func (c *controller) HandleConfirm(request *brevity.ConfirmRequest) {
+	log.Debug().Str("callsign", request.Callsign).Str("requestType", request.RequestType).Type("type", request).Msg("handling request")
+	response := brevity.ConfirmResponse{Callsign: request.Callsign, RequestType: request.RequestType}
+	if callsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition); trackfile != nil {
+		response.Callsign = callsign
+	}
+	c.out <- response
+}
@@ -5,10 +5,16 @@ import (
 	"context"
 	"time"
 
+	"github.com/dharmab/skyeye/internal/conf"
+	"github.com/dharmab/skyeye/pkg/alerts"
+	"github.com/dharmab/skyeye/pkg/areas"
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/ground"
 	"github.com/dharmab/skyeye/pkg/radar"
+	"github.com/dharmab/skyeye/pkg/sim"
 	"github.com/dharmab/skyeye/pkg/simpleradio"
+	"github.com/dharmab/skyeye/pkg/tankers"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
 	"github.com/martinlindhe/unit"
 	"github.com/rs/zerolog/log"
@@ -28,18 +34,50 @@ type Controller interface {
 	HandleAlphaCheck(*brevity.AlphaCheckRequest)
 	// HandleBogeyDope handles a BOGEY DOPE by reporting the closest enemy group to the requesting aircraft.
 	HandleBogeyDope(*brevity.BogeyDopeRequest)
+	// HandleConfirm handles a request which was recognized with low confidence, by asking the caller to repeat it.
+	HandleConfirm(*brevity.ConfirmRequest)
 	// HandleDeclare handles a DECLARE by reporting information about the target group.
 	HandleDeclare(*brevity.DeclareRequest)
 	// HandlePicture handles a PICTURE by reporting a tactical air picture.
 	HandlePicture(*brevity.PictureRequest)
 	// HandleRadioCheck handles a RADIO CHECK by responding to the requesting aircraft.
 	HandleRadioCheck(*brevity.RadioCheckRequest)
+	// HandleSighting handles a TALLY, NO JOY, VISUAL, BLIND or PRESS by updating which groups the requesting aircraft
+	// has sighted.
+	HandleSighting(*brevity.SightingRequest)
 	// HandleSnaplock handles a SNAPLOCK by reporting information about the target group.
 	HandleSnaplock(*brevity.SnaplockRequest)
 	// HandleSpiked handles a SPIKED by reporting any enemy groups in the direction of the radar spike.
 	HandleSpiked(*brevity.SpikedRequest)
+	// HandleStatus handles a STATUS by reporting changes to the requesting aircraft's target group since the last call.
+	HandleStatus(*brevity.StatusRequest)
 	// HandleTripwire handles a TRIPWIRE... by not implementing it LOL
 	HandleTripwire(*brevity.TripwireRequest)
+	// HandleGroundDope handles a TROOPS IN CONTACT by reporting the nearest hostile ground group to the requesting aircraft.
+	HandleGroundDope(*brevity.GroundDopeRequest)
+	// HandleSurvivor handles a request for a vector to the nearest downed friendly pilot.
+	HandleSurvivor(*brevity.SurvivorRequest)
+	// HandleTanker handles a request for a vector to the nearest friendly tanker.
+	HandleTanker(*brevity.TankerRequest)
+	// TrackEjections updates the downed friendly pilots from the ejections seen since the mission started. New
+	// ejections are broadcast to friendly aircraft.
+	TrackEjections([]sim.Ejection)
+	// HandleMute handles a request to stop or resume THREAT calls and PICTURE broadcasts to the requesting aircraft.
+	HandleMute(*brevity.MuteRequest)
+	// HandleTraining handles a request to turn training commentary on or off for the requesting aircraft.
+	HandleTraining(*brevity.TrainingRequest)
+	// HandleGameplan handles a request to store or recall the requesting aircraft's briefed gameplan.
+	HandleGameplan(*brevity.GameplanRequest)
+	// SetTraining sets whether training commentary is given to players who have not turned it on or off themselves.
+	SetTraining(bool)
+	// Training reports whether training commentary is given to players who have not turned it on or off themselves.
+	Training() bool
+	// PackageCallsigns returns the callsigns of the players on frequency in the named package. The second return value
+	// is false if there is no such package.
+	PackageCallsigns(name string) ([]string, bool)
+	// NearbyCallsigns returns the callsigns of the players on frequency within the given radius of a point, which is
+	// given as a bullseye position.
+	NearbyCallsigns(point brevity.Bullseye, radius unit.Length) []string
 	// HandleUnableToUnderstand handles requests where the wake word was recognized but the request could not be understood, by asking players on the channel to repeat their message.
 	HandleUnableToUnderstand(*brevity.UnableToUnderstandRequest)
 }
@@ -60,26 +98,80 @@ type controller struct {
 
 	// enableAutomaticPicture enables automatic picture broadcasts.
 	enableAutomaticPicture bool
-	// pictureBroadcastInterval is the interval at which the controller broadcasts a tactical air picture.
-	pictureBroadcastInterval time.Duration
-	// pictureBroadcastDeadline is the time at which the controller will broadcast the next tactical air picture.
-	pictureBroadcastDeadline time.Time
-	// wasLastPictureClean tracks if the most recently broadcast picture was clean, so that the controller can avoid
-	// repeatedly broadcasting clean pictures.
-	wasLastPictureClean bool
+	// pictures schedules automatic picture broadcasts.
+	pictures *pictureScheduler
+	// pictureMaxGroups is the maximum number of groups described in detail in a PICTURE. Any further groups are summarized.
+	pictureMaxGroups int
+	// enableFullPicture allows players to request a FULL PICTURE which describes every group.
+	enableFullPicture bool
 
 	// enableThreatMonitoring enables automatic threat calls.
 	enableThreatMonitoring bool
 	// threatCooldowns tracks the next time a threat call should be published for each threat.
-	threatCooldowns *cooldownTracker
+	threatCooldowns *alerts.Tracker[uint64]
 	// threatMonitoringCooldown is the interval between threat calls for the same threat.
 	threatMonitoringCooldown time.Duration
 	// threatMonitoringRequiresSRS enforces that threat calls are only broadcast when the relevant friendly aircraft are on frequency.
 	threatMonitoringRequiresSRS bool
+	// packageThreats extends threat calls to every player in the threatened aircraft's package.
+	packageThreats bool
+	// coldThreats selects how threat calls handle hostile groups which are cold to the threatened aircraft.
+	coldThreats conf.ColdThreats
+	// mezWarningLookahead is how far ahead friendly aircraft's tracks are projected to warn of hostile SAM MEZs ahead.
+	// Zero disables MEZ warnings.
+	mezWarningLookahead time.Duration
+	// mezWarnings tracks the most recent MEZ warning to each friendly aircraft about each site.
+	mezWarnings *alerts.Tracker[mezWarningKey]
+
+	// commitRange is the range from a fighter to its target group within which the fighter is considered committed.
+	// Zero disables high-frequency updates for committed fighters.
+	commitRange unit.Length
+	// commitUpdateInterval is how often merges are evaluated while any fighter is committed.
+	commitUpdateInterval time.Duration
+
+	// hvaaCallsigns designates friendly aircraft with these callsigns as High Value Airborne Assets, in addition to
+	// aircraft tagged as HVAAs.
+	hvaaCallsigns []string
+	// hvaaProtectionRange is the range from an HVAA within which hostile groups trigger protection alerts. Zero disables
+	// HVAA protection.
+	hvaaProtectionRange unit.Length
+	// hvaaAlerts tracks the most recent protection alert for each threatened HVAA.
+	hvaaAlerts *alerts.Tracker[hvaaAlertKey]
 
 	// merges tracks which contacts are in the merge.
 	merges *mergeTracker
 
+	// engagements tracks the target group most recently described to each fighter.
+	engagements *engagementTracker
+
+	// threats tracks the hostile contacts threatening each friendly aircraft as of the most recent monitoring tick.
+	threats *threatTracker
+
+	// tallies tracks which hostile contacts each friendly aircraft has sighted.
+	tallies *tallyTracker
+
+	// training tracks which players receive training commentary.
+	training *trainingTracker
+
+	// mutes tracks which players asked the GCI to stop broadcasting to them.
+	mutes *muteTracker
+
+	// gameplans tracks the gameplans briefed by each flight.
+	gameplans *gameplanTracker
+
+	// groundForces is the picture of ground forces and ships used to answer TROOPS IN CONTACT and surface BOGEY DOPE
+	// requests.
+	groundForces *ground.Picture
+
+	// namedAreas are the mission-defined areas which may be referenced by name in DECLARE requests. May be nil.
+	namedAreas *areas.Areas
+
+	// survivors tracks downed friendly pilots for Combat Search and Rescue.
+	survivors *survivorTracker
+
+	// tankers identifies friendly tankers and their configured TACAN channels and frequencies.
+	tankers *tankers.Registry
+
 	// out is the channel to publish responses and calls to.
 	out chan<- any
 }
@@ -90,9 +182,24 @@ func New(
 	coalition coalitions.Coalition,
 	enableAutomaticPicture bool,
 	pictureBroadcastInterval time.Duration,
+	quietPictureWhenClean bool,
+	pictureMaxGroups int,
+	enableFullPicture bool,
 	enableThreatMonitoring bool,
 	threatMonitoringCooldown time.Duration,
 	threatMonitoringRequiresSRS bool,
+	packageThreats bool,
+	coldThreats conf.ColdThreats,
+	mezWarningLookahead time.Duration,
+	commitRange unit.Length,
+	commitUpdateInterval time.Duration,
+	mergeCooldown time.Duration,
+	hvaaCallsigns []string,
+	hvaaProtectionRange unit.Length,
+	enableTraining bool,
+	groundForces *ground.Picture,
+	namedAreas *areas.Areas,
+	tankerRegistry *tankers.Registry,
 ) Controller {
 	return &controller{
 		coalition:                   coalition,
@@ -100,13 +207,33 @@ func New(
 		srsClient:                   srsClient,
 		warmupTime:                  time.Now().Add(15 * time.Second),
 		enableAutomaticPicture:      enableAutomaticPicture,
-		pictureBroadcastInterval:    pictureBroadcastInterval,
-		pictureBroadcastDeadline:    time.Now().Add(pictureBroadcastInterval),
+		pictures:                    newPictureScheduler(pictureBroadcastInterval, quietPictureWhenClean, time.Now()),
+		pictureMaxGroups:            max(1, pictureMaxGroups),
+		enableFullPicture:           enableFullPicture,
 		enableThreatMonitoring:      enableThreatMonitoring,
 		threatMonitoringCooldown:    threatMonitoringCooldown,
-		threatCooldowns:             newCooldownTracker(threatMonitoringCooldown),
+		threatCooldowns:             alerts.NewTracker[uint64](threatMonitoringCooldown),
 		threatMonitoringRequiresSRS: threatMonitoringRequiresSRS,
-		merges:                      newMergeTracker(),
+		packageThreats:              packageThreats,
+		coldThreats:                 coldThreats,
+		mezWarningLookahead:         mezWarningLookahead,
+		mezWarnings:                 alerts.NewTracker[mezWarningKey](threatMonitoringCooldown),
+		commitRange:                 commitRange,
+		commitUpdateInterval:        commitUpdateInterval,
+		hvaaCallsigns:               hvaaCallsigns,
+		hvaaProtectionRange:         hvaaProtectionRange,
+		hvaaAlerts:                  alerts.NewTracker[hvaaAlertKey](threatMonitoringCooldown),
+		merges:                      newMergeTracker(mergeCooldown),
+		engagements:                 newEngagementTracker(),
+		threats:                     newThreatTracker(),
+		tallies:                     newTallyTracker(),
+		training:                    newTrainingTracker(enableTraining),
+		mutes:                       newMuteTracker(),
+		gameplans:                   newGameplanTracker(),
+		groundForces:                groundForces,
+		namedAreas:                  namedAreas,
+		survivors:                   newSurvivorTracker(),
+		tankers:                     tankerRegistry,
 	}
 }
 
@@ -115,7 +242,7 @@ func (c *controller) Run(ctx context.Context, out chan<- any) {
 	c.out = out
 
 	log.Info().Msg("attaching callbacks")
-	c.scope.SetFadedCallback(func(group brevity.Group, coalition coalitions.Coalition) {
+	c.scope.SetFadedCallback(func(group brevity.Group, extrapolated *brevity.Bullseye, coalition coalitions.Coalition) {
 		for _, id := range group.ObjectIDs() {
 			c.remove(id)
 		}
@@ -123,15 +250,17 @@ func (c *controller) Run(ctx context.Context, out chan<- any) {
 			group.SetDeclaration(brevity.Hostile)
 			if c.srsClient.HumansOnFrequency() > 0 {
 				log.Info().Stringer("group", group).Msg("broadcasting FADED call")
-				c.out <- brevity.FadedCall{Group: group}
+				c.out <- brevity.FadedCall{Group: group, Extrapolated: extrapolated}
 			} else {
 				log.Debug().Msg("skipping FADED call because no clients are on frequency")
 			}
 		}
 	})
-	c.scope.SetRemovedCallback(func(trackfile trackfiles.Trackfile) {
+	c.scope.SetRemovedCallback(func(trackfile *trackfiles.Trackfile) {
 		c.remove(trackfile.Contact.ID)
 	})
+	c.scope.SetThreatZoneCallback(c.warnThreatRing)
+	c.scope.SetDuplicateCallsignCallback(c.announceDuplicateCallsign)
 
 	frequencies := make([]unit.Frequency, 0)
 	for _, rf := range c.srsClient.Frequencies() {
@@ -142,19 +271,42 @@ func (c *controller) Run(ctx context.Context, out chan<- any) {
 	ticker := time.NewTicker(15 * time.Second)
 	defer ticker.Stop()
 
+	// While a fighter is committed on its target, evaluate merges more often so that MERGED calls are timely.
+	var commitTicks <-chan time.Time
+	if c.commitRange > 0 && c.commitUpdateInterval > 0 {
+		commitTicker := time.NewTicker(c.commitUpdateInterval)
+		defer commitTicker.Stop()
+		commitTicks = commitTicker.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			log.Info().Msg("detaching callbacks")
 			c.scope.SetFadedCallback(nil)
 			c.scope.SetRemovedCallback(nil)
+			c.scope.SetThreatZoneCallback(nil)
+			c.scope.SetDuplicateCallsignCallback(nil)
 			return
 		case <-ticker.C:
 			c.broadcastMerges()
-			c.broadcastThreats()
-			if c.enableAutomaticPicture && time.Now().After(c.pictureBroadcastDeadline) {
+			threats := c.scope.Threats(c.coalition.Opposite())
+			c.threats.update(threats)
+			c.broadcastThreats(threats)
+			c.protectHVAAs()
+			c.warnMEZs()
+			c.broadcastEjections()
+			if c.enableAutomaticPicture {
 				logger := log.With().Logger()
-				c.broadcastPicture(&logger, false)
+				if c.pictures.isDue(time.Now()) {
+					c.broadcastPicture(&logger, false, false)
+				} else {
+					c.broadcastPictureClean(&logger)
+				}
+			}
+		case <-commitTicks:
+			if c.isAnyFighterCommitted() {
+				c.broadcastMerges()
 			}
 		}
 	}
@@ -162,6 +314,9 @@ func (c *controller) Run(ctx context.Context, out chan<- any) {
 
 func (c *controller) remove(id uint64) {
 	log.Debug().Uint64("id", id).Msg("removing ID from controller state tracking")
-	c.threatCooldowns.remove(id)
+	c.threatCooldowns.Reset(id)
 	c.merges.remove(id)
+	c.hvaaAlerts.ResetFunc(func(key hvaaAlertKey) bool { return key.involves(id) })
+	c.mezWarnings.ResetFunc(func(key mezWarningKey) bool { return key.id == id })
+	c.tallies.remove(id)
 }
@@ -0,0 +1,233 @@
+package controller
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/radar"
+	"github.com/dharmab/skyeye/pkg/simpleradio"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+// fakeRadar is a [radar.Radar] backed by a fixed set of trackfiles, for testing handlers. Calling a method which is
+// not implemented here panics.
+type fakeRadar struct {
+	radar.Radar
+	// contacts are the trackfiles on the scope, keyed by callsign.
+	contacts map[string]*trackfiles.Trackfile
+	// bullseye is the bullseye of every coalition.
+	bullseye orb.Point
+	// packages are the packages of every coalition.
+	packages []radar.Package
+}
+
+// newFakeRadar creates a fake radar with no contacts.
+func newFakeRadar() *fakeRadar {
+	return &fakeRadar{contacts: make(map[string]*trackfiles.Trackfile)}
+}
+
+// add places a contact on the scope at the given point and altitude.
+func (r *fakeRadar) add(id uint64, callsign string, coalition coalitions.Coalition, point orb.Point, altitude unit.Length) *trackfiles.Trackfile {
+	trackfile := trackfiles.NewTrackfile(trackfiles.Labels{ID: id, Name: callsign, Coalition: coalition, ACMIName: "F-15C"})
+	trackfile.Update(trackfiles.Frame{Time: time.Now(), Point: point, Altitude: altitude})
+	r.contacts[callsign] = trackfile
+	return trackfile
+}
+
+// move updates a contact's position.
+func (r *fakeRadar) move(callsign string, point orb.Point) {
+	trackfile := r.contacts[callsign]
+	last := trackfile.LastKnown()
+	trackfile.Update(trackfiles.Frame{Time: last.Time.Add(time.Second), Point: point, Altitude: last.Altitude})
+}
+
+// FindCallsign implements [radar.Radar.FindCallsign].
+func (r *fakeRadar) FindCallsign(callsign string, coalition coalitions.Coalition) (string, *trackfiles.Trackfile) {
+	trackfile, ok := r.contacts[callsign]
+	if !ok || trackfile.Contact.Coalition != coalition {
+		return "", nil
+	}
+	return callsign, trackfile
+}
+
+// ResolveCallsign implements [radar.Radar.ResolveCallsign].
+func (r *fakeRadar) ResolveCallsign(callsign string, coalition coalitions.Coalition) (string, *trackfiles.Trackfile) {
+	return r.FindCallsign(callsign, coalition)
+}
+
+// FindUnit implements [radar.Radar.FindUnit].
+func (r *fakeRadar) FindUnit(id uint64) *trackfiles.Trackfile {
+	for _, trackfile := range r.contacts {
+		if trackfile.Contact.ID == id {
+			return trackfile
+		}
+	}
+	return nil
+}
+
+// Declination implements [radar.Radar.Declination].
+func (*fakeRadar) Declination(orb.Point) unit.Angle {
+	return 0
+}
+
+// Bullseye implements [radar.Radar.Bullseye].
+func (r *fakeRadar) Bullseye(coalitions.Coalition) orb.Point {
+	return r.bullseye
+}
+
+// Trackfiles implements [radar.Radar.Trackfiles].
+func (r *fakeRadar) Trackfiles() []*trackfiles.Trackfile {
+	result := make([]*trackfiles.Trackfile, 0, len(r.contacts))
+	for _, trackfile := range r.contacts {
+		result = append(result, trackfile)
+	}
+	return result
+}
+
+// Packages implements [radar.Radar.Packages].
+func (r *fakeRadar) Packages(coalitions.Coalition) []radar.Package {
+	return r.packages
+}
+
+// fakeSRSClient is a [simpleradio.Client] with a fixed number of peers on frequency, for testing handlers. Calling a
+// method which is not implemented here panics.
+type fakeSRSClient struct {
+	simpleradio.Client
+	// humans is the number of human peers on frequency.
+	humans int
+	// onFrequency are the names of the peers on frequency.
+	onFrequency []string
+}
+
+// IsOnFrequency implements [simpleradio.Client.IsOnFrequency].
+func (c *fakeSRSClient) IsOnFrequency(name string) bool {
+	return slices.Contains(c.onFrequency, name)
+}
+
+// HumansOnFrequency implements [simpleradio.Client.HumansOnFrequency].
+func (c *fakeSRSClient) HumansOnFrequency() int {
+	return c.humans
+}
+
+// FindNearbyGroupsWithBRAA implements [radar.Radar.FindNearbyGroupsWithBRAA]. Each contact is its own group.
+func (r *fakeRadar) FindNearbyGroupsWithBRAA(
+	origin,
+	pointOfInterest orb.Point,
+	_,
+	_,
+	radius unit.Length,
+	coalition coalitions.Coalition,
+	_ brevity.ContactCategory,
+	excludedIDs []uint64,
+) []brevity.Group {
+	groups := make([]brevity.Group, 0)
+	for _, grp := range r.groupsNear(origin, pointOfInterest, radius, coalition) {
+		if !slices.Contains(excludedIDs, grp.id) {
+			groups = append(groups, grp)
+		}
+	}
+	return groups
+}
+
+// FindNearestGroupWithBRAA implements [radar.Radar.FindNearestGroupWithBRAA]. Each contact is its own group.
+func (r *fakeRadar) FindNearestGroupWithBRAA(
+	origin orb.Point,
+	_,
+	_,
+	radius unit.Length,
+	coalition coalitions.Coalition,
+	_ brevity.ContactCategory,
+) brevity.Group {
+	groups := r.groupsNear(origin, origin, radius, coalition)
+	if len(groups) == 0 {
+		return nil
+	}
+	return groups[0]
+}
+
+// Threats implements [radar.Radar.Threats]. The fake radar never reports threats.
+func (*fakeRadar) Threats(coalitions.Coalition) map[brevity.Group][]uint64 {
+	return map[brevity.Group][]uint64{}
+}
+
+// groupsNear returns a group for each contact of the given coalition within the radius of the point of interest, with
+// BRAA from the origin, ordered by increasing distance from the point of interest.
+func (r *fakeRadar) groupsNear(origin, pointOfInterest orb.Point, radius unit.Length, coalition coalitions.Coalition) []*fakeGroup {
+	groups := make([]*fakeGroup, 0)
+	for _, trackfile := range r.contacts {
+		if trackfile.Contact.Coalition != coalition {
+			continue
+		}
+		point := trackfile.LastKnown().Point
+		if spatial.Distance(pointOfInterest, point) > radius {
+			continue
+		}
+		groups = append(groups, &fakeGroup{
+			id:       trackfile.Contact.ID,
+			distance: spatial.Distance(pointOfInterest, point),
+			braa: brevity.NewBRAA(
+				spatial.TrueBearing(origin, point).Magnetic(0),
+				spatial.Distance(origin, point),
+				[]unit.Length{trackfile.LastKnown().Altitude},
+				brevity.UnknownAspect,
+			),
+		})
+	}
+	slices.SortFunc(groups, func(a, b *fakeGroup) int {
+		return cmp.Or(cmp.Compare(a.distance, b.distance), cmp.Compare(a.id, b.id))
+	})
+	return groups
+}
+
+// fakeGroup is a [brevity.Group] of a single contact, for testing handlers. Calling a method which is not implemented
+// here panics.
+type fakeGroup struct {
+	brevity.Group
+	id          uint64
+	distance    unit.Length
+	braa        brevity.BRAA
+	declaration brevity.Declaration
+	mergedWith  int
+}
+
+// ObjectIDs implements [brevity.Group.ObjectIDs].
+func (g *fakeGroup) ObjectIDs() []uint64 {
+	return []uint64{g.id}
+}
+
+// BRAA implements [brevity.Group.BRAA].
+func (g *fakeGroup) BRAA() brevity.BRAA {
+	return g.braa
+}
+
+// Declaration implements [brevity.Group.Declaration].
+func (g *fakeGroup) Declaration() brevity.Declaration {
+	return g.declaration
+}
+
+// SetDeclaration implements [brevity.Group.SetDeclaration].
+func (g *fakeGroup) SetDeclaration(declaration brevity.Declaration) {
+	g.declaration = declaration
+}
+
+// MergedWith implements [brevity.Group.MergedWith].
+func (g *fakeGroup) MergedWith() int {
+	return g.mergedWith
+}
+
+// SetMergedWith implements [brevity.Group.SetMergedWith].
+func (g *fakeGroup) SetMergedWith(count int) {
+	g.mergedWith = count
+}
+
+// String implements [brevity.Group.String].
+func (g *fakeGroup) String() string {
+	return fmt.Sprintf("group %d", g.id)
+}
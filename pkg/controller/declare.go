@@ -1,7 +1,6 @@
 package controller
 
 import (
-	"github.com/dharmab/skyeye/pkg/bearings"
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/spatial"
 	"github.com/martinlindhe/unit"
@@ -14,7 +13,9 @@ func (c *controller) HandleDeclare(request *brevity.DeclareRequest) {
 	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
 	logger.Debug().Msg("handling request")
 
-	if request.IsBRAA {
+	if request.Area != "" {
+		logger = logger.With().Str("area", request.Area).Logger()
+	} else if request.IsBRAA {
 		logger = logger.With().
 			Float64("bearingDegrees", request.Bearing.Degrees()).
 			Float64("rangeNM", request.Range.NauticalMiles()).
@@ -31,25 +32,35 @@ func (c *controller) HandleDeclare(request *brevity.DeclareRequest) {
 		Logger()
 	logger.Info().Msg("handling DECLARE request")
 
-	foundCallsign, trackfile := c.scope.FindCallsign(request.Callsign, c.coalition)
+	foundCallsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition)
 	if trackfile == nil {
 		logger.Info().Msg("no trackfile found for requestor")
 		c.out <- brevity.NegativeRadarContactResponse{Callsign: request.Callsign}
 		return
 	}
 
-	var origin orb.Point
-	var bearing bearings.Bearing
-	var distance unit.Length
-	if request.IsBRAA {
+	radius := 7 * unit.NauticalMile
+
+	var pointOfInterest orb.Point
+	if request.Area != "" {
+		area, ok := c.namedAreas.Find(request.Area)
+		if !ok {
+			logger.Info().Msg("no named area matches the declared location")
+			c.out <- brevity.DeclareResponse{Callsign: foundCallsign, Declaration: brevity.Unable}
+			return
+		}
+		logger.Debug().Str("name", area.Name).Msg("locating point of interest using named area")
+		pointOfInterest = area.Center()
+		// Search the whole area, but no less than the usual radius so that small areas still catch nearby contacts.
+		radius = max(radius, area.Radius())
+	} else if request.IsBRAA {
 		logger.Debug().Msg("locating point of interest using BRAA")
 		if !request.Bearing.IsMagnetic() {
 			logger.Warn().Stringer("bearing", request.Bearing).Msg("bearing provided to HandleDeclare should be magnetic")
 		}
-		origin = trackfile.LastKnown().Point
+		origin := trackfile.LastKnown().Point
 		declination := c.scope.Declination(origin)
-		bearing = request.Bearing.True(declination)
-		distance = request.Range
+		pointOfInterest = spatial.PointAtBearingAndDistance(origin, request.Bearing.True(declination), request.Range)
 	} else {
 		logger.Debug().Msg("locating point of interest using bullseye")
 		if request == nil {
@@ -60,14 +71,10 @@ func (c *controller) HandleDeclare(request *brevity.DeclareRequest) {
 		if !request.Bullseye.Bearing().IsMagnetic() {
 			logger.Warn().Stringer("bearing", request.Bullseye.Bearing()).Msg("bearing provided to HandleDeclare should be magnetic")
 		}
-		origin = c.scope.Bullseye(trackfile.Contact.Coalition)
+		origin := c.scope.Bullseye(trackfile.Contact.Coalition)
 		declination := c.scope.Declination(origin)
-		bearing = request.Bullseye.Bearing().True(declination)
-		distance = request.Bullseye.Distance()
+		pointOfInterest = spatial.PointAtBearingAndDistance(origin, request.Bullseye.Bearing().True(declination), request.Bullseye.Distance())
 	}
-	pointOfInterest := spatial.PointAtBearingAndDistance(origin, bearing, distance)
-
-	radius := 7 * unit.NauticalMile
 
 	minAltitude := lowestAltitude
 	maxAltitude := highestAltitude
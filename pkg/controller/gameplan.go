@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/rs/zerolog/log"
+)
+
+// gameplan is a flight's briefed gameplan.
+type gameplan struct {
+	// text is the gameplan in the flight's own words.
+	text string
+	// commitRange is the briefed commit range, or zero if none was briefed.
+	commitRange unit.Length
+}
+
+// gameplanTracker tracks the gameplans briefed by each flight.
+type gameplanTracker struct {
+	// gameplans maps callsigns to their most recently briefed gameplan.
+	gameplans map[string]gameplan
+	lock      sync.RWMutex
+}
+
+func newGameplanTracker() *gameplanTracker {
+	return &gameplanTracker{
+		gameplans: make(map[string]gameplan),
+	}
+}
+
+// set stores the gameplan for the given callsign, replacing any previous gameplan.
+func (t *gameplanTracker) set(callsign string, plan gameplan) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.gameplans[callsign] = plan
+}
+
+// get returns the gameplan for the given callsign. The second return value is false if the callsign has no gameplan.
+func (t *gameplanTracker) get(callsign string) (gameplan, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	plan, ok := t.gameplans[callsign]
+	return plan, ok
+}
+
+// all returns a copy of all gameplans, keyed by callsign.
+func (t *gameplanTracker) all() map[string]gameplan {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return maps.Clone(t.gameplans)
+}
+
+// HandleGameplan implements [Controller.HandleGameplan].
+func (c *controller) HandleGameplan(request *brevity.GameplanRequest) {
+	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
+	logger.Debug().Msg("handling request")
+	foundCallsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition)
+	if trackfile == nil {
+		logger.Info().Msg("no trackfile found for requestor")
+		c.out <- brevity.NegativeRadarContactResponse{Callsign: request.Callsign}
+		return
+	}
+	logger = logger.With().Str("callsign", foundCallsign).Logger()
+
+	if request.Gameplan == "" {
+		plan, _ := c.gameplans.get(foundCallsign)
+		logger.Info().Str("gameplan", plan.text).Msg("recalling gameplan")
+		c.out <- brevity.GameplanResponse{Callsign: foundCallsign, Gameplan: plan.text, IsRecall: true}
+		return
+	}
+
+	c.gameplans.set(foundCallsign, gameplan{text: request.Gameplan, commitRange: request.CommitRange})
+	logger.Info().Str("gameplan", request.Gameplan).Float64("commitRangeNM", request.CommitRange.NauticalMiles()).Msg("stored gameplan")
+	c.out <- brevity.GameplanResponse{Callsign: foundCallsign, Gameplan: request.Gameplan}
+}
+
+// addCommitThreats adds hostile groups within the briefed commit range of each flight to the threats, so that THREAT
+// calls to that flight start at its commit range rather than the usual threat radius.
+func (c *controller) addCommitThreats(threats map[brevity.Group][]uint64) {
+	for callsign, plan := range c.gameplans.all() {
+		if plan.commitRange <= 0 {
+			continue
+		}
+		_, trackfile := c.scope.FindCallsign(callsign, c.coalition)
+		if trackfile == nil {
+			continue
+		}
+		groups := c.scope.FindNearbyGroupsWithBullseye(
+			trackfile.LastKnown().Point,
+			lowestAltitude,
+			highestAltitude,
+			plan.commitRange,
+			c.coalition.Opposite(),
+			brevity.FixedWing,
+			nil,
+		)
+		for _, grp := range groups {
+			addThreat(threats, grp, trackfile.Contact.ID)
+		}
+	}
+}
+
+// addThreat records that the given group threatens the friendly aircraft with the given ID. If the group overlaps a
+// group already in the threats, the friendly is added to that group instead, so that the group is called once.
+func addThreat(threats map[brevity.Group][]uint64, grp brevity.Group, friendID uint64) {
+	for existing, friendIDs := range threats {
+		overlaps := slices.ContainsFunc(existing.ObjectIDs(), func(id uint64) bool {
+			return slices.Contains(grp.ObjectIDs(), id)
+		})
+		if overlaps {
+			if !slices.Contains(friendIDs, friendID) {
+				threats[existing] = append(friendIDs, friendID)
+			}
+			return
+		}
+	}
+	threats[grp] = []uint64{friendID}
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameplanTracker(t *testing.T) {
+	t.Parallel()
+	tracker := newGameplanTracker()
+	_, ok := tracker.get("mobius 1")
+	assert.False(t, ok)
+
+	tracker.set("mobius 1", gameplan{text: "skate 30 mile commit", commitRange: 30 * unit.NauticalMile})
+	plan, ok := tracker.get("mobius 1")
+	require.True(t, ok)
+	assert.Equal(t, "skate 30 mile commit", plan.text)
+
+	tracker.set("mobius 1", gameplan{text: "banzai"})
+	plan, ok = tracker.get("mobius 1")
+	require.True(t, ok)
+	assert.Equal(t, "banzai", plan.text)
+	assert.Zero(t, plan.commitRange)
+	assert.Len(t, tracker.all(), 1)
+}
+
+// idGroup is a distinct [brevity.Group] with the given object IDs. Calling any other method panics.
+type idGroup struct {
+	brevity.Group
+	ids []uint64
+}
+
+func (g *idGroup) ObjectIDs() []uint64 {
+	return g.ids
+}
+
+func TestAddThreat(t *testing.T) {
+	t.Parallel()
+	fighters := &idGroup{ids: []uint64{10, 11}}
+	threats := map[brevity.Group][]uint64{
+		fighters: {1},
+	}
+
+	// A group overlapping an existing threat is merged into it.
+	addThreat(threats, &idGroup{ids: []uint64{11}}, 2)
+	assert.Len(t, threats, 1)
+	assert.Equal(t, []uint64{1, 2}, threats[fighters])
+
+	// A friendly is not added twice.
+	addThreat(threats, &idGroup{ids: []uint64{10}}, 2)
+	assert.Equal(t, []uint64{1, 2}, threats[fighters])
+
+	// A new group is added.
+	bombers := &idGroup{ids: []uint64{20}}
+	addThreat(threats, bombers, 2)
+	assert.Len(t, threats, 2)
+	assert.Equal(t, []uint64{2}, threats[bombers])
+}
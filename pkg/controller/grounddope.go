@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/ground"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog/log"
+)
+
+// groundDopeRadius is how far from the requestor to search for hostile ground groups.
+const groundDopeRadius = 100 * unit.NauticalMile
+
+// groundGridPrecision is the number of digits in each of the easting and northing of a grid reference. Four digits
+// locates a group to within 10 meters.
+const groundGridPrecision = 4
+
+// HandleGroundDope implements [Controller.HandleGroundDope].
+func (c *controller) HandleGroundDope(request *brevity.GroundDopeRequest) {
+	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
+	logger.Debug().Msg("handling request")
+
+	foundCallsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition)
+	if trackfile == nil {
+		logger.Info().Msg("no trackfile found for requestor")
+		c.out <- brevity.NegativeRadarContactResponse{Callsign: request.Callsign}
+		return
+	}
+	logger = logger.With().Str("callsign", foundCallsign).Logger()
+
+	origin := trackfile.LastKnown().Point
+	group, ok := c.groundForces.Nearest(origin, c.coalition.Opposite(), groundDopeRadius)
+	if !ok {
+		logger.Info().Msg("no hostile ground groups found")
+		c.out <- brevity.GroundDopeResponse{Callsign: foundCallsign}
+		return
+	}
+
+	logger.Info().Str("group", group.Name).Int("units", group.Units).Msg("found nearest hostile ground group")
+	c.out <- brevity.GroundDopeResponse{
+		Callsign: foundCallsign,
+		Group:    c.describeGroundGroup(origin, group),
+	}
+}
+
+// describeGroundGroup describes the position of a ground group relative to the origin and the bullseye, and by grid.
+func (c *controller) describeGroundGroup(origin orb.Point, group ground.Group) *brevity.GroundGroup {
+	bearing := spatial.TrueBearing(origin, group.Point).Magnetic(c.scope.Declination(origin))
+	description := &brevity.GroundGroup{
+		BRA:   brevity.NewBRA(bearing, spatial.Distance(origin, group.Point)),
+		Units: group.Units,
+	}
+	if bullseye := c.scope.Bullseye(c.coalition); !spatial.IsZero(bullseye) {
+		bearing := spatial.TrueBearing(bullseye, group.Point).Magnetic(c.scope.Declination(bullseye))
+		description.Bullseye = brevity.NewBullseye(bearing, spatial.Distance(bullseye, group.Point))
+	}
+	if grid, ok := spatial.MGRS(group.Point, groundGridPrecision); ok {
+		description.Grid = grid
+	}
+	for _, kind := range group.Kinds {
+		description.Kinds = append(description.Kinds, string(kind))
+	}
+	description.Platforms = group.Platforms
+	return description
+}
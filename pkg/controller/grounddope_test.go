@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/ground"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/tacview/tags"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleGroundDope(t *testing.T) {
+	t.Parallel()
+	helicopter := orb.Point{41.0, 42.0}
+	armor := spatial.PointAtBearingAndDistance(helicopter, bearings.NewTrueBearing(90*unit.Degree), 10*unit.NauticalMile)
+	tank := []string{tags.Ground, tags.Heavy, tags.Armor, tags.Vehicle, tags.Tank}
+	newController := func(bullseye orb.Point, units ...sim.GroundUnit) (*controller, chan any) {
+		scope := newFakeRadar()
+		scope.bullseye = bullseye
+		scope.add(1, "dustoff 1", coalitions.Blue, helicopter, 500*unit.Foot)
+		groundForces := ground.New(2)
+		groundForces.Update(units)
+		out := make(chan any, 10)
+		return &controller{
+			coalition:    coalitions.Blue,
+			scope:        scope,
+			groundForces: groundForces,
+			out:          out,
+		}, out
+	}
+	receive := func(t *testing.T, out chan any) any {
+		t.Helper()
+		require.Len(t, out, 1)
+		return <-out
+	}
+	hostileArmor := []sim.GroundUnit{
+		{ID: 10, Group: "Armor-1", Coalition: coalitions.Red, Point: armor, Types: tank},
+		{ID: 11, Group: "Armor-1", Coalition: coalitions.Red, Point: armor, Types: tank},
+	}
+
+	t.Run("unknown requestor", func(t *testing.T) {
+		t.Parallel()
+		c, out := newController(orb.Point{}, hostileArmor...)
+		c.HandleGroundDope(&brevity.GroundDopeRequest{Callsign: "dustoff 2"})
+		assert.Equal(t, brevity.NegativeRadarContactResponse{Callsign: "dustoff 2"}, receive(t, out))
+	})
+
+	t.Run("no hostile ground groups", func(t *testing.T) {
+		t.Parallel()
+		friendlyArmor := []sim.GroundUnit{
+			{ID: 10, Group: "Armor-2", Coalition: coalitions.Blue, Point: armor, Types: tank},
+			{ID: 11, Group: "Armor-2", Coalition: coalitions.Blue, Point: armor, Types: tank},
+		}
+		c, out := newController(orb.Point{}, friendlyArmor...)
+		c.HandleGroundDope(&brevity.GroundDopeRequest{Callsign: "dustoff 1"})
+		assert.Equal(t, brevity.GroundDopeResponse{Callsign: "dustoff 1"}, receive(t, out))
+	})
+
+	t.Run("nearest hostile ground group", func(t *testing.T) {
+		t.Parallel()
+		bullseye := spatial.PointAtBearingAndDistance(helicopter, bearings.NewTrueBearing(0), 30*unit.NauticalMile)
+		c, out := newController(bullseye, hostileArmor...)
+		c.HandleGroundDope(&brevity.GroundDopeRequest{Callsign: "dustoff 1"})
+		response, ok := receive(t, out).(brevity.GroundDopeResponse)
+		require.True(t, ok)
+		assert.Equal(t, "dustoff 1", response.Callsign)
+		require.NotNil(t, response.Group)
+		assert.Equal(t, 2, response.Group.Units)
+		assert.Equal(t, []string{string(ground.Armor)}, response.Group.Kinds)
+		assert.InDelta(t, 90, response.Group.BRA.Bearing().Degrees(), 1)
+		assert.InDelta(t, 10, response.Group.BRA.Range().NauticalMiles(), 0.5)
+		assert.NotEmpty(t, response.Group.Grid)
+		require.NotNil(t, response.Group.Bullseye)
+		assert.InDelta(t, 31.6, response.Group.Bullseye.Distance().NauticalMiles(), 0.5)
+	})
+
+	t.Run("unknown bullseye", func(t *testing.T) {
+		t.Parallel()
+		c, out := newController(orb.Point{}, hostileArmor...)
+		c.HandleGroundDope(&brevity.GroundDopeRequest{Callsign: "dustoff 1"})
+		response, ok := receive(t, out).(brevity.GroundDopeResponse)
+		require.True(t, ok)
+		require.NotNil(t, response.Group)
+		assert.Nil(t, response.Group.Bullseye)
+	})
+}
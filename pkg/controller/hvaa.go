@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/encyclopedia"
+	"github.com/dharmab/skyeye/pkg/parser"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/rs/zerolog/log"
+)
+
+// hvaaEscortSearchRadius is the maximum distance from an HVAA to the fighters directed to protect it.
+const hvaaEscortSearchRadius = 120 * unit.NauticalMile
+
+// hvaaAlertKey identifies a hostile group threatening an HVAA. Hostile groups are identified by their lowest object ID,
+// since group membership may change between evaluations.
+type hvaaAlertKey struct {
+	hvaaID   uint64
+	threatID uint64
+}
+
+// involves returns true if the given object ID is either the HVAA or the threat.
+func (k hvaaAlertKey) involves(id uint64) bool {
+	return k.hvaaID == id || k.threatID == id
+}
+
+// isHVAACallsign returns true if the unit name begins with any of the given callsigns. For example, "Magic" matches
+// "Magic 1" and "Magic 1-1", but not "Magical 1".
+func isHVAACallsign(name string, callsigns []string) bool {
+	name, _, _ = strings.Cut(name, "|")
+	name = strings.ToLower(strings.Join(strings.Fields(name), " ")) + " "
+	for _, callsign := range callsigns {
+		callsign = strings.ToLower(strings.Join(strings.Fields(callsign), " "))
+		if callsign != "" && strings.HasPrefix(name, callsign+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// isDesignatedHVAA returns true if the trackfile is tagged as an HVAA or matches a configured HVAA callsign.
+func (c *controller) isDesignatedHVAA(trackfile *trackfiles.Trackfile) bool {
+	return trackfile.HasTag(brevity.TagHVAA) || isHVAACallsign(trackfile.Contact.Name, c.hvaaCallsigns)
+}
+
+// protectHVAAs directs friendly fighters to protect any friendly HVAAs threatened by hostile groups.
+func (c *controller) protectHVAAs() {
+	if !c.enableThreatMonitoring || c.hvaaProtectionRange <= 0 {
+		return
+	}
+	now := time.Now()
+	for _, trackfile := range c.scope.Trackfiles() {
+		if trackfile.Contact.Coalition != c.coalition || !c.isDesignatedHVAA(trackfile) {
+			continue
+		}
+		c.protectHVAA(trackfile, now)
+	}
+}
+
+// protectHVAA broadcasts protection alerts for each hostile group within the protection range of the given HVAA.
+func (c *controller) protectHVAA(hvaa *trackfiles.Trackfile, now time.Time) {
+	point := hvaa.LastKnown().Point
+	hvaaName, ok := parser.ParsePilotCallsign(hvaa.Contact.Name)
+	if !ok {
+		hvaaName = hvaa.Contact.Name
+	}
+	logger := log.With().Uint64("hvaaID", hvaa.Contact.ID).Str("hvaa", hvaaName).Logger()
+
+	// Find hostile groups within each range band, so that the urgency escalates as the threat closes.
+	hostile := c.coalition.Opposite()
+	threats := c.scope.FindNearbyGroupsWithBullseye(point, lowestAltitude, highestAltitude, c.hvaaProtectionRange, hostile, brevity.Aircraft, nil)
+	closing := threatKeys(c.scope.FindNearbyGroupsWithBullseye(point, lowestAltitude, highestAltitude, c.hvaaProtectionRange/2, hostile, brevity.Aircraft, nil))
+	imminent := threatKeys(c.scope.FindNearbyGroupsWithBullseye(point, lowestAltitude, highestAltitude, c.hvaaProtectionRange/4, hostile, brevity.Aircraft, nil))
+
+	var escorts []string
+	for _, threat := range threats {
+		if !c.isCombatant(threat) {
+			continue
+		}
+		threatID := threat.ObjectIDs()[0]
+		urgency := brevity.HVAAThreatened
+		if _, ok := imminent[threatID]; ok {
+			urgency = brevity.HVAAThreatImminent
+		} else if _, ok := closing[threatID]; ok {
+			urgency = brevity.HVAAThreatClosing
+		}
+
+		if escorts == nil {
+			escorts = c.findHVAAEscorts(hvaa)
+		}
+		if len(escorts) == 0 {
+			logger.Debug().Msg("skipping HVAA protection alert because no friendly fighters are available")
+			return
+		}
+
+		key := hvaaAlertKey{hvaaID: hvaa.Contact.ID, threatID: threatID}
+		if !c.hvaaAlerts.Alert(key, int(urgency), now) {
+			continue
+		}
+
+		threat.SetDeclaration(brevity.Hostile)
+		threat.SetThreat(true)
+		call := brevity.HVAAThreatCall{
+			Callsigns: escorts,
+			HVAA:      hvaaName,
+			Group:     threat,
+			Urgency:   urgency,
+		}
+		logger.Info().Any("call", call).Msg("broadcasting HVAA protection alert")
+		c.out <- call
+	}
+}
+
+// findHVAAEscorts returns the callsigns of the nearest friendly fighter group to the given HVAA.
+func (c *controller) findHVAAEscorts(hvaa *trackfiles.Trackfile) []string {
+	groups := c.scope.FindNearbyGroupsWithBullseye(
+		hvaa.LastKnown().Point,
+		lowestAltitude,
+		highestAltitude,
+		hvaaEscortSearchRadius,
+		c.coalition,
+		brevity.FixedWing,
+		[]uint64{hvaa.Contact.ID},
+	)
+	for _, grp := range groups {
+		callsigns := make([]string, 0)
+		for _, id := range grp.ObjectIDs() {
+			trackfile := c.scope.FindUnit(id)
+			if trackfile == nil || c.isDesignatedHVAA(trackfile) {
+				continue
+			}
+			data, ok := encyclopedia.GetAircraftData(trackfile.Contact.ACMIName)
+			if !ok || !data.HasTag(encyclopedia.Fighter) {
+				continue
+			}
+			callsigns = c.addFriendlyToBroadcast(callsigns, trackfile)
+		}
+		callsigns = c.mutes.filter(callsigns)
+		if len(callsigns) > 0 {
+			return callsigns
+		}
+	}
+	return []string{}
+}
+
+// isCombatant returns false if every contact in the group is known to be a non-combatant.
+func (c *controller) isCombatant(grp brevity.Group) bool {
+	for _, id := range grp.ObjectIDs() {
+		trackfile := c.scope.FindUnit(id)
+		if trackfile == nil {
+			continue
+		}
+		data, ok := encyclopedia.GetAircraftData(trackfile.Contact.ACMIName)
+		if !ok || data.IsCombatant() {
+			return true
+		}
+	}
+	return false
+}
+
+// threatKeys returns the set of keys identifying the given groups.
+func threatKeys(groups []brevity.Group) map[uint64]struct{} {
+	keys := make(map[uint64]struct{}, len(groups))
+	for _, grp := range groups {
+		if ids := grp.ObjectIDs(); len(ids) > 0 {
+			keys[ids[0]] = struct{}{}
+		}
+	}
+	return keys
+}
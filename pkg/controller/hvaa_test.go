@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/alerts"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsHVAACallsign(t *testing.T) {
+	t.Parallel()
+	callsigns := []string{"Magic", "texaco", "Dark Star"}
+	testCases := []struct {
+		name     string
+		expected bool
+	}{
+		{"Magic 1", true},
+		{"Magic 1-1", true},
+		{"magic 2 | Mobius", true},
+		{"Texaco 1", true},
+		{"Dark  Star 1", true},
+		{"Magical 1", false},
+		{"Darkstar 1", false},
+		{"Mobius 1", false},
+		{"", false},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, isHVAACallsign(test.name, callsigns))
+		})
+	}
+}
+
+func TestHVAAAlertTracker(t *testing.T) {
+	t.Parallel()
+	tracker := alerts.NewTracker[hvaaAlertKey](3 * time.Minute)
+	key := hvaaAlertKey{hvaaID: 1, threatID: 2}
+	now := time.Now()
+
+	assert.True(t, tracker.Alert(key, int(brevity.HVAAThreatened), now), "first alert")
+	assert.False(t, tracker.Alert(key, int(brevity.HVAAThreatened), now.Add(time.Minute)), "same urgency within cooldown")
+	assert.True(t, tracker.Alert(key, int(brevity.HVAAThreatClosing), now.Add(time.Minute)), "escalation")
+	assert.False(t, tracker.Alert(key, int(brevity.HVAAThreatened), now.Add(2*time.Minute)), "de-escalation within cooldown")
+	assert.True(t, tracker.Alert(key, int(brevity.HVAAThreatImminent), now.Add(2*time.Minute)), "further escalation")
+	assert.True(t, tracker.Alert(key, int(brevity.HVAAThreatImminent), now.Add(6*time.Minute)), "cooldown expired")
+
+	other := hvaaAlertKey{hvaaID: 1, threatID: 3}
+	assert.True(t, tracker.Alert(other, int(brevity.HVAAThreatened), now), "different threat")
+
+	tracker.ResetFunc(func(key hvaaAlertKey) bool { return key.involves(2) })
+	assert.True(t, tracker.Alert(key, int(brevity.HVAAThreatened), now.Add(6*time.Minute)), "threat removed")
+	assert.False(t, tracker.Alert(other, int(brevity.HVAAThreatened), now.Add(time.Minute)), "other threat retained")
+}
@@ -4,27 +4,50 @@ import (
 	"maps"
 	"slices"
 	"sync"
+	"time"
 
+	"github.com/dharmab/skyeye/pkg/alerts"
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/spatial"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geo"
 	"github.com/rs/zerolog/log"
 )
 
+// mergeHysteresis keeps contacts in the merge until they separate well beyond the distance at which they entered it.
+var mergeHysteresis = alerts.Hysteresis[unit.Length]{Enter: brevity.MergeEntryDistance, Exit: brevity.MergeExitDistance}
+
 // mergeTracker tracks hostile IDs and the friendly IDs they have merged with.
+//
+// A friendly's engagement begins when it first merges with a hostile and ends once it has been clear of every hostile
+// for the cooldown. Hostiles and friendlies which re-enter the merge during the cooldown are part of the same
+// engagement, so that the merge is only announced once.
 type mergeTracker struct {
 	merged map[uint64]map[uint64]struct{}
-	lock   sync.RWMutex
+	// separations tracks the cooldown after each hostile and friendly pair exits the merge, keyed by hostile ID and
+	// friendly ID.
+	separations *alerts.Tracker[[2]uint64]
+	// engagements tracks the cooldown after each friendly last exited the merge with any hostile.
+	engagements *alerts.Tracker[uint64]
+	// cooldown is how long a friendly must be clear of every hostile for its engagement to end.
+	cooldown time.Duration
+	lock     sync.RWMutex
 }
 
-func newMergeTracker() *mergeTracker {
+func newMergeTracker(cooldown time.Duration) *mergeTracker {
 	return &mergeTracker{
-		merged: make(map[uint64]map[uint64]struct{}),
+		merged:      make(map[uint64]map[uint64]struct{}),
+		separations: alerts.NewTracker[[2]uint64](cooldown),
+		engagements: alerts.NewTracker[uint64](cooldown),
+		cooldown:    cooldown,
 	}
 }
 
-// merge records that the given hostile has merged with the given friendly.
-func (t *mergeTracker) merge(hostileID, friendID uint64) {
+// merge records that the given hostile has merged with the given friendly. It returns true if this is a new
+// engagement, or false if the contacts were already merged or re-entered the merge during the cooldown.
+func (t *mergeTracker) merge(hostileID, friendID uint64) bool {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 	friendIDs, ok := t.merged[hostileID]
@@ -32,7 +55,15 @@ func (t *mergeTracker) merge(hostileID, friendID uint64) {
 		friendIDs = make(map[uint64]struct{})
 		t.merged[hostileID] = friendIDs
 	}
+	if _, ok := friendIDs[friendID]; ok {
+		return false
+	}
 	friendIDs[friendID] = struct{}{}
+
+	key := [2]uint64{hostileID, friendID}
+	isNew := !t.separations.IsSuppressed(key, 0, time.Now())
+	t.separations.Reset(key)
+	return isNew
 }
 
 // isMerged checks if the given hostile has merged with the given friendly.
@@ -47,6 +78,16 @@ func (t *mergeTracker) isMerged(hostileID, friendID uint64) bool {
 	return ok
 }
 
+// isEngaged checks if the given friendly is merged with any hostile. The caller must hold the lock.
+func (t *mergeTracker) isEngaged(friendID uint64) bool {
+	for _, friendIDs := range t.merged {
+		if _, ok := friendIDs[friendID]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 // friendliesMergedWith returns the IDS that the given hostile ID is merged with.
 func (t *mergeTracker) friendliesMergedWith(hostileID uint64) []uint64 {
 	t.lock.RLock()
@@ -62,44 +103,73 @@ func (t *mergeTracker) friendliesMergedWith(hostileID uint64) []uint64 {
 func (t *mergeTracker) separate(hostileID, friendID uint64) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
+	t.unmerge(hostileID, friendID, time.Now())
+}
+
+// unmerge removes a merged hostile and friendly from the merge and starts their cooldowns. The caller must hold the
+// lock.
+func (t *mergeTracker) unmerge(hostileID, friendID uint64, now time.Time) {
 	friendIDs, ok := t.merged[hostileID]
 	if !ok {
 		return
 	}
+	if _, ok := friendIDs[friendID]; !ok {
+		return
+	}
 	delete(friendIDs, friendID)
 	if len(friendIDs) == 0 {
 		delete(t.merged, hostileID)
 	}
+	t.separations.Record([2]uint64{hostileID, friendID}, 0, now, t.cooldown)
+	t.engagements.Record(friendID, 0, now, t.cooldown)
 }
 
-// remove removes the given ID from the merge tracker.
+// remove removes the given ID from the merge tracker. A removed hostile is treated as having exited the merge, so that
+// the friendlies it was merged with are eventually called clean. A removed friendly is forgotten entirely.
 func (t *mergeTracker) remove(id uint64) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
-	_, ok := t.merged[id]
-	if ok {
-		delete(t.merged, id)
-	} else {
-		for hostileID, friendIDs := range t.merged {
-			delete(friendIDs, id)
-			if len(friendIDs) == 0 {
-				delete(t.merged, hostileID)
-			}
+	if friendIDs, ok := t.merged[id]; ok {
+		now := time.Now()
+		for friendID := range friendIDs {
+			t.unmerge(id, friendID, now)
+		}
+		return
+	}
+	for hostileID, friendIDs := range t.merged {
+		delete(friendIDs, id)
+		if len(friendIDs) == 0 {
+			delete(t.merged, hostileID)
 		}
 	}
+	t.separations.ResetFunc(func(key [2]uint64) bool { return key[0] == id || key[1] == id })
+	t.engagements.Reset(id)
 }
 
-// keep removes any IDs that are not in the given slice from the merge tracker.
+// keep separates any hostile IDs that are not in the given slice from the friendlies they were merged with.
 func (t *mergeTracker) keep(idsToKeep ...uint64) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
-	for id := range t.merged {
+	now := time.Now()
+	for id, friendIDs := range t.merged {
 		if !slices.Contains(idsToKeep, id) {
-			delete(t.merged, id)
+			for friendID := range friendIDs {
+				t.unmerge(id, friendID, now)
+			}
 		}
 	}
 }
 
+// resolve returns the IDs of friendlies whose engagements have ended: they are not merged with any hostile, and have
+// been clear of every hostile for at least the cooldown. The returned friendlies are forgotten.
+func (t *mergeTracker) resolve() []uint64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	resolved := slices.DeleteFunc(t.engagements.Expire(time.Now()), t.isEngaged)
+	slices.Sort(resolved)
+	return resolved
+}
+
 // broadcastMerges updates the merge tracker and broadcasts merged calls for any new merges.
 func (c *controller) broadcastMerges() {
 	merges := c.scope.Merges(c.coalition)
@@ -122,6 +192,26 @@ func (c *controller) broadcastMerges() {
 			logger.Debug().Msg("skipping merged call because no relevant clients are on frequency")
 		}
 	}
+
+	c.broadcastClean()
+}
+
+// broadcastClean broadcasts a CLEAN call to friendlies whose engagements have ended. Their tallies are forgotten, so
+// that threat calls resume for any group they meet next.
+func (c *controller) broadcastClean() {
+	call := brevity.CleanCall{Callsigns: make([]string, 0)}
+	for _, friendID := range c.merges.resolve() {
+		c.tallies.clear(friendID)
+		friendly := c.scope.FindUnit(friendID)
+		if friendly == nil {
+			continue
+		}
+		call.Callsigns = c.addFriendlyToBroadcast(call.Callsigns, friendly)
+	}
+	if len(call.Callsigns) > 0 {
+		log.Info().Strs("callsigns", call.Callsigns).Msg("broadcasting clean call")
+		c.out <- call
+	}
 }
 
 // updateMergesForGroup updates the merge tracker for the given hostile group and friendly contacts.
@@ -151,7 +241,8 @@ func (c *controller) updateMergesForGroup(hostileGroup brevity.Group, friendlies
 }
 
 // updateMergesForContact checks if the given hostile and friendly have merged or separated, and updates the merge tracker accordingly.
-// It returns true if the contacts were merged, or false if they were already merged or if they were separated.
+// It returns true if the contacts began a new engagement, or false if they were already merged, re-entered the merge
+// during the cooldown, or were separated.
 func (c *controller) updateMergesForContact(hostile, friendly *trackfiles.Trackfile) bool {
 	logger := log.
 		With().
@@ -163,14 +254,15 @@ func (c *controller) updateMergesForContact(hostile, friendly *trackfiles.Trackf
 
 	isMerged := c.merges.isMerged(hostile.Contact.ID, friendly.Contact.ID)
 	distance := spatial.Distance(friendly.LastKnown().Point, hostile.LastKnown().Point)
-	enteredMerge := distance < brevity.MergeEntryDistance
-	exitedMerge := distance > brevity.MergeExitDistance
-
-	if !isMerged && enteredMerge {
-		logger.Info().Msg("hostile and friendly merged")
-		c.merges.merge(hostile.Contact.ID, friendly.Contact.ID)
-		return true
-	} else if isMerged && exitedMerge {
+	isInMerge := mergeHysteresis.Update(isMerged, distance)
+
+	if !isMerged && isInMerge {
+		if c.merges.merge(hostile.Contact.ID, friendly.Contact.ID) {
+			logger.Info().Msg("hostile and friendly merged")
+			return true
+		}
+		logger.Info().Msg("hostile and friendly re-entered merge during cooldown")
+	} else if isMerged && !isInMerge {
 		logger.Info().Msg("hostile and friendly exited merge")
 		c.merges.separate(hostile.Contact.ID, friendly.Contact.ID)
 	} else if isMerged {
@@ -210,3 +302,35 @@ func (c *controller) isGroupMergedWithFriendly(hostileGroup brevity.Group, frien
 	}
 	return false
 }
+
+// respondIfInsideGroup responds to the requester with a MERGED call if the requester is inside the given hostile group,
+// since a near-zero BRAA to a group the requester is already in would be confusing. The merge is recorded so that it
+// isn't broadcast again. Returns true if a MERGED call was sent.
+func (c *controller) respondIfInsideGroup(callsign string, trackfile *trackfiles.Trackfile, group brevity.Group) bool {
+	members := make([]orb.Point, 0, len(group.ObjectIDs()))
+	for _, id := range group.ObjectIDs() {
+		if member := c.scope.FindUnit(id); member != nil {
+			members = append(members, member.LastKnown().Point)
+		}
+	}
+	if !isInsideGroup(trackfile.LastKnown().Point, members) {
+		return false
+	}
+	log.Info().Str("callsign", callsign).Stringer("group", group).Msg("requestor is inside hostile group")
+	for _, id := range group.ObjectIDs() {
+		c.merges.merge(id, trackfile.Contact.ID)
+	}
+	c.fillInMergeDetails(group)
+	c.out <- brevity.MergedCall{Callsigns: []string{callsign}, Group: group}
+	return true
+}
+
+// isInsideGroup checks if the given position is within the extent of a group with members at the given positions. The
+// extent is the bounding box of the members, padded by the merge entry distance.
+func isInsideGroup(position orb.Point, members []orb.Point) bool {
+	if len(members) == 0 {
+		return false
+	}
+	extent := geo.BoundPad(orb.MultiPoint(members).Bound(), brevity.MergeEntryDistance.Meters())
+	return extent.Contains(position)
+}
@@ -2,13 +2,18 @@ package controller
 
 import (
 	"testing"
+	"time"
 
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestMergeTrackerMerge(t *testing.T) {
 	t.Parallel()
-	tracker := newMergeTracker()
+	tracker := newMergeTracker(0)
 	tracker.merge(1, 2)
 	assert.True(t, tracker.isMerged(1, 2))
 	assert.False(t, tracker.isMerged(2, 1))
@@ -21,7 +26,7 @@ func TestMergeTrackerMerge(t *testing.T) {
 
 func TestMergeTrackerFriendliesMergedWith(t *testing.T) {
 	t.Parallel()
-	tracker := newMergeTracker()
+	tracker := newMergeTracker(0)
 	tracker.merge(1, 2)
 	assert.Len(t, tracker.friendliesMergedWith(1), 1)
 	assert.Contains(t, tracker.friendliesMergedWith(1), uint64(2))
@@ -33,7 +38,7 @@ func TestMergeTrackerFriendliesMergedWith(t *testing.T) {
 
 func TestMergeTrackerSeparate(t *testing.T) {
 	t.Parallel()
-	tracker := newMergeTracker()
+	tracker := newMergeTracker(0)
 	tracker.merge(1, 2)
 	tracker.merge(1, 3)
 	assert.True(t, tracker.isMerged(1, 2))
@@ -45,7 +50,7 @@ func TestMergeTrackerSeparate(t *testing.T) {
 
 func TestMergeTrackerRemove(t *testing.T) {
 	t.Parallel()
-	tracker := newMergeTracker()
+	tracker := newMergeTracker(0)
 	red1 := uint64(1)
 	red2 := uint64(2)
 	red3 := uint64(3)
@@ -95,7 +100,7 @@ func TestMergeTrackerRemove(t *testing.T) {
 }
 func TestMergeTrackerKeep(t *testing.T) {
 	t.Parallel()
-	tracker := newMergeTracker()
+	tracker := newMergeTracker(0)
 	tracker.merge(1, 11)
 	tracker.merge(1, 12)
 	tracker.merge(2, 11)
@@ -108,3 +113,61 @@ func TestMergeTrackerKeep(t *testing.T) {
 	assert.False(t, tracker.isMerged(3, 12))
 	assert.True(t, tracker.isMerged(4, 11))
 }
+
+func TestMergeTrackerCooldown(t *testing.T) {
+	t.Parallel()
+	tracker := newMergeTracker(time.Hour)
+	assert.True(t, tracker.merge(1, 11), "new engagement")
+	assert.False(t, tracker.merge(1, 11), "already merged")
+	tracker.separate(1, 11)
+	assert.False(t, tracker.isMerged(1, 11))
+	assert.False(t, tracker.merge(1, 11), "re-entered merge during cooldown")
+	assert.True(t, tracker.isMerged(1, 11))
+	assert.True(t, tracker.merge(2, 11), "new hostile")
+
+	tracker = newMergeTracker(0)
+	tracker.merge(1, 11)
+	tracker.separate(1, 11)
+	assert.True(t, tracker.merge(1, 11), "re-entered merge after cooldown")
+}
+
+func TestMergeTrackerResolve(t *testing.T) {
+	t.Parallel()
+	tracker := newMergeTracker(0)
+	tracker.merge(1, 11)
+	tracker.merge(1, 12)
+	tracker.merge(2, 12)
+	assert.Empty(t, tracker.resolve())
+
+	tracker.keep(2)
+	assert.Equal(t, []uint64{11}, tracker.resolve(), "12 is still merged with 2")
+	assert.Empty(t, tracker.resolve(), "resolved friendlies are forgotten")
+
+	tracker.remove(2)
+	assert.Equal(t, []uint64{12}, tracker.resolve(), "removed hostiles exit the merge")
+
+	tracker.merge(3, 13)
+	tracker.separate(3, 13)
+	tracker.remove(13)
+	assert.Empty(t, tracker.resolve(), "removed friendlies are not called clean")
+
+	tracker = newMergeTracker(time.Hour)
+	tracker.merge(1, 11)
+	tracker.separate(1, 11)
+	assert.Empty(t, tracker.resolve(), "cooldown has not elapsed")
+}
+
+func TestIsInsideGroup(t *testing.T) {
+	t.Parallel()
+	center := orb.Point{42.5, 43.5}
+	at := func(bearing unit.Angle, distance unit.Length) orb.Point {
+		return spatial.PointAtBearingAndDistance(center, bearings.NewTrueBearing(bearing), distance)
+	}
+	wall := []orb.Point{at(270*unit.Degree, 6*unit.NauticalMile), at(90*unit.Degree, 6*unit.NauticalMile)}
+
+	assert.False(t, isInsideGroup(center, nil))
+	assert.True(t, isInsideGroup(center, []orb.Point{at(0, 1*unit.NauticalMile)}))
+	assert.False(t, isInsideGroup(center, []orb.Point{at(0, 10*unit.NauticalMile)}))
+	assert.True(t, isInsideGroup(center, wall), "between the members of the group")
+	assert.False(t, isInsideGroup(at(0, 10*unit.NauticalMile), wall))
+}
@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"math"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/radar"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// mezProjectionStep is the interval between points checked along a friendly aircraft's projected track.
+	mezProjectionStep = 5 * time.Second
+	// noseArc is the angle either side of an aircraft's nose within which a point is reported as on the nose.
+	noseArc = 30 * unit.Degree
+)
+
+// projectMEZPenetration projects the trackfile along its current course and ground speed for up to the given
+// lookahead, and returns how long until it enters the zone. The second return value is false if the trackfile is
+// already inside the zone, is not moving, or does not enter the zone within the lookahead. The altitude is assumed to
+// stay the same, and the ceiling is compared against the altitude above sea level.
+func projectMEZPenetration(trackfile *trackfiles.Trackfile, zone radar.ThreatZone, lookahead time.Duration) (time.Duration, bool) {
+	if trackfile.Direction() == brevity.UnknownDirection {
+		return 0, false
+	}
+	latest := trackfile.LastKnown()
+	if zone.Ceiling > 0 && latest.Altitude >= zone.Ceiling {
+		return 0, false
+	}
+	if spatial.Distance(latest.Point, zone.Center) <= zone.Radius {
+		return 0, false
+	}
+	for elapsed := mezProjectionStep; elapsed <= lookahead; elapsed += mezProjectionStep {
+		point := trackfile.Extrapolate(latest.Time.Add(elapsed), lookahead)
+		if spatial.Distance(point, zone.Center) <= zone.Radius {
+			return elapsed, true
+		}
+	}
+	return 0, false
+}
+
+// relativePosition returns the position of a point at the given bearing relative to an aircraft's course. Both
+// bearings should be of the same kind.
+func relativePosition(course, bearing bearings.Bearing) brevity.RelativePosition {
+	θ := bearings.SignedDifference(bearing, course).Degrees()
+	switch {
+	case math.Abs(θ) <= noseArc.Degrees():
+		return brevity.OnNose
+	case θ < 0:
+		return brevity.OffLeft
+	default:
+		return brevity.OffRight
+	}
+}
+
+// mezWarningKey identifies a friendly aircraft approaching a SAM site.
+type mezWarningKey struct {
+	id   uint64
+	site string
+}
+
+// warnMEZs warns friendly aircraft whose current track will take them into the MEZ of a hostile SAM site within the
+// lookahead.
+func (c *controller) warnMEZs() {
+	if !c.enableThreatMonitoring || c.mezWarningLookahead <= 0 {
+		return
+	}
+	zones := c.scope.ThreatZones(c.coalition.Opposite())
+	if len(zones) == 0 {
+		return
+	}
+	now := time.Now()
+	for _, trackfile := range c.scope.Trackfiles() {
+		if trackfile.Contact.Coalition != c.coalition {
+			continue
+		}
+		c.warnMEZ(trackfile, zones, now)
+	}
+}
+
+// warnMEZ warns the friendly aircraft about the first MEZ its current track will enter, if any.
+func (c *controller) warnMEZ(trackfile *trackfiles.Trackfile, zones []radar.ThreatZone, now time.Time) {
+	var nearest *radar.ThreatZone
+	var soonest time.Duration
+	for i := range zones {
+		elapsed, ok := projectMEZPenetration(trackfile, zones[i], c.mezWarningLookahead)
+		if ok && (nearest == nil || elapsed < soonest) {
+			nearest = &zones[i]
+			soonest = elapsed
+		}
+	}
+	if nearest == nil {
+		return
+	}
+	callsigns := c.mutes.filter(c.addFriendlyToBroadcast(nil, trackfile))
+	if len(callsigns) == 0 {
+		return
+	}
+	if !c.mezWarnings.Alert(mezWarningKey{id: trackfile.Contact.ID, site: nearest.Site}, 0, now) {
+		return
+	}
+
+	latest := trackfile.LastKnown()
+	entry := trackfile.Extrapolate(latest.Time.Add(soonest), c.mezWarningLookahead)
+	bearing := spatial.TrueBearing(latest.Point, nearest.Center).Magnetic(c.scope.Declination(latest.Point))
+	call := brevity.MEZWarningCall{
+		Callsign: callsigns[0],
+		System:   nearest.System,
+		Position: relativePosition(trackfile.Course(), bearing),
+		Range:    spatial.Distance(latest.Point, entry),
+	}
+	log.Info().Any("call", call).Str("group", nearest.Group).Stringer("eta", soonest).Msg("broadcasting MEZ warning")
+	c.out <- call
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/radar"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectMEZPenetration(t *testing.T) {
+	t.Parallel()
+	start := orb.Point{42.5, 43.5}
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	speed := 250 * unit.MetersPerSecond
+	newTrackfile := func(course unit.Angle, altitude unit.Length) *trackfiles.Trackfile {
+		trackfile := trackfiles.NewTrackfile(trackfiles.Labels{})
+		trackfile.Update(trackfiles.Frame{Time: now.Add(-time.Second), Point: start, Altitude: altitude, Heading: unit.Angle(course.Degrees())})
+		trackfile.Update(trackfiles.Frame{
+			Time:     now,
+			Point:    spatial.PointAtBearingAndDistance(start, bearings.NewTrueBearing(course), unit.Length(speed.MetersPerSecond())*unit.Meter),
+			Altitude: altitude,
+			Heading:  unit.Angle(course.Degrees()),
+		})
+		return trackfile
+	}
+	// The edge of the zone is about 15 nautical miles north of the start, about 111 seconds away.
+	zone := radar.ThreatZone{
+		Center:  spatial.PointAtBearingAndDistance(start, bearings.NewTrueBearing(0), 40*unit.NauticalMile),
+		Radius:  25 * unit.NauticalMile,
+		Ceiling: 30000 * unit.Foot,
+	}
+
+	testCases := []struct {
+		name      string
+		trackfile *trackfiles.Trackfile
+		lookahead time.Duration
+		expected  bool
+	}{
+		{"toward zone", newTrackfile(0, 20000*unit.Foot), 2 * time.Minute, true},
+		{"beyond lookahead", newTrackfile(0, 20000*unit.Foot), time.Minute, false},
+		{"away from zone", newTrackfile(180*unit.Degree, 20000*unit.Foot), 2 * time.Minute, false},
+		{"above ceiling", newTrackfile(0, 35000*unit.Foot), 2 * time.Minute, false},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			elapsed, ok := projectMEZPenetration(test.trackfile, zone, test.lookahead)
+			require.Equal(t, test.expected, ok)
+			if ok {
+				assert.InDelta(t, 110, elapsed.Seconds(), 10)
+			}
+		})
+	}
+
+	t.Run("inside zone", func(t *testing.T) {
+		t.Parallel()
+		inside := zone
+		inside.Center = start
+		_, ok := projectMEZPenetration(newTrackfile(0, 20000*unit.Foot), inside, 2*time.Minute)
+		assert.False(t, ok)
+	})
+}
+
+func TestRelativePosition(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		course   float64
+		bearing  float64
+		expected brevity.RelativePosition
+	}{
+		{0, 0, brevity.OnNose},
+		{350, 15, brevity.OnNose},
+		{10, 345, brevity.OnNose},
+		{90, 30, brevity.OffLeft},
+		{90, 150, brevity.OffRight},
+		{350, 60, brevity.OffRight},
+		{10, 270, brevity.OffLeft},
+	}
+	for _, test := range testCases {
+		actual := relativePosition(
+			bearings.NewMagneticBearing(unit.Angle(test.course)*unit.Degree),
+			bearings.NewMagneticBearing(unit.Angle(test.bearing)*unit.Degree),
+		)
+		assert.Equal(t, test.expected, actual, "course %v, bearing %v", test.course, test.bearing)
+	}
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/parser"
+	"github.com/rs/zerolog/log"
+)
+
+// muteTracker tracks which callers asked the GCI to stop broadcasting to them.
+type muteTracker struct {
+	muted map[string]struct{}
+	lock  sync.RWMutex
+}
+
+func newMuteTracker() *muteTracker {
+	return &muteTracker{muted: make(map[string]struct{})}
+}
+
+// set stops or resumes broadcasts to the given callsign.
+func (t *muteTracker) set(callsign string, isMuted bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if isMuted {
+		t.muted[callsign] = struct{}{}
+	} else {
+		delete(t.muted, callsign)
+	}
+}
+
+// isMuted checks if broadcasts to the given callsign are stopped.
+func (t *muteTracker) isMuted(callsign string) bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	_, ok := t.muted[callsign]
+	return ok
+}
+
+// filter returns the given callsigns without the callsigns whose broadcasts are stopped.
+func (t *muteTracker) filter(callsigns []string) []string {
+	return slices.DeleteFunc(slices.Clone(callsigns), t.isMuted)
+}
+
+// HandleMute implements [Controller.HandleMute].
+func (c *controller) HandleMute(request *brevity.MuteRequest) {
+	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Bool("muted", request.Muted).Logger()
+	logger.Debug().Msg("handling request")
+	foundCallsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition)
+	if trackfile == nil {
+		logger.Info().Msg("no trackfile found for requestor")
+		c.out <- brevity.NegativeRadarContactResponse{Callsign: request.Callsign}
+		return
+	}
+	c.mutes.set(foundCallsign, request.Muted)
+	logger.Info().Str("callsign", foundCallsign).Msg("set broadcast mute")
+	c.out <- brevity.MuteResponse{Callsign: foundCallsign, Muted: request.Muted}
+}
+
+// isEveryoneMuted checks if every player on frequency has stopped broadcasts.
+func (c *controller) isEveryoneMuted() bool {
+	humans := c.srsClient.HumansOnFrequency()
+	if humans == 0 {
+		return false
+	}
+	muted := make(map[string]struct{})
+	for _, frequency := range c.srsClient.Frequencies() {
+		for _, name := range c.srsClient.Listeners(frequency) {
+			if callsign, ok := parser.ParsePilotCallsign(name); ok && c.mutes.isMuted(callsign) {
+				muted[name] = struct{}{}
+			}
+		}
+	}
+	return len(muted) >= humans
+}
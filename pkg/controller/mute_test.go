@@ -0,0 +1,24 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMuteTracker(t *testing.T) {
+	t.Parallel()
+	tracker := newMuteTracker()
+	callsigns := []string{"mobius 1", "yellow 13"}
+	assert.Equal(t, callsigns, tracker.filter(callsigns))
+
+	tracker.set("mobius 1", true)
+	assert.True(t, tracker.isMuted("mobius 1"))
+	assert.False(t, tracker.isMuted("yellow 13"))
+	assert.Equal(t, []string{"yellow 13"}, tracker.filter(callsigns))
+	assert.Equal(t, []string{"mobius 1", "yellow 13"}, callsigns, "filter should not modify its argument")
+
+	tracker.set("mobius 1", false)
+	assert.False(t, tracker.isMuted("mobius 1"))
+	assert.Equal(t, callsigns, tracker.filter(callsigns))
+}
@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+)
+
+// NearbyCallsigns implements [Controller.NearbyCallsigns].
+func (c *controller) NearbyCallsigns(point brevity.Bullseye, radius unit.Length) []string {
+	bullseye := c.scope.Bullseye(c.coalition)
+	declination := c.scope.Declination(bullseye)
+	center := spatial.PointAtBearingAndDistance(bullseye, point.Bearing().True(declination), point.Distance())
+	callsigns := make([]string, 0)
+	for _, trackfile := range c.scope.Trackfiles() {
+		if trackfile.Contact.Coalition != c.coalition {
+			continue
+		}
+		if spatial.Distance(center, trackfile.LastKnown().Point) > radius {
+			continue
+		}
+		callsigns = c.addFriendlyToBroadcast(callsigns, trackfile)
+	}
+	return callsigns
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"strings"
+)
+
+// PackageCallsigns implements [Controller.PackageCallsigns].
+func (c *controller) PackageCallsigns(name string) ([]string, bool) {
+	name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), " package")
+	for _, pkg := range c.scope.Packages(c.coalition) {
+		if pkg.Name != name {
+			continue
+		}
+		callsigns := make([]string, 0)
+		for _, id := range pkg.ObjectIDs {
+			if friendly := c.scope.FindUnit(id); friendly != nil {
+				callsigns = c.addFriendlyToBroadcast(callsigns, friendly)
+			}
+		}
+		return callsigns, true
+	}
+	return nil, false
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/radar"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageCallsigns(t *testing.T) {
+	t.Parallel()
+	scope := newFakeRadar()
+	scope.add(1, "mobius 1", coalitions.Blue, orb.Point{41.0, 42.0}, 20000*unit.Foot)
+	scope.add(2, "mobius 2", coalitions.Blue, orb.Point{41.0, 42.0}, 20000*unit.Foot)
+	scope.add(3, "yellow 13", coalitions.Blue, orb.Point{41.0, 42.0}, 20000*unit.Foot)
+	scope.packages = []radar.Package{
+		{Name: "north", ObjectIDs: []uint64{1, 2}},
+		{Name: "south", ObjectIDs: []uint64{3, 4}},
+	}
+	c := &controller{
+		coalition: coalitions.Blue,
+		scope:     scope,
+		srsClient: &fakeSRSClient{onFrequency: []string{"mobius 1", "yellow 13"}},
+	}
+
+	callsigns, ok := c.PackageCallsigns("North Package")
+	assert.True(t, ok)
+	assert.ElementsMatch(t, []string{"mobius 1", "mobius 2"}, callsigns)
+
+	// Aircraft which have left the scope are skipped. Callsigns are normalized as they are spoken.
+	callsigns, ok = c.PackageCallsigns("south")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"yellow 1 3"}, callsigns)
+
+	_, ok = c.PackageCallsigns("east")
+	assert.False(t, ok)
+
+	// When SRS is required, only players on frequency are included.
+	c.threatMonitoringRequiresSRS = true
+	callsigns, ok = c.PackageCallsigns("north")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"mobius 1"}, callsigns)
+}
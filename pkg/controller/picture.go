@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"math"
 	"time"
 
 	"github.com/dharmab/skyeye/internal/conf"
@@ -14,29 +15,113 @@ func (c *controller) HandlePicture(request *brevity.PictureRequest) {
 	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
 	logger.Debug().Msg("handling request")
 
-	c.broadcastPicture(&logger, true)
+	full := request.Full && c.enableFullPicture
+	if request.Full && !c.enableFullPicture {
+		logger.Debug().Msg("FULL PICTURE is disabled, describing only the highest priority groups")
+	}
+	if request.BRAA && request.Callsign != "" {
+		c.respondPictureWithBRAA(&logger, request.Callsign, full)
+		return
+	}
+	c.broadcastPicture(&logger, true, full)
 }
 
-func (c *controller) broadcastPicture(logger *zerolog.Logger, forceBroadcast bool) {
+// pictureGroupLimit returns the number of groups to describe in detail in a PICTURE. A FULL PICTURE describes every
+// group.
+func (c *controller) pictureGroupLimit(full bool) int {
+	if full {
+		return math.MaxInt
+	}
+	return c.pictureMaxGroups
+}
+
+// respondPictureWithBRAA responds to the caller with a PICTURE anchored on their own aircraft. Unlike a BULLSEYE
+// PICTURE, this is addressed only to the caller and does not affect the automatic PICTURE schedule. If full is true,
+// every group is described.
+func (c *controller) respondPictureWithBRAA(logger *zerolog.Logger, callsign string, full bool) {
+	foundCallsign, trackfile := c.scope.ResolveCallsign(callsign, c.coalition)
+	if trackfile == nil {
+		logger.Info().Msg("no trackfile found for requestor")
+		c.out <- brevity.NegativeRadarContactResponse{Callsign: callsign}
+		return
+	}
+	origin := trackfile.LastKnown().Point
+	groups := c.scope.GetPictureWithBRAA(origin, conf.DefaultPictureRadius, c.coalition.Opposite(), brevity.FixedWing)
+	count := len(groups)
+	if limit := c.pictureGroupLimit(full); len(groups) > limit {
+		groups = groups[:limit]
+	}
+	for _, group := range groups {
+		group.SetDeclaration(brevity.Hostile)
+		c.fillInMergeDetails(group)
+	}
+	logger.Info().Str("callsign", foundCallsign).Int("groups", len(groups)).Int("count", count).Msg("responding with BRAA PICTURE")
+	c.out <- brevity.PictureResponse{Callsign: foundCallsign, Count: count, Groups: groups}
+	if len(groups) > 0 {
+		c.commentate(foundCallsign, groups[0], true)
+	}
+}
+
+// broadcastPicture broadcasts a BULLSEYE PICTURE to everyone and resets the automatic PICTURE schedule. If full is
+// true, every group is described.
+func (c *controller) broadcastPicture(logger *zerolog.Logger, forceBroadcast bool, full bool) {
 	if c.srsClient.ClientsOnFrequency() == 0 && !forceBroadcast {
 		logger.Debug().Msg("skipping PICTURE broadcast because no clients are on frequency")
 		return
 	}
-	count, groups := c.scope.GetPicture(conf.DefaultPictureRadius, c.coalition.Opposite(), brevity.FixedWing)
-	isPictureClean := count == 0
+	if c.isEveryoneMuted() && !forceBroadcast {
+		logger.Debug().Msg("skipping PICTURE broadcast because every player on frequency stopped broadcasts")
+		return
+	}
+	// Checking for a clean scope is much cheaper than grouping it, and the scope is often clean between engagements.
+	var groups []brevity.Group
+	if c.scope.IsPictureClean(conf.DefaultPictureRadius, c.coalition.Opposite(), brevity.FixedWing) {
+		logger.Debug().Msg("scope is clean")
+	} else {
+		groups = c.scope.GetPicture(conf.DefaultPictureRadius, c.coalition.Opposite(), brevity.FixedWing)
+	}
+	count := len(groups)
+	snapshot := snapshotPicture(groups)
+	var furthestBullseye *brevity.Bullseye
+	if limit := c.pictureGroupLimit(full); len(groups) > limit {
+		for _, group := range groups[limit:] {
+			bullseye := group.Bullseye()
+			if bullseye != nil && (furthestBullseye == nil || bullseye.Distance() > furthestBullseye.Distance()) {
+				furthestBullseye = bullseye
+			}
+		}
+		groups = groups[:limit]
+	}
 	for _, group := range groups {
 		group.SetDeclaration(brevity.Hostile)
 		c.fillInMergeDetails(group)
 	}
 
-	if c.wasLastPictureClean && isPictureClean && !forceBroadcast {
-		logger.Info().Msg("skipping PICTURE broadcast because situation has not changed since last broadcast")
+	if reason := c.pictures.skipReason(snapshot); reason != "" && !forceBroadcast {
+		logger.Info().Str("reason", reason).Msg("skipping PICTURE broadcast")
 	} else {
 		logger.Info().Int("groups", len(groups)).Int("count", count).Msg("broadcasting PICTURE")
-		c.out <- brevity.PictureResponse{Count: count, Groups: groups}
+		c.out <- brevity.PictureResponse{Count: count, Groups: groups, FurthestBullseye: furthestBullseye}
+		if len(groups) > 0 {
+			c.commentate("", groups[0], true)
+		}
+		c.pictures.record(snapshot)
 	}
 
-	c.pictureBroadcastDeadline = time.Now().Add(c.pictureBroadcastInterval)
-	c.wasLastPictureClean = isPictureClean
-	logger.Info().Time("deadline", c.pictureBroadcastDeadline).Msg("extended next PICTURE broadcast time")
+	c.pictures.extend(time.Now())
+	logger.Info().Time("deadline", c.pictures.deadline).Msg("extended next PICTURE broadcast time")
+}
+
+// broadcastPictureClean broadcasts a single clean PICTURE as soon as the scope goes clean after a PICTURE which
+// described groups, rather than waiting for the next scheduled PICTURE. Nothing is broadcast if the controller is
+// quiet while the scope is clean.
+func (c *controller) broadcastPictureClean(logger *zerolog.Logger) {
+	if !c.pictures.shouldAnnounceClean() {
+		return
+	}
+	if !c.scope.IsPictureClean(conf.DefaultPictureRadius, c.coalition.Opposite(), brevity.FixedWing) {
+		return
+	}
+	logger.Info().Msg("scope has gone clean since last PICTURE")
+	c.broadcastPicture(logger, false, false)
 }
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+)
+
+const (
+	// pictureChangeDistance is how far a group must move since the last PICTURE for the situation to have changed.
+	pictureChangeDistance = 3 * unit.NauticalMile
+	// pictureChangeAltitude is how far a group must climb or descend since the last PICTURE for the situation to have
+	// changed.
+	pictureChangeAltitude = 2000 * unit.Foot
+)
+
+// pictureGroup is the state of a group as described in a PICTURE.
+type pictureGroup struct {
+	// ids are the sorted object IDs of the group's contacts.
+	ids []uint64
+	// x and y locate the group relative to the bullseye, east and north respectively.
+	x, y     unit.Length
+	altitude unit.Length
+	track    brevity.Track
+}
+
+// pictureSnapshot is the situation described in a PICTURE, used to tell if anything has changed since.
+type pictureSnapshot struct {
+	groups []pictureGroup
+}
+
+// snapshotPicture records the situation described by the given groups.
+func snapshotPicture(groups []brevity.Group) pictureSnapshot {
+	snapshot := pictureSnapshot{groups: make([]pictureGroup, 0, len(groups))}
+	for _, group := range groups {
+		state := pictureGroup{
+			ids:      slices.Sorted(slices.Values(group.ObjectIDs())),
+			altitude: group.Altitude(),
+			track:    group.Track(),
+		}
+		if bullseye := group.Bullseye(); bullseye != nil {
+			radians := bullseye.Bearing().Value().Radians()
+			state.x = unit.Length(math.Sin(radians)) * bullseye.Distance()
+			state.y = unit.Length(math.Cos(radians)) * bullseye.Distance()
+		}
+		snapshot.groups = append(snapshot.groups, state)
+	}
+	return snapshot
+}
+
+// isClean returns true if the snapshot has no groups.
+func (s pictureSnapshot) isClean() bool {
+	return len(s.groups) == 0
+}
+
+// hasChanged returns true if a group has appeared, disappeared, split, joined, changed track, or moved significantly
+// between the other snapshot and this one.
+func (s pictureSnapshot) hasChanged(other pictureSnapshot) bool {
+	if len(s.groups) != len(other.groups) {
+		return true
+	}
+	for _, group := range s.groups {
+		i := slices.IndexFunc(other.groups, func(g pictureGroup) bool { return slices.Equal(g.ids, group.ids) })
+		if i < 0 {
+			return true
+		}
+		previous := other.groups[i]
+		moved := unit.Length(math.Hypot((group.x - previous.x).Meters(), (group.y - previous.y).Meters()))
+		if moved >= pictureChangeDistance {
+			return true
+		}
+		if math.Abs((group.altitude - previous.altitude).Meters()) >= pictureChangeAltitude.Meters() {
+			return true
+		}
+		if group.track != previous.track {
+			return true
+		}
+	}
+	return false
+}
+
+// pictureScheduler schedules automatic PICTURE broadcasts, and suppresses broadcasts which wouldn't tell anyone
+// anything new.
+type pictureScheduler struct {
+	// interval is the time between automatic PICTURE broadcasts.
+	interval time.Duration
+	// deadline is the time of the next automatic PICTURE broadcast.
+	deadline time.Time
+	// isQuietWhenClean stops automatic broadcasts while the scope is clean.
+	isQuietWhenClean bool
+	// last is the situation described in the most recently broadcast PICTURE, or nil if none has been broadcast.
+	last *pictureSnapshot
+}
+
+func newPictureScheduler(interval time.Duration, isQuietWhenClean bool, now time.Time) *pictureScheduler {
+	return &pictureScheduler{
+		interval:         interval,
+		deadline:         now.Add(interval),
+		isQuietWhenClean: isQuietWhenClean,
+	}
+}
+
+// isDue returns true if the next automatic PICTURE is due.
+func (s *pictureScheduler) isDue(now time.Time) bool {
+	return now.After(s.deadline)
+}
+
+// extend pushes the next automatic PICTURE back by a full interval.
+func (s *pictureScheduler) extend(now time.Time) {
+	s.deadline = now.Add(s.interval)
+}
+
+// skipReason returns why an automatic PICTURE describing the given snapshot should not be broadcast, or an empty
+// string if it should be.
+func (s *pictureScheduler) skipReason(snapshot pictureSnapshot) string {
+	if snapshot.isClean() && s.isQuietWhenClean {
+		return "scope is clean"
+	}
+	if s.last != nil && !snapshot.hasChanged(*s.last) {
+		return "situation has not changed since last broadcast"
+	}
+	return ""
+}
+
+// record remembers the situation described in a broadcast PICTURE.
+func (s *pictureScheduler) record(snapshot pictureSnapshot) {
+	s.last = &snapshot
+}
+
+// shouldAnnounceClean returns true if the last PICTURE described groups, so a clean PICTURE should be broadcast as
+// soon as the scope goes clean.
+func (s *pictureScheduler) shouldAnnounceClean() bool {
+	return s.last != nil && !s.last.isClean() && !s.isQuietWhenClean
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+// pictureStubGroup is a [brevity.Group] with only the methods used to snapshot a PICTURE.
+type pictureStubGroup struct {
+	brevity.Group
+	ids      []uint64
+	bearing  unit.Angle
+	distance unit.Length
+	altitude unit.Length
+	track    brevity.Track
+}
+
+func (g pictureStubGroup) ObjectIDs() []uint64   { return g.ids }
+func (g pictureStubGroup) Altitude() unit.Length { return g.altitude }
+func (g pictureStubGroup) Track() brevity.Track  { return g.track }
+func (g pictureStubGroup) Bullseye() *brevity.Bullseye {
+	return brevity.NewBullseye(bearings.NewMagneticBearing(g.bearing), g.distance)
+}
+
+func TestPictureSnapshotHasChanged(t *testing.T) {
+	t.Parallel()
+	base := pictureStubGroup{
+		ids:      []uint64{2, 1},
+		bearing:  90 * unit.Degree,
+		distance: 40 * unit.NauticalMile,
+		altitude: 25000 * unit.Foot,
+		track:    brevity.West,
+	}
+	other := pictureStubGroup{
+		ids:      []uint64{3},
+		bearing:  180 * unit.Degree,
+		distance: 60 * unit.NauticalMile,
+		altitude: 10000 * unit.Foot,
+		track:    brevity.North,
+	}
+	previous := snapshotPicture([]brevity.Group{base, other})
+
+	modify := func(f func(*pictureStubGroup)) []brevity.Group {
+		modified := base
+		f(&modified)
+		return []brevity.Group{modified, other}
+	}
+	testCases := []struct {
+		name     string
+		groups   []brevity.Group
+		expected bool
+	}{
+		{"unchanged", []brevity.Group{other, base}, false},
+		{"contacts reordered", modify(func(g *pictureStubGroup) { g.ids = []uint64{1, 2} }), false},
+		{"moved slightly", modify(func(g *pictureStubGroup) { g.distance = 38 * unit.NauticalMile }), false},
+		{"climbed slightly", modify(func(g *pictureStubGroup) { g.altitude = 26000 * unit.Foot }), false},
+		{"moved", modify(func(g *pictureStubGroup) { g.distance = 35 * unit.NauticalMile }), true},
+		{"moved around the bullseye", modify(func(g *pictureStubGroup) { g.bearing = 100 * unit.Degree }), true},
+		{"climbed", modify(func(g *pictureStubGroup) { g.altitude = 30000 * unit.Foot }), true},
+		{"turned", modify(func(g *pictureStubGroup) { g.track = brevity.Northwest }), true},
+		{"joined", modify(func(g *pictureStubGroup) { g.ids = []uint64{1, 2, 4} }), true},
+		{"appeared", []brevity.Group{base, other, pictureStubGroup{ids: []uint64{5}}}, true},
+		{"left", []brevity.Group{base}, true},
+		{"clean", nil, true},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, snapshotPicture(test.groups).hasChanged(previous))
+		})
+	}
+}
+
+func TestPictureScheduler(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	scheduler := newPictureScheduler(2*time.Minute, false, now)
+	assert.False(t, scheduler.isDue(now.Add(time.Minute)))
+	assert.True(t, scheduler.isDue(now.Add(3*time.Minute)))
+	scheduler.extend(now.Add(3 * time.Minute))
+	assert.False(t, scheduler.isDue(now.Add(4*time.Minute)))
+
+	clean := snapshotPicture(nil)
+	groups := snapshotPicture([]brevity.Group{pictureStubGroup{ids: []uint64{1}, distance: 20 * unit.NauticalMile}})
+	assert.Empty(t, scheduler.skipReason(clean), "first PICTURE")
+	assert.False(t, scheduler.shouldAnnounceClean())
+
+	scheduler.record(clean)
+	assert.NotEmpty(t, scheduler.skipReason(clean), "still clean")
+	assert.Empty(t, scheduler.skipReason(groups))
+
+	scheduler.record(groups)
+	assert.NotEmpty(t, scheduler.skipReason(groups), "unchanged")
+	assert.Empty(t, scheduler.skipReason(clean), "gone clean")
+	assert.True(t, scheduler.shouldAnnounceClean())
+}
+
+func TestPictureSchedulerQuietWhenClean(t *testing.T) {
+	t.Parallel()
+	scheduler := newPictureScheduler(2*time.Minute, true, time.Now())
+	clean := snapshotPicture(nil)
+	groups := snapshotPicture([]brevity.Group{pictureStubGroup{ids: []uint64{1}, distance: 20 * unit.NauticalMile}})
+
+	assert.NotEmpty(t, scheduler.skipReason(clean), "first PICTURE")
+	assert.Empty(t, scheduler.skipReason(groups))
+	scheduler.record(groups)
+	assert.NotEmpty(t, scheduler.skipReason(clean), "gone clean")
+	assert.False(t, scheduler.shouldAnnounceClean())
+}
@@ -10,7 +10,7 @@ func (c *controller) HandleRadioCheck(request *brevity.RadioCheckRequest) {
 	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
 	logger.Debug().Msg("handling request")
 	var response brevity.RadioCheckResponse
-	if foundCallsign, trackfile := c.scope.FindCallsign(request.Callsign, c.coalition); trackfile == nil {
+	if foundCallsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition); trackfile == nil {
 		logger.Debug().Msg("no trackfile found for requestor")
 		response.Callsign = request.Callsign
 		return
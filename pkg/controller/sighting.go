@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog/log"
+)
+
+// tallyTracker tracks which hostile contacts each friendly aircraft has reported TALLY on.
+type tallyTracker struct {
+	// tallies maps friendly unit IDs to the unit IDs of the hostile contacts the friendly has sighted.
+	tallies map[uint64][]uint64
+	// lock used to synchronize access to the tallies map.
+	lock sync.RWMutex
+}
+
+func newTallyTracker() *tallyTracker {
+	return &tallyTracker{
+		tallies: make(map[uint64][]uint64),
+	}
+}
+
+// set records that the given friendly has sighted the given hostile contacts, replacing any previous tally.
+func (t *tallyTracker) set(friendID uint64, hostileIDs []uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.tallies[friendID] = slices.Clone(hostileIDs)
+}
+
+// clear forgets the given friendly's tally.
+func (t *tallyTracker) clear(friendID uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.tallies, friendID)
+}
+
+// isTally returns true if the given friendly has sighted any of the given hostile contacts.
+func (t *tallyTracker) isTally(friendID uint64, hostileIDs []uint64) bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return slices.ContainsFunc(t.tallies[friendID], func(id uint64) bool {
+		return slices.Contains(hostileIDs, id)
+	})
+}
+
+// remove forgets any tally held by or on the given contact.
+func (t *tallyTracker) remove(id uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.tallies, id)
+	for friendID, hostileIDs := range t.tallies {
+		t.tallies[friendID] = slices.DeleteFunc(hostileIDs, func(hostileID uint64) bool {
+			return hostileID == id
+		})
+	}
+}
+
+// HandleSighting implements Controller.HandleSighting.
+func (c *controller) HandleSighting(request *brevity.SightingRequest) {
+	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Str("sighting", string(request.Sighting)).Logger()
+	logger.Debug().Msg("handling request")
+
+	foundCallsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition)
+	if trackfile == nil {
+		logger.Info().Msg("no trackfile found for requestor")
+		c.out <- brevity.NegativeRadarContactResponse{Callsign: request.Callsign}
+		return
+	}
+	logger = logger.With().Str("callsign", foundCallsign).Logger()
+
+	switch request.Sighting {
+	case brevity.Tally, brevity.Press:
+		targetIDs := c.sightedIDs(foundCallsign, trackfile.LastKnown().Point)
+		if len(targetIDs) == 0 {
+			logger.Info().Msg("no hostile group found to record tally on")
+		} else {
+			logger.Info().Uints64("targetIDs", targetIDs).Msg("suppressing threat calls for sighted group")
+			c.tallies.set(trackfile.Contact.ID, targetIDs)
+		}
+	case brevity.NoJoy, brevity.Blind:
+		if request.Sighting == brevity.NoJoy {
+			logger.Info().Msg("resuming threat calls for target group")
+			c.tallies.clear(trackfile.Contact.ID)
+		}
+		logger.Info().Msg("updating target group")
+		c.updateTarget(foundCallsign, trackfile)
+		return
+	}
+
+	c.out <- brevity.SightingResponse{Callsign: foundCallsign, Sighting: request.Sighting}
+}
+
+// updateTarget sends a fresh BRAA to the fighter's target group, or a BOGEY DOPE if it has no target group on the
+// scope.
+func (c *controller) updateTarget(callsign string, trackfile *trackfiles.Trackfile) {
+	e, ok := c.engagements.get(callsign)
+	if !ok {
+		c.HandleBogeyDope(&brevity.BogeyDopeRequest{Callsign: callsign, Filter: brevity.Aircraft})
+		return
+	}
+	group := c.findGroupWithBRAA(trackfile.LastKnown().Point, e.targetIDs, c.coalition.Opposite())
+	if group == nil {
+		c.HandleBogeyDope(&brevity.BogeyDopeRequest{Callsign: callsign, Filter: brevity.Aircraft})
+		return
+	}
+	group.SetDeclaration(brevity.Hostile)
+	c.engagements.record(callsign, group, c.threatIDs(trackfile.Contact.ID))
+	c.fillInMergeDetails(group)
+	c.out <- brevity.BogeyDopeResponse{Callsign: callsign, Group: group}
+}
+
+// sightedIDs returns the object IDs of the group the fighter most likely has a tally on: its target group if it has
+// one, or otherwise the nearest hostile group.
+func (c *controller) sightedIDs(callsign string, origin orb.Point) []uint64 {
+	if e, ok := c.engagements.get(callsign); ok && len(e.targetIDs) > 0 {
+		return e.targetIDs
+	}
+	nearestGroup := c.scope.FindNearestGroupWithBRAA(
+		origin,
+		lowestAltitude,
+		highestAltitude,
+		300*unit.NauticalMile,
+		c.coalition.Opposite(),
+		brevity.Aircraft,
+	)
+	if nearestGroup == nil {
+		return nil
+	}
+	return nearestGroup.ObjectIDs()
+}
@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTallyTracker(t *testing.T) {
+	t.Parallel()
+	tracker := newTallyTracker()
+	assert.False(t, tracker.isTally(1, []uint64{10, 11}))
+
+	tracker.set(1, []uint64{10, 11})
+	assert.True(t, tracker.isTally(1, []uint64{11, 12}))
+	assert.False(t, tracker.isTally(1, []uint64{12}))
+	assert.False(t, tracker.isTally(2, []uint64{10}))
+
+	// A hostile contact leaving the scope is forgotten without affecting the rest of the group.
+	tracker.remove(10)
+	assert.False(t, tracker.isTally(1, []uint64{10}))
+	assert.True(t, tracker.isTally(1, []uint64{11}))
+
+	tracker.clear(1)
+	assert.False(t, tracker.isTally(1, []uint64{11}))
+
+	// A friendly leaving the scope forgets its tally.
+	tracker.set(2, []uint64{20})
+	tracker.remove(2)
+	assert.False(t, tracker.isTally(2, []uint64{20}))
+}
+
+func TestHandleSighting(t *testing.T) {
+	t.Parallel()
+	fighter := orb.Point{41.0, 42.0}
+	scope := newFakeRadar()
+	scope.add(1, "mobius 1", coalitions.Blue, fighter, 20000*unit.Foot)
+	scope.add(2, "nearest bandit", coalitions.Red, spatial.PointAtBearingAndDistance(fighter, bearings.NewTrueBearing(90*unit.Degree), 20*unit.NauticalMile), 20000*unit.Foot)
+	scope.add(3, "target bandit", coalitions.Red, spatial.PointAtBearingAndDistance(fighter, bearings.NewTrueBearing(270*unit.Degree), 40*unit.NauticalMile), 20000*unit.Foot)
+	out := make(chan any, 10)
+	c := &controller{
+		coalition:   coalitions.Blue,
+		scope:       scope,
+		engagements: newEngagementTracker(),
+		threats:     newThreatTracker(),
+		tallies:     newTallyTracker(),
+		merges:      newMergeTracker(time.Minute),
+		out:         out,
+	}
+	receive := func() any {
+		t.Helper()
+		require.Len(t, out, 1)
+		return <-out
+	}
+
+	c.HandleSighting(&brevity.SightingRequest{Callsign: "yellow 13", Sighting: brevity.Tally})
+	assert.Equal(t, brevity.NegativeRadarContactResponse{Callsign: "yellow 13"}, receive())
+
+	// Without a target group, a TALLY is recorded on the nearest hostile group.
+	c.HandleSighting(&brevity.SightingRequest{Callsign: "mobius 1", Sighting: brevity.Tally})
+	assert.Equal(t, brevity.SightingResponse{Callsign: "mobius 1", Sighting: brevity.Tally}, receive())
+	assert.True(t, c.tallies.isTally(1, []uint64{2}))
+
+	// With a target group, a PRESS is recorded on the target group.
+	c.engagements.engagements["mobius 1"] = engagement{targetIDs: []uint64{3}}
+	c.HandleSighting(&brevity.SightingRequest{Callsign: "mobius 1", Sighting: brevity.Press})
+	assert.Equal(t, brevity.SightingResponse{Callsign: "mobius 1", Sighting: brevity.Press}, receive())
+	assert.True(t, c.tallies.isTally(1, []uint64{3}))
+	assert.False(t, c.tallies.isTally(1, []uint64{2}))
+
+	// BLIND updates the BRAA to the target group without affecting the tally.
+	c.HandleSighting(&brevity.SightingRequest{Callsign: "mobius 1", Sighting: brevity.Blind})
+	response, ok := receive().(brevity.BogeyDopeResponse)
+	require.True(t, ok)
+	assert.Equal(t, []uint64{3}, response.Group.ObjectIDs())
+	assert.Equal(t, brevity.Hostile, response.Group.Declaration())
+	assert.InDelta(t, 40, response.Group.BRAA().Range().NauticalMiles(), 0.5)
+	assert.True(t, c.tallies.isTally(1, []uint64{3}))
+
+	// NO JOY updates the BRAA to the target group and resumes threat calls.
+	c.HandleSighting(&brevity.SightingRequest{Callsign: "mobius 1", Sighting: brevity.NoJoy})
+	response, ok = receive().(brevity.BogeyDopeResponse)
+	require.True(t, ok)
+	assert.Equal(t, []uint64{3}, response.Group.ObjectIDs())
+	assert.False(t, c.tallies.isTally(1, []uint64{3}))
+
+	c.HandleSighting(&brevity.SightingRequest{Callsign: "mobius 1", Sighting: brevity.Visual})
+	assert.Equal(t, brevity.SightingResponse{Callsign: "mobius 1", Sighting: brevity.Visual}, receive())
+}
+
+func TestBroadcastCleanForgetsTally(t *testing.T) {
+	t.Parallel()
+	scope := newFakeRadar()
+	scope.add(1, "mobius 1", coalitions.Blue, orb.Point{41.0, 42.0}, 20000*unit.Foot)
+	out := make(chan any, 10)
+	c := &controller{
+		coalition: coalitions.Blue,
+		scope:     scope,
+		srsClient: &fakeSRSClient{onFrequency: []string{"mobius 1"}},
+		tallies:   newTallyTracker(),
+		merges:    newMergeTracker(0),
+		out:       out,
+	}
+	c.tallies.set(1, []uint64{2})
+	c.merges.merge(2, 1)
+	c.broadcastClean()
+	assert.Empty(t, out, "still merged")
+	assert.True(t, c.tallies.isTally(1, []uint64{2}))
+
+	c.merges.separate(2, 1)
+	c.broadcastClean()
+	require.Len(t, out, 1)
+	assert.Equal(t, brevity.CleanCall{Callsigns: []string{"mobius 1"}}, <-out)
+	assert.False(t, c.tallies.isTally(1, []uint64{2}))
+}
@@ -22,7 +22,7 @@ func (c *controller) HandleSnaplock(request *brevity.SnaplockRequest) {
 		logger.Error().Stringer("bearing", request.BRA.Bearing()).Msg("bearing provided to HandleSnaplock should be magnetic")
 	}
 
-	foundCallsign, trackfile := c.scope.FindCallsign(request.Callsign, c.coalition)
+	foundCallsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition)
 	if trackfile == nil {
 		logger.Info().Msg("no trackfile found for requestor")
 		c.out <- brevity.NegativeRadarContactResponse{Callsign: request.Callsign}
@@ -85,6 +85,12 @@ func (c *controller) HandleSnaplock(request *brevity.SnaplockRequest) {
 		response.Group.SetDeclaration(response.Declaration)
 		c.fillInMergeDetails(response.Group)
 	}
+	if response.Declaration == brevity.Hostile {
+		c.engagements.record(foundCallsign, response.Group, c.threatIDs(trackfile.Contact.ID))
+	}
 
 	c.out <- response
+	if response.Declaration == brevity.Hostile {
+		c.commentate(foundCallsign, response.Group, false)
+	}
 }
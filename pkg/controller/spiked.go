@@ -15,7 +15,7 @@ func (c *controller) HandleSpiked(request *brevity.SpikedRequest) {
 		logger.Error().Stringer("bearing", request.Bearing).Msg("bearing provided to HandleSpiked should be magnetic")
 	}
 
-	foundCallsign, trackfile := c.scope.FindCallsign(request.Callsign, c.coalition)
+	foundCallsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition)
 	if trackfile == nil {
 		logger.Info().Msg("no trackfile found for requestor")
 		c.out <- brevity.NegativeRadarContactResponse{Callsign: request.Callsign}
@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/dharmab/skyeye/internal/conf"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog/log"
+)
+
+// engagement is a snapshot of a fighter's target group, taken when the controller last described the group to the
+// fighter.
+type engagement struct {
+	// targetIDs are the object IDs of the contacts in the target group.
+	targetIDs []uint64
+	// _range to the target group.
+	_range unit.Length
+	// aspect of the target group.
+	aspect brevity.Aspect
+	// altitude of the target group.
+	altitude unit.Length
+	// threatIDs are the object IDs of all hostile contacts within THREAT range of the fighter.
+	threatIDs []uint64
+}
+
+// engagementTracker tracks the most recent engagement for each fighter callsign.
+type engagementTracker struct {
+	engagements map[string]engagement
+	lock        sync.RWMutex
+}
+
+func newEngagementTracker() *engagementTracker {
+	return &engagementTracker{
+		engagements: make(map[string]engagement),
+	}
+}
+
+// record the given group as the fighter's target. If the group is nil, the fighter's engagement is forgotten.
+func (t *engagementTracker) record(callsign string, group brevity.Group, threatIDs []uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if group == nil || group.BRAA() == nil {
+		delete(t.engagements, callsign)
+		return
+	}
+	t.engagements[callsign] = engagement{
+		targetIDs: group.ObjectIDs(),
+		_range:    group.BRAA().Range(),
+		aspect:    group.BRAA().Aspect(),
+		altitude:  group.BRAA().Altitude(),
+		threatIDs: threatIDs,
+	}
+}
+
+// get returns the fighter's most recent engagement, if any.
+func (t *engagementTracker) get(callsign string) (engagement, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	e, ok := t.engagements[callsign]
+	return e, ok
+}
+
+// all returns a copy of every fighter's most recent engagement, keyed by callsign.
+func (t *engagementTracker) all() map[string]engagement {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return maps.Clone(t.engagements)
+}
+
+// HandleStatus implements Controller.HandleStatus.
+func (c *controller) HandleStatus(request *brevity.StatusRequest) {
+	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
+	logger.Debug().Msg("handling request")
+
+	foundCallsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition)
+	if trackfile == nil {
+		logger.Info().Msg("no trackfile found for requestor")
+		c.out <- brevity.NegativeRadarContactResponse{Callsign: request.Callsign}
+		return
+	}
+	logger = logger.With().Str("callsign", foundCallsign).Logger()
+
+	previous, ok := c.engagements.get(foundCallsign)
+	if !ok {
+		logger.Info().Msg("no previous call to compare against, responding with BOGEY DOPE")
+		c.HandleBogeyDope(&brevity.BogeyDopeRequest{Callsign: request.Callsign, Filter: brevity.Aircraft})
+		return
+	}
+
+	origin := trackfile.LastKnown().Point
+	response := brevity.StatusResponse{
+		Callsign:       foundCallsign,
+		PreviousAspect: previous.aspect,
+		NewThreats:     make([]brevity.Group, 0),
+	}
+
+	group := c.findGroupWithBRAA(origin, previous.targetIDs, c.coalition.Opposite())
+	if group != nil {
+		group.SetDeclaration(brevity.Hostile)
+		c.fillInMergeDetails(group)
+		response.Group = group
+		response.RangeChange = group.BRAA().Range() - previous._range
+		response.AltitudeChange = group.BRAA().Altitude() - previous.altitude
+	} else {
+		logger.Info().Uints64("targetIDs", previous.targetIDs).Msg("target group is no longer on the scope")
+	}
+
+	threatIDs := c.threatIDs(trackfile.Contact.ID)
+	for _, id := range threatIDs {
+		isKnown := slices.Contains(previous.threatIDs, id) || slices.Contains(previous.targetIDs, id)
+		if group != nil && slices.Contains(group.ObjectIDs(), id) {
+			isKnown = true
+		}
+		for _, threat := range response.NewThreats {
+			if slices.Contains(threat.ObjectIDs(), id) {
+				isKnown = true
+			}
+		}
+		if isKnown {
+			continue
+		}
+		if threat := c.findGroupWithBRAA(origin, []uint64{id}, c.coalition.Opposite()); threat != nil {
+			threat.SetDeclaration(brevity.Hostile)
+			threat.SetThreat(true)
+			c.fillInMergeDetails(threat)
+			response.NewThreats = append(response.NewThreats, threat)
+		}
+	}
+
+	c.engagements.record(foundCallsign, group, threatIDs)
+	logger.Info().
+		Float64("rangeChange", response.RangeChange.NauticalMiles()).
+		Float64("altitudeChange", response.AltitudeChange.Feet()).
+		Int("newThreats", len(response.NewThreats)).
+		Msg("responding with status")
+	c.out <- response
+}
+
+// findGroupWithBRAA returns the group on the given coalition containing any of the given object IDs, with BRAA set
+// relative to the given origin. Returns nil if none of the contacts are on the scope.
+func (c *controller) findGroupWithBRAA(origin orb.Point, ids []uint64, coalition coalitions.Coalition) brevity.Group {
+	for _, id := range ids {
+		trackfile := c.scope.FindUnit(id)
+		if trackfile == nil {
+			continue
+		}
+		groups := c.scope.FindNearbyGroupsWithBRAA(
+			origin,
+			trackfile.LastKnown().Point,
+			lowestAltitude,
+			highestAltitude,
+			conf.DefaultMarginRadius,
+			coalition,
+			brevity.Aircraft,
+			[]uint64{},
+		)
+		for _, group := range groups {
+			if slices.Contains(group.ObjectIDs(), id) {
+				return group
+			}
+		}
+	}
+	return nil
+}
+
+// threatIDs returns the object IDs of all hostile contacts which met THREAT criteria against the given friendly at the
+// most recent monitoring tick.
+func (c *controller) threatIDs(friendID uint64) []uint64 {
+	return c.threats.get(friendID)
+}
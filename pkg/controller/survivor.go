@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/parser"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog/log"
+)
+
+// ejectionCorrelationRange is how close a friendly aircraft must be to an ejected pilot when they are first seen to be
+// identified as the aircraft the pilot ejected from.
+const ejectionCorrelationRange = 1 * unit.NauticalMile
+
+// survivorOverheadRange is the range from a survivor within which an aircraft is considered overhead.
+const survivorOverheadRange = 1 * unit.NauticalMile
+
+// survivor is a downed friendly pilot.
+type survivor struct {
+	sim.Ejection
+	// callsign of the aircraft the pilot ejected from. Empty if unknown.
+	callsign string
+	// isAlerted is true once the ejection has been broadcast.
+	isAlerted bool
+}
+
+// survivorTracker tracks downed friendly pilots for the rest of the mission.
+type survivorTracker struct {
+	survivors map[uint64]*survivor
+	lock      sync.Mutex
+}
+
+func newSurvivorTracker() *survivorTracker {
+	return &survivorTracker{survivors: make(map[uint64]*survivor)}
+}
+
+// update replaces the tracked survivors with the given ejections. New ejections are identified with the identify
+// function. Survivors which are no longer present, such as after the mission restarts, are forgotten.
+func (t *survivorTracker) update(ejections []sim.Ejection, identify func(sim.Ejection) string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	survivors := make(map[uint64]*survivor, len(ejections))
+	for _, ejection := range ejections {
+		s, ok := t.survivors[ejection.ID]
+		if !ok {
+			s = &survivor{callsign: identify(ejection)}
+		}
+		s.Ejection = ejection
+		survivors[ejection.ID] = s
+	}
+	t.survivors = survivors
+}
+
+// unalerted returns the survivors which have not been broadcast yet.
+func (t *survivorTracker) unalerted() []survivor {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	var result []survivor
+	for _, s := range t.survivors {
+		if !s.isAlerted {
+			result = append(result, *s)
+		}
+	}
+	slices.SortFunc(result, func(a, b survivor) int { return a.Time.Compare(b.Time) })
+	return result
+}
+
+// markAlerted records that the survivor with the given ID has been broadcast.
+func (t *survivorTracker) markAlerted(id uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if s, ok := t.survivors[id]; ok {
+		s.isAlerted = true
+	}
+}
+
+// nearest returns the survivor nearest to the given point. The second return value is false if there are no survivors.
+func (t *survivorTracker) nearest(point orb.Point) (survivor, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	var nearest *survivor
+	for _, s := range t.survivors {
+		if nearest == nil || spatial.Distance(point, s.Point) < spatial.Distance(point, nearest.Point) {
+			nearest = s
+		}
+	}
+	if nearest == nil {
+		return survivor{}, false
+	}
+	return *nearest, true
+}
+
+// TrackEjections implements [Controller.TrackEjections].
+func (c *controller) TrackEjections(ejections []sim.Ejection) {
+	friendly := slices.DeleteFunc(slices.Clone(ejections), func(ejection sim.Ejection) bool {
+		return ejection.Coalition != c.coalition
+	})
+	c.survivors.update(friendly, c.identifyEjection)
+}
+
+// identifyEjection returns the callsign of the friendly aircraft nearest to an ejection, or an empty string if there
+// is no friendly aircraft close enough.
+func (c *controller) identifyEjection(ejection sim.Ejection) string {
+	var callsign string
+	nearest := ejectionCorrelationRange
+	for _, trackfile := range c.scope.Trackfiles() {
+		if trackfile.Contact.Coalition != c.coalition {
+			continue
+		}
+		distance := spatial.Distance(ejection.Point, trackfile.LastKnown().Point)
+		if distance > nearest {
+			continue
+		}
+		if parsed, ok := parser.ParsePilotCallsign(trackfile.Contact.Name); ok {
+			callsign = parsed
+			nearest = distance
+		}
+	}
+	return callsign
+}
+
+// broadcastEjections broadcasts an alert for each new downed friendly pilot. Alerts which are skipped are retried on
+// the next call.
+func (c *controller) broadcastEjections() {
+	for _, s := range c.survivors.unalerted() {
+		logger := log.With().Uint64("id", s.ID).Str("callsign", s.callsign).Logger()
+		bullseye := c.scope.Bullseye(c.coalition)
+		if spatial.IsZero(bullseye) {
+			logger.Warn().Msg("skipping ejection alert because the bullseye is unknown")
+			continue
+		}
+		if c.srsClient.HumansOnFrequency() == 0 {
+			logger.Debug().Msg("skipping ejection alert because no clients are on frequency")
+			continue
+		}
+		logger.Info().Msg("broadcasting ejection alert")
+		c.out <- brevity.SurvivorCall{
+			Callsign: s.callsign,
+			Bullseye: *c.bullseyeOf(bullseye, s.Point),
+		}
+		c.survivors.markAlerted(s.ID)
+	}
+}
+
+// bullseyeOf returns the bullseye of a point.
+func (c *controller) bullseyeOf(bullseye, point orb.Point) *brevity.Bullseye {
+	bearing := spatial.TrueBearing(bullseye, point).Magnetic(c.scope.Declination(bullseye))
+	return brevity.NewBullseye(bearing, spatial.Distance(bullseye, point))
+}
+
+// HandleSurvivor implements [Controller.HandleSurvivor].
+func (c *controller) HandleSurvivor(request *brevity.SurvivorRequest) {
+	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
+	logger.Debug().Msg("handling request")
+
+	foundCallsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition)
+	if trackfile == nil {
+		logger.Info().Msg("no trackfile found for requestor")
+		c.out <- brevity.NegativeRadarContactResponse{Callsign: request.Callsign}
+		return
+	}
+	logger = logger.With().Str("callsign", foundCallsign).Logger()
+
+	origin := trackfile.LastKnown().Point
+	s, ok := c.survivors.nearest(origin)
+	if !ok {
+		logger.Info().Msg("no survivors found")
+		c.out <- brevity.SurvivorResponse{Callsign: foundCallsign}
+		return
+	}
+
+	logger.Info().Uint64("id", s.ID).Str("survivor", s.callsign).Msg("found nearest survivor")
+	description := &brevity.Survivor{Callsign: s.callsign}
+	distance := spatial.Distance(origin, s.Point)
+	if distance <= survivorOverheadRange {
+		description.IsOverhead = true
+	} else {
+		bearing := spatial.TrueBearing(origin, s.Point).Magnetic(c.scope.Declination(origin))
+		description.BRA = brevity.NewBRA(bearing, distance)
+	}
+	if bullseye := c.scope.Bullseye(c.coalition); !spatial.IsZero(bullseye) {
+		description.Bullseye = c.bullseyeOf(bullseye, s.Point)
+	}
+	if grid, ok := spatial.MGRS(s.Point, groundGridPrecision); ok {
+		description.Grid = grid
+	}
+	c.out <- brevity.SurvivorResponse{Callsign: foundCallsign, Survivor: description}
+}
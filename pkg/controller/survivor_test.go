@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSurvivorTracker(t *testing.T) {
+	t.Parallel()
+	tracker := newSurvivorTracker()
+	identify := func(ejection sim.Ejection) string {
+		if ejection.ID == 1 {
+			return "mobius 1"
+		}
+		return ""
+	}
+	now := time.Now()
+	first := sim.Ejection{ID: 1, Coalition: coalitions.Blue, Point: orb.Point{41.0, 42.0}, Time: now}
+	second := sim.Ejection{ID: 2, Coalition: coalitions.Blue, Point: orb.Point{43.0, 42.0}, Time: now.Add(time.Minute)}
+
+	_, ok := tracker.nearest(orb.Point{42.0, 42.0})
+	assert.False(t, ok)
+
+	tracker.update([]sim.Ejection{second, first}, identify)
+	alerts := tracker.unalerted()
+	require.Len(t, alerts, 2)
+	assert.Equal(t, "mobius 1", alerts[0].callsign)
+	assert.Empty(t, alerts[1].callsign)
+	assert.Len(t, tracker.unalerted(), 2, "survivors are alerted until marked")
+	tracker.markAlerted(1)
+	tracker.markAlerted(2)
+	assert.Empty(t, tracker.unalerted(), "survivors are only alerted once")
+
+	// The survivor drifts under their parachute. Their position is updated but they are not alerted again.
+	first.Point = orb.Point{41.5, 42.0}
+	tracker.update([]sim.Ejection{first, second}, identify)
+	assert.Empty(t, tracker.unalerted())
+	nearest, ok := tracker.nearest(orb.Point{41.0, 42.0})
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), nearest.ID)
+	assert.Equal(t, "mobius 1", nearest.callsign)
+	assert.Equal(t, orb.Point{41.5, 42.0}, nearest.Point)
+
+	// The mission restarted.
+	tracker.update(nil, identify)
+	_, ok = tracker.nearest(orb.Point{41.0, 42.0})
+	assert.False(t, ok)
+}
+
+func TestBroadcastEjections(t *testing.T) {
+	t.Parallel()
+	scope := newFakeRadar()
+	scope.bullseye = orb.Point{41.0, 42.0}
+	srsClient := &fakeSRSClient{}
+	out := make(chan any, 10)
+	c := &controller{
+		coalition: coalitions.Blue,
+		scope:     scope,
+		srsClient: srsClient,
+		survivors: newSurvivorTracker(),
+		out:       out,
+	}
+	c.TrackEjections([]sim.Ejection{
+		{ID: 1, Coalition: coalitions.Blue, Point: orb.Point{41.5, 42.0}, Time: time.Now()},
+		{ID: 2, Coalition: coalitions.Red, Point: orb.Point{41.5, 42.0}, Time: time.Now()},
+	})
+
+	// Nobody is on frequency, so the alert is held until someone is.
+	c.broadcastEjections()
+	assert.Empty(t, out)
+
+	srsClient.humans = 1
+	c.broadcastEjections()
+	require.Len(t, out, 1)
+	call, ok := (<-out).(brevity.SurvivorCall)
+	require.True(t, ok)
+	assert.NotNil(t, call.Bullseye)
+
+	c.broadcastEjections()
+	assert.Empty(t, out, "survivors are only alerted once")
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/encyclopedia"
+	"github.com/dharmab/skyeye/pkg/parser"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/rs/zerolog/log"
+)
+
+// HandleTanker implements [Controller.HandleTanker].
+func (c *controller) HandleTanker(request *brevity.TankerRequest) {
+	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Logger()
+	logger.Debug().Msg("handling request")
+
+	foundCallsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition)
+	if trackfile == nil {
+		logger.Info().Msg("no trackfile found for requestor")
+		c.out <- brevity.NegativeRadarContactResponse{Callsign: request.Callsign}
+		return
+	}
+	logger = logger.With().Str("callsign", foundCallsign).Logger()
+
+	tanker := c.nearestTanker(trackfile)
+	if tanker == nil {
+		logger.Info().Msg("no tankers found")
+		c.out <- brevity.TankerResponse{Callsign: foundCallsign}
+		return
+	}
+
+	logger.Info().Uint64("id", tanker.Contact.ID).Str("tanker", tanker.Contact.Name).Msg("found nearest tanker")
+	origin := trackfile.LastKnown().Point
+	declination := c.scope.Declination(origin)
+	bearing := spatial.TrueBearing(origin, tanker.LastKnown().Point).Magnetic(declination)
+	description := &brevity.Tanker{
+		BRAA: brevity.NewBRAA(
+			bearing,
+			spatial.Distance(origin, tanker.LastKnown().Point),
+			[]unit.Length{tanker.LastKnown().Altitude},
+			brevity.AspectFromAngle(bearing, tanker.Course()),
+		),
+	}
+	if callsign, ok := parser.ParsePilotCallsign(tanker.Contact.Name); ok {
+		description.Callsign = callsign
+	}
+	if data, ok := encyclopedia.GetAircraftData(tanker.Contact.ACMIName); ok {
+		description.Platform = data.PlatformDesignation
+	}
+	if configured, ok := c.tankers.Lookup(tanker.Contact.Name); ok {
+		description.TACAN = configured.TACAN
+		description.Frequency = configured.Frequency
+	}
+	c.out <- brevity.TankerResponse{Callsign: foundCallsign, Tanker: description}
+}
+
+// nearestTanker returns the friendly tanker nearest to the given trackfile, or nil if there are no friendly tankers.
+func (c *controller) nearestTanker(trackfile *trackfiles.Trackfile) *trackfiles.Trackfile {
+	origin := trackfile.LastKnown().Point
+	var nearest *trackfiles.Trackfile
+	for _, candidate := range c.scope.Trackfiles() {
+		if candidate.Contact.Coalition != c.coalition || candidate.Contact.ID == trackfile.Contact.ID {
+			continue
+		}
+		if !c.tankers.IsTanker(candidate) {
+			continue
+		}
+		distance := spatial.Distance(origin, candidate.LastKnown().Point)
+		if nearest == nil || distance < spatial.Distance(origin, nearest.LastKnown().Point) {
+			nearest = candidate
+		}
+	}
+	return nearest
+}
@@ -1,74 +1,135 @@
 package controller
 
 import (
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/dharmab/skyeye/internal/conf"
 	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/radar"
 	"github.com/rs/zerolog/log"
 )
 
-type cooldownTracker struct {
-	// cooldown is the interval between threat calls for the same threat.
-	cooldown time.Duration
-	// cooldowns maps unit IDs to the time at which the the threat cooldown expires. The threat cooldown suppresses
-	// threat calls for the threat with the given unit ID.
-	cooldowns map[uint64]time.Time
-	// lock used to synchronize access to the cooldowns map.
-	lock sync.RWMutex
+// threatTracker records which hostile contacts met THREAT criteria against each friendly aircraft at the most recent
+// monitoring tick, so that requests can be answered without recomputing threats.
+type threatTracker struct {
+	// threatIDs maps each friendly aircraft's object ID to the object IDs of the hostile contacts threatening it.
+	threatIDs map[uint64][]uint64
+	lock      sync.RWMutex
 }
 
-func newCooldownTracker(cooldown time.Duration) *cooldownTracker {
-	return &cooldownTracker{
-		cooldown:  cooldown,
-		cooldowns: make(map[uint64]time.Time),
-	}
+func newThreatTracker() *threatTracker {
+	return &threatTracker{threatIDs: make(map[uint64][]uint64)}
 }
 
-func (t *cooldownTracker) extendCooldown(id uint64) {
+// update replaces the recorded threats.
+func (t *threatTracker) update(threats map[brevity.Group][]uint64) {
+	threatIDs := make(map[uint64][]uint64)
+	for group, friendIDs := range threats {
+		for _, friendID := range friendIDs {
+			threatIDs[friendID] = append(threatIDs[friendID], group.ObjectIDs()...)
+		}
+	}
 	t.lock.Lock()
 	defer t.lock.Unlock()
-	t.cooldowns[id] = time.Now().Add(t.cooldown)
+	t.threatIDs = threatIDs
 }
 
-func (t *cooldownTracker) isOnCooldown(id uint64) bool {
+// get returns the object IDs of the hostile contacts threatening the given friendly aircraft.
+func (t *threatTracker) get(friendID uint64) []uint64 {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
+	return append(make([]uint64, 0), t.threatIDs[friendID]...)
+}
 
-	cooldown, ok := t.cooldowns[id]
-	if !ok {
-		return false
+// broadcastThreats broadcasts THREAT calls for the threats found at this monitoring tick.
+func (c *controller) broadcastThreats(threats map[brevity.Group][]uint64) {
+	if !c.enableThreatMonitoring {
+		return
+	}
+	c.addCommitThreats(threats)
+	var packages []radar.Package
+	if c.packageThreats && len(threats) > 0 {
+		packages = c.scope.Packages(c.coalition)
+	}
+	isDemoted := func(grp brevity.Group) bool {
+		return c.coldThreats == conf.DemoteColdThreats && c.isColdToAll(grp, threats[grp])
+	}
+	for _, hostileGroup := range prioritizeThreats(threats, c.isHVAA, isDemoted) {
+		c.broadcastThreat(hostileGroup, expandToPackages(threats[hostileGroup], packages), isDemoted(hostileGroup))
 	}
-
-	return time.Now().Before(cooldown)
 }
 
-func (t *cooldownTracker) remove(id uint64) {
-	t.lock.Lock()
-	defer t.lock.Unlock()
-	delete(t.cooldowns, id)
+// isHVAA returns true if the friendly aircraft with the given ID is tagged as a High Value Airborne Asset.
+func (c *controller) isHVAA(id uint64) bool {
+	trackfile := c.scope.FindUnit(id)
+	return trackfile != nil && trackfile.HasTag(brevity.TagHVAA)
 }
 
-func (c *controller) broadcastThreats() {
-	if !c.enableThreatMonitoring {
-		return
+// prioritizeThreats orders the threat groups so that threats to High Value Airborne Assets are called first, and
+// demoted threats are called last.
+func prioritizeThreats(threats map[brevity.Group][]uint64, isHVAA func(uint64) bool, isDemoted func(brevity.Group) bool) []brevity.Group {
+	groups := make([]brevity.Group, 0, len(threats))
+	for grp := range threats {
+		groups = append(groups, grp)
+	}
+	threatensHVAA := func(grp brevity.Group) bool {
+		return slices.ContainsFunc(threats[grp], isHVAA)
 	}
-	threats := c.scope.Threats(c.coalition.Opposite())
-	for hostileGroup, friendIDs := range threats {
-		c.broadcastThreat(hostileGroup, friendIDs)
+	demoted := make(map[brevity.Group]bool, len(groups))
+	for _, grp := range groups {
+		demoted[grp] = isDemoted(grp)
 	}
+	slices.SortStableFunc(groups, func(a, b brevity.Group) int {
+		switch {
+		case !demoted[a] && demoted[b]:
+			return -1
+		case demoted[a] && !demoted[b]:
+			return 1
+		case threatensHVAA(a) && !threatensHVAA(b):
+			return -1
+		case !threatensHVAA(a) && threatensHVAA(b):
+			return 1
+		default:
+			return 0
+		}
+	})
+	return groups
+}
+
+// expandToPackages adds the other aircraft in the packages of the given friendly aircraft.
+func expandToPackages(friendIDs []uint64, packages []radar.Package) []uint64 {
+	expanded := slices.Clone(friendIDs)
+	for _, pkg := range packages {
+		inPackage := slices.ContainsFunc(friendIDs, func(id uint64) bool {
+			return slices.Contains(pkg.ObjectIDs, id)
+		})
+		if !inPackage {
+			continue
+		}
+		for _, id := range pkg.ObjectIDs {
+			if !slices.Contains(expanded, id) {
+				expanded = append(expanded, id)
+			}
+		}
+	}
+	return expanded
 }
 
-func (c *controller) broadcastThreat(hostileGroup brevity.Group, friendIDs []uint64) {
+// broadcastThreat broadcasts a THREAT call about the hostile group to the given friendly aircraft. If isDemoted is true,
+// the next call about the group is delayed by twice the usual cooldown.
+func (c *controller) broadcastThreat(hostileGroup brevity.Group, friendIDs []uint64, isDemoted bool) {
 	hostileGroup.SetDeclaration(brevity.Hostile)
 	c.fillInMergeDetails(hostileGroup)
 	hostileGroup.SetThreat(true)
 
 	logger := log.With().Stringer("group", hostileGroup).Uints64("friendIDs", friendIDs).Logger()
 
+	now := time.Now()
 	recentlyNotified := true
 	for _, threatID := range hostileGroup.ObjectIDs() {
-		if !c.threatCooldowns.isOnCooldown(threatID) {
+		if !c.threatCooldowns.IsSuppressed(threatID, 0, now) {
 			recentlyNotified = false
 			break
 		}
@@ -88,6 +149,14 @@ func (c *controller) broadcastThreat(hostileGroup brevity.Group, friendIDs []uin
 			logger.Debug().Msg("omitting friendly from threat call because the threat is already merged")
 			continue
 		}
+		if c.tallies.isTally(friendID, hostileGroup.ObjectIDs()) {
+			logger.Debug().Uint64("friendID", friendID).Msg("omitting friendly from threat call because the friendly has a tally on the threat")
+			continue
+		}
+		if c.coldThreats == conf.ExcludeColdThreats && c.isColdTo(hostileGroup, friendID) {
+			logger.Debug().Uint64("friendID", friendID).Msg("omitting friendly from threat call because the threat is cold")
+			continue
+		}
 		if friendly := c.scope.FindUnit(friendID); friendly != nil {
 			call.Callsigns = c.addFriendlyToBroadcast(call.Callsigns, friendly)
 		}
@@ -97,11 +166,20 @@ func (c *controller) broadcastThreat(hostileGroup brevity.Group, friendIDs []uin
 		logger.Debug().Msg("skipping threat call because no relevant clients are on frequency")
 		return
 	}
+	call.Callsigns = c.mutes.filter(call.Callsigns)
+	if len(call.Callsigns) == 0 {
+		logger.Debug().Msg("skipping threat call because every relevant client stopped broadcasts")
+		return
+	}
 
 	logger.Info().Any("call", call).Msg("broadcasting threat call for group")
 	c.out <- call
 
+	cooldown := c.threatMonitoringCooldown
+	if isDemoted {
+		cooldown *= 2
+	}
 	for _, threatID := range hostileGroup.ObjectIDs() {
-		c.threatCooldowns.extendCooldown(threatID)
+		c.threatCooldowns.Record(threatID, 0, now, cooldown)
 	}
 }
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/radar"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandToPackages(t *testing.T) {
+	t.Parallel()
+	packages := []radar.Package{
+		{Name: "north", ObjectIDs: []uint64{1, 2, 3}},
+		{Name: "south", ObjectIDs: []uint64{4, 5}},
+	}
+	testCases := []struct {
+		name      string
+		friendIDs []uint64
+		expected  []uint64
+	}{
+		{
+			name:      "no packages",
+			friendIDs: []uint64{6},
+			expected:  []uint64{6},
+		},
+		{
+			name:      "one package",
+			friendIDs: []uint64{2},
+			expected:  []uint64{2, 1, 3},
+		},
+		{
+			name:      "multiple packages",
+			friendIDs: []uint64{5, 1, 6},
+			expected:  []uint64{5, 1, 6, 2, 3, 4},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, expandToPackages(test.friendIDs, packages))
+		})
+	}
+}
+
+// stubGroup is a distinct [brevity.Group] for use as a map key in tests. Calling any method panics.
+type stubGroup struct {
+	brevity.Group
+	name string
+}
+
+func TestPrioritizeThreats(t *testing.T) {
+	t.Parallel()
+	fighters := &stubGroup{name: "fighters"}
+	tanker := &stubGroup{name: "tanker"}
+	awacs := &stubGroup{name: "awacs"}
+	threats := map[brevity.Group][]uint64{
+		fighters: {1, 2},
+		tanker:   {3},
+		awacs:    {2, 4},
+	}
+	isHVAA := func(id uint64) bool { return id == 3 || id == 4 }
+
+	groups := prioritizeThreats(threats, isHVAA, func(brevity.Group) bool { return false })
+	assert.Len(t, groups, 3)
+	assert.ElementsMatch(t, []brevity.Group{tanker, awacs}, groups[:2])
+	assert.Equal(t, fighters, groups[2])
+
+	isDemoted := func(grp brevity.Group) bool { return grp == tanker }
+	groups = prioritizeThreats(threats, isHVAA, isDemoted)
+	assert.Equal(t, []brevity.Group{awacs, fighters, tanker}, groups)
+}
+
+func TestThreatTracker(t *testing.T) {
+	t.Parallel()
+	tracker := newThreatTracker()
+	assert.Empty(t, tracker.get(1))
+
+	tracker.update(map[brevity.Group][]uint64{
+		&fakeGroup{id: 10}: {1, 2},
+		&fakeGroup{id: 11}: {1},
+	})
+	assert.ElementsMatch(t, []uint64{10, 11}, tracker.get(1))
+	assert.Equal(t, []uint64{10}, tracker.get(2))
+	assert.Empty(t, tracker.get(3))
+
+	tracker.update(map[brevity.Group][]uint64{})
+	assert.Empty(t, tracker.get(1), "threats from the previous tick should be forgotten")
+}
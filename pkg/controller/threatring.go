@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/radar"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/rs/zerolog/log"
+)
+
+// warnThreatRing warns a friendly aircraft which has entered the threat ring of a hostile SAM or AAA site.
+func (c *controller) warnThreatRing(trackfile *trackfiles.Trackfile, zone radar.ThreatZone) {
+	if !c.enableThreatMonitoring || trackfile.Contact.Coalition != c.coalition {
+		return
+	}
+	callsigns := c.mutes.filter(c.addFriendlyToBroadcast(nil, trackfile))
+	if len(callsigns) == 0 {
+		return
+	}
+	origin := trackfile.LastKnown().Point
+	bearing := spatial.TrueBearing(origin, zone.Center).Magnetic(c.scope.Declination(origin))
+	log.Info().Str("callsign", callsigns[0]).Str("system", zone.System).Str("group", zone.Group).Msg("broadcasting threat ring call")
+	c.out <- brevity.ThreatRingCall{
+		Callsign: callsigns[0],
+		System:   zone.System,
+		BRA:      brevity.NewBRA(bearing, spatial.Distance(origin, zone.Center)),
+	}
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"sync"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
+)
+
+// trainingTracker tracks which callers receive training commentary.
+type trainingTracker struct {
+	// isEnabled is the default for callers who have not turned commentary on or off themselves.
+	isEnabled bool
+	// overrides maps callsigns to whether they turned commentary on or off.
+	overrides map[string]bool
+	lock      sync.RWMutex
+}
+
+func newTrainingTracker(isEnabled bool) *trainingTracker {
+	return &trainingTracker{
+		isEnabled: isEnabled,
+		overrides: make(map[string]bool),
+	}
+}
+
+// setDefault sets whether commentary is given to callers who have not turned it on or off themselves.
+func (t *trainingTracker) setDefault(isEnabled bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.isEnabled = isEnabled
+}
+
+// set turns commentary on or off for the given callsign.
+func (t *trainingTracker) set(callsign string, isEnabled bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.overrides[callsign] = isEnabled
+}
+
+// isEnabledFor checks if commentary should be given to the given callsign. An empty callsign checks the default, which
+// applies to broadcasts.
+func (t *trainingTracker) isEnabledFor(callsign string) bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	if isEnabled, ok := t.overrides[callsign]; ok && callsign != "" {
+		return isEnabled
+	}
+	return t.isEnabled
+}
+
+// HandleTraining implements [Controller.HandleTraining].
+func (c *controller) HandleTraining(request *brevity.TrainingRequest) {
+	logger := log.With().Str("callsign", request.Callsign).Type("type", request).Bool("enabled", request.Enabled).Logger()
+	logger.Debug().Msg("handling request")
+	foundCallsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition)
+	if trackfile == nil {
+		logger.Info().Msg("no trackfile found for requestor")
+		c.out <- brevity.NegativeRadarContactResponse{Callsign: request.Callsign}
+		return
+	}
+	c.training.set(foundCallsign, request.Enabled)
+	logger.Info().Str("callsign", foundCallsign).Msg("set training commentary")
+	c.out <- brevity.TrainingResponse{Callsign: foundCallsign, Enabled: request.Enabled}
+}
+
+// SetTraining implements [Controller.SetTraining].
+func (c *controller) SetTraining(isEnabled bool) {
+	c.training.setDefault(isEnabled)
+	log.Info().Bool("enabled", isEnabled).Msg("set default training commentary")
+}
+
+// Training implements [Controller.Training].
+func (c *controller) Training() bool {
+	return c.training.isEnabledFor("")
+}
+
+// commentate follows up a call about the given group with training commentary, if commentary is enabled for the
+// callsign. An empty callsign is used for broadcasts.
+func (c *controller) commentate(callsign string, group brevity.Group, isPriority bool) {
+	if group == nil || !c.training.isEnabledFor(callsign) {
+		return
+	}
+	c.out <- brevity.CommentaryCall{Callsign: callsign, Group: group, IsPriority: isPriority}
+}
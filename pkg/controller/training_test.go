@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrainingTracker(t *testing.T) {
+	t.Parallel()
+	tracker := newTrainingTracker(false)
+	assert.False(t, tracker.isEnabledFor("mobius 1"))
+	assert.False(t, tracker.isEnabledFor(""))
+
+	tracker.set("mobius 1", true)
+	assert.True(t, tracker.isEnabledFor("mobius 1"))
+	assert.False(t, tracker.isEnabledFor("yellow 13"))
+	assert.False(t, tracker.isEnabledFor(""))
+
+	tracker.setDefault(true)
+	assert.True(t, tracker.isEnabledFor("yellow 13"))
+	assert.True(t, tracker.isEnabledFor(""))
+
+	tracker.set("yellow 13", false)
+	assert.False(t, tracker.isEnabledFor("yellow 13"))
+	assert.True(t, tracker.isEnabledFor("mobius 1"))
+}
@@ -7,7 +7,7 @@ import (
 
 func (c *controller) HandleTripwire(request *brevity.TripwireRequest) {
 	log.Debug().Str("callsign", request.Callsign).Type("type", request).Msg("handling request")
-	foundCallsign, trackfile := c.scope.FindCallsign(request.Callsign, c.coalition)
+	foundCallsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition)
 	if trackfile == nil {
 		log.Debug().Msg("no trackfile found for requestor")
 		c.out <- brevity.NegativeRadarContactResponse{Callsign: request.Callsign}
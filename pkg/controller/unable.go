@@ -8,7 +8,7 @@ import (
 func (c *controller) HandleUnableToUnderstand(request *brevity.UnableToUnderstandRequest) {
 	log.Debug().Str("callsign", request.Callsign).Type("type", request).Msg("handling request")
 	response := brevity.SayAgainResponse{Callsign: "last caller"}
-	if callsign, trackfile := c.scope.FindCallsign(request.Callsign, c.coalition); trackfile != nil {
+	if callsign, trackfile := c.scope.ResolveCallsign(request.Callsign, c.coalition); trackfile != nil {
 		response.Callsign = callsign
 	}
 	c.out <- response
@@ -0,0 +1,276 @@
+// package datalink publishes the radar's air picture to external tools, such as kneeboard generators and web maps.
+//
+// Clients connect over TCP and must send a [Subscription] with the server's token as a line of JSON within a few
+// seconds. After that, they receive a [Picture] as a line of JSON at every update interval. A client may send another
+// subscription at any time to filter what it receives, or to receive only what has changed since the previous picture.
+package datalink
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/radar"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// writeTimeout is how long writing a picture to a client may take before the client is disconnected.
+	writeTimeout = 10 * time.Second
+	// maxSubscriptionSize is the longest subscription line a client may send.
+	maxSubscriptionSize = 64 * 1024
+	// defaultAuthTimeout is how long a client has to send a subscription with the correct token before it is
+	// disconnected.
+	defaultAuthTimeout = 10 * time.Second
+)
+
+// Source provides snapshots of the radar scope.
+type Source interface {
+	// Snapshot returns a consistent copy of the radar scope.
+	Snapshot() radar.RadarSnapshot
+}
+
+// Server publishes the air picture to TCP clients.
+type Server struct {
+	address  string
+	token    string
+	interval time.Duration
+	// keyframeInterval is how often clients which subscribe to deltas receive a full picture.
+	keyframeInterval time.Duration
+	// authTimeout is how long a client has to send a subscription with the correct token before it is disconnected.
+	authTimeout time.Duration
+	source      Source
+
+	lock    sync.Mutex
+	clients map[*client]struct{}
+}
+
+// NewServer constructs a server which listens on the given address and publishes a picture from the source at the
+// given interval. Clients which subscribe to deltas receive a keyframe at the keyframe interval. Clients must send a
+// subscription with a matching token before they receive anything. The token must not be empty.
+func NewServer(address, token string, interval, keyframeInterval time.Duration, source Source) *Server {
+	return &Server{
+		address:          address,
+		token:            token,
+		interval:         interval,
+		keyframeInterval: keyframeInterval,
+		authTimeout:      defaultAuthTimeout,
+		source:           source,
+		clients:          make(map[*client]struct{}),
+	}
+}
+
+// Run accepts clients and publishes pictures until the context is cancelled.
+func (s *Server) Run(ctx context.Context, wg *sync.WaitGroup) error {
+	if s.token == "" {
+		return errors.New("datalink token must not be empty")
+	}
+	var lc net.ListenConfig
+	listener, err := lc.Listen(ctx, "tcp", s.address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.address, err)
+	}
+	log.Info().Str("address", listener.Addr().String()).Msg("datalink listening")
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		log.Info().Msg("stopping datalink due to context cancellation")
+		_ = listener.Close()
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		for c := range s.clients {
+			_ = c.conn.Close()
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.publish(ctx)
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return err
+			}
+			log.Warn().Err(err).Msg("failed to accept datalink client")
+			continue
+		}
+		c := s.connect(conn)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			c.read(s.token, s.authTimeout)
+		}()
+		go func() {
+			defer wg.Done()
+			defer s.disconnect(c)
+			c.write(ctx)
+		}()
+	}
+}
+
+// publish sends a picture to every client at each interval until the context is cancelled.
+func (s *Server) publish(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.lock.Lock()
+			if len(s.clients) == 0 {
+				s.lock.Unlock()
+				continue
+			}
+			s.lock.Unlock()
+			snapshot := s.source.Snapshot()
+			s.lock.Lock()
+			for c := range s.clients {
+				c.offer(snapshot)
+			}
+			s.lock.Unlock()
+		}
+	}
+}
+
+func (s *Server) connect(conn net.Conn) *client {
+	c := newClient(conn, s.keyframeInterval)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.clients[c] = struct{}{}
+	log.Info().Stringer("remote", conn.RemoteAddr()).Int("clients", len(s.clients)).Msg("datalink client connected")
+	return c
+}
+
+func (s *Server) disconnect(c *client) {
+	_ = c.conn.Close()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.clients, c)
+	log.Info().Stringer("remote", c.conn.RemoteAddr()).Int("clients", len(s.clients)).Msg("datalink client disconnected")
+}
+
+// client is a connected datalink client.
+type client struct {
+	conn net.Conn
+	// subscription is the client's most recent subscription. It is nil until the client is authorized.
+	subscription atomic.Pointer[Subscription]
+	// snapshots holds the most recent snapshot which has not yet been sent. If the client falls behind, older
+	// snapshots are dropped.
+	snapshots chan radar.RadarSnapshot
+	// rejected is closed if the client sends an incorrect token or does not send a token in time.
+	rejected   chan struct{}
+	rejectOnce sync.Once
+	// deltas encodes pictures as keyframes and deltas for a client which subscribes to deltas.
+	deltas *deltaEncoder
+}
+
+func newClient(conn net.Conn, keyframeInterval time.Duration) *client {
+	return &client{
+		conn:      conn,
+		snapshots: make(chan radar.RadarSnapshot, 1),
+		rejected:  make(chan struct{}),
+		deltas:    newDeltaEncoder(keyframeInterval),
+	}
+}
+
+// reject stops sending pictures to the client.
+func (c *client) reject() {
+	c.rejectOnce.Do(func() { close(c.rejected) })
+}
+
+// offer queues a snapshot to send to the client, replacing any snapshot which has not yet been sent.
+func (c *client) offer(snapshot radar.RadarSnapshot) {
+	select {
+	case <-c.snapshots:
+	default:
+	}
+	c.snapshots <- snapshot
+}
+
+// read reads subscriptions from the client until the connection is closed or the client sends an incorrect token. The
+// client is rejected if it does not send a subscription with the correct token within the timeout. An authorized
+// client which stops sending subscriptions continues to receive pictures.
+func (c *client) read(token string, authTimeout time.Duration) {
+	defer func() {
+		if c.subscription.Load() == nil {
+			c.reject()
+		}
+	}()
+	if err := c.conn.SetReadDeadline(time.Now().Add(authTimeout)); err != nil {
+		return
+	}
+	scanner := bufio.NewScanner(c.conn)
+	scanner.Buffer(make([]byte, 0, 1024), maxSubscriptionSize)
+	for scanner.Scan() {
+		var subscription Subscription
+		if err := json.Unmarshal(scanner.Bytes(), &subscription); err != nil {
+			log.Warn().Err(err).Stringer("remote", c.conn.RemoteAddr()).Msg("ignoring malformed datalink subscription")
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(subscription.Token), []byte(token)) != 1 {
+			log.Warn().Stringer("remote", c.conn.RemoteAddr()).Msg("rejecting datalink client with incorrect token")
+			c.reject()
+			return
+		}
+		log.Debug().Stringer("remote", c.conn.RemoteAddr()).Any("subscription", subscription).Msg("updated datalink subscription")
+		if c.subscription.Swap(&subscription) == nil {
+			// The client is authorized, so it may go quiet without being disconnected.
+			if err := c.conn.SetReadDeadline(time.Time{}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// write sends pictures to the client until the context is cancelled, the client is rejected or a write fails.
+func (c *client) write(ctx context.Context) {
+	encoder := json.NewEncoder(c.conn)
+	// previous is the subscription the last picture was filtered with, so that a new subscription starts with a
+	// keyframe.
+	var previous *Subscription
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.rejected:
+			return
+		case snapshot := <-c.snapshots:
+			subscription := c.subscription.Load()
+			if subscription == nil {
+				continue
+			}
+			if err := c.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+			picture := subscription.filter(snapshot)
+			if subscription.Deltas {
+				if subscription != previous {
+					c.deltas.reset()
+				}
+				picture = c.deltas.encode(picture, time.Now())
+			}
+			previous = subscription
+			if err := encoder.Encode(picture); err != nil {
+				log.Warn().Err(err).Stringer("remote", c.conn.RemoteAddr()).Msg("failed to send picture to datalink client")
+				return
+			}
+		}
+	}
+}
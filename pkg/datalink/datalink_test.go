@@ -0,0 +1,249 @@
+package datalink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/radar"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSource struct {
+	snapshot radar.RadarSnapshot
+}
+
+func (s *mockSource) Snapshot() radar.RadarSnapshot {
+	return s.snapshot
+}
+
+func testSnapshot() radar.RadarSnapshot {
+	track := func(id uint64, name string, coalition coalitions.Coalition, point orb.Point, altitude unit.Length, isFaded bool) radar.TrackfileSnapshot {
+		return radar.TrackfileSnapshot{
+			Contact: trackfiles.Labels{ID: id, Name: name, Coalition: coalition, ACMIName: "F-15C"},
+			LastKnown: trackfiles.Frame{
+				Point:    point,
+				Altitude: altitude,
+			},
+			Course:  bearings.NewTrueBearing(90 * unit.Degree),
+			Speed:   400 * unit.Knot,
+			IsFaded: isFaded,
+		}
+	}
+	return radar.RadarSnapshot{
+		Bullseyes: map[coalitions.Coalition]orb.Point{
+			coalitions.Blue: {33, 33},
+			coalitions.Red:  {34, 34},
+		},
+		Trackfiles: []radar.TrackfileSnapshot{
+			track(1, "Mobius 1", coalitions.Blue, orb.Point{33, 33}, 20000*unit.Foot, false),
+			track(2, "Yellow 13", coalitions.Red, orb.Point{33.1, 33.1}, 30000*unit.Foot, false),
+			track(3, "Yellow 4", coalitions.Red, orb.Point{33.1, 33.1}, 500*unit.Foot, false),
+			track(4, "Yellow 7", coalitions.Red, orb.Point{36, 36}, 30000*unit.Foot, false),
+			track(5, "Yellow 9", coalitions.Red, orb.Point{33.1, 33.1}, 30000*unit.Foot, true),
+		},
+		Groups: map[coalitions.Coalition][]radar.GroupSnapshot{
+			coalitions.Red: {
+				{
+					ObjectIDs: []uint64{2},
+					Point:     orb.Point{33.1, 33.1},
+					Stacks:    []brevity.Stack{{Altitude: 30000 * unit.Foot, Count: 1}},
+					Track:     brevity.North,
+					Platforms: []string{"Flanker"},
+				},
+			},
+		},
+	}
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+	snapshot := testSnapshot()
+	ids := func(picture Picture) []uint64 {
+		result := make([]uint64, 0)
+		for _, track := range picture.Tracks {
+			result = append(result, track.ID)
+		}
+		return result
+	}
+
+	testCases := []struct {
+		name         string
+		subscription Subscription
+		expectedIDs  []uint64
+		groups       int
+	}{
+		{
+			name:        "everything",
+			expectedIDs: []uint64{1, 2, 3, 4, 5},
+		},
+		{
+			name:         "coalition",
+			subscription: Subscription{Coalitions: []string{"red"}},
+			expectedIDs:  []uint64{2, 3, 4, 5},
+		},
+		{
+			name:         "hide faded",
+			subscription: Subscription{HideFaded: true},
+			expectedIDs:  []uint64{1, 2, 3, 4},
+		},
+		{
+			name:         "altitude",
+			subscription: Subscription{MinAltitudeFeet: 1000, MaxAltitudeFeet: 25000},
+			expectedIDs:  []uint64{1},
+		},
+		{
+			name:         "radius",
+			subscription: Subscription{Center: &Position{Lat: 33, Lon: 33}, RadiusNM: 50},
+			expectedIDs:  []uint64{1, 2, 3, 5},
+		},
+		{
+			name:         "groups",
+			subscription: Subscription{Coalitions: []string{"Red"}, Groups: true},
+			expectedIDs:  []uint64{2, 3, 4, 5},
+			groups:       1,
+		},
+		{
+			name:         "groups of other coalition",
+			subscription: Subscription{Coalitions: []string{"Blue"}, Groups: true},
+			expectedIDs:  []uint64{1},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			picture := test.subscription.filter(snapshot)
+			assert.Equal(t, test.expectedIDs, ids(picture))
+			assert.Len(t, picture.Groups, test.groups)
+		})
+	}
+
+	picture := (&Subscription{Coalitions: []string{"Blue"}}).filter(snapshot)
+	assert.Equal(t, map[string]Position{"Blue": {Lat: 33, Lon: 33}}, picture.Bullseyes)
+}
+
+// freeAddress returns a local address which is not in use.
+func freeAddress(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	return address
+}
+
+// startServer runs a datalink server until the test ends.
+func startServer(t *testing.T, token string) string {
+	t.Helper()
+	return runServer(t, NewServer(freeAddress(t), token, 10*time.Millisecond, time.Minute, &mockSource{snapshot: testSnapshot()}))
+}
+
+// runServer runs the given datalink server until the test ends.
+func runServer(t *testing.T, server *Server) string {
+	t.Helper()
+	address := server.address
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	go func() {
+		assert.NoError(t, server.Run(ctx, &wg))
+	}()
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, time.Second, 10*time.Millisecond)
+	return address
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+	address := startServer(t, "secret")
+	conn, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	subscription, err := json.Marshal(Subscription{Token: "secret", Coalitions: []string{"Red"}, HideFaded: true})
+	require.NoError(t, err)
+	_, err = conn.Write(append(subscription, '\n'))
+	require.NoError(t, err)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	reader := bufio.NewReader(conn)
+	// Skip any picture which was sent before the subscription was received.
+	for {
+		line, err := reader.ReadBytes('\n')
+		require.NoError(t, err)
+		var picture Picture
+		require.NoError(t, json.Unmarshal(line, &picture))
+		if len(picture.Tracks) == 3 {
+			for _, track := range picture.Tracks {
+				assert.Equal(t, "Red", track.Coalition)
+				assert.False(t, track.Faded)
+			}
+			return
+		}
+	}
+}
+
+func TestServerRejectsIncorrectToken(t *testing.T) {
+	t.Parallel()
+	address := startServer(t, "secret")
+	conn, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(`{"token": "wrong"}` + "\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = bufio.NewReader(conn).ReadBytes('\n')
+	require.Error(t, err)
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		assert.False(t, netErr.Timeout(), "connection should be closed, not time out")
+	}
+}
+
+func TestServerRejectsSlowClient(t *testing.T) {
+	t.Parallel()
+	server := NewServer(freeAddress(t), "secret", 10*time.Millisecond, time.Minute, &mockSource{snapshot: testSnapshot()})
+	server.authTimeout = 50 * time.Millisecond
+	address := runServer(t, server)
+	conn, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = bufio.NewReader(conn).ReadBytes('\n')
+	require.Error(t, err)
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		assert.False(t, netErr.Timeout(), "connection should be closed, not time out")
+	}
+}
+
+func TestServerRequiresToken(t *testing.T) {
+	t.Parallel()
+	server := NewServer(freeAddress(t), "", 10*time.Millisecond, time.Minute, &mockSource{snapshot: testSnapshot()})
+	var wg sync.WaitGroup
+	require.Error(t, server.Run(context.Background(), &wg))
+}
@@ -0,0 +1,102 @@
+package datalink
+
+import (
+	"slices"
+	"time"
+)
+
+// deltaEncoder turns the successive pictures sent to a client into keyframes and deltas.
+type deltaEncoder struct {
+	// keyframeInterval is how often a keyframe is sent.
+	keyframeInterval time.Duration
+	// tracks and groups are those in the previous picture, by ID. Nil until the first keyframe.
+	tracks map[uint64]Track
+	groups map[uint64]Group
+	// lastKeyframe is when the last keyframe was sent.
+	lastKeyframe time.Time
+}
+
+func newDeltaEncoder(keyframeInterval time.Duration) *deltaEncoder {
+	return &deltaEncoder{keyframeInterval: keyframeInterval}
+}
+
+// reset forces the next picture to be a keyframe.
+func (e *deltaEncoder) reset() {
+	e.tracks = nil
+	e.groups = nil
+}
+
+// encode returns the picture as a keyframe, or as a delta from the previous picture.
+func (e *deltaEncoder) encode(picture Picture, now time.Time) Picture {
+	isKeyframe := e.tracks == nil || now.Sub(e.lastKeyframe) >= e.keyframeInterval
+	previousTracks, previousGroups := e.tracks, e.groups
+	e.tracks = make(map[uint64]Track, len(picture.Tracks))
+	for _, track := range picture.Tracks {
+		e.tracks[track.ID] = track
+	}
+	e.groups = make(map[uint64]Group, len(picture.Groups))
+	for _, grp := range picture.Groups {
+		e.groups[grp.ID] = grp
+	}
+	if isKeyframe {
+		e.lastKeyframe = now
+		picture.Type = KeyframePicture
+		return picture
+	}
+
+	delta := Picture{
+		Type:        DeltaPicture,
+		MissionTime: picture.MissionTime,
+		Bullseyes:   picture.Bullseyes,
+		Tracks:      make([]Track, 0),
+	}
+	for _, track := range picture.Tracks {
+		if previous, ok := previousTracks[track.ID]; !ok || !previous.equal(track) {
+			delta.Tracks = append(delta.Tracks, track)
+		}
+	}
+	for _, grp := range picture.Groups {
+		if previous, ok := previousGroups[grp.ID]; !ok || !previous.equal(grp) {
+			delta.Groups = append(delta.Groups, grp)
+		}
+	}
+	delta.RemovedTracks = removed(previousTracks, e.tracks)
+	delta.RemovedGroups = removed(previousGroups, e.groups)
+	return delta
+}
+
+// removed returns the sorted IDs which are in the previous map but not the current map.
+func removed[T any](previous, current map[uint64]T) []uint64 {
+	var ids []uint64
+	for id := range previous {
+		if _, ok := current[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func (t Track) equal(other Track) bool {
+	return t.ID == other.ID &&
+		t.Name == other.Name &&
+		t.Coalition == other.Coalition &&
+		t.Aircraft == other.Aircraft &&
+		t.Time.Equal(other.Time) &&
+		t.Position == other.Position &&
+		t.AltitudeFeet == other.AltitudeFeet &&
+		t.CourseDegrees == other.CourseDegrees &&
+		t.SpeedKnots == other.SpeedKnots &&
+		slices.Equal(t.Tags, other.Tags) &&
+		t.Faded == other.Faded
+}
+
+func (g Group) equal(other Group) bool {
+	return g.ID == other.ID &&
+		g.Coalition == other.Coalition &&
+		slices.Equal(g.IDs, other.IDs) &&
+		g.Position == other.Position &&
+		slices.Equal(g.AltitudesFeet, other.AltitudesFeet) &&
+		g.Track == other.Track &&
+		slices.Equal(g.Platforms, other.Platforms)
+}
@@ -0,0 +1,56 @@
+package datalink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeltaEncoder(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	encoder := newDeltaEncoder(30 * time.Second)
+	mobius := Track{ID: 1, Name: "Mobius 1", Coalition: "Blue", Position: Position{Lat: 33, Lon: 33}}
+	yellow := Track{ID: 2, Name: "Yellow 13", Coalition: "Red", Position: Position{Lat: 34, Lon: 34}}
+	grp := Group{ID: 2, Coalition: "Red", IDs: []uint64{2}, Position: Position{Lat: 34, Lon: 34}}
+
+	keyframe := encoder.encode(Picture{Tracks: []Track{mobius, yellow}, Groups: []Group{grp}}, now)
+	assert.Equal(t, KeyframePicture, keyframe.Type)
+	assert.Len(t, keyframe.Tracks, 2)
+	assert.Len(t, keyframe.Groups, 1)
+
+	// Nothing changed.
+	delta := encoder.encode(Picture{Tracks: []Track{mobius, yellow}, Groups: []Group{grp}}, now.Add(2*time.Second))
+	assert.Equal(t, DeltaPicture, delta.Type)
+	assert.Empty(t, delta.Tracks)
+	assert.Empty(t, delta.Groups)
+	assert.Empty(t, delta.RemovedTracks)
+	assert.Empty(t, delta.RemovedGroups)
+
+	// Yellow 13 moved, and Mobius 1 was removed along with the group.
+	moved := yellow
+	moved.Position = Position{Lat: 34.1, Lon: 34}
+	delta = encoder.encode(Picture{Tracks: []Track{moved}}, now.Add(4*time.Second))
+	assert.Equal(t, DeltaPicture, delta.Type)
+	assert.Equal(t, []Track{moved}, delta.Tracks)
+	assert.Equal(t, []uint64{1}, delta.RemovedTracks)
+	assert.Equal(t, []uint64{2}, delta.RemovedGroups)
+
+	// A tag was added.
+	tagged := moved
+	tagged.Tags = []string{"HVAA"}
+	delta = encoder.encode(Picture{Tracks: []Track{tagged}}, now.Add(6*time.Second))
+	assert.Equal(t, []Track{tagged}, delta.Tracks)
+
+	// The keyframe interval passed.
+	keyframe = encoder.encode(Picture{Tracks: []Track{tagged}}, now.Add(30*time.Second))
+	assert.Equal(t, KeyframePicture, keyframe.Type)
+	require.Len(t, keyframe.Tracks, 1)
+
+	// A reset forces a keyframe.
+	encoder.reset()
+	keyframe = encoder.encode(Picture{Tracks: []Track{tagged}}, now.Add(32*time.Second))
+	assert.Equal(t, KeyframePicture, keyframe.Type)
+}
@@ -0,0 +1,209 @@
+package datalink
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/radar"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+// Subscription is sent by a client to choose what it receives. Each subscription replaces the previous one.
+type Subscription struct {
+	// Token must match the server's token. No pictures are sent until a subscription with the correct token is
+	// received, and a subscription with an incorrect token disconnects the client.
+	Token string `json:"token,omitempty"`
+	// Coalitions are the names of the coalitions to include, such as "Red". Empty includes every coalition.
+	Coalitions []string `json:"coalitions,omitempty"`
+	// HideFaded leaves out tracks which have faded from the scope but have not yet been removed.
+	HideFaded bool `json:"hideFaded,omitempty"`
+	// MinAltitudeFeet leaves out tracks below this altitude. Zero does not filter.
+	MinAltitudeFeet float64 `json:"minAltitudeFeet,omitempty"`
+	// MaxAltitudeFeet leaves out tracks above this altitude. Zero does not filter.
+	MaxAltitudeFeet float64 `json:"maxAltitudeFeet,omitempty"`
+	// Center and RadiusNM leave out tracks further than the radius from the center. Zero does not filter.
+	Center   *Position `json:"center,omitempty"`
+	RadiusNM float64   `json:"radiusNM,omitempty"`
+	// Groups includes the groups the radar has collected the tracks into.
+	Groups bool `json:"groups,omitempty"`
+	// Deltas asks for keyframes and deltas instead of a full picture at every interval. See [Picture].
+	Deltas bool `json:"deltas,omitempty"`
+}
+
+// Position is a point on the Earth.
+type Position struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+func positionOf(point orb.Point) Position {
+	return Position{Lat: point.Lat(), Lon: point.Lon()}
+}
+
+// Picture is sent to each client at every update interval.
+//
+// A client which subscribes to deltas receives a keyframe first, then deltas until the next keyframe. A keyframe is a
+// full picture. A delta contains only the tracks and groups which are new or have changed since the previous picture,
+// and lists the IDs of those which have been removed. Keyframes are sent periodically, and whenever the subscription
+// changes.
+type Picture struct {
+	// Type is [KeyframePicture] or [DeltaPicture] for clients which subscribe to deltas, and empty otherwise.
+	Type PictureType `json:"type,omitempty"`
+	// MissionTime is the mission time when the picture was taken.
+	MissionTime time.Time `json:"missionTime"`
+	// Bullseyes maps coalition names to their bullseyes.
+	Bullseyes map[string]Position `json:"bullseyes"`
+	// Tracks are the aircraft on the scope which match the subscription, ordered by ID.
+	Tracks []Track `json:"tracks"`
+	// Groups are the groups of aircraft which match the subscription. Only sent if the subscription asks for them.
+	Groups []Group `json:"groups,omitempty"`
+	// RemovedTracks are the IDs of tracks in the previous picture which are not in this one. Only sent in deltas.
+	RemovedTracks []uint64 `json:"removedTracks,omitempty"`
+	// RemovedGroups are the IDs of groups in the previous picture which are not in this one. Only sent in deltas.
+	RemovedGroups []uint64 `json:"removedGroups,omitempty"`
+}
+
+// PictureType distinguishes keyframes from deltas.
+type PictureType string
+
+const (
+	// KeyframePicture is a full picture.
+	KeyframePicture PictureType = "keyframe"
+	// DeltaPicture contains only the changes since the previous picture.
+	DeltaPicture PictureType = "delta"
+)
+
+// Track is a single aircraft.
+type Track struct {
+	// ID is the object ID of the aircraft.
+	ID uint64 `json:"id"`
+	// Name is the name of the unit. For players, this is the player's in-game name.
+	Name string `json:"name"`
+	// Coalition is the name of the aircraft's coalition, such as "Red".
+	Coalition string `json:"coalition"`
+	// Aircraft is the ACMI name of the aircraft type, such as "F-16C_50".
+	Aircraft string `json:"aircraft"`
+	// Time is the mission time of the track's last update.
+	Time time.Time `json:"time"`
+	Position
+	// AltitudeFeet is the altitude above sea level.
+	AltitudeFeet float64 `json:"altitudeFeet"`
+	// CourseDegrees is the magnetic course over the ground.
+	CourseDegrees float64 `json:"courseDegrees"`
+	// SpeedKnots is the ground speed.
+	SpeedKnots float64 `json:"speedKnots"`
+	// Tags are annotations attached to the track, such as "HVAA".
+	Tags []string `json:"tags,omitempty"`
+	// Faded is true if the track has faded from the scope but has not yet been removed.
+	Faded bool `json:"faded,omitempty"`
+}
+
+// Group is a group of aircraft.
+type Group struct {
+	// ID is the lowest object ID of the aircraft in the group. A group keeps its ID for as long as that aircraft
+	// remains in the group.
+	ID uint64 `json:"id"`
+	// Coalition is the name of the group's coalition, such as "Red".
+	Coalition string `json:"coalition"`
+	// IDs are the object IDs of the aircraft in the group.
+	IDs []uint64 `json:"ids"`
+	Position
+	// AltitudesFeet are the altitudes of the group's stacks, from highest to lowest.
+	AltitudesFeet []float64 `json:"altitudesFeet"`
+	// Track is the group's cardinal direction of travel, such as "north".
+	Track string `json:"track"`
+	// Platforms are the aircraft types in the group.
+	Platforms []string `json:"platforms,omitempty"`
+}
+
+// includesCoalition returns true if the subscription includes the given coalition.
+func (s *Subscription) includesCoalition(coalition coalitions.Coalition) bool {
+	return len(s.Coalitions) == 0 || slices.ContainsFunc(s.Coalitions, func(name string) bool {
+		return strings.EqualFold(name, coalition.String())
+	})
+}
+
+// includesPoint returns true if the given point and altitude are within the subscription's declutter limits.
+func (s *Subscription) includesPoint(point orb.Point, altitude unit.Length) bool {
+	if s.MinAltitudeFeet > 0 && altitude.Feet() < s.MinAltitudeFeet {
+		return false
+	}
+	if s.MaxAltitudeFeet > 0 && altitude.Feet() > s.MaxAltitudeFeet {
+		return false
+	}
+	if s.Center != nil && s.RadiusNM > 0 {
+		center := orb.Point{s.Center.Lon, s.Center.Lat}
+		if spatial.Distance(center, point).NauticalMiles() > s.RadiusNM {
+			return false
+		}
+	}
+	return true
+}
+
+// filter returns the part of the snapshot which matches the subscription.
+func (s *Subscription) filter(snapshot radar.RadarSnapshot) Picture {
+	picture := Picture{
+		MissionTime: snapshot.MissionTime,
+		Bullseyes:   make(map[string]Position),
+		Tracks:      make([]Track, 0),
+	}
+	for coalition, bullseye := range snapshot.Bullseyes {
+		if s.includesCoalition(coalition) {
+			picture.Bullseyes[coalition.String()] = positionOf(bullseye)
+		}
+	}
+	for _, trackfile := range snapshot.Trackfiles {
+		frame := trackfile.LastKnown
+		if !s.includesCoalition(trackfile.Contact.Coalition) ||
+			(s.HideFaded && trackfile.IsFaded) ||
+			!s.includesPoint(frame.Point, frame.Altitude) {
+			continue
+		}
+		picture.Tracks = append(picture.Tracks, Track{
+			ID:            trackfile.Contact.ID,
+			Name:          trackfile.Contact.Name,
+			Coalition:     trackfile.Contact.Coalition.String(),
+			Aircraft:      trackfile.Contact.ACMIName,
+			Time:          frame.Time,
+			Position:      positionOf(frame.Point),
+			AltitudeFeet:  frame.Altitude.Feet(),
+			CourseDegrees: trackfile.Course.Degrees(),
+			SpeedKnots:    trackfile.Speed.Knots(),
+			Tags:          trackfile.Tags,
+			Faded:         trackfile.IsFaded,
+		})
+	}
+	if !s.Groups {
+		return picture
+	}
+	for _, coalition := range coalitions.All() {
+		if !s.includesCoalition(coalition) {
+			continue
+		}
+		for _, grp := range snapshot.Groups[coalition] {
+			var highest unit.Length
+			altitudes := make([]float64, 0, len(grp.Stacks))
+			for _, stack := range grp.Stacks {
+				highest = max(highest, stack.Altitude)
+				altitudes = append(altitudes, stack.Altitude.Feet())
+			}
+			if len(grp.ObjectIDs) == 0 || !s.includesPoint(grp.Point, highest) {
+				continue
+			}
+			picture.Groups = append(picture.Groups, Group{
+				ID:            slices.Min(grp.ObjectIDs),
+				Coalition:     coalition.String(),
+				IDs:           grp.ObjectIDs,
+				Position:      positionOf(grp.Point),
+				AltitudesFeet: altitudes,
+				Track:         string(grp.Track),
+				Platforms:     grp.Platforms,
+			})
+		}
+	}
+	return picture
+}
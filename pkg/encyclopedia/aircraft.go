@@ -2,9 +2,12 @@
 package encyclopedia
 
 import (
+	"maps"
+	"slices"
+	"sync"
+
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/martinlindhe/unit"
-	"github.com/rs/zerolog/log"
 )
 
 // Data sources:
@@ -19,6 +22,11 @@ const (
 	Unarmed
 	Fighter
 	Attack
+	// NonCombatant aircraft, such as transports, tankers, AEW&C aircraft and trainers, do not fly combat missions. This is
+	// distinct from Unarmed, which describes aircraft that pose no air-to-air threat (e.g. bombers).
+	NonCombatant
+	// Tanker aircraft can refuel other aircraft in flight.
+	Tanker
 )
 
 type Aircraft struct {
@@ -80,6 +88,22 @@ func (a Aircraft) HasAnyTag(tags ...AircraftTag) bool {
 	return false
 }
 
+// ReportingName returns the name used to describe the aircraft on the radio: the NATO reporting name, nickname,
+// official name or platform designation, in that order of preference.
+func (a Aircraft) ReportingName() string {
+	for _, name := range []string{a.NATOReportingName, a.Nickname, a.OfficialName} {
+		if name != "" {
+			return name
+		}
+	}
+	return a.PlatformDesignation
+}
+
+// IsCombatant returns true unless the aircraft is known to be a non-combatant.
+func (a Aircraft) IsCombatant() bool {
+	return !a.HasTag(NonCombatant)
+}
+
 func (a Aircraft) ThreatRadius() unit.Length {
 	if a.threatRadius != 0 || a.HasTag(Unarmed) {
 		return a.threatRadius
@@ -97,12 +121,13 @@ func variants(data Aircraft, naming map[string]string) []Aircraft {
 	variants := []Aircraft{}
 	for nameSuffix, designationSuffx := range naming {
 		aircraft := Aircraft{
-			tags:                data.tags,
+			tags:                maps.Clone(data.tags),
 			PlatformDesignation: data.PlatformDesignation,
 			TypeDesignation:     data.TypeDesignation + designationSuffx,
 			NATOReportingName:   data.NATOReportingName,
 			OfficialName:        data.OfficialName,
 			Nickname:            data.Nickname,
+			threatRadius:        data.threatRadius,
 		}
 		if data.ACMIShortName != "" {
 			aircraft.ACMIShortName = data.ACMIShortName + nameSuffix
@@ -114,6 +139,17 @@ func variants(data Aircraft, naming map[string]string) []Aircraft {
 	return variants
 }
 
+// withNonCombatants tags the variants with the given ACMI short names as non-combatants. This is used for unarmed
+// trainer and display variants of otherwise armed aircraft.
+func withNonCombatants(aircraft []Aircraft, names ...string) []Aircraft {
+	for _, a := range aircraft {
+		if slices.Contains(names, a.ACMIShortName) {
+			a.tags[NonCombatant] = true
+		}
+	}
+	return aircraft
+}
+
 var a10Data = Aircraft{
 	tags: map[AircraftTag]bool{
 		FixedWing: true,
@@ -166,19 +202,23 @@ var c101Data = Aircraft{
 }
 
 func c101Variants() []Aircraft {
-	return variants(
-		c101Data,
-		map[string]string{
-			"CC": "CC",
-			"EB": "EB",
-		},
+	return withNonCombatants(
+		variants(
+			c101Data,
+			map[string]string{
+				"CC": "CC",
+				"EB": "EB",
+			},
+		),
+		"C-101EB",
 	)
 }
 
 var ch47Data = Aircraft{
 	tags: map[AircraftTag]bool{
-		RotaryWing: true,
-		Unarmed:    true,
+		RotaryWing:   true,
+		Unarmed:      true,
+		NonCombatant: true,
 	},
 	PlatformDesignation: "CH-47",
 	OfficialName:        "Chinook",
@@ -564,8 +604,10 @@ var flankerData = Aircraft{
 
 var kc135Data = Aircraft{
 	tags: map[AircraftTag]bool{
-		FixedWing: true,
-		Unarmed:   true,
+		FixedWing:    true,
+		Unarmed:      true,
+		NonCombatant: true,
+		Tanker:       true,
 	},
 	PlatformDesignation: "KC-135",
 	OfficialName:        "Stratotanker",
@@ -599,12 +641,15 @@ var l39Data = Aircraft{
 }
 
 func l39Variants() []Aircraft {
-	return variants(
-		l39Data,
-		map[string]string{
-			"C":  "C",
-			"ZA": "ZA",
-		},
+	return withNonCombatants(
+		variants(
+			l39Data,
+			map[string]string{
+				"C":  "C",
+				"ZA": "ZA",
+			},
+		),
+		"L-39C",
 	)
 }
 
@@ -618,12 +663,15 @@ var mb339Data = Aircraft{
 }
 
 func mb339Variants() []Aircraft {
-	return variants(
-		mb339Data,
-		map[string]string{
-			"A":     "A",
-			"A/PAN": "A",
-		},
+	return withNonCombatants(
+		variants(
+			mb339Data,
+			map[string]string{
+				"A":     "A",
+				"A/PAN": "A",
+			},
+		),
+		"MB-339A/PAN",
 	)
 }
 
@@ -637,11 +685,19 @@ var s3Data = Aircraft{
 }
 
 func s3Variants() []Aircraft {
-	return variants(
-		s3Data,
-		map[string]string{
-			"B":        "B",
-			"B Tanker": "B",
+	return append(
+		variants(s3Data, map[string]string{"B": "B"}),
+		Aircraft{
+			ACMIShortName: "S-3B Tanker",
+			tags: map[AircraftTag]bool{
+				FixedWing:    true,
+				Unarmed:      true,
+				Tanker:       true,
+				NonCombatant: true,
+			},
+			PlatformDesignation: s3Data.PlatformDesignation,
+			TypeDesignation:     "S-3B",
+			OfficialName:        s3Data.OfficialName,
 		},
 	)
 }
@@ -692,8 +748,9 @@ var aircraftData = []Aircraft{
 	{
 		ACMIShortName: "A-50",
 		tags: map[AircraftTag]bool{
-			FixedWing: true,
-			Unarmed:   true,
+			FixedWing:    true,
+			Unarmed:      true,
+			NonCombatant: true,
 		},
 		PlatformDesignation: "A-50",
 		TypeDesignation:     "A-50",
@@ -733,8 +790,9 @@ var aircraftData = []Aircraft{
 	{
 		ACMIShortName: "An-26B",
 		tags: map[AircraftTag]bool{
-			FixedWing: true,
-			Unarmed:   true,
+			FixedWing:    true,
+			Unarmed:      true,
+			NonCombatant: true,
 		},
 		PlatformDesignation: "An-26",
 		TypeDesignation:     "An-26B",
@@ -743,8 +801,9 @@ var aircraftData = []Aircraft{
 	{
 		ACMIShortName: "An-30M",
 		tags: map[AircraftTag]bool{
-			FixedWing: true,
-			Unarmed:   true,
+			FixedWing:    true,
+			Unarmed:      true,
+			NonCombatant: true,
 		},
 		PlatformDesignation: "An-30",
 		TypeDesignation:     "An-30M",
@@ -785,8 +844,9 @@ var aircraftData = []Aircraft{
 	{
 		ACMIShortName: "C-17A",
 		tags: map[AircraftTag]bool{
-			FixedWing: true,
-			Unarmed:   true,
+			FixedWing:    true,
+			Unarmed:      true,
+			NonCombatant: true,
 		},
 		PlatformDesignation: "C-17",
 		TypeDesignation:     "C-17A",
@@ -795,8 +855,9 @@ var aircraftData = []Aircraft{
 	{
 		ACMIShortName: "C-47",
 		tags: map[AircraftTag]bool{
-			FixedWing: true,
-			Unarmed:   true,
+			FixedWing:    true,
+			Unarmed:      true,
+			NonCombatant: true,
 		},
 		PlatformDesignation: "C-47",
 		OfficialName:        "Skytrain",
@@ -804,8 +865,9 @@ var aircraftData = []Aircraft{
 	{
 		ACMIShortName: "C-130",
 		tags: map[AircraftTag]bool{
-			FixedWing: true,
-			Unarmed:   true,
+			FixedWing:    true,
+			Unarmed:      true,
+			NonCombatant: true,
 		},
 		PlatformDesignation: "C-130",
 		TypeDesignation:     "C-130",
@@ -815,8 +877,9 @@ var aircraftData = []Aircraft{
 	{
 		ACMIShortName: "CH-53E",
 		tags: map[AircraftTag]bool{
-			RotaryWing: true,
-			Unarmed:    true,
+			RotaryWing:   true,
+			Unarmed:      true,
+			NonCombatant: true,
 		},
 		PlatformDesignation: "CH-53",
 		TypeDesignation:     "CH-53E",
@@ -825,8 +888,9 @@ var aircraftData = []Aircraft{
 	{
 		ACMIShortName: "E-2C",
 		tags: map[AircraftTag]bool{
-			FixedWing: true,
-			Unarmed:   true,
+			FixedWing:    true,
+			Unarmed:      true,
+			NonCombatant: true,
 		},
 		PlatformDesignation: "E-2",
 		TypeDesignation:     "E-2C",
@@ -835,8 +899,9 @@ var aircraftData = []Aircraft{
 	{
 		ACMIShortName: "E-3A",
 		tags: map[AircraftTag]bool{
-			FixedWing: true,
-			Unarmed:   true,
+			FixedWing:    true,
+			Unarmed:      true,
+			NonCombatant: true,
 		},
 		PlatformDesignation: "E-3",
 		TypeDesignation:     "E-3A",
@@ -866,8 +931,9 @@ var aircraftData = []Aircraft{
 	{
 		ACMIShortName: "IL-76MD",
 		tags: map[AircraftTag]bool{
-			FixedWing: true,
-			Unarmed:   true,
+			FixedWing:    true,
+			Unarmed:      true,
+			NonCombatant: true,
 		},
 		PlatformDesignation: "Il-76",
 		TypeDesignation:     "Il-76MD",
@@ -876,8 +942,10 @@ var aircraftData = []Aircraft{
 	{
 		ACMIShortName: "IL-78M",
 		tags: map[AircraftTag]bool{
-			FixedWing: true,
-			Unarmed:   true,
+			FixedWing:    true,
+			Unarmed:      true,
+			NonCombatant: true,
+			Tanker:       true,
 		},
 		PlatformDesignation: "Il-78",
 		TypeDesignation:     "Il-78M",
@@ -915,8 +983,10 @@ var aircraftData = []Aircraft{
 	{
 		ACMIShortName: "KC130",
 		tags: map[AircraftTag]bool{
-			FixedWing: true,
-			Unarmed:   true,
+			FixedWing:    true,
+			Unarmed:      true,
+			NonCombatant: true,
+			Tanker:       true,
 		},
 		PlatformDesignation: "KC-130",
 		TypeDesignation:     "KC-130",
@@ -926,8 +996,9 @@ var aircraftData = []Aircraft{
 	{
 		ACMIShortName: "KJ-2000",
 		tags: map[AircraftTag]bool{
-			FixedWing: true,
-			Unarmed:   true,
+			FixedWing:    true,
+			Unarmed:      true,
+			NonCombatant: true,
 		},
 		PlatformDesignation: "KJ-2000",
 		TypeDesignation:     "KJ-2000",
@@ -957,8 +1028,9 @@ var aircraftData = []Aircraft{
 	{
 		ACMIShortName: "Mi-26",
 		tags: map[AircraftTag]bool{
-			RotaryWing: true,
-			Unarmed:    true,
+			RotaryWing:   true,
+			Unarmed:      true,
+			NonCombatant: true,
 		},
 		PlatformDesignation: "Mi-26",
 		TypeDesignation:     "Mi-26",
@@ -1194,8 +1266,9 @@ var aircraftData = []Aircraft{
 	{
 		ACMIShortName: "UH-60A",
 		tags: map[AircraftTag]bool{
-			RotaryWing: true,
-			Unarmed:    true,
+			RotaryWing:   true,
+			Unarmed:      true,
+			NonCombatant: true,
 		},
 		PlatformDesignation: "UH-60",
 		TypeDesignation:     "UH-60A",
@@ -1203,9 +1276,13 @@ var aircraftData = []Aircraft{
 	},
 }
 
-// aircraftDataLUT maps the name exported in ACMI data to aircraft data.
+// aircraftDataLUT maps the name exported in ACMI data to aircraft data. It is built from the built-in data and may be
+// updated by ApplyDataset.
 var aircraftDataLUT map[string]Aircraft
 
+// aircraftDataLock protects aircraftDataLUT.
+var aircraftDataLock sync.RWMutex
+
 func init() {
 	aircraftDataLUT = make(map[string]Aircraft)
 	for _, vars := range [][]Aircraft{
@@ -1245,10 +1322,13 @@ func init() {
 // GetAircraftData returns the aircraft data for the given name, if it exists.
 // The name should be the Name property of an ACMI object.
 // The second return value is false if the data does not exist.
+// Missing names are logged the first time they are looked up, and counted for MissingAircraft.
 func GetAircraftData(name string) (Aircraft, bool) {
+	aircraftDataLock.RLock()
 	data, ok := aircraftDataLUT[name]
+	aircraftDataLock.RUnlock()
 	if !ok {
-		log.Warn().Str("name", name).Msg("Aircraft missing from encyclopedia")
+		reportMissing(name)
 	}
 	return data, ok
 }
@@ -0,0 +1,42 @@
+package encyclopedia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCombatant(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		acmiName    string
+		isCombatant bool
+	}{
+		{acmiName: "Su-27", isCombatant: true},
+		{acmiName: "S-3B", isCombatant: true},
+		{acmiName: "S-3B Tanker", isCombatant: false},
+		{acmiName: "KC-135", isCombatant: false},
+		{acmiName: "C-101CC", isCombatant: true},
+		{acmiName: "C-101EB", isCombatant: false},
+		{acmiName: "L-39ZA", isCombatant: true},
+		{acmiName: "L-39C", isCombatant: false},
+		{acmiName: "MB-339A", isCombatant: true},
+		{acmiName: "MB-339A/PAN", isCombatant: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.acmiName, func(t *testing.T) {
+			t.Parallel()
+			aircraft, ok := GetAircraftData(test.acmiName)
+			require.True(t, ok)
+			assert.Equal(t, test.isCombatant, aircraft.IsCombatant())
+		})
+	}
+}
+
+func TestVariantsThreatRadius(t *testing.T) {
+	t.Parallel()
+	combatant, ok := GetAircraftData("L-39ZA")
+	require.True(t, ok)
+	assert.Equal(t, SAR1IRThreat, combatant.ThreatRadius())
+}
@@ -0,0 +1,156 @@
+package encyclopedia
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/martinlindhe/unit"
+	"gopkg.in/yaml.v3"
+)
+
+// Dataset is aircraft data generated for a version of DCS World, usually loaded from a YAML or JSON file with
+// LoadDataset. It supplements the built-in data, so that new modules and renamed ACMI names can be supported without
+// a new release of SkyEye.
+type Dataset struct {
+	// DCSVersion is the version of DCS World the dataset was generated from, e.g. "2.9.10.4160".
+	DCSVersion string `yaml:"dcs_version"`
+	// Aircraft are added to the encyclopedia. Each replaces any built-in aircraft with the same ACMI name.
+	Aircraft []DatasetAircraft `yaml:"aircraft"`
+	// Renames map ACMI names which were changed by a DCS patch.
+	Renames []Rename `yaml:"renames"`
+}
+
+// DatasetAircraft is the data for one aircraft in a Dataset. See Aircraft for descriptions of the fields.
+type DatasetAircraft struct {
+	ACMIName            string   `yaml:"acmi_name"`
+	Tags                []string `yaml:"tags"`
+	PlatformDesignation string   `yaml:"platform_designation"`
+	TypeDesignation     string   `yaml:"type_designation"`
+	NATOReportingName   string   `yaml:"nato_reporting_name"`
+	OfficialName        string   `yaml:"official_name"`
+	Nickname            string   `yaml:"nickname"`
+	// ThreatRadius in nautical miles. If zero, the threat radius is inferred from the tags.
+	ThreatRadius float64 `yaml:"threat_radius"`
+}
+
+// Rename records an aircraft whose ACMI name was changed by a DCS patch. Both names are recognized, so that telemetry
+// from servers on either side of the patch is understood.
+type Rename struct {
+	// Since is the first DCS version which uses the new name.
+	Since string `yaml:"since"`
+	// From is the ACMI name before the patch.
+	From string `yaml:"from"`
+	// To is the ACMI name after the patch.
+	To string `yaml:"to"`
+}
+
+// tagNames maps the names of tags in a dataset to aircraft tags.
+var tagNames = map[string]AircraftTag{
+	"fixed-wing":    FixedWing,
+	"rotary-wing":   RotaryWing,
+	"unarmed":       Unarmed,
+	"fighter":       Fighter,
+	"attack":        Attack,
+	"non-combatant": NonCombatant,
+	"tanker":        Tanker,
+}
+
+// parseTags converts the names of an aircraft's tags to aircraft tags. Every aircraft must be tagged either fixed-wing
+// or rotary-wing.
+func parseTags(acmiName string, names []string) (map[AircraftTag]bool, error) {
+	tags := make(map[AircraftTag]bool, len(names))
+	for _, name := range names {
+		tag, ok := tagNames[name]
+		if !ok {
+			return nil, fmt.Errorf("aircraft %q has unknown tag %q; must be one of %s", acmiName, name, strings.Join(slices.Sorted(maps.Keys(tagNames)), ", "))
+		}
+		tags[tag] = true
+	}
+	if !tags[FixedWing] && !tags[RotaryWing] {
+		return nil, fmt.Errorf("aircraft %q must be tagged fixed-wing or rotary-wing", acmiName)
+	}
+	return tags, nil
+}
+
+// LoadDataset reads and validates a dataset from a YAML or JSON file.
+func LoadDataset(path string) (*Dataset, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset: %w", err)
+	}
+	var dataset Dataset
+	if err := yaml.Unmarshal(b, &dataset); err != nil {
+		return nil, fmt.Errorf("failed to parse dataset: %w", err)
+	}
+	if _, err := dataset.aircraft(); err != nil {
+		return nil, err
+	}
+	return &dataset, nil
+}
+
+// aircraft converts the dataset's aircraft to encyclopedia entries.
+func (d *Dataset) aircraft() ([]Aircraft, error) {
+	entries := make([]Aircraft, 0, len(d.Aircraft))
+	for _, a := range d.Aircraft {
+		if a.ACMIName == "" {
+			return nil, errors.New("dataset aircraft is missing an ACMI name")
+		}
+		if a.PlatformDesignation == "" {
+			return nil, fmt.Errorf("dataset aircraft %q is missing a platform designation", a.ACMIName)
+		}
+		tags, err := parseTags(a.ACMIName, a.Tags)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, Aircraft{
+			ACMIShortName:       a.ACMIName,
+			tags:                tags,
+			PlatformDesignation: a.PlatformDesignation,
+			TypeDesignation:     a.TypeDesignation,
+			NATOReportingName:   a.NATOReportingName,
+			OfficialName:        a.OfficialName,
+			Nickname:            a.Nickname,
+			threatRadius:        unit.Length(a.ThreatRadius) * unit.NauticalMile,
+		})
+	}
+	return entries, nil
+}
+
+// ApplyDataset adds the given dataset's aircraft and renames to the encyclopedia. If the dataset is invalid, the
+// encyclopedia is not changed.
+func ApplyDataset(dataset *Dataset) error {
+	entries, err := dataset.aircraft()
+	if err != nil {
+		return err
+	}
+
+	aircraftDataLock.Lock()
+	defer aircraftDataLock.Unlock()
+	lut := maps.Clone(aircraftDataLUT)
+	for _, data := range entries {
+		lut[data.ACMIShortName] = data
+	}
+	for _, rename := range dataset.Renames {
+		if rename.From == "" || rename.To == "" {
+			return fmt.Errorf("rename since %q must have both a from and a to name", rename.Since)
+		}
+		// The dataset may be older or newer than the built-in data, so either name may be the known one.
+		if data, ok := lut[rename.To]; ok {
+			if _, ok := lut[rename.From]; !ok {
+				data.ACMIShortName = rename.From
+				lut[rename.From] = data
+			}
+		} else if data, ok := lut[rename.From]; ok {
+			data.ACMIShortName = rename.To
+			lut[rename.To] = data
+		} else {
+			return fmt.Errorf("rename from %q to %q since %q refers to an unknown aircraft", rename.From, rename.To, rename.Since)
+		}
+	}
+	aircraftDataLUT = lut
+	return nil
+}
@@ -0,0 +1,135 @@
+package encyclopedia
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// restoreAircraftData restores the built-in aircraft data when the test finishes. Tests which change the aircraft
+// data must not run in parallel, since other tests read the same data.
+func restoreAircraftData(t *testing.T) {
+	t.Helper()
+	aircraftDataLock.RLock()
+	lut := aircraftDataLUT
+	aircraftDataLock.RUnlock()
+	t.Cleanup(func() {
+		aircraftDataLock.Lock()
+		defer aircraftDataLock.Unlock()
+		aircraftDataLUT = lut
+	})
+}
+
+func TestLoadDataset(t *testing.T) {
+	restoreAircraftData(t)
+	path := filepath.Join(t.TempDir(), "aircraft.yaml")
+	data := `
+dcs_version: 2.9.10.4160
+aircraft:
+  - acmi_name: TEST-1A
+    platform_designation: TEST-1
+    type_designation: TEST-1A
+    official_name: Tester
+    tags: [fixed-wing, fighter]
+    threat_radius: 20
+renames:
+  - since: 2.9.10.4160
+    from: F-15C
+    to: TEST-F-15C
+  - since: 2.9.10.4160
+    from: TEST-1
+    to: TEST-1A
+`
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	dataset, err := LoadDataset(path)
+	require.NoError(t, err)
+	assert.Equal(t, "2.9.10.4160", dataset.DCSVersion)
+	require.NoError(t, ApplyDataset(dataset))
+
+	aircraft, ok := GetAircraftData("TEST-1A")
+	require.True(t, ok)
+	assert.Equal(t, "TEST-1", aircraft.PlatformDesignation)
+	assert.Equal(t, "Tester", aircraft.OfficialName)
+	assert.Equal(t, brevity.FixedWing, aircraft.Category())
+	assert.True(t, aircraft.HasTag(Fighter))
+	assert.InDelta(t, 20, aircraft.ThreatRadius().NauticalMiles(), 0.01)
+
+	// Both the old and new names of renamed aircraft are recognized.
+	renamed, ok := GetAircraftData("TEST-F-15C")
+	require.True(t, ok)
+	assert.Equal(t, "F-15", renamed.PlatformDesignation)
+	assert.Equal(t, "TEST-F-15C", renamed.ACMIShortName)
+	_, ok = GetAircraftData("F-15C")
+	assert.True(t, ok)
+	renamed, ok = GetAircraftData("TEST-1")
+	require.True(t, ok)
+	assert.Equal(t, "TEST-1", renamed.PlatformDesignation)
+}
+
+func TestDatasetInvalid(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name    string
+		dataset Dataset
+	}{
+		{
+			name:    "missing ACMI name",
+			dataset: Dataset{Aircraft: []DatasetAircraft{{PlatformDesignation: "TEST-2", Tags: []string{"fixed-wing"}}}},
+		},
+		{
+			name:    "missing platform designation",
+			dataset: Dataset{Aircraft: []DatasetAircraft{{ACMIName: "TEST-2", Tags: []string{"fixed-wing"}}}},
+		},
+		{
+			name:    "unknown tag",
+			dataset: Dataset{Aircraft: []DatasetAircraft{{ACMIName: "TEST-2", PlatformDesignation: "TEST-2", Tags: []string{"fixed-wing", "bomber"}}}},
+		},
+		{
+			name:    "missing category",
+			dataset: Dataset{Aircraft: []DatasetAircraft{{ACMIName: "TEST-2", PlatformDesignation: "TEST-2", Tags: []string{"fighter"}}}},
+		},
+		{
+			name: "unknown rename",
+			dataset: Dataset{
+				Aircraft: []DatasetAircraft{{ACMIName: "TEST-2", PlatformDesignation: "TEST-2", Tags: []string{"fixed-wing"}}},
+				Renames:  []Rename{{Since: "2.9.10.4160", From: "TEST-3", To: "TEST-4"}},
+			},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			require.Error(t, ApplyDataset(&test.dataset))
+			// An invalid dataset does not change the encyclopedia.
+			_, ok := GetAircraftData("TEST-2")
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestMissingAircraft(t *testing.T) {
+	t.Parallel()
+	// The counts are global, so compare against the count before this run.
+	before := MissingAircraft()["TEST-MISSING"]
+	for range 3 {
+		_, ok := GetAircraftData("TEST-MISSING")
+		assert.False(t, ok)
+	}
+	assert.Equal(t, before+3, MissingAircraft()["TEST-MISSING"])
+	_, ok := MissingAircraft()["F-15C"]
+	assert.False(t, ok)
+}
+
+func TestDatasetThreatRadiusDefault(t *testing.T) {
+	t.Parallel()
+	entries, err := (&Dataset{Aircraft: []DatasetAircraft{{ACMIName: "TEST-5", PlatformDesignation: "TEST-5", Tags: []string{"fixed-wing", "attack"}}}}).aircraft()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, SAR1IRThreat, entries[0].ThreatRadius())
+	assert.Equal(t, unit.Length(0), entries[0].threatRadius)
+}
@@ -0,0 +1,31 @@
+package encyclopedia
+
+import (
+	"expvar"
+
+	"github.com/rs/zerolog/log"
+)
+
+// missingAircraft counts lookups of ACMI names which are missing from the encyclopedia. It is published as the
+// "missing_aircraft" expvar.
+var missingAircraft = expvar.NewMap("missing_aircraft")
+
+// reportMissing counts a lookup of a missing ACMI name, and logs a warning the first time the name is seen.
+func reportMissing(name string) {
+	if missingAircraft.Get(name) == nil {
+		log.Warn().Str("name", name).Msg("aircraft missing from encyclopedia")
+	}
+	missingAircraft.Add(name, 1)
+}
+
+// MissingAircraft returns the ACMI names which were looked up but are missing from the encyclopedia, mapped to the
+// number of times each was looked up. Add these aircraft to a dataset to support them.
+func MissingAircraft() map[string]int64 {
+	missing := make(map[string]int64)
+	missingAircraft.Do(func(kv expvar.KeyValue) {
+		if count, ok := kv.Value.(*expvar.Int); ok {
+			missing[kv.Key] = count.Value()
+		}
+	})
+	return missing
+}
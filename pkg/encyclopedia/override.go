@@ -0,0 +1,77 @@
+package encyclopedia
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/martinlindhe/unit"
+)
+
+// Override changes how a known aircraft is classified, so that admins can adjust the built-in data or a dataset for
+// their server without maintaining their own dataset. For example, a training server might treat an L-39 as an
+// unarmed trainer.
+type Override struct {
+	// ACMIName is the ACMI name of the aircraft to override.
+	ACMIName string
+	// Tags replace the aircraft's tags. If empty, the aircraft's tags are not changed.
+	Tags []string
+	// ThreatRadius in nautical miles. If zero, the aircraft's threat radius is not changed.
+	ThreatRadius float64
+}
+
+// ParseOverride parses an override in the form ACMI_NAME:TAGS or ACMI_NAME:TAGS:THREAT_RADIUS, where TAGS is a
+// list of tags separated by "+" (e.g. "L-39C:fixed-wing+unarmed" or "C-101CC:fixed-wing+fighter:5"). TAGS may be
+// empty to change only the threat radius (e.g. "L-39C::25").
+func ParseOverride(s string) (Override, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+		return Override{}, fmt.Errorf("aircraft override %q must be in the form ACMI_NAME:TAGS or ACMI_NAME:TAGS:THREAT_RADIUS", s)
+	}
+	override := Override{ACMIName: parts[0]}
+	if parts[1] != "" {
+		override.Tags = strings.Split(parts[1], "+")
+		if _, err := parseTags(override.ACMIName, override.Tags); err != nil {
+			return Override{}, err
+		}
+	}
+	if len(parts) == 3 {
+		radius, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil {
+			return Override{}, fmt.Errorf("failed to parse threat radius of aircraft override %q: %w", s, err)
+		}
+		if radius < 0 {
+			return Override{}, fmt.Errorf("threat radius of aircraft override %q must not be negative", s)
+		}
+		override.ThreatRadius = radius
+	}
+	return override, nil
+}
+
+// ApplyOverrides changes the classification of the given aircraft in the encyclopedia. Overrides should be applied
+// after any dataset, so that they take precedence. If any override is invalid, the encyclopedia is not changed.
+func ApplyOverrides(overrides []Override) error {
+	aircraftDataLock.Lock()
+	defer aircraftDataLock.Unlock()
+	lut := maps.Clone(aircraftDataLUT)
+	for _, override := range overrides {
+		data, ok := lut[override.ACMIName]
+		if !ok {
+			return fmt.Errorf("aircraft override refers to unknown aircraft %q", override.ACMIName)
+		}
+		if len(override.Tags) > 0 {
+			tags, err := parseTags(override.ACMIName, override.Tags)
+			if err != nil {
+				return err
+			}
+			data.tags = tags
+		}
+		if override.ThreatRadius != 0 {
+			data.threatRadius = unit.Length(override.ThreatRadius) * unit.NauticalMile
+		}
+		lut[override.ACMIName] = data
+	}
+	aircraftDataLUT = lut
+	return nil
+}
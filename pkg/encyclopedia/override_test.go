@@ -0,0 +1,80 @@
+package encyclopedia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOverride(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		input    string
+		expected Override
+		ok       bool
+	}{
+		{input: "L-39C:fixed-wing+unarmed", expected: Override{ACMIName: "L-39C", Tags: []string{"fixed-wing", "unarmed"}}, ok: true},
+		{input: "C-101CC:fixed-wing+fighter:5", expected: Override{ACMIName: "C-101CC", Tags: []string{"fixed-wing", "fighter"}, ThreatRadius: 5}, ok: true},
+		{input: "L-39C::25", expected: Override{ACMIName: "L-39C", ThreatRadius: 25}, ok: true},
+		{input: "L-39C", ok: false},
+		{input: ":fixed-wing", ok: false},
+		{input: "L-39C:fixed-wing+bomber", ok: false},
+		{input: "L-39C:fighter", ok: false},
+		{input: "L-39C:fixed-wing:far", ok: false},
+		{input: "L-39C:fixed-wing:-5", ok: false},
+		{input: "L-39C:fixed-wing:5:5", ok: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			actual, err := ParseOverride(test.input)
+			if !test.ok {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	restoreAircraftData(t)
+	require.NoError(t, ApplyDataset(&Dataset{Aircraft: []DatasetAircraft{
+		{ACMIName: "TEST-OVERRIDE-1", PlatformDesignation: "TEST-OVERRIDE", Tags: []string{"fixed-wing", "unarmed"}},
+		{ACMIName: "TEST-OVERRIDE-2", PlatformDesignation: "TEST-OVERRIDE", Tags: []string{"fixed-wing", "fighter"}, ThreatRadius: 35},
+		{ACMIName: "TEST-OVERRIDE-3", PlatformDesignation: "TEST-OVERRIDE", Tags: []string{"fixed-wing", "fighter"}, ThreatRadius: 35},
+	}}))
+
+	require.NoError(t, ApplyOverrides([]Override{
+		{ACMIName: "TEST-OVERRIDE-1", Tags: []string{"fixed-wing", "fighter"}},
+		{ACMIName: "TEST-OVERRIDE-2", ThreatRadius: 10},
+		{ACMIName: "TEST-OVERRIDE-3", Tags: []string{"fixed-wing", "attack"}},
+	}))
+
+	aircraft, ok := GetAircraftData("TEST-OVERRIDE-1")
+	require.True(t, ok)
+	assert.True(t, aircraft.HasTag(Fighter))
+	assert.False(t, aircraft.HasTag(Unarmed))
+	assert.Equal(t, SAR2AR1Threat, aircraft.ThreatRadius())
+	assert.Equal(t, "TEST-OVERRIDE", aircraft.PlatformDesignation)
+
+	aircraft, ok = GetAircraftData("TEST-OVERRIDE-2")
+	require.True(t, ok)
+	assert.True(t, aircraft.HasTag(Fighter))
+	assert.InDelta(t, 10, aircraft.ThreatRadius().NauticalMiles(), 0.01)
+
+	// Overriding only the tags keeps the threat radius.
+	aircraft, ok = GetAircraftData("TEST-OVERRIDE-3")
+	require.True(t, ok)
+	assert.True(t, aircraft.HasTag(Attack))
+	assert.InDelta(t, 35, aircraft.ThreatRadius().NauticalMiles(), 0.01)
+}
+
+func TestApplyOverridesUnknownAircraft(t *testing.T) {
+	t.Parallel()
+	require.Error(t, ApplyOverrides([]Override{{ACMIName: "TEST-OVERRIDE-MISSING", Tags: []string{"fixed-wing"}}}))
+	_, ok := GetAircraftData("TEST-OVERRIDE-MISSING")
+	assert.False(t, ok)
+}
@@ -0,0 +1,66 @@
+package encyclopedia
+
+import "github.com/martinlindhe/unit"
+
+// Data sources:
+// https://github.com/Quaggles/dcs-lua-datamine/tree/master/_G/db/Units/Cars/Car
+
+// SAM is a surface-to-air missile or gun system.
+type SAM struct {
+	// ACMIName is the Name property of the system's launcher in ACMI telemetry.
+	ACMIName string
+	// System is the common name of the system.
+	// e.g. SA-10, Patriot
+	System string
+	// ThreatRadius is the approximate maximum engagement range of the system against a high altitude target. This is
+	// the radius of the Missile Engagement Zone (MEZ) ring drawn around the launcher.
+	ThreatRadius unit.Length
+	// Ceiling is the approximate highest altitude the system can engage, for guns and short range systems which
+	// cannot reach aircraft at medium altitude. Zero if the system can reach any altitude.
+	Ceiling unit.Length
+}
+
+// samData lists the launchers of SAM and AAA systems. Only the launcher (or the combined launcher and radar vehicle of
+// self-contained systems) is listed, so that each site is counted once.
+var samData = []SAM{
+	{ACMIName: "S_75M_Volhov", System: "SA-2", ThreatRadius: 23 * unit.NauticalMile},
+	{ACMIName: "5p73 s-125 ln", System: "SA-3", ThreatRadius: 10 * unit.NauticalMile},
+	{ACMIName: "Kub 2P25 ln", System: "SA-6", ThreatRadius: 13 * unit.NauticalMile},
+	{ACMIName: "Osa 9A33 ln", System: "SA-8", ThreatRadius: 5 * unit.NauticalMile, Ceiling: 16000 * unit.Foot},
+	{ACMIName: "Strela-1 9P31", System: "SA-9", ThreatRadius: 2 * unit.NauticalMile, Ceiling: 11000 * unit.Foot},
+	{ACMIName: "S-300PS 5P85C ln", System: "SA-10", ThreatRadius: 40 * unit.NauticalMile},
+	{ACMIName: "S-300PS 5P85D ln", System: "SA-10", ThreatRadius: 40 * unit.NauticalMile},
+	{ACMIName: "SA-11 Buk LN 9A310M1", System: "SA-11", ThreatRadius: 19 * unit.NauticalMile},
+	{ACMIName: "Strela-10M3", System: "SA-13", ThreatRadius: 3 * unit.NauticalMile, Ceiling: 11000 * unit.Foot},
+	{ACMIName: "Tor 9A331", System: "SA-15", ThreatRadius: 6 * unit.NauticalMile, Ceiling: 20000 * unit.Foot},
+	{ACMIName: "2S6 Tunguska", System: "SA-19", ThreatRadius: 4 * unit.NauticalMile, Ceiling: 11000 * unit.Foot},
+	{ACMIName: "HQ-7_LN_SP", System: "HQ-7", ThreatRadius: 6 * unit.NauticalMile, Ceiling: 18000 * unit.Foot},
+	{ACMIName: "Patriot ln", System: "Patriot", ThreatRadius: 54 * unit.NauticalMile},
+	{ACMIName: "Hawk ln", System: "Hawk", ThreatRadius: 24 * unit.NauticalMile},
+	{ACMIName: "NASAMS_LN_B", System: "NASAMS", ThreatRadius: 13 * unit.NauticalMile},
+	{ACMIName: "NASAMS_LN_C", System: "NASAMS", ThreatRadius: 13 * unit.NauticalMile},
+	{ACMIName: "rapier_fsa_launcher", System: "Rapier", ThreatRadius: 4 * unit.NauticalMile, Ceiling: 10000 * unit.Foot},
+	{ACMIName: "Roland ADS", System: "Roland", ThreatRadius: 4 * unit.NauticalMile, Ceiling: 18000 * unit.Foot},
+	{ACMIName: "M48 Chaparral", System: "Chaparral", ThreatRadius: 4 * unit.NauticalMile, Ceiling: 10000 * unit.Foot},
+	{ACMIName: "M1097 Avenger", System: "Avenger", ThreatRadius: 2 * unit.NauticalMile, Ceiling: 12000 * unit.Foot},
+	{ACMIName: "ZSU-23-4 Shilka", System: "Shilka", ThreatRadius: 1.5 * unit.NauticalMile, Ceiling: 10000 * unit.Foot},
+	{ACMIName: "Gepard", System: "Gepard", ThreatRadius: 2 * unit.NauticalMile, Ceiling: 12000 * unit.Foot},
+	{ACMIName: "Vulcan", System: "Vulcan", ThreatRadius: 1 * unit.NauticalMile, Ceiling: 8000 * unit.Foot},
+	{ACMIName: "ZU-23 Emplacement", System: "ZU-23", ThreatRadius: 1 * unit.NauticalMile, Ceiling: 8000 * unit.Foot},
+}
+
+// samsByACMIName indexes samData by ACMI name.
+var samsByACMIName = func() map[string]SAM {
+	index := make(map[string]SAM, len(samData))
+	for _, sam := range samData {
+		index[sam.ACMIName] = sam
+	}
+	return index
+}()
+
+// GetSAMData returns the SAM system whose launcher has the given ACMI name. The second return value is false if the
+// unit is not a known SAM launcher.
+func GetSAMData(acmiName string) (SAM, bool) {
+	sam, ok := samsByACMIName[acmiName]
+	return sam, ok
+}
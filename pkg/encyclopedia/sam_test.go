@@ -0,0 +1,30 @@
+package encyclopedia
+
+import (
+	"testing"
+
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSAMData(t *testing.T) {
+	t.Parallel()
+	sam, ok := GetSAMData("SA-11 Buk LN 9A310M1")
+	require.True(t, ok)
+	assert.Equal(t, "SA-11", sam.System)
+	assert.InDelta(t, 19, sam.ThreatRadius.NauticalMiles(), 0.1)
+	assert.Zero(t, sam.Ceiling)
+
+	sam, ok = GetSAMData("ZSU-23-4 Shilka")
+	require.True(t, ok)
+	assert.Positive(t, sam.Ceiling)
+
+	_, ok = GetSAMData("SA-11 Buk SR 9S18M1")
+	assert.False(t, ok, "search radars are not launchers")
+
+	for _, sam := range samData {
+		assert.Positive(t, sam.ThreatRadius, sam.ACMIName)
+		assert.Less(t, sam.ThreatRadius, 100*unit.NauticalMile, sam.ACMIName)
+	}
+}
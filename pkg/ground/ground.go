@@ -0,0 +1,249 @@
+// package ground maintains a picture of the ground forces in the simulation, for supporting close air support.
+package ground
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/encyclopedia"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/tacview/tags"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+// Kind is a broad category of ground unit, as it is described on the radio.
+type Kind string
+
+const (
+	Armor      Kind = "armor"
+	AirDefense Kind = "air defense"
+	Infantry   Kind = "infantry"
+	Vehicles   Kind = "vehicles"
+	Ships      Kind = "ships"
+)
+
+// kindOf categorizes a unit by its ACMI type tags. Ships are always ships, even though most warships also carry air
+// defenses. Otherwise, air defense takes precedence, since it matters most to aircraft.
+func kindOf(groundUnit sim.GroundUnit) Kind {
+	switch {
+	case slices.Contains(groundUnit.Types, tags.Sea), slices.Contains(groundUnit.Types, tags.Watercraft):
+		return Ships
+	case slices.Contains(groundUnit.Types, tags.AntiAircraft):
+		return AirDefense
+	case slices.Contains(groundUnit.Types, tags.Armor), slices.Contains(groundUnit.Types, tags.Tank):
+		return Armor
+	case slices.Contains(groundUnit.Types, tags.Infantry), slices.Contains(groundUnit.Types, tags.Human):
+		return Infantry
+	default:
+		return Vehicles
+	}
+}
+
+// Group is a group of ground units.
+type Group struct {
+	// Name of the group in the mission. Units which are not part of a named group form their own group.
+	Name string
+	// Coalition the group belongs to.
+	Coalition coalitions.Coalition
+	// Point is the center of the group.
+	Point orb.Point
+	// Units is the number of units in the group.
+	Units int
+	// Kinds of units in the group, ordered from most to least important to aircraft.
+	Kinds []Kind
+	// Platforms are the unit types in the group, from most to least numerous. SAM launchers are described by their SAM
+	// system rather than the launcher's unit type.
+	Platforms []string
+}
+
+// IsMaritime returns true if the group is made up of ships.
+func (g Group) IsMaritime() bool {
+	return slices.Contains(g.Kinds, Ships)
+}
+
+// IsThreat returns true if the group is a threat to aircraft: ships, or groups with air defenses.
+func (g Group) IsThreat() bool {
+	return g.IsMaritime() || slices.Contains(g.Kinds, AirDefense)
+}
+
+// SAMSite is the launchers of one SAM system within a group.
+type SAMSite struct {
+	// Group is the name of the group in the mission. Launchers which are not part of a named group form their own site.
+	Group string
+	// Coalition the site belongs to.
+	Coalition coalitions.Coalition
+	// Point is the center of the site's launchers.
+	Point orb.Point
+	// Launchers is the number of launchers at the site.
+	Launchers int
+	// SAM is the SAM system.
+	SAM encyclopedia.SAM
+}
+
+// Picture is a thread-safe picture of the ground forces in the simulation.
+type Picture struct {
+	// minUnits is the fewest units a group must have to be included in the picture. This filters out clutter such as
+	// lone trucks and individual soldiers. Threat groups are exempt, since a lone ship or launcher is still a threat.
+	minUnits int
+	groups   []Group
+	// samSites are not subject to the clutter filter, since a single launcher is still a threat.
+	samSites []SAMSite
+	lock     sync.RWMutex
+}
+
+// New creates an empty picture. Groups with fewer than minUnits units are filtered out, unless they are threats to
+// aircraft.
+func New(minUnits int) *Picture {
+	return &Picture{minUnits: max(1, minUnits)}
+}
+
+// Update replaces the picture with the given ground units.
+func (p *Picture) Update(units []sim.GroundUnit) {
+	type accumulator struct {
+		group     Group
+		lon, lat  float64
+		kinds     map[Kind]bool
+		platforms map[string]int
+	}
+	accumulators := make(map[string]*accumulator)
+	for _, groundUnit := range units {
+		key := fmt.Sprintf("%s/%s", groundUnit.Coalition, groundUnit.Group)
+		if groundUnit.Group == "" {
+			key = fmt.Sprintf("%s/#%d", groundUnit.Coalition, groundUnit.ID)
+		}
+		acc, ok := accumulators[key]
+		if !ok {
+			acc = &accumulator{
+				group:     Group{Name: groundUnit.Group, Coalition: groundUnit.Coalition},
+				kinds:     make(map[Kind]bool),
+				platforms: make(map[string]int),
+			}
+			accumulators[key] = acc
+		}
+		acc.group.Units++
+		acc.lon += groundUnit.Point.Lon()
+		acc.lat += groundUnit.Point.Lat()
+		acc.kinds[kindOf(groundUnit)] = true
+		if platform := platformOf(groundUnit); platform != "" {
+			acc.platforms[platform]++
+		}
+	}
+
+	type samAccumulator struct {
+		site     SAMSite
+		lon, lat float64
+	}
+	samAccumulators := make(map[string]*samAccumulator)
+	for _, groundUnit := range units {
+		sam, ok := encyclopedia.GetSAMData(groundUnit.Name)
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("%s/%s/%s", groundUnit.Coalition, groundUnit.Group, sam.System)
+		if groundUnit.Group == "" {
+			key = fmt.Sprintf("%s/#%d", groundUnit.Coalition, groundUnit.ID)
+		}
+		acc, ok := samAccumulators[key]
+		if !ok {
+			acc = &samAccumulator{site: SAMSite{Group: groundUnit.Group, Coalition: groundUnit.Coalition, SAM: sam}}
+			samAccumulators[key] = acc
+		}
+		acc.site.Launchers++
+		acc.lon += groundUnit.Point.Lon()
+		acc.lat += groundUnit.Point.Lat()
+	}
+	samSites := make([]SAMSite, 0, len(samAccumulators))
+	for _, acc := range samAccumulators {
+		n := float64(acc.site.Launchers)
+		acc.site.Point = orb.Point{acc.lon / n, acc.lat / n}
+		samSites = append(samSites, acc.site)
+	}
+
+	groups := make([]Group, 0, len(accumulators))
+	for _, acc := range accumulators {
+		for _, kind := range []Kind{Ships, AirDefense, Armor, Infantry, Vehicles} {
+			if acc.kinds[kind] {
+				acc.group.Kinds = append(acc.group.Kinds, kind)
+			}
+		}
+		if acc.group.Units < p.minUnits && !acc.group.IsThreat() {
+			continue
+		}
+		n := float64(acc.group.Units)
+		acc.group.Point = orb.Point{acc.lon / n, acc.lat / n}
+		for platform := range acc.platforms {
+			acc.group.Platforms = append(acc.group.Platforms, platform)
+		}
+		slices.SortFunc(acc.group.Platforms, func(a, b string) int {
+			return cmp.Or(cmp.Compare(acc.platforms[b], acc.platforms[a]), cmp.Compare(a, b))
+		})
+		groups = append(groups, acc.group)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.groups = groups
+	p.samSites = samSites
+}
+
+// SAMSites returns every SAM site in the picture.
+func (p *Picture) SAMSites() []SAMSite {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return slices.Clone(p.samSites)
+}
+
+// Nearest returns the land group of the given coalition nearest to the origin, within the given radius. Ships are
+// excluded. The second return value is false if there is no such group.
+func (p *Picture) Nearest(origin orb.Point, coalition coalitions.Coalition, radius unit.Length) (Group, bool) {
+	return p.nearest(origin, coalition, radius, func(group Group) bool { return !group.IsMaritime() })
+}
+
+// NearestThreat returns the group of ships or air defenses of the given coalition nearest to the origin, within the
+// given radius. The second return value is false if there is no such group.
+func (p *Picture) NearestThreat(origin orb.Point, coalition coalitions.Coalition, radius unit.Length) (Group, bool) {
+	return p.nearest(origin, coalition, radius, Group.IsThreat)
+}
+
+// nearest returns the group of the given coalition nearest to the origin, within the given radius, for which include
+// returns true.
+func (p *Picture) nearest(origin orb.Point, coalition coalitions.Coalition, radius unit.Length, include func(Group) bool) (Group, bool) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	var nearest Group
+	var nearestDistance unit.Length
+	isFound := false
+	for _, group := range p.groups {
+		if group.Coalition != coalition || !include(group) {
+			continue
+		}
+		distance := spatial.Distance(origin, group.Point)
+		if distance > radius {
+			continue
+		}
+		if !isFound || distance < nearestDistance {
+			nearest = group
+			nearestDistance = distance
+			isFound = true
+		}
+	}
+	if isFound {
+		nearest.Kinds = slices.Clone(nearest.Kinds)
+		nearest.Platforms = slices.Clone(nearest.Platforms)
+	}
+	return nearest, isFound
+}
+
+// platformOf returns the platform a unit is described as on the radio: the SAM system for SAM launchers, otherwise
+// the unit type.
+func platformOf(groundUnit sim.GroundUnit) string {
+	if sam, ok := encyclopedia.GetSAMData(groundUnit.Name); ok {
+		return sam.System
+	}
+	return groundUnit.Name
+}
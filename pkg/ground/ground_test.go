@@ -0,0 +1,162 @@
+package ground
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/tacview/tags"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPictureNearest(t *testing.T) {
+	t.Parallel()
+	tank := []string{tags.Ground, tags.Heavy, tags.Armor, tags.Vehicle, tags.Tank}
+	sam := []string{tags.Ground, tags.AntiAircraft, tags.Vehicle}
+	soldier := []string{tags.Ground, tags.Light, tags.Human, tags.Infantry}
+	truck := []string{tags.Ground, tags.Vehicle}
+
+	picture := New(2)
+	picture.Update([]sim.GroundUnit{
+		{ID: 1, Group: "Armor-1", Coalition: coalitions.Red, Point: orb.Point{41.00, 42.00}, Types: tank},
+		{ID: 2, Group: "Armor-1", Coalition: coalitions.Red, Point: orb.Point{41.02, 42.00}, Types: tank},
+		{ID: 3, Group: "Armor-1", Coalition: coalitions.Red, Point: orb.Point{41.01, 42.01}, Types: sam},
+		{ID: 4, Group: "Infantry-1", Coalition: coalitions.Red, Point: orb.Point{41.50, 42.00}, Types: soldier},
+		{ID: 5, Group: "Infantry-1", Coalition: coalitions.Red, Point: orb.Point{41.50, 42.00}, Types: soldier},
+		// A lone truck is filtered out as clutter even though it is nearest.
+		{ID: 6, Group: "Truck-1", Coalition: coalitions.Red, Point: orb.Point{40.90, 42.00}, Types: truck},
+		// Units without a group name do not clump together.
+		{ID: 7, Coalition: coalitions.Red, Point: orb.Point{40.91, 42.00}, Types: truck},
+		{ID: 8, Coalition: coalitions.Red, Point: orb.Point{40.91, 42.00}, Types: truck},
+		{ID: 9, Group: "Armor-2", Coalition: coalitions.Blue, Point: orb.Point{40.80, 42.00}, Types: tank},
+		{ID: 10, Group: "Armor-2", Coalition: coalitions.Blue, Point: orb.Point{40.80, 42.00}, Types: tank},
+	})
+
+	origin := orb.Point{40.80, 42.00}
+	group, ok := picture.Nearest(origin, coalitions.Red, 50*unit.NauticalMile)
+	require.True(t, ok)
+	assert.Equal(t, "Armor-1", group.Name)
+	assert.Equal(t, 3, group.Units)
+	assert.Equal(t, []Kind{AirDefense, Armor}, group.Kinds)
+	assert.InDelta(t, 41.01, group.Point.Lon(), 0.0001)
+	assert.InDelta(t, 42.0033, group.Point.Lat(), 0.0001)
+
+	group, ok = picture.Nearest(origin, coalitions.Blue, 50*unit.NauticalMile)
+	require.True(t, ok)
+	assert.Equal(t, "Armor-2", group.Name)
+
+	_, ok = picture.Nearest(origin, coalitions.Red, 5*unit.NauticalMile)
+	assert.False(t, ok)
+
+	picture.Update(nil)
+	_, ok = picture.Nearest(origin, coalitions.Red, 50*unit.NauticalMile)
+	assert.False(t, ok)
+}
+
+func TestPictureSAMSites(t *testing.T) {
+	t.Parallel()
+	launcher := []string{tags.Ground, tags.AntiAircraft, tags.Vehicle}
+	picture := New(2)
+	picture.Update([]sim.GroundUnit{
+		{ID: 1, Name: "SA-11 Buk LN 9A310M1", Group: "SAM-1", Coalition: coalitions.Red, Point: orb.Point{41.00, 42.00}, Types: launcher},
+		{ID: 2, Name: "SA-11 Buk LN 9A310M1", Group: "SAM-1", Coalition: coalitions.Red, Point: orb.Point{41.02, 42.00}, Types: launcher},
+		{ID: 3, Name: "SA-11 Buk SR 9S18M1", Group: "SAM-1", Coalition: coalitions.Red, Point: orb.Point{41.01, 42.01}, Types: launcher},
+		// A lone launcher is still a threat, so it is not filtered out as clutter.
+		{ID: 4, Name: "Strela-10M3", Group: "SHORAD-1", Coalition: coalitions.Red, Point: orb.Point{41.50, 42.00}, Types: launcher},
+		{ID: 5, Name: "T-72B", Group: "Armor-1", Coalition: coalitions.Red, Point: orb.Point{41.50, 42.00}, Types: []string{tags.Ground, tags.Armor}},
+	})
+	sites := picture.SAMSites()
+	require.Len(t, sites, 2)
+	slices.SortFunc(sites, func(a, b SAMSite) int { return cmp.Compare(a.Group, b.Group) })
+	assert.Equal(t, "SAM-1", sites[0].Group)
+	assert.Equal(t, "SA-11", sites[0].SAM.System)
+	assert.Equal(t, 2, sites[0].Launchers)
+	assert.InDelta(t, 41.01, sites[0].Point.Lon(), 0.0001)
+	assert.Equal(t, "SHORAD-1", sites[1].Group)
+	assert.Equal(t, "SA-13", sites[1].SAM.System)
+}
+
+func TestPictureNearestThreat(t *testing.T) {
+	t.Parallel()
+	ship := []string{tags.Sea, tags.Watercraft, tags.Warship}
+	launcher := []string{tags.Ground, tags.AntiAircraft, tags.Vehicle}
+	tank := []string{tags.Ground, tags.Heavy, tags.Armor, tags.Vehicle, tags.Tank}
+	picture := New(2)
+	picture.Update([]sim.GroundUnit{
+		// Armor is nearest, but is not a threat to aircraft.
+		{ID: 1, Name: "T-72B", Group: "Armor-1", Coalition: coalitions.Red, Point: orb.Point{40.90, 42.00}, Types: tank},
+		{ID: 2, Name: "T-72B", Group: "Armor-1", Coalition: coalitions.Red, Point: orb.Point{40.90, 42.00}, Types: tank},
+		{ID: 3, Name: "SA-11 Buk LN 9A310M1", Group: "SAM-1", Coalition: coalitions.Red, Point: orb.Point{41.20, 42.00}, Types: launcher},
+		{ID: 4, Name: "SA-11 Buk LN 9A310M1", Group: "SAM-1", Coalition: coalitions.Red, Point: orb.Point{41.20, 42.00}, Types: launcher},
+		{ID: 5, Name: "Ural-375", Group: "SAM-1", Coalition: coalitions.Red, Point: orb.Point{41.20, 42.00}, Types: []string{tags.Ground, tags.Vehicle}},
+		{ID: 6, Name: "MOSCOW", Group: "Fleet-1", Coalition: coalitions.Red, Point: orb.Point{41.00, 42.00}, Types: ship},
+		{ID: 7, Name: "NEUSTRASH", Group: "Fleet-1", Coalition: coalitions.Red, Point: orb.Point{41.00, 42.00}, Types: ship},
+	})
+
+	origin := orb.Point{40.80, 42.00}
+	group, ok := picture.NearestThreat(origin, coalitions.Red, 50*unit.NauticalMile)
+	require.True(t, ok)
+	assert.Equal(t, "Fleet-1", group.Name)
+	assert.True(t, group.IsMaritime())
+	assert.Equal(t, []Kind{Ships}, group.Kinds)
+	assert.Equal(t, []string{"MOSCOW", "NEUSTRASH"}, group.Platforms)
+
+	// Ships are not ground forces.
+	group, ok = picture.Nearest(origin, coalitions.Red, 50*unit.NauticalMile)
+	require.True(t, ok)
+	assert.Equal(t, "Armor-1", group.Name)
+
+	group, ok = picture.NearestThreat(origin, coalitions.Red, 15*unit.NauticalMile)
+	require.True(t, ok)
+	assert.Equal(t, "Fleet-1", group.Name)
+	_, ok = picture.NearestThreat(origin, coalitions.Red, 5*unit.NauticalMile)
+	assert.False(t, ok)
+}
+
+func TestPictureLoneThreats(t *testing.T) {
+	t.Parallel()
+	ship := []string{tags.Sea, tags.Watercraft, tags.Warship}
+	launcher := []string{tags.Ground, tags.AntiAircraft, tags.Vehicle}
+	truck := []string{tags.Ground, tags.Vehicle}
+	picture := New(3)
+	picture.Update([]sim.GroundUnit{
+		{ID: 1, Name: "Tor 9A331", Group: "SHORAD-1", Coalition: coalitions.Red, Point: orb.Point{41.00, 42.00}, Types: launcher},
+		{ID: 2, Name: "MOSCOW", Group: "Fleet-1", Coalition: coalitions.Red, Point: orb.Point{41.50, 42.00}, Types: ship},
+		{ID: 3, Name: "Ural-375", Group: "Truck-1", Coalition: coalitions.Red, Point: orb.Point{40.90, 42.00}, Types: truck},
+	})
+
+	origin := orb.Point{40.80, 42.00}
+	group, ok := picture.NearestThreat(origin, coalitions.Red, 50*unit.NauticalMile)
+	require.True(t, ok, "a lone SAM launcher is not clutter")
+	assert.Equal(t, "SHORAD-1", group.Name)
+	assert.Equal(t, []string{"SA-15"}, group.Platforms)
+
+	group, ok = picture.NearestThreat(orb.Point{41.50, 42.00}, coalitions.Red, 5*unit.NauticalMile)
+	require.True(t, ok, "a lone ship is not clutter")
+	assert.Equal(t, "Fleet-1", group.Name)
+
+	group, ok = picture.Nearest(origin, coalitions.Red, 50*unit.NauticalMile)
+	require.True(t, ok)
+	assert.Equal(t, "SHORAD-1", group.Name, "a lone truck is still clutter")
+}
+
+func TestPicturePlatforms(t *testing.T) {
+	t.Parallel()
+	launcher := []string{tags.Ground, tags.AntiAircraft, tags.Vehicle}
+	truck := []string{tags.Ground, tags.Vehicle}
+	picture := New(1)
+	picture.Update([]sim.GroundUnit{
+		{ID: 1, Name: "Ural-375", Group: "SAM-1", Coalition: coalitions.Red, Point: orb.Point{41.00, 42.00}, Types: truck},
+		{ID: 2, Name: "SA-11 Buk LN 9A310M1", Group: "SAM-1", Coalition: coalitions.Red, Point: orb.Point{41.00, 42.00}, Types: launcher},
+		{ID: 3, Name: "SA-11 Buk LN 9A310M1", Group: "SAM-1", Coalition: coalitions.Red, Point: orb.Point{41.00, 42.00}, Types: launcher},
+	})
+	group, ok := picture.NearestThreat(orb.Point{41.00, 42.00}, coalitions.Red, 10*unit.NauticalMile)
+	require.True(t, ok)
+	// Launchers are described by their SAM system, and the most numerous platform comes first.
+	assert.Equal(t, []string{"SA-11", "Ural-375"}, group.Platforms)
+}
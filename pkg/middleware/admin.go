@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"context"
+	"slices"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/parser"
+	"github.com/rs/zerolog/log"
+)
+
+// AuthorizeAdmins marks admin requests as authorized if the caller is an admin. A caller is an admin if the request's
+// callsign matches one of the given callsigns, or the request was transmitted by an SRS client with one of the given
+// GUIDs. GUIDs are harder to impersonate than callsigns, since anyone can say any callsign. This should come after
+// BindTransmitters, so that callsigns are attributed to the transmitting pilot. Other requests are passed on
+// unchanged.
+func AuthorizeAdmins(callsigns []string, guids []string) Middleware {
+	admins := make([]string, 0, len(callsigns))
+	for _, callsign := range callsigns {
+		if normalized, ok := parser.ParsePilotCallsign(callsign); ok {
+			admins = append(admins, normalized)
+		}
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request any) {
+			if admin, ok := request.(*brevity.AdminRequest); ok {
+				guid := TransmitterFrom(ctx).GUID
+				isAdmin := slices.Contains(admins, admin.Callsign) || (guid != "" && slices.Contains(guids, guid))
+				log.Info().Str("callsign", admin.Callsign).Str("guid", guid).Bool("authorized", isAdmin).Msg("checked admin authorization")
+				admin.IsAuthorized = isAdmin
+			}
+			next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
+)
+
+// CheckIns tracks which callsigns have checked in with a RADIO CHECK or ALPHA CHECK.
+type CheckIns struct {
+	lock      sync.Mutex
+	checkedIn map[string]struct{}
+}
+
+// NewCheckIns returns a check-in tracker where no callsign has checked in.
+func NewCheckIns() *CheckIns {
+	return &CheckIns{checkedIn: make(map[string]struct{})}
+}
+
+// Reset forgets every check-in, e.g. when a new mission starts.
+func (c *CheckIns) Reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	clear(c.checkedIn)
+}
+
+// checkIn records that the callsign has checked in.
+func (c *CheckIns) checkIn(callsign string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.checkedIn[callsign] = struct{}{}
+}
+
+// isCheckedIn returns true if the callsign has checked in.
+func (c *CheckIns) isCheckedIn(callsign string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, ok := c.checkedIn[callsign]
+	return ok
+}
+
+// RequireCheckIn drops requests of the given types from callsigns which have not yet checked in with a RADIO CHECK or
+// ALPHA CHECK. Request types are given as returned by RequestType, e.g. "picture".
+func RequireCheckIn(checkIns *CheckIns, requestTypes ...string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request any) {
+			callsign := Callsign(request)
+			switch request.(type) {
+			case *brevity.RadioCheckRequest, *brevity.AlphaCheckRequest:
+				if callsign != "" {
+					checkIns.checkIn(callsign)
+				}
+			default:
+				if slices.Contains(requestTypes, RequestType(request)) && !checkIns.isCheckedIn(callsign) {
+					log.Info().Str("callsign", callsign).Str("type", RequestType(request)).Msg("dropping request from callsign which has not checked in")
+					return
+				}
+			}
+			next(ctx, request)
+		}
+	}
+}
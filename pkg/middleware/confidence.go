@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
+)
+
+type confidenceKey struct{}
+
+// WithConfidence returns a context carrying the speech recognizer's confidence in the request, from 0 to 1.
+func WithConfidence(ctx context.Context, confidence float64) context.Context {
+	return context.WithValue(ctx, confidenceKey{}, confidence)
+}
+
+// Confidence returns the speech recognizer's confidence in the request. Requests which did not come from speech
+// recognition are fully trusted.
+func Confidence(ctx context.Context) float64 {
+	if confidence, ok := ctx.Value(confidenceKey{}).(float64); ok {
+		return confidence
+	}
+	return 1
+}
+
+// confirmationWindow is how long a caller has to repeat a low-confidence request to confirm it.
+const confirmationWindow = 30 * time.Second
+
+// unconfirmed is a low-confidence request waiting for the caller to repeat it.
+type unconfirmed struct {
+	requestType string
+	deadline    time.Time
+}
+
+// RequireConfidence asks the caller to confirm requests which were recognized with a confidence below the threshold
+// for their type. The threshold for each request type is given by thresholds, keyed by RequestType (e.g. "declare"),
+// or the default threshold for types not in the map. A low-confidence request is replaced by a
+// *brevity.ConfirmRequest. If the caller repeats the same type of request within a short window, the repeat is passed
+// on regardless of confidence, since two recognitions agree on the request type.
+func RequireConfidence(defaultThreshold float64, thresholds map[string]float64) Middleware {
+	return requireConfidence(defaultThreshold, thresholds, time.Now)
+}
+
+func requireConfidence(defaultThreshold float64, thresholds map[string]float64, now func() time.Time) Middleware {
+	var lock sync.Mutex
+	pending := make(map[string]unconfirmed)
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request any) {
+			callsign := Callsign(request)
+			requestType := RequestType(request)
+			if _, ok := request.(*brevity.UnableToUnderstandRequest); ok || callsign == "" {
+				next(ctx, request)
+				return
+			}
+
+			threshold, ok := thresholds[requestType]
+			if !ok {
+				threshold = defaultThreshold
+			}
+			confidence := Confidence(ctx)
+
+			lock.Lock()
+			previous, isPending := pending[callsign]
+			delete(pending, callsign)
+			isConfirmation := isPending && previous.requestType == requestType && now().Before(previous.deadline)
+			if confidence < threshold && !isConfirmation {
+				pending[callsign] = unconfirmed{requestType: requestType, deadline: now().Add(confirmationWindow)}
+			}
+			lock.Unlock()
+
+			logger := log.With().Str("callsign", callsign).Str("type", requestType).Float64("confidence", confidence).Float64("threshold", threshold).Logger()
+			switch {
+			case confidence >= threshold:
+				next(ctx, request)
+			case isConfirmation:
+				logger.Info().Msg("caller confirmed low-confidence request")
+				next(ctx, request)
+			default:
+				logger.Info().Msg("asking caller to confirm low-confidence request")
+				next(ctx, &brevity.ConfirmRequest{Callsign: callsign, RequestType: requestType})
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// inFlightTimeout is how long a request is considered in flight if its response is never transmitted, e.g. because
+// the controller had nothing to say.
+const inFlightTimeout = 30 * time.Second
+
+// inFlightRequest is a request waiting for its response to be transmitted.
+type inFlightRequest struct {
+	request any
+	// answeredBy is the callsign the response will be addressed to. This may differ from the callsign the pilot said,
+	// because the controller matches callsigns approximately.
+	answeredBy string
+	deadline   time.Time
+}
+
+// InFlight tracks the request from each callsign which is waiting for its response to be transmitted. Requests are
+// keyed by the callsign the pilot said.
+type InFlight struct {
+	lock     sync.Mutex
+	requests map[string]inFlightRequest
+	now      func() time.Time
+}
+
+// NewInFlight returns an empty in-flight request tracker.
+func NewInFlight() *InFlight {
+	return newInFlight(time.Now)
+}
+
+func newInFlight(now func() time.Time) *InFlight {
+	return &InFlight{
+		requests: make(map[string]inFlightRequest),
+		now:      now,
+	}
+}
+
+// Answered records that a response addressed to the given callsign has been transmitted, which answers any in-flight
+// request which was said with that callsign or which resolved to it.
+func (f *InFlight) Answered(callsign string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for key, request := range f.requests {
+		if key == callsign || request.answeredBy == callsign {
+			delete(f.requests, key)
+		}
+	}
+}
+
+// track records the request as in flight, unless it repeats the callsign's in-flight request. answeredBy is the
+// callsign the response will be addressed to. It returns false if the request is a duplicate.
+func (f *InFlight) track(callsign, answeredBy string, request any) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	now := f.now()
+	if previous, ok := f.requests[callsign]; ok && now.Before(previous.deadline) && reflect.DeepEqual(previous.request, snapshot(request)) {
+		return false
+	}
+	f.requests[callsign] = inFlightRequest{request: snapshot(request), answeredBy: answeredBy, deadline: now.Add(inFlightTimeout)}
+	return true
+}
+
+// snapshot returns a copy of the request's value, so that later changes to the request by handlers don't affect
+// comparisons.
+func snapshot(request any) any {
+	v := reflect.ValueOf(request)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		return v.Elem().Interface()
+	}
+	return request
+}
+
+// CollapseDuplicates drops requests which repeat a callsign's in-flight request, so that a pilot who repeats
+// themselves before hearing the answer receives one response rather than two near-identical responses back to back.
+// Requests without a callsign are never collapsed. resolve returns the callsign which the controller will address the
+// response to, so that the request is cleared when that response is transmitted.
+func CollapseDuplicates(inFlight *InFlight, resolve func(callsign string) string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request any) {
+			callsign := Callsign(request)
+			if callsign != "" && !inFlight.track(callsign, resolve(callsign), request) {
+				log.Info().Str("callsign", callsign).Str("type", RequestType(request)).Msg("dropping duplicate of in-flight request")
+				return
+			}
+			next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// BlockCallsigns drops requests from callsigns which contain any of the given words, such as profanity. Matching is
+// case-insensitive.
+func BlockCallsigns(words ...string) Middleware {
+	blocked := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = strings.TrimSpace(strings.ToLower(word)); word != "" {
+			blocked = append(blocked, word)
+		}
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request any) {
+			callsign := strings.ToLower(Callsign(request))
+			for _, word := range blocked {
+				if strings.Contains(callsign, word) {
+					log.Info().Str("type", RequestType(request)).Msg("dropping request from blocked callsign")
+					return
+				}
+			}
+			next(ctx, request)
+		}
+	}
+}
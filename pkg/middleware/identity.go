@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/dharmab/skyeye/pkg/parser"
+	"github.com/rs/zerolog/log"
+)
+
+// Transmitter identifies the SRS client which transmitted a request.
+type Transmitter struct {
+	// GUID is the SRS client's unique ID.
+	GUID string
+	// Name is the SRS client name, which usually matches the player's in-game name.
+	Name string
+	// UnitID is the in-game ID of the unit the SRS client is bound to, or zero if the client is not bound to a unit.
+	UnitID uint64
+}
+
+type transmitterKey struct{}
+
+// WithTransmitter returns a context carrying the SRS client which transmitted the request.
+func WithTransmitter(ctx context.Context, transmitter Transmitter) context.Context {
+	return context.WithValue(ctx, transmitterKey{}, transmitter)
+}
+
+// TransmitterFrom returns the SRS client which transmitted the request. The result is the zero value if the request
+// did not come over SRS or the client is unknown.
+func TransmitterFrom(ctx context.Context) Transmitter {
+	if transmitter, ok := ctx.Value(transmitterKey{}).(Transmitter); ok {
+		return transmitter
+	}
+	return Transmitter{}
+}
+
+// binding is the aircraft an SRS client is bound to.
+type binding struct {
+	// name and unitID are the client's name and unit ID when the binding was made. If either changes, e.g. because
+	// the player switched slots, the binding is made again.
+	name   string
+	unitID uint64
+	// callsign is the callsign of the client's aircraft.
+	callsign string
+}
+
+// BindTransmitters maintains a mapping of SRS clients to the aircraft they are flying, so that requests are attributed
+// to the transmitting pilot's aircraft even when speech recognition garbles the spoken callsign. If a request's
+// callsign does not match any aircraft, it is replaced by the callsign of the transmitting client's aircraft.
+//
+// A client is bound to an aircraft by the in-game unit ID reported by SRS, or failing that by parsing a callsign from
+// the SRS client name, which usually matches the player's in-game name. isKnown reports whether a callsign matches an
+// aircraft. unitName returns the pilot name of the aircraft with the given unit ID.
+func BindTransmitters(isKnown func(callsign string) bool, unitName func(id uint64) (string, bool)) Middleware {
+	var lock sync.Mutex
+	bindings := make(map[string]binding)
+
+	bind := func(transmitter Transmitter) (string, bool) {
+		lock.Lock()
+		defer lock.Unlock()
+		existing, ok := bindings[transmitter.GUID]
+		if ok && existing.name == transmitter.Name && existing.unitID == transmitter.UnitID && isKnown(existing.callsign) {
+			return existing.callsign, true
+		}
+		delete(bindings, transmitter.GUID)
+
+		callsign, ok := "", false
+		if transmitter.UnitID != 0 {
+			if name, found := unitName(transmitter.UnitID); found {
+				callsign, ok = parser.ParsePilotCallsign(name)
+			}
+		}
+		if !ok {
+			callsign, ok = parser.ParsePilotCallsign(transmitter.Name)
+			ok = ok && isKnown(callsign)
+		}
+		if !ok {
+			return "", false
+		}
+		bindings[transmitter.GUID] = binding{name: transmitter.Name, unitID: transmitter.UnitID, callsign: callsign}
+		log.Info().Str("GUID", transmitter.GUID).Str("name", transmitter.Name).Uint64("unitID", transmitter.UnitID).Str("callsign", callsign).Msg("bound SRS client to aircraft")
+		return callsign, true
+	}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request any) {
+			transmitter := TransmitterFrom(ctx)
+			callsign := Callsign(request)
+			if transmitter.GUID != "" && (callsign == "" || !isKnown(callsign)) {
+				if bound, ok := bind(transmitter); ok && bound != callsign && setCallsign(request, bound) {
+					log.Info().Str("callsign", callsign).Str("boundCallsign", bound).Msg("attributing request to transmitting client's aircraft")
+				}
+			}
+			next(ctx, request)
+		}
+	}
+}
+
+// setCallsign sets the callsign of a request. It returns false if the request does not have a settable callsign.
+func setCallsign(request any, callsign string) bool {
+	v := reflect.ValueOf(request)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return false
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	field := v.FieldByName("Callsign")
+	if !field.IsValid() || field.Kind() != reflect.String || !field.CanSet() {
+		return false
+	}
+	field.SetString(callsign)
+	return true
+}
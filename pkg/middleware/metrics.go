@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"context"
+	"expvar"
+)
+
+// requestCounts counts handled requests by type. It is published as the "requests" expvar.
+var requestCounts = expvar.NewMap("requests")
+
+// Metrics counts requests by type before passing them to the next handler.
+func Metrics() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request any) {
+			requestCounts.Add(RequestType(request), 1)
+			next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// package middleware provides composable policies which are applied to brevity requests before they are handled by
+// the GCI controller.
+package middleware
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// Handler handles a brevity request, such as a *brevity.PictureRequest.
+type Handler func(ctx context.Context, request any)
+
+// Middleware wraps a Handler with additional behavior. A middleware may inspect or modify the request, or drop it by
+// not calling the next handler.
+type Middleware func(next Handler) Handler
+
+// Chain wraps the handler in the given middleware. The first middleware is the outermost, so it sees each request
+// first.
+func Chain(handler Handler, middleware ...Middleware) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
+
+// Callsign returns the callsign of the aircraft which made the request, or an empty string if the request does not
+// have a callsign. All brevity requests have a Callsign field, so this uses reflection to avoid having to update a
+// type switch for every new request type.
+func Callsign(request any) string {
+	v := reflect.Indirect(reflect.ValueOf(request))
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	field := v.FieldByName("Callsign")
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+	return field.String()
+}
+
+// CallType returns a short name for the type of a response or call, such as "threat" for a brevity.ThreatCall.
+func CallType(call any) string {
+	t := reflect.TypeOf(call)
+	if t == nil {
+		return ""
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	name := strings.TrimSuffix(strings.TrimSuffix(t.Name(), "Response"), "Call")
+	return strings.ToLower(name)
+}
+
+// RequestType returns a short name for the type of the request, such as "picture" for a *brevity.PictureRequest.
+func RequestType(request any) string {
+	t := reflect.TypeOf(request)
+	if t == nil {
+		return ""
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return strings.ToLower(strings.TrimSuffix(t.Name(), "Request"))
+}
@@ -0,0 +1,288 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder is a terminal handler which records the requests it receives.
+type recorder struct {
+	requests []any
+}
+
+func (r *recorder) handle(_ context.Context, request any) {
+	r.requests = append(r.requests, request)
+}
+
+func TestCallsign(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "mobius 1", Callsign(&brevity.PictureRequest{Callsign: "mobius 1"}))
+	assert.Equal(t, "mobius 1", Callsign(brevity.RadioCheckRequest{Callsign: "mobius 1"}))
+	assert.Empty(t, Callsign("mobius 1"))
+	assert.Empty(t, Callsign(nil))
+}
+
+func TestRequestType(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "picture", RequestType(&brevity.PictureRequest{}))
+	assert.Equal(t, "bogeydope", RequestType(&brevity.BogeyDopeRequest{}))
+	assert.Equal(t, "unabletounderstand", RequestType(&brevity.UnableToUnderstandRequest{}))
+}
+
+func TestCallType(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "threat", CallType(brevity.ThreatCall{}))
+	assert.Equal(t, "bogeydope", CallType(brevity.BogeyDopeResponse{}))
+	assert.Equal(t, "picture", CallType(&brevity.PictureResponse{}))
+	assert.Empty(t, CallType(nil))
+}
+
+func TestChainOrder(t *testing.T) {
+	t.Parallel()
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, request any) {
+				order = append(order, name)
+				next(ctx, request)
+			}
+		}
+	}
+	r := &recorder{}
+	handler := Chain(r.handle, mark("a"), mark("b"), mark("c"))
+	handler(context.Background(), &brevity.PictureRequest{})
+	assert.Equal(t, []string{"a", "b", "c"}, order)
+	assert.Len(t, r.requests, 1)
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := &recorder{}
+	limiter := newRateLimiter(10*time.Second, func() time.Time { return now })
+	handler := Chain(r.handle, RateLimit(limiter))
+	ctx := context.Background()
+
+	handler(ctx, &brevity.PictureRequest{Callsign: "mobius 1"})
+	handler(ctx, &brevity.PictureRequest{Callsign: "mobius 1"})
+	handler(ctx, &brevity.PictureRequest{Callsign: "yellow 13"})
+	assert.Len(t, r.requests, 2)
+
+	now = now.Add(11 * time.Second)
+	handler(ctx, &brevity.PictureRequest{Callsign: "mobius 1"})
+	assert.Len(t, r.requests, 3)
+
+	handler(ctx, &brevity.UnableToUnderstandRequest{})
+	handler(ctx, &brevity.UnableToUnderstandRequest{})
+	assert.Len(t, r.requests, 5)
+
+	// A pilot who missed the response can always ask to say again.
+	handler(ctx, &brevity.SayAgainRequest{Callsign: "mobius 1"})
+	handler(ctx, &brevity.SayAgainRequest{Callsign: "mobius 1"})
+	assert.Len(t, r.requests, 7)
+
+	// Callsigns whose last request is older than the interval are forgotten.
+	now = now.Add(11 * time.Second)
+	handler(ctx, &brevity.PictureRequest{Callsign: "mobius 1"})
+	assert.Len(t, r.requests, 8)
+	assert.NotContains(t, limiter.lastRequest, "yellow 13")
+
+	limiter.Reset()
+	handler(ctx, &brevity.PictureRequest{Callsign: "mobius 1"})
+	assert.Len(t, r.requests, 9, "rate limits are forgotten on reset")
+}
+
+func TestRequireCheckIn(t *testing.T) {
+	t.Parallel()
+	r := &recorder{}
+	checkIns := NewCheckIns()
+	handler := Chain(r.handle, RequireCheckIn(checkIns, "picture"))
+	ctx := context.Background()
+
+	handler(ctx, &brevity.PictureRequest{Callsign: "mobius 1"})
+	assert.Empty(t, r.requests)
+
+	handler(ctx, &brevity.BogeyDopeRequest{Callsign: "mobius 1"})
+	assert.Len(t, r.requests, 1)
+
+	handler(ctx, &brevity.RadioCheckRequest{Callsign: "mobius 1"})
+	handler(ctx, &brevity.PictureRequest{Callsign: "mobius 1"})
+	assert.Len(t, r.requests, 3)
+
+	handler(ctx, &brevity.PictureRequest{Callsign: "yellow 13"})
+	assert.Len(t, r.requests, 3)
+
+	checkIns.Reset()
+	handler(ctx, &brevity.PictureRequest{Callsign: "mobius 1"})
+	assert.Len(t, r.requests, 3, "check-ins are forgotten on reset")
+}
+
+func TestBlockCallsigns(t *testing.T) {
+	t.Parallel()
+	r := &recorder{}
+	handler := Chain(r.handle, BlockCallsigns("darn", " "))
+	ctx := context.Background()
+
+	handler(ctx, &brevity.RadioCheckRequest{Callsign: "darnit 1"})
+	assert.Empty(t, r.requests)
+	handler(ctx, &brevity.RadioCheckRequest{Callsign: "mobius 1"})
+	assert.Len(t, r.requests, 1)
+}
+
+func TestAuthorizeAdmins(t *testing.T) {
+	t.Parallel()
+	r := &recorder{}
+	handler := Chain(r.handle, AuthorizeAdmins([]string{"Yellow 13"}, []string{"guid-a"}))
+	byGUID := WithTransmitter(context.Background(), Transmitter{GUID: "guid-a", Name: "Trigger"})
+	other := WithTransmitter(context.Background(), Transmitter{GUID: "guid-b", Name: "Trigger"})
+
+	handler(other, &brevity.AdminRequest{Callsign: "yellow 1 3", Command: brevity.MuteCommand})
+	handler(byGUID, &brevity.AdminRequest{Callsign: "trigger 1", Command: brevity.MuteCommand})
+	handler(other, &brevity.AdminRequest{Callsign: "mobius 1", Command: brevity.MuteCommand, IsAuthorized: true})
+	handler(other, &brevity.PictureRequest{Callsign: "mobius 1"})
+	assert.Equal(t, []any{
+		&brevity.AdminRequest{Callsign: "yellow 1 3", Command: brevity.MuteCommand, IsAuthorized: true},
+		&brevity.AdminRequest{Callsign: "trigger 1", Command: brevity.MuteCommand, IsAuthorized: true},
+		&brevity.AdminRequest{Callsign: "mobius 1", Command: brevity.MuteCommand, IsAuthorized: false},
+		&brevity.PictureRequest{Callsign: "mobius 1"},
+	}, r.requests)
+}
+
+func TestBindTransmitters(t *testing.T) {
+	t.Parallel()
+	known := map[string]bool{"mobius 1": true, "yellow 1 3": true}
+	units := map[uint64]string{42: "Yellow 13 | Kei"}
+	r := &recorder{}
+	handler := Chain(r.handle, BindTransmitters(
+		func(callsign string) bool { return known[callsign] },
+		func(id uint64) (string, bool) {
+			callsign, ok := units[id]
+			return callsign, ok
+		},
+	))
+	byName := WithTransmitter(context.Background(), Transmitter{GUID: "a", Name: "Mobius 1 | Dharma"})
+	byUnit := WithTransmitter(context.Background(), Transmitter{GUID: "b", Name: "Trigger", UnitID: 42})
+	unbound := WithTransmitter(context.Background(), Transmitter{GUID: "c", Name: "Trigger"})
+
+	handler(byName, &brevity.BogeyDopeRequest{Callsign: "mobile 1"})
+	handler(byName, &brevity.UnableToUnderstandRequest{})
+	handler(byUnit, &brevity.BogeyDopeRequest{Callsign: "yell oh 13"})
+	handler(unbound, &brevity.BogeyDopeRequest{Callsign: "yell oh 13"})
+	// A spoken callsign which matches an aircraft is trusted.
+	handler(byUnit, &brevity.BogeyDopeRequest{Callsign: "mobius 1"})
+	// The binding is made again after the player switches aircraft.
+	units[43] = "Mobius 1"
+	handler(WithTransmitter(context.Background(), Transmitter{GUID: "b", Name: "Trigger", UnitID: 43}), &brevity.BogeyDopeRequest{Callsign: "yell oh 13"})
+
+	expected := []string{"mobius 1", "mobius 1", "yellow 1 3", "yell oh 13", "mobius 1", "mobius 1"}
+	assert.Len(t, r.requests, len(expected))
+	for i, request := range r.requests {
+		assert.Equal(t, expected[i], Callsign(request))
+	}
+}
+
+func TestConfidence(t *testing.T) {
+	t.Parallel()
+	assert.InDelta(t, 1.0, Confidence(context.Background()), 0.0001)
+	assert.InDelta(t, 0.4, Confidence(WithConfidence(context.Background(), 0.4)), 0.0001)
+}
+
+func TestRequireConfidence(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := &recorder{}
+	handler := Chain(r.handle, requireConfidence(0.5, map[string]float64{"declare": 0.8}, func() time.Time { return now }))
+	confident := WithConfidence(context.Background(), 0.9)
+	unsure := WithConfidence(context.Background(), 0.6)
+	doubtful := WithConfidence(context.Background(), 0.3)
+
+	// Confident requests are passed through.
+	handler(confident, &brevity.DeclareRequest{Callsign: "mobius 1"})
+	handler(unsure, &brevity.PictureRequest{Callsign: "mobius 1"})
+	assert.IsType(t, &brevity.DeclareRequest{}, r.requests[0])
+	assert.IsType(t, &brevity.PictureRequest{}, r.requests[1])
+
+	// A low-confidence request must be confirmed by repeating it.
+	handler(unsure, &brevity.DeclareRequest{Callsign: "mobius 1"})
+	assert.Equal(t, &brevity.ConfirmRequest{Callsign: "mobius 1", RequestType: "declare"}, r.requests[2])
+	handler(unsure, &brevity.DeclareRequest{Callsign: "mobius 1"})
+	assert.IsType(t, &brevity.DeclareRequest{}, r.requests[3])
+
+	// A different request does not confirm the pending request.
+	handler(doubtful, &brevity.BogeyDopeRequest{Callsign: "yellow 13"})
+	handler(doubtful, &brevity.SnaplockRequest{Callsign: "yellow 13"})
+	assert.Equal(t, &brevity.ConfirmRequest{Callsign: "yellow 13", RequestType: "bogeydope"}, r.requests[4])
+	assert.Equal(t, &brevity.ConfirmRequest{Callsign: "yellow 13", RequestType: "snaplock"}, r.requests[5])
+
+	// Confirmations expire.
+	now = now.Add(time.Minute)
+	handler(doubtful, &brevity.SnaplockRequest{Callsign: "yellow 13"})
+	assert.Equal(t, &brevity.ConfirmRequest{Callsign: "yellow 13", RequestType: "snaplock"}, r.requests[6])
+
+	// Requests without a callsign can't be confirmed, so they are passed through.
+	handler(doubtful, &brevity.UnableToUnderstandRequest{})
+	handler(doubtful, &brevity.PictureRequest{})
+	assert.Len(t, r.requests, 9)
+}
+
+func TestCollapseDuplicates(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	inFlight := newInFlight(func() time.Time { return now })
+	r := &recorder{}
+	handler := Chain(r.handle, CollapseDuplicates(inFlight, func(callsign string) string { return callsign }))
+	ctx := context.Background()
+
+	handler(ctx, &brevity.PictureRequest{Callsign: "mobius 1"})
+	handler(ctx, &brevity.PictureRequest{Callsign: "mobius 1"})
+	handler(ctx, &brevity.PictureRequest{Callsign: "yellow 13"})
+	assert.Len(t, r.requests, 2, "duplicate is collapsed")
+
+	handler(ctx, &brevity.BogeyDopeRequest{Callsign: "mobius 1", Filter: brevity.Aircraft})
+	handler(ctx, &brevity.BogeyDopeRequest{Callsign: "mobius 1", Filter: brevity.FixedWing})
+	assert.Len(t, r.requests, 4, "different requests are not collapsed")
+
+	inFlight.Answered("mobius 1")
+	handler(ctx, &brevity.BogeyDopeRequest{Callsign: "mobius 1", Filter: brevity.FixedWing})
+	assert.Len(t, r.requests, 5, "repeat after the response is not collapsed")
+
+	handler(ctx, &brevity.PictureRequest{Callsign: "yellow 13"})
+	now = now.Add(inFlightTimeout)
+	handler(ctx, &brevity.PictureRequest{Callsign: "yellow 13"})
+	assert.Len(t, r.requests, 6, "repeat after the timeout is not collapsed")
+
+	handler(ctx, &brevity.UnableToUnderstandRequest{})
+	handler(ctx, &brevity.UnableToUnderstandRequest{})
+	assert.Len(t, r.requests, 8)
+}
+
+func TestCollapseDuplicatesMatchedCallsign(t *testing.T) {
+	t.Parallel()
+	inFlight := NewInFlight()
+	r := &recorder{}
+	// The controller matches the garbled callsign to the nearest trackfile and addresses its response to that.
+	resolve := func(callsign string) string {
+		if callsign == "mobius one" {
+			return "mobius 1"
+		}
+		return callsign
+	}
+	handler := Chain(r.handle, CollapseDuplicates(inFlight, resolve))
+	ctx := context.Background()
+
+	handler(ctx, &brevity.PictureRequest{Callsign: "mobius one"})
+	handler(ctx, &brevity.PictureRequest{Callsign: "mobius one"})
+	handler(ctx, &brevity.PictureRequest{Callsign: "yellow 13"})
+	assert.Len(t, r.requests, 2, "duplicate is collapsed")
+
+	inFlight.Answered("mobius 1")
+	handler(ctx, &brevity.PictureRequest{Callsign: "mobius one"})
+	assert.Len(t, r.requests, 3, "repeat after the response to the matched callsign is not collapsed")
+
+	handler(ctx, &brevity.PictureRequest{Callsign: "yellow 13"})
+	assert.Len(t, r.requests, 3, "other callers' requests stay in flight")
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rs/zerolog/log"
+)
+
+// RateLimiter tracks when each callsign's last request was accepted.
+type RateLimiter struct {
+	lock        sync.Mutex
+	interval    time.Duration
+	lastRequest map[string]time.Time
+	now         func() time.Time
+}
+
+// NewRateLimiter returns a rate limiter which permits one request per callsign within the given interval.
+func NewRateLimiter(interval time.Duration) *RateLimiter {
+	return newRateLimiter(interval, time.Now)
+}
+
+func newRateLimiter(interval time.Duration, now func() time.Time) *RateLimiter {
+	return &RateLimiter{
+		interval:    interval,
+		lastRequest: make(map[string]time.Time),
+		now:         now,
+	}
+}
+
+// Reset forgets every callsign's last request, e.g. when a new mission starts.
+func (l *RateLimiter) Reset() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	clear(l.lastRequest)
+}
+
+// allow records a request from the callsign. It returns false if the callsign's previous request was accepted less
+// than the interval ago. Callsigns whose last request is older than the interval are forgotten, so that callsigns heard
+// once during a long mission do not accumulate.
+func (l *RateLimiter) allow(callsign string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := l.now()
+	for key, last := range l.lastRequest {
+		if now.Sub(last) >= l.interval {
+			delete(l.lastRequest, key)
+		}
+	}
+	if _, ok := l.lastRequest[callsign]; ok {
+		return false
+	}
+	l.lastRequest[callsign] = now
+	return true
+}
+
+// RateLimit drops requests from a callsign which arrive less than the limiter's interval after that callsign's
+// previous request. Requests without a callsign are not rate limited, since we can't tell who sent them. Requests to
+// SAY AGAIN are not rate limited either, since a pilot asks to repeat the previous response because they missed it.
+func RateLimit(limiter *RateLimiter) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request any) {
+			_, isSayAgain := request.(*brevity.SayAgainRequest)
+			if callsign := Callsign(request); callsign != "" && !isSayAgain && !limiter.allow(callsign) {
+				log.Warn().Str("callsign", callsign).Str("type", RequestType(request)).Msg("dropping rate limited request")
+				return
+			}
+			next(ctx, request)
+		}
+	}
+}
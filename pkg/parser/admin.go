@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"slices"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// parseAdmin parses an admin command. The second return value is false if no command was recognized.
+func parseAdmin(callsign string, args []string) (*brevity.AdminRequest, bool) {
+	has := func(words ...string) bool {
+		return slices.ContainsFunc(args, func(arg string) bool {
+			return slices.ContainsFunc(words, func(word string) bool { return IsSimilar(arg, word) })
+		})
+	}
+	var command brevity.AdminCommand
+	switch {
+	// "mute" and "unmute" are too similar to tell apart by fuzzy matching.
+	case isUnmute(args):
+		command = brevity.UnmuteCommand
+	case slices.Contains(args, "mute") || has("silence"):
+		command = brevity.MuteCommand
+	case has("bullseye"):
+		command = brevity.SetBullseyeCommand
+		if has("reset", "restore", "clear") {
+			command = brevity.ResetBullseyeCommand
+		}
+	case has("reload"):
+		command = brevity.ReloadCommand
+	default:
+		return nil, false
+	}
+	return &brevity.AdminRequest{Callsign: callsign, Command: command}, true
+}
+
+// isUnmute returns true if the arguments contain "unmute", or "un mute" as separate words, such as when "un-mute" is
+// transcribed with a hyphen.
+func isUnmute(args []string) bool {
+	for i, arg := range args {
+		if arg == "unmute" || (arg == "un" && i+1 < len(args) && args[i+1] == "mute") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParserAdmin(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text:     "anyface, mobius 1, admin mute",
+			expected: &brevity.AdminRequest{Callsign: "mobius 1", Command: brevity.MuteCommand},
+		},
+		{
+			text:     "anyface, mobius 1, admin unmute",
+			expected: &brevity.AdminRequest{Callsign: "mobius 1", Command: brevity.UnmuteCommand},
+		},
+		{
+			text:     "anyface, mobius 1, admin un-mute",
+			expected: &brevity.AdminRequest{Callsign: "mobius 1", Command: brevity.UnmuteCommand},
+		},
+		{
+			text:     "anyface, mobius 1, admin un mute",
+			expected: &brevity.AdminRequest{Callsign: "mobius 1", Command: brevity.UnmuteCommand},
+		},
+		{
+			text:     "anyface, mobius 1, admin, un reload config",
+			expected: &brevity.AdminRequest{Callsign: "mobius 1", Command: brevity.ReloadCommand},
+		},
+		{
+			text:     "anyface, mobius 1, admin, set bullseye",
+			expected: &brevity.AdminRequest{Callsign: "mobius 1", Command: brevity.SetBullseyeCommand},
+		},
+		{
+			text:     "anyface, mobius 1, admin, reset bullseye",
+			expected: &brevity.AdminRequest{Callsign: "mobius 1", Command: brevity.ResetBullseyeCommand},
+		},
+		{
+			text:     "anyface, mobius 1, admin, reload config",
+			expected: &brevity.AdminRequest{Callsign: "mobius 1", Command: brevity.ReloadCommand},
+		},
+		{
+			text:     "anyface, mobius 1, admin, make me a sandwich",
+			expected: &brevity.UnableToUnderstandRequest{Callsign: "mobius 1"},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		assert.Equal(t, test.expected, request)
+	})
+}
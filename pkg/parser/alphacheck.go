@@ -0,0 +1,29 @@
+package parser
+
+import (
+	"slices"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// gridWords are words which ask for a position as an MGRS grid reference.
+var gridWords = []string{"grid", "grids", "mgrs", "mgr"}
+
+// latLongWords are words which ask for a position as latitude and longitude.
+var latLongWords = []string{"lat", "latitude", "long", "longitude", "latlong", "latlon", "coordinates"}
+
+// parseAlphaCheck parses an ALPHA CHECK request, including an optional position format.
+func parseAlphaCheck(callsign string, args []string) *brevity.AlphaCheckRequest {
+	request := &brevity.AlphaCheckRequest{Callsign: callsign}
+	for _, arg := range args {
+		if slices.Contains(gridWords, arg) {
+			request.Format = brevity.GridFormat
+			break
+		}
+		if slices.Contains(latLongWords, arg) {
+			request.Format = brevity.LatLongFormat
+			break
+		}
+	}
+	return request
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/rodaine/numwords"
 )
 
 var bogeyFilterMap = map[string]brevity.ContactCategory{
@@ -17,6 +19,10 @@ var bogeyFilterMap = map[string]brevity.ContactCategory{
 	"chopper":     brevity.RotaryWing,
 	"helo":        brevity.RotaryWing,
 	"rotary wing": brevity.RotaryWing,
+	"surface":     brevity.Surface,
+	"ship":        brevity.Surface,
+	"boat":        brevity.Surface,
+	"maritime":    brevity.Surface,
 }
 
 func (p *parser) parseBogeyDope(callsign string, scanner *bufio.Scanner) (*brevity.BogeyDopeRequest, bool) {
@@ -31,5 +37,48 @@ func (p *parser) parseBogeyDope(callsign string, scanner *bufio.Scanner) (*brevi
 			break
 		}
 	}
-	return &brevity.BogeyDopeRequest{Callsign: callsign, Filter: filter}, true
+	return &brevity.BogeyDopeRequest{Callsign: callsign, Filter: filter, Nose: parseNose(s), Groups: parseGroupCount(s)}, true
+}
+
+// maxBogeyDopeGroups is the most groups which may be requested in a single BOGEY DOPE, to keep the response short.
+const maxBogeyDopeGroups = 3
+
+// parseGroupCount parses the number of groups requested, e.g. "two groups". Returns zero if the text does not ask for
+// more than one group.
+func parseGroupCount(s string) int {
+	words := strings.Fields(s)
+	for i, word := range words {
+		if word != "groups" {
+			continue
+		}
+		if i > 0 {
+			if n, err := numwords.ParseInt(words[i-1]); err == nil && n > 1 {
+				return min(n, maxBogeyDopeGroups)
+			}
+		}
+		return 2
+	}
+	return 0
+}
+
+// defaultNose is the angle either side of the nose used when a caller asks for a BOGEY DOPE on their nose without
+// giving an angle.
+const defaultNose = 30 * unit.Degree
+
+// parseNose parses a sector relative to the caller's nose, e.g. "nose 30 degrees". Returns zero if the text does not
+// ask about the nose.
+func parseNose(s string) unit.Angle {
+	words := strings.Fields(s)
+	for i, word := range words {
+		if word != "nose" {
+			continue
+		}
+		if i+1 < len(words) {
+			if d, err := numwords.ParseInt(words[i+1]); err == nil && d > 0 {
+				return unit.Angle(min(d, 180)) * unit.Degree
+			}
+		}
+		return defaultNose
+	}
+	return 0
 }
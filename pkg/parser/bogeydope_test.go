@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
 	"github.com/stretchr/testify/require"
 )
 
@@ -31,12 +32,78 @@ func TestParserBogeyDope(t *testing.T) {
 				Filter:   brevity.RotaryWing,
 			},
 		},
+		{
+			text: "anyface hornet 1 bogey dope surface",
+			expected: &brevity.BogeyDopeRequest{
+				Callsign: "hornet 1",
+				Filter:   brevity.Surface,
+			},
+		},
+		{
+			text: "anyface eagle 1 bogey dope nose 45 degrees",
+			expected: &brevity.BogeyDopeRequest{
+				Callsign: "eagle 1",
+				Filter:   brevity.Aircraft,
+				Nose:     45 * unit.Degree,
+			},
+		},
+		{
+			text: "anyface eagle 1 bogey dope fighters on my nose thirty",
+			expected: &brevity.BogeyDopeRequest{
+				Callsign: "eagle 1",
+				Filter:   brevity.FixedWing,
+				Nose:     30 * unit.Degree,
+			},
+		},
+		{
+			text: "anyface eagle 1 bogey dope nose",
+			expected: &brevity.BogeyDopeRequest{
+				Callsign: "eagle 1",
+				Filter:   brevity.Aircraft,
+				Nose:     defaultNose,
+			},
+		},
+		{
+			text: "anyface eagle 1 bogey dope two groups",
+			expected: &brevity.BogeyDopeRequest{
+				Callsign: "eagle 1",
+				Filter:   brevity.Aircraft,
+				Groups:   2,
+			},
+		},
+		{
+			text: "anyface eagle 1 bogey dope 3 groups fighters",
+			expected: &brevity.BogeyDopeRequest{
+				Callsign: "eagle 1",
+				Filter:   brevity.FixedWing,
+				Groups:   3,
+			},
+		},
+		{
+			text: "anyface eagle 1 bogey dope ten groups",
+			expected: &brevity.BogeyDopeRequest{
+				Callsign: "eagle 1",
+				Filter:   brevity.Aircraft,
+				Groups:   maxBogeyDopeGroups,
+			},
+		},
+		{
+			text: "anyface eagle 1 bogey dope groups on my nose",
+			expected: &brevity.BogeyDopeRequest{
+				Callsign: "eagle 1",
+				Filter:   brevity.Aircraft,
+				Nose:     defaultNose,
+				Groups:   2,
+			},
+		},
 	}
-	runParserTestCases(t, New(TestCallsign, true), testCases, func(t *testing.T, test parserTestCase, request any) {
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
 		t.Helper()
 		expected := test.expected.(*brevity.BogeyDopeRequest)
 		actual := request.(*brevity.BogeyDopeRequest)
 		require.Equal(t, expected.Callsign, actual.Callsign)
 		require.Equal(t, expected.Filter, actual.Filter)
+		require.InDelta(t, expected.Nose.Degrees(), actual.Nose.Degrees(), 0.1)
+		require.Equal(t, expected.Groups, actual.Groups)
 	})
 }
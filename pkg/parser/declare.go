@@ -2,6 +2,7 @@ package parser
 
 import (
 	"bufio"
+	"strings"
 	"unicode"
 
 	"github.com/dharmab/skyeye/pkg/bearings"
@@ -15,6 +16,8 @@ func (p *parser) parseDeclare(callsign string, scanner *bufio.Scanner) (*brevity
 	var bearing bearings.Bearing
 	var _range unit.Length
 	var IsBRAA bool
+	// areaWords collects the words which were not a location, in case the location is the name of an area.
+	var areaWords []string
 	for {
 		if scanner.Text() == "" {
 			scanner.Scan()
@@ -40,6 +43,9 @@ func (p *parser) parseDeclare(callsign string, scanner *bufio.Scanner) (*brevity
 			if IsSimilar(scanner.Text(), word) {
 				log.Debug().Str("text", scanner.Text()).Msg("found bullseye token")
 				bullseye = p.parseBullseye(scanner)
+				if bullseye == nil {
+					return nil, false
+				}
 				parsedAsBullseye = true
 				break
 			}
@@ -73,8 +79,23 @@ func (p *parser) parseDeclare(callsign string, scanner *bufio.Scanner) (*brevity
 			break
 		}
 
+		areaWords = append(areaWords, scanner.Text())
 		if ok := scanner.Scan(); !ok {
 			log.Debug().Msg("end of input")
+			// Only treat the words as an area if they name one, so that a garbled bullseye or BRAA is reported as
+			// unreadable rather than as an unknown area.
+			if area := strings.Join(areaWords, " "); len(areaWords) > 0 {
+				if _, ok := p.namedAreas.Find(area); !ok {
+					log.Debug().Str("text", area).Msg("no bullseye, BRAA or named area found")
+					return nil, false
+				}
+				log.Debug().Str("area", area).Msg("no bullseye or BRAA found, using named area")
+				return &brevity.DeclareRequest{
+					Callsign: callsign,
+					Area:     area,
+					Track:    brevity.UnknownDirection,
+				}, true
+			}
 			return nil, false
 		}
 	}
@@ -3,9 +3,11 @@ package parser
 import (
 	"testing"
 
+	"github.com/dharmab/skyeye/pkg/areas"
 	"github.com/dharmab/skyeye/pkg/bearings"
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -13,6 +15,30 @@ import (
 func TestParserDeclare(t *testing.T) {
 	t.Parallel()
 	testCases := []parserTestCase{
+		{
+			text: "anyface, mobius 1, declare bullseye 090/40, fifteen thousand",
+			expected: &brevity.DeclareRequest{
+				Callsign: "mobius 1",
+				Bullseye: *brevity.NewBullseye(
+					bearings.NewMagneticBearing(90*unit.Degree),
+					40*unit.NauticalMile,
+				),
+				Altitude: 15000 * unit.Foot,
+				Track:    brevity.UnknownDirection,
+			},
+		},
+		{
+			text: "anyface, mobius 1, declare bullseye 090/40, fifteen thousand, track north",
+			expected: &brevity.DeclareRequest{
+				Callsign: "mobius 1",
+				Bullseye: *brevity.NewBullseye(
+					bearings.NewMagneticBearing(90*unit.Degree),
+					40*unit.NauticalMile,
+				),
+				Altitude: 15000 * unit.Foot,
+				Track:    brevity.North,
+			},
+		},
 		{
 			text: "anyface, chevy one one, declare, 075 26 2000",
 			expected: &brevity.DeclareRequest{
@@ -61,6 +87,42 @@ func TestParserDeclare(t *testing.T) {
 				Track:    brevity.UnknownDirection,
 			},
 		},
+		{
+			text: "anyface, chevy one one, declare, 075 26 angels 12",
+			expected: &brevity.DeclareRequest{
+				Callsign: "chevy 1 1",
+				Bullseye: *brevity.NewBullseye(
+					bearings.NewMagneticBearing(75*unit.Degree),
+					26*unit.NauticalMile,
+				),
+				Altitude: 12000 * unit.Foot,
+				Track:    brevity.UnknownDirection,
+			},
+		},
+		{
+			text: "anyface, chevy one one, declare, 075 26 at angels twenty",
+			expected: &brevity.DeclareRequest{
+				Callsign: "chevy 1 1",
+				Bullseye: *brevity.NewBullseye(
+					bearings.NewMagneticBearing(75*unit.Degree),
+					26*unit.NauticalMile,
+				),
+				Altitude: 20000 * unit.Foot,
+				Track:    brevity.UnknownDirection,
+			},
+		},
+		{
+			text: "anyface, chevy one one, declare, 075 26 cherubs 5",
+			expected: &brevity.DeclareRequest{
+				Callsign: "chevy 1 1",
+				Bullseye: *brevity.NewBullseye(
+					bearings.NewMagneticBearing(75*unit.Degree),
+					26*unit.NauticalMile,
+				),
+				Altitude: 500 * unit.Foot,
+				Track:    brevity.UnknownDirection,
+			},
+		},
 		{
 			text: "anyface, chevy one one, declare, 075 26 altitude 2000",
 			expected: &brevity.DeclareRequest{
@@ -177,13 +239,37 @@ func TestParserDeclare(t *testing.T) {
 				IsBRAA:   true,
 			},
 		},
+		{
+			text: "anyface, chevy one one, declare, over the lake",
+			expected: &brevity.DeclareRequest{
+				Callsign: "chevy 1 1",
+				Area:     "over the lake",
+				Track:    brevity.UnknownDirection,
+			},
+		},
+		{
+			text: "anyface, chevy one one, declare, grid kilo uniform",
+			expected: &brevity.DeclareRequest{
+				Callsign: "chevy 1 1",
+				Area:     "grid kilo uniform",
+				Track:    brevity.UnknownDirection,
+			},
+		},
 	}
-	runParserTestCases(t, New(TestCallsign, true), testCases, func(t *testing.T, test parserTestCase, request any) {
+	square := orb.Polygon{{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}}
+	namedAreas := areas.New(
+		areas.Area{Name: "Lake", Polygon: square},
+		areas.Area{Name: "Grid KU", Aliases: []string{"kilo uniform"}, Polygon: square},
+	)
+	runParserTestCases(t, New(TestCallsign, true, namedAreas), testCases, func(t *testing.T, test parserTestCase, request any) {
 		t.Helper()
 		expected := test.expected.(*brevity.DeclareRequest)
 		actual := request.(*brevity.DeclareRequest)
 		assert.Equal(t, expected.Callsign, actual.Callsign)
-		if expected.IsBRAA {
+		assert.Equal(t, expected.Area, actual.Area)
+		if expected.Area != "" {
+			assert.False(t, actual.IsBRAA)
+		} else if expected.IsBRAA {
 			assert.True(t, actual.IsBRAA)
 			require.NotNil(t, actual)
 			require.NotNil(t, actual.Bearing)
@@ -202,3 +288,26 @@ func TestParserDeclare(t *testing.T) {
 		assert.Equal(t, expected.Track, actual.Track)
 	})
 }
+
+func TestParserDeclareUnknownArea(t *testing.T) {
+	t.Parallel()
+	square := orb.Polygon{{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}}
+	testCases := []parserTestCase{
+		{
+			// Without named areas, words which are not a location are unreadable.
+			text:     "anyface, chevy one one, declare, over the lake",
+			expected: &brevity.UnableToUnderstandRequest{},
+		},
+		{
+			text:     "anyface, chevy one one, declare, bowl seventy two",
+			expected: &brevity.UnableToUnderstandRequest{},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(*testing.T, parserTestCase, any) {})
+	runParserTestCases(
+		t,
+		New(TestCallsign, true, areas.New(areas.Area{Name: "Snake Ridge", Polygon: square})),
+		testCases,
+		func(*testing.T, parserTestCase, any) {},
+	)
+}
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/rodaine/numwords"
+)
+
+// maxGameplanWords is the longest gameplan which is stored. Gameplans are meant to be short reminders, not briefings.
+const maxGameplanWords = 16
+
+// recallWords are words which ask the GCI to recall a stored gameplan instead of storing a new one.
+var recallWords = []string{"say", "recall", "check", "confirm", "repeat"}
+
+// parseGameplan parses a GAMEPLAN request. If the request only asks for the gameplan, the request is a recall.
+func parseGameplan(callsign string, args []string) *brevity.GameplanRequest {
+	fields := strings.Fields(numwords.ParseString(strings.Join(args, " ")))
+	isRecall := !slices.ContainsFunc(fields, func(field string) bool {
+		return !slices.Contains(recallWords, field)
+	})
+	if isRecall {
+		return &brevity.GameplanRequest{Callsign: callsign}
+	}
+	if len(fields) > maxGameplanWords {
+		fields = fields[:maxGameplanWords]
+	}
+	return &brevity.GameplanRequest{
+		Callsign:    callsign,
+		Gameplan:    strings.Join(fields, " "),
+		CommitRange: parseCommitRange(fields),
+	}
+}
+
+// parseCommitRange finds a commit range in nautical miles in a gameplan, e.g. "30 mile commit" or "commit at 30".
+// Returns zero if no commit range was found.
+func parseCommitRange(fields []string) unit.Length {
+	i := slices.IndexFunc(fields, func(field string) bool {
+		return strings.HasPrefix(field, "commit")
+	})
+	if i < 0 {
+		return 0
+	}
+	// Look for the nearest number, preferring "30 mile commit" over "commit at 30".
+	for _, j := range []int{i - 1, i - 2, i - 3, i + 1, i + 2, i + 3} {
+		if j < 0 || j >= len(fields) {
+			continue
+		}
+		if n, err := strconv.Atoi(fields[j]); err == nil && n > 0 {
+			return unit.Length(n) * unit.NauticalMile
+		}
+	}
+	return 0
+}
@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParserGameplan(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text: "anyface, mobius 1, gameplan skate, 30 mile commit",
+			expected: &brevity.GameplanRequest{
+				Callsign:    "mobius 1",
+				Gameplan:    "skate 30 mile commit",
+				CommitRange: 30 * unit.NauticalMile,
+			},
+		},
+		{
+			text: "anyface, mobius 1, game plan is banzai, commit at forty",
+			expected: &brevity.GameplanRequest{
+				Callsign:    "mobius 1",
+				Gameplan:    "is banzai commit at 40",
+				CommitRange: 40 * unit.NauticalMile,
+			},
+		},
+		{
+			text: "anyface, mobius 1, gameplan short skate",
+			expected: &brevity.GameplanRequest{
+				Callsign: "mobius 1",
+				Gameplan: "short skate",
+			},
+		},
+		{
+			text: "anyface, mobius 1, gameplan",
+			expected: &brevity.GameplanRequest{
+				Callsign: "mobius 1",
+			},
+		},
+		{
+			text: "anyface, mobius 1, gameplan check",
+			expected: &brevity.GameplanRequest{
+				Callsign: "mobius 1",
+			},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.GameplanRequest)
+		actual := request.(*brevity.GameplanRequest)
+		assert.Equal(t, expected.Callsign, actual.Callsign)
+		assert.Equal(t, expected.Gameplan, actual.Gameplan)
+		assert.InDelta(t, expected.CommitRange.NauticalMiles(), actual.CommitRange.NauticalMiles(), 0.1)
+	})
+}
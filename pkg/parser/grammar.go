@@ -0,0 +1,211 @@
+package parser
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// Grammar describes every request the parser understands and the phrasings it accepts, so that players and
+// documentation can see exactly what a release of SkyEye understands.
+type Grammar struct {
+	// Format is the general shape of a request.
+	Format string `json:"format"`
+	// Intents are the kinds of request the parser understands.
+	Intents []Intent `json:"intents"`
+}
+
+// Intent is one kind of request.
+type Intent struct {
+	// Keyword is the word which identifies the request.
+	Keyword string `json:"keyword"`
+	// Request is the name of the brevity request the intent is parsed into.
+	Request string `json:"request"`
+	// Description of what the request asks for.
+	Description string `json:"description"`
+	// Alternates are other phrases which are heard as the keyword, including common speech recognition mistakes.
+	Alternates []string `json:"alternates,omitempty"`
+	// Arguments are phrases which may follow the keyword to change the request.
+	Arguments []Argument `json:"arguments,omitempty"`
+	// Example is a request which the parser understands as this intent.
+	Example string `json:"example"`
+}
+
+// Argument is a phrase which may follow a request keyword.
+type Argument struct {
+	// Name of the argument.
+	Name string `json:"name"`
+	// Description of what the argument changes.
+	Description string `json:"description"`
+	// Phrases are the words which are understood as this argument. Empty if the argument is freeform, like a bearing.
+	Phrases []string `json:"phrases,omitempty"`
+}
+
+// intentDescription is the part of an intent which is written by hand. The rest is generated from the parser's tables.
+type intentDescription struct {
+	description string
+	arguments   []Argument
+	example     string
+}
+
+// intentDescriptions describes each request word. Every request word must have an entry.
+var intentDescriptions = map[string]intentDescription{
+	radioCheck: {
+		description: "Check that the GCI can hear you.",
+		example:     "anyface mobius 1 radio check",
+	},
+	alphaCheck: {
+		description: "Ask for your position relative to the bullseye.",
+		arguments: []Argument{
+			{Name: "grid", Description: "Give the position as an MGRS grid reference.", Phrases: gridWords},
+			{Name: "latlong", Description: "Give the position as latitude and longitude.", Phrases: latLongWords},
+		},
+		example: "anyface mobius 1 alpha check",
+	},
+	bogeyDope: {
+		description: "Ask for the nearest hostile group.",
+		arguments: []Argument{
+			{Name: "filter", Description: "Only consider a category of contact.", Phrases: slices.Sorted(maps.Keys(bogeyFilterMap))},
+			{Name: "nose", Description: "Only consider groups within an angle either side of your nose, e.g. \"nose 30\".", Phrases: []string{"nose"}},
+			{Name: "groups", Description: "Describe more than one group, e.g. \"two groups\".", Phrases: []string{"groups"}},
+		},
+		example: "anyface mobius 1 bogey dope",
+	},
+	declare: {
+		description: "Ask for the identity of a contact at a location.",
+		arguments: []Argument{
+			{Name: "bullseye", Description: "A bearing and range from the bullseye, then an altitude, e.g. \"bullseye 090 20, 15000\"."},
+			{Name: "braa", Description: "A bearing and range from your aircraft, then an altitude, e.g. \"braa 090 20, 15000\".", Phrases: braaWords},
+			{Name: "area", Description: "The name of an area configured by the server operator."},
+		},
+		example: "anyface mobius 1 declare bullseye 090 20 15000",
+	},
+	picture: {
+		description: "Ask for a summary of the air picture.",
+		arguments: []Argument{
+			{Name: "braa", Description: "Describe groups relative to your aircraft instead of the bullseye.", Phrases: braaWords},
+			{Name: "full", Description: "Describe every group in detail instead of only the highest priority groups.", Phrases: fullWords},
+		},
+		example: "anyface mobius 1 picture",
+	},
+	spiked: {
+		description: "Report a radar warning receiver spike and ask for the source.",
+		arguments: []Argument{
+			{Name: "bearing", Description: "The bearing of the spike from your aircraft."},
+		},
+		example: "anyface mobius 1 spiked 090",
+	},
+	snaplock: {
+		description: "Ask for the identity of a contact you have locked.",
+		arguments: []Argument{
+			{Name: "bra", Description: "The bearing, range and altitude of the contact from your aircraft."},
+		},
+		example: "anyface mobius 1 snaplock 090 20 15000",
+	},
+	status: {
+		description: "Ask what has changed about your target group. Without your callsign, ask for the GCI's own health.",
+		example:     "anyface mobius 1 status",
+	},
+	tripwire: {
+		description: "Ask for a warning when hostile aircraft approach.",
+		example:     "anyface mobius 1 tripwire",
+	},
+	training: {
+		description: "Turn training commentary on or off.",
+		arguments: []Argument{
+			{Name: "off", Description: "Turn commentary off instead of on.", Phrases: offWords},
+		},
+		example: "anyface mobius 1 training on",
+	},
+	groundDope: {
+		description: "Ask for the nearest hostile ground group.",
+		example:     "anyface hog 1 troops in contact",
+	},
+	survivor: {
+		description: "Ask for the nearest downed pilot.",
+		example:     "anyface dustoff 1 survivor",
+	},
+	admin: {
+		description: "Run an administrative command. Only accepted from authorized callsigns.",
+		arguments: []Argument{
+			{Name: "command", Description: "The command to run.", Phrases: []string{"mute", "unmute", "silence", "bullseye", "reset bullseye", "reload"}},
+		},
+		example: "anyface overlord 1 admin mute",
+	},
+	gameplan: {
+		description: "Store a short gameplan, or ask the GCI to repeat the stored gameplan.",
+		arguments: []Argument{
+			{Name: "gameplan", Description: "The gameplan to store. A commit range may be included, e.g. \"30 mile commit\"."},
+			{Name: "recall", Description: "Repeat the stored gameplan instead of storing a new one.", Phrases: recallWords},
+		},
+		example: "anyface mobius 1 gameplan say",
+	},
+	sayAgain: {
+		description: "Ask the GCI to repeat its last response to you.",
+		example:     "anyface mobius 1 say again",
+	},
+	tally: {
+		description: "Report that you can see your target group.",
+		example:     "anyface mobius 1 tally",
+	},
+	noJoy: {
+		description: "Report that you cannot see your target group.",
+		example:     "anyface mobius 1 no joy",
+	},
+	visual: {
+		description: "Report that you can see a friendly aircraft.",
+		example:     "anyface mobius 1 visual",
+	},
+	blind: {
+		description: "Report that you cannot see a friendly aircraft.",
+		example:     "anyface mobius 1 blind",
+	},
+	press: {
+		description: "Report that you are continuing your attack.",
+		example:     "anyface mobius 1 press",
+	},
+	tanker: {
+		description: "Ask for the nearest friendly tanker.",
+		example:     "anyface mobius 1 vector to tanker",
+	},
+	mute: {
+		description: "Stop THREAT calls and PICTURE broadcasts to your flight. Your own requests are still answered.",
+		example:     "anyface stop broadcasting to mobius 1",
+	},
+	unmute: {
+		description: "Resume THREAT calls and PICTURE broadcasts to your flight.",
+		example:     "anyface mobius 1 resume",
+	},
+}
+
+// Describe returns the parser's grammar. The alternate phrasings and argument phrases are taken from the same tables
+// the parser uses, and each intent's request type is found by parsing its example, so the grammar cannot drift from
+// the parser.
+var Describe = sync.OnceValue(func() Grammar {
+	p := &parser{gciCallsign: Anyface}
+	grammar := Grammar{
+		Format:  "<GCI callsign or ANYFACE> <your callsign> <keyword> <arguments>",
+		Intents: make([]Intent, 0, len(requestWords)),
+	}
+	for _, word := range requestWords {
+		description := intentDescriptions[word]
+		intent := Intent{
+			Keyword:     word,
+			Description: description.description,
+			Arguments:   description.arguments,
+			Example:     description.example,
+		}
+		if request := p.Parse(description.example); request != nil {
+			intent.Request = reflect.TypeOf(request).Elem().Name()
+		}
+		for alternate, w := range alternateRequestWords {
+			if w == word {
+				intent.Alternates = append(intent.Alternates, alternate)
+			}
+		}
+		slices.Sort(intent.Alternates)
+		grammar.Intents = append(grammar.Intents, intent)
+	}
+	return grammar
+})
@@ -0,0 +1,30 @@
+package parser
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+	grammar := Describe()
+	require.Len(t, grammar.Intents, len(requestWords))
+	for _, intent := range grammar.Intents {
+		t.Run(intent.Keyword, func(t *testing.T) {
+			t.Parallel()
+			assert.NotEmpty(t, intent.Description, "request word is missing a description")
+			assert.NotEmpty(t, intent.Request, "example was not parsed")
+			assert.NotEqual(t, "UnableToUnderstandRequest", intent.Request, "example was not understood")
+			assert.IsNonDecreasing(t, intent.Alternates)
+		})
+	}
+
+	i := slices.IndexFunc(grammar.Intents, func(intent Intent) bool { return intent.Keyword == bogeyDope })
+	require.GreaterOrEqual(t, i, 0)
+	assert.Equal(t, "BogeyDopeRequest", grammar.Intents[i].Request)
+	assert.Contains(t, grammar.Intents[i].Alternates, "bogeydope")
+	assert.Contains(t, grammar.Intents[i].Arguments[0].Phrases, "surface")
+}
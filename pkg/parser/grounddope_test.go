@@ -0,0 +1,32 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParserGroundDope(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text:     "anyface, hog 1, troops in contact",
+			expected: &brevity.GroundDopeRequest{Callsign: "hog 1"},
+		},
+		{
+			text:     "anyface, hog 1, ground dope",
+			expected: &brevity.GroundDopeRequest{Callsign: "hog 1"},
+		},
+		{
+			text:     "anyface, hound 1, request troops",
+			expected: &brevity.GroundDopeRequest{Callsign: "hound 1"},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.GroundDopeRequest)
+		actual := request.(*brevity.GroundDopeRequest)
+		assert.Equal(t, expected.Callsign, actual.Callsign)
+	})
+}
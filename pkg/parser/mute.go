@@ -0,0 +1,21 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/rodaine/numwords"
+)
+
+// parseMuteTarget parses a request to stop or resume broadcasts where the callsign follows the request, such as
+// "stop broadcasting to mobius 1".
+func parseMuteTarget(args []string, muted bool) (*brevity.MuteRequest, bool) {
+	if len(args) > 0 && (args[0] == "to" || args[0] == "for") {
+		args = args[1:]
+	}
+	callsign, ok := ParsePilotCallsign(numwords.ParseString(strings.Join(args, " ")))
+	if !ok {
+		return nil, false
+	}
+	return &brevity.MuteRequest{Callsign: callsign, Muted: muted}, true
+}
@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParserMute(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text:     "anyface, stop broadcasting to mobius 1",
+			expected: &brevity.MuteRequest{Callsign: "mobius 1", Muted: true},
+		},
+		{
+			text:     "anyface, mobius 1, stop broadcasts",
+			expected: &brevity.MuteRequest{Callsign: "mobius 1", Muted: true},
+		},
+		{
+			text:     "anyface, resume broadcasting to mobius one",
+			expected: &brevity.MuteRequest{Callsign: "mobius 1", Muted: false},
+		},
+		{
+			text:     "anyface, mobius 1, resume",
+			expected: &brevity.MuteRequest{Callsign: "mobius 1", Muted: false},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.MuteRequest)
+		actual := request.(*brevity.MuteRequest)
+		assert.Equal(t, expected.Callsign, actual.Callsign)
+		assert.Equal(t, expected.Muted, actual.Muted)
+	})
+}
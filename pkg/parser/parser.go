@@ -6,6 +6,7 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/dharmab/skyeye/pkg/areas"
 	"github.com/dharmab/skyeye/pkg/brevity"
 	fuzz "github.com/hbollon/go-edlib"
 	"github.com/rodaine/numwords"
@@ -23,12 +24,17 @@ type Parser interface {
 type parser struct {
 	gciCallsign       string
 	enableTextLogging bool
+	// namedAreas are the areas which may be declared by name. May be nil.
+	namedAreas *areas.Areas
 }
 
-func New(callsign string, enableTextLogging bool) Parser {
+// New creates a parser for the given GCI callsign. namedAreas are the areas which may be referenced by name in DECLARE
+// requests, and may be nil.
+func New(callsign string, enableTextLogging bool, namedAreas *areas.Areas) Parser {
 	return &parser{
 		gciCallsign:       strings.ReplaceAll(callsign, " ", ""),
 		enableTextLogging: enableTextLogging,
+		namedAreas:        namedAreas,
 	}
 }
 
@@ -42,55 +48,99 @@ const (
 	radioCheck string = "radio"
 	spiked     string = "spiked"
 	snaplock   string = "snaplock"
+	status     string = "status"
 	tripwire   string = "tripwire"
+	training   string = "training"
+	groundDope string = "troops"
+	survivor   string = "survivor"
+	admin      string = "admin"
+	gameplan   string = "gameplan"
+	sayAgain   string = "sayagain"
+	tally      string = "tally"
+	noJoy      string = "nojoy"
+	visual     string = "visual"
+	blind      string = "blind"
+	press      string = "press"
+	tanker     string = "vectortotanker"
+	mute       string = "stopbroadcasts"
+	unmute     string = "resume"
 )
 
-var requestWords = []string{radioCheck, alphaCheck, bogeyDope, declare, picture, spiked, snaplock, tripwire}
+var requestWords = []string{radioCheck, alphaCheck, bogeyDope, declare, picture, spiked, snaplock, status, tripwire, training, groundDope, survivor, admin, gameplan, sayAgain, tally, noJoy, visual, blind, press, tanker, mute, unmute}
 
 var alternateRequestWords = map[string]string{
-	"voki":        bogeyDope,
-	"snap lock":   snaplock,
-	"radiocheck":  radioCheck,
-	"pogy dope":   bogeyDope,
-	"pogito":      bogeyDope,
-	"oogie":       bogeyDope,
-	"okey":        bogeyDope,
-	"ogidope":     bogeyDope,
-	"ogi dope":    bogeyDope,
-	"ogi dop":     bogeyDope,
-	"ogi doke":    bogeyDope,
-	"lucky dope":  bogeyDope,
-	"fogy dope":   bogeyDope,
-	"foggydope":   bogeyDope,
-	"declared":    declare,
-	"comsjack":    radioCheck,
-	"coms":        radioCheck,
-	"comps check": radioCheck,
-	"comp check":  radioCheck,
-	"commshack":   radioCheck,
-	"Commscheck":  radioCheck,
-	"comms":       radioCheck,
-	"comm":        radioCheck,
-	"comcheck":    radioCheck,
-	"com check":   radioCheck,
-	"buggy dope":  bogeyDope,
-	"bug it up":   bogeyDope,
-	"bubby dope":  bogeyDope,
-	"bovido":      bogeyDope,
-	"boogie":      bogeyDope,
-	"boog it up":  bogeyDope,
-	"booby dop":   bogeyDope,
-	"bokeydope":   bogeyDope,
-	"bokey":       bogeyDope,
-	"bokeido":     bogeyDope,
-	"bokeh":       bogeyDope,
-	"bogy":        bogeyDope,
-	"bogueed":     bogeyDope,
-	"bogeydope":   bogeyDope,
-	"bogeydoke":   bogeyDope,
-	"bogeido":     bogeyDope,
-	"bog it up":   bogeyDope,
-	"alphacheck":  alphaCheck,
+	"voki":                bogeyDope,
+	"snap lock":           snaplock,
+	"radiocheck":          radioCheck,
+	"pogy dope":           bogeyDope,
+	"pogito":              bogeyDope,
+	"oogie":               bogeyDope,
+	"okey":                bogeyDope,
+	"ogidope":             bogeyDope,
+	"ogi dope":            bogeyDope,
+	"ogi dop":             bogeyDope,
+	"ogi doke":            bogeyDope,
+	"lucky dope":          bogeyDope,
+	"fogy dope":           bogeyDope,
+	"foggydope":           bogeyDope,
+	"declared":            declare,
+	"comsjack":            radioCheck,
+	"coms":                radioCheck,
+	"comps check":         radioCheck,
+	"comp check":          radioCheck,
+	"commshack":           radioCheck,
+	"Commscheck":          radioCheck,
+	"comms":               radioCheck,
+	"comm":                radioCheck,
+	"comcheck":            radioCheck,
+	"com check":           radioCheck,
+	"buggy dope":          bogeyDope,
+	"bug it up":           bogeyDope,
+	"bubby dope":          bogeyDope,
+	"bovido":              bogeyDope,
+	"boogie":              bogeyDope,
+	"boog it up":          bogeyDope,
+	"booby dop":           bogeyDope,
+	"bokeydope":           bogeyDope,
+	"bokey":               bogeyDope,
+	"bokeido":             bogeyDope,
+	"bokeh":               bogeyDope,
+	"bogy":                bogeyDope,
+	"bogueed":             bogeyDope,
+	"bogeydope":           bogeyDope,
+	"bogeydoke":           bogeyDope,
+	"bogeido":             bogeyDope,
+	"bog it up":           bogeyDope,
+	"alphacheck":          alphaCheck,
+	"say my position":     alphaCheck,
+	"troops in contact":   groundDope,
+	"ground dope":         groundDope,
+	"csar":                survivor,
+	"game plan":           gameplan,
+	"say again":           sayAgain,
+	"say it again":        sayAgain,
+	"repeat last":         sayAgain,
+	"no joy":              noJoy,
+	"tali":                tally,
+	"tally ho":            tally,
+	"vector to tanker":    tanker,
+	"nearest tanker":      tanker,
+	"tanker":              tanker,
+	"stop broadcasting":   mute,
+	"stop broadcasts":     mute,
+	"stop broadcast":      mute,
+	"resume broadcasting": unmute,
+	"resume broadcasts":   unmute,
+	"resume broadcast":    unmute,
+}
+
+// sightings maps request words to the sightings they report.
+var sightings = map[string]brevity.Sighting{
+	tally:  brevity.Tally,
+	noJoy:  brevity.NoJoy,
+	visual: brevity.Visual,
+	blind:  brevity.Blind,
+	press:  brevity.Press,
 }
 
 func IsSimilar(a, b string) bool {
@@ -114,6 +164,14 @@ func (p *parser) findGCICallsign(fields []string) (string, string, bool) {
 	return "", "", false
 }
 
+// HasWakePhrase returns true if the text starts with something that sounds like the given GCI callsign or ANYFACE. This
+// is a cheaper check than Parse, useful for discarding transmissions that are not addressed to the GCI.
+func HasWakePhrase(tx string, callsign string) bool {
+	p := &parser{gciCallsign: strings.ReplaceAll(callsign, " ", "")}
+	_, _, ok := p.findGCICallsign(strings.Fields(normalize(tx)))
+	return ok
+}
+
 func findRequestWord(fields []string) (string, int, bool) {
 	for i, field := range fields {
 		for _, word := range requestWords {
@@ -129,14 +187,17 @@ func normalize(tx string) string {
 	tx, _, _ = strings.Cut(tx, "|")
 	tx = strings.ToLower(tx)
 	tx = strings.ReplaceAll(tx, "-", " ")
+	tx = strings.ReplaceAll(tx, "/", " ")
 	for _, r := range tx {
 		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r) {
 			tx = strings.ReplaceAll(tx, string(r), "")
 		}
 	}
-	tx = strings.TrimSpace(tx)
+	// Pad the text so that alternate words are only replaced when they are whole words. Otherwise, words like
+	// "commit" would be mangled by the alternate word "comm".
+	tx = " " + strings.Join(strings.Fields(tx), " ") + " "
 	for alt, word := range alternateRequestWords {
-		tx = strings.ReplaceAll(tx, alt, word)
+		tx = strings.ReplaceAll(tx, " "+alt+" ", " "+word+" ")
 	}
 	tx = strings.Join(strings.Fields(tx), " ")
 	return tx
@@ -221,6 +282,14 @@ func (p *parser) Parse(tx string) any {
 	if !foundPilotCallsign && foundRequestWord && requestWord == picture {
 		return &brevity.PictureRequest{Callsign: ""}
 	}
+	if !foundPilotCallsign && foundRequestWord && requestWord == status {
+		return &brevity.HealthRequest{}
+	}
+	if !foundPilotCallsign && foundRequestWord && (requestWord == mute || requestWord == unmute) {
+		if request, ok := parseMuteTarget(requestArgs, requestWord == mute); ok {
+			return request
+		}
+	}
 	if !foundPilotCallsign {
 		logger.Trace().Msg("no pilot callsign found")
 		return &brevity.UnableToUnderstandRequest{}
@@ -233,13 +302,36 @@ func (p *parser) Parse(tx string) any {
 	// Try to parse a request from the remaining text.
 	switch requestWord {
 	case alphaCheck:
-		return &brevity.AlphaCheckRequest{Callsign: pilotCallsign}
+		return parseAlphaCheck(pilotCallsign, requestArgs)
 	case radioCheck:
 		return &brevity.RadioCheckRequest{Callsign: pilotCallsign}
 	case picture:
-		return &brevity.PictureRequest{Callsign: pilotCallsign}
+		return parsePicture(pilotCallsign, requestArgs)
+	case status:
+		return &brevity.StatusRequest{Callsign: pilotCallsign}
 	case tripwire:
 		return &brevity.TripwireRequest{Callsign: pilotCallsign}
+	case training:
+		return parseTraining(pilotCallsign, requestArgs)
+	case groundDope:
+		return &brevity.GroundDopeRequest{Callsign: pilotCallsign}
+	case survivor:
+		return &brevity.SurvivorRequest{Callsign: pilotCallsign}
+	case tanker:
+		return &brevity.TankerRequest{Callsign: pilotCallsign}
+	case mute, unmute:
+		return &brevity.MuteRequest{Callsign: pilotCallsign, Muted: requestWord == mute}
+	case gameplan:
+		return parseGameplan(pilotCallsign, requestArgs)
+	case sayAgain:
+		return &brevity.SayAgainRequest{Callsign: pilotCallsign}
+	case tally, noJoy, visual, blind, press:
+		return &brevity.SightingRequest{Callsign: pilotCallsign, Sighting: sightings[requestWord]}
+	case admin:
+		if request, ok := parseAdmin(pilotCallsign, requestArgs); ok {
+			return request
+		}
+		return &brevity.UnableToUnderstandRequest{Callsign: pilotCallsign}
 	}
 
 	event = logger.Debug()
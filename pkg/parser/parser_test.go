@@ -73,7 +73,7 @@ func TestParserSadPaths(t *testing.T) {
 	}
 	runParserTestCases(
 		t,
-		New(TestCallsign, true),
+		New(TestCallsign, true, nil),
 		testCases,
 		func(*testing.T, parserTestCase, any) {},
 	)
@@ -100,12 +100,34 @@ func TestParserAlphaCheck(t *testing.T) {
 				Callsign: "intruder 1 1",
 			},
 		},
+		{
+			text: "anyface, dustoff 1, alpha check grid",
+			expected: &brevity.AlphaCheckRequest{
+				Callsign: "dustoff 1",
+				Format:   brevity.GridFormat,
+			},
+		},
+		{
+			text: "anyface, dustoff 1, say my position in grid",
+			expected: &brevity.AlphaCheckRequest{
+				Callsign: "dustoff 1",
+				Format:   brevity.GridFormat,
+			},
+		},
+		{
+			text: "anyface, sandy 1, say my position in lat long",
+			expected: &brevity.AlphaCheckRequest{
+				Callsign: "sandy 1",
+				Format:   brevity.LatLongFormat,
+			},
+		},
 	}
-	runParserTestCases(t, New(TestCallsign, true), testCases, func(t *testing.T, test parserTestCase, request any) {
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
 		t.Helper()
 		expected := test.expected.(*brevity.AlphaCheckRequest)
 		actual := request.(*brevity.AlphaCheckRequest)
 		require.Equal(t, expected.Callsign, actual.Callsign)
+		require.Equal(t, expected.Format, actual.Format)
 	})
 }
 
@@ -161,7 +183,7 @@ func TestParserRadioCheck(t *testing.T) {
 			},
 		},
 	}
-	runParserTestCases(t, New(TestCallsign, true), testCases, func(t *testing.T, test parserTestCase, request any) {
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
 		t.Helper()
 		expected := test.expected.(*brevity.RadioCheckRequest)
 		actual := request.(*brevity.RadioCheckRequest)
@@ -169,6 +191,84 @@ func TestParserRadioCheck(t *testing.T) {
 	})
 }
 
+func TestParserStatus(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text: "anyface eagle 1 status",
+			expected: &brevity.StatusRequest{
+				Callsign: "eagle 1",
+			},
+		},
+		{
+			text: "Skyeye, Mobius 1, request status",
+			expected: &brevity.StatusRequest{
+				Callsign: "mobius 1",
+			},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.StatusRequest)
+		actual := request.(*brevity.StatusRequest)
+		require.Equal(t, expected.Callsign, actual.Callsign)
+	})
+}
+
+func TestParserHealth(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text:     "Skyeye, status",
+			expected: &brevity.HealthRequest{},
+		},
+		{
+			text:     "anyface status",
+			expected: &brevity.HealthRequest{},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		require.Equal(t, test.expected, request)
+	})
+}
+
+func TestParserSayAgain(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text: "anyface eagle 1 say again",
+			expected: &brevity.SayAgainRequest{
+				Callsign: "eagle 1",
+			},
+		},
+		{
+			text: "Skyeye, Mobius 1, say again?",
+			expected: &brevity.SayAgainRequest{
+				Callsign: "mobius 1",
+			},
+		},
+		{
+			text: "Skyeye, Mobius 1, can you say it again",
+			expected: &brevity.SayAgainRequest{
+				Callsign: "mobius 1",
+			},
+		},
+		{
+			text: "anyface wildcat 1 1 repeat last",
+			expected: &brevity.SayAgainRequest{
+				Callsign: "wildcat 1 1",
+			},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.SayAgainRequest)
+		actual := request.(*brevity.SayAgainRequest)
+		require.Equal(t, expected.Callsign, actual.Callsign)
+	})
+}
+
 func TestIsSimilar(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -187,3 +287,24 @@ func TestIsSimilar(t *testing.T) {
 		})
 	}
 }
+
+func TestHasWakePhrase(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		text     string
+		expected bool
+	}{
+		{"anyface", true},
+		{"Anyface, Mobius 1", true},
+		{"Skyeye, Mobius 1, radio check", true},
+		{"Sky Eye Mobius", true},
+		{"Mobius 1, fox 3", false},
+		{"", false},
+	}
+	for _, test := range testCases {
+		t.Run(test.text, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, HasWakePhrase(test.text, TestCallsign))
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package parser
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// fullWords are words which ask for every group in a PICTURE to be described.
+var fullWords = []string{"full", "all", "complete", "everything"}
+
+// parsePicture parses a PICTURE request. If the caller asks for BRAA, the PICTURE is anchored on their aircraft. If
+// the caller asks for a FULL PICTURE, every group is described.
+func parsePicture(callsign string, args []string) *brevity.PictureRequest {
+	isBRAA := strings.Contains(strings.Join(args, " "), "b r a a") || slices.ContainsFunc(args, func(arg string) bool {
+		return slices.Contains(braaWords, arg)
+	})
+	isFull := slices.ContainsFunc(args, func(arg string) bool {
+		return slices.Contains(fullWords, arg)
+	})
+	return &brevity.PictureRequest{Callsign: callsign, BRAA: isBRAA, Full: isFull}
+}
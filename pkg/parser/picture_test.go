@@ -28,11 +28,55 @@ func TestParserPicture(t *testing.T) {
 				Callsign: "",
 			},
 		},
+		{
+			text: "anyface, mobius 1 picture BRAA",
+			expected: &brevity.PictureRequest{
+				Callsign: "mobius 1",
+				BRAA:     true,
+			},
+		},
+		{
+			text: "anyface, mobius 1 picture bra",
+			expected: &brevity.PictureRequest{
+				Callsign: "mobius 1",
+				BRAA:     true,
+			},
+		},
+		{
+			text: "anyface, mobius 1 request picture B-R-A-A",
+			expected: &brevity.PictureRequest{
+				Callsign: "mobius 1",
+				BRAA:     true,
+			},
+		},
+		{
+			text: "anyface, mobius 1 picture, full",
+			expected: &brevity.PictureRequest{
+				Callsign: "mobius 1",
+				Full:     true,
+			},
+		},
+		{
+			text: "anyface, mobius 1 request picture BRAA all",
+			expected: &brevity.PictureRequest{
+				Callsign: "mobius 1",
+				BRAA:     true,
+				Full:     true,
+			},
+		},
+		{
+			text: "anyface, mobius 1 picture bullseye",
+			expected: &brevity.PictureRequest{
+				Callsign: "mobius 1",
+			},
+		},
 	}
-	runParserTestCases(t, New(TestCallsign, true), testCases, func(t *testing.T, test parserTestCase, request any) {
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
 		t.Helper()
 		expected := test.expected.(*brevity.PictureRequest)
 		actual := request.(*brevity.PictureRequest)
 		assert.Equal(t, expected.Callsign, actual.Callsign)
+		assert.Equal(t, expected.BRAA, actual.BRAA)
+		assert.Equal(t, expected.Full, actual.Full)
 	})
 }
@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParserSighting(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text:     "anyface eagle 1 tally",
+			expected: &brevity.SightingRequest{Callsign: "eagle 1", Sighting: brevity.Tally},
+		},
+		{
+			text:     "Skyeye, Mobius 1, tally ho.",
+			expected: &brevity.SightingRequest{Callsign: "mobius 1", Sighting: brevity.Tally},
+		},
+		{
+			text:     "Skyeye, Mobius 1, tally two",
+			expected: &brevity.SightingRequest{Callsign: "mobius 1", Sighting: brevity.Tally},
+		},
+		{
+			text:     "anyface eagle 1 no joy",
+			expected: &brevity.SightingRequest{Callsign: "eagle 1", Sighting: brevity.NoJoy},
+		},
+		{
+			text:     "anyface eagle 1 visual",
+			expected: &brevity.SightingRequest{Callsign: "eagle 1", Sighting: brevity.Visual},
+		},
+		{
+			text:     "anyface eagle 1 blind",
+			expected: &brevity.SightingRequest{Callsign: "eagle 1", Sighting: brevity.Blind},
+		},
+		{
+			text:     "anyface eagle 1 press",
+			expected: &brevity.SightingRequest{Callsign: "eagle 1", Sighting: brevity.Press},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.SightingRequest)
+		actual := request.(*brevity.SightingRequest)
+		require.Equal(t, expected.Callsign, actual.Callsign)
+		require.Equal(t, expected.Sighting, actual.Sighting)
+	})
+}
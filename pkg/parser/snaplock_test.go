@@ -46,7 +46,7 @@ func TestParserSnaplock(t *testing.T) {
 			},
 		},
 	}
-	runParserTestCases(t, New(TestCallsign, true), testCases, func(t *testing.T, test parserTestCase, request any) {
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
 		t.Helper()
 		expected := test.expected.(*brevity.SnaplockRequest)
 		actual := request.(*brevity.SnaplockRequest)
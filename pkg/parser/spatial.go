@@ -98,6 +98,21 @@ func (p *parser) parseRange(scanner *bufio.Scanner) (unit.Length, bool) {
 	return unit.Length(d) * unit.NauticalMile, true
 }
 
+// altitudeUnits are words which may precede an altitude to give it in units other than feet. "Angels" gives the
+// altitude in thousands of feet, and "cherubs" in hundreds of feet.
+var altitudeUnits = map[string]unit.Length{
+	"angels":  1000 * unit.Foot,
+	"cherubs": 100 * unit.Foot,
+}
+
+// spokenMultipliers are words which may follow a number in a spoken altitude, e.g. "fifteen thousand".
+var spokenMultipliers = map[string]int{
+	"thousand":  1000,
+	"thousands": 1000,
+	"hundred":   100,
+}
+
+// parseAltitude parses an altitude in feet, e.g. "12000" or "fifteen thousand", or in angels/cherubs, e.g. "angels 12".
 func (p *parser) parseAltitude(scanner *bufio.Scanner) (unit.Length, bool) {
 	if !scanner.Scan() {
 		return 0, false
@@ -105,11 +120,27 @@ func (p *parser) parseAltitude(scanner *bufio.Scanner) (unit.Length, bool) {
 	if !skipWords(scanner, "at", "altitude") {
 		return 0, false
 	}
+	multiplier := unit.Foot
+	for word, length := range altitudeUnits {
+		if IsSimilar(scanner.Text(), word) {
+			if !scanner.Scan() {
+				return 0, false
+			}
+			multiplier = length
+			break
+		}
+	}
 	d, ok := p.parseNaturalNumber(scanner)
 	if !ok {
 		return 0, false
 	}
-	return unit.Length(d) * unit.Foot, true
+	if scanner.Scan() {
+		if m, ok := spokenMultipliers[scanner.Text()]; ok {
+			d *= m
+			scanner.Scan()
+		}
+	}
+	return unit.Length(d) * multiplier, true
 }
 
 func (p *parser) parseTrack(scanner *bufio.Scanner) brevity.Track {
@@ -27,7 +27,7 @@ func TestParserSpiked(t *testing.T) {
 			},
 		},
 	}
-	runParserTestCases(t, New(TestCallsign, true), testCases, func(t *testing.T, test parserTestCase, request any) {
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
 		t.Helper()
 		expected := test.expected.(*brevity.SpikedRequest)
 		actual := request.(*brevity.SpikedRequest)
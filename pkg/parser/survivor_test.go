@@ -0,0 +1,32 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParserSurvivor(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text:     "anyface, sandy 1, vector to survivor",
+			expected: &brevity.SurvivorRequest{Callsign: "sandy 1"},
+		},
+		{
+			text:     "anyface, sandy 1, request vector to survivor",
+			expected: &brevity.SurvivorRequest{Callsign: "sandy 1"},
+		},
+		{
+			text:     "anyface, jolly 1, CSAR",
+			expected: &brevity.SurvivorRequest{Callsign: "jolly 1"},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.SurvivorRequest)
+		actual := request.(*brevity.SurvivorRequest)
+		assert.Equal(t, expected.Callsign, actual.Callsign)
+	})
+}
@@ -0,0 +1,32 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParserTanker(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text:     "anyface, mobius 1, vector to tanker",
+			expected: &brevity.TankerRequest{Callsign: "mobius 1"},
+		},
+		{
+			text:     "Skyeye, Mobius 1, request vector to the nearest tanker.",
+			expected: &brevity.TankerRequest{Callsign: "mobius 1"},
+		},
+		{
+			text:     "anyface wildcat 1 1 tanker",
+			expected: &brevity.TankerRequest{Callsign: "wildcat 1 1"},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.TankerRequest)
+		actual := request.(*brevity.TankerRequest)
+		assert.Equal(t, expected.Callsign, actual.Callsign)
+	})
+}
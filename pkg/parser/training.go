@@ -0,0 +1,18 @@
+package parser
+
+import (
+	"slices"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+)
+
+// offWords are words which turn training commentary off.
+var offWords = []string{"off", "of", "stop", "disable", "disabled"}
+
+// parseTraining parses a TRAINING request. Training commentary is turned on unless the caller asks to turn it off.
+func parseTraining(callsign string, args []string) *brevity.TrainingRequest {
+	isOff := slices.ContainsFunc(args, func(arg string) bool {
+		return slices.Contains(offWords, arg)
+	})
+	return &brevity.TrainingRequest{Callsign: callsign, Enabled: !isOff}
+}
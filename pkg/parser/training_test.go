@@ -0,0 +1,42 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParserTraining(t *testing.T) {
+	t.Parallel()
+	testCases := []parserTestCase{
+		{
+			text: "anyface, mobius 1, training on",
+			expected: &brevity.TrainingRequest{
+				Callsign: "mobius 1",
+				Enabled:  true,
+			},
+		},
+		{
+			text: "anyface, mobius 1, request training",
+			expected: &brevity.TrainingRequest{
+				Callsign: "mobius 1",
+				Enabled:  true,
+			},
+		},
+		{
+			text: "anyface, mobius 1, training off",
+			expected: &brevity.TrainingRequest{
+				Callsign: "mobius 1",
+				Enabled:  false,
+			},
+		},
+	}
+	runParserTestCases(t, New(TestCallsign, true, nil), testCases, func(t *testing.T, test parserTestCase, request any) {
+		t.Helper()
+		expected := test.expected.(*brevity.TrainingRequest)
+		actual := request.(*brevity.TrainingRequest)
+		assert.Equal(t, expected.Callsign, actual.Callsign)
+		assert.Equal(t, expected.Enabled, actual.Enabled)
+	})
+}
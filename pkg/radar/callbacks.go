@@ -7,17 +7,26 @@ import (
 )
 
 // FadedCallback is a callback function that is called when a group has not been updated by sensors for a timeout period.
-// The group and its coalition are provided.
-type FadedCallback func(group brevity.Group, coalition coalitions.Coalition)
+// The group and its coalition are provided, along with the bullseye of the group's estimated current position based on
+// its last known course and speed. The estimated bullseye is nil if it could not be determined.
+type FadedCallback func(group brevity.Group, extrapolated *brevity.Bullseye, coalition coalitions.Coalition)
 
 func (s *scope) SetFadedCallback(callback FadedCallback) {
 	s.fadedCallback = callback
 }
 
 // RemovedCallback is a callback function that is called when a trackfile is aged out and removed.
-// A copy of the trackfile is provided.
-type RemovedCallback func(trackfile trackfiles.Trackfile)
+// The removed trackfile is provided.
+type RemovedCallback func(trackfile *trackfiles.Trackfile)
 
 func (s *scope) SetRemovedCallback(callback RemovedCallback) {
 	s.removalCallback = callback
 }
+
+// UpdatedCallback is a callback function that is called when a trackfile is painted with a new update. It is called
+// while the scope is locked, so it must return quickly and must not call back into the scope.
+type UpdatedCallback func(trackfile *trackfiles.Trackfile)
+
+func (s *scope) SetUpdatedCallback(callback UpdatedCallback) {
+	s.updatedCallback = callback
+}
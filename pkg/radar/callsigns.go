@@ -0,0 +1,132 @@
+package radar
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/paulmach/orb"
+)
+
+// DuplicateCallsignCallback is a callback function that is called when several aircraft share a callsign and
+// [Radar.ResolveCallsign] chooses a different aircraft than it did for the previous lookup of that callsign. The count is
+// the number of aircraft sharing the callsign.
+type DuplicateCallsignCallback func(callsign string, trackfile *trackfiles.Trackfile, count int)
+
+func (s *scope) SetDuplicateCallsignCallback(callback DuplicateCallsignCallback) {
+	s.duplicateCallsignCallback = callback
+}
+
+// callsignKey identifies a callsign within a coalition.
+type callsignKey struct {
+	coalition coalitions.Coalition
+	callsign  string
+}
+
+// callsignSelection is the aircraft chosen by the most recent lookup of a callsign.
+type callsignSelection struct {
+	id uint64
+	// point is where the aircraft was at the time of the lookup.
+	point orb.Point
+	// isAmbiguous is true if several aircraft shared the callsign at the time of the lookup.
+	isAmbiguous bool
+}
+
+// callsignSelector chooses between aircraft which share a callsign, which is common when AI flights are named like
+// player flights.
+type callsignSelector struct {
+	lock sync.Mutex
+	// bound maps callsigns to the ID of the aircraft bound to the SRS client which most recently transmitted.
+	bound map[callsignKey]uint64
+	// selected maps callsigns to the aircraft chosen by the most recent lookup.
+	selected map[callsignKey]callsignSelection
+}
+
+func newCallsignSelector() *callsignSelector {
+	s := &callsignSelector{}
+	s.reset()
+	return s
+}
+
+// BindUnit implements [Radar.BindUnit].
+func (s *scope) BindUnit(id uint64) {
+	trackfile, ok := s.contacts.getByID(id)
+	if !ok {
+		return
+	}
+	key := callsignKey{coalition: trackfile.Contact.Coalition, callsign: indexedCallsign(trackfile)}
+	s.callsigns.lock.Lock()
+	defer s.callsigns.lock.Unlock()
+	s.callsigns.bound[key] = id
+}
+
+// peek returns the trackfile which choose would return, without recording the choice.
+func (s *callsignSelector) peek(
+	coalition coalitions.Coalition,
+	callsign string,
+	candidates []*trackfiles.Trackfile,
+) *trackfiles.Trackfile {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.prefer(callsignKey{coalition: coalition, callsign: callsign}, candidates)
+}
+
+// choose returns the trackfile to use for a lookup of the given callsign, which matched the given trackfiles, and
+// records the choice for the next lookup. The trackfiles must be ordered by ID. If several aircraft share the
+// callsign, the aircraft bound to the transmitting SRS client is preferred, then the aircraft nearest to where the
+// previous lookup's aircraft was, then the aircraft with the lowest ID. The second return value is true if the choice
+// between several aircraft should be announced, because it differs from the previous lookup's choice.
+func (s *callsignSelector) choose(
+	coalition coalitions.Coalition,
+	callsign string,
+	candidates []*trackfiles.Trackfile,
+) (*trackfiles.Trackfile, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := callsignKey{coalition: coalition, callsign: callsign}
+	previous, hasPrevious := s.selected[key]
+	chosen := s.prefer(key, candidates)
+
+	isAmbiguous := len(candidates) > 1
+	s.selected[key] = callsignSelection{
+		id:          chosen.Contact.ID,
+		point:       chosen.LastKnown().Point,
+		isAmbiguous: isAmbiguous,
+	}
+	isChanged := !hasPrevious || !previous.isAmbiguous || previous.id != chosen.Contact.ID
+	return chosen, isAmbiguous && isChanged
+}
+
+// prefer returns the preferred trackfile among the candidates for the given callsign. The lock must be held.
+func (s *callsignSelector) prefer(key callsignKey, candidates []*trackfiles.Trackfile) *trackfiles.Trackfile {
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+	if i := slices.IndexFunc(candidates, func(tf *trackfiles.Trackfile) bool {
+		id, ok := s.bound[key]
+		return ok && tf.Contact.ID == id
+	}); i >= 0 {
+		return candidates[i]
+	}
+	if previous, ok := s.selected[key]; ok {
+		return slices.MinFunc(candidates, func(a, b *trackfiles.Trackfile) int {
+			return cmp.Compare(
+				spatial.Distance(previous.point, a.LastKnown().Point),
+				spatial.Distance(previous.point, b.LastKnown().Point),
+			)
+		})
+	}
+	return candidates[0]
+}
+
+// reset forgets all bindings and previous choices.
+func (s *callsignSelector) reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.bound = make(map[callsignKey]uint64)
+	s.selected = make(map[callsignKey]callsignSelection)
+}
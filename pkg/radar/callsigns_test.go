@@ -0,0 +1,117 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCallsignTestTrackfile(id uint64, point orb.Point) *trackfiles.Trackfile {
+	trackfile := trackfiles.NewTrackfile(trackfiles.Labels{
+		ID:        id,
+		Name:      "Mobius 1",
+		Coalition: coalitions.Blue,
+		ACMIName:  "F-15C",
+	})
+	trackfile.Update(trackfiles.Frame{
+		Time:     time.Now(),
+		Point:    point,
+		Altitude: 20000 * unit.Foot,
+	})
+	return trackfile
+}
+
+func TestChooseSingleCallsign(t *testing.T) {
+	t.Parallel()
+	selector := newCallsignSelector()
+	mobius := newCallsignTestTrackfile(1, orb.Point{33, 33})
+
+	chosen, isChanged := selector.choose(coalitions.Blue, "mobius 1", []*trackfiles.Trackfile{mobius})
+	assert.Same(t, mobius, chosen)
+	assert.False(t, isChanged)
+}
+
+func TestChooseBoundCallsign(t *testing.T) {
+	t.Parallel()
+	s := &scope{contacts: newContactDatabase(), callsigns: newCallsignSelector()}
+	first := newCallsignTestTrackfile(1, orb.Point{33, 33})
+	second := newCallsignTestTrackfile(2, orb.Point{34, 34})
+	s.contacts.set(first)
+	s.contacts.set(second)
+	s.BindUnit(2)
+
+	callsign, chosen := s.FindCallsign("mobius 1", coalitions.Blue)
+	assert.Equal(t, "mobius 1", callsign)
+	assert.Same(t, second, chosen)
+}
+
+func TestFindCallsignHasNoSideEffects(t *testing.T) {
+	t.Parallel()
+	s := &scope{contacts: newContactDatabase(), callsigns: newCallsignSelector()}
+	announcements := 0
+	s.SetDuplicateCallsignCallback(func(string, *trackfiles.Trackfile, int) { announcements++ })
+	ai := newCallsignTestTrackfile(1, orb.Point{33, 33})
+	player := newCallsignTestTrackfile(2, orb.Point{34, 34})
+	s.contacts.set(ai)
+	s.contacts.set(player)
+
+	_, found := s.FindCallsign("mobius 1", coalitions.Blue)
+	assert.Same(t, ai, found)
+	assert.Zero(t, announcements, "FindCallsign should not announce the choice")
+	assert.Empty(t, s.callsigns.selected, "FindCallsign should not record the choice")
+
+	s.BindUnit(2)
+	_, resolved := s.ResolveCallsign("mobius 1", coalitions.Blue)
+	assert.Same(t, player, resolved)
+	assert.Equal(t, 1, announcements)
+
+	// Background lookups follow the resolved choice without announcing it again.
+	s.callsigns.bound = make(map[callsignKey]uint64)
+	for range 3 {
+		_, found = s.FindCallsign("mobius 1", coalitions.Blue)
+		assert.Same(t, player, found)
+	}
+	assert.Equal(t, 1, announcements)
+}
+
+func TestChooseNearestCallsign(t *testing.T) {
+	t.Parallel()
+	selector := newCallsignSelector()
+	player := newCallsignTestTrackfile(5, orb.Point{34, 34})
+
+	// The player checks in before an AI flight with the same callsign spawns.
+	chosen, isChanged := selector.choose(coalitions.Blue, "mobius 1", []*trackfiles.Trackfile{player})
+	require.Same(t, player, chosen)
+	require.False(t, isChanged)
+
+	ai := newCallsignTestTrackfile(2, orb.Point{33, 33})
+	candidates := []*trackfiles.Trackfile{ai, player}
+	chosen, isChanged = selector.choose(coalitions.Blue, "mobius 1", candidates)
+	assert.Same(t, player, chosen)
+	assert.True(t, isChanged, "the first ambiguous lookup should be announced")
+
+	chosen, isChanged = selector.choose(coalitions.Blue, "mobius 1", candidates)
+	assert.Same(t, player, chosen)
+	assert.False(t, isChanged, "repeating the same choice should not be announced")
+}
+
+func TestChooseLowestIDCallsign(t *testing.T) {
+	t.Parallel()
+	selector := newCallsignSelector()
+	first := newCallsignTestTrackfile(1, orb.Point{33, 33})
+	second := newCallsignTestTrackfile(2, orb.Point{34, 34})
+
+	chosen, isChanged := selector.choose(coalitions.Blue, "mobius 1", []*trackfiles.Trackfile{first, second})
+	assert.Same(t, first, chosen)
+	assert.True(t, isChanged)
+
+	chosen, isChanged = selector.choose(coalitions.Red, "mobius 1", []*trackfiles.Trackfile{second})
+	assert.Same(t, second, chosen)
+	assert.False(t, isChanged)
+}
@@ -23,7 +23,7 @@ func (s *scope) updateCenterPoint() {
 	for contact := range s.contacts.values() {
 		data, ok := encyclopedia.GetAircraftData(contact.Contact.ACMIName)
 		isArmed := !ok || data.ThreatRadius() > 0
-		isValid := isValidTrack(contact)
+		isValid := s.isValidTrack(contact) && !s.isStale(contact)
 		if isArmed && isValid {
 			contactLocation := contact.LastKnown().Point
 			switch contact.Contact.Coalition {
@@ -15,10 +15,12 @@ import (
 
 // contactDatabase is a thread-safe trackfile database.
 type contactDatabase interface {
-	// getByCallsignAndCoalititon returns the trackfile with the lowest edit distance to the given callsign, or nil if no closely named trackfile was found.
+	// getByCallsignAndCoalititon returns the trackfiles with the callsign that has the lowest edit distance to the given
+	// callsign, or nil if no closely named trackfile was found. There is more than one trackfile if several aircraft
+	// share the callsign. The trackfiles are ordered by unit ID.
 	// The second return value is true if a trackfile was found, and false otherwise.
 	// The callsign in the trackfile may differ from the input callsign!
-	getByCallsignAndCoalititon(string, coalitions.Coalition) (string, *trackfiles.Trackfile, bool)
+	getByCallsignAndCoalititon(string, coalitions.Coalition) (string, []*trackfiles.Trackfile, bool)
 	// getByID returns the trackfile for the given unit ID, or nil if no trackfile was found.
 	// The second return value is true if a trackfile was found, and false otherwise.
 	getByID(uint64) (*trackfiles.Trackfile, bool)
@@ -34,9 +36,10 @@ type contactDatabase interface {
 }
 
 type database struct {
-	lock        sync.RWMutex
-	contacts    map[uint64]*trackfiles.Trackfile
-	callsignIdx map[coalitions.Coalition]map[string]uint64
+	lock     sync.RWMutex
+	contacts map[uint64]*trackfiles.Trackfile
+	// callsignIdx maps each coalition's callsigns to the IDs of the aircraft using the callsign, in ascending order.
+	callsignIdx map[coalitions.Coalition]map[string][]uint64
 }
 
 func newContactDatabase() contactDatabase {
@@ -46,13 +49,13 @@ func newContactDatabase() contactDatabase {
 }
 
 // getByCallsignAndCoalititon implements [contactDatabase.getByCallsignAndCoalititon].
-func (d *database) getByCallsignAndCoalititon(callsign string, coalition coalitions.Coalition) (string, *trackfiles.Trackfile, bool) {
+func (d *database) getByCallsignAndCoalititon(callsign string, coalition coalitions.Coalition) (string, []*trackfiles.Trackfile, bool) {
 	logger := log.With().Str("callsign", callsign).Str("coalition", coalition.String()).Logger()
 	d.lock.RLock()
 	defer d.lock.RUnlock()
 
 	foundCallsign := ""
-	id, ok := d.callsignIdx[coalition][callsign]
+	ids, ok := d.callsignIdx[coalition][callsign]
 	if ok {
 		foundCallsign = callsign
 	} else {
@@ -68,13 +71,18 @@ func (d *database) getByCallsignAndCoalititon(callsign string, coalition coaliti
 			return "", nil, false
 		}
 		logger.Info().Str("foundCallsign", foundCallsign).Msg("similar callsign found in index")
-		id = d.callsignIdx[coalition][foundCallsign]
+		ids = d.callsignIdx[coalition][foundCallsign]
 	}
-	contact, ok := d.contacts[id]
-	if !ok {
+	contacts := make([]*trackfiles.Trackfile, 0, len(ids))
+	for _, id := range ids {
+		if contact, ok := d.contacts[id]; ok {
+			contacts = append(contacts, contact)
+		}
+	}
+	if len(contacts) == 0 {
 		return "", nil, false
 	}
-	return foundCallsign, contact, true
+	return foundCallsign, contacts, true
 }
 
 // getByID implements [contactDatabase.getByID].
@@ -94,14 +102,23 @@ func (d *database) set(trackfile *trackfiles.Trackfile) {
 	d.lock.Lock()
 	defer d.lock.Unlock()
 
+	callsign := indexedCallsign(trackfile)
+	ids := d.callsignIdx[trackfile.Contact.Coalition][callsign]
+	if i, found := slices.BinarySearch(ids, trackfile.Contact.ID); !found {
+		d.callsignIdx[trackfile.Contact.Coalition][callsign] = slices.Insert(ids, i, trackfile.Contact.ID)
+	}
+	d.contacts[trackfile.Contact.ID] = trackfile
+}
+
+// indexedCallsign returns the callsign a trackfile is indexed by.
+func indexedCallsign(trackfile *trackfiles.Trackfile) string {
 	// TODO get this string munging out of here
 	callsign, _, _ := strings.Cut(trackfile.Contact.Name, "|")
 	callsign, ok := parser.ParsePilotCallsign(callsign)
 	if !ok {
 		callsign = trackfile.Contact.Name
 	}
-	d.callsignIdx[trackfile.Contact.Coalition][callsign] = trackfile.Contact.ID
-	d.contacts[trackfile.Contact.ID] = trackfile
+	return callsign
 }
 
 // delete implements [contactDatabase.delete].
@@ -111,7 +128,12 @@ func (d *database) delete(id uint64) bool {
 
 	contact, ok := d.contacts[id]
 	if ok {
-		delete(d.callsignIdx[contact.Contact.Coalition], contact.Contact.Name)
+		index := d.callsignIdx[contact.Contact.Coalition]
+		callsign := indexedCallsign(contact)
+		index[callsign] = slices.DeleteFunc(index[callsign], func(i uint64) bool { return i == id })
+		if len(index[callsign]) == 0 {
+			delete(index, callsign)
+		}
 	}
 	delete(d.contacts, id)
 
@@ -123,9 +145,9 @@ func (d *database) reset() {
 	d.lock.Lock()
 	defer d.lock.Unlock()
 	d.contacts = make(map[uint64]*trackfiles.Trackfile)
-	d.callsignIdx = make(map[coalitions.Coalition]map[string]uint64)
+	d.callsignIdx = make(map[coalitions.Coalition]map[string][]uint64)
 	for _, c := range []coalitions.Coalition{coalitions.Blue, coalitions.Red, coalitions.Neutrals} {
-		d.callsignIdx[c] = make(map[string]uint64)
+		d.callsignIdx[c] = make(map[string][]uint64)
 	}
 }
 
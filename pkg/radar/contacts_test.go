@@ -24,18 +24,18 @@ func TestGetByCallsign(t *testing.T) {
 	})
 	db.set(trackfile)
 
-	name, tf, ok := db.getByCallsignAndCoalititon("mobius 1", coalitions.Blue)
+	name, tfs, ok := db.getByCallsignAndCoalititon("mobius 1", coalitions.Blue)
 	require.True(t, ok)
 	assert.Equal(t, "mobius 1", name)
-	assert.EqualValues(t, trackfile, tf)
+	assert.EqualValues(t, []*trackfiles.Trackfile{trackfile}, tfs)
 
 	_, _, ok = db.getByCallsignAndCoalititon("mobius 1", coalitions.Red)
 	require.False(t, ok)
 
-	name, tf, ok = db.getByCallsignAndCoalititon("moebius 1", coalitions.Blue)
+	name, tfs, ok = db.getByCallsignAndCoalititon("moebius 1", coalitions.Blue)
 	require.True(t, ok)
 	assert.Equal(t, "mobius 1", name)
-	assert.EqualValues(t, trackfile, tf)
+	assert.EqualValues(t, []*trackfiles.Trackfile{trackfile}, tfs)
 
 	_, _, ok = db.getByCallsignAndCoalititon("yellow 13", coalitions.Red)
 	assert.False(t, ok)
@@ -68,13 +68,46 @@ func TestRealCallsigns(t *testing.T) {
 	for i, test := range testCases {
 		parsedCallsign, ok := parser.ParsePilotCallsign(test.Name)
 		require.True(t, ok)
-		foundCallsign, tf, ok := db.getByCallsignAndCoalititon(test.heardAs, coalitions.Blue)
+		foundCallsign, tfs, ok := db.getByCallsignAndCoalititon(test.heardAs, coalitions.Blue)
 		require.True(t, ok, "queried %s, expected %s, but result was %v", test.heardAs, test.Name, ok)
 		assert.Equal(t, parsedCallsign, foundCallsign)
-		assert.EqualValues(t, uint64(i), tf.Contact.ID)
+		require.Len(t, tfs, 1)
+		assert.EqualValues(t, uint64(i), tfs[0].Contact.ID)
 	}
 }
 
+func TestGetByDuplicateCallsign(t *testing.T) {
+	t.Parallel()
+	db := newContactDatabase()
+	for _, id := range []uint64{3, 1, 2} {
+		db.set(trackfiles.NewTrackfile(trackfiles.Labels{
+			ID:        id,
+			Name:      "Mobius 1",
+			Coalition: coalitions.Blue,
+			ACMIName:  "F-15C",
+		}))
+	}
+
+	_, tfs, ok := db.getByCallsignAndCoalititon("mobius 1", coalitions.Blue)
+	require.True(t, ok)
+	require.Len(t, tfs, 3)
+	for i, tf := range tfs {
+		assert.EqualValues(t, uint64(i+1), tf.Contact.ID)
+	}
+
+	require.True(t, db.delete(2))
+	_, tfs, ok = db.getByCallsignAndCoalititon("mobius 1", coalitions.Blue)
+	require.True(t, ok)
+	require.Len(t, tfs, 2)
+	assert.EqualValues(t, 1, tfs[0].Contact.ID)
+	assert.EqualValues(t, 3, tfs[1].Contact.ID)
+
+	require.True(t, db.delete(1))
+	require.True(t, db.delete(3))
+	_, _, ok = db.getByCallsignAndCoalititon("mobius 1", coalitions.Blue)
+	assert.False(t, ok)
+}
+
 func TestGetByID(t *testing.T) {
 	t.Parallel()
 	db := newContactDatabase()
@@ -4,21 +4,19 @@ import (
 	"context"
 	"time"
 
+	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/spatial"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
 )
 
-func isTrackfileInGroup(trackfile *trackfiles.Trackfile, grp *group) bool {
-	for _, contact := range grp.contacts {
-		if contact.Contact.ID == trackfile.Contact.ID {
-			return true
-		}
-	}
-	return false
-}
+// maxFadedExtrapolation is the longest time a faded group's position is extrapolated from its last known position.
+// Beyond this, the group has had too much time to maneuver for the estimate to be useful.
+const maxFadedExtrapolation = 2 * time.Minute
 
-// collectFaded continuously collects faded contacts. When there is no new faded contact for 10 seconds,
-// it collects all faded contacts into groups, removes the contacts from the database, and calls the fadedCallback.
+// collectFaded continuously collects faded contacts. When there is no new faded contact for the faded window, it
+// collects all faded contacts into groups, removes the contacts from the database, and calls the fadedCallback.
 func (s *scope) collectFaded(ctx context.Context) {
 	collectedFades := []sim.Faded{}
 
@@ -26,7 +24,7 @@ func (s *scope) collectFaded(ctx context.Context) {
 	var deadline time.Time
 
 	// We check the deadline at intervals.
-	ticker := time.NewTicker(10 * time.Second)
+	ticker := time.NewTicker(time.Second)
 
 	defer ticker.Stop()
 	for {
@@ -36,7 +34,7 @@ func (s *scope) collectFaded(ctx context.Context) {
 		case fade := <-s.fades:
 			// When we receive a faded contact, we wait a little in case it's wingman is also fading.
 			// This is common if the flight lands or is being engaged by a coordinated flight.
-			deadline = time.Now().Add(15 * time.Second)
+			deadline = time.Now().Add(s.fadedWindow)
 			collectedFades = append(collectedFades, fade)
 		case <-ticker.C:
 			if len(collectedFades) > 0 && time.Now().After(deadline) {
@@ -50,40 +48,86 @@ func (s *scope) collectFaded(ctx context.Context) {
 
 // handleFaded collects faded contacts into groups, removes the contacts from the database, and calls the fadedCallback.
 func (s *scope) handleFaded(fades []sim.Faded) {
-	var groups []group
+	s.snapshotLock.Lock()
+	faded := make([]*trackfiles.Trackfile, 0, len(fades))
 	for _, fade := range fades {
 		// Find the trackfile for the faded contact
 		trackfile, ok := s.contacts.getByID(fade.ID)
-		if !ok {
-			continue
-		}
-
-		// Check if the trackfile is already collected into a group
-		isGrouped := false
-		for _, grp := range groups {
-			if isTrackfileInGroup(trackfile, &grp) {
-				isGrouped = true
-				break
-			}
-		}
-		// If the trackfile is not already collected into a group, create a new group
-		if !isGrouped {
-			grp := s.findGroupForAircraft(trackfile)
-			if grp != nil {
-				groups = append(groups, *grp)
-			}
+		if ok {
+			faded = append(faded, trackfile)
 		}
 	}
+	groups := s.groupFaded(faded)
 
 	// remove the faded contacts from the database
 	for _, fade := range fades {
 		s.contacts.delete(fade.ID)
+		s.stale.Delete(fade.ID)
+		s.lastFast.Delete(fade.ID)
 	}
+	s.snapshotLock.Unlock()
+
+	s.notifyFaded(groups)
+}
+
+// groupFaded collects the given faded trackfiles into groups, so that contacts which faded together are reported in a
+// single call.
+func (s *scope) groupFaded(faded []*trackfiles.Trackfile) []group {
+	groups := make([]group, 0)
+	for _, contacts := range clusterFaded(faded, s.fadedRadius) {
+		bullseye := s.Bullseye(contacts[0].Contact.Coalition)
+		groups = append(groups, group{
+			bullseye:    &bullseye,
+			contacts:    contacts,
+			declaration: brevity.Unable,
+			terrain:     s.terrain,
+			wind:        s.wind,
+		})
+	}
+	return groups
+}
+
+// clusterFaded divides the given faded trackfiles into clusters of the same coalition, where each trackfile is within
+// the given radius of another trackfile in its cluster. If the radius is zero, each trackfile is its own cluster.
+func clusterFaded(faded []*trackfiles.Trackfile, radius unit.Length) [][]*trackfiles.Trackfile {
+	clusters := make([][]*trackfiles.Trackfile, 0, len(faded))
+	if radius == 0 {
+		for _, trackfile := range faded {
+			clusters = append(clusters, []*trackfiles.Trackfile{trackfile})
+		}
+		return clusters
+	}
+	isClustered := make([]bool, len(faded))
+	for i := range faded {
+		if isClustered[i] {
+			continue
+		}
+		isClustered[i] = true
+		cluster := []*trackfiles.Trackfile{faded[i]}
+		// Grow the cluster until no other trackfile is near any of its members.
+		for j := 0; j < len(cluster); j++ {
+			member := cluster[j]
+			for k, other := range faded {
+				if isClustered[k] || other.Contact.Coalition != member.Contact.Coalition {
+					continue
+				}
+				if spatial.Distance(member.LastKnown().Point, other.LastKnown().Point) <= radius {
+					isClustered[k] = true
+					cluster = append(cluster, other)
+				}
+			}
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters
+}
 
-	// call the faded callback for each group
+// notifyFaded calls the faded callback for each group.
+func (s *scope) notifyFaded(groups []group) {
 	for _, grp := range groups {
 		if s.fadedCallback != nil {
-			s.fadedCallback(&grp, grp.contacts[0].Contact.Coalition)
+			extrapolated := grp.extrapolatedBullseye(s.missionTime, maxFadedExtrapolation)
+			s.fadedCallback(&grp, extrapolated, grp.contacts[0].Contact.Coalition)
 		}
 	}
 }
@@ -0,0 +1,55 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterFaded(t *testing.T) {
+	t.Parallel()
+	center := orb.Point{42.5, 43.5}
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	newTrackfile := func(id uint64, coalition coalitions.Coalition, bearing unit.Angle, distance unit.Length) *trackfiles.Trackfile {
+		trackfile := trackfiles.NewTrackfile(trackfiles.Labels{ID: id, Coalition: coalition})
+		trackfile.Update(trackfiles.Frame{
+			Time:  now,
+			Point: spatial.PointAtBearingAndDistance(center, bearings.NewTrueBearing(bearing), distance),
+		})
+		return trackfile
+	}
+	// 1, 2 and 3 are a chain of contacts each 3 miles apart. 4 is far away, and 5 is near 1 but on the other coalition.
+	faded := []*trackfiles.Trackfile{
+		newTrackfile(1, coalitions.Red, 0, 0),
+		newTrackfile(4, coalitions.Red, 0, 40*unit.NauticalMile),
+		newTrackfile(2, coalitions.Red, 90*unit.Degree, 3*unit.NauticalMile),
+		newTrackfile(5, coalitions.Blue, 180*unit.Degree, unit.NauticalMile),
+		newTrackfile(3, coalitions.Red, 90*unit.Degree, 6*unit.NauticalMile),
+	}
+	ids := func(clusters [][]*trackfiles.Trackfile) [][]uint64 {
+		result := make([][]uint64, 0, len(clusters))
+		for _, cluster := range clusters {
+			clusterIDs := make([]uint64, 0, len(cluster))
+			for _, trackfile := range cluster {
+				clusterIDs = append(clusterIDs, trackfile.Contact.ID)
+			}
+			result = append(result, clusterIDs)
+		}
+		return result
+	}
+
+	assert.Equal(t, [][]uint64{{1, 2, 3}, {4}, {5}}, ids(clusterFaded(faded, 5*unit.NauticalMile)))
+	assert.Equal(t, [][]uint64{{1}, {4}, {2}, {5}, {3}}, ids(clusterFaded(faded, 0)))
+	assert.Empty(t, clusterFaded(nil, 5*unit.NauticalMile))
+
+	// Contacts at exactly the same position are not clustered if clustering is disabled.
+	wingman := newTrackfile(6, coalitions.Red, 0, 0)
+	assert.Equal(t, [][]uint64{{1}, {6}}, ids(clusterFaded([]*trackfiles.Trackfile{faded[0], wingman}, 0)))
+}
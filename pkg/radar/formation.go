@@ -0,0 +1,115 @@
+package radar
+
+import (
+	"math"
+	"slices"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// leadTrailMinimumSeparation is the minimum range between the lead and trail elements, measured along the group's
+	// track. Closer elements are just flying a loose formation.
+	leadTrailMinimumSeparation = 2 * unit.NauticalMile
+	// leadTrailAltitudeThreshold is the minimum altitude difference between the lead and trail elements for the trail
+	// element to be described as high or low.
+	leadTrailAltitudeThreshold = 2000 * unit.Foot
+)
+
+// LeadTrail implements [brevity.Group.LeadTrail].
+func (g *group) LeadTrail() *brevity.LeadTrail {
+	if len(g.contacts) < 2 || g.Track() == brevity.UnknownDirection {
+		return nil
+	}
+	declination, err := bearings.Declination(g.point(), g.missionTime())
+	if err != nil {
+		log.Error().Err(err).Stringer("group", g).Msg("failed to get declination for group")
+	}
+	frames := make([]trackfiles.Frame, 0, len(g.contacts))
+	for _, trackfile := range g.contacts {
+		frames = append(frames, trackfile.LastKnown())
+	}
+	return resolveLeadTrail(g.course().True(declination), frames)
+}
+
+// resolveLeadTrail splits the given contacts into lead and trail elements along the given true course. Returns nil if
+// the contacts are not in a lead-trail formation: if there is no large enough gap between them along the course, or if
+// the elements are offset more abeam of each other than behind each other.
+func resolveLeadTrail(course bearings.Bearing, frames []trackfiles.Frame) *brevity.LeadTrail {
+	if len(frames) < 2 {
+		return nil
+	}
+
+	// Position each contact along and across the course, relative to an arbitrary reference contact.
+	type position struct {
+		along    float64
+		across   float64
+		altitude unit.Length
+	}
+	reference := frames[0].Point
+	positions := make([]position, 0, len(frames))
+	for _, frame := range frames {
+		distance := spatial.Distance(reference, frame.Point).Meters()
+		θ := (spatial.TrueBearing(reference, frame.Point).Degrees() - course.Degrees()) * math.Pi / 180
+		positions = append(positions, position{
+			along:    distance * math.Cos(θ),
+			across:   distance * math.Sin(θ),
+			altitude: frame.Altitude,
+		})
+	}
+
+	// Order from front to back, and split at the largest gap.
+	slices.SortFunc(positions, func(a, b position) int {
+		switch {
+		case a.along > b.along:
+			return -1
+		case a.along < b.along:
+			return 1
+		default:
+			return 0
+		}
+	})
+	split, gap := 0, 0.0
+	for i := 1; i < len(positions); i++ {
+		if g := positions[i-1].along - positions[i].along; g > gap {
+			split, gap = i, g
+		}
+	}
+	if unit.Length(gap)*unit.Meter < leadTrailMinimumSeparation {
+		return nil
+	}
+
+	centroid := func(element []position) position {
+		var c position
+		for _, p := range element {
+			c.along += p.along / float64(len(element))
+			c.across += p.across / float64(len(element))
+			c.altitude += p.altitude / unit.Length(len(element))
+		}
+		return c
+	}
+	lead := centroid(positions[:split])
+	trail := centroid(positions[split:])
+
+	separation := lead.along - trail.along
+	if math.Abs(lead.across-trail.across) > separation/2 {
+		return nil
+	}
+
+	trailAltitude := brevity.Level
+	if difference := trail.altitude - lead.altitude; difference >= leadTrailAltitudeThreshold {
+		trailAltitude = brevity.High
+	} else if difference <= -leadTrailAltitudeThreshold {
+		trailAltitude = brevity.Low
+	}
+
+	return &brevity.LeadTrail{
+		Separation:    unit.Length(separation) * unit.Meter,
+		TrailAltitude: trailAltitude,
+	}
+}
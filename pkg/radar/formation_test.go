@@ -0,0 +1,86 @@
+package radar
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveLeadTrail(t *testing.T) {
+	t.Parallel()
+	lead := orb.Point{33.0, 42.0}
+	course := bearings.NewTrueBearing(60 * unit.Degree)
+	// at returns a frame at the given position relative to the lead, in nautical miles along and abeam the course.
+	at := func(behind, abeam float64, altitude unit.Length) trackfiles.Frame {
+		point := spatial.PointAtBearingAndDistance(lead, course.Reciprocal(), unit.Length(behind)*unit.NauticalMile)
+		point = spatial.PointAtBearingAndDistance(point, bearings.NewTrueBearing(150*unit.Degree), unit.Length(abeam)*unit.NauticalMile)
+		return trackfiles.Frame{Point: point, Altitude: altitude}
+	}
+
+	testCases := []struct {
+		name               string
+		frames             []trackfiles.Frame
+		expectedSeparation unit.Length
+		expectedAltitude   brevity.RelativeAltitude
+		isLeadTrail        bool
+	}{
+		{
+			name:   "single contact",
+			frames: []trackfiles.Frame{at(0, 0, 20000*unit.Foot)},
+		},
+		{
+			name:   "close formation",
+			frames: []trackfiles.Frame{at(0, 0, 20000*unit.Foot), at(1, 0.5, 20000*unit.Foot)},
+		},
+		{
+			name:   "line abreast",
+			frames: []trackfiles.Frame{at(0, 0, 20000*unit.Foot), at(0, 4, 20000*unit.Foot)},
+		},
+		{
+			name:               "trail level",
+			frames:             []trackfiles.Frame{at(0, 0, 20000*unit.Foot), at(5, 0, 21000*unit.Foot)},
+			expectedSeparation: 5 * unit.NauticalMile,
+			expectedAltitude:   brevity.Level,
+			isLeadTrail:        true,
+		},
+		{
+			name: "two ship elements, trail high",
+			frames: []trackfiles.Frame{
+				at(5, 0, 25000*unit.Foot),
+				at(0, 0, 20000*unit.Foot),
+				at(0.5, 1, 20000*unit.Foot),
+				at(5.5, 1, 25000*unit.Foot),
+			},
+			expectedSeparation: 5 * unit.NauticalMile,
+			expectedAltitude:   brevity.High,
+			isLeadTrail:        true,
+		},
+		{
+			name:               "trail low",
+			frames:             []trackfiles.Frame{at(0, 0, 20000*unit.Foot), at(8, 1, 10000*unit.Foot)},
+			expectedSeparation: 8 * unit.NauticalMile,
+			expectedAltitude:   brevity.Low,
+			isLeadTrail:        true,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual := resolveLeadTrail(course, test.frames)
+			if !test.isLeadTrail {
+				assert.Nil(t, actual)
+				return
+			}
+			require.NotNil(t, actual)
+			assert.InDelta(t, test.expectedSeparation.NauticalMiles(), actual.Separation.NauticalMiles(), 0.1)
+			assert.Equal(t, test.expectedAltitude, actual.TrailAltitude)
+		})
+	}
+}
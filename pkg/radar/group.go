@@ -10,7 +10,9 @@ import (
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/encyclopedia"
 	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/terrain"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/dharmab/skyeye/pkg/weather"
 	"github.com/martinlindhe/unit"
 	"github.com/paulmach/orb"
 	"github.com/rs/zerolog/log"
@@ -26,6 +28,10 @@ type group struct {
 	aspect      *brevity.Aspect
 	declaration brevity.Declaration
 	mergedWith  int
+	// terrain provides the elevation of the terrain beneath the group. May be nil.
+	terrain terrain.Model
+	// wind is used to estimate the group's true airspeed from its ground speed. May be nil.
+	wind *weather.Wind
 }
 
 var _ brevity.Group = &group{}
@@ -108,6 +114,15 @@ func (g *group) course() bearings.Bearing {
 	return g.contacts[0].Course()
 }
 
+// aspectTrend returns how the group's aspect toward the origin is changing.
+func (g *group) aspectTrend(origin orb.Point) brevity.AspectTrend {
+	if len(g.contacts) == 0 {
+		return brevity.SteadyAspect
+	}
+	// TODO interpolate from all members
+	return g.contacts[0].Trend(origin).AspectTrend()
+}
+
 // Aspect implements [brevity.Group.Aspect].
 func (g *group) Aspect() brevity.Aspect {
 	if g.aspect == nil {
@@ -146,14 +161,8 @@ func (g *group) Platforms() []string {
 	platforms := make(map[string]struct{})
 	for _, trackfile := range g.contacts {
 		var name string
-		data, ok := encyclopedia.GetAircraftData(trackfile.Contact.ACMIName)
-		if ok {
-			for _, reportingName := range []string{data.NATOReportingName, data.Nickname, data.OfficialName, data.PlatformDesignation} {
-				if reportingName != "" {
-					name = reportingName
-					break
-				}
-			}
+		if data, ok := encyclopedia.GetAircraftData(trackfile.Contact.ACMIName); ok {
+			name = data.ReportingName()
 		}
 		platforms[name] = struct{}{}
 	}
@@ -169,14 +178,60 @@ func (g *group) High() bool {
 	return g.Altitude() > 40000*unit.Foot
 }
 
+// AboveGroundLevel implements [brevity.Group.AboveGroundLevel].
+func (g *group) AboveGroundLevel() (unit.Length, bool) {
+	if g.terrain == nil || len(g.contacts) == 0 {
+		return 0, false
+	}
+	var highest unit.Length
+	for i, trackfile := range g.contacts {
+		frame := trackfile.LastKnown()
+		elevation, ok := g.terrain.Elevation(frame.Point)
+		if !ok {
+			return 0, false
+		}
+		// Altitude and terrain data don't always agree exactly, so clamp to the ground.
+		height := max(frame.Altitude-elevation, 0)
+		if i == 0 || height > highest {
+			highest = height
+		}
+	}
+	return highest, true
+}
+
+// speed returns the highest ground speed and the highest Mach number of the group's contacts. The Mach number is
+// estimated from the true airspeed if the wind is known, otherwise from the ground speed.
+func (g *group) speed() (unit.Speed, float64) {
+	var groundSpeed unit.Speed
+	var mach float64
+	for _, trackfile := range g.contacts {
+		frame := trackfile.LastKnown()
+		speed := trackfile.Speed()
+		declination, err := bearings.Declination(frame.Point, frame.Time)
+		if err != nil {
+			declination = 0
+		}
+		course := trackfile.Course().True(declination)
+		trueAirspeed := g.wind.TrueAirspeed(course, speed, frame.Altitude)
+		groundSpeed = max(groundSpeed, speed)
+		mach = max(mach, weather.Mach(trueAirspeed, frame.Altitude))
+	}
+	return groundSpeed, mach
+}
+
 // Fast implements [brevity.Group.Fast].
 func (g *group) Fast() bool {
-	return false
+	groundSpeed, mach := g.speed()
+	return (groundSpeed >= 600*unit.Knot || mach >= 1.0) && !isVeryFast(groundSpeed, mach)
 }
 
 // VeryFast implements [brevity.Group.VeryFast].
 func (g *group) VeryFast() bool {
-	return false
+	return isVeryFast(g.speed())
+}
+
+func isVeryFast(groundSpeed unit.Speed, mach float64) bool {
+	return groundSpeed > 900*unit.Knot || mach > 1.5
 }
 
 // MergedWith implements [brevity.Group.MergedWith].
@@ -242,6 +297,17 @@ func (g *group) isFighter() bool {
 	return isFighter
 }
 
+// isNonCombatant returns true if every contact in the group is known to be a non-combatant.
+func (g *group) isNonCombatant() bool {
+	for _, trackfile := range g.contacts {
+		data, ok := encyclopedia.GetAircraftData(trackfile.Contact.ACMIName)
+		if !ok || data.IsCombatant() {
+			return false
+		}
+	}
+	return true
+}
+
 // point returns the center point of the group.
 func (g *group) point() orb.Point {
 	center := g.contacts[0].LastKnown().Point
@@ -251,6 +317,30 @@ func (g *group) point() orb.Point {
 	return center
 }
 
+// extrapolatedBullseye returns the bullseye of the group's estimated position at the given mission time, based on the
+// last known course and speed of each contact. Returns nil if the group has no bullseye, the group's track is unknown,
+// or the group is not estimated to have moved appreciably since it was last observed.
+func (g *group) extrapolatedBullseye(at time.Time, window time.Duration) *brevity.Bullseye {
+	if g.bullseye == nil || g.Track() == brevity.UnknownDirection {
+		return nil
+	}
+	center := g.contacts[0].Extrapolate(at, window)
+	for _, trackfile := range g.contacts[1:] {
+		center = geo.Midpoint(center, trackfile.Extrapolate(at, window))
+	}
+	if spatial.Distance(g.point(), center) < 1*unit.NauticalMile {
+		return nil
+	}
+
+	declination, err := bearings.Declination(*g.bullseye, at)
+	if err != nil {
+		log.Error().Err(err).Stringer("group", g).Msg("failed to get declination for group")
+	}
+	bearing := spatial.TrueBearing(*g.bullseye, center).Magnetic(declination)
+	distance := spatial.Distance(*g.bullseye, center)
+	return brevity.NewBullseye(bearing, distance)
+}
+
 // missionTime returns the mission-time timestamp of the most recent trackfile in the group.
 func (g *group) missionTime() time.Time {
 	var latest time.Time
@@ -285,3 +375,17 @@ func (g *group) ObjectIDs() []uint64 {
 	slices.Sort(ids)
 	return ids
 }
+
+// Tags implements [brevity.Group.Tags].
+func (g *group) Tags() []string {
+	tags := make([]string, 0)
+	for _, trackfile := range g.contacts {
+		for _, tag := range trackfile.Tags() {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	slices.Sort(tags)
+	return tags
+}
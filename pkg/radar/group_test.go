@@ -0,0 +1,118 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/dharmab/skyeye/pkg/weather"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSpeedTestTrackfile returns a trackfile flying due east at the given ground speed and altitude.
+func newSpeedTestTrackfile(groundSpeed unit.Speed, altitude unit.Length) *trackfiles.Trackfile {
+	trackfile := trackfiles.NewTrackfile(trackfiles.Labels{
+		ID:        1,
+		Name:      "Yellow 13",
+		Coalition: coalitions.Red,
+		ACMIName:  "MiG-25PD",
+	})
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	origin := orb.Point{36, 34}
+	for i := range 2 {
+		elapsed := time.Duration(i) * 10 * time.Second
+		distance := unit.Length(groundSpeed.MetersPerSecond()*elapsed.Seconds()) * unit.Meter
+		trackfile.Update(trackfiles.Frame{
+			Time:     start.Add(elapsed),
+			Point:    spatial.PointAtBearingAndDistance(origin, bearings.NewTrueBearing(90*unit.Degree), distance),
+			Altitude: altitude,
+			Heading:  90 * unit.Degree,
+		})
+	}
+	return trackfile
+}
+
+func TestGroupSpeed(t *testing.T) {
+	t.Parallel()
+	jetStream := weather.NewWind(weather.Layer{
+		Altitude:  0,
+		Direction: bearings.NewTrueBearing(270 * unit.Degree),
+		Speed:     150 * unit.Knot,
+	})
+	testCases := []struct {
+		name        string
+		groundSpeed unit.Speed
+		altitude    unit.Length
+		wind        *weather.Wind
+		isFast      bool
+		isVeryFast  bool
+	}{
+		{name: "subsonic", groundSpeed: 450 * unit.Knot, altitude: 30000 * unit.Foot},
+		{name: "fast by ground speed", groundSpeed: 650 * unit.Knot, altitude: 1000 * unit.Foot, isFast: true},
+		{name: "very fast by ground speed", groundSpeed: 950 * unit.Knot, altitude: 1000 * unit.Foot, isVeryFast: true},
+		{name: "fast by Mach", groundSpeed: 590 * unit.Knot, altitude: 40000 * unit.Foot, isFast: true},
+		{name: "very fast by Mach", groundSpeed: 880 * unit.Knot, altitude: 40000 * unit.Foot, isVeryFast: true},
+		{name: "tailwind", groundSpeed: 590 * unit.Knot, altitude: 40000 * unit.Foot, wind: jetStream},
+		{name: "tailwind at high Mach", groundSpeed: 880 * unit.Knot, altitude: 40000 * unit.Foot, wind: jetStream, isFast: true},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			grp := &group{
+				contacts: []*trackfiles.Trackfile{newSpeedTestTrackfile(test.groundSpeed, test.altitude)},
+				wind:     test.wind,
+			}
+			assert.Equal(t, test.isFast, grp.Fast())
+			assert.Equal(t, test.isVeryFast, grp.VeryFast())
+		})
+	}
+}
+
+func TestGroupPlatforms(t *testing.T) {
+	t.Parallel()
+	newTrackfile := func(id uint64, acmiName string) *trackfiles.Trackfile {
+		return trackfiles.NewTrackfile(trackfiles.Labels{ID: id, Coalition: coalitions.Red, ACMIName: acmiName})
+	}
+	grp := &group{
+		contacts: []*trackfiles.Trackfile{
+			newTrackfile(1, "Su-27"),
+			newTrackfile(2, "MB-339A"),
+			newTrackfile(3, "M-2000C"),
+			newTrackfile(4, "Su-27"),
+		},
+	}
+	assert.ElementsMatch(t, []string{"Flanker", "MB-339", "Mirage 2000"}, grp.Platforms())
+}
+
+func TestGroupIsNonCombatant(t *testing.T) {
+	t.Parallel()
+	newTrackfile := func(id uint64, acmiName string) *trackfiles.Trackfile {
+		return trackfiles.NewTrackfile(trackfiles.Labels{ID: id, Coalition: coalitions.Red, ACMIName: acmiName})
+	}
+	testCases := []struct {
+		name           string
+		acmiNames      []string
+		isNonCombatant bool
+	}{
+		{name: "tankers", acmiNames: []string{"KC-135", "S-3B Tanker"}, isNonCombatant: true},
+		{name: "trainers", acmiNames: []string{"L-39C", "C-101EB", "MB-339A/PAN"}, isNonCombatant: true},
+		{name: "armed trainers", acmiNames: []string{"L-39ZA"}, isNonCombatant: false},
+		{name: "escorted tanker", acmiNames: []string{"S-3B Tanker", "Su-27"}, isNonCombatant: false},
+		{name: "unknown type", acmiNames: []string{"KC-135", "Unknown"}, isNonCombatant: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			grp := &group{}
+			for i, acmiName := range test.acmiNames {
+				grp.contacts = append(grp.contacts, newTrackfile(uint64(i+1), acmiName))
+			}
+			assert.Equal(t, test.isNonCombatant, grp.isNonCombatant())
+		})
+	}
+}
@@ -3,12 +3,14 @@ package radar
 import (
 	"slices"
 
+	"github.com/dharmab/skyeye/internal/conf"
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
 	"github.com/dharmab/skyeye/pkg/encyclopedia"
 	"github.com/dharmab/skyeye/pkg/spatial"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
 	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
 )
 
 func (s *scope) enumerateGroups(coalition coalitions.Coalition) []*group {
@@ -24,11 +26,11 @@ func (s *scope) enumerateGroups(coalition coalitions.Coalition) []*group {
 			continue
 		}
 
-		if !isValidTrack(trackfile) {
+		if !s.isValidTrack(trackfile) || s.isStale(trackfile) {
 			continue
 		}
 
-		grp := s.findGroupForAircraft(trackfile)
+		grp := s.findGroupForAircraft(orb.Point{}, trackfile)
 		if grp == nil {
 			continue
 		}
@@ -42,7 +44,9 @@ func (s *scope) enumerateGroups(coalition coalitions.Coalition) []*group {
 }
 
 // findGroupForAircraft creates a new group for the given trackfile and adds all nearby aircraft which can be considered part of the group.
-func (s *scope) findGroupForAircraft(trackfile *trackfiles.Trackfile) *group {
+// The grouping radius depends on the trackfile's range from the origin. If the origin is the zero point, the default
+// grouping radius is used.
+func (s *scope) findGroupForAircraft(origin orb.Point, trackfile *trackfiles.Trackfile) *group {
 	if trackfile == nil {
 		return nil
 	}
@@ -51,20 +55,53 @@ func (s *scope) findGroupForAircraft(trackfile *trackfiles.Trackfile) *group {
 		bullseye:    &bullseye,
 		contacts:    make([]*trackfiles.Trackfile, 0),
 		declaration: brevity.Unable,
+		terrain:     s.terrain,
+		wind:        s.wind,
 	}
 	grp.contacts = append(grp.contacts, trackfile)
-	s.addNearbyAircraftToGroup(trackfile, grp)
+	s.addNearbyAircraftToGroup(trackfile, grp, s.groupingRadius(origin, trackfile))
 	return grp
 }
 
+// defaultGroupingRadius is used when there is no requester to measure range from, or no grouping radii are configured.
+// The spread is increased from the ATP numbers beacause the DCS AI isn't amazing at holding formation.
+const defaultGroupingRadius = 5 * unit.NauticalMile
+
+// groupingRadius returns the radius within which aircraft are grouped with the given trackfile, based on its range
+// from the origin. Like a real controller, the GCI groups more loosely at long range and more tightly at short range.
+func (s *scope) groupingRadius(origin orb.Point, trackfile *trackfiles.Trackfile) unit.Length {
+	if spatial.IsZero(origin) {
+		return defaultGroupingRadius
+	}
+	return interpolateGroupingRadius(s.groupingRadii, spatial.Distance(origin, trackfile.LastKnown().Point))
+}
+
+// interpolateGroupingRadius returns the grouping radius at the given range, interpolating linearly between the given
+// breakpoints. The radius is held constant before the first breakpoint and after the last.
+func interpolateGroupingRadius(radii []conf.GroupingRadius, _range unit.Length) unit.Length {
+	if len(radii) == 0 {
+		return defaultGroupingRadius
+	}
+	if _range <= radii[0].Range {
+		return radii[0].Radius
+	}
+	for i := 1; i < len(radii); i++ {
+		previous, next := radii[i-1], radii[i]
+		if _range < next.Range {
+			fraction := float64(_range-previous.Range) / float64(next.Range-previous.Range)
+			return previous.Radius + unit.Length(fraction)*(next.Radius-previous.Radius)
+		}
+	}
+	return radii[len(radii)-1].Radius
+}
+
 // addNearbyAircraftToGroup recursively adds all nearby aircraft which:
 //   - are of the same coalition
-//   - are within 5 nautical miles in 2D distance of each other
+//   - are within the spread interval in 2D distance of each other
 //   - have similar tags
 //
-// The spread is increased from the ATP numbers beacause the DCS AI isn't amazing at holding formation.
 // We allow mixed platform groups because these are fairly common in DCS.
-func (s *scope) addNearbyAircraftToGroup(this *trackfiles.Trackfile, group *group) {
+func (s *scope) addNearbyAircraftToGroup(this *trackfiles.Trackfile, group *group, spreadInterval unit.Length) {
 	var tag encyclopedia.AircraftTag
 	thisData, ok := encyclopedia.GetAircraftData(this.Contact.ACMIName)
 	if ok {
@@ -76,7 +113,6 @@ func (s *scope) addNearbyAircraftToGroup(this *trackfiles.Trackfile, group *grou
 			tag = encyclopedia.Unarmed
 		}
 	}
-	spreadInterval := 5 * unit.NauticalMile
 	for other := range s.contacts.values() {
 		// Skip if this one is already in the group
 		if slices.Contains(group.ObjectIDs(), other.Contact.ID) {
@@ -108,6 +144,6 @@ func (s *scope) addNearbyAircraftToGroup(this *trackfiles.Trackfile, group *grou
 		}
 
 		group.contacts = append(group.contacts, other)
-		s.addNearbyAircraftToGroup(other, group)
+		s.addNearbyAircraftToGroup(other, group, spreadInterval)
 	}
 }
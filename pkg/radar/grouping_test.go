@@ -0,0 +1,37 @@
+package radar
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/internal/conf"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolateGroupingRadius(t *testing.T) {
+	t.Parallel()
+	radii := []conf.GroupingRadius{
+		{Range: 20 * unit.NauticalMile, Radius: 3 * unit.NauticalMile},
+		{Range: 60 * unit.NauticalMile, Radius: 8 * unit.NauticalMile},
+	}
+	testCases := []struct {
+		name     string
+		radii    []conf.GroupingRadius
+		_range   unit.Length
+		expected unit.Length
+	}{
+		{name: "no breakpoints", radii: nil, _range: 40 * unit.NauticalMile, expected: defaultGroupingRadius},
+		{name: "point blank", radii: radii, _range: 0, expected: 3 * unit.NauticalMile},
+		{name: "first breakpoint", radii: radii, _range: 20 * unit.NauticalMile, expected: 3 * unit.NauticalMile},
+		{name: "between breakpoints", radii: radii, _range: 40 * unit.NauticalMile, expected: 5.5 * unit.NauticalMile},
+		{name: "last breakpoint", radii: radii, _range: 60 * unit.NauticalMile, expected: 8 * unit.NauticalMile},
+		{name: "long range", radii: radii, _range: 150 * unit.NauticalMile, expected: 8 * unit.NauticalMile},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual := interpolateGroupingRadius(test.radii, test._range)
+			assert.InDelta(t, test.expected.NauticalMiles(), actual.NauticalMiles(), 0.01)
+		})
+	}
+}
@@ -1,15 +1,41 @@
 package radar
 
 import (
+	"cmp"
+	"slices"
+
 	"github.com/dharmab/skyeye/pkg/coalitions"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/rs/zerolog/log"
 )
 
+// FindCallsign implements [Radar.FindCallsign].
 func (s *scope) FindCallsign(callsign string, coalition coalitions.Coalition) (string, *trackfiles.Trackfile) {
-	foundCallsign, tf, ok := s.contacts.getByCallsignAndCoalititon(callsign, coalition)
+	foundCallsign, candidates, ok := s.contacts.getByCallsignAndCoalititon(callsign, coalition)
 	if !ok {
 		return callsign, nil
 	}
+	return foundCallsign, s.callsigns.peek(coalition, foundCallsign, candidates)
+}
+
+// ResolveCallsign implements [Radar.ResolveCallsign].
+func (s *scope) ResolveCallsign(callsign string, coalition coalitions.Coalition) (string, *trackfiles.Trackfile) {
+	foundCallsign, candidates, ok := s.contacts.getByCallsignAndCoalititon(callsign, coalition)
+	if !ok {
+		return callsign, nil
+	}
+	tf, isChanged := s.callsigns.choose(coalition, foundCallsign, candidates)
+	if isChanged {
+		log.Info().
+			Str("callsign", foundCallsign).
+			Int("count", len(candidates)).
+			Uint64("id", tf.Contact.ID).
+			Str("name", tf.Contact.Name).
+			Msg("chose between aircraft sharing a callsign")
+		if s.duplicateCallsignCallback != nil {
+			s.duplicateCallsignCallback(foundCallsign, tf, len(candidates))
+		}
+	}
 	return foundCallsign, tf
 }
 
@@ -20,3 +46,12 @@ func (s *scope) FindUnit(id uint64) *trackfiles.Trackfile {
 	}
 	return trackfile
 }
+
+// Trackfiles implements [Radar.Trackfiles].
+func (s *scope) Trackfiles() []*trackfiles.Trackfile {
+	result := slices.Collect(s.contacts.values())
+	slices.SortFunc(result, func(a, b *trackfiles.Trackfile) int {
+		return cmp.Compare(a.Contact.ID, b.Contact.ID)
+	})
+	return result
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/dharmab/skyeye/pkg/encyclopedia"
 	"github.com/dharmab/skyeye/pkg/spatial"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/paulmach/orb"
 )
 
 // Merges returns a map of fixed-wing groups on the opposing coalition to the contacts on the given coalition that they are merged with.
@@ -30,7 +31,7 @@ func (s *scope) Merges(coalition coalitions.Coalition) map[brevity.Group][]*trac
 			continue
 		}
 
-		grp := s.findGroupForAircraft(contact)
+		grp := s.findGroupForAircraft(orb.Point{}, contact)
 		mergedWith := make(map[uint64]*trackfiles.Trackfile)
 		for _, contact := range grp.contacts {
 			visited[contact.Contact.ID] = struct{}{}
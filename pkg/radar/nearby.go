@@ -1,6 +1,7 @@
 package radar
 
 import (
+	"github.com/dharmab/skyeye/pkg/bearings"
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
 	"github.com/dharmab/skyeye/pkg/spatial"
@@ -10,7 +11,9 @@ import (
 	"golang.org/x/exp/slices"
 )
 
-func (s *scope) findNearbyGroups(pointOfInterest orb.Point, minAltitude, maxAltitude, radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory, excludedIDs []uint64) []*group {
+// findNearbyGroups finds groups within the given radius of the point of interest, ordered by increasing distance from
+// the point of interest. Aircraft are grouped based on their range from the origin.
+func (s *scope) findNearbyGroups(origin, pointOfInterest orb.Point, minAltitude, maxAltitude, radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory, excludedIDs []uint64) []*group {
 	circle := geo.NewBoundAroundPoint(pointOfInterest, radius.Meters())
 	groups := make([]*group, 0)
 	visited := make(map[uint64]struct{})
@@ -22,10 +25,10 @@ func (s *scope) findNearbyGroups(pointOfInterest orb.Point, minAltitude, maxAlti
 			continue
 		}
 		isMatch := s.isMatch(trackfile, coalition, filter)
-		inCircle := circle.Contains(trackfile.LastKnown().Point)
+		inCircle := spatial.BoundContains(circle, trackfile.LastKnown().Point)
 		inStack := minAltitude <= trackfile.LastKnown().Altitude && trackfile.LastKnown().Altitude <= maxAltitude
 		if isMatch && inCircle && inStack {
-			grp := s.findGroupForAircraft(trackfile)
+			grp := s.findGroupForAircraft(origin, trackfile)
 			for _, id := range grp.ObjectIDs() {
 				visited[id] = struct{}{}
 			}
@@ -44,7 +47,7 @@ func (s *scope) findNearbyGroups(pointOfInterest orb.Point, minAltitude, maxAlti
 }
 
 func (s *scope) FindNearbyGroupsWithBullseye(interest orb.Point, minAltitude, maxAltitude, radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory, excludedIDs []uint64) []brevity.Group {
-	groups := s.findNearbyGroups(interest, minAltitude, maxAltitude, radius, coalition, filter, excludedIDs)
+	groups := s.findNearbyGroups(orb.Point{}, interest, minAltitude, maxAltitude, radius, coalition, filter, excludedIDs)
 	result := make([]brevity.Group, 0, len(groups))
 	for _, grp := range groups {
 		result = append(result, grp)
@@ -53,13 +56,14 @@ func (s *scope) FindNearbyGroupsWithBullseye(interest orb.Point, minAltitude, ma
 }
 
 func (s *scope) FindNearbyGroupsWithBRAA(origin, interest orb.Point, minAltitude, maxAltitude, radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory, excludedIDs []uint64) []brevity.Group {
-	groups := s.findNearbyGroups(interest, minAltitude, maxAltitude, radius, coalition, filter, excludedIDs)
+	groups := s.findNearbyGroups(origin, interest, minAltitude, maxAltitude, radius, coalition, filter, excludedIDs)
 	result := make([]brevity.Group, 0, len(groups))
-	for _, grp := range groups {
-		bearing := spatial.TrueBearing(origin, grp.point()).Magnetic(s.Declination(origin))
+	magnetic := s.magneticBearings(origin, groups)
+	for i, grp := range groups {
+		bearing := magnetic[i]
 		_range := spatial.Distance(origin, grp.point())
 		aspect := brevity.AspectFromAngle(bearing, grp.course())
-		grp.braa = brevity.NewBRAA(bearing, _range, grp.altitudes(), aspect)
+		grp.braa = brevity.NewBRAAWithTrend(bearing, _range, grp.altitudes(), aspect, grp.aspectTrend(origin))
 		grp.bullseye = nil
 
 		result = append(result, grp)
@@ -67,3 +71,13 @@ func (s *scope) FindNearbyGroupsWithBRAA(origin, interest orb.Point, minAltitude
 
 	return result
 }
+
+// magneticBearings returns the magnetic bearings from the origin to each of the groups. The declination at the origin
+// is only looked up once.
+func (s *scope) magneticBearings(origin orb.Point, groups []*group) []bearings.Bearing {
+	trueBearings := make([]bearings.Bearing, len(groups))
+	for i, grp := range groups {
+		trueBearings[i] = spatial.TrueBearing(origin, grp.point())
+	}
+	return bearings.ToMagnetic(trueBearings, s.Declination(origin))
+}
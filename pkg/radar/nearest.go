@@ -67,7 +67,7 @@ func (s *scope) FindNearestGroupWithBRAA(
 		return nil
 	}
 
-	grp := s.findGroupForAircraft(trackfile)
+	grp := s.findGroupForAircraft(origin, trackfile)
 	if grp == nil {
 		return nil
 	}
@@ -76,11 +76,12 @@ func (s *scope) FindNearestGroupWithBRAA(
 	bearing := spatial.TrueBearing(origin, grp.point()).Magnetic(declination)
 	_range := spatial.Distance(origin, grp.point())
 	aspect := brevity.AspectFromAngle(bearing, trackfile.Course())
-	grp.braa = brevity.NewBRAA(
+	grp.braa = brevity.NewBRAAWithTrend(
 		bearing,
 		_range,
 		grp.altitudes(),
 		aspect,
+		trackfile.Trend(origin).AspectTrend(),
 	)
 	grp.bullseye = nil
 	grp.aspect = &aspect
@@ -92,7 +93,7 @@ func (s *scope) FindNearestGroupWithBRAA(
 // FindNearestGroupWithBullseye implements [Radar.FindNearestGroupWithBullseye].
 func (s *scope) FindNearestGroupWithBullseye(origin orb.Point, minAltitude, maxAltitude, radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory) brevity.Group {
 	nearestTrackfile := s.FindNearestTrackfile(origin, minAltitude, maxAltitude, radius, coalition, filter)
-	grp := s.findGroupForAircraft(nearestTrackfile)
+	grp := s.findGroupForAircraft(origin, nearestTrackfile)
 	declination := s.Declination(origin)
 	bearing := spatial.TrueBearing(origin, grp.point()).Magnetic(declination)
 	aspect := brevity.AspectFromAngle(bearing, grp.course())
@@ -138,6 +139,7 @@ func (s *scope) FindNearestGroupInSector(origin orb.Point, minAltitude, maxAltit
 			inSector := planar.PolygonContains(sector, contactLocation)
 			logger.Debug().Float64("distanceNM", distanceToContact.NauticalMiles()).Bool("inSector", inSector).Msg("checking distance and location")
 			if distanceToContact < nearestDistance && distanceToContact > conf.DefaultMarginRadius && inSector {
+				nearestDistance = distanceToContact
 				nearestContact = trackfile
 			}
 		}
@@ -149,7 +151,7 @@ func (s *scope) FindNearestGroupInSector(origin orb.Point, minAltitude, maxAltit
 
 	logger = log.With().Uint64("id", nearestContact.Contact.ID).Logger()
 	logger.Debug().Msg("found nearest contact")
-	grp := s.findGroupForAircraft(nearestContact)
+	grp := s.findGroupForAircraft(origin, nearestContact)
 	if grp == nil {
 		return nil
 	}
@@ -158,11 +160,12 @@ func (s *scope) FindNearestGroupInSector(origin orb.Point, minAltitude, maxAltit
 	log.Debug().Str("aspect", string(aspect)).Msg("determined aspect")
 	_range := spatial.Distance(origin, nearestContact.LastKnown().Point)
 	grp.aspect = &aspect
-	grp.braa = brevity.NewBRAA(
+	grp.braa = brevity.NewBRAAWithTrend(
 		preciseBearing,
 		_range,
 		grp.altitudes(),
 		grp.Aspect(),
+		nearestContact.Trend(origin).AspectTrend(),
 	)
 	logger.Debug().Stringer("group", grp).Msg("determined nearest group")
 	grp.bullseye = nil
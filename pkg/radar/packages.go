@@ -0,0 +1,128 @@
+package radar
+
+import (
+	"fmt"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+// Package is a set of flights on the same coalition which are moving together, such as a strike package and its
+// escorts.
+type Package struct {
+	// Name identifies the package by its position relative to the coalition's other packages, e.g. "north".
+	Name string
+	// ObjectIDs of the aircraft in the package.
+	ObjectIDs []uint64
+}
+
+const (
+	// packageRadius is the maximum distance between two flights in the same package.
+	packageRadius = 20 * unit.NauticalMile
+	// packageCourseTolerance is the maximum difference between the courses of two flights in the same package.
+	packageCourseTolerance = 30 * unit.Degree
+)
+
+// Packages implements [Radar.Packages].
+func (s *scope) Packages(coalition coalitions.Coalition) []Package {
+	groups := s.enumerateGroups(coalition)
+
+	// Correlate co-moving groups using a disjoint set, so that a package may be strung out over a greater distance
+	// than the package radius as long as each flight is close to another flight in the package.
+	parents := make([]int, len(groups))
+	for i := range parents {
+		parents[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+	for i := range groups {
+		for j := i + 1; j < len(groups); j++ {
+			if isCoMoving(groups[i], groups[j]) {
+				parents[find(j)] = find(i)
+			}
+		}
+	}
+
+	// Collect groups into packages. A single flight on its own is not a package.
+	members := make(map[int][]*group)
+	roots := make([]int, 0)
+	for i, grp := range groups {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], grp)
+	}
+	packages := make([]Package, 0)
+	centers := make([]orb.Point, 0)
+	for _, root := range roots {
+		if len(members[root]) < 2 {
+			continue
+		}
+		pkg := Package{ObjectIDs: make([]uint64, 0)}
+		var points []orb.Point
+		for _, grp := range members[root] {
+			pkg.ObjectIDs = append(pkg.ObjectIDs, grp.ObjectIDs()...)
+			points = append(points, grp.point())
+		}
+		packages = append(packages, pkg)
+		centers = append(centers, centroid(points))
+	}
+
+	reference := s.Bullseye(coalition)
+	if len(centers) > 1 {
+		reference = centroid(centers)
+	}
+	for i, name := range namePackages(reference, centers, s.Declination(reference)) {
+		packages[i].Name = name
+	}
+	return packages
+}
+
+// isCoMoving returns true if the two groups are near each other and moving in roughly the same direction.
+func isCoMoving(a, b *group) bool {
+	if a.Track() == brevity.UnknownDirection || b.Track() == brevity.UnknownDirection {
+		return false
+	}
+	if spatial.Distance(a.point(), b.point()) > packageRadius {
+		return false
+	}
+	return bearings.Difference(a.course(), b.course()) <= packageCourseTolerance
+}
+
+// centroid returns the average of the given points.
+func centroid(points []orb.Point) orb.Point {
+	var lon, lat float64
+	for _, point := range points {
+		lon += point.Lon()
+		lat += point.Lat()
+	}
+	n := float64(len(points))
+	return orb.Point{lon / n, lat / n}
+}
+
+// namePackages names packages by the cardinal direction from the reference point to the center of each package. If
+// multiple packages would share a name, the later packages are numbered, e.g. "north", "north 2".
+func namePackages(reference orb.Point, centers []orb.Point, declination unit.Angle) []string {
+	names := make([]string, len(centers))
+	counts := make(map[brevity.Track]int)
+	for i, center := range centers {
+		direction := brevity.TrackFromBearing(spatial.TrueBearing(reference, center).Magnetic(declination))
+		counts[direction]++
+		if counts[direction] == 1 {
+			names[i] = string(direction)
+		} else {
+			names[i] = fmt.Sprintf("%s %d", direction, counts[direction])
+		}
+	}
+	return names
+}
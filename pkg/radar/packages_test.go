@@ -0,0 +1,53 @@
+package radar
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamePackages(t *testing.T) {
+	t.Parallel()
+	reference := orb.Point{42.5, 43.5}
+	at := func(bearing unit.Angle) orb.Point {
+		return spatial.PointAtBearingAndDistance(reference, bearings.NewTrueBearing(bearing), 30*unit.NauticalMile)
+	}
+	testCases := []struct {
+		name     string
+		centers  []orb.Point
+		expected []string
+	}{
+		{
+			name:     "single package",
+			centers:  []orb.Point{at(90 * unit.Degree)},
+			expected: []string{"east"},
+		},
+		{
+			name:     "north and south",
+			centers:  []orb.Point{at(350 * unit.Degree), at(175 * unit.Degree)},
+			expected: []string{"north", "south"},
+		},
+		{
+			name:     "duplicate directions",
+			centers:  []orb.Point{at(270 * unit.Degree), at(0), at(275 * unit.Degree)},
+			expected: []string{"west", "north", "west 2"},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, namePackages(reference, test.centers, 0))
+		})
+	}
+}
+
+func TestCentroid(t *testing.T) {
+	t.Parallel()
+	center := centroid([]orb.Point{{42, 43}, {43, 44}, {44, 42}})
+	assert.InDelta(t, 43, center.Lon(), 0.001)
+	assert.InDelta(t, 43, center.Lat(), 0.001)
+}
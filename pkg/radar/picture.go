@@ -8,12 +8,39 @@ import (
 	"github.com/dharmab/skyeye/pkg/coalitions"
 	"github.com/dharmab/skyeye/pkg/spatial"
 	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geo"
 	"github.com/rs/zerolog/log"
 )
 
 // GetPicture implements [Radar.GetPicture].
-func (s *scope) GetPicture(radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory) (int, []brevity.Group) {
+func (s *scope) GetPicture(radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory) []brevity.Group {
 	// Find groups near the center point
+	origin := s.pictureOrigin(coalition)
+	groups := s.picture(origin, s.center, radius, coalition, filter)
+	result := make([]brevity.Group, len(groups))
+	for i, grp := range groups {
+		result[i] = grp
+	}
+	return result
+}
+
+// IsPictureClean implements [Radar.IsPictureClean].
+func (s *scope) IsPictureClean(radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory) bool {
+	circle := geo.NewBoundAroundPoint(s.pictureOrigin(coalition), radius.Meters())
+	for trackfile := range s.contacts.values() {
+		// Non-combatants are not excluded here, because a non-combatant may be grouped with combatants outside the
+		// radius. We can't be sure the picture is clean without grouping it.
+		if s.isMatch(trackfile, coalition, filter) && spatial.BoundContains(circle, trackfile.LastKnown().Point) {
+			return false
+		}
+	}
+	return true
+}
+
+// pictureOrigin returns the point a PICTURE is anchored on. This is the center point, or the bullseye if the center
+// point is not yet set.
+func (s *scope) pictureOrigin(coalition coalitions.Coalition) orb.Point {
 	origin := s.center
 	if spatial.IsZero(origin) {
 		log.Warn().Msg("center point is not set yet, using bullseye")
@@ -22,9 +49,31 @@ func (s *scope) GetPicture(radius unit.Length, coalition coalitions.Coalition, f
 			log.Warn().Msg("bullseye point is not yet set, picture will be incoherent")
 		}
 	}
+	return origin
+}
+
+// GetPictureWithBRAA implements [Radar.GetPictureWithBRAA].
+func (s *scope) GetPictureWithBRAA(origin orb.Point, radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory) []brevity.Group {
+	groups := s.picture(origin, origin, radius, coalition, filter)
+	result := make([]brevity.Group, len(groups))
+	magnetic := s.magneticBearings(origin, groups)
+	for i, grp := range groups {
+		bearing := magnetic[i]
+		_range := spatial.Distance(origin, grp.point())
+		aspect := brevity.AspectFromAngle(bearing, grp.course())
+		grp.braa = brevity.NewBRAAWithTrend(bearing, _range, grp.altitudes(), aspect, grp.aspectTrend(origin))
+		grp.bullseye = nil
+		result[i] = grp
+	}
+	return result
+}
 
+// picture finds groups within the given radius of the search point, ordered from highest to lowest threat to the
+// defended point. Aircraft are grouped based on their range from the defended point.
+func (s *scope) picture(search, defended orb.Point, radius unit.Length, coalition coalitions.Coalition, filter brevity.ContactCategory) []*group {
 	groups := s.findNearbyGroups(
-		origin,
+		defended,
+		search,
 		0,
 		math.MaxFloat64,
 		radius,
@@ -33,22 +82,19 @@ func (s *scope) GetPicture(radius unit.Length, coalition coalitions.Coalition, f
 		[]uint64{},
 	)
 
-	// Sort groups from highest to lowest threat
-	slices.SortFunc(groups, s.compareThreat)
-
-	// Return the top 3 groups
-	capacity := 3
-	if len(groups) < capacity {
-		capacity = len(groups)
-	}
-	result := make([]brevity.Group, capacity)
-	for i := range capacity {
-		result[i] = groups[i]
+	if s.excludeNonCombatants {
+		groups = slices.DeleteFunc(groups, (*group).isNonCombatant)
 	}
-	return len(groups), result
+
+	// Sort groups from highest to lowest threat
+	slices.SortFunc(groups, func(a, b *group) int {
+		return compareThreat(defended, a, b)
+	})
+	return groups
 }
 
-func (s *scope) compareThreat(a, b *group) int {
+// compareThreat compares the threat that two groups pose to the defended point.
+func compareThreat(defended orb.Point, a, b *group) int {
 	aIsHigherThreat := -1
 	bIsHigherThreat := 1
 
@@ -62,8 +108,8 @@ func (s *scope) compareThreat(a, b *group) int {
 	}
 
 	// Prioritize aircraft within threat radius over aircraft outside threat radius
-	distanceA := spatial.Distance(s.center, a.point())
-	distanceB := spatial.Distance(s.center, b.point())
+	distanceA := spatial.Distance(defended, a.point())
+	distanceB := spatial.Distance(defended, b.point())
 	aIsThreat := distanceA < a.threatRadius()
 	bIsThreat := distanceB < b.threatRadius()
 	if aIsThreat && !bIsThreat {
@@ -6,13 +6,16 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dharmab/skyeye/internal/conf"
 	"github.com/dharmab/skyeye/pkg/bearings"
 	"github.com/dharmab/skyeye/pkg/brevity"
 	"github.com/dharmab/skyeye/pkg/coalitions"
 	"github.com/dharmab/skyeye/pkg/encyclopedia"
 	"github.com/dharmab/skyeye/pkg/sim"
 	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/terrain"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/dharmab/skyeye/pkg/weather"
 	"github.com/martinlindhe/unit"
 	"github.com/paulmach/orb"
 	"github.com/rs/zerolog/log"
@@ -32,21 +35,48 @@ type Radar interface {
 	// Run consumes updates from the simulation channels until the context is cancelled.
 	Run(context.Context, *sync.WaitGroup)
 	// FindCallsign returns the trackfile on the given coalition that mosty closely matches the given callsign,
-	// or nil if no closely matching trackfile was found.
+	// or nil if no closely matching trackfile was found. If several aircraft share the callsign, the aircraft given to
+	// BindUnit is preferred, then the aircraft nearest to the aircraft chosen by the previous ResolveCallsign.
 	// The first return value is the callsign of the trackfile, and the second is the trackfile itself.
-	// The returned callsign may differ from the input callsign!
+	// The returned callsign may differ from the input callsign! FindCallsign has no side effects, so it is safe to use
+	// for background monitoring.
 	FindCallsign(string, coalitions.Coalition) (string, *trackfiles.Trackfile)
+	// ResolveCallsign is like FindCallsign, but also records which aircraft was chosen for later lookups, and calls the
+	// callback set by SetDuplicateCallsignCallback if the choice between several aircraft sharing the callsign has
+	// changed. It should be used only to identify the caller of a request.
+	ResolveCallsign(string, coalitions.Coalition) (string, *trackfiles.Trackfile)
 	// FindUnit returns the trackfile for the given unit ID, or nil if no trackfile was found.
 	FindUnit(uint64) *trackfiles.Trackfile
+	// BindUnit records that the SRS client which most recently transmitted is bound to the aircraft with the given
+	// unit ID, so that FindCallsign prefers that aircraft over others sharing its callsign.
+	BindUnit(uint64)
+	// Trackfiles returns all trackfiles on the scope, ordered by object ID.
+	Trackfiles() []*trackfiles.Trackfile
 	// GetPicture returns a picture of the radar scope anchored at the center point, within the given radius,
-	// filtered by the given coalition and contact category. The first return value is the total number of groups
-	// and the second is a slice of up to to 3 high priority groups. Each group has Bullseye set relative to the
-	// the point provided in SetBullseye.
+	// filtered by the given coalition and contact category. The groups are ordered from highest to lowest priority.
+	// Each group has Bullseye set relative to the the point provided in SetBullseye.
 	GetPicture(
 		radius unit.Length,
 		coalition coalitions.Coalition,
 		category brevity.ContactCategory,
-	) (int, []brevity.Group)
+	) []brevity.Group
+	// IsPictureClean returns true if a picture from GetPicture with the same arguments would have no groups. This is
+	// much cheaper than GetPicture because aircraft are not grouped. It may return false for a picture which turns out
+	// to be clean once non-combatant groups are excluded.
+	IsPictureClean(
+		radius unit.Length,
+		coalition coalitions.Coalition,
+		category brevity.ContactCategory,
+	) bool
+	// GetPictureWithBRAA returns a picture of the radar scope anchored at the given origin, within the given radius,
+	// filtered by the given coalition and contact category. The groups are ordered from highest to lowest threat to the
+	// origin. Each group has BRAA set relative to the origin.
+	GetPictureWithBRAA(
+		origin orb.Point,
+		radius unit.Length,
+		coalition coalitions.Coalition,
+		category brevity.ContactCategory,
+	) []brevity.Group
 	// FindNearbyGroupsWithBRAA returns all groups within the given radius of the given point of interest, within the given
 	// altitude block, filtered by the given coalition and contact category. Any given unit IDs are excluded from the search.
 	// Each group has BRAA set relative to the given origin. The groups are ordered by increasing distance from the point
@@ -114,10 +144,27 @@ type Radar interface {
 	SetFadedCallback(FadedCallback)
 	// SetRemovedCallback sets the callback function to be called when a trackfile is aged out.
 	SetRemovedCallback(RemovedCallback)
+	// SetUpdatedCallback sets the callback function to be called when a trackfile is painted with a new update.
+	SetUpdatedCallback(UpdatedCallback)
+	// SetThreatZoneCallback sets the callback function to be called when a trackfile enters a threat zone.
+	SetThreatZoneCallback(ThreatZoneCallback)
+	// SetDuplicateCallsignCallback sets the callback function to be called when ResolveCallsign chooses between
+	// several aircraft sharing a callsign.
+	SetDuplicateCallsignCallback(DuplicateCallsignCallback)
+	// SetAirDefenses replaces the threat zones with the zones of the known SAM and AAA launchers among the given
+	// ground units.
+	SetAirDefenses([]sim.GroundUnit)
+	// ThreatZones returns the threat zones of the given coalition's SAM and AAA launchers.
+	ThreatZones(coalitions.Coalition) []ThreatZone
 	// Threats returns a map of threat groups of the given coalition to threatened object IDs.
 	Threats(coalitions.Coalition) map[brevity.Group][]uint64
 	// Merges returns a map of hostile groups of the given coalition to friendly trackfiles.
 	Merges(coalitions.Coalition) map[brevity.Group][]*trackfiles.Trackfile
+	// Packages returns the packages of co-moving flights on the given coalition.
+	Packages(coalitions.Coalition) []Package
+	// Snapshot returns a consistent copy of the trackfiles, groups, bullseyes and mission time. Prefer this over
+	// several separate calls when the results must agree with each other.
+	Snapshot() RadarSnapshot
 }
 
 var _ Radar = &scope{}
@@ -131,21 +178,82 @@ type scope struct {
 	contacts              contactDatabase
 	fadedCallback         FadedCallback
 	removalCallback       RemovedCallback
+	updatedCallback       UpdatedCallback
 	center                orb.Point
 	mandatoryThreatRadius unit.Length
+	// fadeTimeout is how long a trackfile may go without updates before it is considered faded.
+	fadeTimeout time.Duration
+	// retention is how long a trackfile may go without updates before it is removed from the scope.
+	retention time.Duration
+	// fadedWindow is how long to wait after a contact fades for further contacts to fade, so that contacts which fade
+	// together are reported in a single call.
+	fadedWindow time.Duration
+	// fadedRadius is the distance within which contacts which fade together are reported as a single group.
+	fadedRadius unit.Length
+	// stale contains the IDs of trackfiles which have faded due to a lack of updates, but have not yet been removed.
+	stale sync.Map
+	// lastFast maps the IDs of trackfiles to the most recent time they were above the speed filter.
+	lastFast sync.Map
+	// excludeNonCombatants controls whether groups of non-combatant aircraft are left out of pictures and threats.
+	excludeNonCombatants bool
+	// terrain provides terrain elevation, so that groups can report their height above ground level. May be nil.
+	terrain terrain.Model
+	// wind is used to estimate the true airspeed of groups. May be nil.
+	wind *weather.Wind
+	// groupingRadii controls how far apart aircraft may be to be grouped together, depending on their range from the
+	// requester. If empty, the default grouping radius is used at all ranges.
+	groupingRadii []conf.GroupingRadius
+	// sweep models a rotating radar, so that contacts are painted once per sweep rather than on every update.
+	sweep *sweep
+	// zones are the threat zones of SAM and AAA launchers.
+	zones              *zoneDatabase
+	threatZoneCallback ThreatZoneCallback
+	// snapshotLock is held for writing while the scope is modified, so that Snapshot sees a consistent scope.
+	snapshotLock sync.RWMutex
+	// callsigns chooses between aircraft which share a callsign.
+	callsigns                 *callsignSelector
+	duplicateCallsignCallback DuplicateCallsignCallback
 }
 
-func New(coalition coalitions.Coalition, starts <-chan sim.Started, updates <-chan sim.Updated, fades <-chan sim.Faded, mandatoryThreatRadius unit.Length) Radar {
+func New(
+	coalition coalitions.Coalition,
+	starts <-chan sim.Started,
+	updates <-chan sim.Updated,
+	fades <-chan sim.Faded,
+	mandatoryThreatRadius unit.Length,
+	fadeTimeout time.Duration,
+	retention time.Duration,
+	fadedWindow time.Duration,
+	fadedRadius unit.Length,
+	excludeNonCombatants bool,
+	terrain terrain.Model,
+	wind *weather.Wind,
+	groupingRadii []conf.GroupingRadius,
+	sweepInterval time.Duration,
+) Radar {
 	return &scope{
 		starts:                starts,
 		updates:               updates,
 		fades:                 fades,
 		contacts:              newContactDatabase(),
+		zones:                 newZoneDatabase(),
+		callsigns:             newCallsignSelector(),
 		mandatoryThreatRadius: mandatoryThreatRadius,
+		fadeTimeout:           fadeTimeout,
+		retention:             retention,
+		fadedWindow:           fadedWindow,
+		fadedRadius:           fadedRadius,
+		excludeNonCombatants:  excludeNonCombatants,
+		terrain:               terrain,
+		wind:                  wind,
+		groupingRadii:         groupingRadii,
+		sweep:                 newSweep(sweepInterval),
 	}
 }
 
 func (s *scope) SetMissionTime(t time.Time) {
+	s.snapshotLock.Lock()
+	defer s.snapshotLock.Unlock()
 	s.missionTime = t
 }
 
@@ -158,6 +266,8 @@ func (s *scope) SetBullseye(bullseye orb.Point, coalition coalitions.Coalition)
 			Float64("lat", bullseye.Lat()).
 			Msg("updating bullseye")
 	}
+	s.snapshotLock.Lock()
+	defer s.snapshotLock.Unlock()
 	s.bullseyes.Store(coalition, bullseye)
 }
 
@@ -179,21 +289,34 @@ func (s *scope) Run(ctx context.Context, wg *sync.WaitGroup) {
 
 	s.updateCenterPoint()
 
-	gcTicker := time.NewTicker(1 * time.Minute)
+	gcTicker := time.NewTicker(10 * time.Second)
 	defer gcTicker.Stop()
 	recenterTicker := time.NewTicker(5 * time.Second)
 	defer recenterTicker.Stop()
+	zoneTicker := time.NewTicker(5 * time.Second)
+	defer zoneTicker.Stop()
 	for {
 		select {
 		case start := <-s.starts:
 			log.Info().Time("missionTime", start.MissionTimestamp).Msg("clearing all trackfiles due to mission (re)start")
+			s.snapshotLock.Lock()
 			s.contacts.reset()
+			s.stale.Clear()
+			s.lastFast.Clear()
+			s.snapshotLock.Unlock()
+			s.sweep.reset()
+			s.zones.reset()
+			s.callsigns.reset()
 		case update := <-s.updates:
+			s.snapshotLock.Lock()
 			s.handleUpdate(update)
+			s.snapshotLock.Unlock()
 		case <-gcTicker.C:
 			s.handleGarbageCollection()
 		case <-recenterTicker.C:
 			s.updateCenterPoint()
+		case <-zoneTicker.C:
+			s.checkThreatZones()
 		case <-ctx.Done():
 			return
 		}
@@ -209,17 +332,37 @@ func (s *scope) handleUpdate(update sim.Updated) {
 		Logger()
 
 	trackfile, ok := s.contacts.getByID(update.Labels.ID)
+	var lastPainted time.Time
+	if ok {
+		lastPainted = trackfile.LastKnown().Time
+	}
+	if !s.sweep.isPainted(update.Labels.ID, ok, lastPainted, update.Frame.Time) {
+		return
+	}
 	if ok {
 		trackfile.Update(update.Frame)
+		if _, ok := s.stale.LoadAndDelete(update.Labels.ID); ok {
+			logger.Info().Msg("faded trackfile received new update")
+		}
 	} else {
 		trackfile = trackfiles.NewTrackfile(update.Labels)
+		trackfile.Update(update.Frame)
 		s.contacts.set(trackfile)
 		logger.Info().Msg("created new trackfile")
 	}
+	if isAboveSpeedFilter(trackfile) {
+		s.lastFast.Store(update.Labels.ID, trackfile.LastKnown().Time)
+	}
+	if s.updatedCallback != nil {
+		s.updatedCallback(trackfile)
+	}
 }
 
-// handleGarbageCollection removes trackfiles that have not been updated in a long time.
+// handleGarbageCollection fades trackfiles that have not been updated within the fade timeout, and removes trackfiles
+// that have not been updated within the retention period.
 func (s *scope) handleGarbageCollection() {
+	s.snapshotLock.Lock()
+	fades := []*trackfiles.Trackfile{}
 	for trackfile := range s.contacts.values() {
 		logger := log.With().
 			Uint64("id", trackfile.Contact.ID).
@@ -228,25 +371,65 @@ func (s *scope) handleGarbageCollection() {
 			Logger()
 
 		lastSeen := trackfile.LastKnown().Time
-		isOld := lastSeen.Before(s.missionTime.Add(-1 * time.Minute))
-		isNotZero := !lastSeen.IsZero()
-		if isNotZero && isOld {
+		if lastSeen.IsZero() {
+			continue
+		}
+		age := s.missionTime.Sub(lastSeen)
+		if age > s.retention {
 			s.contacts.delete(trackfile.Contact.ID)
+			s.stale.Delete(trackfile.Contact.ID)
+			s.lastFast.Delete(trackfile.Contact.ID)
 			logger.Info().
-				Stringer("age", s.missionTime.Sub(lastSeen)).
+				Stringer("age", age).
 				Msg("removed aged out trackfile")
+		} else if age > s.fadeTimeout && !s.isStale(trackfile) {
+			logger.Info().
+				Stringer("age", age).
+				Msg("trackfile faded due to lack of updates")
+			fades = append(fades, trackfile)
 		}
 	}
+
+	s.sweep.prune(s.missionTime.Add(-s.retention))
+
+	// Group the faded trackfiles before marking them as stale, so that flights which fade together are reported together.
+	groups := s.groupFaded(fades)
+	for _, trackfile := range fades {
+		s.stale.Store(trackfile.Contact.ID, struct{}{})
+	}
+	// Release the lock before calling the callbacks, which may be slow.
+	s.snapshotLock.Unlock()
+	s.notifyFaded(groups)
+}
+
+// isStale returns true if the trackfile has faded due to a lack of updates.
+func (s *scope) isStale(trackfile *trackfiles.Trackfile) bool {
+	_, ok := s.stale.Load(trackfile.Contact.ID)
+	return ok
+}
+
+// speedFilterHoldTime is how long a trackfile which has been above the speed filter remains valid after it slows below
+// the speed filter. This prevents aircraft which briefly slow down, such as helicopters coming to a hover or fighters
+// in post-stall maneuvers, from flapping in and out of the picture.
+const speedFilterHoldTime = 30 * time.Second
+
+// isAboveSpeedFilter checks if the trackfile is moving faster than 50 knots.
+func isAboveSpeedFilter(trackfile *trackfiles.Trackfile) bool {
+	return trackfile.Speed() > 50*unit.Knot
 }
 
 // isValidTrack checks if the trackfile is valid. This means the following conditions are met:
 //   - Last known position is not (0, 0)
-//   - Speed is above 50 knots
-func isValidTrack(trackfile *trackfiles.Trackfile) bool {
+//   - Speed is above 50 knots, or was above 50 knots within the speed filter hold time
+func (s *scope) isValidTrack(trackfile *trackfiles.Trackfile) bool {
 	isValidPosition := !spatial.IsZero(trackfile.LastKnown().Point)
-	isAboveSpeedFilter := trackfile.Speed() > 50*unit.Knot
-	isValid := isValidPosition && isAboveSpeedFilter
-	return isValid
+	isFast := isAboveSpeedFilter(trackfile)
+	if !isFast {
+		if lastFast, ok := s.lastFast.Load(trackfile.Contact.ID); ok {
+			isFast = trackfile.LastKnown().Time.Sub(lastFast.(time.Time)) < speedFilterHoldTime
+		}
+	}
+	return isValidPosition && isFast
 }
 
 // isMatch checks:
@@ -257,7 +440,11 @@ func (s *scope) isMatch(trackfile *trackfiles.Trackfile, coalition coalitions.Co
 	if trackfile.Contact.Coalition != coalition {
 		return false
 	}
-	if !isValidTrack(trackfile) {
+	if !s.isValidTrack(trackfile) || s.isStale(trackfile) {
+		return false
+	}
+	// The radar tracks only aircraft
+	if filter == brevity.Surface {
 		return false
 	}
 	data, ok := encyclopedia.GetAircraftData(trackfile.Contact.ACMIName)
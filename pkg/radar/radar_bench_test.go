@@ -0,0 +1,166 @@
+package radar
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+// benchmarkSizes are the numbers of contacts used in benchmarks. These roughly correspond to a small mission, a busy
+// public server and a stress test.
+var benchmarkSizes = []int{100, 500, 2000}
+
+// benchmarkAircraft is a mix of aircraft commonly found in missions.
+var benchmarkAircraft = map[coalitions.Coalition][]string{
+	coalitions.Blue: {"F-15C", "F-16C_50", "FA-18C_hornet", "F-14B", "A-10C_2", "KC-135", "E-3A"},
+	coalitions.Red:  {"Su-27", "MiG-29S", "MiG-31", "Su-25T", "Su-24M", "IL-78M", "A-50"},
+}
+
+// benchmarkBullseye is the bullseye used in benchmarks. It is roughly in the middle of the Caucasus map.
+var benchmarkBullseye = orb.Point{42.5, 43.5}
+
+// newBenchmarkUpdates generates a synthetic dataset of updates for the given number of contacts. Contacts are
+// distributed in flights of two within 200 nautical miles of the bullseye. Each contact has two updates so that
+// they have a valid speed and course. The dataset is deterministic so that benchmark runs are comparable.
+func newBenchmarkUpdates(n int) []sim.Updated {
+	rng := rand.New(rand.NewPCG(uint64(n), 0))
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	updates := make([]sim.Updated, 0, n*2)
+	for i := 0; i < n; i += 2 {
+		var coalition coalitions.Coalition = coalitions.Blue
+		if rng.IntN(2) == 0 {
+			coalition = coalitions.Red
+		}
+		platforms := benchmarkAircraft[coalition]
+		platform := platforms[rng.IntN(len(platforms))]
+		lead := spatial.PointAtBearingAndDistance(
+			benchmarkBullseye,
+			bearings.NewTrueBearing(unit.Angle(rng.Float64()*360)*unit.Degree),
+			unit.Length(rng.Float64()*200)*unit.NauticalMile,
+		)
+		heading := bearings.NewTrueBearing(unit.Angle(rng.Float64()*360) * unit.Degree)
+		altitude := unit.Length(5000+rng.IntN(35000)) * unit.Foot
+		speed := unit.Speed(300+rng.IntN(300)) * unit.Knot
+		for j := 0; j < 2 && i+j < n; j++ {
+			id := uint64(i + j + 1)
+			origin := spatial.PointAtBearingAndDistance(lead, heading.Reciprocal(), unit.Length(j)*unit.NauticalMile)
+			labels := trackfiles.Labels{
+				ID:        id,
+				Name:      fmt.Sprintf("Flight %d-%d", i/2+1, j+1),
+				Coalition: coalition,
+				ACMIName:  platform,
+			}
+			for k := range 2 {
+				elapsed := time.Duration(k) * 2 * time.Second
+				distance := unit.Length(speed.MetersPerSecond()*elapsed.Seconds()) * unit.Meter
+				updates = append(updates, sim.Updated{
+					Labels: labels,
+					Frame: trackfiles.Frame{
+						Time:     start.Add(elapsed),
+						Point:    spatial.PointAtBearingAndDistance(origin, heading, distance),
+						Altitude: altitude,
+						Heading:  unit.Angle(heading.Degrees()) * unit.Degree,
+					},
+				})
+			}
+		}
+	}
+	return updates
+}
+
+// newBenchmarkScope returns a scope populated with the given number of contacts.
+func newBenchmarkScope(n int) *scope {
+	s := &scope{
+		contacts:              newContactDatabase(),
+		mandatoryThreatRadius: 25 * unit.NauticalMile,
+		fadeTimeout:           time.Minute,
+		retention:             5 * time.Minute,
+		sweep:                 newSweep(0),
+	}
+	s.SetBullseye(benchmarkBullseye, coalitions.Blue)
+	s.SetBullseye(benchmarkBullseye, coalitions.Red)
+	for _, update := range newBenchmarkUpdates(n) {
+		s.handleUpdate(update)
+		s.SetMissionTime(update.Frame.Time)
+	}
+	s.updateCenterPoint()
+	return s
+}
+
+func BenchmarkHandleUpdate(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		b.Run(fmt.Sprintf("contacts=%d", n), func(b *testing.B) {
+			updates := newBenchmarkUpdates(n)
+			s := newBenchmarkScope(n)
+			b.ResetTimer()
+			for i := range b.N {
+				s.handleUpdate(updates[i%len(updates)])
+			}
+		})
+	}
+}
+
+func BenchmarkEnumerateGroups(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		b.Run(fmt.Sprintf("contacts=%d", n), func(b *testing.B) {
+			s := newBenchmarkScope(n)
+			b.ResetTimer()
+			for range b.N {
+				_ = s.enumerateGroups(coalitions.Red)
+			}
+		})
+	}
+}
+
+func BenchmarkGetPicture(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		b.Run(fmt.Sprintf("contacts=%d", n), func(b *testing.B) {
+			s := newBenchmarkScope(n)
+			b.ResetTimer()
+			for range b.N {
+				_ = s.GetPicture(300*unit.NauticalMile, coalitions.Red, brevity.Aircraft)
+			}
+		})
+	}
+}
+
+func BenchmarkThreats(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		b.Run(fmt.Sprintf("contacts=%d", n), func(b *testing.B) {
+			s := newBenchmarkScope(n)
+			b.ResetTimer()
+			for range b.N {
+				_ = s.Threats(coalitions.Red)
+			}
+		})
+	}
+}
+
+func BenchmarkFindNearestGroupWithBRAA(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		b.Run(fmt.Sprintf("contacts=%d", n), func(b *testing.B) {
+			s := newBenchmarkScope(n)
+			b.ResetTimer()
+			for range b.N {
+				_ = s.FindNearestGroupWithBRAA(
+					benchmarkBullseye,
+					0,
+					100000*unit.Foot,
+					300*unit.NauticalMile,
+					coalitions.Red,
+					brevity.Aircraft,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package radar
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpeedFilterHysteresis(t *testing.T) {
+	t.Parallel()
+	s := &scope{
+		contacts: newContactDatabase(),
+		sweep:    newSweep(0),
+	}
+	labels := trackfiles.Labels{ID: 1, Name: "Mobius 1", Coalition: coalitions.Blue, ACMIName: "F-15C"}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	point := orb.Point{42.5, 43.5}
+	interval := 2 * time.Second
+	update := func(i int, speed unit.Speed) {
+		distance := unit.Length(speed.MetersPerSecond()*interval.Seconds()) * unit.Meter
+		point = spatial.PointAtBearingAndDistance(point, bearings.NewTrueBearing(0), distance)
+		s.handleUpdate(sim.Updated{
+			Labels: labels,
+			Frame: trackfiles.Frame{
+				Time:     start.Add(time.Duration(i) * interval),
+				Point:    point,
+				Altitude: 5000 * unit.Foot,
+			},
+		})
+	}
+
+	// A parked aircraft is never valid.
+	for i := range 5 {
+		update(i, 0)
+	}
+	trackfile, ok := s.contacts.getByID(labels.ID)
+	require.True(t, ok)
+	assert.False(t, s.isValidTrack(trackfile))
+
+	// Once the aircraft is moving, it remains valid while it briefly slows down.
+	for i := 5; i < 10; i++ {
+		update(i, 300*unit.Knot)
+	}
+	assert.True(t, s.isValidTrack(trackfile))
+	for i := 10; i < 15; i++ {
+		update(i, 10*unit.Knot)
+		assert.True(t, s.isValidTrack(trackfile))
+	}
+
+	// Sustained low speed invalidates the track.
+	for i := 15; i < 30; i++ {
+		update(i, 10*unit.Knot)
+	}
+	assert.False(t, s.isValidTrack(trackfile))
+}
+
+func TestIsPictureClean(t *testing.T) {
+	t.Parallel()
+	center := orb.Point{42.5, 43.5}
+	s := &scope{
+		contacts: newContactDatabase(),
+		sweep:    newSweep(0),
+		center:   center,
+	}
+	radius := 100 * unit.NauticalMile
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	add := func(labels trackfiles.Labels, point orb.Point) {
+		for i := range 5 {
+			point = spatial.PointAtBearingAndDistance(point, bearings.NewTrueBearing(0), 300*unit.Meter)
+			s.handleUpdate(sim.Updated{
+				Labels: labels,
+				Frame: trackfiles.Frame{
+					Time:     start.Add(time.Duration(i) * time.Second),
+					Point:    point,
+					Altitude: 20000 * unit.Foot,
+				},
+			})
+		}
+	}
+
+	assert.True(t, s.IsPictureClean(radius, coalitions.Red, brevity.FixedWing))
+
+	// Friendly aircraft and hostile aircraft outside the radius don't dirty the picture.
+	add(trackfiles.Labels{ID: 1, Name: "Mobius 1", Coalition: coalitions.Blue, ACMIName: "F-15C"}, center)
+	add(trackfiles.Labels{ID: 2, Name: "Yellow 13", Coalition: coalitions.Red, ACMIName: "Su-27"}, spatial.PointAtBearingAndDistance(center, bearings.NewTrueBearing(90), 150*unit.NauticalMile))
+	assert.True(t, s.IsPictureClean(radius, coalitions.Red, brevity.FixedWing))
+
+	// Helicopters don't dirty a fixed-wing picture.
+	add(trackfiles.Labels{ID: 3, Name: "Red 1", Coalition: coalitions.Red, ACMIName: "Mi-24V"}, spatial.PointAtBearingAndDistance(center, bearings.NewTrueBearing(90), 50*unit.NauticalMile))
+	assert.True(t, s.IsPictureClean(radius, coalitions.Red, brevity.FixedWing))
+
+	add(trackfiles.Labels{ID: 4, Name: "Yellow 4", Coalition: coalitions.Red, ACMIName: "Su-27"}, spatial.PointAtBearingAndDistance(center, bearings.NewTrueBearing(90), 50*unit.NauticalMile))
+	assert.False(t, s.IsPictureClean(radius, coalitions.Red, brevity.FixedWing))
+}
+
+func TestExcludeNonCombatants(t *testing.T) {
+	t.Parallel()
+	for _, excludeNonCombatants := range []bool{true, false} {
+		t.Run(fmt.Sprintf("exclude=%v", excludeNonCombatants), func(t *testing.T) {
+			t.Parallel()
+			center := orb.Point{42.5, 43.5}
+			s := &scope{
+				contacts:              newContactDatabase(),
+				sweep:                 newSweep(0),
+				center:                center,
+				mandatoryThreatRadius: 25 * unit.NauticalMile,
+				excludeNonCombatants:  excludeNonCombatants,
+			}
+			start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			add := func(labels trackfiles.Labels, point orb.Point) {
+				for i := range 5 {
+					point = spatial.PointAtBearingAndDistance(point, bearings.NewTrueBearing(0), 300*unit.Meter)
+					s.handleUpdate(sim.Updated{
+						Labels: labels,
+						Frame: trackfiles.Frame{
+							Time:     start.Add(time.Duration(i) * time.Second),
+							Point:    point,
+							Altitude: 20000 * unit.Foot,
+						},
+					})
+				}
+			}
+			add(trackfiles.Labels{ID: 1, Name: "Mobius 1", Coalition: coalitions.Blue, ACMIName: "F-15C"}, center)
+			add(trackfiles.Labels{ID: 2, Name: "Yellow 4", Coalition: coalitions.Red, ACMIName: "Su-27"}, spatial.PointAtBearingAndDistance(center, bearings.NewTrueBearing(90), 10*unit.NauticalMile))
+			add(trackfiles.Labels{ID: 3, Name: "Texaco 1", Coalition: coalitions.Red, ACMIName: "S-3B Tanker"}, spatial.PointAtBearingAndDistance(center, bearings.NewTrueBearing(270), 10*unit.NauticalMile))
+			add(trackfiles.Labels{ID: 4, Name: "Student 1", Coalition: coalitions.Red, ACMIName: "L-39C"}, spatial.PointAtBearingAndDistance(center, bearings.NewTrueBearing(180), 10*unit.NauticalMile))
+
+			expected := 3
+			if excludeNonCombatants {
+				expected = 1
+			}
+			picture := s.picture(center, center, 100*unit.NauticalMile, coalitions.Red, brevity.Aircraft)
+			assert.Len(t, picture, expected)
+			threats := s.Threats(coalitions.Red)
+			assert.Len(t, threats, expected)
+			if excludeNonCombatants {
+				require.Len(t, picture, 1)
+				assert.Equal(t, []string{"Flanker"}, picture[0].Platforms())
+			}
+		})
+	}
+}
+
+func TestHandleGarbageCollection(t *testing.T) {
+	t.Parallel()
+	center := orb.Point{42.5, 43.5}
+	s := &scope{
+		contacts:    newContactDatabase(),
+		sweep:       newSweep(0),
+		center:      center,
+		fadeTimeout: 30 * time.Second,
+		retention:   5 * time.Minute,
+	}
+	faded := make([]uint64, 0)
+	s.SetFadedCallback(func(group brevity.Group, _ *brevity.Bullseye, _ coalitions.Coalition) {
+		faded = append(faded, group.ObjectIDs()...)
+	})
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	update := func(id uint64, at time.Time) {
+		s.handleUpdate(sim.Updated{
+			Labels: trackfiles.Labels{ID: id, Name: fmt.Sprintf("Yellow %d", id), Coalition: coalitions.Red, ACMIName: "Su-27"},
+			Frame:  trackfiles.Frame{Time: at, Point: center, Altitude: 20000 * unit.Foot},
+		})
+	}
+	update(1, start)
+	update(2, start)
+	exists := func(id uint64) bool {
+		_, ok := s.contacts.getByID(id)
+		return ok
+	}
+
+	// Before the fade timeout, nothing happens.
+	s.missionTime = start.Add(20 * time.Second)
+	s.handleGarbageCollection()
+	assert.True(t, exists(1))
+	assert.True(t, exists(2))
+	assert.Empty(t, faded)
+
+	// After the fade timeout, the trackfiles fade but are retained.
+	update(2, start.Add(time.Minute))
+	s.missionTime = start.Add(time.Minute)
+	s.handleGarbageCollection()
+	assert.True(t, exists(1))
+	assert.True(t, s.isStale(mustGet(t, s, 1)))
+	assert.False(t, s.isStale(mustGet(t, s, 2)))
+	assert.Equal(t, []uint64{1}, faded)
+
+	// A faded trackfile is only reported once.
+	s.missionTime = start.Add(2 * time.Minute)
+	s.handleGarbageCollection()
+	assert.Equal(t, []uint64{1, 2}, faded)
+
+	// Just before the retention period, the trackfile is kept.
+	s.missionTime = start.Add(5 * time.Minute)
+	s.handleGarbageCollection()
+	assert.True(t, exists(1))
+	assert.Equal(t, []uint64{1, 2}, faded)
+
+	// After the retention period, the trackfile is removed. The trackfile updated more recently is kept.
+	s.missionTime = start.Add(5*time.Minute + time.Second)
+	s.handleGarbageCollection()
+	assert.False(t, exists(1))
+	assert.True(t, exists(2))
+	_, isStale := s.stale.Load(uint64(1))
+	assert.False(t, isStale, "removed trackfiles are forgotten")
+
+	s.missionTime = start.Add(7 * time.Minute)
+	s.handleGarbageCollection()
+	assert.False(t, exists(2))
+}
+
+// mustGet returns the trackfile with the given ID, failing the test if it does not exist.
+func mustGet(t *testing.T, s *scope, id uint64) *trackfiles.Trackfile {
+	t.Helper()
+	trackfile, ok := s.contacts.getByID(id)
+	require.True(t, ok)
+	return trackfile
+}
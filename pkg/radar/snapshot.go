@@ -0,0 +1,98 @@
+package radar
+
+import (
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+// RadarSnapshot is a consistent, read-only copy of the radar scope at a single moment. It shares no state with the
+// scope, so it may be read from any goroutine while the scope continues to update.
+type RadarSnapshot struct {
+	// MissionTime is the mission time when the snapshot was taken.
+	MissionTime time.Time
+	// Bullseyes maps each coalition to its bullseye. Coalitions whose bullseye is not yet known are omitted.
+	Bullseyes map[coalitions.Coalition]orb.Point
+	// Trackfiles are copies of every trackfile on the scope, ordered by object ID.
+	Trackfiles []TrackfileSnapshot
+	// Groups maps each coalition to its groups of aircraft. Faded and invalid trackfiles are not grouped.
+	Groups map[coalitions.Coalition][]GroupSnapshot
+}
+
+// TrackfileSnapshot is a copy of a trackfile.
+type TrackfileSnapshot struct {
+	// Contact contains identifying information.
+	Contact trackfiles.Labels
+	// LastKnown is the most recent position of the contact.
+	LastKnown trackfiles.Frame
+	// Course is the contact's magnetic course.
+	Course bearings.Bearing
+	// Speed is the contact's ground speed.
+	Speed unit.Speed
+	// Tags are annotations attached to the trackfile by external systems.
+	Tags []string
+	// IsFaded is true if the trackfile has faded due to a lack of updates, but has not yet been removed.
+	IsFaded bool
+}
+
+// GroupSnapshot is a copy of a group of aircraft.
+type GroupSnapshot struct {
+	// ObjectIDs are the IDs of the trackfiles in the group.
+	ObjectIDs []uint64
+	// Point is the center of the group.
+	Point orb.Point
+	// Stacks are the altitude stacks of the group.
+	Stacks []brevity.Stack
+	// Track is the group's direction of travel.
+	Track brevity.Track
+	// Platforms are the aircraft types in the group.
+	Platforms []string
+}
+
+// Snapshot implements [Radar.Snapshot].
+func (s *scope) Snapshot() RadarSnapshot {
+	s.snapshotLock.RLock()
+	defer s.snapshotLock.RUnlock()
+
+	snapshot := RadarSnapshot{
+		MissionTime: s.missionTime,
+		Bullseyes:   make(map[coalitions.Coalition]orb.Point),
+		Groups:      make(map[coalitions.Coalition][]GroupSnapshot),
+	}
+	for _, coalition := range coalitions.All() {
+		if p, ok := s.bullseyes.Load(coalition); ok {
+			snapshot.Bullseyes[coalition] = p.(orb.Point)
+		}
+		groups := s.enumerateGroups(coalition)
+		if len(groups) == 0 {
+			continue
+		}
+		snapshots := make([]GroupSnapshot, 0, len(groups))
+		for _, grp := range groups {
+			snapshots = append(snapshots, GroupSnapshot{
+				ObjectIDs: grp.ObjectIDs(),
+				Point:     grp.point(),
+				Stacks:    grp.Stacks(),
+				Track:     grp.Track(),
+				Platforms: grp.Platforms(),
+			})
+		}
+		snapshot.Groups[coalition] = snapshots
+	}
+	for _, trackfile := range s.Trackfiles() {
+		snapshot.Trackfiles = append(snapshot.Trackfiles, TrackfileSnapshot{
+			Contact:   trackfile.Contact,
+			LastKnown: trackfile.LastKnown(),
+			Course:    trackfile.Course(),
+			Speed:     trackfile.Speed(),
+			Tags:      trackfile.Tags(),
+			IsFaded:   s.isStale(trackfile),
+		})
+	}
+	return snapshot
+}
@@ -0,0 +1,99 @@
+package radar
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+	s := &scope{
+		contacts: newContactDatabase(),
+		sweep:    newSweep(0),
+		zones:    newZoneDatabase(),
+	}
+	bullseye := orb.Point{42.0, 43.0}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.SetBullseye(bullseye, coalitions.Blue)
+	s.SetMissionTime(start)
+
+	snapshot := s.Snapshot()
+	assert.Equal(t, start, snapshot.MissionTime)
+	assert.Equal(t, map[coalitions.Coalition]orb.Point{coalitions.Blue: bullseye}, snapshot.Bullseyes)
+	assert.Empty(t, snapshot.Trackfiles)
+	assert.Empty(t, snapshot.Groups)
+
+	update := func(labels trackfiles.Labels, origin orb.Point, i int) {
+		point := spatial.PointAtBearingAndDistance(origin, bearings.NewTrueBearing(90*unit.Degree), unit.Length(i)*150*unit.Meter)
+		s.snapshotLock.Lock()
+		defer s.snapshotLock.Unlock()
+		s.handleUpdate(sim.Updated{
+			Labels: labels,
+			Frame: trackfiles.Frame{
+				Time:     start.Add(time.Duration(i) * time.Second),
+				Point:    point,
+				Altitude: 20000 * unit.Foot,
+			},
+		})
+	}
+	lead := trackfiles.Labels{ID: 2, Name: "Mobius 1", Coalition: coalitions.Blue, ACMIName: "F-15C"}
+	wingman := trackfiles.Labels{ID: 1, Name: "Mobius 2", Coalition: coalitions.Blue, ACMIName: "F-15C"}
+	bandit := trackfiles.Labels{ID: 3, Name: "Yellow 13", Coalition: coalitions.Red, ACMIName: "Su-27"}
+	for i := range 5 {
+		update(lead, orb.Point{42.5, 43.5}, i)
+		update(wingman, orb.Point{42.5, 43.501}, i)
+		update(bandit, orb.Point{43.5, 43.5}, i)
+	}
+
+	snapshot = s.Snapshot()
+	require.Len(t, snapshot.Trackfiles, 3)
+	assert.Equal(t, wingman, snapshot.Trackfiles[0].Contact, "trackfiles should be ordered by ID")
+	assert.Equal(t, lead, snapshot.Trackfiles[1].Contact)
+	assert.InDelta(t, 291, snapshot.Trackfiles[1].Speed.Knots(), 5)
+	require.Len(t, snapshot.Groups[coalitions.Blue], 1)
+	assert.ElementsMatch(t, []uint64{1, 2}, snapshot.Groups[coalitions.Blue][0].ObjectIDs)
+	assert.Equal(t, []string{"Eagle"}, snapshot.Groups[coalitions.Blue][0].Platforms)
+	require.Len(t, snapshot.Groups[coalitions.Red], 1)
+	assert.Equal(t, []uint64{3}, snapshot.Groups[coalitions.Red][0].ObjectIDs)
+}
+
+func TestSnapshotConcurrentUpdates(t *testing.T) {
+	t.Parallel()
+	s := &scope{
+		contacts: newContactDatabase(),
+		sweep:    newSweep(0),
+		zones:    newZoneDatabase(),
+	}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 200 {
+			labels := trackfiles.Labels{ID: uint64(i % 20), Name: "Mobius 1", Coalition: coalitions.Blue, ACMIName: "F-15C"}
+			s.snapshotLock.Lock()
+			s.handleUpdate(sim.Updated{
+				Labels: labels,
+				Frame:  trackfiles.Frame{Time: start.Add(time.Duration(i) * time.Second), Point: orb.Point{42.5, 43.5 + float64(i)/1000}},
+			})
+			s.snapshotLock.Unlock()
+			s.SetMissionTime(start.Add(time.Duration(i) * time.Second))
+		}
+	}()
+	for range 50 {
+		snapshot := s.Snapshot()
+		assert.LessOrEqual(t, len(snapshot.Trackfiles), 20)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,69 @@
+package radar
+
+import "time"
+
+// sweep models a rotating search radar, such as an AWACS rotodome. A contact is only painted once per sweep, so
+// position updates are quantized to sweep ticks, and a new contact only appears after it has been seen for a full
+// sweep. This makes the scope behave like a plausible sensor rather than perfect telemetry.
+type sweep struct {
+	// interval is the time for one rotation of the radar. Zero disables the model, and every update is painted.
+	interval time.Duration
+	// pending tracks contacts which have been seen but not yet painted.
+	pending map[uint64]pendingContact
+}
+
+// pendingContact is a contact which has been seen but not yet painted.
+type pendingContact struct {
+	// firstSeen is when the contact was first seen in the current run of updates.
+	firstSeen time.Time
+	// lastSeen is when the contact was most recently seen.
+	lastSeen time.Time
+}
+
+func newSweep(interval time.Duration) *sweep {
+	return &sweep{
+		interval: interval,
+		pending:  make(map[uint64]pendingContact),
+	}
+}
+
+// isPainted checks if an update for the given contact at the given time is painted by the radar. isOnScope is true if
+// the contact already has a trackfile, and lastPainted is the time of its most recent update.
+func (s *sweep) isPainted(id uint64, isOnScope bool, lastPainted, at time.Time) bool {
+	if s.interval <= 0 {
+		return true
+	}
+	if !isOnScope {
+		contact, ok := s.pending[id]
+		if !ok || at.Sub(contact.lastSeen) > s.interval {
+			contact.firstSeen = at
+		}
+		contact.lastSeen = at
+		if at.Sub(contact.firstSeen) < s.interval {
+			s.pending[id] = contact
+			return false
+		}
+		delete(s.pending, id)
+		return true
+	}
+	return lastPainted.IsZero() || s.tick(at) > s.tick(lastPainted)
+}
+
+// tick returns the index of the sweep during which the given time falls.
+func (s *sweep) tick(t time.Time) int64 {
+	return t.UnixNano() / s.interval.Nanoseconds()
+}
+
+// prune forgets pending contacts which have not been seen since the given time.
+func (s *sweep) prune(before time.Time) {
+	for id, contact := range s.pending {
+		if contact.lastSeen.Before(before) {
+			delete(s.pending, id)
+		}
+	}
+}
+
+// reset forgets all pending contacts.
+func (s *sweep) reset() {
+	clear(s.pending)
+}
@@ -0,0 +1,50 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSweepDisabled(t *testing.T) {
+	t.Parallel()
+	s := newSweep(0)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.True(t, s.isPainted(1, false, time.Time{}, now))
+	assert.True(t, s.isPainted(1, true, now, now.Add(time.Second)))
+}
+
+func TestSweepNewContact(t *testing.T) {
+	t.Parallel()
+	s := newSweep(10 * time.Second)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.False(t, s.isPainted(1, false, time.Time{}, start))
+	assert.False(t, s.isPainted(1, false, time.Time{}, start.Add(5*time.Second)))
+	assert.True(t, s.isPainted(1, false, time.Time{}, start.Add(10*time.Second)))
+
+	// A contact which drops out of the updates for longer than a sweep starts over.
+	assert.False(t, s.isPainted(2, false, time.Time{}, start))
+	assert.False(t, s.isPainted(2, false, time.Time{}, start.Add(30*time.Second)))
+	assert.True(t, s.isPainted(2, false, time.Time{}, start.Add(40*time.Second)))
+}
+
+func TestSweepQuantizesUpdates(t *testing.T) {
+	t.Parallel()
+	s := newSweep(10 * time.Second)
+	painted := time.Date(2024, 1, 1, 12, 0, 2, 0, time.UTC)
+	assert.False(t, s.isPainted(1, true, painted, painted.Add(2*time.Second)))
+	assert.False(t, s.isPainted(1, true, painted, painted.Add(7*time.Second)))
+	assert.True(t, s.isPainted(1, true, painted, painted.Add(8*time.Second)))
+}
+
+func TestSweepPrune(t *testing.T) {
+	t.Parallel()
+	s := newSweep(10 * time.Second)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.isPainted(1, false, time.Time{}, start)
+	s.isPainted(2, false, time.Time{}, start.Add(time.Minute))
+	s.prune(start.Add(30 * time.Second))
+	assert.NotContains(t, s.pending, uint64(1))
+	assert.Contains(t, s.pending, uint64(2))
+}
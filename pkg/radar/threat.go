@@ -7,6 +7,7 @@ import (
 	"github.com/dharmab/skyeye/pkg/coalitions"
 	"github.com/dharmab/skyeye/pkg/spatial"
 	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
 )
 
 func (s *scope) Threats(coalition coalitions.Coalition) map[brevity.Group][]uint64 {
@@ -17,7 +18,11 @@ func (s *scope) Threats(coalition coalitions.Coalition) map[brevity.Group][]uint
 		radius = s.mandatoryThreatRadius
 	}
 	for _, grp := range hostileGroups {
+		if s.excludeNonCombatants && grp.isNonCombatant() {
+			continue
+		}
 		friendlyGroups := s.findNearbyGroups(
+			orb.Point{},
 			grp.point(),
 			0,
 			math.MaxFloat64,
@@ -54,7 +59,7 @@ func (s *scope) Threats(coalition coalitions.Coalition) map[brevity.Group][]uint
 			bearing := spatial.TrueBearing(trackfile.LastKnown().Point, grp.point()).Magnetic(declination)
 			_range := spatial.Distance(trackfile.LastKnown().Point, grp.point())
 			aspect := brevity.AspectFromAngle(bearing, grp.course())
-			grp.braa = brevity.NewBRAA(bearing, _range, grp.altitudes(), aspect)
+			grp.braa = brevity.NewBRAAWithTrend(bearing, _range, grp.altitudes(), aspect, grp.aspectTrend(trackfile.LastKnown().Point))
 			grp.bullseye = nil
 		}
 	}
@@ -0,0 +1,190 @@
+package radar
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dharmab/skyeye/pkg/alerts"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/encyclopedia"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// zoneExitMargin is how far beyond a zone's ring a trackfile which is inside the zone must fly to leave it, so that
+	// a trackfile skirting the edge of the ring is not warned repeatedly.
+	zoneExitMargin = 2 * unit.NauticalMile
+	// zoneCeilingMargin is how far above a zone's ceiling a trackfile which is inside the zone must climb to leave it.
+	zoneCeilingMargin = 2000 * unit.Foot
+)
+
+// ThreatZone is the engagement zone of a single SAM or AAA launcher: a ring around the launcher, up to the system's
+// ceiling.
+type ThreatZone struct {
+	// ID of the launcher.
+	ID uint64
+	// Site identifies the launcher's site: its coalition, mission group and system. Launchers at the same site share a
+	// site, so that a pilot entering several overlapping rings is warned once.
+	Site string
+	// System is the common name of the system, such as "SA-11".
+	System string
+	// Group is the name of the launcher's group in the mission. Empty if unknown.
+	Group string
+	// Coalition the launcher belongs to.
+	Coalition coalitions.Coalition
+	// Center of the ring.
+	Center orb.Point
+	// Radius of the ring.
+	Radius unit.Length
+	// Ceiling is the highest altitude above the launcher the system can engage. Zero if the system can reach any
+	// altitude.
+	Ceiling unit.Length
+}
+
+// ThreatZoneCallback is a callback function that is called when a trackfile enters a threat zone of the opposing
+// coalition. It is called once per site, when the trackfile first enters any of the site's zones.
+type ThreatZoneCallback func(trackfile *trackfiles.Trackfile, zone ThreatZone)
+
+func (s *scope) SetThreatZoneCallback(callback ThreatZoneCallback) {
+	s.threatZoneCallback = callback
+}
+
+// zoneDatabase holds the threat zones and the sites each trackfile is inside of.
+type zoneDatabase struct {
+	zones []ThreatZone
+	// inside maps trackfile IDs to the sites the trackfile was inside of at the last check.
+	inside map[uint64]map[string]struct{}
+	lock   sync.RWMutex
+}
+
+func newZoneDatabase() *zoneDatabase {
+	return &zoneDatabase{inside: make(map[uint64]map[string]struct{})}
+}
+
+// SetAirDefenses implements [Radar.SetAirDefenses].
+func (s *scope) SetAirDefenses(units []sim.GroundUnit) {
+	zones := make([]ThreatZone, 0)
+	for _, groundUnit := range units {
+		sam, ok := encyclopedia.GetSAMData(groundUnit.Name)
+		if !ok {
+			continue
+		}
+		site := fmt.Sprintf("%s/%s/%s", groundUnit.Coalition, groundUnit.Group, sam.System)
+		if groundUnit.Group == "" {
+			site = fmt.Sprintf("%s/#%d", groundUnit.Coalition, groundUnit.ID)
+		}
+		zones = append(zones, ThreatZone{
+			ID:        groundUnit.ID,
+			Site:      site,
+			System:    sam.System,
+			Group:     groundUnit.Group,
+			Coalition: groundUnit.Coalition,
+			Center:    groundUnit.Point,
+			Radius:    sam.ThreatRadius,
+			Ceiling:   sam.Ceiling,
+		})
+	}
+	s.zones.lock.Lock()
+	defer s.zones.lock.Unlock()
+	s.zones.zones = zones
+}
+
+// ThreatZones implements [Radar.ThreatZones].
+func (s *scope) ThreatZones(coalition coalitions.Coalition) []ThreatZone {
+	s.zones.lock.RLock()
+	defer s.zones.lock.RUnlock()
+	zones := make([]ThreatZone, 0)
+	for _, zone := range s.zones.zones {
+		if zone.Coalition == coalition {
+			zones = append(zones, zone)
+		}
+	}
+	return zones
+}
+
+// contains returns true if the trackfile is within the zone's ring and below its ceiling. A trackfile which was inside
+// the zone at the last check remains inside until it clears the ring or ceiling by a margin.
+func (s *scope) contains(zone ThreatZone, trackfile *trackfiles.Trackfile, wasInside bool) bool {
+	frame := trackfile.LastKnown()
+	ring := alerts.Hysteresis[unit.Length]{Enter: zone.Radius, Exit: zone.Radius + zoneExitMargin}
+	if !ring.Update(wasInside, spatial.Distance(zone.Center, frame.Point)) {
+		return false
+	}
+	if zone.Ceiling == 0 {
+		return true
+	}
+	height := frame.Altitude
+	if s.terrain != nil {
+		if elevation, ok := s.terrain.Elevation(zone.Center); ok {
+			height -= elevation
+		}
+	}
+	ceiling := alerts.Hysteresis[unit.Length]{Enter: zone.Ceiling, Exit: zone.Ceiling + zoneCeilingMargin}
+	return ceiling.Update(wasInside, height)
+}
+
+// checkThreatZones finds trackfiles which have entered a threat zone of the opposing coalition since the last check,
+// and calls the threat zone callback for each.
+func (s *scope) checkThreatZones() {
+	type entry struct {
+		trackfile *trackfiles.Trackfile
+		zone      ThreatZone
+	}
+	entries := make([]entry, 0)
+	func() {
+		s.zones.lock.Lock()
+		defer s.zones.lock.Unlock()
+		inside := make(map[uint64]map[string]struct{})
+		for trackfile := range s.contacts.values() {
+			if !s.isValidTrack(trackfile) || s.isStale(trackfile) {
+				continue
+			}
+			id := trackfile.Contact.ID
+			for _, zone := range s.zones.zones {
+				if zone.Coalition != trackfile.Contact.Coalition.Opposite() {
+					continue
+				}
+				if _, ok := inside[id][zone.Site]; ok {
+					continue
+				}
+				_, wasInside := s.zones.inside[id][zone.Site]
+				if !s.contains(zone, trackfile, wasInside) {
+					continue
+				}
+				if inside[id] == nil {
+					inside[id] = make(map[string]struct{})
+				}
+				inside[id][zone.Site] = struct{}{}
+				if !wasInside {
+					entries = append(entries, entry{trackfile: trackfile, zone: zone})
+				}
+			}
+		}
+		s.zones.inside = inside
+	}()
+
+	// Call the callback after releasing the lock, since the callback may query the zones.
+	for _, e := range entries {
+		log.Info().
+			Uint64("id", e.trackfile.Contact.ID).
+			Str("name", e.trackfile.Contact.Name).
+			Str("system", e.zone.System).
+			Str("group", e.zone.Group).
+			Msg("trackfile entered threat zone")
+		if s.threatZoneCallback != nil {
+			s.threatZoneCallback(e.trackfile, e.zone)
+		}
+	}
+}
+
+// reset forgets which zones each trackfile was inside of.
+func (d *zoneDatabase) reset() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.inside = make(map[uint64]map[string]struct{})
+}
@@ -0,0 +1,83 @@
+package radar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckThreatZones(t *testing.T) {
+	t.Parallel()
+	site := orb.Point{42.5, 43.5}
+	s := &scope{
+		contacts: newContactDatabase(),
+		sweep:    newSweep(0),
+		zones:    newZoneDatabase(),
+	}
+	s.SetAirDefenses([]sim.GroundUnit{
+		{ID: 100, Name: "SA-11 Buk LN 9A310M1", Group: "SAM-1", Coalition: coalitions.Red, Point: site},
+		{ID: 101, Name: "SA-11 Buk LN 9A310M1", Group: "SAM-1", Coalition: coalitions.Red, Point: site},
+		{ID: 102, Name: "ZSU-23-4 Shilka", Group: "AAA-1", Coalition: coalitions.Red, Point: site},
+		{ID: 103, Name: "T-72B", Group: "Armor-1", Coalition: coalitions.Red, Point: site},
+	})
+	require.Len(t, s.ThreatZones(coalitions.Red), 3)
+	assert.Empty(t, s.ThreatZones(coalitions.Blue))
+
+	var entered []string
+	s.SetThreatZoneCallback(func(trackfile *trackfiles.Trackfile, zone ThreatZone) {
+		entered = append(entered, zone.System)
+	})
+
+	labels := trackfiles.Labels{ID: 1, Name: "Mobius 1", Coalition: coalitions.Blue, ACMIName: "F-15C"}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	i := 0
+	fly := func(distance unit.Length) {
+		for range 5 {
+			// Fly north past the site, starting the given distance south of it.
+			point := spatial.PointAtBearingAndDistance(site, bearings.NewTrueBearing(180*unit.Degree), distance)
+			point = spatial.PointAtBearingAndDistance(point, bearings.NewTrueBearing(0), unit.Length(i)*50*unit.Meter)
+			s.handleUpdate(sim.Updated{
+				Labels: labels,
+				Frame: trackfiles.Frame{
+					Time:     start.Add(time.Duration(i) * time.Second),
+					Point:    point,
+					Altitude: 20000 * unit.Foot,
+				},
+			})
+			i++
+		}
+		s.checkThreatZones()
+	}
+
+	fly(30 * unit.NauticalMile)
+	assert.Empty(t, entered)
+
+	// The SA-11 rings overlap, so the site is reported once. The Shilka cannot reach 20000 feet.
+	fly(10 * unit.NauticalMile)
+	assert.Equal(t, []string{"SA-11"}, entered)
+
+	fly(10 * unit.NauticalMile)
+	assert.Equal(t, []string{"SA-11"}, entered, "remaining inside the ring should not repeat the warning")
+
+	fly(30 * unit.NauticalMile)
+	fly(10 * unit.NauticalMile)
+	assert.Equal(t, []string{"SA-11", "SA-11"}, entered, "re-entering the ring should repeat the warning")
+
+	// Drifting just outside the 19NM ring does not leave the zone, so drifting back in does not repeat the warning.
+	fly(20.5 * unit.NauticalMile)
+	fly(10 * unit.NauticalMile)
+	assert.Equal(t, []string{"SA-11", "SA-11"}, entered, "skirting the edge of the ring should not repeat the warning")
+
+	fly(30 * unit.NauticalMile)
+	fly(10 * unit.NauticalMile)
+	assert.Equal(t, []string{"SA-11", "SA-11", "SA-11"}, entered, "clearing the ring by the margin should reset the warning")
+}
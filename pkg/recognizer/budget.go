@@ -0,0 +1,74 @@
+package recognizer
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrOverloaded is returned by a budgeted recognizer when too many samples are already waiting for recognition.
+var ErrOverloaded = errors.New("speech recognition is overloaded")
+
+// budgetedRecognizer limits the number of samples recognized concurrently. Excess samples wait in a queue. When the
+// queue is deep, samples are recognized using a cheaper fallback recognizer (if provided) to catch up. When the queue
+// is full, samples are rejected with ErrOverloaded rather than exhausting memory on the host.
+type budgetedRecognizer struct {
+	recognizer Recognizer
+	fallback   Recognizer
+	// slots is a semaphore limiting the number of concurrent recognitions.
+	slots chan struct{}
+	// pending is the number of samples being recognized or waiting for a slot.
+	pending atomic.Int32
+	// maxQueued is the maximum number of samples that may wait for a slot.
+	maxQueued int32
+	// fallbackDepth is the queue depth at or above which the fallback recognizer is used.
+	fallbackDepth int32
+}
+
+var _ Recognizer = &budgetedRecognizer{}
+
+// NewBudgetedRecognizer creates a Recognizer which runs at most maxConcurrent recognitions at once, and queues at most
+// maxQueued further samples. If fallback is not nil, it is used instead of the recognizer whenever fallbackDepth or
+// more samples are queued.
+func NewBudgetedRecognizer(recognizer Recognizer, fallback Recognizer, maxConcurrent, maxQueued, fallbackDepth int) Recognizer {
+	return &budgetedRecognizer{
+		recognizer:    recognizer,
+		fallback:      fallback,
+		slots:         make(chan struct{}, max(1, maxConcurrent)),
+		maxQueued:     int32(max(0, maxQueued)),
+		fallbackDepth: int32(max(1, fallbackDepth)),
+	}
+}
+
+// Recognize implements [Recognizer.Recognize].
+func (r *budgetedRecognizer) Recognize(ctx context.Context, sample []float32, enableTranscriptionLogging bool) (Transcript, error) {
+	depth := r.pending.Add(1)
+	if depth > r.maxQueued+int32(cap(r.slots)) {
+		r.pending.Add(-1)
+		log.Warn().Int32("depth", depth-1).Msg("speech recognition queue is full, discarding audio sample")
+		return Transcript{}, ErrOverloaded
+	}
+
+	// The fallback decision is made on arrival, since it reflects how far behind we are.
+	recognizer := r.recognizer
+	waiting := depth - int32(cap(r.slots))
+	if r.fallback != nil && waiting >= r.fallbackDepth {
+		log.Warn().Int32("queued", waiting).Msg("speech recognition is running behind, using fallback model")
+		recognizer = r.fallback
+	}
+
+	select {
+	case r.slots <- struct{}{}:
+	case <-ctx.Done():
+		r.pending.Add(-1)
+		return Transcript{}, ctx.Err()
+	}
+	defer func() {
+		<-r.slots
+		r.pending.Add(-1)
+	}()
+
+	return recognizer.Recognize(ctx, sample, enableTranscriptionLogging)
+}
@@ -0,0 +1,114 @@
+package recognizer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingRecognizer blocks until released, and then returns fixed text.
+type blockingRecognizer struct {
+	text     string
+	started  chan struct{}
+	release  chan struct{}
+	lock     sync.Mutex
+	inflight int
+	peak     int
+}
+
+func newBlockingRecognizer(text string) *blockingRecognizer {
+	return &blockingRecognizer{
+		text:    text,
+		started: make(chan struct{}, 16),
+		release: make(chan struct{}),
+	}
+}
+
+func (r *blockingRecognizer) Recognize(ctx context.Context, _ []float32, _ bool) (Transcript, error) {
+	r.lock.Lock()
+	r.inflight++
+	r.peak = max(r.peak, r.inflight)
+	r.lock.Unlock()
+	defer func() {
+		r.lock.Lock()
+		r.inflight--
+		r.lock.Unlock()
+	}()
+	r.started <- struct{}{}
+	select {
+	case <-r.release:
+	case <-ctx.Done():
+		return Transcript{}, ctx.Err()
+	}
+	return Transcript{Text: r.text, Confidence: 1}, nil
+}
+
+func TestBudgetedRecognizerLimitsConcurrency(t *testing.T) {
+	t.Parallel()
+	primary := newBlockingRecognizer("primary")
+	r := NewBudgetedRecognizer(primary, nil, 1, 4, 1)
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			transcript, err := r.Recognize(context.Background(), nil, false)
+			assert.NoError(t, err)
+			assert.Equal(t, "primary", transcript.Text)
+		}()
+	}
+	for range 3 {
+		<-primary.started
+		primary.release <- struct{}{}
+	}
+	wg.Wait()
+	assert.Equal(t, 1, primary.peak)
+}
+
+func TestBudgetedRecognizerOverloaded(t *testing.T) {
+	t.Parallel()
+	primary := newBlockingRecognizer("primary")
+	r := NewBudgetedRecognizer(primary, nil, 1, 1, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Occupy the only slot.
+	go func() { _, _ = r.Recognize(ctx, nil, false) }()
+	<-primary.started
+	// Occupy the only place in the queue.
+	go func() { _, _ = r.Recognize(ctx, nil, false) }()
+	require.Eventually(t, func() bool {
+		return r.(*budgetedRecognizer).pending.Load() == 2
+	}, time.Second, time.Millisecond)
+
+	_, err := r.Recognize(ctx, nil, false)
+	require.ErrorIs(t, err, ErrOverloaded)
+}
+
+func TestBudgetedRecognizerFallback(t *testing.T) {
+	t.Parallel()
+	primary := newBlockingRecognizer("primary")
+	fallback := &fakeRecognizer{text: "fallback"}
+	r := NewBudgetedRecognizer(primary, fallback, 1, 4, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() { _, _ = r.Recognize(ctx, nil, false) }()
+	<-primary.started
+
+	result := make(chan string)
+	go func() {
+		transcript, _ := r.Recognize(ctx, nil, false)
+		result <- transcript.Text
+	}()
+	require.Eventually(t, func() bool {
+		return r.(*budgetedRecognizer).pending.Load() == 2
+	}, time.Second, time.Millisecond)
+	primary.release <- struct{}{}
+	assert.Equal(t, "fallback", <-result)
+}
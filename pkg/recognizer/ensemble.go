@@ -0,0 +1,32 @@
+package recognizer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Merge combines two transcripts of the same audio sample from different recognition passes. If both passes
+// recognized the same words, the agreement makes the text more likely to be correct than either pass alone suggests,
+// so the merged confidence is the probability that at least one pass was correct. Otherwise, the more confident
+// transcript is returned.
+func Merge(first, second Transcript) Transcript {
+	if normalize(first.Text) == normalize(second.Text) {
+		return Transcript{
+			Text:       first.Text,
+			Confidence: 1 - (1-first.Confidence)*(1-second.Confidence),
+		}
+	}
+	if second.Confidence > first.Confidence {
+		return second
+	}
+	return first
+}
+
+// normalize reduces text to lowercase words of letters and digits, so that transcripts which differ only in case,
+// punctuation or spacing compare equal.
+func normalize(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,44 @@
+package recognizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		first    Transcript
+		second   Transcript
+		expected Transcript
+	}{
+		{
+			name:     "agreement",
+			first:    Transcript{Text: "Anyface, Mobius 1, declare 0 5 5, 32.", Confidence: 0.6},
+			second:   Transcript{Text: "anyface mobius 1 declare 0 5 5 32", Confidence: 0.5},
+			expected: Transcript{Text: "Anyface, Mobius 1, declare 0 5 5, 32.", Confidence: 0.8},
+		},
+		{
+			name:     "second more confident",
+			first:    Transcript{Text: "anyface mobius 1 declare 0 5 5 32", Confidence: 0.5},
+			second:   Transcript{Text: "anyface mobius 1 declare 0 9 5 32", Confidence: 0.7},
+			expected: Transcript{Text: "anyface mobius 1 declare 0 9 5 32", Confidence: 0.7},
+		},
+		{
+			name:     "first more confident",
+			first:    Transcript{Text: "anyface mobius 1 declare 0 5 5 32", Confidence: 0.7},
+			second:   Transcript{Text: "anyface mobius 1 declare 0 9 5 32", Confidence: 0.5},
+			expected: Transcript{Text: "anyface mobius 1 declare 0 5 5 32", Confidence: 0.7},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual := Merge(test.first, test.second)
+			assert.Equal(t, test.expected.Text, actual.Text)
+			assert.InDelta(t, test.expected.Confidence, actual.Confidence, 0.001)
+		})
+	}
+}
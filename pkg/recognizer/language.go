@@ -0,0 +1,22 @@
+package recognizer
+
+import "context"
+
+// DefaultLanguage is the language speech is recognized in if no language hint is provided.
+const DefaultLanguage = "en"
+
+type languageKey struct{}
+
+// WithLanguage returns a context carrying a hint that speech should be recognized in the given language. The language
+// is a two-letter ISO 639-1 code, such as "en" or "ru".
+func WithLanguage(ctx context.Context, language string) context.Context {
+	return context.WithValue(ctx, languageKey{}, language)
+}
+
+// Language returns the language hint carried by the context, or DefaultLanguage if there is none.
+func Language(ctx context.Context) string {
+	if language, ok := ctx.Value(languageKey{}).(string); ok && language != "" {
+		return language
+	}
+	return DefaultLanguage
+}
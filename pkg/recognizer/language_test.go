@@ -0,0 +1,16 @@
+package recognizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguage(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	assert.Equal(t, DefaultLanguage, Language(ctx))
+	assert.Equal(t, "ru", Language(WithLanguage(ctx, "ru")))
+	assert.Equal(t, DefaultLanguage, Language(WithLanguage(ctx, "")))
+}
@@ -6,5 +6,13 @@ import "context"
 // Recognizer recognizes text from speech.
 type Recognizer interface {
 	// Recognize takes PCMF32LE audio data and returns any recognized text.
-	Recognize(ctx context.Context, pcm []float32, enableTranscriptionLogging bool) (string, error)
+	Recognize(ctx context.Context, pcm []float32, enableTranscriptionLogging bool) (Transcript, error)
+}
+
+// Transcript is text recognized from speech.
+type Transcript struct {
+	// Text that was recognized.
+	Text string
+	// Confidence is how confident the recognizer is that the text is correct, from 0 to 1.
+	Confidence float64
 }
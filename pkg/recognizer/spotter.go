@@ -0,0 +1,62 @@
+package recognizer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ggerganov/whisper.cpp/bindings/go/pkg/whisper"
+	"github.com/rs/zerolog/log"
+)
+
+// keywordSpottingWindow is the length of audio at the start of each sample which is checked for a keyword. Requests
+// always begin with the GCI callsign, so there is no need to recognize the entire sample.
+const keywordSpottingWindow = 3 * time.Second
+
+// keywordFilter is a Recognizer which runs a cheap keyword spotting pass on the start of each sample before running
+// full speech recognition. Samples which do not contain a keyword are discarded without running full recognition.
+type keywordFilter struct {
+	spotter    Recognizer
+	recognizer Recognizer
+	isKeyword  func(string) bool
+}
+
+var _ Recognizer = &keywordFilter{}
+
+// NewKeywordFilter creates a Recognizer which uses the spotter to recognize the first few seconds of each sample. If
+// isKeyword returns true for the spotter's output, the entire sample is recognized using the recognizer. Otherwise,
+// the sample is discarded and an empty transcript is returned. The spotter should be much cheaper to run than the
+// recognizer, e.g. a smaller model.
+func NewKeywordFilter(spotter Recognizer, recognizer Recognizer, isKeyword func(string) bool) Recognizer {
+	return &keywordFilter{
+		spotter:    spotter,
+		recognizer: recognizer,
+		isKeyword:  isKeyword,
+	}
+}
+
+// Recognize implements [Recognizer.Recognize].
+func (f *keywordFilter) Recognize(ctx context.Context, sample []float32, enableTranscriptionLogging bool) (Transcript, error) {
+	window := int(keywordSpottingWindow.Seconds() * whisper.SampleRate)
+	head := sample
+	if len(head) > window {
+		head = head[:window]
+	}
+
+	start := time.Now()
+	spotted, err := f.spotter.Recognize(ctx, head, false)
+	if err != nil {
+		return Transcript{}, fmt.Errorf("error spotting keyword: %w", err)
+	}
+	event := log.Debug().Stringer("clockTime", time.Since(start))
+	if enableTranscriptionLogging {
+		event = event.Str("text", spotted.Text)
+	}
+	if !f.isKeyword(spotted.Text) {
+		event.Msg("no keyword spotted in audio sample, skipping speech recognition")
+		return Transcript{}, nil
+	}
+	event.Msg("keyword spotted in audio sample")
+
+	return f.recognizer.Recognize(ctx, sample, enableTranscriptionLogging)
+}
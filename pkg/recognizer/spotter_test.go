@@ -0,0 +1,70 @@
+package recognizer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRecognizer returns fixed text and records the length of the last sample it recognized.
+type fakeRecognizer struct {
+	text      string
+	err       error
+	calls     int
+	lastInput int
+}
+
+func (r *fakeRecognizer) Recognize(_ context.Context, sample []float32, _ bool) (Transcript, error) {
+	r.calls++
+	r.lastInput = len(sample)
+	return Transcript{Text: r.text, Confidence: 1}, r.err
+}
+
+func isAnyface(text string) bool {
+	return strings.HasPrefix(text, "anyface")
+}
+
+func TestKeywordFilterSpotted(t *testing.T) {
+	t.Parallel()
+	spotter := &fakeRecognizer{text: "anyface mobius"}
+	full := &fakeRecognizer{text: "anyface mobius 1 radio check"}
+	filter := NewKeywordFilter(spotter, full, isAnyface)
+
+	sample := make([]float32, 10*16000)
+	transcript, err := filter.Recognize(context.Background(), sample, true)
+	require.NoError(t, err)
+	assert.Equal(t, "anyface mobius 1 radio check", transcript.Text)
+	assert.Equal(t, 1, spotter.calls)
+	assert.Equal(t, 3*16000, spotter.lastInput)
+	assert.Equal(t, 1, full.calls)
+	assert.Equal(t, len(sample), full.lastInput)
+}
+
+func TestKeywordFilterNotSpotted(t *testing.T) {
+	t.Parallel()
+	spotter := &fakeRecognizer{text: "mobius 1 fox 3"}
+	full := &fakeRecognizer{text: "mobius 1 fox 3 on the bandit"}
+	filter := NewKeywordFilter(spotter, full, isAnyface)
+
+	transcript, err := filter.Recognize(context.Background(), make([]float32, 16000), true)
+	require.NoError(t, err)
+	assert.Empty(t, transcript.Text)
+	assert.Equal(t, 1, spotter.calls)
+	assert.Equal(t, 16000, spotter.lastInput)
+	assert.Zero(t, full.calls)
+}
+
+func TestKeywordFilterSpotterError(t *testing.T) {
+	t.Parallel()
+	spotter := &fakeRecognizer{err: errors.New("boom")}
+	full := &fakeRecognizer{text: "anyface mobius 1 radio check"}
+	filter := NewKeywordFilter(spotter, full, isAnyface)
+
+	_, err := filter.Recognize(context.Background(), make([]float32, 16000), true)
+	require.Error(t, err)
+	assert.Zero(t, full.calls)
+}
@@ -26,7 +26,7 @@ func NewWhisperRecognizer(model *whisper.Model, callsign string) Recognizer {
 const maxSize = 256 * 1024
 
 // Recognize implements [Recognizer.Recognize] using whisper.cpp.
-func (r *whisperRecognizer) Recognize(ctx context.Context, sample []float32, enableTranscriptionLogging bool) (string, error) {
+func (r *whisperRecognizer) Recognize(ctx context.Context, sample []float32, enableTranscriptionLogging bool) (Transcript, error) {
 	if len(sample) > maxSize {
 		log.Warn().Int("length", len(sample)).Int("maxLength", maxSize).Msg("clamping sample to maximum size")
 		sample = sample[:maxSize]
@@ -34,13 +34,22 @@ func (r *whisperRecognizer) Recognize(ctx context.Context, sample []float32, ena
 
 	wCtx, err := r.model.NewContext()
 	if err != nil {
-		return "", fmt.Errorf("error creating whisper context: %w", err)
+		return Transcript{}, fmt.Errorf("error creating whisper context: %w", err)
 	}
 	prompt := fmt.Sprintf("You receive commands in this template: {Either ANYFACE or %s} {PILOT CALLSIGN} {DIGITS} {'RADIO' or 'ALPHA' or 'BOGEY' or 'PICTURE' or 'DECLARE' or 'SNAPLOCK' or 'SPIKED'} {ARGUMENTS}. Parse numbers as digits. Separate numbers if there is silence between them. You may hear keywords in the arguments such as BULLSEYE or BRAA.", r.callsign)
 	wCtx.SetInitialPrompt(prompt)
 
+	language := Language(ctx)
 	if wCtx.IsMultilingual() {
-		_ = wCtx.SetLanguage("en")
+		if err := wCtx.SetLanguage(language); err != nil {
+			log.Warn().Err(err).Str("language", language).Msg("unsupported language hint, falling back to default language")
+			_ = wCtx.SetLanguage(DefaultLanguage)
+		} else if language != DefaultLanguage {
+			// The parser only understands English, so translate other languages as we recognize them.
+			wCtx.SetTranslate(true)
+		}
+	} else if language != DefaultLanguage {
+		log.Warn().Str("language", language).Msg("whisper model is not multilingual, ignoring language hint")
 	}
 
 	err = wCtx.Process(
@@ -55,24 +64,40 @@ func (r *whisperRecognizer) Recognize(ctx context.Context, sample []float32, ena
 		nil,
 	)
 	if err != nil {
-		return "", fmt.Errorf("error processing sample: %w", err)
+		return Transcript{}, fmt.Errorf("error processing sample: %w", err)
 	}
 
 	var textBuilder strings.Builder
+	// The confidence is the mean probability of the text tokens.
+	var totalP float64
+	var textTokens int
+	transcript := func() Transcript {
+		t := Transcript{Text: textBuilder.String()}
+		if textTokens > 0 {
+			t.Confidence = totalP / float64(textTokens)
+		}
+		return t
+	}
 	for {
 		select {
 		case <-ctx.Done():
 			log.Warn().Msg("returning early from speech recognition due to context cancellation")
-			return textBuilder.String(), nil
+			return transcript(), nil
 		default:
 			segment, err := wCtx.NextSegment()
 			if errors.Is(err, io.EOF) {
-				return textBuilder.String(), nil
+				return transcript(), nil
 			}
 			if err != nil {
-				return textBuilder.String(), fmt.Errorf("error processing segment: %w", err)
+				return transcript(), fmt.Errorf("error processing segment: %w", err)
 			}
 			textBuilder.WriteString(segment.Text)
+			for _, token := range segment.Tokens {
+				if wCtx.IsText(token) {
+					totalP += float64(token.P)
+					textTokens++
+				}
+			}
 		}
 	}
 }
@@ -7,18 +7,27 @@ import (
 
 	"github.com/dharmab/skyeye/pkg/coalitions"
 	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
 	"github.com/paulmach/orb"
 )
 
 // Sim is the interface for receiving telemetry data from the flight simulator.
 type Sim interface {
 	// Stream aircraft updates from the sim to the provided channels.
-	// The first channel receives updates for active aircraft.
-	// The second channel receives messages when an aircraft disappears.
+	// The first channel receives messages when a mission starts.
+	// The second channel receives updates for active aircraft.
+	// The third channel receives messages when an aircraft disappears.
+	// The remaining channels receive weapon and sensor events, if the sim provides them.
 	// This function blocks until the context is cancelled.
-	Stream(context.Context, chan<- Started, chan<- Updated, chan<- Faded)
+	Stream(context.Context, chan<- Started, chan<- Updated, chan<- Faded, chan<- WeaponLaunched, chan<- WeaponImpacted, chan<- SensorEvent)
 	// Bullseye returns the coalition's bullseye center.
 	Bullseye(coalitions.Coalition) (orb.Point, error)
+	// GroundUnits returns every ground unit and ship currently in the sim, excluding static objects.
+	GroundUnits() []GroundUnit
+	// Ejections returns every ejected pilot seen since the mission started, including pilots who have since landed.
+	Ejections() []Ejection
+	// Kills returns every object destroyed since the mission started.
+	Kills() []Kill
 	// Time returns the starting time of the mission.
 	// This is useful for looking up magnetic variation.
 	Time() time.Time
@@ -49,3 +58,107 @@ type Faded struct {
 	// ID of the aircraft that disappeared.
 	ID uint64
 }
+
+// WeaponLaunched is a message sent when a missile, rocket, bomb or torpedo first appears. Guns are not reported.
+type WeaponLaunched struct {
+	// ID of the weapon.
+	ID uint64
+	// Name is the weapon type, such as "AIM_120C".
+	Name string
+	// Coalition of the weapon.
+	Coalition coalitions.Coalition
+	// ParentID is the ID of the object which launched the weapon, or zero if unknown.
+	ParentID uint64
+	// Point is the weapon's position when it was first seen.
+	Point orb.Point
+	// Altitude is the weapon's altitude when it was first seen.
+	Altitude unit.Length
+	// Mission time when the weapon was first seen.
+	MissionTimestamp time.Time
+}
+
+// WeaponImpacted is a message sent when a weapon disappears, either because it hit something or because it reached
+// the end of its flight without hitting anything. The telemetry does not distinguish between the two.
+type WeaponImpacted struct {
+	// ID of the weapon.
+	ID uint64
+	// Name is the weapon type, such as "AIM_120C".
+	Name string
+	// Coalition of the weapon.
+	Coalition coalitions.Coalition
+	// Point is the weapon's last known position.
+	Point orb.Point
+	// Altitude is the weapon's last known altitude.
+	Altitude unit.Length
+	// Mission time when the weapon disappeared.
+	MissionTimestamp time.Time
+}
+
+// SensorEventKind is a change in the state of an object's sensors.
+type SensorEventKind string
+
+const (
+	// RadarOn means the object's radar started transmitting.
+	RadarOn SensorEventKind = "radar on"
+	// RadarOff means the object's radar stopped transmitting.
+	RadarOff SensorEventKind = "radar off"
+	// Locked means the object locked a target.
+	Locked SensorEventKind = "locked"
+	// Unlocked means the object dropped its lock.
+	Unlocked SensorEventKind = "unlocked"
+)
+
+// SensorEvent is a message sent when the state of an object's sensors changes.
+type SensorEvent struct {
+	// ID of the object whose sensors changed state.
+	ID uint64
+	// Kind of change.
+	Kind SensorEventKind
+	// TargetID is the ID of the locked target for Locked events, or of the previously locked target for Unlocked
+	// events. Zero for other events.
+	TargetID uint64
+	// Mission time when the change was observed.
+	MissionTimestamp time.Time
+}
+
+// GroundUnit is a snapshot of a ground unit or ship.
+type GroundUnit struct {
+	// ID of the unit.
+	ID uint64
+	// Name is the unit type, such as "T-72B" or "MOSCOW".
+	Name string
+	// Group is the name of the unit's group in the mission. Empty if unknown.
+	Group string
+	// Coalition the unit belongs to.
+	Coalition coalitions.Coalition
+	// Point is the unit's position.
+	Point orb.Point
+	// Types are the unit's ACMI type tags, such as "Ground", "Armor" and "Tank".
+	Types []string
+}
+
+// Ejection is a record of a pilot who ejected from their aircraft.
+type Ejection struct {
+	// ID of the parachutist object.
+	ID uint64
+	// Coalition the pilot belongs to.
+	Coalition coalitions.Coalition
+	// Point is the pilot's last known position. Once the pilot lands, this is where they landed.
+	Point orb.Point
+	// Time is the mission time when the ejection was first seen.
+	Time time.Time
+}
+
+// Kill is a record of an object which was destroyed.
+type Kill struct {
+	// ID of the destroyed object.
+	ID uint64
+	// Name is the object type, such as "F-16C_50" or "T-72B".
+	Name string
+	// Coalition the object belonged to.
+	Coalition coalitions.Coalition
+	// IsAircraft is true if the object was an airplane or helicopter.
+	IsAircraft bool
+	// Time is the mission time when the object was destroyed.
+	Time time.Time
+}
@@ -10,22 +10,50 @@ import (
 	"time"
 
 	"github.com/dharmab/skyeye/pkg/simpleradio/types"
-	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/martinlindhe/unit"
 	"github.com/rs/zerolog/log"
 )
 
 type Audio []float32
 
+// Transmission is a transmission received over the radio.
+type Transmission struct {
+	// Frequency the transmission was received on.
+	Frequency RadioFrequency
+	// Audio is F32LE PCM audio data. This is nil if the client decodes lazily; use [Transmission.Decode] to get the
+	// audio in either case.
+	Audio Audio
+	// ClientGUID is the GUID of the SRS client which transmitted.
+	ClientGUID types.GUID
+	// ClientName is the name of the SRS client which transmitted, or an empty string if the client is unknown.
+	ClientName string
+	// UnitID is the in-game ID of the unit the transmitting client is bound to, or zero if the client is unknown or
+	// not bound to a unit.
+	UnitID uint64
+	// decode decodes the transmission's Opus frames. This is nil unless the client decodes lazily.
+	decode func() Audio
+}
+
+// Decode returns the transmission's F32LE PCM audio data. If the client decodes lazily, the audio is decoded on each
+// call, so call this once and keep the result.
+func (t Transmission) Decode() Audio {
+	if t.decode != nil {
+		return t.decode()
+	}
+	return t.Audio
+}
+
 // Client is a SimpleRadio-Standalone client.
 type Client interface {
 	// Run starts the SimpleRadio-Standalone client. It should be called exactly once.
 	Run(context.Context, *sync.WaitGroup) error
 	// Send sends a message to the SRS server.
 	Send(types.Message) error
-	// Receive returns a channel that receives transmissions over the radio. Each transmission is F32LE PCM audio data.
-	Receive() <-chan Audio
-	// Transmit queues a transmission to send over the radio. The audio data should be in F32LE PCM format.
-	Transmit(Audio)
+	// Receive returns a channel that receives transmissions over the radio.
+	Receive() <-chan Transmission
+	// Transmit queues a transmission to send over the radio. The audio data should be in F32LE PCM format. The
+	// transmission is sent on the given frequencies, or on all of the client's frequencies if none are given.
+	Transmit(Audio, ...RadioFrequency)
 	// Frequencies returns the frequencies the client is listening on.
 	Frequencies() []RadioFrequency
 	// ClientsOnFrequency returns the number of peers on the client's frequencies.
@@ -38,6 +66,12 @@ type Client interface {
 	BotsOnFrequency() int
 	// IsOnFrequency checks if the named unit is on any of the client's frequencies.
 	IsOnFrequency(string) bool
+	// Listeners returns the sorted names of the peers listening on the given frequency. It returns nil if the client
+	// has no radio on the frequency.
+	Listeners(RadioFrequency) []string
+	// Retune changes the client's radio on the first frequency to the second frequency. It returns an error if the
+	// client has no radio on the first frequency.
+	Retune(from, to RadioFrequency) error
 }
 
 // client implements the SRS Client.
@@ -55,6 +89,10 @@ type client struct {
 	// clientInfo is the client information for this client. It is what players will see in the SRS client list, and in
 	/// the in-game overlay when this client transmits.
 	clientInfo types.ClientInfo
+	// radiosLock protects clientInfo.RadioInfo, which changes if the server settings restrict any of the configured radios.
+	radiosLock sync.RWMutex
+	// configuredRadios are the radios the client was configured with, before any server restrictions were applied.
+	configuredRadios []types.Radio
 	// clients is a map of GUIDs to client info, which the bot will use to filter out other clients that are not in the
 	// same coalition and frequency.
 	clients map[types.GUID]types.ClientInfo
@@ -63,13 +101,17 @@ type client struct {
 
 	// secureCoalitionRadios indicates if the client should only receive transmissions from the same coalition.
 	secureCoalitionRadios bool
+	// isExternalAWACSModeDisabled indicates if the server has disabled External AWACS Mode.
+	isExternalAWACSModeDisabled bool
 
 	// rxChan is a channel where received audio is published. A read-only version is available publicly.
-	rxChan chan Audio
+	rxChan chan Transmission
 	// txChan is a channel where audio to be transmitted is buffered.
-	txChan chan Audio
+	txChan chan queuedTransmission
 	// receivers tracks the state of each radio we are listening to.
 	receivers map[types.Radio]*receiver
+	// receiversLock protects the receivers map and relays, which change when a radio is retuned.
+	receiversLock sync.RWMutex
 	// packetNumber is incremented for each voice packet transmitted.
 	packetNumber uint64
 	// txLock prevents multiple outgoing transmissions from occurring simultaneously. It must be acquired before writing
@@ -77,6 +119,21 @@ type client struct {
 	txLock sync.Mutex
 	// mute suppresses audio transmission.
 	mute bool
+	// longFrameLength is the frame length used for long transmissions.
+	longFrameLength time.Duration
+	// lazyDecode defers decoding received audio until it is needed, for transmissions which are not relayed.
+	lazyDecode bool
+	// txHoldTime is the maximum time an outgoing transmission is delayed while another station is transmitting on the
+	// same frequency. Zero disables the delay.
+	txHoldTime time.Duration
+	// relays are pairs of radios between which received audio is retransmitted.
+	relays []types.Relay
+	// relayTone is the frequency of the tone played before relayed audio.
+	relayTone unit.Frequency
+	// txWindow tracks the client's own transmissions, so that echoes of them are not received.
+	txWindow transmissionWindow
+	// rogues tracks clients whose audio is ignored because they transmitted continuously for too long.
+	rogues *rogueSources
 
 	// lastPing tracks the last time a ping was received. If no pings are received for a period of time, the client will
 	// attempt to reconnect.
@@ -85,9 +142,28 @@ type client struct {
 
 func NewClient(config types.ClientConfiguration) (Client, error) {
 	guid := types.NewGUID()
+	if config.GUID != "" {
+		var err error
+		guid, err = types.ParseGUID(config.GUID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GUID: %w", err)
+		}
+	}
+	log.Info().Str("guid", string(guid)).Msg("using SRS client GUID")
+
+	longFrameLength := config.LongTransmissionFrameLength
+	if longFrameLength == 0 {
+		longFrameLength = frameLength
+	}
+	if err := validateLongFrameLength(longFrameLength); err != nil {
+		return nil, fmt.Errorf("invalid long transmission frame length: %w", err)
+	}
 
 	receivers := make(map[types.Radio]*receiver, len(config.Radios))
 	for _, radio := range config.Radios {
+		if err := newRadioFrequency(radio).checkModulation(); err != nil {
+			return nil, fmt.Errorf("invalid radio: %w", err)
+		}
 		receivers[radio] = &receiver{}
 	}
 
@@ -106,15 +182,22 @@ func NewClient(config types.ClientConfiguration) (Client, error) {
 			},
 			Position: &types.Position{},
 		},
+		configuredRadios:          config.Radios,
 		externalAWACSModePassword: config.ExternalAWACSModePassword,
 		clients:                   make(map[types.GUID]types.ClientInfo),
 
-		txChan:       make(chan Audio),
-		rxChan:       make(chan Audio),
-		receivers:    receivers,
-		packetNumber: 1,
-		mute:         config.Mute,
-		lastPing:     time.Now(),
+		txChan:          make(chan queuedTransmission),
+		rxChan:          make(chan Transmission),
+		receivers:       receivers,
+		packetNumber:    1,
+		mute:            config.Mute,
+		lazyDecode:      config.LazyDecode,
+		txHoldTime:      config.TransmitHoldTime,
+		longFrameLength: longFrameLength,
+		relays:          config.Relays,
+		relayTone:       config.RelayTone,
+		rogues:          newRogueSources(config.MaxTransmissionDuration),
+		lastPing:        time.Now(),
 	}
 
 	err := client.connectTCP()
@@ -141,9 +224,11 @@ func (c *client) initialize() error {
 		return fmt.Errorf("connecting external AWACS mode failed: %w", err)
 	}
 
+	c.receiversLock.RLock()
 	for _, receiver := range c.receivers {
 		receiver.reset()
 	}
+	c.receiversLock.RUnlock()
 
 	c.SendPing()
 
@@ -209,7 +294,7 @@ func (c *client) Run(ctx context.Context, wg *sync.WaitGroup) error {
 	}()
 
 	udpVoiceRxChan := make(chan []byte, 64*0xFFFFF)
-	voiceBytesRxChan := make(chan []voice.VoicePacket, 0xFFFFF)
+	voiceBytesRxChan := make(chan receivedTransmission, 0xFFFFF)
 	wg.Add(2)
 	go func() {
 		defer wg.Done()
@@ -220,7 +305,7 @@ func (c *client) Run(ctx context.Context, wg *sync.WaitGroup) error {
 		c.decodeVoice(ctx, voiceBytesRxChan)
 	}()
 
-	voicePacketsTxChan := make(chan []voice.VoicePacket, 3)
+	voicePacketsTxChan := make(chan encodedTransmission, 3)
 	wg.Add(4)
 	go func() {
 		defer wg.Done()
@@ -0,0 +1,220 @@
+package simpleradio
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRadio = types.Radio{Frequency: 251000000, Modulation: types.ModulationAM}
+
+// newTestClient constructs a client connected to the given fake server.
+func newTestClient(t *testing.T, server *fakeServer) *client {
+	t.Helper()
+	c, err := NewClient(types.ClientConfiguration{
+		Address:                   server.address(),
+		ConnectionTimeout:         time.Second,
+		ClientName:                "SkyEye [BOT]",
+		ExternalAWACSModePassword: "hunter2",
+		Coalition:                 coalitions.Blue,
+		Radios:                    []types.Radio{testRadio},
+	})
+	require.NoError(t, err)
+	return c.(*client)
+}
+
+// runTestClient runs the client until the test completes.
+func runTestClient(t *testing.T, c *client) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	var wg sync.WaitGroup
+	go func() {
+		_ = c.Run(ctx, &wg)
+	}()
+}
+
+func TestClientHandshake(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	c := newTestClient(t, server)
+	runTestClient(t, c)
+
+	sync := server.expectMessage(t, types.MessageSync)
+	assert.Equal(t, c.clientInfo.GUID, sync.Client.GUID)
+	assert.Equal(t, "SkyEye [BOT]", sync.Client.Name)
+	assert.EqualValues(t, coalitions.Blue, sync.Client.Coalition)
+	assert.Equal(t, []types.Radio{testRadio}, sync.Client.RadioInfo.Radios)
+	assert.NotEmpty(t, sync.Version)
+
+	awacs := server.expectMessage(t, types.MessageExternalAWACSModePassword)
+	assert.Equal(t, "hunter2", awacs.ExternalAWACSModePassword)
+	assert.Equal(t, c.clientInfo.GUID, awacs.Client.GUID)
+
+	server.expectMessage(t, types.MessagePing)
+	assert.Equal(t, []byte(c.clientInfo.GUID), server.expectPing(t))
+}
+
+func TestClientSync(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	c := newTestClient(t, server)
+	runTestClient(t, c)
+	server.expectMessage(t, types.MessageSync)
+
+	player := types.ClientInfo{
+		GUID:      types.NewGUID(),
+		Name:      "Mobius 1",
+		Coalition: coalitions.Blue,
+		RadioInfo: types.RadioInfo{Radios: []types.Radio{testRadio}},
+	}
+	bot := types.ClientInfo{
+		GUID:      types.NewGUID(),
+		Name:      "Overlord [BOT]",
+		Coalition: coalitions.Blue,
+		RadioInfo: types.RadioInfo{Radios: []types.Radio{testRadio}},
+	}
+	enemy := types.ClientInfo{
+		GUID:      types.NewGUID(),
+		Name:      "Yellow 13",
+		Coalition: coalitions.Red,
+		RadioInfo: types.RadioInfo{Radios: []types.Radio{testRadio}},
+	}
+	elsewhere := types.ClientInfo{
+		GUID:      types.NewGUID(),
+		Name:      "Hitman 11",
+		Coalition: coalitions.Blue,
+		RadioInfo: types.RadioInfo{Radios: []types.Radio{{Frequency: 133000000, Modulation: types.ModulationAM}}},
+	}
+	server.send(t, types.Message{
+		Version: "2.1.0.2",
+		Type:    types.MessageSync,
+		Clients: []types.ClientInfo{c.clientInfo, player, bot, enemy, elsewhere},
+	})
+
+	assert.Eventually(t, func() bool { return c.ClientsOnFrequency() == 2 }, fakeServerTimeout, 10*time.Millisecond)
+	assert.Equal(t, 1, c.HumansOnFrequency())
+	assert.Equal(t, 1, c.BotsOnFrequency())
+	assert.True(t, c.IsOnFrequency("Mobius 1"))
+	assert.False(t, c.IsOnFrequency("Yellow 13"))
+	assert.False(t, c.IsOnFrequency("Hitman 11"))
+	assert.Equal(t, []string{"Mobius 1", "Overlord [BOT]"}, c.Listeners(newRadioFrequency(testRadio)))
+	assert.Nil(t, c.Listeners(RadioFrequency{Frequency: 133 * unit.Megahertz, Modulation: types.ModulationAM}))
+
+	server.send(t, types.Message{
+		Version: "2.1.0.2",
+		Type:    types.MessageClientDisconnect,
+		Client:  player,
+	})
+	assert.Eventually(t, func() bool { return !c.IsOnFrequency("Mobius 1") }, fakeServerTimeout, 10*time.Millisecond)
+}
+
+func TestTransmissionDecode(t *testing.T) {
+	t.Parallel()
+	eager := Transmission{Audio: Audio{0.1, 0.2}}
+	assert.Equal(t, Audio{0.1, 0.2}, eager.Decode())
+
+	calls := 0
+	lazy := Transmission{decode: func() Audio {
+		calls++
+		return Audio{0.3}
+	}}
+	assert.Nil(t, lazy.Audio)
+	assert.Equal(t, Audio{0.3}, lazy.Decode())
+	assert.Equal(t, 1, calls)
+}
+
+func TestClientTransmitFraming(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	c := newTestClient(t, server)
+	t.Cleanup(c.close)
+
+	guid := []byte(c.clientInfo.GUID)
+	frequencies := []voice.Frequency{{Frequency: testRadio.Frequency, Modulation: byte(testRadio.Modulation)}}
+	packets := []voice.VoicePacket{
+		voice.NewVoicePacket([]byte{1, 2, 3}, frequencies, 100000002, 1, 0, guid, guid),
+		voice.NewVoicePacket([]byte{4, 5, 6, 7}, frequencies, 100000002, 2, 0, guid, guid),
+	}
+	go c.writePackets(packets, frameLength)
+
+	for _, expected := range packets {
+		actual, err := voice.Decode(server.expectVoice(t))
+		require.NoError(t, err)
+		assert.Equal(t, expected.AudioBytes, actual.AudioBytes)
+		assert.Equal(t, expected.Frequencies, actual.Frequencies)
+		assert.Equal(t, expected.UnitID, actual.UnitID)
+		assert.Equal(t, expected.PacketID, actual.PacketID)
+		assert.Equal(t, expected.OriginGUID, actual.OriginGUID)
+	}
+}
+
+func TestClientReceiveFraming(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	c := newTestClient(t, server)
+	t.Cleanup(c.close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	pings := make(chan []byte, 0xF)
+	voiceBytes := make(chan []byte, 0xFF)
+	transmissions := make(chan receivedTransmission, 1)
+	go c.receiveUDP(ctx, pings, voiceBytes)
+	go c.receiveVoice(ctx, voiceBytes, transmissions)
+
+	// The server learns the client's UDP address from its ping.
+	c.SendPing()
+	server.expectPing(t)
+
+	origin := []byte(types.NewGUID())
+	frequencies := []voice.Frequency{{Frequency: testRadio.Frequency, Modulation: byte(testRadio.Modulation)}}
+	n := int(2*minRxDuration/frameLength) + 1
+	for i := range n {
+		packet := voice.NewVoicePacket([]byte{byte(i)}, frequencies, 1, uint64(i+1), 0, origin, origin)
+		server.sendVoice(t, packet.Encode())
+	}
+
+	select {
+	case transmission := <-transmissions:
+		assert.True(t, transmission.frequency.IsSameFrequency(newRadioFrequency(testRadio)))
+		assert.True(t, transmission.isRecognizable)
+		assert.Equal(t, types.GUID(origin), transmission.origin)
+		require.Len(t, transmission.packets, n)
+		for i, packet := range transmission.packets {
+			assert.Equal(t, uint64(i+1), packet.PacketID)
+			assert.Equal(t, origin, packet.OriginGUID)
+		}
+	case <-time.After(fakeServerTimeout):
+		require.FailNow(t, "timed out waiting for transmission")
+	}
+}
+
+func TestClientReconnect(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	c := newTestClient(t, server)
+	t.Cleanup(c.close)
+	require.Eventually(t, func() bool { return server.connectionCount() == 1 }, fakeServerTimeout, 10*time.Millisecond)
+
+	server.disconnect()
+
+	ctx, cancel := context.WithTimeout(context.Background(), fakeServerTimeout)
+	defer cancel()
+	require.NoError(t, c.reconnect(ctx))
+	require.NoError(t, c.initialize())
+
+	require.Eventually(t, func() bool { return server.connectionCount() == 2 }, fakeServerTimeout, 10*time.Millisecond)
+	sync := server.expectMessage(t, types.MessageSync)
+	assert.Equal(t, c.clientInfo.GUID, sync.Client.GUID)
+	server.expectMessage(t, types.MessageExternalAWACSModePassword)
+	assert.Equal(t, []byte(c.clientInfo.GUID), server.expectPing(t))
+}
@@ -0,0 +1,80 @@
+package simpleradio
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+)
+
+// echoTail is how long after an outgoing transmission ends that incoming audio on the same frequencies is still
+// considered an echo. This allows for copies of our transmission which are delayed by the server or by clients which
+// retransmit audio onto other frequencies.
+const echoTail = 500 * time.Millisecond
+
+// transmissionWindow tracks when the client is transmitting, and on which frequencies, so that the client does not
+// feed its own transmissions back into the recognizer.
+type transmissionWindow struct {
+	// lock protects the window's state.
+	lock sync.RWMutex
+	// radios are the frequencies of the current or most recent transmission.
+	radios []types.Radio
+	// start is when the current or most recent transmission started.
+	start time.Time
+	// end is when the most recent transmission ended. This is zero while a transmission is in progress.
+	end time.Time
+}
+
+// open records the start of a transmission on the given frequencies.
+func (w *transmissionWindow) open(frequencies []voice.Frequency, t time.Time) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.radios = make([]types.Radio, 0, len(frequencies))
+	for _, frequency := range frequencies {
+		w.radios = append(w.radios, radioFromVoiceFrequency(frequency))
+	}
+	w.start = t
+	w.end = time.Time{}
+}
+
+// close records the end of a transmission.
+func (w *transmissionWindow) close(t time.Time) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.end = t
+}
+
+// contains checks if audio received on the given radio at the given time may be an echo of our own transmission.
+func (w *transmissionWindow) contains(radio types.Radio, t time.Time) bool {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	if w.start.IsZero() || t.Before(w.start) {
+		return false
+	}
+	if !w.end.IsZero() && t.After(w.end.Add(echoTail)) {
+		return false
+	}
+	for _, r := range w.radios {
+		if r.IsSameFrequency(radio) {
+			return true
+		}
+	}
+	return false
+}
+
+// isOwnTransmission checks if the voice packet was originated or relayed by this client. SRS servers which allow
+// retransmission may send our own audio back to us, either directly or via another client's relay.
+func (c *client) isOwnTransmission(packet *voice.VoicePacket) bool {
+	guid := c.clientInfo.GUID
+	return types.GUID(packet.OriginGUID) == guid || types.GUID(packet.RelayGUID) == guid
+}
+
+// radioFromVoiceFrequency converts a voice packet frequency to a radio for frequency comparisons.
+func radioFromVoiceFrequency(frequency voice.Frequency) types.Radio {
+	return types.Radio{
+		Frequency:   frequency.Frequency,
+		Modulation:  types.Modulation(frequency.Modulation),
+		IsEncrypted: frequency.Encryption != 0,
+	}
+}
@@ -0,0 +1,55 @@
+package simpleradio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransmissionWindow(t *testing.T) {
+	t.Parallel()
+	uhf := types.Radio{Frequency: 251000000, Modulation: types.ModulationAM}
+	vhf := types.Radio{Frequency: 133000000, Modulation: types.ModulationAM}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	window := &transmissionWindow{}
+	assert.False(t, window.contains(uhf, start), "no transmission yet")
+
+	window.open([]voice.Frequency{{Frequency: uhf.Frequency, Modulation: byte(uhf.Modulation)}}, start)
+	assert.False(t, window.contains(uhf, start.Add(-time.Second)), "before transmission")
+	assert.True(t, window.contains(uhf, start.Add(10*time.Second)), "during transmission")
+	assert.False(t, window.contains(vhf, start.Add(10*time.Second)), "different frequency")
+
+	end := start.Add(5 * time.Second)
+	window.close(end)
+	assert.True(t, window.contains(uhf, end.Add(echoTail/2)), "within echo tail")
+	assert.False(t, window.contains(uhf, end.Add(2*echoTail)), "after echo tail")
+}
+
+func TestIsOwnTransmission(t *testing.T) {
+	t.Parallel()
+	own := types.NewGUID()
+	other := types.NewGUID()
+	c := &client{clientInfo: types.ClientInfo{GUID: own}}
+	tests := []struct {
+		name     string
+		relay    types.GUID
+		origin   types.GUID
+		expected bool
+	}{
+		{"other client", other, other, false},
+		{"own transmission", own, own, true},
+		{"relayed by other client", other, own, true},
+		{"relayed by own client", own, other, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			packet := voice.NewVoicePacket([]byte{}, []voice.Frequency{}, 0, 1, 0, []byte(test.relay), []byte(test.origin))
+			assert.Equal(t, test.expected, c.isOwnTransmission(&packet))
+		})
+	}
+}
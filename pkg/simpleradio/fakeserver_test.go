@@ -0,0 +1,214 @@
+package simpleradio
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServerTimeout is how long tests wait for the fake server to observe traffic from the client.
+const fakeServerTimeout = 5 * time.Second
+
+// fakeServer is a minimal SRS server for testing the client without a real SRS install. It accepts TCP connections
+// for data messages and listens on the same port for UDP pings and voice packets. UDP pings are echoed back to the
+// sender, like a real SRS server.
+type fakeServer struct {
+	tcpListener *net.TCPListener
+	udpConn     *net.UDPConn
+
+	// messages receives each data message sent by the client.
+	messages chan types.Message
+	// pings receives each UDP ping sent by the client.
+	pings chan []byte
+	// voice receives each UDP voice packet sent by the client.
+	voice chan []byte
+
+	// lock protects the fields below.
+	lock sync.Mutex
+	// connections are the accepted TCP connections, including closed ones.
+	connections []*net.TCPConn
+	// udpPeer is the address which most recently sent a UDP packet.
+	udpPeer *net.UDPAddr
+}
+
+// newFakeServer starts a fake SRS server on a random local port. The server is stopped when the test completes.
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+	s := &fakeServer{
+		messages: make(chan types.Message, 64),
+		pings:    make(chan []byte, 64),
+		voice:    make(chan []byte, 1024),
+	}
+
+	// Find a port which is free for both TCP and UDP.
+	var err error
+	for range 10 {
+		s.tcpListener, err = net.ListenTCP("tcp", &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		require.NoError(t, err)
+		port := s.tcpListener.Addr().(*net.TCPAddr).Port
+		s.udpConn, err = net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: port})
+		if err == nil {
+			break
+		}
+		_ = s.tcpListener.Close()
+	}
+	require.NoError(t, err, "failed to find a free port for the fake SRS server")
+
+	go s.acceptTCP()
+	go s.receiveUDP()
+	t.Cleanup(s.close)
+	return s
+}
+
+// address returns the address clients should connect to.
+func (s *fakeServer) address() string {
+	return s.tcpListener.Addr().String()
+}
+
+// acceptTCP accepts TCP connections until the listener is closed.
+func (s *fakeServer) acceptTCP() {
+	for {
+		connection, err := s.tcpListener.AcceptTCP()
+		if err != nil {
+			return
+		}
+		s.lock.Lock()
+		s.connections = append(s.connections, connection)
+		s.lock.Unlock()
+		go s.readMessages(connection)
+	}
+}
+
+// readMessages reads newline-delimited JSON messages from the connection until it is closed.
+func (s *fakeServer) readMessages(connection *net.TCPConn) {
+	reader := bufio.NewReader(connection)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+		var message types.Message
+		if err := json.Unmarshal(line, &message); err != nil {
+			continue
+		}
+		s.messages <- message
+	}
+}
+
+// receiveUDP reads UDP packets until the connection is closed.
+func (s *fakeServer) receiveUDP() {
+	for {
+		buf := make([]byte, 1500)
+		n, peer, err := s.udpConn.ReadFromUDP(buf)
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			continue
+		}
+		s.lock.Lock()
+		s.udpPeer = peer
+		s.lock.Unlock()
+		packet := buf[:n]
+		if n == types.GUIDLength {
+			s.pings <- packet
+			_, _ = s.udpConn.WriteToUDP(packet, peer)
+		} else {
+			s.voice <- packet
+		}
+	}
+}
+
+// send writes a data message to every open TCP connection.
+func (s *fakeServer) send(t *testing.T, message types.Message) {
+	t.Helper()
+	b, err := json.Marshal(message)
+	require.NoError(t, err)
+	b = append(b, '\n')
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, connection := range s.connections {
+		_, _ = connection.Write(b)
+	}
+}
+
+// sendVoice writes a UDP voice packet to the client.
+func (s *fakeServer) sendVoice(t *testing.T, b []byte) {
+	t.Helper()
+	s.lock.Lock()
+	peer := s.udpPeer
+	s.lock.Unlock()
+	require.NotNil(t, peer, "client has not sent any UDP traffic yet")
+	_, err := s.udpConn.WriteToUDP(b, peer)
+	require.NoError(t, err)
+}
+
+// disconnect closes every TCP connection, as if the server restarted.
+func (s *fakeServer) disconnect() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, connection := range s.connections {
+		_ = connection.Close()
+	}
+}
+
+// connectionCount returns the number of TCP connections the server has accepted.
+func (s *fakeServer) connectionCount() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.connections)
+}
+
+// expectMessage waits for the client to send a message of the given type, skipping any other messages.
+func (s *fakeServer) expectMessage(t *testing.T, messageType types.MessageType) types.Message {
+	t.Helper()
+	timeout := time.After(fakeServerTimeout)
+	for {
+		select {
+		case message := <-s.messages:
+			if message.Type == messageType {
+				return message
+			}
+		case <-timeout:
+			require.FailNow(t, "timed out waiting for message", "type %d", messageType)
+		}
+	}
+}
+
+// expectPing waits for the client to send a UDP ping.
+func (s *fakeServer) expectPing(t *testing.T) []byte {
+	t.Helper()
+	select {
+	case ping := <-s.pings:
+		return ping
+	case <-time.After(fakeServerTimeout):
+		require.FailNow(t, "timed out waiting for UDP ping")
+	}
+	return nil
+}
+
+// expectVoice waits for the client to send a UDP voice packet.
+func (s *fakeServer) expectVoice(t *testing.T) []byte {
+	t.Helper()
+	select {
+	case b := <-s.voice:
+		return b
+	case <-time.After(fakeServerTimeout):
+		require.FailNow(t, "timed out waiting for voice packet")
+	}
+	return nil
+}
+
+// close stops the server.
+func (s *fakeServer) close() {
+	_ = s.tcpListener.Close()
+	_ = s.udpConn.Close()
+	s.disconnect()
+}
@@ -12,6 +12,8 @@ import (
 const (
 	// frameLength is the length of an Opus frame sent by SRS.
 	frameLength = 40 * time.Millisecond
+	// maxFrameLength is the longest audio which may be contained in a single Opus packet.
+	maxFrameLength = 120 * time.Millisecond
 	// sampleRate is the sample rate of the audio data sent by SRS.
 	sampleRate = 16 * unit.Kilohertz // Wideband
 	// channels is the number of channels in the audio data sent by SRS.
@@ -20,12 +22,10 @@ const (
 	encodingBufferSize = 1024
 )
 
-// frameSize is the Opus frame size used in SRS voice packets.
-var frameSize = channels * frameLength.Milliseconds() * int64(sampleRate.Kilohertz())
-
-// decodeFrame decodes the given Opus frame(s) into F32LE PCM audio data.
+// decodeFrame decodes the given Opus frame(s) into F32LE PCM audio data. Packets may contain up to [maxFrameLength] of
+// audio, in case other clients send longer frames.
 func (c *client) decodeFrame(decoder *opus.Decoder, b []byte) ([]float32, error) {
-	f32le := make([]float32, frameSize)
+	f32le := make([]float32, samplesPerFrame(maxFrameLength))
 	n, err := decoder.DecodeFloat32(b, f32le)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode Opus audio: %w", err)
@@ -0,0 +1,45 @@
+package simpleradio
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/hraban/opus.v2"
+)
+
+// newBenchmarkFrame returns a single frame of a 440 Hz sine wave.
+func newBenchmarkFrame() []float32 {
+	frame := make([]float32, samplesPerFrame(frameLength))
+	for i := range frame {
+		frame[i] = float32(0.5 * math.Sin(2*math.Pi*440*float64(i)/sampleRate.Hertz()))
+	}
+	return frame
+}
+
+func BenchmarkEncodeFrame(b *testing.B) {
+	c := &client{}
+	encoder, err := opus.NewEncoder(int(sampleRate.Hertz()), channels, opusApplicationVoIP)
+	require.NoError(b, err)
+	frame := newBenchmarkFrame()
+	b.ResetTimer()
+	for range b.N {
+		_, err := c.encodeFrame(encoder, frame)
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkDecodeFrame(b *testing.B) {
+	c := &client{}
+	encoder, err := opus.NewEncoder(int(sampleRate.Hertz()), channels, opusApplicationVoIP)
+	require.NoError(b, err)
+	encoded, err := c.encodeFrame(encoder, newBenchmarkFrame())
+	require.NoError(b, err)
+	decoder, err := opus.NewDecoder(int(sampleRate.Hertz()), channels)
+	require.NoError(b, err)
+	b.ResetTimer()
+	for range b.N {
+		_, err := c.decodeFrame(decoder, encoded)
+		require.NoError(b, err)
+	}
+}
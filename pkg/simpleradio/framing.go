@@ -0,0 +1,42 @@
+package simpleradio
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// longTransmission is the duration above which a transmission may be sent in longer frames. Short transmissions always
+// use standard frames, since they are sent in few packets anyway.
+const longTransmission = 5 * time.Second
+
+// longFrameLengths are the frame lengths which may be used for long transmissions. Opus encodes frames longer than
+// 60ms as several frames packed into one packet.
+var longFrameLengths = []time.Duration{
+	frameLength,
+	60 * time.Millisecond,
+	80 * time.Millisecond,
+	100 * time.Millisecond,
+	maxFrameLength,
+}
+
+// validateLongFrameLength checks if the given frame length may be used for long transmissions.
+func validateLongFrameLength(length time.Duration) error {
+	if !slices.Contains(longFrameLengths, length) {
+		return fmt.Errorf("frame length %s is not one of %v", length, longFrameLengths)
+	}
+	return nil
+}
+
+// samplesPerFrame returns the number of samples in a frame of the given length.
+func samplesPerFrame(length time.Duration) int {
+	return int(channels * length.Milliseconds() * int64(sampleRate.Kilohertz()))
+}
+
+// txFrameLength returns the frame length to use for a transmission of the given duration.
+func (c *client) txFrameLength(duration time.Duration) time.Duration {
+	if duration < longTransmission {
+		return frameLength
+	}
+	return c.longFrameLength
+}
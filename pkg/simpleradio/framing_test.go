@@ -0,0 +1,53 @@
+package simpleradio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/hraban/opus.v2"
+)
+
+func TestTxFrameLength(t *testing.T) {
+	t.Parallel()
+	c := &client{longFrameLength: 120 * time.Millisecond}
+	assert.Equal(t, 120*time.Millisecond, c.txFrameLength(30*time.Second))
+	assert.Equal(t, 120*time.Millisecond, c.txFrameLength(longTransmission))
+	assert.Equal(t, frameLength, c.txFrameLength(2*time.Second), "transmission is short")
+
+	c = &client{longFrameLength: frameLength}
+	assert.Equal(t, frameLength, c.txFrameLength(30*time.Second))
+}
+
+func TestValidateLongFrameLength(t *testing.T) {
+	t.Parallel()
+	for _, length := range longFrameLengths {
+		assert.NoError(t, validateLongFrameLength(length), length)
+	}
+	assert.Error(t, validateLongFrameLength(50*time.Millisecond))
+	assert.Error(t, validateLongFrameLength(240*time.Millisecond))
+}
+
+func TestSamplesPerFrame(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, 640, samplesPerFrame(frameLength))
+	assert.Equal(t, 1920, samplesPerFrame(maxFrameLength))
+}
+
+func TestEncodeLongFrames(t *testing.T) {
+	t.Parallel()
+	c := &client{}
+	encoder, err := opus.NewEncoder(int(sampleRate.Hertz()), channels, opusApplicationVoIP)
+	require.NoError(t, err)
+	decoder, err := opus.NewDecoder(int(sampleRate.Hertz()), channels)
+	require.NoError(t, err)
+	for _, length := range longFrameLengths {
+		frame := make([]float32, samplesPerFrame(length))
+		encoded, err := c.encodeFrame(encoder, frame)
+		require.NoError(t, err, length)
+		decoded, err := c.decodeFrame(decoder, encoded)
+		require.NoError(t, err, length)
+		assert.Len(t, decoded, samplesPerFrame(length), length)
+	}
+}
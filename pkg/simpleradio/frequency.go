@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -12,6 +13,10 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// ErrUnsupportedModulation is returned for frequencies which use a modulation that cannot carry the GCI's voice, such as
+// MIDS or intercom.
+var ErrUnsupportedModulation = errors.New("unsupported modulation; only AM and FM frequencies are supported")
+
 // RadioFrequency selects a frequency and either AM or FM modulation.
 type RadioFrequency struct {
 	Frequency  unit.Frequency
@@ -20,7 +25,8 @@ type RadioFrequency struct {
 
 // ParseRadioFrequency parses a string into a RadioFrequency.
 // The string should be a postive decimal number optionally followed by either "AM" or "FM".
-// If the modulation is not recognized, it defaults to AM.
+// If the modulation is not recognized, it defaults to AM. If the modulation is recognized but unsupported, such as
+// "MIDS", an error wrapping [ErrUnsupportedModulation] is returned.
 func ParseRadioFrequency(s string) (*RadioFrequency, error) {
 	pos := strings.IndexFunc(s, func(r rune) bool {
 		return (r < '0' || r > '9') && r != '.' && r != '-'
@@ -43,15 +49,16 @@ func ParseRadioFrequency(s string) (*RadioFrequency, error) {
 	}
 	frequency := unit.Frequency(mhz) * unit.Megahertz
 
-	var modulation types.Modulation
-	switch suffix {
-	case "FM":
-		modulation = types.ModulationFM
-	case "AM":
-		modulation = types.ModulationAM
-	default:
-		log.Warn().Str("input", s).Msg("unknown modulation, defaulting to AM")
-		modulation = types.ModulationAM
+	modulation := types.Modulation(types.ModulationAM)
+	if suffix != "" {
+		m, ok := types.ParseModulation(suffix)
+		if !ok {
+			log.Warn().Str("input", s).Msg("unknown modulation, defaulting to AM")
+		} else if !m.IsSupported() {
+			return nil, fmt.Errorf("%s: %w", s, ErrUnsupportedModulation)
+		} else {
+			modulation = m
+		}
 	}
 
 	return &RadioFrequency{
@@ -60,43 +67,48 @@ func ParseRadioFrequency(s string) (*RadioFrequency, error) {
 	}, nil
 }
 
+// newRadioFrequency returns the RadioFrequency the given radio is tuned to.
+func newRadioFrequency(radio types.Radio) RadioFrequency {
+	return RadioFrequency{
+		Frequency:  unit.Frequency(radio.Frequency) * unit.Hertz,
+		Modulation: radio.Modulation,
+	}
+}
+
+// checkModulation returns an error wrapping [ErrUnsupportedModulation] if the frequency's modulation is unsupported.
+func (f RadioFrequency) checkModulation() error {
+	if !f.Modulation.IsSupported() {
+		return fmt.Errorf("%s uses %s modulation: %w", f, f.Modulation, ErrUnsupportedModulation)
+	}
+	return nil
+}
+
 func (f RadioFrequency) IsSameFrequency(other RadioFrequency) bool {
 	return f.Frequency == other.Frequency && f.Modulation == other.Modulation
 }
 
 // String representation of the RadioFrequency.
 func (f RadioFrequency) String() string {
-	var suffix string
-	switch f.Modulation {
-	case types.ModulationFM:
-		suffix = "FM"
-	case types.ModulationAM:
-		suffix = "AM"
-	}
-
-	return fmt.Sprintf("%f.3%s", f.Frequency, suffix)
+	return fmt.Sprintf("%.3f%s", f.Frequency.Megahertz(), f.Modulation)
 }
 
 // Frequencies implements [Client.Frequencies].
 func (c *client) Frequencies() []RadioFrequency {
 	frequencies := make([]RadioFrequency, 0)
-	for _, radio := range c.clientInfo.RadioInfo.Radios {
-		frequency := RadioFrequency{
-			Frequency:  unit.Frequency(radio.Frequency) * unit.Hertz,
-			Modulation: radio.Modulation,
-		}
-		frequencies = append(frequencies, frequency)
+	for _, radio := range c.radioInfo().Radios {
+		frequencies = append(frequencies, newRadioFrequency(radio))
 	}
 	return frequencies
 }
 
 // ClientsOnFrequency implements [Client.ClientsOnFrequency].
 func (c *client) ClientsOnFrequency() int {
+	radioInfo := c.radioInfo()
 	c.clientsLock.RLock()
 	defer c.clientsLock.RUnlock()
 	count := 0
 	for _, client := range c.clients {
-		if ok := c.clientInfo.RadioInfo.IsOnFrequency(client.RadioInfo); ok {
+		if ok := radioInfo.IsOnFrequency(client.RadioInfo); ok {
 			count++
 		}
 	}
@@ -109,11 +121,12 @@ func isBot(client types.ClientInfo) bool {
 
 // HumansOnFrequency implements [Client.HumansOnFrequency].
 func (c *client) HumansOnFrequency() int {
+	radioInfo := c.radioInfo()
 	c.clientsLock.RLock()
 	defer c.clientsLock.RUnlock()
 	count := 0
 	for _, client := range c.clients {
-		if ok := c.clientInfo.RadioInfo.IsOnFrequency(client.RadioInfo); ok && !isBot(client) {
+		if ok := radioInfo.IsOnFrequency(client.RadioInfo); ok && !isBot(client) {
 			count++
 		}
 	}
@@ -122,11 +135,12 @@ func (c *client) HumansOnFrequency() int {
 
 // BotsOnFrequency implements [Client.BotsOnFrequency].
 func (c *client) BotsOnFrequency() int {
+	radioInfo := c.radioInfo()
 	c.clientsLock.RLock()
 	defer c.clientsLock.RUnlock()
 	count := 0
 	for _, client := range c.clients {
-		if ok := c.clientInfo.RadioInfo.IsOnFrequency(client.RadioInfo); ok && isBot(client) {
+		if ok := radioInfo.IsOnFrequency(client.RadioInfo); ok && isBot(client) {
 			count++
 		}
 	}
@@ -135,14 +149,42 @@ func (c *client) BotsOnFrequency() int {
 
 // IsOnFrequency implements [Client.IsOnFrequency].
 func (c *client) IsOnFrequency(name string) bool {
+	radioInfo := c.radioInfo()
 	c.clientsLock.RLock()
 	defer c.clientsLock.RUnlock()
 	for _, client := range c.clients {
 		if client.Name == name {
-			if ok := c.clientInfo.RadioInfo.IsOnFrequency(client.RadioInfo); ok {
+			if ok := radioInfo.IsOnFrequency(client.RadioInfo); ok {
 				return true
 			}
 		}
 	}
 	return false
 }
+
+// Listeners implements [Client.Listeners].
+func (c *client) Listeners(frequency RadioFrequency) []string {
+	var radio *types.Radio
+	for _, r := range c.radioInfo().Radios {
+		if newRadioFrequency(r).IsSameFrequency(frequency) {
+			radio = &r
+			break
+		}
+	}
+	if radio == nil {
+		return nil
+	}
+	c.clientsLock.RLock()
+	defer c.clientsLock.RUnlock()
+	names := make([]string, 0)
+	for _, client := range c.clients {
+		for _, other := range client.RadioInfo.Radios {
+			if radio.IsSameFrequency(other) {
+				names = append(names, client.Name)
+				break
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
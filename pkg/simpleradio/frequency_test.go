@@ -29,6 +29,12 @@ func TestParseFrequency(t *testing.T) {
 		{"AM", RadioFrequency{}, false},
 		{"FM", RadioFrequency{}, false},
 		{"0AM", RadioFrequency{}, false},
+		{"251.0am", RadioFrequency{251 * unit.Megahertz, types.ModulationAM}, true},
+		{"30.0 fm", RadioFrequency{30 * unit.Megahertz, types.ModulationFM}, true},
+		{"251.0XM", RadioFrequency{251 * unit.Megahertz, types.ModulationAM}, true},
+		{"1030MIDS", RadioFrequency{}, false},
+		{"251.0INTERCOM", RadioFrequency{}, false},
+		{"251.0SATCOM", RadioFrequency{}, false},
 	}
 
 	for _, test := range tests {
@@ -50,3 +56,17 @@ func TestParseFrequency(t *testing.T) {
 		})
 	}
 }
+
+func TestRadioFrequencyString(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "251.000AM", RadioFrequency{251 * unit.Megahertz, types.ModulationAM}.String())
+	assert.Equal(t, "30.025FM", RadioFrequency{30.025 * unit.Megahertz, types.ModulationFM}.String())
+	assert.Equal(t, "1030.000MIDS", RadioFrequency{1030 * unit.Megahertz, types.ModulationMIDS}.String())
+}
+
+func TestParseUnsupportedModulation(t *testing.T) {
+	t.Parallel()
+	_, err := ParseRadioFrequency("1030MIDS")
+	require.ErrorIs(t, err, ErrUnsupportedModulation)
+	assert.Contains(t, err.Error(), "1030MIDS")
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/dharmab/skyeye/pkg/simpleradio/types"
 	"github.com/rs/zerolog/log"
@@ -39,6 +38,8 @@ func (c *client) newMessage(t types.MessageType) types.Message {
 // newMessageWithClient creates a new message with the client's version, the given message type, and the client's info.
 func (c *client) newMessageWithClient(t types.MessageType) types.Message {
 	message := c.newMessage(t)
+	c.radiosLock.RLock()
+	defer c.radiosLock.RUnlock()
 	message.Client = c.clientInfo
 	return message
 }
@@ -49,12 +50,15 @@ func (c *client) handleMessage(message types.Message) {
 	case types.MessagePing:
 		logMessageAndIgnore(message)
 	case types.MessageServerSettings:
-		c.updateServerSettings(message)
+		c.updateServerSettings(message.ServerSettings)
 	case types.MessageVersionMismatch:
 		log.Warn().Any("message", message).Msg("received version mismatch message from SRS server")
 	case types.MessageExternalAWACSModeDisconnect:
 		logMessageAndIgnore(message)
 	case types.MessageSync:
+		if len(message.ServerSettings) > 0 {
+			c.updateServerSettings(message.ServerSettings)
+		}
 		c.syncClients(message.Clients)
 	case types.MessageUpdate:
 		c.syncClient(message.Client)
@@ -75,20 +79,6 @@ func (c *client) handleMessage(message types.Message) {
 	}
 }
 
-// updateServerSettings updates the client's settings to match the server's settings.
-func (c *client) updateServerSettings(message types.Message) {
-	log.Debug().Any("serverSettings", message.ServerSettings).Msg("received server settings")
-	if enabled, ok := message.ServerSettings[string(types.CoalitionAudioSecurity)]; ok {
-		if strings.ToLower(enabled) == "true" {
-			log.Info().Msg("enabling secure coalition radios")
-			c.secureCoalitionRadios = true
-		} else {
-			log.Info().Msg("disabling secure coalition radios")
-			c.secureCoalitionRadios = false
-		}
-	}
-}
-
 // updateRadios sends a radio update message to the SRS server containing this client's information.
 func (c *client) updateRadios() error {
 	message := c.newMessageWithClient(types.MessageRadioUpdate)
@@ -1,7 +1,9 @@
 package simpleradio
 
 import (
+	"cmp"
 	"context"
+	"slices"
 	"sync"
 	"time"
 
@@ -20,40 +22,66 @@ type receiver struct {
 	origin types.GUID
 	// deadline is extended every time another voice packet is received. When we pass the deadline, the transmission is considered over.
 	deadline time.Time
-	// packetNumber is the number of the last received voice packet. We only record a packet if its packet number is larger than the last received packet's, and skip any that were dropped or delivered out of order.
-	// If we were more ambitious we would reassemble the packets and use Opus's forward error correction to recover from lost packets... too bad!
+	// packetNumber is the highest packet number received in the current transmission. Packets which arrive out of order
+	// are reordered if they are within maxReorderDistance of this number, and discarded otherwise.
+	// If we were more ambitious we would use Opus's forward error correction to recover from lost packets... too bad!
 	packetNumber uint64
 }
 
+// receivedTransmission is a complete transmission's worth of voice packets received on a single frequency.
+type receivedTransmission struct {
+	frequency RadioFrequency
+	// origin is the GUID of the client which transmitted.
+	origin  types.GUID
+	packets []voice.VoicePacket
+	// relayTargets are the frequencies onto which the transmission should be retransmitted.
+	relayTargets []RadioFrequency
+	// isRecognizable is true if the transmission is long enough to be considered for speech recognition.
+	isRecognizable bool
+}
+
 // Receive implements [Client.Receive].
-func (c *client) Receive() <-chan Audio {
+func (c *client) Receive() <-chan Transmission {
 	return c.rxChan
 }
 
 // receive checks if the given packet is part of a new transmission or matches a transmission in progress.
-// If either case is true, the packet is buffered into the receiver.
+// If either case is true, the packet is buffered into the receiver in packet ID order. Packets which arrive slightly
+// out of order are inserted where they belong, while duplicates and packets which arrive too late are discarded.
 func (r *receiver) receive(packet *voice.VoicePacket) {
-	// Accept the packet if it is either:
-	// - the first packet of a new transmission
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
 	isNewTransmission := r.origin == "" && r.packetNumber == 0
-	// - a newer packet from the same origin
-	isNewerPacket := packet.PacketID > r.packetNumber
-	isSameOrigin := r.origin == types.GUID(packet.OriginGUID)
-	shouldAcceptPacket := isNewTransmission || (isNewerPacket && isSameOrigin)
-	if !shouldAcceptPacket {
+	if !isNewTransmission {
+		if r.origin != types.GUID(packet.OriginGUID) {
+			return
+		}
+		// Packet IDs are unsigned, so compare without subtracting to avoid underflow.
+		if packet.PacketID+maxReorderDistance <= r.packetNumber {
+			log.Trace().Uint64("packetID", packet.PacketID).Uint64("latest", r.packetNumber).Msg("discarding voice packet which arrived too late")
+			return
+		}
+	}
+
+	i, isDuplicate := slices.BinarySearchFunc(r.buffer, packet.PacketID, func(p voice.VoicePacket, id uint64) int {
+		return cmp.Compare(p.PacketID, id)
+	})
+	if isDuplicate {
+		log.Trace().Uint64("packetID", packet.PacketID).Msg("discarding duplicate voice packet")
 		return
 	}
+	if i < len(r.buffer) {
+		log.Trace().Uint64("packetID", packet.PacketID).Msg("reordering voice packet which arrived out of order")
+	}
 
 	if isNewTransmission {
 		log.Info().Str("origin", string(packet.OriginGUID)).Msg("receiving transmission")
 	}
-
-	r.lock.Lock()
-	defer r.lock.Unlock()
-	r.buffer = append(r.buffer, *packet)
+	r.buffer = slices.Insert(r.buffer, i, *packet)
 	r.origin = types.GUID(packet.OriginGUID)
 	r.deadline = time.Now().Add(maxRxGap)
-	r.packetNumber = packet.PacketID
+	r.packetNumber = max(r.packetNumber, packet.PacketID)
 }
 
 // hasTransmission checks if the receiver has a complete transmission buffered.
@@ -65,11 +93,26 @@ func (r *receiver) hasTransmission() bool {
 	return hasPackets && isComplete
 }
 
+// inProgress returns the origin and buffered duration of the transmission being received. The origin is empty if no
+// transmission has been received since the last reset.
+func (r *receiver) inProgress() (types.GUID, time.Duration) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.origin, time.Duration(len(r.buffer)) * frameLength
+}
+
 // isReceivingTransmission checks if the receiver is currently buffering an in-progress transmission.
 func (r *receiver) isReceivingTransmission() bool {
+	_, ok := r.receivingUntil()
+	return ok
+}
+
+// receivingUntil returns the deadline of the in-progress transmission, if any. The second return value is false if the
+// receiver is not currently buffering a transmission.
+func (r *receiver) receivingUntil() (time.Time, bool) {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
-	return r.deadline.After(time.Now())
+	return r.deadline, r.deadline.After(time.Now())
 }
 
 // reset clears the receiver's buffer.
@@ -85,12 +128,16 @@ func (r *receiver) reset() {
 // maxRxGap is a duration after which the receiver will assume the end of a transmission if no packets are received.
 const maxRxGap = 300 * time.Millisecond
 
+// maxReorderDistance is how many packets behind the newest received packet a late packet may be and still be inserted
+// into the transmission. At one packet per frame this is 400ms, which is plenty for packets reordered by the network.
+const maxReorderDistance = 10
+
 // minRxDuration is the mimimum duration of a transmission to be considered for speech recognition. This reduces
 // thrashing due to transmissions too short to contain any useful content.
 const minRxDuration = 1 * time.Second // 1s is whisper.cpp's minimum duration, it errors for any samples shorter than this.
 
 // receiveVoice listens for incoming UDP voice packets, decodes them into VoicePacket structs, and routes them to the out channel for audio decoding.
-func (c *client) receiveVoice(ctx context.Context, in <-chan []byte, out chan<- []voice.VoicePacket) {
+func (c *client) receiveVoice(ctx context.Context, in <-chan []byte, out chan<- receivedTransmission) {
 	// t is a ticker which triggers the check for the end of a transmission.
 	t := time.NewTicker(frameLength)
 	for {
@@ -104,6 +151,11 @@ func (c *client) receiveVoice(ctx context.Context, in <-chan []byte, out chan<-
 
 			logger := log.With().Str("GUID", string(packet.OriginGUID)).Logger()
 
+			if c.isOwnTransmission(packet) {
+				logger.Trace().Msg("ignoring echo of own voice packet")
+				continue
+			}
+
 			if c.secureCoalitionRadios {
 				client, ok := c.clients[types.GUID(packet.OriginGUID)]
 				if !ok {
@@ -116,36 +168,58 @@ func (c *client) receiveVoice(ctx context.Context, in <-chan []byte, out chan<-
 				}
 			}
 
+			now := time.Now()
+			if isolated, released := c.rogues.isIsolated(types.GUID(packet.OriginGUID), now); released {
+				logger.Info().Str("name", c.clientName(types.GUID(packet.OriginGUID))).Msg("no longer ignoring audio from client which stopped transmitting continuously")
+			} else if isolated {
+				logger.Trace().Msg("ignoring voice packet from isolated client")
+				continue
+			}
+
+			c.receiversLock.RLock()
 			for radio, receiver := range c.receivers {
+				if c.txWindow.contains(radio, now) {
+					logger.Trace().Msg("ignoring voice packet received during own transmission")
+					continue
+				}
 				for _, frequency := range packet.Frequencies {
-					testRadio := types.Radio{
-						Frequency:   frequency.Frequency,
-						Modulation:  types.Modulation(frequency.Modulation),
-						IsEncrypted: frequency.Encryption != 0,
-					}
-					if testRadio.IsSameFrequency(radio) {
+					if radioFromVoiceFrequency(frequency).IsSameFrequency(radio) {
 						receiver.receive(packet)
 					}
 				}
 			}
+			c.receiversLock.RUnlock()
 		case <-t.C:
+			c.isolateRogueSources(time.Now())
 			// Check if everyone has stopped talking.
 			if len(in) == 0 {
-				for _, receiver := range c.receivers {
+				c.receiversLock.RLock()
+				transmissions := make([]receivedTransmission, 0)
+				for radio, receiver := range c.receivers {
 					if receiver.hasTransmission() {
-						duration := time.Duration(len(receiver.buffer)) * frameLength
+						origin, duration := receiver.inProgress()
 						logger := log.With().Stringer("duration", duration).Logger()
-						if duration > minRxDuration {
+						transmission := receivedTransmission{
+							frequency:      newRadioFrequency(radio),
+							origin:         origin,
+							relayTargets:   relayTargets(c.relays, radio),
+							isRecognizable: duration > minRxDuration,
+						}
+						if transmission.isRecognizable || len(transmission.relayTargets) > 0 {
 							logger.Info().Msg("received transmission")
-							audio := make([]voice.VoicePacket, len(receiver.buffer))
-							copy(audio, receiver.buffer)
-							out <- audio
+							transmission.packets = make([]voice.VoicePacket, len(receiver.buffer))
+							copy(transmission.packets, receiver.buffer)
+							transmissions = append(transmissions, transmission)
 						} else {
 							logger.Info().Msg("discarding transmission below minimum size")
 						}
 						receiver.reset()
 					}
 				}
+				c.receiversLock.RUnlock()
+				for _, transmission := range transmissions {
+					out <- transmission
+				}
 			}
 		case <-ctx.Done():
 			log.Info().Msg("stopping SRS audio receiver due to context cancellation")
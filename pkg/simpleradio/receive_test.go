@@ -0,0 +1,39 @@
+package simpleradio
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiverReordersPackets(t *testing.T) {
+	t.Parallel()
+	origin := types.NewGUID()
+	other := types.NewGUID()
+	frequencies := []voice.Frequency{{Frequency: 251000000, Modulation: byte(types.ModulationAM)}}
+	newPacket := func(id uint64, guid types.GUID) *voice.VoicePacket {
+		packet := voice.NewVoicePacket([]byte{byte(id)}, frequencies, 1, id, 0, []byte(guid), []byte(guid))
+		return &packet
+	}
+
+	r := &receiver{}
+	for _, id := range []uint64{101, 103, 102, 102, 104, 106, 105} {
+		r.receive(newPacket(id, origin))
+	}
+	// Packets from another client are ignored while a transmission is in progress.
+	r.receive(newPacket(107, other))
+	r.receive(newPacket(120, origin))
+	// Late packets are inserted if they are not too far behind, and otherwise discarded.
+	r.receive(newPacket(120-maxReorderDistance+1, origin))
+	r.receive(newPacket(120-maxReorderDistance, origin))
+
+	ids := make([]uint64, 0, len(r.buffer))
+	for _, packet := range r.buffer {
+		ids = append(ids, packet.PacketID)
+	}
+	assert.Equal(t, []uint64{101, 102, 103, 104, 105, 106, 111, 120}, ids)
+	assert.Equal(t, origin, r.origin)
+	assert.Equal(t, uint64(120), r.packetNumber)
+}
@@ -0,0 +1,54 @@
+package simpleradio
+
+import (
+	"math"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/martinlindhe/unit"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// relayToneDuration is the length of the tone played before relayed audio.
+	relayToneDuration = 250 * time.Millisecond
+	// relayToneAmplitude is the amplitude of the tone played before relayed audio, as a fraction of full scale.
+	relayToneAmplitude = 0.25
+)
+
+// relayTargets returns the frequencies onto which audio received on the given radio should be retransmitted.
+func relayTargets(relays []types.Relay, radio types.Radio) []RadioFrequency {
+	targets := make([]RadioFrequency, 0)
+	for _, relay := range relays {
+		if relay.A.IsSameFrequency(radio) {
+			targets = append(targets, newRadioFrequency(relay.B))
+		}
+		if relay.B.IsSameFrequency(radio) {
+			targets = append(targets, newRadioFrequency(relay.A))
+		}
+	}
+	return targets
+}
+
+// relay retransmits the given audio onto the given frequencies, preceded by the relay tone.
+func (c *client) relay(audio Audio, source RadioFrequency, targets []RadioFrequency) {
+	log.Info().Stringer("source", source).Any("targets", targets).Msg("relaying transmission")
+	relayed := make(Audio, 0, len(audio))
+	relayed = append(relayed, tone(c.relayTone, relayToneDuration)...)
+	relayed = append(relayed, audio...)
+	c.Transmit(relayed, targets...)
+}
+
+// tone generates a sine wave of the given frequency and duration. If the frequency is zero, no audio is generated.
+func tone(frequency unit.Frequency, duration time.Duration) Audio {
+	if frequency <= 0 {
+		return Audio{}
+	}
+	n := int(duration.Seconds() * sampleRate.Hertz())
+	audio := make(Audio, n)
+	for i := range audio {
+		t := float64(i) / sampleRate.Hertz()
+		audio[i] = float32(relayToneAmplitude * math.Sin(2*math.Pi*frequency.Hertz()*t))
+	}
+	return audio
+}
@@ -0,0 +1,42 @@
+package simpleradio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayTargets(t *testing.T) {
+	t.Parallel()
+	uhf := types.Radio{Frequency: 251000000, Modulation: types.ModulationAM}
+	vhf := types.Radio{Frequency: 133000000, Modulation: types.ModulationAM}
+	fm := types.Radio{Frequency: 30000000, Modulation: types.ModulationFM}
+	relays := []types.Relay{{A: uhf, B: vhf}}
+
+	targets := relayTargets(relays, uhf)
+	require.Len(t, targets, 1)
+	assert.Equal(t, newRadioFrequency(vhf), targets[0])
+
+	targets = relayTargets(relays, vhf)
+	require.Len(t, targets, 1)
+	assert.Equal(t, newRadioFrequency(uhf), targets[0])
+
+	assert.Empty(t, relayTargets(relays, fm))
+	assert.Empty(t, relayTargets(nil, uhf))
+}
+
+func TestTone(t *testing.T) {
+	t.Parallel()
+	assert.Empty(t, tone(0, time.Second))
+
+	audio := tone(1*unit.Kilohertz, 250*time.Millisecond)
+	assert.Len(t, audio, 4000)
+	for _, sample := range audio {
+		assert.LessOrEqual(t, sample, float32(relayToneAmplitude))
+		assert.GreaterOrEqual(t, sample, float32(-relayToneAmplitude))
+	}
+}
@@ -0,0 +1,67 @@
+package simpleradio
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/rs/zerolog/log"
+)
+
+// Retune implements [Client.Retune].
+func (c *client) Retune(from, to RadioFrequency) error {
+	if err := to.checkModulation(); err != nil {
+		return fmt.Errorf("cannot retune from %s: %w", from, err)
+	}
+	isFrom := func(radio types.Radio) bool {
+		return newRadioFrequency(radio).IsSameFrequency(from)
+	}
+	retune := func(radio types.Radio) types.Radio {
+		radio.Frequency = to.Frequency.Hertz()
+		radio.Modulation = to.Modulation
+		return radio
+	}
+
+	c.radiosLock.Lock()
+	i := slices.IndexFunc(c.configuredRadios, isFrom)
+	if i == -1 {
+		c.radiosLock.Unlock()
+		return fmt.Errorf("no radio is tuned to %s", from)
+	}
+	old := c.configuredRadios[i]
+	c.configuredRadios = slices.Clone(c.configuredRadios)
+	c.configuredRadios[i] = retune(old)
+	radios := slices.Clone(c.clientInfo.RadioInfo.Radios)
+	for j, radio := range radios {
+		if isFrom(radio) {
+			radios[j] = retune(radio)
+		}
+	}
+	c.clientInfo.RadioInfo.Radios = radios
+	c.radiosLock.Unlock()
+
+	c.receiversLock.Lock()
+	for radio := range c.receivers {
+		if isFrom(radio) {
+			delete(c.receivers, radio)
+		}
+	}
+	c.receivers[retune(old)] = &receiver{}
+	relays := slices.Clone(c.relays)
+	for j, relay := range relays {
+		if isFrom(relay.A) {
+			relays[j].A = retune(relay.A)
+		}
+		if isFrom(relay.B) {
+			relays[j].B = retune(relay.B)
+		}
+	}
+	c.relays = relays
+	c.receiversLock.Unlock()
+
+	log.Info().Stringer("from", from).Stringer("to", to).Msg("retuned radio")
+	if err := c.updateRadios(); err != nil {
+		return fmt.Errorf("failed to retune radio: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package simpleradio
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetune(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	c := newTestClient(t, server)
+	runTestClient(t, c)
+	server.expectMessage(t, types.MessageSync)
+
+	from := newRadioFrequency(testRadio)
+	to := RadioFrequency{Frequency: 264 * unit.Megahertz, Modulation: types.ModulationAM}
+	require.NoError(t, c.Retune(from, to))
+
+	update := server.expectMessage(t, types.MessageRadioUpdate)
+	require.Len(t, update.Client.RadioInfo.Radios, 1)
+	assert.True(t, newRadioFrequency(update.Client.RadioInfo.Radios[0]).IsSameFrequency(to))
+	assert.Equal(t, []RadioFrequency{to}, c.Frequencies())
+
+	c.receiversLock.RLock()
+	assert.Len(t, c.receivers, 1)
+	for radio := range c.receivers {
+		assert.True(t, newRadioFrequency(radio).IsSameFrequency(to))
+	}
+	c.receiversLock.RUnlock()
+
+	assert.Error(t, c.Retune(from, to))
+}
+
+func TestRestrictRadiosAfterRetune(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	c := newTestClient(t, server)
+	runTestClient(t, c)
+	server.expectMessage(t, types.MessageSync)
+
+	from := newRadioFrequency(testRadio)
+	to := RadioFrequency{Frequency: 264 * unit.Megahertz, Modulation: types.ModulationAM}
+	require.NoError(t, c.Retune(from, to))
+	server.expectMessage(t, types.MessageRadioUpdate)
+
+	// The server's restrictions apply to the retuned frequency, not the frequency the client was started with.
+	c.restrictRadios([]unit.Frequency{from.Frequency}, nil)
+	assert.Equal(t, []RadioFrequency{to}, c.Frequencies())
+	c.restrictRadios([]unit.Frequency{to.Frequency}, nil)
+	update := server.expectMessage(t, types.MessageRadioUpdate)
+	assert.Empty(t, update.Client.RadioInfo.Radios)
+	assert.Empty(t, c.Frequencies())
+}
@@ -0,0 +1,93 @@
+package simpleradio
+
+import (
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/rs/zerolog/log"
+)
+
+// rogueCooldown is how long an isolated client must stop transmitting before its audio is heard again.
+const rogueCooldown = 1 * time.Minute
+
+// rogueSources tracks clients which transmitted continuously for longer than the maximum transmission duration, such
+// as a stuck push-to-talk key or an open microphone playing music. Audio from an isolated client is ignored, so that it
+// neither ties up speech recognition nor prevents other players on the frequency from being heard. It is only
+// accessed from the voice receiving goroutine, so it is not safe for concurrent use.
+type rogueSources struct {
+	// maxDuration is the longest a single transmission may last before its origin is isolated. Zero disables
+	// isolation.
+	maxDuration time.Duration
+	// isolated maps the GUIDs of isolated clients to the last time a packet was received from them.
+	isolated map[types.GUID]time.Time
+}
+
+func newRogueSources(maxDuration time.Duration) *rogueSources {
+	return &rogueSources{
+		maxDuration: maxDuration,
+		isolated:    make(map[types.GUID]time.Time),
+	}
+}
+
+// isRogue returns true if a transmission of the given duration is long enough to isolate its origin.
+func (r *rogueSources) isRogue(duration time.Duration) bool {
+	return r.maxDuration > 0 && duration > r.maxDuration
+}
+
+// isolate ignores further audio from the given client.
+func (r *rogueSources) isolate(guid types.GUID, now time.Time) {
+	r.isolated[guid] = now
+}
+
+// isIsolated returns true if audio received from the given client at the given time should be ignored. An isolated
+// client remains isolated for as long as it keeps transmitting, and is released once it has been quiet for
+// rogueCooldown. The second return value is true if the client was released by this call.
+func (r *rogueSources) isIsolated(guid types.GUID, now time.Time) (isolated bool, released bool) {
+	last, ok := r.isolated[guid]
+	if !ok {
+		return false, false
+	}
+	if now.Sub(last) > rogueCooldown {
+		delete(r.isolated, guid)
+		return false, true
+	}
+	r.isolated[guid] = now
+	return true, false
+}
+
+// isolateRogueSources isolates the origin of any in-progress transmission which has lasted longer than the maximum
+// transmission duration, and discards the audio buffered from it on every frequency.
+func (c *client) isolateRogueSources(now time.Time) {
+	c.receiversLock.RLock()
+	defer c.receiversLock.RUnlock()
+	for radio, receiver := range c.receivers {
+		origin, duration := receiver.inProgress()
+		if origin == "" || !c.rogues.isRogue(duration) {
+			continue
+		}
+		log.Warn().
+			Str("GUID", string(origin)).
+			Str("name", c.clientName(origin)).
+			Stringer("frequency", newRadioFrequency(radio)).
+			Stringer("duration", duration).
+			Msg("ignoring audio from client which is transmitting continuously, possibly due to a stuck push-to-talk key or open microphone")
+		c.rogues.isolate(origin, now)
+		for _, other := range c.receivers {
+			if o, _ := other.inProgress(); o == origin {
+				other.reset()
+			}
+		}
+	}
+}
+
+// clientName returns the name of the client with the given GUID, or an empty string if the client is unknown.
+func (c *client) clientName(guid types.GUID) string {
+	return c.peer(guid).Name
+}
+
+// peer returns the information of the client with the given GUID, or the zero value if the client is unknown.
+func (c *client) peer(guid types.GUID) types.ClientInfo {
+	c.clientsLock.RLock()
+	defer c.clientsLock.RUnlock()
+	return c.clients[guid]
+}
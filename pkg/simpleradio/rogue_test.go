@@ -0,0 +1,80 @@
+package simpleradio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRogueSources(t *testing.T) {
+	t.Parallel()
+	rogues := newRogueSources(30 * time.Second)
+	assert.False(t, rogues.isRogue(20*time.Second))
+	assert.True(t, rogues.isRogue(31*time.Second))
+
+	guid := types.NewGUID()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	isolated, released := rogues.isIsolated(guid, start)
+	assert.False(t, isolated)
+	assert.False(t, released)
+
+	rogues.isolate(guid, start)
+	isolated, _ = rogues.isIsolated(guid, start.Add(time.Second))
+	assert.True(t, isolated)
+	// Continuing to transmit keeps the client isolated.
+	isolated, _ = rogues.isIsolated(guid, start.Add(rogueCooldown))
+	assert.True(t, isolated)
+	isolated, _ = rogues.isIsolated(guid, start.Add(rogueCooldown*3/2))
+	assert.True(t, isolated)
+
+	isolated, released = rogues.isIsolated(guid, start.Add(3*rogueCooldown))
+	assert.False(t, isolated)
+	assert.True(t, released)
+	isolated, released = rogues.isIsolated(guid, start.Add(3*rogueCooldown))
+	assert.False(t, isolated)
+	assert.False(t, released)
+}
+
+func TestRogueSourcesDisabled(t *testing.T) {
+	t.Parallel()
+	rogues := newRogueSources(0)
+	assert.False(t, rogues.isRogue(time.Hour))
+}
+
+func TestIsolateRogueSources(t *testing.T) {
+	t.Parallel()
+	uhf := types.Radio{Frequency: 251000000, Modulation: types.ModulationAM}
+	vhf := types.Radio{Frequency: 133000000, Modulation: types.ModulationAM}
+	c := &client{
+		clients:   map[types.GUID]types.ClientInfo{},
+		receivers: map[types.Radio]*receiver{uhf: {}, vhf: {}},
+		rogues:    newRogueSources(time.Second),
+	}
+	rogue := types.NewGUID()
+	c.clients[rogue] = types.ClientInfo{GUID: rogue, Name: "Hot Mic"}
+
+	frequencies := []voice.Frequency{
+		{Frequency: uhf.Frequency, Modulation: byte(uhf.Modulation)},
+		{Frequency: vhf.Frequency, Modulation: byte(vhf.Modulation)},
+	}
+	n := int(2*time.Second/frameLength) + 1
+	for i := range n {
+		packet := voice.NewVoicePacket([]byte{byte(i)}, frequencies, 1, uint64(i+1), 0, []byte(rogue), []byte(rogue))
+		for _, r := range c.receivers {
+			r.receive(&packet)
+		}
+	}
+
+	now := time.Now()
+	c.isolateRogueSources(now)
+	for _, r := range c.receivers {
+		origin, duration := r.inProgress()
+		assert.Empty(t, origin)
+		assert.Zero(t, duration)
+	}
+	isolated, _ := c.rogues.isIsolated(rogue, now)
+	assert.True(t, isolated)
+}
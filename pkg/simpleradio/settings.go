@@ -0,0 +1,134 @@
+package simpleradio
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/martinlindhe/unit"
+	"github.com/rs/zerolog/log"
+)
+
+// radioInfo returns a copy of the client's current radio information.
+func (c *client) radioInfo() types.RadioInfo {
+	c.radiosLock.RLock()
+	defer c.radiosLock.RUnlock()
+	return c.clientInfo.RadioInfo
+}
+
+// updateServerSettings updates the client's settings to match the server's settings. The server sends its settings
+// when we connect, and again whenever an admin changes them.
+func (c *client) updateServerSettings(settings map[string]string) {
+	log.Debug().Any("serverSettings", settings).Msg("received server settings")
+	if enabled, ok := settings[string(types.CoalitionAudioSecurity)]; ok {
+		if isTrue(enabled) {
+			log.Info().Msg("enabling secure coalition radios")
+			c.secureCoalitionRadios = true
+		} else {
+			log.Info().Msg("disabling secure coalition radios")
+			c.secureCoalitionRadios = false
+		}
+	}
+
+	if enabled, ok := settings[string(types.ExternalAWACSMode)]; ok {
+		c.updateExternalAWACSMode(isTrue(enabled))
+	}
+
+	testFrequencies, hasTestFrequencies := settings[string(types.TestFrequencies)]
+	lobbyFrequencies, hasLobbyFrequencies := settings[string(types.GlobalLobbyFrequencies)]
+	if hasTestFrequencies || hasLobbyFrequencies {
+		c.restrictRadios(parseFrequencyList(testFrequencies), parseFrequencyList(lobbyFrequencies))
+	}
+}
+
+// updateExternalAWACSMode reacts to the server enabling or disabling External AWACS Mode.
+func (c *client) updateExternalAWACSMode(isEnabled bool) {
+	if !isEnabled {
+		if !c.isExternalAWACSModeDisabled {
+			log.Error().Msg("External AWACS Mode is disabled on the SRS server. The GCI cannot use the radio until an SRS admin enables it")
+		}
+		c.isExternalAWACSModeDisabled = true
+		return
+	}
+	if c.isExternalAWACSModeDisabled {
+		log.Info().Msg("External AWACS Mode was re-enabled on the SRS server, reconnecting")
+		c.isExternalAWACSModeDisabled = false
+		if err := c.connectExternalAWACSMode(); err != nil {
+			log.Error().Err(err).Msg("failed to reconnect to external AWACS mode")
+		}
+	}
+}
+
+// restrictRadios removes any configured radios which are tuned to one of the server's test frequencies, since the
+// server echoes transmissions on those frequencies back to the sender. It warns if any configured radios are tuned to
+// a global lobby frequency, since every coalition can hear those frequencies. If the client's radios change, the
+// server is notified.
+func (c *client) restrictRadios(testFrequencies, lobbyFrequencies []unit.Frequency) {
+	// Hold the lock while reading the configured radios, since they may be retuned concurrently.
+	c.radiosLock.Lock()
+	radios := make([]types.Radio, 0, len(c.configuredRadios))
+	for _, radio := range c.configuredRadios {
+		frequency := newRadioFrequency(radio)
+		if isTunedToAny(radio, testFrequencies) {
+			log.Warn().Stringer("frequency", frequency).Msg("configured SRS frequency is a test frequency on the SRS server and will not be used")
+			continue
+		}
+		if isTunedToAny(radio, lobbyFrequencies) {
+			log.Warn().Stringer("frequency", frequency).Msg("configured SRS frequency is a global lobby frequency on the SRS server; all coalitions will hear the GCI on this frequency")
+		}
+		radios = append(radios, radio)
+	}
+	if len(radios) == 0 {
+		log.Error().Msg("none of the configured SRS frequencies can be used with the SRS server's current settings")
+	}
+
+	isChanged := !slices.Equal(radios, c.clientInfo.RadioInfo.Radios)
+	if isChanged {
+		c.clientInfo.RadioInfo.Radios = radios
+	}
+	c.radiosLock.Unlock()
+
+	if isChanged {
+		log.Info().Int("count", len(radios)).Msg("updating radios to match SRS server settings")
+		if err := c.updateRadios(); err != nil {
+			log.Error().Err(err).Msg("failed to update radios")
+		}
+	}
+}
+
+// isTrue checks if a server setting value is true.
+func isTrue(value string) bool {
+	return strings.EqualFold(strings.TrimSpace(value), "true")
+}
+
+// isTunedToAny checks if the radio is tuned to any of the given frequencies, regardless of modulation.
+func isTunedToAny(radio types.Radio, frequencies []unit.Frequency) bool {
+	for _, frequency := range frequencies {
+		// 1KHz range acceptable, same as Radio.IsSameFrequency
+		if math.Abs(radio.Frequency-frequency.Hertz()) <= 500.0 {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFrequencyList parses a server setting containing a comma-separated list of frequencies in MHz, such as
+// "247.2,120.3". Values which cannot be parsed are skipped.
+func parseFrequencyList(s string) []unit.Frequency {
+	frequencies := make([]unit.Frequency, 0)
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		mhz, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			log.Warn().Err(err).Str("value", field).Msg("failed to parse frequency in SRS server settings")
+			continue
+		}
+		frequencies = append(frequencies, unit.Frequency(mhz)*unit.Megahertz)
+	}
+	return frequencies
+}
@@ -0,0 +1,76 @@
+package simpleradio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFrequencyList(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected []unit.Frequency
+	}{
+		{"", []unit.Frequency{}},
+		{"247.2", []unit.Frequency{247.2 * unit.Megahertz}},
+		{"247.2,120.3", []unit.Frequency{247.2 * unit.Megahertz, 120.3 * unit.Megahertz}},
+		{" 247.2 , 120.3 ,", []unit.Frequency{247.2 * unit.Megahertz, 120.3 * unit.Megahertz}},
+		{"247.2,eekum bokum", []unit.Frequency{247.2 * unit.Megahertz}},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			actual := parseFrequencyList(test.input)
+			assert.Len(t, actual, len(test.expected))
+			for i := range test.expected {
+				assert.InDelta(t, test.expected[i].Megahertz(), actual[i].Megahertz(), 0.0001)
+			}
+		})
+	}
+}
+
+func TestIsTunedToAny(t *testing.T) {
+	t.Parallel()
+	radio := types.Radio{Frequency: 251000000, Modulation: types.ModulationAM}
+	assert.True(t, isTunedToAny(radio, []unit.Frequency{120.3 * unit.Megahertz, 251 * unit.Megahertz}))
+	assert.True(t, isTunedToAny(radio, []unit.Frequency{251.0004 * unit.Megahertz}))
+	assert.False(t, isTunedToAny(radio, []unit.Frequency{251.1 * unit.Megahertz}))
+	assert.False(t, isTunedToAny(radio, []unit.Frequency{}))
+}
+
+func TestServerSettingsTestFrequencies(t *testing.T) {
+	t.Parallel()
+	server := newFakeServer(t)
+	c := newTestClient(t, server)
+	runTestClient(t, c)
+	server.expectMessage(t, types.MessageSync)
+
+	// The server echoes transmissions on test frequencies, so the client stops using them.
+	server.send(t, types.Message{
+		Version:        "2.1.0.2",
+		Type:           types.MessageServerSettings,
+		ServerSettings: map[string]string{string(types.TestFrequencies): "247.2,251.0"},
+	})
+	update := server.expectMessage(t, types.MessageRadioUpdate)
+	assert.Empty(t, update.Client.RadioInfo.Radios)
+	assert.Eventually(t, func() bool { return len(c.Frequencies()) == 0 }, fakeServerTimeout, 10*time.Millisecond)
+
+	// Lobby frequencies only cause a warning.
+	server.send(t, types.Message{
+		Version: "2.1.0.2",
+		Type:    types.MessageServerSettings,
+		ServerSettings: map[string]string{
+			string(types.TestFrequencies):        "247.2",
+			string(types.GlobalLobbyFrequencies): "251.0",
+		},
+	})
+	update = server.expectMessage(t, types.MessageRadioUpdate)
+	require.Len(t, update.Client.RadioInfo.Radios, 1)
+	assert.Equal(t, testRadio, update.Client.RadioInfo.Radios[0])
+	assert.Equal(t, []RadioFrequency{newRadioFrequency(testRadio)}, c.Frequencies())
+}
@@ -5,28 +5,45 @@ import (
 	"errors"
 	"math/rand/v2"
 	"net"
+	"slices"
 	"time"
 
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
 	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
 	"github.com/rs/zerolog/log"
 )
 
+// queuedTransmission is audio waiting to be transmitted on the given frequencies.
+type queuedTransmission struct {
+	audio       Audio
+	frequencies []RadioFrequency
+}
+
+// encodedTransmission is a transmission's worth of voice packets, ready to be written to the server.
+type encodedTransmission struct {
+	packets []voice.VoicePacket
+	// frameLength is the length of the audio in each packet.
+	frameLength time.Duration
+}
+
 // Transmit implements [Client.Transmit].
-func (c *client) Transmit(sample Audio) {
-	c.txChan <- sample
+func (c *client) Transmit(sample Audio, frequencies ...RadioFrequency) {
+	c.txChan <- queuedTransmission{audio: sample, frequencies: frequencies}
 }
 
 // transmit voice packets from queued transmissions to the SRS server.
-func (c *client) transmit(ctx context.Context, packetChan <-chan []voice.VoicePacket) {
+func (c *client) transmit(ctx context.Context, packetChan <-chan encodedTransmission) {
 	for {
 		select {
-		case packets := <-packetChan:
+		case transmission := <-packetChan:
 			func() {
 				c.txLock.Lock()
 				defer c.txLock.Unlock()
-				c.waitForClearChannel()
+				if len(transmission.packets) > 0 {
+					c.waitForClearChannel(transmission.packets[0].Frequencies)
+				}
 				if !c.mute {
-					c.writePackets(packets)
+					c.writePackets(transmission.packets, transmission.frameLength)
 				}
 			}()
 			// Pause between transmissions to sound more natural.
@@ -39,32 +56,61 @@ func (c *client) transmit(ctx context.Context, packetChan <-chan []voice.VoicePa
 	}
 }
 
-// waitForClearChannel waits for incoming transmissions to finish.
-func (c *client) waitForClearChannel() {
+// waitForClearChannel waits for incoming transmissions on the given frequencies to finish, so that the client does not
+// double with another station. If the frequencies are still busy after the client's maximum hold time, it returns
+// anyway.
+func (c *client) waitForClearChannel(frequencies []voice.Frequency) {
+	if c.txHoldTime <= 0 {
+		return
+	}
+	giveUp := time.Now().Add(c.txHoldTime)
 	for {
-		isReceiving := false
-		deadline := time.Now()
-		for _, receiver := range c.receivers {
-			if receiver.isReceivingTransmission() {
-				isReceiving = true
-				if receiver.deadline.After(deadline) {
-					deadline = receiver.deadline
-				}
-			}
+		c.receiversLock.RLock()
+		deadline, isReceiving := incomingDeadline(c.receivers, frequencies)
+		c.receiversLock.RUnlock()
+		if !isReceiving {
+			return
 		}
-		if isReceiving {
-			delay := time.Until(deadline) + 250*time.Millisecond
-			log.Info().Stringer("delay", delay).Msg("delaying outgoing transmission to avoid interrupting incoming transmission")
-			time.Sleep(delay)
-		} else {
+		delay := min(time.Until(deadline)+250*time.Millisecond, time.Until(giveUp))
+		if delay <= 0 {
+			log.Warn().Stringer("holdTime", c.txHoldTime).Msg("transmitting despite incoming transmission because maximum hold time has elapsed")
 			return
 		}
+		log.Info().Stringer("delay", delay).Msg("delaying outgoing transmission to avoid interrupting incoming transmission")
+		time.Sleep(delay)
+	}
+}
+
+// incomingDeadline returns the latest deadline of any in-progress incoming transmission on the given frequencies. The
+// second return value is false if none of the frequencies are receiving a transmission.
+func incomingDeadline(receivers map[types.Radio]*receiver, frequencies []voice.Frequency) (time.Time, bool) {
+	var deadline time.Time
+	isReceiving := false
+	for radio, receiver := range receivers {
+		isSameFrequency := slices.ContainsFunc(frequencies, func(frequency voice.Frequency) bool {
+			return radioFromVoiceFrequency(frequency).IsSameFrequency(radio)
+		})
+		if !isSameFrequency {
+			continue
+		}
+		if until, ok := receiver.receivingUntil(); ok {
+			isReceiving = true
+			if until.After(deadline) {
+				deadline = until
+			}
+		}
 	}
+	return deadline, isReceiving
 }
 
-// writePackets writes voice packets to the UDP connection.
-func (c *client) writePackets(packets []voice.VoicePacket) {
+// writePackets writes voice packets containing frames of the given length to the UDP connection. While the packets are
+// being written, incoming audio on the same frequencies is ignored, so the client does not hear itself.
+func (c *client) writePackets(packets []voice.VoicePacket, length time.Duration) {
 	startTime := time.Now()
+	if len(packets) > 0 {
+		c.txWindow.open(packets[0].Frequencies, startTime)
+		defer func() { c.txWindow.close(time.Now()) }()
+	}
 	for i, packet := range packets {
 		b := packet.Encode()
 		// Tight timing is important here - don't write the next packet until halfway through the previous packet's frame.
@@ -72,8 +118,8 @@ func (c *client) writePackets(packets []voice.VoicePacket) {
 		// Write too slowly, and the transmission will stutter.
 		delay := time.Until(
 			startTime.
-				Add(time.Duration(i) * frameLength).
-				Add(-frameLength / 2),
+				Add(time.Duration(i) * length).
+				Add(-length / 2),
 		)
 		time.Sleep(delay)
 		_, err := c.udpConnection.Write(b)
@@ -0,0 +1,41 @@
+package simpleradio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
+	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncomingDeadline(t *testing.T) {
+	t.Parallel()
+	uhf := types.Radio{Frequency: 251000000, Modulation: types.ModulationAM}
+	vhf := types.Radio{Frequency: 133000000, Modulation: types.ModulationAM}
+	fm := types.Radio{Frequency: 30000000, Modulation: types.ModulationFM}
+	toVoice := func(radio types.Radio) voice.Frequency {
+		return voice.Frequency{Frequency: radio.Frequency, Modulation: byte(radio.Modulation)}
+	}
+
+	now := time.Now()
+	receivers := map[types.Radio]*receiver{
+		uhf: {deadline: now.Add(time.Second)},
+		vhf: {deadline: now.Add(2 * time.Second)},
+		fm:  {deadline: now.Add(-time.Second)},
+	}
+
+	deadline, ok := incomingDeadline(receivers, []voice.Frequency{toVoice(uhf)})
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(time.Second), deadline)
+
+	deadline, ok = incomingDeadline(receivers, []voice.Frequency{toVoice(uhf), toVoice(vhf)})
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(2*time.Second), deadline)
+
+	_, ok = incomingDeadline(receivers, []voice.Frequency{toVoice(fm)})
+	assert.False(t, ok, "transmission on FM has ended")
+
+	_, ok = incomingDeadline(receivers, []voice.Frequency{{Frequency: 305000000}})
+	assert.False(t, ok, "not listening on frequency")
+}
@@ -4,11 +4,13 @@ import (
 	"time"
 
 	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/martinlindhe/unit"
 )
 
 // ClientConfiguration is configuration used to construct the audio and data clients.
 type ClientConfiguration struct {
-	// GUID corresponds to [ClientInfo.GUID].
+	// GUID corresponds to [ClientInfo.GUID]. If empty, a new GUID is generated, and the client appears to the server as
+	// a new client.
 	GUID string
 	// Address is the network address of the SRS server, including port.
 	Address string
@@ -26,4 +28,26 @@ type ClientConfiguration struct {
 	AllowRecording bool
 	// Mute is true if the client should not transmit.
 	Mute bool
+	// TransmitHoldTime is the maximum time the client delays an outgoing transmission while another station is
+	// transmitting on the same frequency. After this time, the client transmits anyway. Zero disables the delay.
+	TransmitHoldTime time.Duration
+	// Relays are pairs of radios between which the client retransmits received audio in both directions. Both radios
+	// in each pair must be in Radios.
+	Relays []Relay
+	// RelayTone is the frequency of a tone played before relayed audio. If zero, no tone is played.
+	RelayTone unit.Frequency
+	// MaxTransmissionDuration is the longest another client may transmit continuously before the client ignores its
+	// audio. Zero disables this.
+	MaxTransmissionDuration time.Duration
+	// LongTransmissionFrameLength is the Opus frame length used for long transmissions. Longer frames reduce the
+	// number of packets sent. Zero uses the standard 40ms frame length.
+	LongTransmissionFrameLength time.Duration
+	// LazyDecode defers decoding received audio until the audio is needed, except for relayed audio.
+	LazyDecode bool
+}
+
+// Relay is a pair of radios between which audio is retransmitted.
+type Relay struct {
+	A Radio
+	B Radio
 }
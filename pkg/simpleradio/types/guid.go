@@ -1,6 +1,13 @@
 package types
 
-import "github.com/lithammer/shortuuid/v3"
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/lithammer/shortuuid/v3"
+)
 
 // GUID is a unique identifier for an SRS network client. Each client generates a 22-byte GUID on startup. GUIDs are encoded in base57.
 type GUID string
@@ -15,3 +22,40 @@ func NewGUID() (guid GUID) {
 	}
 	return
 }
+
+// ParseGUID parses a GUID from a string. Other SRS clients encode GUIDs differently, so any string of GUIDLength
+// letters, digits, dashes and underscores is accepted.
+func ParseGUID(s string) (GUID, error) {
+	s = strings.TrimSpace(s)
+	if len(s) != GUIDLength {
+		return "", fmt.Errorf("GUID %q must be %d characters long", s, GUIDLength)
+	}
+	for _, r := range s {
+		isValid := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_'
+		if !isValid {
+			return "", fmt.Errorf("GUID %q contains invalid character %q", s, r)
+		}
+	}
+	return GUID(s), nil
+}
+
+// LoadOrCreateGUID reads a GUID from the file at the given path. If the file does not exist, a new GUID is generated
+// and written to the file, so that the same GUID is used the next time.
+func LoadOrCreateGUID(path string) (GUID, error) {
+	b, err := os.ReadFile(path)
+	if err == nil {
+		guid, err := ParseGUID(string(b))
+		if err != nil {
+			return "", fmt.Errorf("failed to parse GUID file %s: %w", path, err)
+		}
+		return guid, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to read GUID file %s: %w", path, err)
+	}
+	guid := NewGUID()
+	if err := os.WriteFile(path, []byte(guid+"\n"), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write GUID file %s: %w", path, err)
+	}
+	return guid, nil
+}
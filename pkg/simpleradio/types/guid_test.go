@@ -1,6 +1,8 @@
 package types
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -15,3 +17,37 @@ func TestNewGUID(t *testing.T) {
 		require.Len(t, []byte(g), GUIDLength)
 	}
 }
+
+func TestParseGUID(t *testing.T) {
+	t.Parallel()
+	guid := NewGUID()
+	parsed, err := ParseGUID(string(guid) + "\n")
+	require.NoError(t, err)
+	require.Equal(t, guid, parsed)
+
+	parsed, err = ParseGUID("AbCdEfGhIjKlMnOpQr-_12")
+	require.NoError(t, err)
+	require.Equal(t, GUID("AbCdEfGhIjKlMnOpQr-_12"), parsed)
+
+	for _, s := range []string{"", "too short", "AbCdEfGhIjKlMnOpQr-_123", "AbCdEfGhIjKlMnOpQr 12"} {
+		_, err := ParseGUID(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestLoadOrCreateGUID(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "guid")
+
+	created, err := LoadOrCreateGUID(path)
+	require.NoError(t, err)
+	require.Len(t, []byte(created), GUIDLength)
+
+	loaded, err := LoadOrCreateGUID(path)
+	require.NoError(t, err)
+	require.Equal(t, created, loaded)
+
+	require.NoError(t, os.WriteFile(path, []byte("garbage"), 0o600))
+	_, err = LoadOrCreateGUID(path)
+	require.Error(t, err)
+}
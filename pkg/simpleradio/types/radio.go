@@ -1,7 +1,9 @@
 package types
 
 import (
+	"fmt"
 	"math"
+	"strings"
 )
 
 // This file implements types from https://github.com/ciribob/DCS-SimpleRadioStandalone/blob/master/DCS-SR-Common/DCSState/RadioInformation.cs
@@ -28,6 +30,41 @@ const (
 	ModulationSINCGARS = 7
 )
 
+var modulationNames = map[Modulation]string{
+	ModulationAM:        "AM",
+	ModulationFM:        "FM",
+	ModulationIntercom:  "INTERCOM",
+	ModulationDisabled:  "DISABLED",
+	ModulationHAVEQUICK: "HAVEQUICK",
+	ModulationSATCOM:    "SATCOM",
+	ModulationMIDS:      "MIDS",
+	ModulationSINCGARS:  "SINCGARS",
+}
+
+// String returns the name of the modulation, such as "AM" or "MIDS".
+func (m Modulation) String() string {
+	if name, ok := modulationNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("MODULATION(%d)", byte(m))
+}
+
+// IsSupported is true if voice can be sent and received with this modulation. Only AM and FM are supported; the other
+// modulations are either not used for voice or are simulated by the SRS client rather than carried as plain audio.
+func (m Modulation) IsSupported() bool {
+	return m == ModulationAM || m == ModulationFM
+}
+
+// ParseModulation parses the name of a modulation, such as "AM" or "MIDS". It is case insensitive.
+func ParseModulation(s string) (Modulation, bool) {
+	for m, name := range modulationNames {
+		if strings.EqualFold(s, name) {
+			return m, true
+		}
+	}
+	return 0, false
+}
+
 // Radio describes one of a client's radios.
 type Radio struct {
 	// Frequency is the transmission frequency in Hz.
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModulation(t *testing.T) {
+	t.Parallel()
+	for _, m := range []Modulation{ModulationAM, ModulationFM} {
+		assert.True(t, m.IsSupported(), m.String())
+	}
+	for _, m := range []Modulation{ModulationIntercom, ModulationDisabled, ModulationHAVEQUICK, ModulationSATCOM, ModulationMIDS, ModulationSINCGARS} {
+		assert.False(t, m.IsSupported(), m.String())
+		parsed, ok := ParseModulation(m.String())
+		assert.True(t, ok)
+		assert.Equal(t, m, parsed)
+	}
+	parsed, ok := ParseModulation("fm")
+	assert.True(t, ok)
+	assert.Equal(t, Modulation(ModulationFM), parsed)
+	_, ok = ParseModulation("XM")
+	assert.False(t, ok)
+	assert.Equal(t, "MODULATION(42)", Modulation(42).String())
+}
@@ -2,7 +2,9 @@ package simpleradio
 
 import (
 	"context"
+	"time"
 
+	"github.com/dharmab/skyeye/pkg/simpleradio/types"
 	"github.com/dharmab/skyeye/pkg/simpleradio/voice"
 	"github.com/rs/zerolog/log"
 	"gopkg.in/hraban/opus.v2"
@@ -11,31 +13,39 @@ import (
 // Mirror of OPUS_APPLICATION_VOIP from the Opus API.
 const opusApplicationVoIP = 2048
 
-// deocdeVoice decodes incoming voice packets from voicePacketsChan into F32LE PCM audio data published to the client's rxChan.
-func (c *client) decodeVoice(ctx context.Context, voicePacketsChan <-chan []voice.VoicePacket) {
+// decodeVoice decodes incoming voice packets from voicePacketsChan into F32LE PCM audio data published to the client's
+// rxChan. If the client decodes lazily, transmissions which are not relayed are published undecoded, and are decoded
+// by [Transmission.Decode].
+func (c *client) decodeVoice(ctx context.Context, voicePacketsChan <-chan receivedTransmission) {
 	for {
 		select {
-		case voicePackets := <-voicePacketsChan:
-			decoder, err := opus.NewDecoder(int(sampleRate.Hertz()), channels)
-			if err != nil {
-				log.Error().Err(err).Msg("failed to create Opus decoder")
-				continue
+		case transmission := <-voicePacketsChan:
+			frames := make([][]byte, 0, len(transmission.packets))
+			for _, packet := range transmission.packets {
+				frames = append(frames, packet.AudioBytes)
 			}
-			transmissionPCM := make([]float32, 0)
-			for _, packet := range voicePackets {
-				packetPCM, err := c.decodeFrame(decoder, packet.AudioBytes)
-				if err != nil {
-					log.Error().Err(err).Msg("failed to decode audio")
-				} else {
-					transmissionPCM = append(transmissionPCM, packetPCM...)
+			if c.lazyDecode && len(transmission.relayTargets) == 0 {
+				if transmission.isRecognizable {
+					log.Info().Int("frames", len(frames)).Stringer("frequency", transmission.frequency).Msg("publishing received audio to receiving channel without decoding")
+					t := c.newTransmission(transmission, nil)
+					t.decode = func() Audio { return c.decodeFrames(frames) }
+					c.rxChan <- t
 				}
+				continue
 			}
 
-			if len(transmissionPCM) > 0 {
-				log.Info().Int("len", len(transmissionPCM)).Msg("publishing received audio to receiving channel")
-				c.rxChan <- transmissionPCM
-			} else {
+			transmissionPCM := c.decodeFrames(frames)
+			if len(transmissionPCM) == 0 {
 				log.Debug().Msg("decoded transmission PCM is empty")
+				continue
+			}
+			if len(transmission.relayTargets) > 0 {
+				// Transmit blocks until the encoder is ready, so relay in the background.
+				go c.relay(transmissionPCM, transmission.frequency, transmission.relayTargets)
+			}
+			if transmission.isRecognizable {
+				log.Info().Int("len", len(transmissionPCM)).Stringer("frequency", transmission.frequency).Msg("publishing received audio to receiving channel")
+				c.rxChan <- c.newTransmission(transmission, transmissionPCM)
 			}
 		case <-ctx.Done():
 			log.Info().Msg("stopping voice decoder due to context cancellation")
@@ -44,19 +54,76 @@ func (c *client) decodeVoice(ctx context.Context, voicePacketsChan <-chan []voic
 	}
 }
 
-// encodeVoice encodes audio from the client's txChan and publishes an entire transmission's worth of voice packets to packetCh.
-func (c *client) encodeVoice(ctx context.Context, packetChan chan<- []voice.VoicePacket) {
-	frequencyList := make([]voice.Frequency, 0, len(c.clientInfo.RadioInfo.Radios))
-	for _, radio := range c.clientInfo.RadioInfo.Radios {
-		frequencyList = append(frequencyList, voice.Frequency{
-			Frequency:  radio.Frequency,
-			Modulation: byte(radio.Modulation),
-			Encryption: 0,
-		})
+// newTransmission returns the transmission to publish for the received voice packets, with the given decoded audio.
+func (c *client) newTransmission(transmission receivedTransmission, audio Audio) Transmission {
+	origin := c.peer(transmission.origin)
+	return Transmission{
+		Frequency:  transmission.frequency,
+		Audio:      audio,
+		ClientGUID: transmission.origin,
+		ClientName: origin.Name,
+		UnitID:     origin.RadioInfo.UnitID,
+	}
+}
+
+// decodeFrames decodes a transmission's Opus frames into F32LE PCM audio data. Frames which fail to decode are skipped.
+func (c *client) decodeFrames(frames [][]byte) Audio {
+	decoder, err := opus.NewDecoder(int(sampleRate.Hertz()), channels)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create Opus decoder")
+		return nil
+	}
+	pcm := make([]float32, 0)
+	for _, frame := range frames {
+		framePCM, err := c.decodeFrame(decoder, frame)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to decode audio")
+		} else {
+			pcm = append(pcm, framePCM...)
+		}
 	}
+	return pcm
+}
+
+// frequencyList returns the voice packet frequencies for the given radio frequencies. If no frequencies are given, all
+// of the client's frequencies are returned. Frequencies the client is not tuned to, or which use an unsupported
+// modulation, are omitted.
+func (c *client) frequencyList(frequencies []RadioFrequency) []voice.Frequency {
+	radios := c.radioInfo().Radios
+	frequencyList := make([]voice.Frequency, 0, len(radios))
+	for _, radio := range radios {
+		if !radio.Modulation.IsSupported() {
+			continue
+		}
+		isSelected := len(frequencies) == 0
+		for _, frequency := range frequencies {
+			selection := types.Radio{Frequency: frequency.Frequency.Hertz(), Modulation: frequency.Modulation}
+			if radio.IsSameFrequency(selection) {
+				isSelected = true
+			}
+		}
+		if isSelected {
+			frequencyList = append(frequencyList, voice.Frequency{
+				Frequency:  radio.Frequency,
+				Modulation: byte(radio.Modulation),
+				Encryption: 0,
+			})
+		}
+	}
+	return frequencyList
+}
+
+// encodeVoice encodes audio from the client's txChan and publishes an entire transmission's worth of voice packets to packetCh.
+func (c *client) encodeVoice(ctx context.Context, packetChan chan<- encodedTransmission) {
 	for {
 		select {
-		case audio := <-c.txChan:
+		case transmission := <-c.txChan:
+			audio := transmission.audio
+			frequencyList := c.frequencyList(transmission.frequencies)
+			if len(frequencyList) == 0 {
+				log.Warn().Any("frequencies", transmission.frequencies).Msg("discarding transmission because the client is not tuned to any of its frequencies")
+				continue
+			}
 			log.Trace().Msg("encoding transmission from PCM data")
 			encoder, err := opus.NewEncoder(int(sampleRate.Hertz()), channels, opusApplicationVoIP)
 			if err != nil {
@@ -64,19 +131,22 @@ func (c *client) encodeVoice(ctx context.Context, packetChan chan<- []voice.Voic
 				continue
 			}
 
+			duration := time.Duration(len(audio)/channels) * time.Second / time.Duration(sampleRate.Hertz())
+			length := c.txFrameLength(duration)
+			size := samplesPerFrame(length)
 			txPackets := make([]voice.VoicePacket, 0)
-			for i := 0; i < len(audio); i += int(frameSize) {
+			for i := 0; i < len(audio); i += size {
 				logger := log.With().Int("index", i).Logger()
 				var frameAudio []float32
 				// pad frame to frame size
-				if i+int(frameSize) < len(audio) {
-					frameAudio = audio[i : i+int(frameSize)]
+				if i+size < len(audio) {
+					frameAudio = audio[i : i+size]
 				} else {
 					frameAudio = audio[i:]
 				}
 				// Align audio to Opus frame size
-				if len(frameAudio) < int(frameSize) {
-					padding := make([]float32, int(frameSize)-len(frameAudio))
+				if len(frameAudio) < size {
+					padding := make([]float32, size-len(frameAudio))
 					frameAudio = append(frameAudio, padding...)
 				}
 				audioBytes, err := c.encodeFrame(encoder, frameAudio)
@@ -99,8 +169,8 @@ func (c *client) encodeVoice(ctx context.Context, packetChan chan<- []voice.Voic
 				// TODO transmission struct with attached text and trace id
 				txPackets = append(txPackets, voicePacket)
 			}
-			log.Trace().Int("count", len(txPackets)).Msg("encoded transmission packets")
-			packetChan <- txPackets
+			log.Trace().Int("count", len(txPackets)).Stringer("frameLength", length).Msg("encoded transmission packets")
+			packetChan <- encodedTransmission{packets: txPackets, frameLength: length}
 		case <-ctx.Done():
 			log.Info().Msg("stopping voice encoder due to context cancellation")
 			return
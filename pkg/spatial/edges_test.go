@@ -0,0 +1,157 @@
+package spatial
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geo"
+	"github.com/stretchr/testify/assert"
+)
+
+// edgeCase is a pair of points which are known to be troublesome for naive spherical math.
+type edgeCase struct {
+	name     string
+	a        orb.Point
+	b        orb.Point
+	distance unit.Length
+	bearing  float64
+}
+
+// edgeCases are fixtures shared by the edge case tests. The Marianas map is close to the antimeridian, so paths
+// across the date line are realistic in DCS.
+var edgeCases = []edgeCase{
+	{
+		name:     "eastbound across antimeridian",
+		a:        orb.Point{179.9, 15},
+		b:        orb.Point{-179.9, 15},
+		distance: 21.5 * unit.Kilometer,
+		bearing:  90,
+	},
+	{
+		name:     "westbound across antimeridian",
+		a:        orb.Point{-179.9, 15},
+		b:        orb.Point{179.9, 15},
+		distance: 21.5 * unit.Kilometer,
+		bearing:  270,
+	},
+	{
+		name:     "northbound near north pole",
+		a:        orb.Point{10, 89},
+		b:        orb.Point{10, 89.5},
+		distance: 55.6 * unit.Kilometer,
+		bearing:  360,
+	},
+	{
+		name:     "across north pole",
+		a:        orb.Point{0, 89.5},
+		b:        orb.Point{180, 89.5},
+		distance: 111.2 * unit.Kilometer,
+		bearing:  360,
+	},
+	{
+		name:     "southbound near south pole",
+		a:        orb.Point{-60, -89},
+		b:        orb.Point{-60, -89.5},
+		distance: 55.6 * unit.Kilometer,
+		bearing:  180,
+	},
+	{
+		name:     "very short distance",
+		a:        orb.Point{145.7, 15.1},
+		b:        orb.Point{145.70001, 15.1},
+		distance: 1.07 * unit.Meter,
+		bearing:  90,
+	},
+}
+
+func TestEdgeCaseDistance(t *testing.T) {
+	t.Parallel()
+	for _, test := range edgeCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual := Distance(test.a, test.b)
+			assert.InDelta(t, test.distance.Meters(), actual.Meters(), test.distance.Meters()*0.01)
+			assert.InDelta(t, actual.Meters(), Distance(test.b, test.a).Meters(), 0.001)
+		})
+	}
+}
+
+func TestEdgeCaseTrueBearing(t *testing.T) {
+	t.Parallel()
+	for _, test := range edgeCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual := TrueBearing(test.a, test.b)
+			expected := bearings.NewTrueBearing(unit.Angle(test.bearing) * unit.Degree)
+			assert.InDelta(t, 0, bearings.Difference(expected, actual).Degrees(), 0.5)
+		})
+	}
+}
+
+func TestEdgeCasePointAtBearingAndDistance(t *testing.T) {
+	t.Parallel()
+	for _, test := range edgeCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			bearing := TrueBearing(test.a, test.b)
+			actual := PointAtBearingAndDistance(test.a, bearing, Distance(test.a, test.b))
+			assert.GreaterOrEqual(t, actual.Lon(), -180.0)
+			assert.Less(t, actual.Lon(), 180.0)
+			assert.InDelta(t, 0, Distance(test.b, actual).Meters(), 1)
+		})
+	}
+}
+
+func TestNormalizePoint(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		point    orb.Point
+		expected orb.Point
+	}{
+		{name: "normal", point: orb.Point{145, 15}, expected: orb.Point{145, 15}},
+		{name: "east of antimeridian", point: orb.Point{180.5, 15}, expected: orb.Point{-179.5, 15}},
+		{name: "west of antimeridian", point: orb.Point{-180.5, 15}, expected: orb.Point{179.5, 15}},
+		{name: "antimeridian", point: orb.Point{180, 15}, expected: orb.Point{-180, 15}},
+		{name: "several turns", point: orb.Point{3*360 + 10, 15}, expected: orb.Point{10, 15}},
+		{name: "past north pole", point: orb.Point{10, 90.5}, expected: orb.Point{10, 90}},
+		{name: "past south pole", point: orb.Point{10, -90.5}, expected: orb.Point{10, -90}},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual := NormalizePoint(test.point)
+			assert.InDelta(t, test.expected.Lon(), actual.Lon(), 1e-9)
+			assert.InDelta(t, test.expected.Lat(), actual.Lat(), 1e-9)
+		})
+	}
+}
+
+func TestBoundContains(t *testing.T) {
+	t.Parallel()
+	acrossAntimeridian := geo.NewBoundAroundPoint(orb.Point{179.9, 15}, 100000)
+	normal := geo.NewBoundAroundPoint(orb.Point{145.7, 15.1}, 100000)
+	testCases := []struct {
+		name     string
+		bound    orb.Bound
+		point    orb.Point
+		expected bool
+	}{
+		{name: "inside", bound: normal, point: orb.Point{145.8, 15.2}, expected: true},
+		{name: "outside", bound: normal, point: orb.Point{147, 15.1}, expected: false},
+		{name: "west of antimeridian", bound: acrossAntimeridian, point: orb.Point{179.5, 15}, expected: true},
+		{name: "east of antimeridian", bound: acrossAntimeridian, point: orb.Point{-179.9, 15}, expected: true},
+		{name: "unnormalized longitude", bound: acrossAntimeridian, point: orb.Point{180.1, 15}, expected: true},
+		{name: "far east of antimeridian", bound: acrossAntimeridian, point: orb.Point{-170, 15}, expected: false},
+		{name: "far west of antimeridian", bound: acrossAntimeridian, point: orb.Point{170, 15}, expected: false},
+		{name: "north of bound", bound: acrossAntimeridian, point: orb.Point{179.9, 17}, expected: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, BoundContains(test.bound, test.point))
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package spatial
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/paulmach/orb"
+)
+
+// WGS 84 ellipsoid parameters used for UTM projection.
+const (
+	semiMajorAxis = 6378137.0
+	flattening    = 1 / 298.257223563
+	utmScale      = 0.9996
+	falseEasting  = 500000.0
+	falseNorthing = 10000000.0
+)
+
+// latitudeBands are the MGRS latitude band letters, from 80°S northward in 8° bands. Band X is 12° tall.
+const latitudeBands = "CDEFGHJKLMNPQRSTUVWX"
+
+// columnLetters are the 100km square column letters for each of the three repeating zone sets.
+var columnLetters = [3]string{"STUVWXYZ", "ABCDEFGH", "JKLMNPQR"}
+
+// rowLetters are the 100km square row letters. Even-numbered zones begin 5 letters in.
+const rowLetters = "ABCDEFGHJKLMNPQRSTUV"
+
+// UTM is a position in the Universal Transverse Mercator grid.
+type UTM struct {
+	// Zone is the UTM zone number, from 1 to 60.
+	Zone int
+	// Band is the MGRS latitude band letter.
+	Band byte
+	// Easting in meters.
+	Easting float64
+	// Northing in meters. In the southern hemisphere this includes the 10,000km false northing.
+	Northing float64
+}
+
+// ToUTM converts a point to UTM coordinates. The second return value is false for points in the polar regions, which
+// are outside the UTM grid.
+func ToUTM(point orb.Point) (UTM, bool) {
+	lat, lon := point.Lat(), point.Lon()
+	if lat < -80 || lat > 84 {
+		return UTM{}, false
+	}
+	lon = normalizeLongitude(lon)
+	zone := utmZone(lat, lon)
+	band := latitudeBands[min(int((lat+80)/8), len(latitudeBands)-1)]
+
+	centralMeridian := float64(6*zone-183) * math.Pi / 180
+	φ := lat * math.Pi / 180
+	λ := lon*math.Pi/180 - centralMeridian
+
+	e2 := flattening * (2 - flattening)
+	ep2 := e2 / (1 - e2)
+	n := semiMajorAxis / math.Sqrt(1-e2*math.Sin(φ)*math.Sin(φ))
+	t := math.Tan(φ) * math.Tan(φ)
+	c := ep2 * math.Cos(φ) * math.Cos(φ)
+	a := math.Cos(φ) * λ
+	m := semiMajorAxis * ((1-e2/4-3*e2*e2/64-5*e2*e2*e2/256)*φ -
+		(3*e2/8+3*e2*e2/32+45*e2*e2*e2/1024)*math.Sin(2*φ) +
+		(15*e2*e2/256+45*e2*e2*e2/1024)*math.Sin(4*φ) -
+		(35*e2*e2*e2/3072)*math.Sin(6*φ))
+
+	easting := utmScale*n*(a+(1-t+c)*math.Pow(a, 3)/6+(5-18*t+t*t+72*c-58*ep2)*math.Pow(a, 5)/120) + falseEasting
+	northing := utmScale * (m + n*math.Tan(φ)*(a*a/2+(5-t+9*c+4*c*c)*math.Pow(a, 4)/24+(61-58*t+t*t+600*c-330*ep2)*math.Pow(a, 6)/720))
+	if lat < 0 {
+		northing += falseNorthing
+	}
+	return UTM{Zone: zone, Band: band, Easting: easting, Northing: northing}, true
+}
+
+// utmZone returns the UTM zone number for the given latitude and longitude, including the exceptions around Norway and
+// Svalbard.
+func utmZone(lat, lon float64) int {
+	zone := int((lon+180)/6) + 1
+	if zone > 60 {
+		zone = 60
+	}
+	if lat >= 56 && lat < 64 && lon >= 3 && lon < 12 {
+		return 32
+	}
+	if lat >= 72 && lat < 84 {
+		switch {
+		case lon >= 0 && lon < 9:
+			return 31
+		case lon >= 9 && lon < 21:
+			return 33
+		case lon >= 21 && lon < 33:
+			return 35
+		case lon >= 33 && lon < 42:
+			return 37
+		}
+	}
+	return zone
+}
+
+// MGRS formats a point as a Military Grid Reference System coordinate with the given number of digits of precision
+// for each of the easting and northing, such as "37T GG 1234 5678" for 4 digits (10 meter precision). Precision is
+// clamped between 1 and 5 digits. The second return value is false for points in the polar regions, which are outside
+// the MGRS grid.
+func MGRS(point orb.Point, precision int) (string, bool) {
+	utm, ok := ToUTM(point)
+	if !ok {
+		return "", false
+	}
+	precision = max(1, min(5, precision))
+
+	set := utm.Zone % 3
+	column := columnLetters[set][int(utm.Easting/100000)-1]
+	rowOffset := 0
+	if utm.Zone%2 == 0 {
+		rowOffset = 5
+	}
+	row := rowLetters[(int(utm.Northing/100000)+rowOffset)%len(rowLetters)]
+
+	divisor := math.Pow10(5 - precision)
+	easting := int(math.Mod(utm.Easting, 100000) / divisor)
+	northing := int(math.Mod(utm.Northing, 100000) / divisor)
+	return fmt.Sprintf("%d%c %c%c %0*d %0*d", utm.Zone, utm.Band, column, row, precision, easting, precision, northing), true
+}
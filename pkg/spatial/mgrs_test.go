@@ -0,0 +1,66 @@
+package spatial
+
+import (
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMGRS(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name      string
+		point     orb.Point
+		precision int
+		expected  string
+	}{
+		{
+			name:      "equator and prime meridian",
+			point:     orb.Point{0, 0},
+			precision: 5,
+			expected:  "31N AA 66021 00000",
+		},
+		{
+			name:      "central meridian",
+			point:     orb.Point{3, 0},
+			precision: 5,
+			expected:  "31N EA 00000 00000",
+		},
+		{
+			name:      "Washington Monument",
+			point:     orb.Point{-77.0353, 38.8895},
+			precision: 4,
+			expected:  "18S UJ 2347 0648",
+		},
+		{
+			name:      "southern hemisphere",
+			point:     orb.Point{151.2153, -33.8568},
+			precision: 3,
+			expected:  "56H LH 349 522",
+		},
+		{
+			name:      "Norway exception",
+			point:     orb.Point{5.3, 60.4},
+			precision: 1,
+			expected:  "32V KN 9 0",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual, ok := MGRS(test.point, test.precision)
+			require.True(t, ok)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestMGRSPolar(t *testing.T) {
+	t.Parallel()
+	_, ok := MGRS(orb.Point{0, 85}, 5)
+	assert.False(t, ok)
+	_, ok = MGRS(orb.Point{0, -81}, 5)
+	assert.False(t, ok)
+}
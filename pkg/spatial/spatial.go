@@ -11,9 +11,10 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-// Distance returns the absolute distance between two points on the earth.
+// Distance returns the absolute distance between two points on the earth. The haversine formula is used because the
+// faster equirectangular approximation is badly wrong near the poles.
 func Distance(a, b orb.Point) unit.Length {
-	return unit.Length(math.Abs(geo.Distance(a, b))) * unit.Meter
+	return unit.Length(math.Abs(geo.DistanceHaversine(a, b))) * unit.Meter
 }
 
 // TrueBearing returns the true bearing between two points.
@@ -25,13 +26,48 @@ func TrueBearing(a, b orb.Point) bearings.Bearing {
 	)
 }
 
+// PointAtBearingAndDistance returns the point at the given true bearing and distance from the origin. The result is
+// normalized, so that paths which cross the antimeridian or a pole return a valid longitude.
 func PointAtBearingAndDistance(origin orb.Point, bearing bearings.Bearing, distance unit.Length) orb.Point {
 	if bearing.IsMagnetic() {
 		log.Warn().Stringer("bearing", bearing).Msg("bearing provided to PointAtBearingAndDistance should not be magnetic")
 	}
 	degrees := bearing.Degrees()
 	meters := distance.Meters()
-	return geo.PointAtBearingAndDistance(origin, degrees, meters)
+	return NormalizePoint(geo.PointAtBearingAndDistance(origin, degrees, meters))
+}
+
+// NormalizePoint wraps the point's longitude into the range [-180, 180) and clamps its latitude to the range
+// [-90, 90].
+func NormalizePoint(point orb.Point) orb.Point {
+	return orb.Point{normalizeLongitude(point.Lon()), max(-90, min(90, point.Lat()))}
+}
+
+// normalizeLongitude wraps a longitude into the range [-180, 180).
+func normalizeLongitude(lon float64) float64 {
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	return lon - 180
+}
+
+// BoundContains checks if the bound contains the point. Unlike [orb.Bound.Contains], this handles bounds which cross
+// the antimeridian, such as those returned by [geo.NewBoundAroundPoint] near the date line, where the minimum
+// longitude is east of the maximum longitude.
+func BoundContains(bound orb.Bound, point orb.Point) bool {
+	if point.Lat() < bound.Min.Lat() || point.Lat() > bound.Max.Lat() {
+		return false
+	}
+	if bound.Max.Lon()-bound.Min.Lon() >= 360 {
+		return true
+	}
+	minLon, maxLon := normalizeLongitude(bound.Min.Lon()), normalizeLongitude(bound.Max.Lon())
+	lon := normalizeLongitude(point.Lon())
+	if minLon <= maxLon {
+		return minLon <= lon && lon <= maxLon
+	}
+	return lon >= minLon || lon <= maxLon
 }
 
 // IsZero returns true if the point is the origin.
@@ -243,3 +243,27 @@ func TestNormalizeAltitude(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkDistance(b *testing.B) {
+	origin := orb.Point{42.5, 43.5}
+	target := orb.Point{41.2, 44.8}
+	for range b.N {
+		_ = Distance(origin, target)
+	}
+}
+
+func BenchmarkTrueBearing(b *testing.B) {
+	origin := orb.Point{42.5, 43.5}
+	target := orb.Point{41.2, 44.8}
+	for range b.N {
+		_ = TrueBearing(origin, target)
+	}
+}
+
+func BenchmarkPointAtBearingAndDistance(b *testing.B) {
+	origin := orb.Point{42.5, 43.5}
+	bearing := bearings.NewTrueBearing(135 * unit.Degree)
+	for range b.N {
+		_ = PointAtBearingAndDistance(origin, bearing, 40*unit.NauticalMile)
+	}
+}
@@ -58,6 +58,17 @@ type streamer struct {
 	removals chan *types.Object
 	// bullseyesIdx indexes bullseye object IDs by coalition.
 	bullseyesIdx sync.Map
+	// ejections records every ejected pilot seen during the mission, by object ID. Unlike objects, ejections are kept
+	// after the parachutist is removed, so that the location of a downed pilot is not lost. Protected by objectsLock.
+	ejections map[uint64]sim.Ejection
+	// kills records every object destroyed during the mission, by object ID. Protected by objectsLock.
+	kills map[uint64]sim.Kill
+	// weapons records the launch of every weapon in flight, by object ID. Protected by objectsLock.
+	weapons map[uint64]sim.WeaponLaunched
+	// launches, impacts and sensors are internal channels for passing weapon and sensor events.
+	launches chan sim.WeaponLaunched
+	impacts  chan sim.WeaponImpacted
+	sensors  chan sim.SensorEvent
 	// updateInterval is the interval at which the streamer will publish object updates.s
 	updateInterval time.Duration
 	// inMultiline is true when the streamer is currently processing a line that contains newline characters.
@@ -73,6 +84,12 @@ func New(acmi *bufio.Reader, updateInterval time.Duration) ACMI {
 	return &streamer{
 		acmi:           acmi,
 		objects:        make(map[uint64]*types.Object),
+		ejections:      make(map[uint64]sim.Ejection),
+		kills:          make(map[uint64]sim.Kill),
+		weapons:        make(map[uint64]sim.WeaponLaunched),
+		launches:       make(chan sim.WeaponLaunched, eventBufferSize),
+		impacts:        make(chan sim.WeaponImpacted, eventBufferSize),
+		sensors:        make(chan sim.SensorEvent, eventBufferSize),
 		starts:         make(chan time.Time),
 		removals:       make(chan *types.Object),
 		updateInterval: updateInterval,
@@ -112,7 +129,7 @@ func (s *streamer) Run(ctx context.Context) error {
 
 // handleLine parses a line of ACMI data.
 //   - headers and comments are ignored.
-//   - global object updates update the reference time and point.
+//   - global object updates update the reference time and point, and record destroyed objects.
 //   - object updates are stored in the object map.
 //   - object removals remove the object from the internal object map and publish to the removals channel.
 func (s *streamer) handleLine(line string) error {
@@ -213,6 +230,12 @@ func (s *streamer) handleLine(line string) error {
 		if refPointChanged {
 			logger.Info().Float64("longitude", s.referencePoint.Lon()).Float64("latitude", s.referencePoint.Lat()).Msg("reference point updated")
 		}
+		if event, ok := update.Properties[properties.Event]; ok {
+			if err := s.handleEvent(event); err != nil {
+				logger.Error().Err(err).Msg("error handling event")
+				updateErr = errors.Join(updateErr, fmt.Errorf("error handling event: %w", err))
+			}
+		}
 		if updateErr != nil {
 			return fmt.Errorf("error updating global object: %w", updateErr)
 		}
@@ -226,6 +249,7 @@ func (s *streamer) handleLine(line string) error {
 	if update.IsRemoval {
 		object, ok := s.objects[update.ID]
 		if ok {
+			s.observeImpact(object)
 			s.removals <- object
 			delete(s.objects, update.ID)
 		}
@@ -252,6 +276,7 @@ func (s *streamer) handleLine(line string) error {
 						Str("old", oldValue).
 						Str("new", newValue).
 						Msg("static property changed (ID reused for new object?)")
+					s.observeImpact(s.objects[update.ID])
 					s.removals <- s.objects[update.ID]
 					s.objects[update.ID] = types.NewObject(update.ID)
 					break
@@ -260,15 +285,28 @@ func (s *streamer) handleLine(line string) error {
 		}
 	}
 
-	if err = s.objects[update.ID].Update(update, s.referencePoint); err != nil {
+	object := s.objects[update.ID]
+	previousRadarMode, _ := object.GetProperty(properties.RadarMode)
+	previousLockedTarget, _ := object.GetProperty(properties.LockedTarget)
+	if err = object.Update(update, s.referencePoint); err != nil {
 		return fmt.Errorf("error updating object: %w", err)
 	}
+	s.observeLaunch(object)
+	s.observeSensors(object, update, previousRadarMode, previousLockedTarget)
 
 	return nil
 }
 
 // Stream implements [ACMI.Stream].
-func (s *streamer) Stream(ctx context.Context, starts chan<- sim.Started, updates chan<- sim.Updated, fades chan<- sim.Faded) {
+func (s *streamer) Stream(
+	ctx context.Context,
+	starts chan<- sim.Started,
+	updates chan<- sim.Updated,
+	fades chan<- sim.Faded,
+	launches chan<- sim.WeaponLaunched,
+	impacts chan<- sim.WeaponImpacted,
+	sensors chan<- sim.SensorEvent,
+) {
 	ticker := time.NewTicker(s.updateInterval)
 	defer ticker.Stop()
 	s.processUpdates(updates)
@@ -287,6 +325,28 @@ func (s *streamer) Stream(ctx context.Context, starts chan<- sim.Started, update
 				Timestamp:        observedAt,
 				MissionTimestamp: s.referenceTime,
 			}
+		// Weapon and sensor events are discarded if the caller did not provide a channel for them.
+		case launch := <-s.launches:
+			if launches != nil {
+				select {
+				case launches <- launch:
+				case <-ctx.Done():
+				}
+			}
+		case impact := <-s.impacts:
+			if impacts != nil {
+				select {
+				case impacts <- impact:
+				case <-ctx.Done():
+				}
+			}
+		case event := <-s.sensors:
+			if sensors != nil {
+				select {
+				case sensors <- event:
+				case <-ctx.Done():
+				}
+			}
 		case <-ticker.C:
 			s.processUpdates(updates)
 		}
@@ -308,6 +368,9 @@ func (s *streamer) processUpdates(updates chan<- sim.Updated) {
 		if slices.Contains(types, tags.Bullseye) {
 			s.updateBullseye(object)
 		}
+		if slices.Contains(types, tags.Parachutist) {
+			s.updateEjection(object)
+		}
 		if slices.Contains(types, tags.FixedWing) || slices.Contains(types, tags.Rotorcraft) {
 			if err := s.updateAircraft(updates, object); err != nil {
 				logger.Error().Err(err).Msg("error updating aircraft")
@@ -344,6 +407,88 @@ func (s *streamer) updateBullseye(object *types.Object) {
 	s.bullseyesIdx.Store(coalition, object.ID)
 }
 
+// updateEjection records the position of an ejected pilot.
+func (s *streamer) updateEjection(object *types.Object) {
+	logger := log.With().Uint64("id", object.ID).Logger()
+	coordinates, err := object.GetCoordinates(s.referencePoint)
+	if err != nil || coordinates == nil {
+		return
+	}
+	ejection, ok := s.ejections[object.ID]
+	if !ok {
+		prop, _ := object.GetProperty(properties.Coalition)
+		ejection = sim.Ejection{
+			ID:        object.ID,
+			Coalition: properties.PropertyToCoalition(prop),
+			Time:      s.cursorTime,
+		}
+		logger.Info().Stringer("coalition", ejection.Coalition).Msg("observed ejected pilot")
+	}
+	ejection.Point = coordinates.Location
+	s.ejections[object.ID] = ejection
+}
+
+// Ejections implements [ACMI.Ejections].
+func (s *streamer) Ejections() []sim.Ejection {
+	s.objectsLock.RLock()
+	defer s.objectsLock.RUnlock()
+	ejections := make([]sim.Ejection, 0, len(s.ejections))
+	for _, ejection := range s.ejections {
+		ejections = append(ejections, ejection)
+	}
+	return ejections
+}
+
+// handleEvent handles the value of a global event property. Only Destroyed events are handled; other events are
+// ignored.
+func (s *streamer) handleEvent(event string) error {
+	fields := strings.Split(event, "|")
+	if fields[0] != properties.DestroyedEvent || len(fields) < 2 {
+		return nil
+	}
+	id, err := strconv.ParseUint(fields[1], 16, 64)
+	if err != nil {
+		return fmt.Errorf("error parsing destroyed object ID: %w", err)
+	}
+
+	s.objectsLock.Lock()
+	defer s.objectsLock.Unlock()
+	object, ok := s.objects[id]
+	if !ok {
+		return nil
+	}
+	if _, ok := s.kills[id]; ok {
+		return nil
+	}
+	objectTypes, err := object.GetTypes()
+	if err != nil {
+		return fmt.Errorf("error getting destroyed object types: %w", err)
+	}
+	name, _ := object.GetProperty(properties.Name)
+	prop, _ := object.GetProperty(properties.Coalition)
+	kill := sim.Kill{
+		ID:         id,
+		Name:       name,
+		Coalition:  properties.PropertyToCoalition(prop),
+		IsAircraft: slices.Contains(objectTypes, tags.FixedWing) || slices.Contains(objectTypes, tags.Rotorcraft),
+		Time:       s.cursorTime,
+	}
+	log.Info().Uint64("id", id).Str("name", kill.Name).Stringer("coalition", kill.Coalition).Msg("observed destroyed object")
+	s.kills[id] = kill
+	return nil
+}
+
+// Kills implements [ACMI.Kills].
+func (s *streamer) Kills() []sim.Kill {
+	s.objectsLock.RLock()
+	defer s.objectsLock.RUnlock()
+	kills := make([]sim.Kill, 0, len(s.kills))
+	for _, kill := range s.kills {
+		kills = append(kills, kill)
+	}
+	return kills
+}
+
 // Bullseye implements [ACMI.Bullseye].
 func (s *streamer) Bullseye(coalition coalitions.Coalition) (orb.Point, error) {
 	val, ok := s.bullseyesIdx.Load(coalition)
@@ -364,6 +509,50 @@ func (s *streamer) Bullseye(coalition coalitions.Coalition) (orb.Point, error) {
 	return coordinates.Location, nil
 }
 
+// GroundUnits implements [ACMI.GroundUnits].
+func (s *streamer) GroundUnits() []sim.GroundUnit {
+	s.objectsLock.RLock()
+	defer s.objectsLock.RUnlock()
+	units := make([]sim.GroundUnit, 0)
+	for _, object := range s.objects {
+		unit, ok := s.buildGroundUnit(object)
+		if ok {
+			units = append(units, unit)
+		}
+	}
+	return units
+}
+
+// buildGroundUnit creates a ground unit from an object. Ships are included. The second return value is false if the
+// object is not a ground unit or ship, or is a static object such as a building.
+func (s *streamer) buildGroundUnit(object *types.Object) (sim.GroundUnit, bool) {
+	objectTypes, err := object.GetTypes()
+	if err != nil || !(slices.Contains(objectTypes, tags.Ground) || slices.Contains(objectTypes, tags.Sea)) {
+		return sim.GroundUnit{}, false
+	}
+	if slices.Contains(objectTypes, tags.Static) || slices.Contains(objectTypes, tags.Building) || slices.Contains(objectTypes, tags.Parachutist) {
+		return sim.GroundUnit{}, false
+	}
+	coordinates, err := object.GetCoordinates(s.referencePoint)
+	if err != nil || coordinates == nil {
+		return sim.GroundUnit{}, false
+	}
+	acmiCoalition, ok := object.GetProperty(properties.Coalition)
+	if !ok {
+		return sim.GroundUnit{}, false
+	}
+	name, _ := object.GetProperty(properties.Name)
+	group, _ := object.GetProperty(properties.Group)
+	return sim.GroundUnit{
+		ID:        object.ID,
+		Name:      name,
+		Group:     group,
+		Coalition: properties.PropertyToCoalition(acmiCoalition),
+		Point:     coordinates.Location,
+		Types:     objectTypes,
+	}, true
+}
+
 // Time implements [ACMI.Time].
 func (s *streamer) Time() time.Time {
 	return s.cursorTime
@@ -0,0 +1,125 @@
+package acmi
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/dharmab/skyeye/pkg/tacview/properties"
+	"github.com/dharmab/skyeye/pkg/tacview/tags"
+	"github.com/dharmab/skyeye/pkg/tacview/types"
+	"github.com/rs/zerolog/log"
+)
+
+// eventBufferSize is the number of weapon and sensor events which may wait to be streamed. Further events are dropped
+// rather than stalling the ACMI protocol handler.
+const eventBufferSize = 256
+
+// isWeapon returns true if the object is a missile, rocket, bomb or torpedo. Gun rounds are excluded, since there are
+// far too many of them to be useful.
+func isWeapon(objectTypes []string) bool {
+	if !slices.Contains(objectTypes, tags.Weapon) {
+		return false
+	}
+	return slices.ContainsFunc([]string{tags.Missile, tags.Rocket, tags.Bomb, tags.Torpedo}, func(tag string) bool {
+		return slices.Contains(objectTypes, tag)
+	})
+}
+
+// observeLaunch publishes a launch event the first time a weapon object is seen. Must be called with objectsLock held.
+func (s *streamer) observeLaunch(object *types.Object) {
+	if _, ok := s.weapons[object.ID]; ok {
+		return
+	}
+	objectTypes, err := object.GetTypes()
+	if err != nil || !isWeapon(objectTypes) {
+		return
+	}
+	name, _ := object.GetProperty(properties.Name)
+	prop, _ := object.GetProperty(properties.Coalition)
+	launch := sim.WeaponLaunched{
+		ID:               object.ID,
+		Name:             name,
+		Coalition:        properties.PropertyToCoalition(prop),
+		MissionTimestamp: s.cursorTime,
+	}
+	if parent, ok := object.GetProperty(properties.Parent); ok {
+		if id, err := strconv.ParseUint(parent, 16, 64); err == nil {
+			launch.ParentID = id
+		}
+	}
+	if coordinates, err := object.GetCoordinates(s.referencePoint); err == nil && coordinates != nil {
+		launch.Point = coordinates.Location
+		if coordinates.Altitude != nil {
+			launch.Altitude = *coordinates.Altitude
+		}
+	}
+	s.weapons[object.ID] = launch
+	select {
+	case s.launches <- launch:
+	default:
+		log.Warn().Uint64("id", object.ID).Msg("dropping weapon launch event because the stream is behind")
+	}
+}
+
+// observeImpact publishes an impact event when a weapon object is removed. Must be called with objectsLock held.
+func (s *streamer) observeImpact(object *types.Object) {
+	launch, ok := s.weapons[object.ID]
+	if !ok {
+		return
+	}
+	delete(s.weapons, object.ID)
+	impact := sim.WeaponImpacted{
+		ID:               launch.ID,
+		Name:             launch.Name,
+		Coalition:        launch.Coalition,
+		Point:            launch.Point,
+		Altitude:         launch.Altitude,
+		MissionTimestamp: s.cursorTime,
+	}
+	if coordinates, err := object.GetCoordinates(s.referencePoint); err == nil && coordinates != nil {
+		impact.Point = coordinates.Location
+		if coordinates.Altitude != nil {
+			impact.Altitude = *coordinates.Altitude
+		}
+	}
+	select {
+	case s.impacts <- impact:
+	default:
+		log.Warn().Uint64("id", object.ID).Msg("dropping weapon impact event because the stream is behind")
+	}
+}
+
+// observeSensors publishes sensor events for changes to an object's radar mode and locked target. The previous values
+// are the properties before the update was applied.
+func (s *streamer) observeSensors(object *types.Object, update *types.ObjectUpdate, previousRadarMode, previousLockedTarget string) {
+	events := make([]sim.SensorEvent, 0)
+	if mode, ok := update.Properties[properties.RadarMode]; ok && mode != previousRadarMode {
+		kind := sim.RadarOff
+		if mode != "" && mode != "0" {
+			kind = sim.RadarOn
+		}
+		// An object which first appears with its radar off isn't interesting.
+		if previousRadarMode != "" || kind == sim.RadarOn {
+			events = append(events, sim.SensorEvent{ID: object.ID, Kind: kind})
+		}
+	}
+	if target, ok := update.Properties[properties.LockedTarget]; ok && target != previousLockedTarget {
+		if previousLockedTarget != "" {
+			if id, err := strconv.ParseUint(previousLockedTarget, 16, 64); err == nil && id != 0 {
+				events = append(events, sim.SensorEvent{ID: object.ID, Kind: sim.Unlocked, TargetID: id})
+			}
+		}
+		if id, err := strconv.ParseUint(target, 16, 64); err == nil && id != 0 {
+			events = append(events, sim.SensorEvent{ID: object.ID, Kind: sim.Locked, TargetID: id})
+		}
+	}
+	for _, event := range events {
+		event.MissionTimestamp = s.cursorTime
+		select {
+		case s.sensors <- event:
+		default:
+			log.Warn().Uint64("id", object.ID).Msg("dropping sensor event because the stream is behind")
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package acmi
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsWeapon(t *testing.T) {
+	t.Parallel()
+	assert.True(t, isWeapon([]string{"Weapon", "Missile"}))
+	assert.True(t, isWeapon([]string{"Weapon", "Bomb"}))
+	assert.False(t, isWeapon([]string{"Weapon", "Shell"}))
+	assert.False(t, isWeapon([]string{"Air", "FixedWing"}))
+}
+
+func TestWeaponAndSensorEvents(t *testing.T) {
+	t.Parallel()
+	s := New(bufio.NewReader(strings.NewReader("")), time.Second).(*streamer)
+	for _, line := range []string{
+		"102,T=33|44|8000,Type=Air+FixedWing,Name=F-16C_50,Coalition=Allies,RadarMode=0",
+		"102,RadarMode=1",
+		"102,LockedTarget=103",
+		"101,T=33|44|8000,Type=Weapon+Missile,Name=AIM_120C,Coalition=Allies,Parent=102",
+		"101,T=33.01|44|8000",
+		"102,LockedTarget=0",
+	} {
+		require.NoError(t, s.handleLine(line))
+	}
+
+	require.Len(t, s.launches, 1)
+	launch := <-s.launches
+	assert.Equal(t, uint64(0x101), launch.ID)
+	assert.Equal(t, "AIM_120C", launch.Name)
+	assert.Equal(t, uint64(0x102), launch.ParentID)
+
+	expected := []sim.SensorEvent{
+		{ID: 0x102, Kind: sim.RadarOn},
+		{ID: 0x102, Kind: sim.Locked, TargetID: 0x103},
+		{ID: 0x102, Kind: sim.Unlocked, TargetID: 0x103},
+	}
+	require.Len(t, s.sensors, len(expected))
+	for _, e := range expected {
+		actual := <-s.sensors
+		assert.Equal(t, e.ID, actual.ID)
+		assert.Equal(t, e.Kind, actual.Kind)
+		assert.Equal(t, e.TargetID, actual.TargetID)
+	}
+}
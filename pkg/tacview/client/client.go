@@ -20,7 +20,13 @@ import (
 type Client interface {
 	Run(context.Context, *sync.WaitGroup) error
 	Bullseye(coalitions.Coalition) (orb.Point, error)
+	GroundUnits() []sim.GroundUnit
+	Ejections() []sim.Ejection
+	Kills() []sim.Kill
 	Time() time.Time
+	// LastUpdate returns the wall clock time at which the mission time last advanced, or the zero time if no
+	// telemetry has been received.
+	LastUpdate() time.Time
 	Close() error
 }
 
@@ -28,29 +34,78 @@ type tacviewClient struct {
 	starts         chan<- sim.Started
 	updates        chan<- sim.Updated
 	fades          chan<- sim.Faded
+	launches       chan<- sim.WeaponLaunched
+	impacts        chan<- sim.WeaponImpacted
+	sensors        chan<- sim.SensorEvent
 	updateInterval time.Duration
 	bullseyes      map[coalitions.Coalition]orb.Point
 	bullseyesLock  sync.RWMutex
+	groundUnits    []sim.GroundUnit
+	groundLock     sync.RWMutex
+	ejections      []sim.Ejection
+	ejectionsLock  sync.RWMutex
+	kills          []sim.Kill
+	killsLock      sync.RWMutex
 	missionTime    time.Time
+	lastUpdate     time.Time
+	timeLock       sync.RWMutex
+	// missionStart is the reference time of the mission being streamed, used to recognize a reconnected stream which
+	// resumes the same mission.
+	missionStart time.Time
+	// previousKills and previousEjections are the kills and ejections seen by earlier connections during the same
+	// mission. A resumed stream only reports those it sees itself.
+	previousKills     []sim.Kill
+	previousEjections []sim.Ejection
+	missionLock       sync.Mutex
 }
 
-func newTacviewClient(starts chan<- sim.Started, updates chan<- sim.Updated, fades chan<- sim.Faded, updateInterval time.Duration) *tacviewClient {
+// resyncTimeout is how long to wait for a reconnected stream to report its first time frame, to decide whether it
+// resumes the mission which was being streamed before the connection dropped.
+const resyncTimeout = 10 * time.Second
+
+func newTacviewClient(
+	starts chan<- sim.Started,
+	updates chan<- sim.Updated,
+	fades chan<- sim.Faded,
+	launches chan<- sim.WeaponLaunched,
+	impacts chan<- sim.WeaponImpacted,
+	sensors chan<- sim.SensorEvent,
+	updateInterval time.Duration,
+) *tacviewClient {
 	return &tacviewClient{
 		starts:         starts,
 		updates:        updates,
 		fades:          fades,
+		launches:       launches,
+		impacts:        impacts,
+		sensors:        sensors,
 		updateInterval: updateInterval,
 		bullseyes:      map[coalitions.Coalition]orb.Point{},
 	}
 }
 
 func (c *tacviewClient) stream(ctx context.Context, wg *sync.WaitGroup, source acmi.ACMI) error {
+	// Carry over the previous source's kills and ejections in case the new source resumes the same mission. They are
+	// forgotten if it starts a new mission.
+	kills, ejections := c.Kills(), c.Ejections()
+	c.missionLock.Lock()
+	c.previousKills = kills
+	c.previousEjections = ejections
+	c.missionLock.Unlock()
+	lastMissionTime := c.Time()
+
 	sCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	starts := make(chan sim.Started)
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		source.Stream(sCtx, c.starts, c.updates, c.fades)
+		c.forwardStarts(sCtx, source, starts, lastMissionTime)
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		source.Stream(sCtx, starts, c.updates, c.fades, c.launches, c.impacts, c.sensors)
 	}()
 
 	wg.Add(1)
@@ -71,6 +126,9 @@ func (c *tacviewClient) stream(ctx context.Context, wg *sync.WaitGroup, source a
 				if err != nil {
 					log.Warn().Err(err).Msg("error updating bullseyes")
 				}
+				c.updateGroundUnits(source)
+				c.updateEjections(source)
+				c.updateKills(source)
 			}
 		}
 	}()
@@ -95,8 +153,96 @@ func (c *tacviewClient) stream(ctx context.Context, wg *sync.WaitGroup, source a
 	}
 }
 
+// forwardStarts forwards mission starts from the source, except when a reconnected stream resumes the mission which
+// was being streamed before the connection dropped. The telemetry service replays the global header and every object
+// to each new connection, so the existing trackfiles are refreshed rather than cleared.
+func (c *tacviewClient) forwardStarts(ctx context.Context, source acmi.ACMI, starts <-chan sim.Started, lastMissionTime time.Time) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case start := <-starts:
+			if c.isResumed(ctx, source, start, lastMissionTime) {
+				log.Info().Time("missionTime", source.Time()).Msg("telemetry resumed the same mission, keeping trackfiles")
+				continue
+			}
+			c.startMission(start)
+			select {
+			case c.starts <- start:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// isResumed returns true if the start belongs to the mission which was being streamed before the connection dropped.
+// A restarted mission has the same reference time as before, so the stream only resumes the mission if its first time
+// frame is no earlier than the last mission time seen before the connection dropped.
+func (c *tacviewClient) isResumed(ctx context.Context, source acmi.ACMI, start sim.Started, lastMissionTime time.Time) bool {
+	c.missionLock.Lock()
+	missionStart := c.missionStart
+	c.missionLock.Unlock()
+	if missionStart.IsZero() || lastMissionTime.IsZero() || !missionStart.Equal(start.MissionTimestamp) {
+		return false
+	}
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(resyncTimeout)
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timeout:
+			log.Warn().Msg("reconnected telemetry stream did not report the mission time, treating it as a new mission")
+			return false
+		case <-ticker.C:
+			if first := source.Time(); !first.IsZero() {
+				return !first.Before(lastMissionTime)
+			}
+		}
+	}
+}
+
+// startMission forgets the state of the previous mission.
+func (c *tacviewClient) startMission(start sim.Started) {
+	c.missionLock.Lock()
+	defer c.missionLock.Unlock()
+	c.missionStart = start.MissionTimestamp
+	c.previousKills = nil
+	c.previousEjections = nil
+
+	c.killsLock.Lock()
+	c.kills = nil
+	c.killsLock.Unlock()
+	c.ejectionsLock.Lock()
+	c.ejections = nil
+	c.ejectionsLock.Unlock()
+}
+
 func (c *tacviewClient) updateTime(source acmi.ACMI) {
-	c.missionTime = source.Time()
+	missionTime := source.Time()
+	c.timeLock.Lock()
+	defer c.timeLock.Unlock()
+	if missionTime.After(c.missionTime) {
+		c.lastUpdate = time.Now()
+	}
+	c.missionTime = missionTime
+}
+
+// Time implements [Client.Time].
+func (c *tacviewClient) Time() time.Time {
+	c.timeLock.RLock()
+	defer c.timeLock.RUnlock()
+	return c.missionTime
+}
+
+// LastUpdate implements [Client.LastUpdate].
+func (c *tacviewClient) LastUpdate() time.Time {
+	c.timeLock.RLock()
+	defer c.timeLock.RUnlock()
+	return c.lastUpdate
 }
 
 func (c *tacviewClient) updateBullseyes(source acmi.ACMI) error {
@@ -112,6 +258,52 @@ func (c *tacviewClient) updateBullseyes(source acmi.ACMI) error {
 	return nil
 }
 
+func (c *tacviewClient) updateGroundUnits(source acmi.ACMI) {
+	units := source.GroundUnits()
+	c.groundLock.Lock()
+	defer c.groundLock.Unlock()
+	c.groundUnits = units
+}
+
+// GroundUnits returns the ground units seen at the most recent update.
+func (c *tacviewClient) GroundUnits() []sim.GroundUnit {
+	c.groundLock.RLock()
+	defer c.groundLock.RUnlock()
+	return c.groundUnits
+}
+
+func (c *tacviewClient) updateEjections(source acmi.ACMI) {
+	c.missionLock.Lock()
+	ejections := carryOver(c.previousEjections, source.Ejections(), func(e sim.Ejection) uint64 { return e.ID })
+	c.missionLock.Unlock()
+	c.ejectionsLock.Lock()
+	defer c.ejectionsLock.Unlock()
+	c.ejections = ejections
+}
+
+// Ejections returns the ejections seen at the most recent update.
+func (c *tacviewClient) Ejections() []sim.Ejection {
+	c.ejectionsLock.RLock()
+	defer c.ejectionsLock.RUnlock()
+	return c.ejections
+}
+
+func (c *tacviewClient) updateKills(source acmi.ACMI) {
+	c.missionLock.Lock()
+	kills := carryOver(c.previousKills, source.Kills(), func(k sim.Kill) uint64 { return k.ID })
+	c.missionLock.Unlock()
+	c.killsLock.Lock()
+	defer c.killsLock.Unlock()
+	c.kills = kills
+}
+
+// Kills returns the kills seen at the most recent update.
+func (c *tacviewClient) Kills() []sim.Kill {
+	c.killsLock.RLock()
+	defer c.killsLock.RUnlock()
+	return c.kills
+}
+
 func (c *tacviewClient) Bullseye(coalition coalitions.Coalition) (orb.Point, error) {
 	c.bullseyesLock.RLock()
 	defer c.bullseyesLock.RUnlock()
@@ -124,3 +316,22 @@ func (c *tacviewClient) Bullseye(coalition coalitions.Coalition) (orb.Point, err
 	}
 	return point, nil
 }
+
+// carryOver returns the previous records which are not among the current records, followed by the current records.
+// Records are matched by the given ID function.
+func carryOver[T any](previous, current []T, id func(T) uint64) []T {
+	if len(previous) == 0 {
+		return current
+	}
+	seen := make(map[uint64]struct{}, len(current))
+	for _, record := range current {
+		seen[id(record)] = struct{}{}
+	}
+	records := make([]T, 0, len(previous)+len(current))
+	for _, record := range previous {
+		if _, ok := seen[id(record)]; !ok {
+			records = append(records, record)
+		}
+	}
+	return append(records, current...)
+}
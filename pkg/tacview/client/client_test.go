@@ -0,0 +1,19 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/sim"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCarryOver(t *testing.T) {
+	t.Parallel()
+	id := func(k sim.Kill) uint64 { return k.ID }
+	previous := []sim.Kill{{ID: 1, Name: "F-16C_50"}, {ID: 2, Name: "MiG-29S"}}
+	current := []sim.Kill{{ID: 2, Name: "MiG-29S"}, {ID: 3, Name: "T-72B"}}
+
+	assert.Equal(t, []sim.Kill{{ID: 1, Name: "F-16C_50"}, {ID: 2, Name: "MiG-29S"}, {ID: 3, Name: "T-72B"}}, carryOver(previous, current, id))
+	assert.Equal(t, current, carryOver(nil, current, id))
+	assert.Equal(t, previous, carryOver(previous, nil, id))
+}
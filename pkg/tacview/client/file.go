@@ -30,13 +30,16 @@ func NewFileClient(
 	starts chan<- sim.Started,
 	updates chan<- sim.Updated,
 	fades chan<- sim.Faded,
+	launches chan<- sim.WeaponLaunched,
+	impacts chan<- sim.WeaponImpacted,
+	sensors chan<- sim.SensorEvent,
 	updateInterval time.Duration,
 ) (Client, error) {
 	f, err := openFile(path)
 	if err != nil {
 		return nil, err
 	}
-	tacviewClient := newTacviewClient(starts, updates, fades, updateInterval)
+	tacviewClient := newTacviewClient(starts, updates, fades, launches, impacts, sensors, updateInterval)
 	return &fileClient{
 		file:          f,
 		tacviewClient: tacviewClient,
@@ -82,10 +85,6 @@ func (c *fileClient) Run(ctx context.Context, wg *sync.WaitGroup) error {
 	return c.tacviewClient.stream(ctx, wg, acmi)
 }
 
-func (c *fileClient) Time() time.Time {
-	return c.tacviewClient.missionTime
-}
-
 func (c *fileClient) Close() error {
 	return c.file.Close()
 }
@@ -17,6 +17,14 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+const (
+	// minReconnectDelay is the delay before the first attempt to reconnect after the connection is lost.
+	minReconnectDelay = time.Second
+	// maxReconnectDelay is the longest delay between attempts to reconnect. The delay doubles after each failed
+	// attempt up to this limit, and is reset once a connection has stayed up for at least this long.
+	maxReconnectDelay = time.Minute
+)
+
 type telemetryClient struct {
 	address  string
 	hostname string
@@ -34,11 +42,14 @@ func NewTelemetryClient(
 	starts chan<- sim.Started,
 	updates chan<- sim.Updated,
 	fades chan<- sim.Faded,
+	launches chan<- sim.WeaponLaunched,
+	impacts chan<- sim.WeaponImpacted,
+	sensors chan<- sim.SensorEvent,
 	updateInterval time.Duration,
 ) (Client, error) {
 	log.Info().Str("protocol", "tcp").Str("address", address).Msg("connecting to telemetry service")
 
-	tacviewClient := newTacviewClient(starts, updates, fades, updateInterval)
+	tacviewClient := newTacviewClient(starts, updates, fades, launches, impacts, sensors, updateInterval)
 	return &telemetryClient{
 		address:       address,
 		hostname:      clientHostname,
@@ -47,28 +58,30 @@ func NewTelemetryClient(
 	}, nil
 }
 
-// Run implements [Client.Run].
+// Run implements [Client.Run]. If the connection is lost, it reconnects with exponential backoff. When the telemetry
+// service replays the current mission to the new connection, the trackfiles are refreshed without restarting the
+// mission.
 func (c *telemetryClient) Run(ctx context.Context, wg *sync.WaitGroup) error {
+	delay := minReconnectDelay
 	for {
+		connectedAt := time.Now()
+		err := c.run(ctx, wg)
+		if err == nil || ctx.Err() != nil {
+			return nil
+		}
+		if time.Since(connectedAt) >= maxReconnectDelay {
+			delay = minReconnectDelay
+		}
+		log.Error().Err(err).Stringer("delay", delay).Msg("telemetry error, attempting to reconnect")
 		select {
 		case <-ctx.Done():
 			return nil
-		default:
-			if err := c.run(ctx, wg); err != nil {
-				log.Error().Err(err).Msg("telemetry error, attempting to reconnect")
-				time.Sleep(10 * time.Second)
-			} else {
-				return nil
-			}
+		case <-time.After(delay):
 		}
+		delay = min(2*delay, maxReconnectDelay)
 	}
 }
 
-// Time implements [Client.Time].
-func (c *telemetryClient) Time() time.Time {
-	return c.missionTime
-}
-
 func (c *telemetryClient) run(ctx context.Context, wg *sync.WaitGroup) error {
 	addr, err := net.ResolveTCPAddr("tcp", c.address)
 	if err != nil {
@@ -83,8 +96,7 @@ func (c *telemetryClient) run(ctx context.Context, wg *sync.WaitGroup) error {
 	reader := bufio.NewReader(connection)
 
 	if err := c.handshake(reader, connection, c.hostname, c.password); err != nil {
-		err := fmt.Errorf("handshake error: %w", err)
-		log.Error().Err(err).Msg("error during handshake, attempting to reconnect")
+		return fmt.Errorf("handshake error: %w", err)
 	}
 
 	source := acmi.New(reader, c.updateInterval)
@@ -28,4 +28,7 @@ const (
 	// ReferenceLatitude is the latitude of a median point in degrees.
 	// Add this to each object's latitude to get the actual latitude.
 	ReferenceLatitude = "ReferenceLatitude"
+	// Event is an event which occurred in the current frame, such as an object being destroyed.
+	// The value is the event type, the IDs of the objects involved and optional text, separated by pipes, e.g. "Destroyed|3000102|".
+	Event = "Event"
 )
@@ -0,0 +1,335 @@
+// package recorder writes the GCI's own view of the war to compressed Tacview ACMI files, for debriefing what the GCI
+// knew when it made each call.
+package recorder
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/radar"
+	"github.com/dharmab/skyeye/pkg/tacview/properties"
+	"github.com/dharmab/skyeye/pkg/tacview/tags"
+	"github.com/rs/zerolog/log"
+)
+
+// https://www.tacview.net/documentation/acmi/en/
+
+const (
+	// groupIDBase is added to the lowest object ID in a group to give the group's synthetic object ID, so that groups
+	// do not collide with real objects. A group keeps its ID from one frame to the next for as long as its lowest
+	// member remains in the group.
+	groupIDBase uint64 = 0x7F00_0000_0000_0000
+	// bullseyeIDBase is added to a coalition's ID to give the synthetic object ID of the coalition's bullseye.
+	bullseyeIDBase uint64 = 0x7E00_0000_0000_0000
+)
+
+// Source provides snapshots of the radar scope.
+type Source interface {
+	// Snapshot returns a consistent copy of the radar scope.
+	Snapshot() radar.RadarSnapshot
+}
+
+// Recorder writes a frame of trackfiles and groups from the radar scope at a regular interval, along with the requests
+// the GCI heard and the responses it gave. Each mission is written to a new file.
+type Recorder struct {
+	directory string
+	interval  time.Duration
+	source    Source
+
+	lock sync.Mutex
+	// pending are events which have not yet been written, because they arrived between frames.
+	pending []string
+	// recording is the file currently being written. Nil until the first frame of a mission.
+	recording *recording
+}
+
+// recording is a single ACMI file.
+type recording struct {
+	file    *os.File
+	archive *zip.Writer
+	w       io.Writer
+	// referenceTime is the mission time that frame offsets are relative to.
+	referenceTime time.Time
+	// labels maps the IDs of objects in the previous frame to their labels, so that removed objects can be written
+	// and unchanged labels are not repeated.
+	labels map[uint64]string
+}
+
+// New constructs a recorder which writes files to the given directory, with a frame from the given source at each
+// interval.
+func New(directory string, interval time.Duration, source Source) *Recorder {
+	return &Recorder{directory: directory, interval: interval, source: source}
+}
+
+// Run records frames until the context is cancelled, then finishes the current file.
+func (r *Recorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("stopping ACMI debrief recording due to context cancellation")
+			if err := r.Close(); err != nil {
+				log.Error().Err(err).Msg("failed to finish ACMI debrief")
+			}
+			return
+		case <-ticker.C:
+			if err := r.Record(); err != nil {
+				log.Error().Err(err).Msg("failed to record ACMI debrief frame")
+			}
+		}
+	}
+}
+
+// Heard records a request the GCI heard. It is written with the next frame.
+func (r *Recorder) Heard(text string) {
+	r.event(properties.MessageEvent, text)
+}
+
+// Said records a response or call the GCI transmitted. It is written with the next frame as a bookmark, so that it is
+// easy to find on the timeline.
+func (r *Recorder) Said(text string) {
+	r.event(properties.BookmarkEvent, text)
+}
+
+func (r *Recorder) event(kind, text string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.pending = append(r.pending, fmt.Sprintf("0,%s=%s|%s", properties.Event, kind, escape(text)))
+}
+
+// Record writes a frame with the current contents of the radar scope, starting a new file if needed.
+func (r *Recorder) Record() error {
+	snapshot := r.source.Snapshot()
+	if snapshot.MissionTime.IsZero() {
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.recording != nil && snapshot.MissionTime.Before(r.recording.referenceTime) {
+		// The mission restarted without a call to Restart.
+		if err := r.finish(); err != nil {
+			return err
+		}
+	}
+	if r.recording == nil {
+		rec, err := r.start(snapshot.MissionTime)
+		if err != nil {
+			return err
+		}
+		r.recording = rec
+	}
+	events := r.pending
+	r.pending = nil
+	return r.recording.writeFrame(snapshot, events)
+}
+
+// Restart finishes the current file. The next frame is written to a new file. This should be called when a new
+// mission starts.
+func (r *Recorder) Restart() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.pending = nil
+	return r.finish()
+}
+
+// Close finishes the current file.
+func (r *Recorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.finish()
+}
+
+// start creates a new file for a mission whose first frame is at the given mission time. Must be called with the lock
+// held.
+func (r *Recorder) start(missionTime time.Time) (*recording, error) {
+	referenceTime := missionTime.UTC().Truncate(time.Second)
+	name := "skyeye-" + referenceTime.Format("20060102-150405")
+	path := filepath.Join(r.directory, name+".zip.acmi")
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ACMI file: %w", err)
+	}
+	archive := zip.NewWriter(file)
+	w, err := archive.Create(name + ".txt.acmi")
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to create ACMI file in archive: %w", err)
+	}
+	rec := &recording{
+		file:          file,
+		archive:       archive,
+		w:             w,
+		referenceTime: referenceTime,
+		labels:        make(map[uint64]string),
+	}
+	header := []string{
+		properties.FileType + "=" + properties.FileTypeTacView,
+		properties.FileVersion + "=" + properties.FileVersion2_2,
+		fmt.Sprintf("0,%s=%s", properties.ReferenceTime, referenceTime.Format(time.RFC3339)),
+		fmt.Sprintf("0,%s=%s", properties.RecordingTime, time.Now().UTC().Format(time.RFC3339)),
+		fmt.Sprintf("0,%s=SkyEye", properties.DataSource),
+		fmt.Sprintf("0,%s=SkyEye", properties.DataRecorder),
+		fmt.Sprintf("0,%s=SkyEye GCI Debrief", properties.Title),
+		fmt.Sprintf("0,%s=0", properties.ReferenceLongitude),
+		fmt.Sprintf("0,%s=0", properties.ReferenceLatitude),
+	}
+	if err := rec.writeLines(header); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	log.Info().Str("path", path).Msg("recording ACMI debrief")
+	return rec, nil
+}
+
+// finish closes the current file, if any. Must be called with the lock held.
+func (r *Recorder) finish() error {
+	if r.recording == nil {
+		return nil
+	}
+	rec := r.recording
+	r.recording = nil
+	if err := rec.archive.Close(); err != nil {
+		_ = rec.file.Close()
+		return fmt.Errorf("failed to finish ACMI archive: %w", err)
+	}
+	if err := rec.file.Close(); err != nil {
+		return fmt.Errorf("failed to close ACMI file: %w", err)
+	}
+	log.Info().Str("path", rec.file.Name()).Msg("finished ACMI debrief")
+	return nil
+}
+
+func (rec *recording) writeLines(lines []string) error {
+	for _, line := range lines {
+		if _, err := io.WriteString(rec.w, line+"\n"); err != nil {
+			return fmt.Errorf("failed to write ACMI data: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeFrame writes the snapshot and the given events as a single frame.
+func (rec *recording) writeFrame(snapshot radar.RadarSnapshot, events []string) error {
+	offset := snapshot.MissionTime.Sub(rec.referenceTime).Seconds()
+	lines := []string{fmt.Sprintf("#%.2f", offset)}
+	lines = append(lines, events...)
+
+	labels := make(map[uint64]string)
+	object := func(id uint64, point transform, label string, static func() []string) {
+		line := fmt.Sprintf("%x,%s=%s", id, properties.Transform, point)
+		previous, ok := rec.labels[id]
+		if !ok {
+			line += "," + strings.Join(static(), ",")
+		}
+		if !ok || previous != label {
+			line += fmt.Sprintf(",%s=%s", properties.Label, escape(label))
+		}
+		labels[id] = label
+		lines = append(lines, line)
+	}
+
+	for _, coalition := range coalitions.All() {
+		bullseye, ok := snapshot.Bullseyes[coalition]
+		if !ok {
+			continue
+		}
+		object(bullseyeIDBase+uint64(coalition), transform{lon: bullseye.Lon(), lat: bullseye.Lat()}, "", func() []string {
+			return []string{
+				fmt.Sprintf("%s=%s+%s+%s", properties.Type, tags.Navaid, tags.Static, tags.Bullseye),
+				fmt.Sprintf("%s=Bullseye", properties.Name),
+				fmt.Sprintf("%s=%s", properties.Coalition, properties.CoaliationToProperty(coalition)),
+				fmt.Sprintf("%s=%s", properties.Color, color(coalition)),
+			}
+		})
+	}
+
+	for _, trackfile := range snapshot.Trackfiles {
+		frame := trackfile.LastKnown
+		label := ""
+		if trackfile.IsFaded {
+			label = "FADED"
+		}
+		object(trackfile.Contact.ID, transform{lon: frame.Point.Lon(), lat: frame.Point.Lat(), altitude: frame.Altitude.Meters()}, label, func() []string {
+			return []string{
+				fmt.Sprintf("%s=%s", properties.Type, tags.Air),
+				fmt.Sprintf("%s=%s", properties.Name, escape(trackfile.Contact.ACMIName)),
+				fmt.Sprintf("%s=%s", properties.Pilot, escape(trackfile.Contact.Name)),
+				fmt.Sprintf("%s=%s", properties.Coalition, properties.CoaliationToProperty(trackfile.Contact.Coalition)),
+				fmt.Sprintf("%s=%s", properties.Color, color(trackfile.Contact.Coalition)),
+			}
+		})
+	}
+
+	for _, coalition := range coalitions.All() {
+		for _, grp := range snapshot.Groups[coalition] {
+			if len(grp.ObjectIDs) == 0 {
+				continue
+			}
+			altitude := 0.0
+			if len(grp.Stacks) > 0 {
+				altitude = grp.Stacks[0].Altitude.Meters()
+			}
+			label := fmt.Sprintf("%d contacts, track %s", len(grp.ObjectIDs), grp.Track)
+			if len(grp.Platforms) > 0 {
+				label += ", " + strings.Join(grp.Platforms, "/")
+			}
+			object(groupIDBase+slices.Min(grp.ObjectIDs), transform{lon: grp.Point.Lon(), lat: grp.Point.Lat(), altitude: altitude}, label, func() []string {
+				return []string{
+					fmt.Sprintf("%s=%s+%s", properties.Type, tags.Navaid, tags.Waypoint),
+					fmt.Sprintf("%s=Group", properties.Name),
+					fmt.Sprintf("%s=%s", properties.Coalition, properties.CoaliationToProperty(coalition)),
+					fmt.Sprintf("%s=%s", properties.Color, color(coalition)),
+				}
+			})
+		}
+	}
+
+	for id := range rec.labels {
+		if _, ok := labels[id]; !ok {
+			lines = append(lines, fmt.Sprintf("-%x", id))
+		}
+	}
+	rec.labels = labels
+	return rec.writeLines(lines)
+}
+
+// transform is an object's position, relative to a reference point of 0° latitude and longitude.
+type transform struct {
+	lon      float64
+	lat      float64
+	altitude float64
+}
+
+func (t transform) String() string {
+	return fmt.Sprintf("%.7f|%.7f|%.1f", t.lon, t.lat, t.altitude)
+}
+
+// color returns the Tacview color of a coalition.
+func color(coalition coalitions.Coalition) string {
+	switch coalition {
+	case coalitions.Red:
+		return "Red"
+	case coalitions.Blue:
+		return "Blue"
+	default:
+		return "Grey"
+	}
+}
+
+// escape escapes commas and line breaks in a property value.
+func escape(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, ",", `\,`)
+	return strings.ReplaceAll(value, "\n", "\\\n")
+}
@@ -0,0 +1,154 @@
+package recorder
+
+import (
+	"archive/zip"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/radar"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSource struct {
+	snapshot radar.RadarSnapshot
+}
+
+func (s *mockSource) Snapshot() radar.RadarSnapshot {
+	return s.snapshot
+}
+
+func track(id uint64, name string, coalition coalitions.Coalition, isFaded bool) radar.TrackfileSnapshot {
+	return radar.TrackfileSnapshot{
+		Contact: trackfiles.Labels{ID: id, Name: name, Coalition: coalition, ACMIName: "F-15C"},
+		LastKnown: trackfiles.Frame{
+			Point:    orb.Point{33, 34},
+			Altitude: 1000 * unit.Meter,
+		},
+		IsFaded: isFaded,
+	}
+}
+
+// readACMI returns the lines of the single ACMI file in the given directory.
+func readACMI(t *testing.T, directory string) []string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(directory, "*.zip.acmi"))
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	assert.Equal(t, "skyeye-20240101-120000.zip.acmi", filepath.Base(paths[0]))
+
+	archive, err := zip.OpenReader(paths[0])
+	require.NoError(t, err)
+	defer archive.Close()
+	require.Len(t, archive.File, 1)
+	assert.Equal(t, "skyeye-20240101-120000.txt.acmi", archive.File[0].Name)
+	r, err := archive.File[0].Open()
+	require.NoError(t, err)
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+	directory := t.TempDir()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	source := &mockSource{}
+	recorder := New(directory, time.Second, source)
+
+	// Nothing is recorded until the mission time is known.
+	require.NoError(t, recorder.Record())
+	require.NoError(t, recorder.Close())
+	paths, err := filepath.Glob(filepath.Join(directory, "*"))
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+
+	source.snapshot = radar.RadarSnapshot{
+		MissionTime: start,
+		Bullseyes:   map[coalitions.Coalition]orb.Point{coalitions.Blue: {33, 33}},
+		Trackfiles: []radar.TrackfileSnapshot{
+			track(0x101, "Mobius 1", coalitions.Blue, false),
+			track(0x202, "Yellow 13, Lead", coalitions.Red, false),
+		},
+		Groups: map[coalitions.Coalition][]radar.GroupSnapshot{
+			coalitions.Red: {
+				{
+					ObjectIDs: []uint64{0x202},
+					Point:     orb.Point{33, 34},
+					Stacks:    []brevity.Stack{{Altitude: 1000 * unit.Meter, Count: 1}},
+					Track:     brevity.North,
+					Platforms: []string{"Flanker"},
+				},
+			},
+		},
+	}
+	recorder.Heard("anyface, mobius 1, request picture")
+	require.NoError(t, recorder.Record())
+
+	source.snapshot.MissionTime = start.Add(1500 * time.Millisecond)
+	source.snapshot.Trackfiles = []radar.TrackfileSnapshot{
+		track(0x101, "Mobius 1", coalitions.Blue, true),
+	}
+	source.snapshot.Groups = nil
+	recorder.Said("Mobius 1, Focus, single group")
+	require.NoError(t, recorder.Record())
+	require.NoError(t, recorder.Close())
+
+	lines := readACMI(t, directory)
+	require.Len(t, lines, 21)
+	assert.Equal(t, "FileType=text/acmi/tacview", lines[0])
+	assert.Equal(t, "FileVersion=2.2", lines[1])
+	assert.Equal(t, "0,ReferenceTime=2024-01-01T12:00:00Z", lines[2])
+	assert.Equal(t, []string{
+		"#0.00",
+		"0,Event=Message|anyface\\, mobius 1\\, request picture",
+		"7e00000000000002,T=33.0000000|33.0000000|0.0,Type=Navaid+Static+Bullseye,Name=Bullseye,Coalition=Enemies,Color=Blue,Label=",
+		"101,T=33.0000000|34.0000000|1000.0,Type=Air,Name=F-15C,Pilot=Mobius 1,Coalition=Enemies,Color=Blue,Label=",
+		"202,T=33.0000000|34.0000000|1000.0,Type=Air,Name=F-15C,Pilot=Yellow 13\\, Lead,Coalition=Allies,Color=Red,Label=",
+		"7f00000000000202,T=33.0000000|34.0000000|1000.0,Type=Navaid+Waypoint,Name=Group,Coalition=Allies,Color=Red,Label=1 contacts\\, track north\\, Flanker",
+		"#1.50",
+		"0,Event=Bookmark|Mobius 1\\, Focus\\, single group",
+		"7e00000000000002,T=33.0000000|33.0000000|0.0",
+		"101,T=33.0000000|34.0000000|1000.0,Label=FADED",
+	}, lines[9:19])
+	assert.ElementsMatch(t, []string{"-202", "-7f00000000000202"}, lines[19:])
+}
+
+func TestRecorderRestart(t *testing.T) {
+	t.Parallel()
+	directory := t.TempDir()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	source := &mockSource{snapshot: radar.RadarSnapshot{MissionTime: start.Add(time.Hour)}}
+	recorder := New(directory, time.Second, source)
+
+	require.NoError(t, recorder.Record())
+	recorder.Heard("discarded")
+	require.NoError(t, recorder.Restart())
+	source.snapshot.MissionTime = start
+	require.NoError(t, recorder.Record())
+	require.NoError(t, recorder.Close())
+
+	paths, err := filepath.Glob(filepath.Join(directory, "*.zip.acmi"))
+	require.NoError(t, err)
+	require.Len(t, paths, 2)
+	for _, path := range paths {
+		archive, err := zip.OpenReader(path)
+		require.NoError(t, err)
+		r, err := archive.File[0].Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.NotContains(t, string(b), "discarded")
+		require.NoError(t, r.Close())
+		require.NoError(t, archive.Close())
+	}
+}
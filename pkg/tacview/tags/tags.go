@@ -6,6 +6,7 @@ const (
 	Sea             = "Sea"
 	Weapon          = "Weapon"
 	Sensor          = "Sensor"
+	Navaid          = "Navaid"
 	Static          = "Static"
 	Heavy           = "Heavy"
 	Medium          = "Medium"
@@ -0,0 +1,98 @@
+// Package tankers identifies friendly tanker aircraft and the TACAN channels and frequencies configured for them.
+package tankers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/encyclopedia"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+)
+
+// Tanker is the TACAN channel and radio frequency of the tankers with a callsign.
+type Tanker struct {
+	// Callsign of the tanker, e.g. "Texaco". This matches any aircraft whose name begins with the callsign, e.g.
+	// "Texaco 1-1".
+	Callsign string
+	// TACAN channel of the tanker, e.g. "51X". Empty if not configured.
+	TACAN string
+	// Frequency the tanker monitors for refueling. Zero if not configured.
+	Frequency unit.Frequency
+}
+
+// tacanPattern matches a TACAN channel from 1 to 126 in the X or Y band.
+var tacanPattern = regexp.MustCompile(`^([1-9]|[1-9][0-9]|1[01][0-9]|12[0-6])[XY]$`)
+
+// Parse parses a tanker in the format CALLSIGN:TACAN:FREQUENCY, with the frequency in MHz. The TACAN channel and
+// frequency may be empty. For example, "Texaco:51X:251.0" or "Arco::252.5".
+func Parse(s string) (Tanker, error) {
+	fields := strings.Split(s, ":")
+	if len(fields) > 3 {
+		return Tanker{}, fmt.Errorf("tanker %q must be in the format CALLSIGN:TACAN:FREQUENCY", s)
+	}
+	tanker := Tanker{Callsign: strings.TrimSpace(fields[0])}
+	if tanker.Callsign == "" {
+		return Tanker{}, fmt.Errorf("tanker %q must have a callsign", s)
+	}
+	if len(fields) > 1 {
+		tanker.TACAN = strings.ToUpper(strings.TrimSpace(fields[1]))
+		if tanker.TACAN != "" && !tacanPattern.MatchString(tanker.TACAN) {
+			return Tanker{}, fmt.Errorf("tanker TACAN channel %q must be a channel from 1 to 126 followed by X or Y", tanker.TACAN)
+		}
+	}
+	if len(fields) > 2 {
+		if field := strings.TrimSpace(fields[2]); field != "" {
+			megahertz, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return Tanker{}, fmt.Errorf("failed to parse tanker frequency: %w", err)
+			}
+			if megahertz <= 0 {
+				return Tanker{}, fmt.Errorf("tanker frequency %v must be positive", megahertz)
+			}
+			tanker.Frequency = unit.Frequency(megahertz) * unit.Megahertz
+		}
+	}
+	return tanker, nil
+}
+
+// Registry identifies tankers and looks up their configured details.
+type Registry struct {
+	tankers []Tanker
+}
+
+// NewRegistry constructs a registry with the given configured tankers. A nil registry identifies tankers using only
+// the encyclopedia.
+func NewRegistry(tankers ...Tanker) *Registry {
+	return &Registry{tankers: tankers}
+}
+
+// IsTanker returns true if the trackfile is a tanker aircraft in the encyclopedia, or matches a configured tanker
+// callsign.
+func (r *Registry) IsTanker(trackfile *trackfiles.Trackfile) bool {
+	if data, ok := encyclopedia.GetAircraftData(trackfile.Contact.ACMIName); ok && data.HasTag(encyclopedia.Tanker) {
+		return true
+	}
+	_, ok := r.Lookup(trackfile.Contact.Name)
+	return ok
+}
+
+// Lookup returns the configured details of the tanker with the given name. A callsign matches names which begin with
+// it, e.g. "Texaco" matches "Texaco 1" and "Texaco 1-1", but not "Texacola 1". The second return value is false if no
+// configured callsign matches the name.
+func (r *Registry) Lookup(name string) (Tanker, bool) {
+	if r == nil {
+		return Tanker{}, false
+	}
+	name, _, _ = strings.Cut(name, "|")
+	name = strings.ToLower(strings.Join(strings.Fields(name), " ")) + " "
+	for _, tanker := range r.tankers {
+		callsign := strings.ToLower(strings.Join(strings.Fields(tanker.Callsign), " "))
+		if callsign != "" && strings.HasPrefix(name, callsign+" ") {
+			return tanker, true
+		}
+	}
+	return Tanker{}, false
+}
@@ -0,0 +1,68 @@
+package tankers
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	tanker, err := Parse("Texaco:51X:251.0")
+	require.NoError(t, err)
+	assert.Equal(t, "Texaco", tanker.Callsign)
+	assert.Equal(t, "51X", tanker.TACAN)
+	assert.InDelta(t, 251.0, tanker.Frequency.Megahertz(), 0.001)
+
+	tanker, err = Parse("Arco::252.5")
+	require.NoError(t, err)
+	assert.Empty(t, tanker.TACAN)
+	assert.InDelta(t, 252.5, tanker.Frequency.Megahertz(), 0.001)
+
+	tanker, err = Parse("Shell:126y")
+	require.NoError(t, err)
+	assert.Equal(t, "126Y", tanker.TACAN)
+	assert.Zero(t, tanker.Frequency)
+
+	for _, s := range []string{"", ":51X:251.0", "Texaco:0X", "Texaco:127X", "Texaco:51Z", "Texaco:51X:x", "Texaco:51X:-1", "Texaco:51X:251.0:extra"} {
+		_, err := Parse(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+	texaco := Tanker{Callsign: "Texaco", TACAN: "51X", Frequency: 251 * unit.Megahertz}
+	registry := NewRegistry(texaco)
+
+	tanker, ok := registry.Lookup("Texaco 1-1")
+	require.True(t, ok)
+	assert.Equal(t, texaco, tanker)
+	_, ok = registry.Lookup("Texacola 1")
+	assert.False(t, ok)
+
+	testCases := []struct {
+		name     string
+		acmiName string
+		expected bool
+	}{
+		{name: "Arco 1", acmiName: "KC135MPRS", expected: true},
+		{name: "Mobius 1", acmiName: "S-3B Tanker", expected: true},
+		{name: "Texaco 2", acmiName: "C-130", expected: true},
+		{name: "Mobius 1", acmiName: "S-3B", expected: false},
+		{name: "Mobius 1", acmiName: "F-15C", expected: false},
+	}
+	for _, test := range testCases {
+		trackfile := trackfiles.NewTrackfile(trackfiles.Labels{
+			ID:        1,
+			Name:      test.name,
+			Coalition: coalitions.Blue,
+			ACMIName:  test.acmiName,
+		})
+		assert.Equal(t, test.expected, registry.IsTanker(trackfile), test.acmiName)
+	}
+}
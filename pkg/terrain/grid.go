@@ -0,0 +1,180 @@
+package terrain
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+// Grid is a terrain elevation model sampled on a regular grid of longitude and latitude. Elevations between samples
+// are interpolated.
+type Grid struct {
+	// cols and rows are the number of samples from west to east and from south to north.
+	cols, rows int
+	// west and south are the longitude and latitude of the southwesternmost sample, in degrees.
+	west, south float64
+	// spacing is the distance between adjacent samples, in degrees.
+	spacing float64
+	// elevations are the sampled elevations in meters, in rows from south to north. NaN marks a missing sample.
+	elevations []float64
+}
+
+var _ Model = &Grid{}
+
+// LoadGrid reads a terrain elevation model from an ESRI ASCII grid file. Most GIS tools can export this format, e.g.
+// `gdal_translate -of AAIGrid`. The grid must use WGS 84 longitude and latitude in degrees, and elevations in meters.
+func LoadGrid(path string) (*Grid, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open terrain grid: %w", err)
+	}
+	defer f.Close()
+	grid, err := ReadGrid(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read terrain grid %s: %w", path, err)
+	}
+	return grid, nil
+}
+
+// ReadGrid reads a terrain elevation model in ESRI ASCII grid format. See [LoadGrid].
+func ReadGrid(r io.Reader) (*Grid, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	scanner.Split(bufio.ScanWords)
+
+	header := make(map[string]float64)
+	var word string
+	for scanner.Scan() {
+		word = scanner.Text()
+		key := strings.ToLower(word)
+		if !isHeaderKey(key) {
+			break
+		}
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("missing value for %s", word)
+		}
+		value, err := strconv.ParseFloat(scanner.Text(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", word, err)
+		}
+		header[key] = value
+		word = ""
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	grid := &Grid{}
+	for _, key := range []string{"ncols", "nrows", "cellsize"} {
+		if _, ok := header[key]; !ok {
+			return nil, fmt.Errorf("missing %s", key)
+		}
+	}
+	grid.cols = int(header["ncols"])
+	grid.rows = int(header["nrows"])
+	grid.spacing = header["cellsize"]
+	if grid.cols < 1 || grid.rows < 1 {
+		return nil, errors.New("ncols and nrows must be positive")
+	}
+	if grid.spacing <= 0 {
+		return nil, errors.New("cellsize must be positive")
+	}
+	// The origin may be given as either the corner or the center of the southwesternmost cell.
+	if x, ok := header["xllcenter"]; ok {
+		grid.west = x
+	} else if x, ok := header["xllcorner"]; ok {
+		grid.west = x + grid.spacing/2
+	} else {
+		return nil, errors.New("missing xllcorner or xllcenter")
+	}
+	if y, ok := header["yllcenter"]; ok {
+		grid.south = y
+	} else if y, ok := header["yllcorner"]; ok {
+		grid.south = y + grid.spacing/2
+	} else {
+		return nil, errors.New("missing yllcorner or yllcenter")
+	}
+	noData, hasNoData := header["nodata_value"]
+
+	// Rows are listed from north to south.
+	grid.elevations = make([]float64, grid.cols*grid.rows)
+	for i := range grid.elevations {
+		if word == "" {
+			if !scanner.Scan() {
+				if err := scanner.Err(); err != nil {
+					return nil, err
+				}
+				return nil, fmt.Errorf("expected %d samples, found %d", len(grid.elevations), i)
+			}
+			word = scanner.Text()
+		}
+		value, err := strconv.ParseFloat(word, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sample: %w", err)
+		}
+		word = ""
+		if hasNoData && value == noData {
+			value = math.NaN()
+		}
+		row := grid.rows - 1 - i/grid.cols
+		grid.elevations[row*grid.cols+i%grid.cols] = value
+	}
+	return grid, nil
+}
+
+// isHeaderKey returns true if the given lowercase word is a key in an ESRI ASCII grid header.
+func isHeaderKey(key string) bool {
+	switch key {
+	case "ncols", "nrows", "xllcorner", "xllcenter", "yllcorner", "yllcenter", "cellsize", "nodata_value":
+		return true
+	}
+	return false
+}
+
+// Elevation implements [Model.Elevation].
+func (g *Grid) Elevation(point orb.Point) (unit.Length, bool) {
+	x := (point.Lon() - g.west) / g.spacing
+	y := (point.Lat() - g.south) / g.spacing
+	if x < 0 || y < 0 || x > float64(g.cols-1) || y > float64(g.rows-1) {
+		return 0, false
+	}
+	col, row := int(x), int(y)
+	nextCol, nextRow := min(col+1, g.cols-1), min(row+1, g.rows-1)
+	dx, dy := x-float64(col), y-float64(row)
+
+	// Bilinear interpolation between the four surrounding samples. Samples which do not contribute are skipped, so
+	// that a point exactly on a sample or along an edge next to a missing sample still has a known elevation.
+	elevation := 0.0
+	for _, s := range []struct {
+		col, row int
+		weight   float64
+	}{
+		{col, row, (1 - dx) * (1 - dy)},
+		{nextCol, row, dx * (1 - dy)},
+		{col, nextRow, (1 - dx) * dy},
+		{nextCol, nextRow, dx * dy},
+	} {
+		if s.weight == 0 {
+			continue
+		}
+		sample := g.sample(s.col, s.row)
+		if math.IsNaN(sample) {
+			return 0, false
+		}
+		elevation += sample * s.weight
+	}
+	return unit.Length(elevation) * unit.Meter, true
+}
+
+// sample returns the sampled elevation at the given column and row, or NaN if the sample is missing.
+func (g *Grid) sample(col, row int) float64 {
+	return g.elevations[row*g.cols+col]
+}
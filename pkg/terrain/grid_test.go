@@ -0,0 +1,80 @@
+package terrain
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGrid = `ncols 3
+nrows 2
+xllcorner 41.5
+yllcorner 42.5
+cellsize 1
+NODATA_value -9999
+100 200 -9999
+0 100 300
+`
+
+func TestReadGrid(t *testing.T) {
+	t.Parallel()
+	grid, err := ReadGrid(strings.NewReader(testGrid))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		point    orb.Point
+		expected float64
+		ok       bool
+	}{
+		{name: "southwest sample", point: orb.Point{42, 43}, expected: 0, ok: true},
+		{name: "northwest sample", point: orb.Point{42, 44}, expected: 100, ok: true},
+		{name: "between samples", point: orb.Point{42.5, 43.5}, expected: 100, ok: true},
+		{name: "along edge", point: orb.Point{43.5, 43}, expected: 200, ok: true},
+		{name: "next to missing sample", point: orb.Point{43.5, 43.5}, ok: false},
+		{name: "outside", point: orb.Point{41, 43}, ok: false},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			elevation, ok := grid.Elevation(test.point)
+			assert.Equal(t, test.ok, ok)
+			if test.ok {
+				assert.InDelta(t, test.expected, elevation.Meters(), 0.001)
+			}
+		})
+	}
+}
+
+func TestReadGridInvalid(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]string{
+		"missing header":  "ncols 2\nnrows 1\n1 2\n",
+		"too few samples": "ncols 2\nnrows 2\nxllcenter 0\nyllcenter 0\ncellsize 1\n1 2 3\n",
+		"invalid sample":  "ncols 1\nnrows 1\nxllcenter 0\nyllcenter 0\ncellsize 1\nhigh\n",
+		"invalid size":    "ncols 0\nnrows 1\nxllcenter 0\nyllcenter 0\ncellsize 1\n",
+	}
+	for name, data := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ReadGrid(strings.NewReader(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadGrid(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "terrain.asc")
+	require.NoError(t, os.WriteFile(path, []byte(testGrid), 0o600))
+	grid, err := LoadGrid(path)
+	require.NoError(t, err)
+	elevation, ok := grid.Elevation(orb.Point{44, 43})
+	assert.True(t, ok)
+	assert.InDelta(t, 300, elevation.Meters(), 0.001)
+}
@@ -0,0 +1,15 @@
+// package terrain provides terrain elevation, so that the altitude of low flying aircraft can be reported relative to
+// the ground beneath them.
+package terrain
+
+import (
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+// Model provides terrain elevation.
+type Model interface {
+	// Elevation returns the elevation of the terrain above sea level at the given point. The second return value is
+	// false if the elevation at the point is unknown.
+	Elevation(orb.Point) (unit.Length, bool)
+}
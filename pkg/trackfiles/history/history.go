@@ -0,0 +1,301 @@
+// package history writes every trackfile update to compressed JSON Lines files, so that the GCI's picture can be
+// replayed after the mission.
+package history
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// prefix and extension are the start and end of the name of each history file.
+	prefix    = "trackfiles-"
+	extension = ".jsonl.gz"
+	// queueLength is the number of updates which may wait to be written before further updates are dropped.
+	queueLength = 4096
+)
+
+// Entry is a single trackfile update. Each line of a history file is one entry.
+type Entry struct {
+	// Time is the mission time of the update.
+	Time time.Time `json:"time"`
+	// ID is the object ID of the aircraft.
+	ID uint64 `json:"id"`
+	// Name is the name of the unit. For players, this is the player's in-game name.
+	Name string `json:"name"`
+	// Coalition is the name of the aircraft's coalition, such as "Red".
+	Coalition string `json:"coalition"`
+	// Aircraft is the ACMI name of the aircraft type, such as "F-16C_50".
+	Aircraft string `json:"aircraft"`
+	// Lat is the latitude of the aircraft in degrees.
+	Lat float64 `json:"lat"`
+	// Lon is the longitude of the aircraft in degrees.
+	Lon float64 `json:"lon"`
+	// AltitudeFeet is the altitude above sea level.
+	AltitudeFeet float64 `json:"altitudeFeet"`
+	// CourseDegrees is the magnetic course over the ground.
+	CourseDegrees float64 `json:"courseDegrees"`
+	// SpeedKnots is the ground speed.
+	SpeedKnots float64 `json:"speedKnots"`
+}
+
+// NewEntry returns an entry for the trackfile's most recent update.
+func NewEntry(trackfile *trackfiles.Trackfile) Entry {
+	frame := trackfile.LastKnown()
+	return Entry{
+		Time:          frame.Time,
+		ID:            trackfile.Contact.ID,
+		Name:          trackfile.Contact.Name,
+		Coalition:     trackfile.Contact.Coalition.String(),
+		Aircraft:      trackfile.Contact.ACMIName,
+		Lat:           frame.Point.Lat(),
+		Lon:           frame.Point.Lon(),
+		AltitudeFeet:  frame.Altitude.Feet(),
+		CourseDegrees: trackfile.Course().Degrees(),
+		SpeedKnots:    trackfile.Speed().Knots(),
+	}
+}
+
+// Writer streams trackfile updates to gzip-compressed JSON Lines files. Each mission is written to a new file, and a
+// file is rotated once it reaches the maximum file size. The oldest files are deleted to keep the directory under the
+// maximum total size.
+type Writer struct {
+	directory    string
+	maxFileSize  int64
+	maxTotalSize int64
+	queue        chan Entry
+
+	lock sync.Mutex
+	// current is the file currently being written. Nil until the first update of a mission.
+	current *file
+	// dropped counts the updates dropped since the last write because the queue was full.
+	dropped int
+}
+
+// file is a single history file.
+type file struct {
+	file    *os.File
+	counter *countingWriter
+	gzip    *gzip.Writer
+	encoder *json.Encoder
+	// missionStart is the mission time of the first update written to the mission's first file.
+	missionStart time.Time
+}
+
+// New constructs a writer which writes files to the given directory. A file is rotated once it reaches maxFileSize
+// bytes, and the oldest files are deleted once the directory's history files exceed maxTotalSize bytes. Either limit is
+// disabled if zero.
+func New(directory string, maxFileSize, maxTotalSize int64) *Writer {
+	return &Writer{
+		directory:    directory,
+		maxFileSize:  maxFileSize,
+		maxTotalSize: maxTotalSize,
+		queue:        make(chan Entry, queueLength),
+	}
+}
+
+// Record queues the trackfile's most recent update to be written. It never blocks; if the writer has fallen behind,
+// the update is dropped.
+func (w *Writer) Record(trackfile *trackfiles.Trackfile) {
+	entry := NewEntry(trackfile)
+	select {
+	case w.queue <- entry:
+	default:
+		w.lock.Lock()
+		w.dropped++
+		w.lock.Unlock()
+	}
+}
+
+// Run writes queued updates until the context is cancelled, then finishes the current file.
+func (w *Writer) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("stopping trackfile history due to context cancellation")
+			if err := w.Close(); err != nil {
+				log.Error().Err(err).Msg("failed to finish trackfile history")
+			}
+			return
+		case entry := <-w.queue:
+			if err := w.Write(entry); err != nil {
+				log.Error().Err(err).Msg("failed to write trackfile history")
+			}
+		}
+	}
+}
+
+// Write writes an update, starting a new file if needed.
+func (w *Writer) Write(entry Entry) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.dropped > 0 {
+		log.Warn().Int("count", w.dropped).Msg("dropped trackfile updates because the history writer fell behind")
+		w.dropped = 0
+	}
+	if w.current != nil && entry.Time.Before(w.current.missionStart) {
+		// The mission restarted without a call to Restart.
+		if err := w.finish(); err != nil {
+			return err
+		}
+	}
+	if w.current != nil && w.maxFileSize > 0 && w.current.counter.n >= w.maxFileSize {
+		missionStart := w.current.missionStart
+		if err := w.finish(); err != nil {
+			return err
+		}
+		if err := w.start(missionStart); err != nil {
+			return err
+		}
+	}
+	if w.current == nil {
+		if err := w.start(entry.Time); err != nil {
+			return err
+		}
+	}
+	if err := w.current.encoder.Encode(entry); err != nil {
+		return fmt.Errorf("failed to write trackfile history: %w", err)
+	}
+	return nil
+}
+
+// Restart finishes the current file. The next update is written to a new file. This should be called when a new
+// mission starts.
+func (w *Writer) Restart() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.finish()
+}
+
+// Close finishes the current file.
+func (w *Writer) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.finish()
+}
+
+// start creates the next file for the mission which started at the given mission time, then deletes the oldest files
+// if the directory is over the size limit. Must be called with the lock held.
+func (w *Writer) start(missionStart time.Time) error {
+	name := prefix + missionStart.UTC().Format("20060102-150405")
+	var f *os.File
+	var path string
+	for part := 1; ; part++ {
+		path = filepath.Join(w.directory, fmt.Sprintf("%s-%03d%s", name, part, extension))
+		var err error
+		f, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("failed to create trackfile history file: %w", err)
+		}
+	}
+	counter := &countingWriter{w: f}
+	compressor := gzip.NewWriter(counter)
+	w.current = &file{
+		file:         f,
+		counter:      counter,
+		gzip:         compressor,
+		encoder:      json.NewEncoder(compressor),
+		missionStart: missionStart,
+	}
+	log.Info().Str("path", path).Msg("recording trackfile history")
+	w.prune()
+	return nil
+}
+
+// finish closes the current file, if any. Must be called with the lock held.
+func (w *Writer) finish() error {
+	if w.current == nil {
+		return nil
+	}
+	current := w.current
+	w.current = nil
+	if err := current.gzip.Close(); err != nil {
+		_ = current.file.Close()
+		return fmt.Errorf("failed to finish trackfile history file: %w", err)
+	}
+	if err := current.file.Close(); err != nil {
+		return fmt.Errorf("failed to close trackfile history file: %w", err)
+	}
+	log.Info().Str("path", current.file.Name()).Msg("finished trackfile history file")
+	return nil
+}
+
+// prune deletes the oldest history files until the total size of the directory's history files is under the limit.
+// The current file is never deleted. Must be called with the lock held.
+func (w *Writer) prune() {
+	if w.maxTotalSize <= 0 {
+		return
+	}
+	entries, err := os.ReadDir(w.directory)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list trackfile history files")
+		return
+	}
+	type historyFile struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	files := make([]historyFile, 0, len(entries))
+	var total int64
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) || !strings.HasSuffix(entry.Name(), extension) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, historyFile{path: filepath.Join(w.directory, entry.Name()), size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+	}
+	slices.SortFunc(files, func(a, b historyFile) int {
+		if c := a.modTime.Compare(b.modTime); c != 0 {
+			return c
+		}
+		return strings.Compare(a.path, b.path)
+	})
+	for _, f := range files {
+		if total <= w.maxTotalSize {
+			return
+		}
+		if w.current != nil && f.path == w.current.file.Name() {
+			continue
+		}
+		if err := os.Remove(f.path); err != nil {
+			log.Error().Err(err).Str("path", f.path).Msg("failed to delete old trackfile history file")
+			continue
+		}
+		log.Info().Str("path", f.path).Msg("deleted old trackfile history file")
+		total -= f.size
+	}
+}
+
+// countingWriter counts the bytes written to the underlying file, so that the compressed size of a file is known
+// without flushing the compressor.
+type countingWriter struct {
+	w *os.File
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
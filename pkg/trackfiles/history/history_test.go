@@ -0,0 +1,116 @@
+package history
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/trackfiles"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var missionStart = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func entry(id uint64, offset time.Duration) Entry {
+	return Entry{Time: missionStart.Add(offset), ID: id, Name: "Mobius 1", Coalition: "Blue", Aircraft: "F-15C"}
+}
+
+// readEntries returns the entries in the history file at the given path.
+func readEntries(t *testing.T, path string) []Entry {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	entries := make([]Entry, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var e Entry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		entries = append(entries, e)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
+
+func TestNewEntry(t *testing.T) {
+	t.Parallel()
+	trackfile := trackfiles.NewTrackfile(trackfiles.Labels{ID: 1, Name: "Mobius 1", Coalition: coalitions.Blue, ACMIName: "F-15C"})
+	trackfile.Update(trackfiles.Frame{Time: missionStart, Point: orb.Point{33, 34}, Altitude: 20000 * unit.Foot})
+	e := NewEntry(trackfile)
+	assert.Equal(t, missionStart, e.Time)
+	assert.Equal(t, uint64(1), e.ID)
+	assert.Equal(t, "Blue", e.Coalition)
+	assert.Equal(t, "F-15C", e.Aircraft)
+	assert.InDelta(t, 34, e.Lat, 0.0001)
+	assert.InDelta(t, 33, e.Lon, 0.0001)
+	assert.InDelta(t, 20000, e.AltitudeFeet, 1)
+}
+
+func TestWriter(t *testing.T) {
+	t.Parallel()
+	directory := t.TempDir()
+	w := New(directory, 0, 0)
+	require.NoError(t, w.Write(entry(1, 0)))
+	require.NoError(t, w.Write(entry(2, time.Second)))
+	require.NoError(t, w.Restart())
+	// A second mission which starts at the same mission time is written to a new file.
+	require.NoError(t, w.Write(entry(3, 0)))
+	// The mission restarts without a call to Restart.
+	require.NoError(t, w.Write(entry(4, -time.Hour)))
+	require.NoError(t, w.Close())
+
+	first := readEntries(t, filepath.Join(directory, "trackfiles-20240101-120000-001.jsonl.gz"))
+	assert.Equal(t, []Entry{entry(1, 0), entry(2, time.Second)}, first)
+	second := readEntries(t, filepath.Join(directory, "trackfiles-20240101-120000-002.jsonl.gz"))
+	assert.Equal(t, []Entry{entry(3, 0)}, second)
+	third := readEntries(t, filepath.Join(directory, "trackfiles-20240101-110000-001.jsonl.gz"))
+	assert.Equal(t, []Entry{entry(4, -time.Hour)}, third)
+}
+
+func TestWriterRotation(t *testing.T) {
+	t.Parallel()
+	directory := t.TempDir()
+	// Every file is over the size limit as soon as the compressor writes its header, so each update rotates the file.
+	w := New(directory, 1, 0)
+	for i := range 3 {
+		require.NoError(t, w.Write(entry(uint64(i), time.Duration(i)*time.Second)))
+	}
+	require.NoError(t, w.Close())
+
+	paths, err := filepath.Glob(filepath.Join(directory, "*.jsonl.gz"))
+	require.NoError(t, err)
+	require.Len(t, paths, 3)
+	for i, path := range paths {
+		assert.Equal(t, []Entry{entry(uint64(i), time.Duration(i)*time.Second)}, readEntries(t, path))
+	}
+}
+
+func TestWriterPrune(t *testing.T) {
+	t.Parallel()
+	directory := t.TempDir()
+	unrelated := filepath.Join(directory, "notes.txt")
+	require.NoError(t, os.WriteFile(unrelated, make([]byte, 1024), 0o600))
+
+	w := New(directory, 1, 1)
+	for i := range 3 {
+		require.NoError(t, w.Write(entry(uint64(i), time.Duration(i)*time.Second)))
+	}
+	require.NoError(t, w.Close())
+
+	paths, err := filepath.Glob(filepath.Join(directory, "*.jsonl.gz"))
+	require.NoError(t, err)
+	// Only the newest file survives, since any two files are over the limit.
+	require.Len(t, paths, 1)
+	assert.Equal(t, []Entry{entry(2, 2*time.Second)}, readEntries(t, paths[0]))
+	assert.FileExists(t, unrelated)
+}
@@ -0,0 +1,44 @@
+package trackfiles
+
+import (
+	"slices"
+	"strings"
+)
+
+// NormalizeTag converts a tag to upper case and collapses whitespace, so that "do  not engage" and "DO NOT ENGAGE"
+// are the same tag.
+func NormalizeTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToUpper(tag)), " ")
+}
+
+// SetTags replaces the trackfile's tags. Tags are normalized with [NormalizeTag], and empty tags are ignored.
+func (t *Trackfile) SetTags(tags ...string) {
+	t.tagsLock.Lock()
+	defer t.tagsLock.Unlock()
+	t.tags = make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		if tag = NormalizeTag(tag); tag != "" {
+			t.tags[tag] = struct{}{}
+		}
+	}
+}
+
+// Tags returns the trackfile's tags in alphabetical order.
+func (t *Trackfile) Tags() []string {
+	t.tagsLock.RLock()
+	defer t.tagsLock.RUnlock()
+	tags := make([]string, 0, len(t.tags))
+	for tag := range t.tags {
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+// HasTag returns true if the trackfile has the given tag.
+func (t *Trackfile) HasTag(tag string) bool {
+	t.tagsLock.RLock()
+	defer t.tagsLock.RUnlock()
+	_, ok := t.tags[NormalizeTag(tag)]
+	return ok
+}
@@ -0,0 +1,43 @@
+package trackfiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		tag      string
+		expected string
+	}{
+		{"HVAA", "HVAA"},
+		{"hvaa", "HVAA"},
+		{" do  not engage ", "DO NOT ENGAGE"},
+		{"", ""},
+		{"   ", ""},
+	}
+	for _, test := range testCases {
+		t.Run(test.tag, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, NormalizeTag(test.tag))
+		})
+	}
+}
+
+func TestTags(t *testing.T) {
+	t.Parallel()
+	trackfile := NewTrackfile(Labels{ID: 1, Name: "Magic 1", ACMIName: "E-3A"})
+	assert.Empty(t, trackfile.Tags())
+
+	trackfile.SetTags("hvaa", "escort", "", "HVAA")
+	assert.Equal(t, []string{"ESCORT", "HVAA"}, trackfile.Tags())
+	assert.True(t, trackfile.HasTag("HVAA"))
+	assert.True(t, trackfile.HasTag("Escort"))
+	assert.False(t, trackfile.HasTag("do not engage"))
+
+	trackfile.SetTags()
+	assert.Empty(t, trackfile.Tags())
+	assert.False(t, trackfile.HasTag("HVAA"))
+}
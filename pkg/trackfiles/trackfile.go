@@ -4,6 +4,7 @@ package trackfiles
 import (
 	"fmt"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/dharmab/skyeye/pkg/bearings"
@@ -36,6 +37,13 @@ type Trackfile struct {
 	Contact Labels
 	// track is a collection of frames, ordered from most recent to least recent.
 	track deque.Deque[Frame]
+	// trendHistory is a sparser collection of frames covering a longer period, ordered from most recent to least
+	// recent. It is used to measure how the track's motion is changing without being misled by momentary jinks.
+	trendHistory deque.Deque[Frame]
+	// tags are annotations attached to the trackfile by external systems, such as "HVAA".
+	tags map[string]struct{}
+	// tagsLock protects tags.
+	tagsLock sync.RWMutex
 }
 
 const maxLength = 4
@@ -55,8 +63,10 @@ type Frame struct {
 
 func NewTrackfile(labels Labels) *Trackfile {
 	return &Trackfile{
-		Contact: labels,
-		track:   *deque.New[Frame](),
+		Contact:      labels,
+		track:        *deque.New[Frame](),
+		trendHistory: *deque.New[Frame](),
+		tags:         make(map[string]struct{}),
 	}
 }
 
@@ -83,6 +93,7 @@ func (t *Trackfile) Update(f Frame) {
 	for t.track.Len() > maxLength {
 		t.track.PopBack()
 	}
+	t.updateTrendHistory(f)
 }
 
 // Bullseye returns the bearing and distance from the bullseye to the track's last known position.
@@ -145,6 +156,24 @@ func (t *Trackfile) Direction() brevity.Track {
 	return brevity.TrackFromBearing(course)
 }
 
+// Extrapolate returns the track's estimated position at the given time, assuming it has continued along its last
+// known course at its last known ground speed. The extrapolation is limited to the given window after the last known
+// position, since the estimate becomes less reliable the longer the track has been unobserved.
+func (t *Trackfile) Extrapolate(at time.Time, window time.Duration) orb.Point {
+	latest := t.LastKnown()
+	if t.track.Len() < 2 {
+		return latest.Point
+	}
+	elapsed := min(at.Sub(latest.Time), window)
+	if elapsed <= 0 {
+		return latest.Point
+	}
+	previous := t.track.At(1)
+	course := spatial.TrueBearing(previous.Point, latest.Point)
+	distance := unit.Length(t.groundSpeed().MetersPerSecond()*elapsed.Seconds()) * unit.Meter
+	return spatial.PointAtBearingAndDistance(latest.Point, course, distance)
+}
+
 // groundSpeed returns the approxmiate speed of the track along the ground (i.e. in two dimensions).
 func (t *Trackfile) groundSpeed() unit.Speed {
 	if t.track.Len() < 2 {
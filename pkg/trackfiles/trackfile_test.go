@@ -186,3 +186,63 @@ func TestTracking(t *testing.T) {
 		})
 	}
 }
+
+func TestExtrapolate(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name             string
+		elapsed          time.Duration
+		window           time.Duration
+		expectedDistance unit.Length
+	}{
+		{
+			name:             "Within window",
+			elapsed:          30 * time.Second,
+			window:           2 * time.Minute,
+			expectedDistance: 3000 * unit.Meter,
+		},
+		{
+			name:             "Beyond window",
+			elapsed:          5 * time.Minute,
+			window:           2 * time.Minute,
+			expectedDistance: 12000 * unit.Meter,
+		},
+		{
+			name:             "Before last known position",
+			elapsed:          -10 * time.Second,
+			window:           2 * time.Minute,
+			expectedDistance: 0,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			trackfile := NewTrackfile(Labels{
+				ID:        1,
+				ACMIName:  "Su-27",
+				Name:      "Flanker 1",
+				Coalition: coalitions.Red,
+			})
+			now := time.Now()
+			start := orb.Point{-115.0338, 36.2350}
+			trackfile.Update(Frame{
+				Time:     now.Add(-2 * time.Second),
+				Point:    start,
+				Altitude: 20000 * unit.Foot,
+			})
+			latest := spatial.PointAtBearingAndDistance(start, bearings.NewTrueBearing(90*unit.Degree), 200*unit.Meter)
+			trackfile.Update(Frame{
+				Time:     now,
+				Point:    latest,
+				Altitude: 20000 * unit.Foot,
+			})
+
+			extrapolated := trackfile.Extrapolate(now.Add(test.elapsed), test.window)
+			require.InDelta(t, test.expectedDistance.Meters(), spatial.Distance(latest, extrapolated).Meters(), 50)
+			if test.expectedDistance > 0 {
+				require.InDelta(t, 90, spatial.TrueBearing(latest, extrapolated).Degrees(), 0.5)
+			}
+		})
+	}
+}
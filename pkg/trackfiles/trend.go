@@ -0,0 +1,130 @@
+package trackfiles
+
+import (
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+)
+
+const (
+	// turningThreshold is the rate of change of aspect angle, per second, above which a track is turning hot or cold.
+	turningThreshold = 2 * unit.Degree
+	// openingThreshold is the rate at which the range must be increasing for a cold track to be opening.
+	openingThreshold = 25 * unit.Knot
+	// coldAspectAngle is the aspect angle beyond which a track is cold to the reference point.
+	coldAspectAngle = 120 * unit.Degree
+	// trendWindow is how much history is used to measure how a track's motion is changing. A window of several
+	// seconds smooths out jinks and radar noise, at the cost of noticing a turn a little later.
+	trendWindow = 30 * time.Second
+	// trendSampleInterval is the minimum time between frames kept in the trend history. Courses are measured between
+	// consecutive samples, so this also sets how much each course is smoothed.
+	trendSampleInterval = 5 * time.Second
+)
+
+// Trend describes how a track's motion relative to a reference point, such as a fighter, is changing over the
+// trackfile's recent history.
+type Trend struct {
+	// AspectAngle is the angle between the track's course and the bearing from the track to the reference point. 0°
+	// means the track is pointed at the reference point, and 180° means it is pointed directly away.
+	AspectAngle unit.Angle
+	// AspectRate is the change in aspect angle per second. Negative values mean the track is turning toward the
+	// reference point.
+	AspectRate unit.Angle
+	// TurnRate is the change in the track's course per second. Positive values are turns to the right.
+	TurnRate unit.Angle
+	// ClosingSpeed is the rate at which the range between the track and the reference point is decreasing. Negative
+	// values mean the range is opening.
+	ClosingSpeed unit.Speed
+}
+
+// Trend returns how the track's motion relative to the given reference point is changing over the trend window. The
+// rates are zero if the trackfile does not have enough history.
+func (t *Trackfile) Trend(reference orb.Point) Trend {
+	samples := t.trendSamples()
+	n := len(samples)
+	if n < 2 {
+		return Trend{}
+	}
+	latest := samples[0]
+	previous := samples[1]
+	course := spatial.TrueBearing(previous.Point, latest.Point)
+	trend := Trend{AspectAngle: aspectAngle(latest.Point, course, reference)}
+
+	if elapsed := latest.Time.Sub(previous.Time).Seconds(); elapsed > 0 {
+		closure := spatial.Distance(previous.Point, reference) - spatial.Distance(latest.Point, reference)
+		trend.ClosingSpeed = unit.Speed(closure.Meters()/elapsed) * unit.MetersPerSecond
+	}
+
+	if n < 3 {
+		return trend
+	}
+	oldest := samples[n-1]
+	afterOldest := samples[n-2]
+	oldCourse := spatial.TrueBearing(oldest.Point, afterOldest.Point)
+	// Each course is measured over a pair of samples, so the time between courses is measured between the midpoints
+	// of the pairs.
+	elapsed := (latest.Time.Sub(oldest.Time) + previous.Time.Sub(afterOldest.Time)).Seconds() / 2
+	if elapsed <= 0 {
+		return trend
+	}
+	trend.TurnRate = bearings.SignedDifference(course, oldCourse) / unit.Angle(elapsed)
+	oldAspect := aspectAngle(afterOldest.Point, oldCourse, reference)
+	trend.AspectRate = (trend.AspectAngle - oldAspect) / unit.Angle(elapsed)
+	return trend
+}
+
+// updateTrendHistory records the frame in the trend history if enough time has passed since the last sample, and
+// forgets samples older than the trend window.
+func (t *Trackfile) updateTrendHistory(f Frame) {
+	if t.trendHistory.Len() == 0 || f.Time.Sub(t.trendHistory.Front().Time) >= trendSampleInterval {
+		t.trendHistory.PushFront(f)
+	}
+	for t.trendHistory.Len() > 0 && f.Time.Sub(t.trendHistory.Back().Time) > trendWindow {
+		t.trendHistory.PopBack()
+	}
+}
+
+// trendSamples returns the most recent frame, followed by the samples in the trend history which are at least the
+// sample interval older than it, ordered from most recent to least recent. If the track is too new to have any such
+// samples, the previous frame is used instead.
+func (t *Trackfile) trendSamples() []Frame {
+	samples := make([]Frame, 0, t.trendHistory.Len()+1)
+	if t.track.Len() == 0 {
+		return samples
+	}
+	latest := t.track.Front()
+	samples = append(samples, latest)
+	for i := range t.trendHistory.Len() {
+		sample := t.trendHistory.At(i)
+		if latest.Time.Sub(sample.Time) >= trendSampleInterval {
+			samples = append(samples, sample)
+		}
+	}
+	if len(samples) < 2 && t.track.Len() >= 2 {
+		samples = append(samples, t.track.At(1))
+	}
+	return samples
+}
+
+// AspectTrend summarizes the trend in brevity terms.
+func (t Trend) AspectTrend() brevity.AspectTrend {
+	switch {
+	case t.AspectRate <= -turningThreshold:
+		return brevity.TurningHot
+	case t.AspectRate >= turningThreshold && t.AspectAngle < coldAspectAngle:
+		return brevity.TurningCold
+	case t.AspectAngle >= coldAspectAngle && t.ClosingSpeed <= -openingThreshold:
+		return brevity.Opening
+	default:
+		return brevity.SteadyAspect
+	}
+}
+
+// aspectAngle returns the angle between the course and the bearing from the point to the reference point.
+func aspectAngle(point orb.Point, course bearings.Bearing, reference orb.Point) unit.Angle {
+	return bearings.Difference(course, spatial.TrueBearing(point, reference))
+}
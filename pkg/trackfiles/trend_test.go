@@ -0,0 +1,137 @@
+package trackfiles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/dharmab/skyeye/pkg/brevity"
+	"github.com/dharmab/skyeye/pkg/coalitions"
+	"github.com/dharmab/skyeye/pkg/spatial"
+	"github.com/martinlindhe/unit"
+	"github.com/paulmach/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrend(t *testing.T) {
+	t.Parallel()
+	reference := orb.Point{-115.0338, 36.2350}
+	start := spatial.PointAtBearingAndDistance(reference, bearings.NewTrueBearing(90*unit.Degree), 20*unit.NauticalMile)
+
+	testCases := []struct {
+		name     string
+		courses  []unit.Angle
+		expected brevity.AspectTrend
+	}{
+		{
+			name:     "turning hot",
+			courses:  []unit.Angle{0, 330 * unit.Degree, 300 * unit.Degree},
+			expected: brevity.TurningHot,
+		},
+		{
+			name:     "turning cold",
+			courses:  []unit.Angle{300 * unit.Degree, 330 * unit.Degree, 0},
+			expected: brevity.TurningCold,
+		},
+		{
+			name:     "opening",
+			courses:  []unit.Angle{90 * unit.Degree, 90 * unit.Degree, 90 * unit.Degree},
+			expected: brevity.Opening,
+		},
+		{
+			name:     "steady beam",
+			courses:  []unit.Angle{0, 0, 0},
+			expected: brevity.SteadyAspect,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			trackfile := NewTrackfile(Labels{
+				ID:        1,
+				ACMIName:  "Su-27",
+				Name:      "Flanker 1",
+				Coalition: coalitions.Red,
+			})
+			now := time.Now()
+			point := start
+			trackfile.Update(Frame{Time: now, Point: point, Altitude: 20000 * unit.Foot})
+			for _, course := range test.courses {
+				now = now.Add(5 * time.Second)
+				point = spatial.PointAtBearingAndDistance(point, bearings.NewTrueBearing(course), 1250*unit.Meter)
+				trackfile.Update(Frame{Time: now, Point: point, Altitude: 20000 * unit.Foot})
+			}
+			assert.Equal(t, test.expected, trackfile.Trend(reference).AspectTrend())
+		})
+	}
+}
+
+func TestTrendSmoothing(t *testing.T) {
+	t.Parallel()
+	reference := orb.Point{-115.0338, 36.2350}
+	start := spatial.PointAtBearingAndDistance(reference, bearings.NewTrueBearing(90*unit.Degree), 20*unit.NauticalMile)
+
+	testCases := []struct {
+		name     string
+		course   func(second int) unit.Angle
+		expected brevity.AspectTrend
+	}{
+		{
+			// Alternating 10° jinks each second would look like a hard turn over a couple of frames.
+			name: "jinking on a steady beam",
+			course: func(second int) unit.Angle {
+				if second%2 == 0 {
+					return 10 * unit.Degree
+				}
+				return 350 * unit.Degree
+			},
+			expected: brevity.SteadyAspect,
+		},
+		{
+			name: "gradual turn hot",
+			course: func(second int) unit.Angle {
+				return unit.Angle(360-3*second) * unit.Degree
+			},
+			expected: brevity.TurningHot,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			trackfile := NewTrackfile(Labels{ID: 1, ACMIName: "Su-27", Name: "Flanker 1", Coalition: coalitions.Red})
+			now := time.Now()
+			point := start
+			trackfile.Update(Frame{Time: now, Point: point, Altitude: 20000 * unit.Foot})
+			for second := range 30 {
+				now = now.Add(time.Second)
+				point = spatial.PointAtBearingAndDistance(point, bearings.NewTrueBearing(test.course(second)), 250*unit.Meter)
+				trackfile.Update(Frame{Time: now, Point: point, Altitude: 20000 * unit.Foot})
+			}
+			assert.Equal(t, test.expected, trackfile.Trend(reference).AspectTrend())
+		})
+	}
+}
+
+func TestTrendHistoryWindow(t *testing.T) {
+	t.Parallel()
+	trackfile := NewTrackfile(Labels{ID: 1})
+	now := time.Now()
+	for second := range 120 {
+		trackfile.Update(Frame{Time: now.Add(time.Duration(second) * time.Second), Point: orb.Point{1, float64(second) / 1000}})
+	}
+	assert.LessOrEqual(t, trackfile.trendHistory.Len(), int(trendWindow/trendSampleInterval)+1)
+	samples := trackfile.trendSamples()
+	assert.LessOrEqual(t, samples[0].Time.Sub(samples[len(samples)-1].Time), trendWindow)
+	assert.GreaterOrEqual(t, samples[0].Time.Sub(samples[len(samples)-1].Time), trendWindow-trendSampleInterval)
+}
+
+func TestTrendWithoutHistory(t *testing.T) {
+	t.Parallel()
+	trackfile := NewTrackfile(Labels{ID: 1})
+	assert.Equal(t, Trend{}, trackfile.Trend(orb.Point{}))
+	trackfile.Update(Frame{Time: time.Now(), Point: orb.Point{1, 1}})
+	assert.Equal(t, Trend{}, trackfile.Trend(orb.Point{}))
+	assert.Equal(t, brevity.SteadyAspect, trackfile.Trend(orb.Point{}).AspectTrend())
+}
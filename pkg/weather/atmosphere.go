@@ -0,0 +1,30 @@
+package weather
+
+import (
+	"math"
+
+	"github.com/martinlindhe/unit"
+)
+
+const (
+	// seaLevelSpeedOfSound is the speed of sound at sea level in the International Standard Atmosphere.
+	seaLevelSpeedOfSound = 661.47 * unit.Knot
+	// seaLevelTemperature is the temperature at sea level in the International Standard Atmosphere, in kelvin.
+	seaLevelTemperature = 288.15
+	// lapseRate is how much the temperature falls with altitude in the troposphere, in kelvin per meter.
+	lapseRate = 0.0065
+	// tropopause is the altitude above which the temperature stops falling.
+	tropopause = 11000 * unit.Meter
+)
+
+// SpeedOfSound returns the speed of sound at the given altitude in the International Standard Atmosphere.
+func SpeedOfSound(altitude unit.Length) unit.Speed {
+	altitude = min(max(altitude, 0), tropopause)
+	temperature := seaLevelTemperature - lapseRate*altitude.Meters()
+	return unit.Speed(seaLevelSpeedOfSound.MetersPerSecond()*math.Sqrt(temperature/seaLevelTemperature)) * unit.MetersPerSecond
+}
+
+// Mach returns the approximate Mach number of the given true airspeed at the given altitude.
+func Mach(trueAirspeed unit.Speed, altitude unit.Length) float64 {
+	return trueAirspeed.MetersPerSecond() / SpeedOfSound(altitude).MetersPerSecond()
+}
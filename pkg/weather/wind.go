@@ -0,0 +1,133 @@
+// package weather models the mission weather, so that an aircraft's speed through the air can be told apart from its
+// speed over the ground.
+package weather
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+)
+
+// Layer is the wind at one altitude.
+type Layer struct {
+	// Altitude of the layer above sea level.
+	Altitude unit.Length
+	// Direction is the true direction the wind blows from.
+	Direction bearings.Bearing
+	// Speed of the wind.
+	Speed unit.Speed
+}
+
+// ParseLayer parses a wind layer in the format ALTITUDE:DIRECTION/SPEED, with the altitude in feet, the true direction
+// the wind blows from in degrees and the speed in knots. For example, "26000:270/60" is a westerly wind of 60 knots at
+// 26,000 feet.
+func ParseLayer(s string) (Layer, error) {
+	altitudeField, windField, ok := strings.Cut(s, ":")
+	if !ok {
+		return Layer{}, fmt.Errorf("wind layer %q must be in the format ALTITUDE:DIRECTION/SPEED", s)
+	}
+	directionField, speedField, ok := strings.Cut(windField, "/")
+	if !ok {
+		return Layer{}, fmt.Errorf("wind layer %q must be in the format ALTITUDE:DIRECTION/SPEED", s)
+	}
+	altitude, err := strconv.ParseFloat(strings.TrimSpace(altitudeField), 64)
+	if err != nil {
+		return Layer{}, fmt.Errorf("failed to parse wind layer altitude: %w", err)
+	}
+	direction, err := strconv.ParseFloat(strings.TrimSpace(directionField), 64)
+	if err != nil {
+		return Layer{}, fmt.Errorf("failed to parse wind layer direction: %w", err)
+	}
+	if direction < 0 || direction > 360 {
+		return Layer{}, fmt.Errorf("wind layer direction %v must be between 0 and 360 degrees", direction)
+	}
+	speed, err := strconv.ParseFloat(strings.TrimSpace(speedField), 64)
+	if err != nil {
+		return Layer{}, fmt.Errorf("failed to parse wind layer speed: %w", err)
+	}
+	if speed < 0 {
+		return Layer{}, fmt.Errorf("wind layer speed %v must not be negative", speed)
+	}
+	return Layer{
+		Altitude:  unit.Length(altitude) * unit.Foot,
+		Direction: bearings.NewTrueBearing(unit.Angle(direction) * unit.Degree),
+		Speed:     unit.Speed(speed) * unit.Knot,
+	}, nil
+}
+
+// velocity is a horizontal velocity in meters per second.
+type velocity struct {
+	east  float64
+	north float64
+}
+
+// Wind models the wind at different altitudes. The wind between two layers is interpolated. Below the lowest layer
+// and above the highest layer, the wind is the same as at the nearest layer.
+type Wind struct {
+	// layers are ordered from lowest to highest.
+	layers []Layer
+}
+
+// NewWind constructs a wind model from the given layers. Returns nil if no layers are given.
+func NewWind(layers ...Layer) *Wind {
+	if len(layers) == 0 {
+		return nil
+	}
+	layers = slices.Clone(layers)
+	slices.SortFunc(layers, func(a, b Layer) int {
+		return cmp.Compare(a.Altitude, b.Altitude)
+	})
+	return &Wind{layers: layers}
+}
+
+// blowing returns the velocity of the air in a layer, which moves in the opposite direction to where the wind blows
+// from.
+func (l Layer) blowing() velocity {
+	towards := l.Direction.Reciprocal().Value().Radians()
+	return velocity{
+		east:  l.Speed.MetersPerSecond() * math.Sin(towards),
+		north: l.Speed.MetersPerSecond() * math.Cos(towards),
+	}
+}
+
+// at returns the velocity of the air at the given altitude.
+func (w *Wind) at(altitude unit.Length) velocity {
+	if altitude <= w.layers[0].Altitude {
+		return w.layers[0].blowing()
+	}
+	for i := 1; i < len(w.layers); i++ {
+		upper := w.layers[i]
+		if altitude > upper.Altitude {
+			continue
+		}
+		lower := w.layers[i-1]
+		// Interpolate the velocity rather than the direction and speed, so that a wind which backs through north is
+		// interpolated the short way around.
+		fraction := (altitude - lower.Altitude).Meters() / (upper.Altitude - lower.Altitude).Meters()
+		a, b := lower.blowing(), upper.blowing()
+		return velocity{
+			east:  a.east + (b.east-a.east)*fraction,
+			north: a.north + (b.north-a.north)*fraction,
+		}
+	}
+	return w.layers[len(w.layers)-1].blowing()
+}
+
+// TrueAirspeed returns the approximate true airspeed of an aircraft with the given true course and ground speed at
+// the given altitude, by removing the wind from its velocity over the ground. Vertical speed and sideslip are ignored.
+func (w *Wind) TrueAirspeed(course bearings.Bearing, groundSpeed unit.Speed, altitude unit.Length) unit.Speed {
+	if w == nil {
+		return groundSpeed
+	}
+	wind := w.at(altitude)
+	radians := course.Value().Radians()
+	east := groundSpeed.MetersPerSecond()*math.Sin(radians) - wind.east
+	north := groundSpeed.MetersPerSecond()*math.Cos(radians) - wind.north
+	return unit.Speed(math.Hypot(east, north)) * unit.MetersPerSecond
+}
@@ -0,0 +1,74 @@
+package weather
+
+import (
+	"testing"
+
+	"github.com/dharmab/skyeye/pkg/bearings"
+	"github.com/martinlindhe/unit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLayer(t *testing.T) {
+	t.Parallel()
+	layer, err := ParseLayer("26000:270/60")
+	require.NoError(t, err)
+	assert.InDelta(t, 26000, layer.Altitude.Feet(), 0.1)
+	assert.True(t, layer.Direction.IsTrue())
+	assert.InDelta(t, 270, layer.Direction.Degrees(), 0.1)
+	assert.InDelta(t, 60, layer.Speed.Knots(), 0.1)
+
+	for _, s := range []string{"", "26000", "26000:270", "x:270/60", "26000:x/60", "26000:270/x", "26000:400/60", "26000:270/-1"} {
+		_, err := ParseLayer(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestTrueAirspeed(t *testing.T) {
+	t.Parallel()
+	wind := NewWind(
+		Layer{Altitude: 26000 * unit.Foot, Direction: bearings.NewTrueBearing(270 * unit.Degree), Speed: 100 * unit.Knot},
+		Layer{Altitude: 0, Direction: bearings.NewTrueBearing(270 * unit.Degree), Speed: 0},
+	)
+	testCases := []struct {
+		name        string
+		course      float64
+		groundSpeed float64
+		altitude    unit.Length
+		expected    float64
+	}{
+		{name: "tailwind", course: 90, groundSpeed: 500, altitude: 26000 * unit.Foot, expected: 400},
+		{name: "headwind", course: 270, groundSpeed: 400, altitude: 26000 * unit.Foot, expected: 500},
+		{name: "above highest layer", course: 90, groundSpeed: 500, altitude: 40000 * unit.Foot, expected: 400},
+		{name: "interpolated", course: 90, groundSpeed: 500, altitude: 13000 * unit.Foot, expected: 450},
+		{name: "calm", course: 90, groundSpeed: 500, altitude: 0, expected: 500},
+		{name: "crosswind", course: 0, groundSpeed: 500, altitude: 26000 * unit.Foot, expected: 509.9},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual := wind.TrueAirspeed(
+				bearings.NewTrueBearing(unit.Angle(test.course)*unit.Degree),
+				unit.Speed(test.groundSpeed)*unit.Knot,
+				test.altitude,
+			)
+			assert.InDelta(t, test.expected, actual.Knots(), 0.1)
+		})
+	}
+}
+
+func TestTrueAirspeedWithoutWind(t *testing.T) {
+	t.Parallel()
+	var wind *Wind
+	assert.Nil(t, NewWind())
+	actual := wind.TrueAirspeed(bearings.NewTrueBearing(90*unit.Degree), 500*unit.Knot, 26000*unit.Foot)
+	assert.InDelta(t, 500, actual.Knots(), 0.1)
+}
+
+func TestMach(t *testing.T) {
+	t.Parallel()
+	assert.InDelta(t, 1.0, Mach(661.47*unit.Knot, 0), 0.001)
+	assert.InDelta(t, 573.6, SpeedOfSound(36089*unit.Foot).Knots(), 0.5)
+	assert.InDelta(t, 573.6, SpeedOfSound(50000*unit.Foot).Knots(), 0.5)
+	assert.InDelta(t, 1.5, Mach(860.4*unit.Knot, 40000*unit.Foot), 0.01)
+}